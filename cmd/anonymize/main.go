@@ -0,0 +1,67 @@
+// Package main provides a command that anonymizes a user, the offline counterpart of POST /admin/anonymizeUser.
+//
+// The user's ID and name are replaced by a salted hash in all data while review history and statistics are
+// kept. The result is written to stdout as JSON and logs to stderr. User IDs are unique across tenants, so
+// the command needs no tenant. Connection settings are read from the same DB_* environment variables
+// as the server.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/festy23/avito_internship/internal/config"
+	"github.com/festy23/avito_internship/internal/database/database"
+	teamRepo "github.com/festy23/avito_internship/internal/team/repository"
+	userModel "github.com/festy23/avito_internship/internal/user/model"
+	"github.com/festy23/avito_internship/internal/user/repository"
+	"github.com/festy23/avito_internship/internal/user/service"
+	"github.com/festy23/avito_internship/pkg/logger"
+)
+
+// exitUsage is the exit status when the command is called without a user.
+const exitUsage = 2
+
+func main() {
+	userID := flag.String("user", "", "ID of the user to anonymize")
+	flag.Parse()
+	if *userID == "" {
+		fmt.Fprintln(os.Stderr, "usage: anonymize -user <user_id>")
+		os.Exit(exitUsage)
+	}
+
+	// Logs go to stderr, so stdout carries the result only
+	logCfg := config.LoadLoggerConfigFromEnv()
+	logCfg.Output = "stderr"
+	log, err := logger.NewWithConfig(logCfg)
+	if err != nil {
+		panic(fmt.Sprintf("failed to initialize logger: %v", err))
+	}
+	defer func() {
+		_ = log.Sync()
+	}()
+
+	db, err := database.New()
+	if err != nil {
+		log.Fatalw("failed to connect to database", "error", err)
+	}
+
+	svc := service.New(repository.New(db, log), log, service.WithTransactions(db, teamRepo.New(db, log)))
+	resp, err := svc.AnonymizeUser(context.Background(), &userModel.AnonymizeUserRequest{UserID: *userID})
+	if err != nil {
+		log.Fatalw("anonymization failed", "error", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(resp); err != nil {
+		log.Fatalw("failed to write result", "error", err)
+	}
+
+	if err = database.Close(db); err != nil {
+		log.Errorw("failed to close database", "error", err)
+	}
+}
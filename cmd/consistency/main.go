@@ -0,0 +1,67 @@
+// Package main provides a command that checks the database for inconsistent data and prints a JSON report.
+//
+// The check finds authors assigned as reviewers, reviewers beyond the per-PR limit, reviewers of missing
+// pull requests and users referencing missing teams; with -fix the issues are repaired. The report is
+// written to stdout and logs to stderr. The command exits with status 3 if issues remain unrepaired.
+// Connection settings are read from the same DB_* environment variables as the server.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/festy23/avito_internship/internal/config"
+	"github.com/festy23/avito_internship/internal/database/database"
+	"github.com/festy23/avito_internship/internal/pullrequest/reconcile"
+	"github.com/festy23/avito_internship/internal/pullrequest/repository"
+	"github.com/festy23/avito_internship/pkg/logger"
+)
+
+// exitIssuesFound is the exit status when the report contains unrepaired issues.
+const exitIssuesFound = 3
+
+func main() {
+	fix := flag.Bool("fix", false, "repair the issues found")
+	flag.Parse()
+
+	// Logs go to stderr, so stdout carries the report only
+	logCfg := config.LoadLoggerConfigFromEnv()
+	logCfg.Output = "stderr"
+	log, err := logger.NewWithConfig(logCfg)
+	if err != nil {
+		panic(fmt.Sprintf("failed to initialize logger: %v", err))
+	}
+	defer func() {
+		_ = log.Sync()
+	}()
+
+	db, err := database.New()
+	if err != nil {
+		log.Fatalw("failed to connect to database", "error", err)
+	}
+
+	reconciler := reconcile.New(repository.New(db, log), db, config.ReconcileConfig{}, log)
+	report, err := reconciler.Check(context.Background(), *fix)
+	if err != nil {
+		log.Fatalw("consistency check failed", "error", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(report); err != nil {
+		log.Fatalw("failed to write report", "error", err)
+	}
+
+	if err = database.Close(db); err != nil {
+		log.Errorw("failed to close database", "error", err)
+	}
+	for _, issue := range report.Issues {
+		if !issue.Repaired {
+			_ = log.Sync()
+			os.Exit(exitIssuesFound)
+		}
+	}
+}
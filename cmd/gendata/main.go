@@ -0,0 +1,61 @@
+// Package main provides a command that fills the database with synthetic data for performance testing.
+//
+// Connection settings are read from the same DB_* environment variables as the server.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/festy23/avito_internship/internal/database/database"
+	"github.com/festy23/avito_internship/internal/database/migrate"
+	"github.com/festy23/avito_internship/internal/gendata"
+	"github.com/festy23/avito_internship/pkg/logger"
+)
+
+func main() {
+	cfg := gendata.DefaultConfig()
+	flag.StringVar(&cfg.Prefix, "prefix", cfg.Prefix, "prefix of generated identifiers")
+	flag.IntVar(&cfg.Teams, "teams", cfg.Teams, "number of teams")
+	flag.IntVar(&cfg.MinTeamSize, "min-team-size", cfg.MinTeamSize, "minimum team size")
+	flag.IntVar(&cfg.MaxTeamSize, "max-team-size", cfg.MaxTeamSize, "maximum team size")
+	flag.Float64Var(&cfg.ActiveRatio, "active-ratio", cfg.ActiveRatio, "share of active users (0..1)")
+	flag.IntVar(&cfg.PullRequests, "prs", cfg.PullRequests, "number of pull requests")
+	flag.Float64Var(&cfg.MergedRatio, "merged-ratio", cfg.MergedRatio, "share of merged pull requests (0..1)")
+	flag.DurationVar(&cfg.Period, "period", cfg.Period, "spread of PR creation times before now")
+	flag.IntVar(&cfg.BatchSize, "batch-size", cfg.BatchSize, "rows per INSERT")
+	flag.Int64Var(&cfg.Seed, "seed", cfg.Seed, "random seed (0 uses the current time)")
+	flag.Parse()
+
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid flags: %v\n", err)
+		os.Exit(2)
+	}
+
+	log, err := logger.New()
+	if err != nil {
+		panic(fmt.Sprintf("failed to initialize logger: %v", err))
+	}
+	defer func() {
+		_ = log.Sync()
+	}()
+
+	db, err := database.New()
+	if err != nil {
+		log.Fatalw("failed to connect to database", "error", err)
+	}
+
+	if err = migrate.Migrate(db); err != nil {
+		log.Fatalw("failed to run migrations", "error", err)
+	}
+
+	stats, err := gendata.New(db, cfg, log).Run(context.Background())
+	if err != nil {
+		log.Fatalw("failed to generate data", "error", err)
+	}
+
+	fmt.Printf("teams: %d\nusers: %d (active: %d)\npull requests: %d (merged: %d)\nreviewers: %d\nevents: %d\n",
+		stats.Teams, stats.Users, stats.ActiveUsers, stats.PullRequests, stats.Merged, stats.Reviewers, stats.Events)
+}
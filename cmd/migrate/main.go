@@ -0,0 +1,46 @@
+// Package main provides a command that applies database migrations and exits.
+//
+// It lets migrations run once per deployment (e.g. in a Kubernetes init container) instead of on
+// every replica start; the server is then started with RUN_MIGRATIONS=false. Connection settings
+// are read from the same DB_* environment variables as the server.
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/festy23/avito_internship/internal/database/database"
+	"github.com/festy23/avito_internship/internal/database/migrate"
+	"github.com/festy23/avito_internship/pkg/logger"
+)
+
+func main() {
+	log, err := logger.New()
+	if err != nil {
+		panic(fmt.Sprintf("failed to initialize logger: %v", err))
+	}
+	defer func() {
+		_ = log.Sync()
+	}()
+
+	db, err := database.New()
+	if err != nil {
+		log.Fatalw("failed to connect to database", "error", err)
+	}
+	defer func() {
+		if err = database.Close(db); err != nil {
+			log.Errorw("failed to close database", "error", err)
+		}
+	}()
+
+	// Concurrent runs are serialized by the migration lock of golang-migrate
+	if err = migrate.MigrateWithRepair(db, migrate.RepairDirtyEnabled(), log); err != nil {
+		log.Fatalw("failed to run migrations", "error", err)
+	}
+
+	version, _, err := migrate.CurrentVersion(context.Background(), db)
+	if err != nil {
+		log.Fatalw("failed to read schema version", "error", err)
+	}
+	log.Infow("database schema is up to date", "version", version)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/festy23/avito_internship/internal/tenant"
+	"github.com/festy23/avito_internship/pkg/apierror"
+)
+
+// client calls the service API and prints the results.
+type client struct {
+	baseURL  string
+	token    string
+	tenantID string
+	json     bool
+	http     *http.Client
+	out      io.Writer
+}
+
+func newClient(baseURL, token, tenantID string, json bool, timeout time.Duration, out io.Writer) *client {
+	return &client{
+		baseURL:  strings.TrimSuffix(baseURL, "/"),
+		token:    token,
+		tenantID: tenantID,
+		json:     json,
+		http:     &http.Client{Timeout: timeout},
+		out:      out,
+	}
+}
+
+// call sends a request with an optional JSON body and decodes the JSON response into result.
+// With -output json the response is also printed as is, and the returned printed flag is true.
+func (c *client) call(method, path string, query url.Values, body, result any) (printed bool, err error) {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return false, err
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(context.Background(), method, target, reader)
+	if err != nil {
+		return false, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if c.tenantID != "" {
+		req.Header.Set(tenant.Header, c.tenantID)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		var errResp apierror.Response
+		if json.Unmarshal(data, &errResp) != nil || errResp.Error.Code == "" {
+			return false, fmt.Errorf("%s %s: %s", method, path, resp.Status)
+		}
+		return false, fmt.Errorf("%s %s: %s: %s", method, path, errResp.Error.Code, errResp.Error.Message)
+	}
+
+	if err = json.Unmarshal(data, result); err != nil {
+		return false, fmt.Errorf("%s %s: decode response: %w", method, path, err)
+	}
+	if c.json {
+		var indented bytes.Buffer
+		if err = json.Indent(&indented, data, "", "  "); err != nil {
+			return false, err
+		}
+		indented.WriteByte('\n')
+		_, err = c.out.Write(indented.Bytes())
+		return true, err
+	}
+	return false, nil
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/tabwriter"
+
+	pullrequestModel "github.com/festy23/avito_internship/internal/pullrequest/model"
+	"github.com/festy23/avito_internship/internal/pullrequest/reconcile"
+	statisticsModel "github.com/festy23/avito_internship/internal/statistics/model"
+	teamModel "github.com/festy23/avito_internship/internal/team/model"
+	userModel "github.com/festy23/avito_internship/internal/user/model"
+)
+
+// teamAdd creates a team with active members given as user_id=username.
+func teamAdd(c *client, args []string) error {
+	if len(args) < 2 {
+		return errUsage
+	}
+	req := teamModel.AddTeamRequest{TeamName: args[0]}
+	for _, arg := range args[1:] {
+		userID, username, ok := strings.Cut(arg, "=")
+		if !ok || userID == "" || username == "" {
+			return errUsage
+		}
+		req.Members = append(req.Members, teamModel.TeamMember{UserID: userID, Username: username, IsActive: true})
+	}
+
+	var resp struct {
+		Team teamModel.TeamResponse `json:"team"`
+	}
+	if printed, err := c.call(http.MethodPost, "/team/add", nil, req, &resp); err != nil || printed {
+		return err
+	}
+	return c.table(func(w io.Writer) {
+		fmt.Fprintln(w, "TEAM\tUSER_ID\tUSERNAME\tACTIVE")
+		for _, m := range resp.Team.Members {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%t\n", resp.Team.TeamName, m.UserID, m.Username, m.IsActive)
+		}
+	})
+}
+
+// userDeactivate deactivates a user, optionally reassigning their open reviews.
+func userDeactivate(c *client, args []string) error {
+	flags := flag.NewFlagSet("user-deactivate", flag.ContinueOnError)
+	reassign := flags.Bool("reassign", false, "reassign the open reviews of the user")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 1 {
+		return errUsage
+	}
+
+	req := userModel.SetIsActiveRequest{UserID: flags.Arg(0), IsActive: false, ReassignOpenReviews: *reassign,
+		ChangedBy: "prctl"}
+	var resp userModel.SetIsActiveResponse
+	if printed, err := c.call(http.MethodPost, "/users/setIsActive", nil, req, &resp); err != nil || printed {
+		return err
+	}
+	return c.table(func(w io.Writer) {
+		fmt.Fprintln(w, "USER_ID\tTEAM\tACTIVE\tREASSIGNED_PRS")
+		fmt.Fprintf(w, "%s\t%s\t%t\t%s\n", resp.User.UserID, resp.User.TeamName, resp.User.IsActive,
+			strings.Join(resp.ReassignedPRs, ","))
+	})
+}
+
+// reassignAll replaces a reviewer in all their open pull requests.
+func reassignAll(c *client, args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+
+	req := pullrequestModel.ReassignAllRequest{OldUserID: args[0]}
+	var resp pullrequestModel.ReassignAllResponse
+	if printed, err := c.call(http.MethodPost, "/pullRequest/reassignAll", nil, req, &resp); err != nil || printed {
+		return err
+	}
+	return c.table(func(w io.Writer) {
+		fmt.Fprintln(w, "PULL_REQUEST_ID\tREPLACED_BY\tERROR")
+		for _, r := range resp.Results {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", r.PullRequestID, r.ReplacedBy, r.Error)
+		}
+	})
+}
+
+// consistencyCheck runs the consistency check, optionally repairing the issues found.
+func consistencyCheck(c *client, args []string) error {
+	flags := flag.NewFlagSet("consistency-check", flag.ContinueOnError)
+	fix := flags.Bool("fix", false, "repair the issues found")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 0 {
+		return errUsage
+	}
+
+	query := url.Values{}
+	if *fix {
+		query.Set("fix", "true")
+	}
+	var report reconcile.Report
+	if printed, err := c.call(http.MethodPost, "/admin/consistencyCheck", query, nil, &report); err != nil || printed {
+		return err
+	}
+	return c.table(func(w io.Writer) {
+		fmt.Fprintln(w, "KIND\tPULL_REQUEST_ID\tUSER_ID\tTEAM\tTENANT\tREPAIRED")
+		for _, i := range report.Issues {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%t\n", i.Kind, i.PullRequestID, i.UserID, i.TeamName, i.TenantID,
+				i.Repaired)
+		}
+	})
+}
+
+// stats prints reviewer or pull request statistics.
+func stats(c *client, args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "reviewers":
+		var resp statisticsModel.ReviewersStatisticsResponse
+		if printed, err := c.call(http.MethodGet, "/statistics/reviewers", nil, nil, &resp); err != nil || printed {
+			return err
+		}
+		return c.table(func(w io.Writer) {
+			fmt.Fprintln(w, "USER_ID\tUSERNAME\tTEAM\tASSIGNMENTS\tWEIGHT\tACTIVE")
+			for _, r := range resp.Reviewers {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%t\n", r.UserID, r.Username, r.TeamName, r.AssignmentCount,
+					r.ReviewWeight, r.IsActive)
+			}
+		})
+	case "pullrequests":
+		var resp statisticsModel.PullRequestStatisticsResponse
+		if printed, err := c.call(http.MethodGet, "/statistics/pullrequests", nil, nil, &resp); err != nil || printed {
+			return err
+		}
+		s := resp.Statistics
+		return c.table(func(w io.Writer) {
+			fmt.Fprintln(w, "TOTAL\tOPEN\tMERGED\tAVG_REVIEWERS\t0_REVIEWERS\t1_REVIEWER\t2_REVIEWERS")
+			fmt.Fprintf(w, "%d\t%d\t%d\t%.2f\t%d\t%d\t%d\n", s.TotalPRs, s.OpenPRs, s.MergedPRs,
+				s.AverageReviewersPerPR, s.PRsWith0Reviewers, s.PRsWith1Reviewer, s.PRsWith2Reviewers)
+		})
+	default:
+		return errUsage
+	}
+}
+
+// table prints rows written by write as aligned columns.
+func (c *client) table(write func(w io.Writer)) error {
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	write(w)
+	return w.Flush()
+}
@@ -0,0 +1,89 @@
+// Package main provides prctl, a command line client of the service API for common operations.
+//
+//	prctl [flags] team-add <team_name> <user_id>=<username>...
+//	prctl [flags] user-deactivate [-reassign] <user_id>
+//	prctl [flags] reassign-all <user_id>
+//	prctl [flags] consistency-check [-fix]
+//	prctl [flags] stats reviewers|pullrequests
+//
+// The service URL, admin token and tenant are taken from the -url, -token and -tenant flags or the
+// PRCTL_URL, ADMIN_TOKEN and PRCTL_TENANT environment variables. Results are printed to stdout as a
+// table or, with -output json, as the JSON response of the API. API errors are printed to stderr
+// and the command exits with status 1; invalid usage exits with status 2.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	// exitFailure is the exit status when the request fails.
+	exitFailure = 1
+	// exitUsage is the exit status of invalid command lines.
+	exitUsage = 2
+)
+
+// errUsage reports an invalid command line; the usage of the command has already been printed.
+var errUsage = errors.New("invalid usage")
+
+// command is a prctl subcommand.
+type command struct {
+	usage string
+	run   func(c *client, args []string) error
+}
+
+var commands = map[string]command{
+	"team-add":          {"team-add <team_name> <user_id>=<username>...", teamAdd},
+	"user-deactivate":   {"user-deactivate [-reassign] <user_id>", userDeactivate},
+	"reassign-all":      {"reassign-all <user_id>", reassignAll},
+	"consistency-check": {"consistency-check [-fix]", consistencyCheck},
+	"stats":             {"stats reviewers|pullrequests", stats},
+}
+
+func main() {
+	flags := flag.NewFlagSet("prctl", flag.ContinueOnError)
+	url := flags.String("url", envOr("PRCTL_URL", "http://localhost:8080"), "service URL (PRCTL_URL)")
+	token := flags.String("token", os.Getenv("ADMIN_TOKEN"), "admin token for admin endpoints (ADMIN_TOKEN)")
+	tenantID := flags.String("tenant", os.Getenv("PRCTL_TENANT"), "tenant ID with TENANT_MODE=header (PRCTL_TENANT)")
+	output := flags.String("output", "table", "output format: table or json")
+	timeout := flags.Duration("timeout", 30*time.Second, "request timeout")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: prctl [flags] <command> [args]\n\ncommands:")
+		for _, name := range []string{"team-add", "user-deactivate", "reassign-all", "consistency-check", "stats"} {
+			fmt.Fprintln(os.Stderr, "  "+commands[name].usage)
+		}
+		fmt.Fprintln(os.Stderr, "\nflags:")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(os.Args[1:]); err != nil {
+		os.Exit(exitUsage)
+	}
+
+	cmd, ok := commands[flags.Arg(0)]
+	if !ok || (*output != "table" && *output != "json") {
+		flags.Usage()
+		os.Exit(exitUsage)
+	}
+
+	c := newClient(*url, *token, *tenantID, *output == "json", *timeout, os.Stdout)
+	if err := cmd.run(c, flags.Args()[1:]); err != nil {
+		if errors.Is(err, errUsage) {
+			fmt.Fprintln(os.Stderr, "usage: prctl [flags] "+cmd.usage)
+			os.Exit(exitUsage)
+		}
+		fmt.Fprintln(os.Stderr, "prctl: "+err.Error())
+		os.Exit(exitFailure)
+	}
+}
+
+// envOr returns the value of the environment variable key, or fallback if it is empty.
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
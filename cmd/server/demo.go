@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/festy23/avito_internship/internal/config"
+	"github.com/festy23/avito_internship/internal/gendata"
+	teamModel "github.com/festy23/avito_internship/internal/team/model"
+)
+
+// demoData is the dataset seeded in demo mode: a few small teams and enough pull requests to
+// make statistics and reassignment interesting. The fixed seed keeps identifiers reproducible.
+var demoData = gendata.Config{
+	Prefix:       "demo",
+	Teams:        4,
+	MinTeamSize:  3,
+	MaxTeamSize:  8,
+	ActiveRatio:  0.8,
+	PullRequests: 40,
+	MergedRatio:  0.5,
+	Period:       14 * 24 * time.Hour,
+	BatchSize:    100,
+	Seed:         1,
+}
+
+// seedDemo fills an empty database with demo data. A database that already has teams is left
+// as is, so restarts in demo mode keep the changes made through the API.
+func seedDemo(ctx context.Context, db *gorm.DB, cfg config.Config, log *zap.SugaredLogger) error {
+	log.Warnw("demo mode is enabled, do not expose this instance",
+		"admin_token_is_demo", cfg.Admin.Token == config.DemoAdminToken)
+
+	var teams int64
+	if err := db.WithContext(ctx).Model(&teamModel.Team{}).Count(&teams).Error; err != nil {
+		return err
+	}
+	if teams > 0 {
+		log.Infow("database is not empty, demo data is not seeded", "teams", teams)
+		return nil
+	}
+
+	stats, err := gendata.New(db, demoData, log).Run(ctx)
+	if err != nil {
+		return err
+	}
+	log.Infow("demo data seeded",
+		"teams", stats.Teams, "users", stats.Users, "pull_requests", stats.PullRequests)
+	return nil
+}
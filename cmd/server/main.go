@@ -3,30 +3,33 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
-	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
 
 	"github.com/festy23/avito_internship/internal/config"
 	"github.com/festy23/avito_internship/internal/database/database"
 	"github.com/festy23/avito_internship/internal/database/migrate"
-	"github.com/festy23/avito_internship/internal/health"
-	"github.com/festy23/avito_internship/internal/middleware"
-	pullrequestRouter "github.com/festy23/avito_internship/internal/pullrequest/router"
-	statisticsRouter "github.com/festy23/avito_internship/internal/statistics/router"
-	teamRouter "github.com/festy23/avito_internship/internal/team/router"
-	userRouter "github.com/festy23/avito_internship/internal/user/router"
+	"github.com/festy23/avito_internship/pkg/app"
 	"github.com/festy23/avito_internship/pkg/logger"
 )
 
 func main() {
+	demo := flag.Bool("demo", false, "seed demo data and relax authentication (same as APP_DEMO=true)")
+	flag.Parse()
+
 	// Load application configuration
 	appConfig := config.LoadFromEnv()
+	if *demo {
+		appConfig.Demo.Enabled = true
+	}
+	appConfig = appConfig.WithDemo()
 
 	// Validate configuration
 	if err := appConfig.Validate(); err != nil {
@@ -53,43 +56,30 @@ func main() {
 		log.Fatalw("failed to connect to database", "error", err)
 	}
 
-	// Apply database migrations
-	if err := migrate.Migrate(db); err != nil {
-		log.Fatalw("failed to run migrations", "error", err)
+	// Apply database migrations unless they run as a separate step (cmd/migrate)
+	if appConfig.RunMigrations {
+		if err = migrate.MigrateWithRepair(db, migrate.RepairDirtyEnabled(), log); err != nil {
+			log.Fatalw("failed to run migrations", "error", err)
+		}
+	} else {
+		checkSchema(db, log)
 	}
 
-	// Setup router
-	r := gin.New()
-
-	// Apply middleware (order matters: recovery first, then logger)
-	r.Use(middleware.Recovery(log))
-	r.Use(middleware.Logger(log))
-
-	// Register health check endpoint
-	healthHandler := health.New(db, log)
-	r.GET("/health", healthHandler.Check)
-
-	teamRouter.RegisterRoutes(r, db, log)
-	userRouter.RegisterRoutes(r, db, log)
-	pullrequestRouter.RegisterRoutes(r, db, log)
-	statisticsRouter.RegisterRoutes(r, db, log)
-
-	// Create HTTP server with timeouts
-	srv := &http.Server{
-		Addr:         appConfig.Server.GetAddress(),
-		Handler:      r,
-		ReadTimeout:  appConfig.Server.ReadTimeout,
-		WriteTimeout: appConfig.Server.WriteTimeout,
-		IdleTimeout:  appConfig.Server.IdleTimeout,
+	// Seed demo data into an empty database
+	if appConfig.Demo.Enabled {
+		if err = seedDemo(context.Background(), db, appConfig, log); err != nil {
+			log.Fatalw("failed to seed demo data", "error", err)
+		}
 	}
 
-	// Start server in goroutine
-	go func() {
-		log.Infow("starting server", "address", srv.Addr)
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalw("failed to start server", "error", err)
-		}
-	}()
+	// Assemble the service: routes, middleware and background jobs
+	application, err := app.New(appConfig, db, log)
+	if err != nil {
+		log.Fatalw("failed to initialize application", "error", err)
+	}
+	if err := application.Start(context.Background()); err != nil {
+		log.Fatalw("failed to start server", "error", err)
+	}
 
 	// Graceful shutdown
 	quit := make(chan os.Signal, 1)
@@ -97,16 +87,8 @@ func main() {
 	<-quit
 
 	log.Infow("shutting down server")
-
-	// Shutdown HTTP server
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	if err := srv.Shutdown(ctx); err != nil {
-		log.Errorw("server forced to shutdown", "error", err)
-	} else {
-		log.Infow("HTTP server stopped")
-	}
+	// Errors are logged by Shutdown; the database is closed either way
+	_ = application.Shutdown(context.Background())
 
 	// Close database connection
 	if err := database.Close(db); err != nil {
@@ -117,3 +99,23 @@ func main() {
 
 	log.Infow("server exited")
 }
+
+// checkSchema warns when migrations are skipped on startup and the database schema is behind the service.
+func checkSchema(db *gorm.DB, log *zap.SugaredLogger) {
+	latest, err := migrate.LatestVersion()
+	if err != nil {
+		log.Warnw("migrations are skipped, schema version is not checked", "error", err)
+		return
+	}
+	version, dirty, err := migrate.CurrentVersion(context.Background(), db)
+	if err != nil {
+		log.Warnw("migrations are skipped, schema version is not checked", "error", err)
+		return
+	}
+	if dirty || version < latest {
+		log.Warnw("database schema is behind the service, run cmd/migrate",
+			"version", version, "dirty", dirty, "latest_version", latest)
+		return
+	}
+	log.Infow("migrations are skipped, database schema is up to date", "version", version)
+}
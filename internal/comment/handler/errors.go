@@ -0,0 +1,22 @@
+package handler
+
+import (
+	commentModel "github.com/festy23/avito_internship/internal/comment/model"
+	"github.com/festy23/avito_internship/pkg/apierror"
+	"github.com/festy23/avito_internship/pkg/cursor"
+	"github.com/festy23/avito_internship/pkg/pagination"
+)
+
+// errorRegistry maps errors shared by all comment endpoints.
+var errorRegistry = apierror.Registry{}.
+	Register(commentModel.ErrPullRequestNotFound, apierror.NotFound("pull request not found")).
+	Register(commentModel.ErrInvalidPullRequestID, apierror.InvalidField("pull_request_id", "length", ""))
+
+var createErrors = errorRegistry.
+	Register(commentModel.ErrUserNotFound, apierror.NotFound("user not found")).
+	Register(commentModel.ErrEmptyBody, apierror.InvalidField("body", "required", "")).
+	Register(commentModel.ErrBodyTooLong, apierror.InvalidField("body", "max", ""))
+
+var listErrors = errorRegistry.
+	Register(pagination.ErrInvalidLimit, apierror.InvalidField("limit", "range", "")).
+	Register(cursor.ErrInvalid, apierror.InvalidField("cursor", "format", "invalid cursor or changed pull request"))
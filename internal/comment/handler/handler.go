@@ -0,0 +1,126 @@
+// Package handler provides HTTP handlers for comment endpoints.
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	commentModel "github.com/festy23/avito_internship/internal/comment/model"
+	"github.com/festy23/avito_internship/internal/comment/service"
+	"github.com/festy23/avito_internship/pkg/apierror"
+	"github.com/festy23/avito_internship/pkg/bind"
+	"github.com/festy23/avito_internship/pkg/cursor"
+)
+
+// Handler handles HTTP requests for comment endpoints.
+type Handler struct {
+	service service.Service
+	cursors *cursor.Codec
+}
+
+// New creates a new comment handler instance. Its pagination cursors are valid only within this process.
+func New(svc service.Service) *Handler {
+	return NewWithCursors(svc, cursor.New(""))
+}
+
+// NewWithCursors creates a new comment handler instance signing pagination cursors with cursors.
+func NewWithCursors(svc service.Service, cursors *cursor.Codec) *Handler {
+	return &Handler{service: svc, cursors: cursors}
+}
+
+// CreateComment handles POST /pullRequest/comment request.
+// @Summary Comment on a pull request
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Param request body commentModel.CreateCommentRequest true "Request"
+// @Success 201 {object} map[string]commentModel.CommentResponse "Response wrapped in comment object"
+// @Failure 400 {object} ErrorResponse "Bad request (INVALID_REQUEST)"
+// @Failure 404 {object} ErrorResponse "PR or user not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pullRequest/comment [post] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) CreateComment(c *gin.Context) {
+	var req commentModel.CreateCommentRequest
+	if !bind.JSON(c, &req) {
+		return
+	}
+
+	resp, err := h.service.CreateComment(c.Request.Context(), &req)
+	if err != nil {
+		createErrors.Fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, map[string]interface{}{
+		"comment": resp,
+	})
+}
+
+// ListComments handles GET /pullRequest/comments request.
+// Comments are returned oldest first in pages: next_cursor of a page is passed as cursor to get the next one.
+// @Summary List comments of a pull request
+// @Tags Comments
+// @Produce json
+// @Param pull_request_id query string true "Pull request ID"
+// @Param limit query int false "Page size (up to PAGINATION_MAX_LIMIT), PAGINATION_DEFAULT_LIMIT when omitted"
+// @Param cursor query string false "next_cursor of the previous page of the same PR"
+// @Success 200 {object} commentModel.ListCommentsResponse
+// @Failure 400 {object} ErrorResponse "Bad request (INVALID_REQUEST)"
+// @Failure 404 {object} ErrorResponse "PR not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pullRequest/comments [get] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) ListComments(c *gin.Context) {
+	query, err := h.parseListQuery(c)
+	if err != nil {
+		listErrors.Fail(c, err)
+		return
+	}
+
+	resp, err := h.service.ListComments(c.Request.Context(), query)
+	if err != nil {
+		listErrors.Fail(c, err)
+		return
+	}
+
+	if resp.Next != nil {
+		resp.NextCursor, err = h.cursors.Encode(listCursorScope(query.PullRequestID), resp.Next)
+		if err != nil {
+			listErrors.Fail(c, err)
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// parseListQuery parses the query parameters of ListComments.
+func (h *Handler) parseListQuery(c *gin.Context) (commentModel.ListQuery, error) {
+	query := commentModel.ListQuery{PullRequestID: c.Query("pull_request_id")}
+	if query.PullRequestID == "" {
+		return query, apierror.InvalidField("pull_request_id", "required", "pull_request_id parameter is required")
+	}
+
+	if raw := c.Query("limit"); raw != "" {
+		var err error
+		if query.Limit, err = strconv.Atoi(raw); err != nil {
+			return query, apierror.InvalidField("limit", "type", "limit must be an integer")
+		}
+	}
+
+	if token := c.Query("cursor"); token != "" {
+		var after commentModel.Position
+		if err := h.cursors.Decode(listCursorScope(query.PullRequestID), token, &after); err != nil {
+			return query, err
+		}
+		query.After = &after
+	}
+	return query, nil
+}
+
+// listCursorScope binds ListComments cursors to the pull request, so a cursor of one PR is rejected for another.
+func listCursorScope(prID string) string {
+	return fmt.Sprintf("listComments %q", prID)
+}
@@ -0,0 +1,255 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	commentModel "github.com/festy23/avito_internship/internal/comment/model"
+	"github.com/festy23/avito_internship/pkg/pagination"
+)
+
+type mockService struct {
+	mock.Mock
+}
+
+func (m *mockService) CreateComment(
+	ctx context.Context,
+	req *commentModel.CreateCommentRequest,
+) (*commentModel.CommentResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*commentModel.CommentResponse), args.Error(1)
+}
+
+func (m *mockService) ListComments(
+	ctx context.Context,
+	query commentModel.ListQuery,
+) (*commentModel.ListCommentsResponse, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*commentModel.ListCommentsResponse), args.Error(1)
+}
+
+func setupRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	return gin.New()
+}
+
+func TestHandler_CreateComment(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		setupMock  func(m *mockService)
+		wantStatus int
+		wantCode   string
+		wantField  string
+	}{
+		{
+			name: "success",
+			body: `{"pull_request_id":"pr-1","user_id":"u1","body":"Looks good"}`,
+			setupMock: func(m *mockService) {
+				req := &commentModel.CreateCommentRequest{PullRequestID: "pr-1", UserID: "u1", Body: "Looks good"}
+				m.On("CreateComment", mock.Anything, req).Return(&commentModel.CommentResponse{
+					ID:            1,
+					PullRequestID: "pr-1",
+					UserID:        "u1",
+					Body:          "Looks good",
+				}, nil)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "missing body",
+			body:       `{"pull_request_id":"pr-1","user_id":"u1"}`,
+			setupMock:  func(*mockService) {},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "INVALID_REQUEST",
+			wantField:  "body",
+		},
+		{
+			name: "blank body",
+			body: `{"pull_request_id":"pr-1","user_id":"u1","body":"  "}`,
+			setupMock: func(m *mockService) {
+				m.On("CreateComment", mock.Anything, mock.Anything).Return(nil, commentModel.ErrEmptyBody)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "INVALID_REQUEST",
+			wantField:  "body",
+		},
+		{
+			name: "pull request not found",
+			body: `{"pull_request_id":"pr-1","user_id":"u1","body":"Hi"}`,
+			setupMock: func(m *mockService) {
+				m.On("CreateComment", mock.Anything, mock.Anything).Return(nil, commentModel.ErrPullRequestNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantCode:   "NOT_FOUND",
+		},
+		{
+			name: "user not found",
+			body: `{"pull_request_id":"pr-1","user_id":"u1","body":"Hi"}`,
+			setupMock: func(m *mockService) {
+				m.On("CreateComment", mock.Anything, mock.Anything).Return(nil, commentModel.ErrUserNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantCode:   "NOT_FOUND",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mockService)
+			tt.setupMock(mockSvc)
+			router := setupRouter()
+			router.POST("/pullRequest/comment", New(mockSvc).CreateComment)
+
+			w := httptest.NewRecorder()
+			httpReq, _ := http.NewRequest("POST", "/pullRequest/comment", bytes.NewBufferString(tt.body))
+			httpReq.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, httpReq)
+
+			assert.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+			if tt.wantCode != "" {
+				var response ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.wantCode, response.Error.Code)
+				if tt.wantField != "" {
+					require.NotEmpty(t, response.Error.Details)
+					assert.Equal(t, tt.wantField, response.Error.Details[0].Field)
+				}
+			} else {
+				var response map[string]commentModel.CommentResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "Looks good", response["comment"].Body)
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_ListComments(t *testing.T) {
+	t.Run("pages", func(t *testing.T) {
+		mockSvc := new(mockService)
+		router := setupRouter()
+		router.GET("/pullRequest/comments", New(mockSvc).ListComments)
+
+		query := commentModel.ListQuery{PullRequestID: "pr-1", Limit: 1}
+		next := &commentModel.Position{ID: 1}
+		mockSvc.On("ListComments", mock.Anything, query).Return(&commentModel.ListCommentsResponse{
+			PullRequestID: "pr-1",
+			Comments:      []commentModel.CommentResponse{{ID: 1, Body: "first"}},
+			Next:          next,
+		}, nil)
+		secondQuery := query
+		secondQuery.After = next
+		mockSvc.On("ListComments", mock.Anything, secondQuery).Return(&commentModel.ListCommentsResponse{
+			PullRequestID: "pr-1",
+			Comments:      []commentModel.CommentResponse{{ID: 2, Body: "second"}},
+		}, nil)
+
+		url := "/pullRequest/comments?pull_request_id=pr-1&limit=1"
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("GET", url, nil)
+		router.ServeHTTP(w, httpReq)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var first commentModel.ListCommentsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &first))
+		require.Len(t, first.Comments, 1)
+		assert.Equal(t, "first", first.Comments[0].Body)
+		require.NotEmpty(t, first.NextCursor)
+
+		w = httptest.NewRecorder()
+		httpReq, _ = http.NewRequest("GET", url+"&cursor="+first.NextCursor, nil)
+		router.ServeHTTP(w, httpReq)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var second commentModel.ListCommentsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &second))
+		assert.Equal(t, "second", second.Comments[0].Body)
+		assert.Empty(t, second.NextCursor)
+
+		w = httptest.NewRecorder()
+		httpReq, _ = http.NewRequest("GET", "/pullRequest/comments?pull_request_id=pr-2&cursor="+first.NextCursor, nil)
+		router.ServeHTTP(w, httpReq)
+		assert.Equal(t, http.StatusBadRequest, w.Code, "cursors are bound to the pull request")
+		mockSvc.AssertExpectations(t)
+	})
+
+	tests := []struct {
+		name       string
+		url        string
+		err        error
+		wantStatus int
+		wantField  string
+	}{
+		{
+			name:       "missing pull_request_id",
+			url:        "/pullRequest/comments",
+			wantStatus: http.StatusBadRequest,
+			wantField:  "pull_request_id",
+		},
+		{
+			name:       "limit is not a number",
+			url:        "/pullRequest/comments?pull_request_id=pr-1&limit=ten",
+			wantStatus: http.StatusBadRequest,
+			wantField:  "limit",
+		},
+		{
+			name:       "malformed cursor",
+			url:        "/pullRequest/comments?pull_request_id=pr-1&cursor=abc",
+			wantStatus: http.StatusBadRequest,
+			wantField:  "cursor",
+		},
+		{
+			name:       "limit out of range",
+			url:        "/pullRequest/comments?pull_request_id=pr-1&limit=1000",
+			err:        fmt.Errorf("%w: must be between 1 and 100, got 1000", pagination.ErrInvalidLimit),
+			wantStatus: http.StatusBadRequest,
+			wantField:  "limit",
+		},
+		{
+			name:       "pull request not found",
+			url:        "/pullRequest/comments?pull_request_id=pr-1",
+			err:        commentModel.ErrPullRequestNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mockService)
+			router := setupRouter()
+			router.GET("/pullRequest/comments", New(mockSvc).ListComments)
+			if tt.err != nil {
+				mockSvc.On("ListComments", mock.Anything, mock.Anything).Return(nil, tt.err)
+			}
+
+			w := httptest.NewRecorder()
+			httpReq, _ := http.NewRequest("GET", tt.url, nil)
+			router.ServeHTTP(w, httpReq)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantField != "" {
+				var response ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "INVALID_REQUEST", response.Error.Code)
+				require.NotEmpty(t, response.Error.Details)
+				assert.Equal(t, tt.wantField, response.Error.Details[0].Field)
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package handler
+
+import (
+	"github.com/festy23/avito_internship/pkg/apierror"
+)
+
+// ErrorResponse represents error response structure matching OpenAPI spec.
+type ErrorResponse = apierror.Response
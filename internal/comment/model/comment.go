@@ -0,0 +1,22 @@
+// Package model provides domain models and DTOs for the comment module.
+package model
+
+import "time"
+
+// MaxBodyLength is the maximum length of a comment body in characters.
+const MaxBodyLength = 10000
+
+// Comment represents a comment left by a user on a pull request.
+// Matches the pull_request_comments table schema; comments are deleted together with their pull request.
+type Comment struct {
+	ID            int64     `gorm:"primaryKey;column:id;type:bigserial"                                                  json:"id"`
+	PullRequestID string    `gorm:"column:pull_request_id;type:varchar(255);not null;index:idx_comments_pull_request_id" json:"pull_request_id"`
+	UserID        string    `gorm:"column:user_id;type:varchar(255);not null"                                            json:"user_id"`
+	Body          string    `gorm:"column:body;type:text;not null"                                                       json:"body"`
+	CreatedAt     time.Time `gorm:"column:created_at;type:timestamptz;not null;default:now()"                            json:"created_at"`
+}
+
+// TableName specifies the table name for GORM.
+func (Comment) TableName() string {
+	return "pull_request_comments"
+}
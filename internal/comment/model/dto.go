@@ -0,0 +1,41 @@
+package model
+
+// CreateCommentRequest represents the request to comment on a pull request.
+// Lengths match the CHECK constraints of the pull_request_comments table.
+type CreateCommentRequest struct {
+	PullRequestID string `json:"pull_request_id" binding:"required,max=255"`
+	UserID        string `json:"user_id"         binding:"required,max=255"`
+	Body          string `json:"body"            binding:"required,max=10000"`
+}
+
+// CommentResponse represents a comment of a pull request.
+type CommentResponse struct {
+	ID            int64  `json:"id"`
+	PullRequestID string `json:"pull_request_id"`
+	UserID        string `json:"user_id"`
+	Body          string `json:"body"`
+	CreatedAt     string `json:"createdAt"`
+}
+
+// ListQuery holds the options of ListComments. A zero Limit means the default page size;
+// After is the position of the previous page.
+type ListQuery struct {
+	PullRequestID string
+	Limit         int
+	After         *Position
+}
+
+// Position is the keyset of the last comment of a page, encoded in its cursor.
+// Comments are listed in the order they were left, which is the order of their IDs.
+type Position struct {
+	ID int64 `json:"id"`
+}
+
+// ListCommentsResponse represents a page of the comments of a pull request, oldest first.
+// NextCursor is set when more comments follow the page; Next is its position before encoding.
+type ListCommentsResponse struct {
+	PullRequestID string            `json:"pull_request_id"`
+	Comments      []CommentResponse `json:"comments"`
+	NextCursor    string            `json:"next_cursor,omitempty"`
+	Next          *Position         `json:"-"`
+}
@@ -0,0 +1,16 @@
+package model
+
+import "errors"
+
+var (
+	// ErrPullRequestNotFound indicates that the commented pull request does not exist.
+	ErrPullRequestNotFound = errors.New("pull request not found")
+	// ErrUserNotFound indicates that the comment author does not exist.
+	ErrUserNotFound = errors.New("user not found")
+	// ErrInvalidPullRequestID indicates that the pull request ID is empty or too long.
+	ErrInvalidPullRequestID = errors.New("pull_request_id must be between 1 and 255 characters")
+	// ErrEmptyBody indicates that the comment body is blank.
+	ErrEmptyBody = errors.New("body must not be blank")
+	// ErrBodyTooLong indicates that the comment body exceeds MaxBodyLength characters.
+	ErrBodyTooLong = errors.New("body must be at most 10000 characters")
+)
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrors_Definition(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"ErrPullRequestNotFound", ErrPullRequestNotFound, "pull request not found"},
+		{"ErrUserNotFound", ErrUserNotFound, "user not found"},
+		{"ErrInvalidPullRequestID", ErrInvalidPullRequestID, "pull_request_id must be between 1 and 255 characters"},
+		{"ErrEmptyBody", ErrEmptyBody, "body must not be blank"},
+		{"ErrBodyTooLong", ErrBodyTooLong, "body must be at most 10000 characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotNil(t, tt.err)
+			assert.Equal(t, tt.expected, tt.err.Error())
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Package repository provides data access layer for the comment module.
+package repository
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/festy23/avito_internship/internal/comment/model"
+	"github.com/festy23/avito_internship/internal/database/dberror"
+	"github.com/festy23/avito_internship/internal/tenant"
+	"github.com/festy23/avito_internship/pkg/clock"
+)
+
+// Repository defines the interface for comment data access operations.
+//
+// Pull requests and users are looked up in the tenant of the context (see tenant.Scope); comments are
+// accessed by the ID of a pull request already resolved in the tenant.
+type Repository interface {
+	// Create stores a comment; its ID and created_at are set by the repository.
+	Create(ctx context.Context, comment *model.Comment) error
+
+	// List returns comments of a pull request in the order they were left, after the comment with
+	// ID afterID (0 for the first page). A positive limit bounds the number of comments.
+	List(ctx context.Context, prID string, afterID int64, limit int) ([]model.Comment, error)
+
+	// PullRequestExists reports whether a pull request exists.
+	PullRequestExists(ctx context.Context, prID string) (bool, error)
+
+	// UserExists reports whether a user exists.
+	UserExists(ctx context.Context, userID string) (bool, error)
+}
+
+type repository struct {
+	db     *gorm.DB
+	clock  clock.Clock
+	logger *zap.SugaredLogger
+}
+
+// New creates a new comment repository instance.
+func New(db *gorm.DB, logger *zap.SugaredLogger) Repository {
+	return NewWithClock(db, clock.New(), logger)
+}
+
+// NewWithClock creates a new comment repository instance that takes timestamps from clk.
+func NewWithClock(db *gorm.DB, clk clock.Clock, logger *zap.SugaredLogger) Repository {
+	return &repository{db: db, clock: clk, logger: logger}
+}
+
+// Create stores a comment.
+func (r *repository) Create(ctx context.Context, comment *model.Comment) error {
+	r.logger.Debugw("Create comment called", "pull_request_id", comment.PullRequestID, "user_id", comment.UserID)
+
+	comment.ID = 0
+	comment.CreatedAt = r.clock.Now()
+	if err := r.db.WithContext(ctx).Create(comment).Error; err != nil {
+		r.logger.Errorw("Create comment database error", "pull_request_id", comment.PullRequestID, "error", err)
+		return dberror.Wrap(err, "create comment", comment.PullRequestID)
+	}
+	return nil
+}
+
+// List returns comments of a pull request in the order they were left.
+func (r *repository) List(ctx context.Context, prID string, afterID int64, limit int) ([]model.Comment, error) {
+	r.logger.Debugw("List comments called", "pull_request_id", prID, "after_id", afterID, "limit", limit)
+
+	db := r.db.WithContext(ctx).
+		Where("pull_request_id = ? AND id > ?", prID, afterID).
+		Order("id ASC")
+	if limit > 0 {
+		db = db.Limit(limit)
+	}
+
+	comments := []model.Comment{}
+	if err := db.Find(&comments).Error; err != nil {
+		r.logger.Errorw("List comments database error", "pull_request_id", prID, "error", err)
+		return nil, dberror.Wrap(err, "list comments", prID)
+	}
+	return comments, nil
+}
+
+// PullRequestExists reports whether a pull request exists.
+func (r *repository) PullRequestExists(ctx context.Context, prID string) (bool, error) {
+	return r.exists(ctx, "pull_requests", "pull_request_id", prID)
+}
+
+// UserExists reports whether a user exists.
+func (r *repository) UserExists(ctx context.Context, userID string) (bool, error) {
+	return r.exists(ctx, "users", "user_id", userID)
+}
+
+// exists reports whether table has a row of the tenant of ctx with the given key.
+func (r *repository) exists(ctx context.Context, table, column, key string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Table(table).
+		Scopes(tenant.Scope(ctx, table)).
+		Where(column+" = ?", key).
+		Count(&count).Error
+	if err != nil {
+		r.logger.Errorw("Exists database error", "table", table, "error", err)
+		return false, dberror.Wrap(err, "check "+table, key)
+	}
+	return count > 0, nil
+}
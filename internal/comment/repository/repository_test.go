@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/festy23/avito_internship/internal/comment/model"
+	"github.com/festy23/avito_internship/internal/tenant"
+	"github.com/festy23/avito_internship/internal/testutil"
+	"github.com/festy23/avito_internship/pkg/clock"
+)
+
+func TestRepository_CreateAndList(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.NewDB(t)
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	repo := NewWithClock(db, clock.NewFake(now), zap.NewNop().Sugar())
+	testutil.NewTeam().WithMembers(2).Create(t, db)
+	testutil.NewPR().WithID("pr-1").Create(t, db)
+	testutil.NewPR().WithID("pr-2").Create(t, db)
+
+	for _, c := range []model.Comment{
+		{PullRequestID: "pr-1", UserID: "u1", Body: "first"},
+		{PullRequestID: "pr-2", UserID: "u1", Body: "other PR"},
+		{PullRequestID: "pr-1", UserID: "u2", Body: "second"},
+		{PullRequestID: "pr-1", UserID: "u1", Body: "third"},
+	} {
+		require.NoError(t, repo.Create(ctx, &c))
+		assert.NotZero(t, c.ID)
+		assert.True(t, c.CreatedAt.Equal(now), "created_at is taken from the repository clock")
+	}
+
+	bodies := func(afterID int64, limit int) ([]string, []model.Comment) {
+		t.Helper()
+		comments, err := repo.List(ctx, "pr-1", afterID, limit)
+		require.NoError(t, err)
+		result := []string{}
+		for _, c := range comments {
+			result = append(result, c.Body)
+		}
+		return result, comments
+	}
+
+	all, _ := bodies(0, 0)
+	assert.Equal(t, []string{"first", "second", "third"}, all)
+
+	page, comments := bodies(0, 2)
+	assert.Equal(t, []string{"first", "second"}, page)
+	page, _ = bodies(comments[1].ID, 2)
+	assert.Equal(t, []string{"third"}, page)
+
+	empty, err := repo.List(ctx, "pr-missing", 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func TestRepository_Exists(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.NewDB(t)
+	repo := New(db, zap.NewNop().Sugar())
+	testutil.NewTeam().WithMembers(1).Create(t, db)
+	testutil.NewPR().WithID("pr-1").Create(t, db)
+
+	exists, err := repo.PullRequestExists(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = repo.PullRequestExists(ctx, "pr-2")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	exists, err = repo.UserExists(ctx, "u1")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = repo.UserExists(ctx, "u2")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	t.Run("other tenant", func(t *testing.T) {
+		acme := tenant.WithID(ctx, "acme")
+		exists, err := repo.PullRequestExists(acme, "pr-1")
+		require.NoError(t, err)
+		assert.False(t, exists)
+		exists, err = repo.UserExists(acme, "u1")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
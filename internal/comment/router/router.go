@@ -0,0 +1,31 @@
+// Package router provides comment module routes registration.
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/festy23/avito_internship/internal/comment/handler"
+	"github.com/festy23/avito_internship/internal/comment/repository"
+	"github.com/festy23/avito_internship/internal/comment/service"
+	"github.com/festy23/avito_internship/pkg/cursor"
+	"github.com/festy23/avito_internship/pkg/pagination"
+)
+
+// RegisterRoutes registers comment module routes.
+// cursors signs pagination cursors and limits bound the page size of the comment list.
+func RegisterRoutes(
+	r gin.IRouter,
+	db *gorm.DB,
+	cursors *cursor.Codec,
+	limits pagination.Limits,
+	logger *zap.SugaredLogger,
+) {
+	repo := repository.New(db, logger)
+	svc := service.New(repo, logger, service.WithPageLimits(limits))
+	h := handler.NewWithCursors(svc, cursors)
+
+	r.POST("/pullRequest/comment", h.CreateComment)
+	r.GET("/pullRequest/comments", h.ListComments)
+}
@@ -0,0 +1,167 @@
+// Package service provides business logic layer for the comment module.
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+
+	"github.com/festy23/avito_internship/internal/comment/model"
+	"github.com/festy23/avito_internship/internal/comment/repository"
+	"github.com/festy23/avito_internship/pkg/pagination"
+)
+
+// Service defines the interface for comment business logic operations.
+type Service interface {
+	// CreateComment adds a comment of a user to a pull request in any status.
+	CreateComment(ctx context.Context, req *model.CreateCommentRequest) (*model.CommentResponse, error)
+
+	// ListComments returns a page of the comments of a pull request, oldest first.
+	ListComments(ctx context.Context, query model.ListQuery) (*model.ListCommentsResponse, error)
+}
+
+// Option configures an optional setting of the service.
+type Option func(*service)
+
+// WithPageLimits bounds the page size of ListComments by limits instead of pagination.DefaultLimits.
+func WithPageLimits(limits pagination.Limits) Option {
+	return func(s *service) {
+		s.limits = limits
+	}
+}
+
+type service struct {
+	repo   repository.Repository
+	limits pagination.Limits
+	logger *zap.SugaredLogger
+}
+
+// New creates a new comment service instance.
+func New(repo repository.Repository, logger *zap.SugaredLogger, opts ...Option) Service {
+	s := &service{
+		repo:   repo,
+		limits: pagination.DefaultLimits(),
+		logger: logger,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// CreateComment adds a comment of a user to a pull request. The body is stored without surrounding whitespace.
+func (s *service) CreateComment(
+	ctx context.Context,
+	req *model.CreateCommentRequest,
+) (*model.CommentResponse, error) {
+	s.logger.Debugw("CreateComment called", "pull_request_id", req.PullRequestID, "user_id", req.UserID)
+
+	if err := validatePullRequestID(req.PullRequestID); err != nil {
+		return nil, err
+	}
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, model.ErrEmptyBody
+	}
+	if utf8.RuneCountInString(body) > model.MaxBodyLength {
+		return nil, model.ErrBodyTooLong
+	}
+
+	if err := s.checkPullRequest(ctx, req.PullRequestID); err != nil {
+		return nil, err
+	}
+	exists, err := s.repo.UserExists(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, model.ErrUserNotFound
+	}
+
+	comment := &model.Comment{PullRequestID: req.PullRequestID, UserID: req.UserID, Body: body}
+	if err = s.repo.Create(ctx, comment); err != nil {
+		s.logger.Errorw("CreateComment failed", "pull_request_id", req.PullRequestID, "error", err)
+		return nil, err
+	}
+
+	s.logger.Infow("CreateComment completed", "pull_request_id", req.PullRequestID, "comment_id", comment.ID)
+	resp := newCommentResponse(comment)
+	return &resp, nil
+}
+
+// ListComments returns a page of the comments of a pull request, oldest first.
+func (s *service) ListComments(
+	ctx context.Context,
+	query model.ListQuery,
+) (*model.ListCommentsResponse, error) {
+	s.logger.Debugw("ListComments called", "pull_request_id", query.PullRequestID, "limit", query.Limit)
+
+	if err := validatePullRequestID(query.PullRequestID); err != nil {
+		return nil, err
+	}
+	limit, err := s.limits.Resolve(query.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Comments of a missing PR are empty, but the client most likely mistyped the ID
+	if err = s.checkPullRequest(ctx, query.PullRequestID); err != nil {
+		return nil, err
+	}
+
+	var afterID int64
+	if query.After != nil {
+		afterID = query.After.ID
+	}
+	// One extra comment tells whether another page follows
+	comments, err := s.repo.List(ctx, query.PullRequestID, afterID, limit+1)
+	if err != nil {
+		s.logger.Errorw("ListComments failed", "pull_request_id", query.PullRequestID, "error", err)
+		return nil, err
+	}
+
+	resp := &model.ListCommentsResponse{PullRequestID: query.PullRequestID}
+	if len(comments) > limit {
+		comments = comments[:limit]
+		resp.Next = &model.Position{ID: comments[limit-1].ID}
+	}
+	resp.Comments = make([]model.CommentResponse, 0, len(comments))
+	for i := range comments {
+		resp.Comments = append(resp.Comments, newCommentResponse(&comments[i]))
+	}
+	return resp, nil
+}
+
+// checkPullRequest returns ErrPullRequestNotFound if the pull request does not exist.
+func (s *service) checkPullRequest(ctx context.Context, prID string) error {
+	exists, err := s.repo.PullRequestExists(ctx, prID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return model.ErrPullRequestNotFound
+	}
+	return nil
+}
+
+// validatePullRequestID checks the length of a pull request ID.
+func validatePullRequestID(prID string) error {
+	if prID == "" || len(prID) > 255 {
+		return model.ErrInvalidPullRequestID
+	}
+	return nil
+}
+
+// newCommentResponse builds the API representation of a comment.
+func newCommentResponse(comment *model.Comment) model.CommentResponse {
+	return model.CommentResponse{
+		ID:            comment.ID,
+		PullRequestID: comment.PullRequestID,
+		UserID:        comment.UserID,
+		Body:          comment.Body,
+		CreatedAt:     comment.CreatedAt.Format(time.RFC3339),
+	}
+}
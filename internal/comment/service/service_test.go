@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/festy23/avito_internship/internal/comment/model"
+	"github.com/festy23/avito_internship/internal/comment/repository"
+	"github.com/festy23/avito_internship/internal/testutil"
+	"github.com/festy23/avito_internship/pkg/pagination"
+)
+
+func TestService_CreateComment(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.NewDB(t)
+	svc := New(repository.New(db, zap.NewNop().Sugar()), zap.NewNop().Sugar())
+	testutil.NewTeam().WithMembers(2).Create(t, db)
+	testutil.NewPR().WithID("pr-1").Create(t, db)
+	testutil.NewPR().WithID("pr-2").Merged().Create(t, db)
+
+	t.Run("stores trimmed body", func(t *testing.T) {
+		resp, err := svc.CreateComment(ctx, &model.CreateCommentRequest{
+			PullRequestID: "pr-1",
+			UserID:        "u2",
+			Body:          "  Looks good\n",
+		})
+		require.NoError(t, err)
+		assert.NotZero(t, resp.ID)
+		assert.Equal(t, "pr-1", resp.PullRequestID)
+		assert.Equal(t, "u2", resp.UserID)
+		assert.Equal(t, "Looks good", resp.Body)
+		assert.NotEmpty(t, resp.CreatedAt)
+	})
+
+	t.Run("merged PR can be commented", func(t *testing.T) {
+		_, err := svc.CreateComment(ctx, &model.CreateCommentRequest{PullRequestID: "pr-2", UserID: "u1", Body: "Thanks"})
+		assert.NoError(t, err)
+	})
+
+	tests := []struct {
+		name    string
+		req     model.CreateCommentRequest
+		wantErr error
+	}{
+		{
+			name:    "blank body",
+			req:     model.CreateCommentRequest{PullRequestID: "pr-1", UserID: "u1", Body: " \n\t"},
+			wantErr: model.ErrEmptyBody,
+		},
+		{
+			name:    "body too long",
+			req:     model.CreateCommentRequest{PullRequestID: "pr-1", UserID: "u1", Body: strings.Repeat("я", 10001)},
+			wantErr: model.ErrBodyTooLong,
+		},
+		{
+			name:    "pull request id too long",
+			req:     model.CreateCommentRequest{PullRequestID: strings.Repeat("p", 256), UserID: "u1", Body: "Hi"},
+			wantErr: model.ErrInvalidPullRequestID,
+		},
+		{
+			name:    "pull request not found",
+			req:     model.CreateCommentRequest{PullRequestID: "pr-missing", UserID: "u1", Body: "Hi"},
+			wantErr: model.ErrPullRequestNotFound,
+		},
+		{
+			name:    "user not found",
+			req:     model.CreateCommentRequest{PullRequestID: "pr-1", UserID: "u-missing", Body: "Hi"},
+			wantErr: model.ErrUserNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.CreateComment(ctx, &tt.req)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+
+	t.Run("body of maximum length", func(t *testing.T) {
+		_, err := svc.CreateComment(ctx, &model.CreateCommentRequest{
+			PullRequestID: "pr-1",
+			UserID:        "u1",
+			Body:          strings.Repeat("я", model.MaxBodyLength),
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestService_ListComments(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.NewDB(t)
+	svc := New(repository.New(db, zap.NewNop().Sugar()), zap.NewNop().Sugar(),
+		WithPageLimits(pagination.Limits{Default: 2, Max: 3}))
+	testutil.NewTeam().WithMembers(2).Create(t, db)
+	testutil.NewPR().WithID("pr-1").Create(t, db)
+	testutil.NewPR().WithID("pr-2").Create(t, db)
+	for _, body := range []string{"first", "second", "third"} {
+		_, err := svc.CreateComment(ctx, &model.CreateCommentRequest{PullRequestID: "pr-1", UserID: "u2", Body: body})
+		require.NoError(t, err)
+	}
+
+	t.Run("pages", func(t *testing.T) {
+		first, err := svc.ListComments(ctx, model.ListQuery{PullRequestID: "pr-1"})
+		require.NoError(t, err)
+		assert.Equal(t, "pr-1", first.PullRequestID)
+		require.Len(t, first.Comments, 2)
+		assert.Equal(t, "first", first.Comments[0].Body)
+		assert.Equal(t, "second", first.Comments[1].Body)
+		require.NotNil(t, first.Next)
+		assert.Equal(t, first.Comments[1].ID, first.Next.ID)
+
+		second, err := svc.ListComments(ctx, model.ListQuery{PullRequestID: "pr-1", After: first.Next})
+		require.NoError(t, err)
+		require.Len(t, second.Comments, 1)
+		assert.Equal(t, "third", second.Comments[0].Body)
+		assert.Nil(t, second.Next)
+	})
+
+	t.Run("pull request without comments", func(t *testing.T) {
+		resp, err := svc.ListComments(ctx, model.ListQuery{PullRequestID: "pr-2", Limit: 3})
+		require.NoError(t, err)
+		assert.Equal(t, []model.CommentResponse{}, resp.Comments)
+		assert.Nil(t, resp.Next)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := svc.ListComments(ctx, model.ListQuery{PullRequestID: "pr-missing"})
+		assert.ErrorIs(t, err, model.ErrPullRequestNotFound)
+
+		_, err = svc.ListComments(ctx, model.ListQuery{PullRequestID: "pr-1", Limit: 4})
+		assert.ErrorIs(t, err, pagination.ErrInvalidLimit)
+
+		_, err = svc.ListComments(ctx, model.ListQuery{})
+		assert.ErrorIs(t, err, model.ErrInvalidPullRequestID)
+	})
+}
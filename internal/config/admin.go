@@ -0,0 +1,53 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// minAdminTokenLength is the minimum length of the administration token.
+const minAdminTokenLength = 32
+
+// defaultAdminImportMaxSizeMB is the default limit of an import request body in megabytes.
+const defaultAdminImportMaxSizeMB = 64
+
+// AdminConfig holds configuration of administration endpoints.
+type AdminConfig struct {
+	// Token authorizes requests to administration endpoints (Authorization: Bearer <token>).
+	// If empty, the endpoints are not registered.
+	Token string
+	// ImportMaxSizeMB limits the size of an import request body in megabytes; the dump is held in memory.
+	ImportMaxSizeMB int
+}
+
+// LoadAdminConfigFromEnv loads administration configuration from environment variables.
+func LoadAdminConfigFromEnv() AdminConfig {
+	return AdminConfig{
+		Token:           GetEnv("ADMIN_TOKEN", ""),
+		ImportMaxSizeMB: GetEnvInt("ADMIN_IMPORT_MAX_SIZE_MB", defaultAdminImportMaxSizeMB),
+	}
+}
+
+// Enabled reports whether administration endpoints should be registered.
+func (c AdminConfig) Enabled() bool {
+	return c.Token != ""
+}
+
+// ImportMaxSize returns the limit of an import request body in bytes.
+func (c AdminConfig) ImportMaxSize() int64 {
+	return int64(c.ImportMaxSizeMB) << 20
+}
+
+// Validate validates administration configuration. Errors never include the token.
+func (c AdminConfig) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if len(c.Token) < minAdminTokenLength {
+		return fmt.Errorf("ADMIN_TOKEN must be at least %d characters", minAdminTokenLength)
+	}
+	if c.ImportMaxSizeMB < 1 {
+		return errors.New("ADMIN_IMPORT_MAX_SIZE_MB must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadAdminConfigFromEnv(t *testing.T) {
+	t.Run("default values", func(t *testing.T) {
+		t.Setenv("ADMIN_TOKEN", "")
+		t.Setenv("ADMIN_IMPORT_MAX_SIZE_MB", "")
+
+		cfg := LoadAdminConfigFromEnv()
+		assert.False(t, cfg.Enabled())
+		assert.Equal(t, int64(64<<20), cfg.ImportMaxSize())
+		assert.NoError(t, cfg.Validate())
+	})
+
+	t.Run("custom values", func(t *testing.T) {
+		token := strings.Repeat("t", 32)
+		t.Setenv("ADMIN_TOKEN", token)
+		t.Setenv("ADMIN_IMPORT_MAX_SIZE_MB", "8")
+
+		cfg := LoadAdminConfigFromEnv()
+		assert.Equal(t, token, cfg.Token)
+		assert.Equal(t, 8, cfg.ImportMaxSizeMB)
+		assert.True(t, cfg.Enabled())
+		assert.NoError(t, cfg.Validate())
+	})
+}
+
+func TestAdminConfig_Validate(t *testing.T) {
+	err := AdminConfig{Token: "short-token"}.Validate()
+	assert.ErrorContains(t, err, "ADMIN_TOKEN")
+	assert.NotContains(t, err.Error(), "short-token")
+
+	err = AdminConfig{Token: strings.Repeat("t", 32), ImportMaxSizeMB: 0}.Validate()
+	assert.ErrorContains(t, err, "ADMIN_IMPORT_MAX_SIZE_MB")
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// ArchiveConfig holds configuration for the merged pull request archival job.
+type ArchiveConfig struct {
+	// RetentionDays is the age in days after which merged PRs are archived (0 disables the job).
+	RetentionDays int
+	// Job holds scheduling settings of the archival job.
+	Job JobConfig
+}
+
+// LoadArchiveConfigFromEnv loads archival job configuration from environment variables.
+// ARCHIVE_INTERVAL is kept for backward compatibility and defines the default schedule.
+func LoadArchiveConfigFromEnv() ArchiveConfig {
+	retentionDays := GetEnvInt("ARCHIVE_MERGED_AFTER_DAYS", 0)
+	interval := GetEnvDuration("ARCHIVE_INTERVAL", time.Hour)
+
+	return ArchiveConfig{
+		RetentionDays: retentionDays,
+		Job: LoadJobConfigFromEnv("ARCHIVE", JobConfig{
+			Enabled:  retentionDays > 0,
+			Schedule: "@every " + interval.String(),
+		}),
+	}
+}
+
+// Enabled reports whether the archival job should run.
+func (c ArchiveConfig) Enabled() bool {
+	return c.Job.Enabled && c.RetentionDays > 0
+}
+
+// Retention returns the retention period as a duration.
+func (c ArchiveConfig) Retention() time.Duration {
+	return time.Duration(c.RetentionDays) * 24 * time.Hour
+}
+
+// Validate validates archival job configuration.
+func (c ArchiveConfig) Validate() error {
+	if c.RetentionDays < 0 {
+		return fmt.Errorf("ARCHIVE_MERGED_AFTER_DAYS must not be negative")
+	}
+	if !c.Enabled() {
+		return nil
+	}
+	return c.Job.Validate("ARCHIVE")
+}
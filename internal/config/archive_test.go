@@ -0,0 +1,82 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadArchiveConfigFromEnv(t *testing.T) {
+	t.Run("default values", func(t *testing.T) {
+		t.Setenv("ARCHIVE_MERGED_AFTER_DAYS", "")
+		t.Setenv("ARCHIVE_INTERVAL", "")
+		t.Setenv("ARCHIVE_ENABLED", "")
+		t.Setenv("ARCHIVE_SCHEDULE", "")
+		t.Setenv("ARCHIVE_JITTER", "")
+
+		cfg := LoadArchiveConfigFromEnv()
+		assert.Equal(t, 0, cfg.RetentionDays)
+		assert.Equal(t, "@every 1h0m0s", cfg.Job.Schedule)
+		assert.Equal(t, time.Duration(0), cfg.Job.Jitter)
+		assert.False(t, cfg.Enabled())
+	})
+
+	t.Run("custom values", func(t *testing.T) {
+		t.Setenv("ARCHIVE_MERGED_AFTER_DAYS", "90")
+		t.Setenv("ARCHIVE_INTERVAL", "30m")
+		t.Setenv("ARCHIVE_ENABLED", "")
+		t.Setenv("ARCHIVE_SCHEDULE", "")
+		t.Setenv("ARCHIVE_JITTER", "")
+
+		cfg := LoadArchiveConfigFromEnv()
+		assert.Equal(t, 90, cfg.RetentionDays)
+		assert.Equal(t, "@every 30m0s", cfg.Job.Schedule)
+		assert.True(t, cfg.Enabled())
+		assert.Equal(t, 90*24*time.Hour, cfg.Retention())
+	})
+
+	t.Run("schedule overrides interval", func(t *testing.T) {
+		t.Setenv("ARCHIVE_MERGED_AFTER_DAYS", "90")
+		t.Setenv("ARCHIVE_INTERVAL", "30m")
+		t.Setenv("ARCHIVE_ENABLED", "")
+		t.Setenv("ARCHIVE_SCHEDULE", "0 3 * * *")
+		t.Setenv("ARCHIVE_JITTER", "5m")
+
+		cfg := LoadArchiveConfigFromEnv()
+		assert.Equal(t, "0 3 * * *", cfg.Job.Schedule)
+		assert.Equal(t, 5*time.Minute, cfg.Job.Jitter)
+	})
+
+	t.Run("explicitly disabled", func(t *testing.T) {
+		t.Setenv("ARCHIVE_MERGED_AFTER_DAYS", "90")
+		t.Setenv("ARCHIVE_ENABLED", "false")
+
+		cfg := LoadArchiveConfigFromEnv()
+		assert.False(t, cfg.Enabled())
+	})
+}
+
+func TestArchiveConfig_Validate(t *testing.T) {
+	enabledJob := JobConfig{Enabled: true, Schedule: "@every 1h"}
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.NoError(t, ArchiveConfig{}.Validate())
+	})
+
+	t.Run("valid enabled config", func(t *testing.T) {
+		assert.NoError(t, ArchiveConfig{RetentionDays: 30, Job: enabledJob}.Validate())
+	})
+
+	t.Run("negative retention", func(t *testing.T) {
+		err := ArchiveConfig{RetentionDays: -1, Job: enabledJob}.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "ARCHIVE_MERGED_AFTER_DAYS")
+	})
+
+	t.Run("invalid schedule", func(t *testing.T) {
+		err := ArchiveConfig{RetentionDays: 30, Job: JobConfig{Enabled: true, Schedule: "@every 0s"}}.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "ARCHIVE_SCHEDULE")
+	})
+}
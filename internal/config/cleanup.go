@@ -0,0 +1,60 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// CleanupConfig holds configuration for the retention cleanup job.
+type CleanupConfig struct {
+	// EventRetentionDays is the age in days after which activity events are deleted (0 keeps them forever).
+	EventRetentionDays int
+	// BatchSize is the maximum number of rows deleted per statement.
+	BatchSize int
+	// BatchPause is the delay between consecutive batches.
+	BatchPause time.Duration
+	// Job holds scheduling settings of the cleanup job.
+	Job JobConfig
+}
+
+// LoadCleanupConfigFromEnv loads cleanup job configuration from environment variables.
+func LoadCleanupConfigFromEnv() CleanupConfig {
+	retentionDays := GetEnvInt("CLEANUP_EVENTS_RETENTION_DAYS", 0)
+
+	return CleanupConfig{
+		EventRetentionDays: retentionDays,
+		BatchSize:          GetEnvInt("CLEANUP_BATCH_SIZE", 1000),
+		BatchPause:         GetEnvDuration("CLEANUP_BATCH_PAUSE", 100*time.Millisecond),
+		Job: LoadJobConfigFromEnv("CLEANUP", JobConfig{
+			Enabled:  retentionDays > 0,
+			Schedule: "@daily",
+		}),
+	}
+}
+
+// Enabled reports whether the cleanup job should run.
+func (c CleanupConfig) Enabled() bool {
+	return c.Job.Enabled && c.EventRetentionDays > 0
+}
+
+// EventRetention returns the activity event retention period as a duration.
+func (c CleanupConfig) EventRetention() time.Duration {
+	return time.Duration(c.EventRetentionDays) * 24 * time.Hour
+}
+
+// Validate validates cleanup job configuration.
+func (c CleanupConfig) Validate() error {
+	if c.EventRetentionDays < 0 {
+		return fmt.Errorf("CLEANUP_EVENTS_RETENTION_DAYS must not be negative")
+	}
+	if !c.Enabled() {
+		return nil
+	}
+	if c.BatchSize < 1 {
+		return fmt.Errorf("CLEANUP_BATCH_SIZE must be greater than 0")
+	}
+	if c.BatchPause < 0 {
+		return fmt.Errorf("CLEANUP_BATCH_PAUSE must not be negative")
+	}
+	return c.Job.Validate("CLEANUP")
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadCleanupConfigFromEnv(t *testing.T) {
+	t.Run("default values", func(t *testing.T) {
+		t.Setenv("CLEANUP_EVENTS_RETENTION_DAYS", "")
+		t.Setenv("CLEANUP_BATCH_SIZE", "")
+		t.Setenv("CLEANUP_BATCH_PAUSE", "")
+		t.Setenv("CLEANUP_ENABLED", "")
+		t.Setenv("CLEANUP_SCHEDULE", "")
+
+		cfg := LoadCleanupConfigFromEnv()
+		assert.Equal(t, 0, cfg.EventRetentionDays)
+		assert.Equal(t, 1000, cfg.BatchSize)
+		assert.Equal(t, 100*time.Millisecond, cfg.BatchPause)
+		assert.Equal(t, "@daily", cfg.Job.Schedule)
+		assert.False(t, cfg.Enabled())
+	})
+
+	t.Run("custom values", func(t *testing.T) {
+		t.Setenv("CLEANUP_EVENTS_RETENTION_DAYS", "180")
+		t.Setenv("CLEANUP_BATCH_SIZE", "500")
+		t.Setenv("CLEANUP_BATCH_PAUSE", "1s")
+		t.Setenv("CLEANUP_ENABLED", "")
+		t.Setenv("CLEANUP_SCHEDULE", "0 4 * * *")
+
+		cfg := LoadCleanupConfigFromEnv()
+		assert.Equal(t, 180, cfg.EventRetentionDays)
+		assert.Equal(t, 500, cfg.BatchSize)
+		assert.Equal(t, time.Second, cfg.BatchPause)
+		assert.Equal(t, "0 4 * * *", cfg.Job.Schedule)
+		assert.True(t, cfg.Enabled())
+		assert.Equal(t, 180*24*time.Hour, cfg.EventRetention())
+	})
+}
+
+func TestCleanupConfig_Validate(t *testing.T) {
+	enabledJob := JobConfig{Enabled: true, Schedule: "@daily"}
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.NoError(t, CleanupConfig{}.Validate())
+	})
+
+	t.Run("valid enabled config", func(t *testing.T) {
+		assert.NoError(t, CleanupConfig{EventRetentionDays: 30, BatchSize: 100, Job: enabledJob}.Validate())
+	})
+
+	t.Run("negative retention", func(t *testing.T) {
+		err := CleanupConfig{EventRetentionDays: -1}.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "CLEANUP_EVENTS_RETENTION_DAYS")
+	})
+
+	t.Run("non-positive batch size", func(t *testing.T) {
+		err := CleanupConfig{EventRetentionDays: 30, Job: enabledJob}.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "CLEANUP_BATCH_SIZE")
+	})
+
+	t.Run("negative batch pause", func(t *testing.T) {
+		err := CleanupConfig{EventRetentionDays: 30, BatchSize: 100, BatchPause: -time.Second, Job: enabledJob}.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "CLEANUP_BATCH_PAUSE")
+	})
+}
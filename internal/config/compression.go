@@ -0,0 +1,59 @@
+package config
+
+import (
+	"compress/gzip"
+	"fmt"
+	"strings"
+
+	"github.com/festy23/avito_internship/internal/middleware"
+)
+
+// defaultCompressionExcludedTypes lists content types that are already compressed or streamed.
+const defaultCompressionExcludedTypes = "image/,video/,audio/,application/zip,text/event-stream"
+
+// CompressionConfig holds configuration of response compression.
+type CompressionConfig struct {
+	// Enabled compresses responses with gzip for clients accepting it.
+	Enabled bool
+	// MinSize is the response size in bytes from which responses are compressed.
+	MinSize int
+	// Level is the gzip compression level, from 1 (fastest) to 9 (smallest).
+	Level int
+	// ExcludedContentTypes is a comma-separated list of content type prefixes sent uncompressed.
+	ExcludedContentTypes string
+}
+
+// LoadCompressionConfigFromEnv loads compression configuration from environment variables.
+func LoadCompressionConfigFromEnv() CompressionConfig {
+	return CompressionConfig{
+		Enabled:              GetEnvBool("COMPRESSION_ENABLED", false),
+		MinSize:              GetEnvInt("COMPRESSION_MIN_SIZE", 1024),
+		Level:                GetEnvInt("COMPRESSION_LEVEL", 5),
+		ExcludedContentTypes: GetEnv("COMPRESSION_EXCLUDED_TYPES", defaultCompressionExcludedTypes),
+	}
+}
+
+// Options returns the options of the compression middleware.
+func (c CompressionConfig) Options() middleware.CompressionOptions {
+	var excluded []string
+	for _, contentType := range strings.Split(c.ExcludedContentTypes, ",") {
+		if contentType = strings.ToLower(strings.TrimSpace(contentType)); contentType != "" {
+			excluded = append(excluded, contentType)
+		}
+	}
+	return middleware.CompressionOptions{MinSize: c.MinSize, Level: c.Level, ExcludedContentTypes: excluded}
+}
+
+// Validate validates compression configuration.
+func (c CompressionConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.MinSize < 0 {
+		return fmt.Errorf("COMPRESSION_MIN_SIZE must not be negative")
+	}
+	if c.Level < gzip.BestSpeed || c.Level > gzip.BestCompression {
+		return fmt.Errorf("COMPRESSION_LEVEL must be between %d and %d", gzip.BestSpeed, gzip.BestCompression)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadCompressionConfigFromEnv(t *testing.T) {
+	t.Run("default values", func(t *testing.T) {
+		t.Setenv("COMPRESSION_ENABLED", "")
+		t.Setenv("COMPRESSION_MIN_SIZE", "")
+		t.Setenv("COMPRESSION_LEVEL", "")
+		t.Setenv("COMPRESSION_EXCLUDED_TYPES", "")
+
+		cfg := LoadCompressionConfigFromEnv()
+		assert.False(t, cfg.Enabled)
+		assert.Equal(t, 1024, cfg.MinSize)
+		assert.Equal(t, 5, cfg.Level)
+		assert.Contains(t, cfg.Options().ExcludedContentTypes, "image/")
+		assert.NoError(t, cfg.Validate())
+	})
+
+	t.Run("custom values", func(t *testing.T) {
+		t.Setenv("COMPRESSION_ENABLED", "true")
+		t.Setenv("COMPRESSION_MIN_SIZE", "256")
+		t.Setenv("COMPRESSION_LEVEL", "9")
+		t.Setenv("COMPRESSION_EXCLUDED_TYPES", " Application/PDF , ,text/csv")
+
+		cfg := LoadCompressionConfigFromEnv()
+		assert.True(t, cfg.Enabled)
+		assert.Equal(t, 256, cfg.Options().MinSize)
+		assert.Equal(t, 9, cfg.Options().Level)
+		assert.Equal(t, []string{"application/pdf", "text/csv"}, cfg.Options().ExcludedContentTypes)
+		assert.NoError(t, cfg.Validate())
+	})
+}
+
+func TestCompressionConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  CompressionConfig
+		err  string
+	}{
+		{"disabled", CompressionConfig{Level: 0}, ""},
+		{"negative min size", CompressionConfig{Enabled: true, MinSize: -1, Level: 5}, "COMPRESSION_MIN_SIZE"},
+		{"level too low", CompressionConfig{Enabled: true, Level: 0}, "COMPRESSION_LEVEL"},
+		{"level too high", CompressionConfig{Enabled: true, Level: 10}, "COMPRESSION_LEVEL"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
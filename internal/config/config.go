@@ -8,6 +8,49 @@ type Config struct {
 	Server ServerConfig
 	// Logger holds logger configuration.
 	Logger LoggerConfig
+	// Archive holds merged pull request archival job configuration.
+	Archive ArchiveConfig
+	// Cleanup holds retention cleanup job configuration.
+	Cleanup CleanupConfig
+	// Maintenance holds database maintenance configuration.
+	Maintenance MaintenanceConfig
+	// Reconcile holds startup data reconciliation configuration.
+	Reconcile ReconcileConfig
+	// Rebalance holds reviewer rebalancing job configuration.
+	Rebalance RebalanceConfig
+	// SLA holds team review SLA job configuration.
+	SLA SLAConfig
+	// Stale holds configuration of the job closing inactive pull requests.
+	Stale StaleConfig
+	// PullRequest holds optional pull request business rules.
+	PullRequest PullRequestConfig
+	// Webhook holds outbound webhook delivery configuration.
+	Webhook WebhookConfig
+	// FaultInjection holds fault-injection middleware configuration for resilience tests.
+	FaultInjection FaultInjectionConfig
+	// Sentry holds panic reporting configuration.
+	Sentry SentryConfig
+	// OTLP holds configuration of pushing metrics to an OpenTelemetry collector.
+	OTLP OTLPConfig
+	// Tenancy holds multi-tenancy configuration.
+	Tenancy TenancyConfig
+	// Pagination holds configuration of paginated list endpoints.
+	Pagination PaginationConfig
+	// User holds configuration of the user endpoints.
+	User UserConfig
+	// Admin holds configuration of administration endpoints.
+	Admin AdminConfig
+	// Compression holds response compression configuration.
+	Compression CompressionConfig
+	// LeaderElection holds configuration of leader election for scheduled background jobs.
+	LeaderElection LeaderElectionConfig
+	// Metrics holds configuration of the Prometheus metrics.
+	Metrics MetricsConfig
+	// Demo holds demo mode configuration.
+	Demo DemoConfig
+	// RunMigrations applies database migrations on startup. Disable it when migrations run as
+	// a separate step (cmd/migrate, e.g. in an init container) before replicas start.
+	RunMigrations bool
 	// GinMode is the Gin framework mode (debug, release, test).
 	GinMode string
 }
@@ -15,13 +58,36 @@ type Config struct {
 // LoadFromEnv loads all configuration from environment variables.
 func LoadFromEnv() Config {
 	return Config{
-		Server:  LoadServerConfigFromEnv(),
-		Logger:  LoadLoggerConfigFromEnv(),
-		GinMode: GetEnv("GIN_MODE", "release"),
+		Server:         LoadServerConfigFromEnv(),
+		Logger:         LoadLoggerConfigFromEnv(),
+		Archive:        LoadArchiveConfigFromEnv(),
+		Cleanup:        LoadCleanupConfigFromEnv(),
+		Maintenance:    LoadMaintenanceConfigFromEnv(),
+		Reconcile:      LoadReconcileConfigFromEnv(),
+		Rebalance:      LoadRebalanceConfigFromEnv(),
+		SLA:            LoadSLAConfigFromEnv(),
+		Stale:          LoadStaleConfigFromEnv(),
+		PullRequest:    LoadPullRequestConfigFromEnv(),
+		Webhook:        LoadWebhookConfigFromEnv(),
+		FaultInjection: LoadFaultInjectionConfigFromEnv(),
+		Sentry:         LoadSentryConfigFromEnv(),
+		OTLP:           LoadOTLPConfigFromEnv(),
+		Tenancy:        LoadTenancyConfigFromEnv(),
+		Pagination:     LoadPaginationConfigFromEnv(),
+		User:           LoadUserConfigFromEnv(),
+		Admin:          LoadAdminConfigFromEnv(),
+		Compression:    LoadCompressionConfigFromEnv(),
+		LeaderElection: LoadLeaderElectionConfigFromEnv(),
+		Metrics:        LoadMetricsConfigFromEnv(),
+		Demo:           LoadDemoConfigFromEnv(),
+		RunMigrations:  GetEnvBool("RUN_MIGRATIONS", true),
+		GinMode:        GetEnv("GIN_MODE", "release"),
 	}
 }
 
 // Validate validates all configuration.
+//
+//nolint:gocyclo // One check per configuration section
 func (c Config) Validate() error {
 	if err := c.Server.Validate(); err != nil {
 		return fmt.Errorf("server config validation failed: %w", err)
@@ -31,6 +97,78 @@ func (c Config) Validate() error {
 		return fmt.Errorf("logger config validation failed: %w", err)
 	}
 
+	if err := c.Archive.Validate(); err != nil {
+		return fmt.Errorf("archive config validation failed: %w", err)
+	}
+
+	if err := c.PullRequest.Validate(); err != nil {
+		return fmt.Errorf("pull request config validation failed: %w", err)
+	}
+
+	if err := c.Cleanup.Validate(); err != nil {
+		return fmt.Errorf("cleanup config validation failed: %w", err)
+	}
+
+	if err := c.Maintenance.Validate(); err != nil {
+		return fmt.Errorf("maintenance config validation failed: %w", err)
+	}
+
+	if err := c.Rebalance.Validate(); err != nil {
+		return fmt.Errorf("rebalance config validation failed: %w", err)
+	}
+
+	if err := c.SLA.Validate(); err != nil {
+		return fmt.Errorf("SLA config validation failed: %w", err)
+	}
+
+	if err := c.Stale.Validate(); err != nil {
+		return fmt.Errorf("stale config validation failed: %w", err)
+	}
+
+	if err := c.Webhook.Validate(); err != nil {
+		return fmt.Errorf("webhook config validation failed: %w", err)
+	}
+
+	if err := c.FaultInjection.Validate(); err != nil {
+		return fmt.Errorf("fault injection config validation failed: %w", err)
+	}
+
+	if err := c.Sentry.Validate(); err != nil {
+		return fmt.Errorf("sentry config validation failed: %w", err)
+	}
+
+	if err := c.OTLP.Validate(); err != nil {
+		return fmt.Errorf("OTLP config validation failed: %w", err)
+	}
+
+	if err := c.Tenancy.Validate(); err != nil {
+		return fmt.Errorf("tenancy config validation failed: %w", err)
+	}
+
+	if err := c.Pagination.Validate(); err != nil {
+		return fmt.Errorf("pagination config validation failed: %w", err)
+	}
+
+	if err := c.User.Validate(); err != nil {
+		return fmt.Errorf("user config validation failed: %w", err)
+	}
+
+	if err := c.Admin.Validate(); err != nil {
+		return fmt.Errorf("admin config validation failed: %w", err)
+	}
+
+	if err := c.Compression.Validate(); err != nil {
+		return fmt.Errorf("compression config validation failed: %w", err)
+	}
+
+	if err := c.LeaderElection.Validate(); err != nil {
+		return fmt.Errorf("leader election config validation failed: %w", err)
+	}
+
+	if err := c.Metrics.Validate(); err != nil {
+		return fmt.Errorf("metrics config validation failed: %w", err)
+	}
+
 	validGinModes := map[string]bool{
 		"debug":   true,
 		"release": true,
@@ -40,5 +178,25 @@ func (c Config) Validate() error {
 		return fmt.Errorf("invalid GIN_MODE: %s (must be: debug, release, test)", c.GinMode)
 	}
 
+	// Fault injection breaks requests on purpose and must never reach production.
+	if c.FaultInjection.Enabled() && c.GinMode == "release" {
+		return fmt.Errorf("FAULT_INJECTION is not allowed with GIN_MODE=release")
+	}
+
+	// Without a shared secret cursors break across replicas and restarts; only dev and test runs may omit it.
+	if c.Pagination.CursorSecret == "" && c.GinMode == "release" {
+		return fmt.Errorf("PAGINATION_CURSOR_SECRET is required with GIN_MODE=release")
+	}
+
+	// The server would cut a waiting request off before it could answer.
+	if c.Server.WriteTimeout > 0 && c.User.EffectiveReviewWaitTimeout() >= c.Server.WriteTimeout {
+		return fmt.Errorf("USER_REVIEW_WAIT_TIMEOUT must be less than SERVER_WRITE_TIMEOUT")
+	}
+
+	// POST /admin/rebalance uses the threshold even if the scheduled job is disabled.
+	if c.Admin.Enabled() && c.Rebalance.Threshold < 1 {
+		return fmt.Errorf("REBALANCE_THRESHOLD must be greater than 0")
+	}
+
 	return nil
 }
@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -38,20 +39,23 @@ func TestLoadFromEnv_DefaultValues(t *testing.T) {
 	cfg := LoadFromEnv()
 	assert.Equal(t, ":8080", cfg.Server.Port)
 	assert.Equal(t, "info", cfg.Logger.Level)
+	assert.True(t, cfg.RunMigrations)
 	assert.Equal(t, "release", cfg.GinMode)
 }
 
 func TestLoadFromEnv_CustomValues(t *testing.T) {
 	restore := setupAndRestoreEnv(t, map[string]string{
-		"SERVER_PORT": ":9090",
-		"LOG_LEVEL":   "debug",
-		"GIN_MODE":    "debug",
+		"SERVER_PORT":    ":9090",
+		"LOG_LEVEL":      "debug",
+		"RUN_MIGRATIONS": "false",
+		"GIN_MODE":       "debug",
 	})
 	defer restore()
 
 	cfg := LoadFromEnv()
 	assert.Equal(t, ":9090", cfg.Server.Port)
 	assert.Equal(t, "debug", cfg.Logger.Level)
+	assert.False(t, cfg.RunMigrations)
 	assert.Equal(t, "debug", cfg.GinMode)
 }
 
@@ -59,15 +63,17 @@ func TestConfig_Validate(t *testing.T) {
 	t.Run("valid config", func(t *testing.T) {
 		cfg := Config{
 			Server: ServerConfig{
-				ReadTimeout:  10 * time.Second,
-				WriteTimeout: 10 * time.Second,
-				IdleTimeout:  120 * time.Second,
+				ReadTimeout:     10 * time.Second,
+				WriteTimeout:    10 * time.Second,
+				IdleTimeout:     120 * time.Second,
+				ShutdownTimeout: 5 * time.Second,
 			},
 			Logger: LoggerConfig{
 				Level:  "info",
 				Format: "json",
 			},
-			GinMode: "release",
+			Pagination: PaginationConfig{CursorSecret: strings.Repeat("s", minCursorSecretLength)},
+			GinMode:    "release",
 		}
 		err := cfg.Validate()
 		assert.NoError(t, err)
@@ -76,9 +82,10 @@ func TestConfig_Validate(t *testing.T) {
 	t.Run("invalid server config", func(t *testing.T) {
 		cfg := Config{
 			Server: ServerConfig{
-				ReadTimeout:  0,
-				WriteTimeout: 10 * time.Second,
-				IdleTimeout:  120 * time.Second,
+				ReadTimeout:     0,
+				WriteTimeout:    10 * time.Second,
+				IdleTimeout:     120 * time.Second,
+				ShutdownTimeout: 5 * time.Second,
 			},
 			Logger: LoggerConfig{
 				Level:  "info",
@@ -94,9 +101,10 @@ func TestConfig_Validate(t *testing.T) {
 	t.Run("invalid logger config", func(t *testing.T) {
 		cfg := Config{
 			Server: ServerConfig{
-				ReadTimeout:  10 * time.Second,
-				WriteTimeout: 10 * time.Second,
-				IdleTimeout:  120 * time.Second,
+				ReadTimeout:     10 * time.Second,
+				WriteTimeout:    10 * time.Second,
+				IdleTimeout:     120 * time.Second,
+				ShutdownTimeout: 5 * time.Second,
 			},
 			Logger: LoggerConfig{
 				Level:  "invalid",
@@ -112,9 +120,10 @@ func TestConfig_Validate(t *testing.T) {
 	t.Run("invalid gin mode", func(t *testing.T) {
 		cfg := Config{
 			Server: ServerConfig{
-				ReadTimeout:  10 * time.Second,
-				WriteTimeout: 10 * time.Second,
-				IdleTimeout:  120 * time.Second,
+				ReadTimeout:     10 * time.Second,
+				WriteTimeout:    10 * time.Second,
+				IdleTimeout:     120 * time.Second,
+				ShutdownTimeout: 5 * time.Second,
 			},
 			Logger: LoggerConfig{
 				Level:  "info",
@@ -132,18 +141,112 @@ func TestConfig_Validate(t *testing.T) {
 		for _, mode := range validModes {
 			cfg := Config{
 				Server: ServerConfig{
-					ReadTimeout:  10 * time.Second,
-					WriteTimeout: 10 * time.Second,
-					IdleTimeout:  120 * time.Second,
+					ReadTimeout:     10 * time.Second,
+					WriteTimeout:    10 * time.Second,
+					IdleTimeout:     120 * time.Second,
+					ShutdownTimeout: 5 * time.Second,
 				},
 				Logger: LoggerConfig{
 					Level:  "info",
 					Format: "json",
 				},
-				GinMode: mode,
+				Pagination: PaginationConfig{CursorSecret: strings.Repeat("s", minCursorSecretLength)},
+				GinMode:    mode,
 			}
 			err := cfg.Validate()
 			assert.NoError(t, err, "mode %s should be valid", mode)
 		}
 	})
+	t.Run("fault injection in release mode", func(t *testing.T) {
+		for _, mode := range []string{"release", "test"} {
+			cfg := Config{
+				Server: ServerConfig{
+					ReadTimeout:     10 * time.Second,
+					WriteTimeout:    10 * time.Second,
+					IdleTimeout:     120 * time.Second,
+					ShutdownTimeout: 5 * time.Second,
+				},
+				Logger: LoggerConfig{
+					Level:  "info",
+					Format: "json",
+				},
+				FaultInjection: FaultInjectionConfig{Rules: "* status=503 percent=10"},
+				GinMode:        mode,
+			}
+			err := cfg.Validate()
+			if mode == "release" {
+				assert.ErrorContains(t, err, "FAULT_INJECTION is not allowed")
+			} else {
+				assert.NoError(t, err)
+			}
+		}
+	})
+	t.Run("cursor secret in release mode", func(t *testing.T) {
+		for _, mode := range []string{"release", "debug", "test"} {
+			cfg := Config{
+				Server: ServerConfig{
+					ReadTimeout:     10 * time.Second,
+					WriteTimeout:    10 * time.Second,
+					IdleTimeout:     120 * time.Second,
+					ShutdownTimeout: 5 * time.Second,
+				},
+				Logger: LoggerConfig{
+					Level:  "info",
+					Format: "json",
+				},
+				GinMode: mode,
+			}
+			err := cfg.Validate()
+			if mode == "release" {
+				assert.ErrorContains(t, err, "PAGINATION_CURSOR_SECRET is required")
+			} else {
+				assert.NoError(t, err)
+			}
+		}
+	})
+	t.Run("rebalance threshold with admin endpoints", func(t *testing.T) {
+		cfg := Config{
+			Server: ServerConfig{
+				ReadTimeout:     10 * time.Second,
+				WriteTimeout:    10 * time.Second,
+				IdleTimeout:     120 * time.Second,
+				ShutdownTimeout: 5 * time.Second,
+			},
+			Logger: LoggerConfig{
+				Level:  "info",
+				Format: "json",
+			},
+			Admin:   AdminConfig{Token: strings.Repeat("a", minAdminTokenLength), ImportMaxSizeMB: 1},
+			GinMode: "test",
+		}
+		assert.ErrorContains(t, cfg.Validate(), "REBALANCE_THRESHOLD")
+
+		cfg.Rebalance.Threshold = 5
+		assert.NoError(t, cfg.Validate())
+	})
+	t.Run("review wait timeout with write timeout", func(t *testing.T) {
+		cfg := Config{
+			Server: ServerConfig{
+				ReadTimeout:     10 * time.Second,
+				WriteTimeout:    10 * time.Second,
+				IdleTimeout:     120 * time.Second,
+				ShutdownTimeout: 5 * time.Second,
+			},
+			Logger: LoggerConfig{
+				Level:  "info",
+				Format: "json",
+			},
+			User:    UserConfig{ReviewWaitTimeout: 10 * time.Second},
+			GinMode: "test",
+		}
+		assert.ErrorContains(t, cfg.Validate(), "USER_REVIEW_WAIT_TIMEOUT must be less than SERVER_WRITE_TIMEOUT")
+
+		cfg.User.ReviewWaitTimeout = 8 * time.Second
+		assert.NoError(t, cfg.Validate())
+
+		cfg.User.ReviewWaitTimeout = 0
+		cfg.Server.WriteTimeout = 5 * time.Second
+		assert.ErrorContains(t, cfg.Validate(), "USER_REVIEW_WAIT_TIMEOUT must be less than SERVER_WRITE_TIMEOUT",
+			"the default timeout is checked too")
+	})
 }
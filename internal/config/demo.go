@@ -0,0 +1,34 @@
+package config
+
+// DemoAdminToken authorizes administration endpoints in demo mode unless ADMIN_TOKEN is set.
+// It is published in the documentation, so demo mode must never be exposed publicly.
+const DemoAdminToken = "demo-admin-token-not-for-production"
+
+// DemoConfig holds configuration of the demo mode.
+type DemoConfig struct {
+	// Enabled seeds an empty database with demo teams, users and pull requests on startup and
+	// relaxes the configuration so the API can be tried without any setup (see Config.WithDemo).
+	Enabled bool
+}
+
+// LoadDemoConfigFromEnv loads demo mode configuration from environment variables.
+func LoadDemoConfigFromEnv() DemoConfig {
+	return DemoConfig{
+		Enabled: GetEnvBool("APP_DEMO", false),
+	}
+}
+
+// WithDemo returns the configuration relaxed for demo mode: Gin runs in debug mode, requests are
+// not isolated by tenant and administration endpoints accept DemoAdminToken unless ADMIN_TOKEN is set.
+// The configuration is returned unchanged if demo mode is disabled.
+func (c Config) WithDemo() Config {
+	if !c.Demo.Enabled {
+		return c
+	}
+	c.GinMode = "debug"
+	c.Tenancy = TenancyConfig{}
+	if c.Admin.Token == "" {
+		c.Admin.Token = DemoAdminToken
+	}
+	return c
+}
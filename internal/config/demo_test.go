@@ -0,0 +1,47 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/festy23/avito_internship/internal/tenant"
+)
+
+func TestLoadDemoConfigFromEnv(t *testing.T) {
+	t.Setenv("APP_DEMO", "")
+	assert.False(t, LoadDemoConfigFromEnv().Enabled)
+
+	t.Setenv("APP_DEMO", "true")
+	assert.True(t, LoadDemoConfigFromEnv().Enabled)
+}
+
+func TestConfig_WithDemo(t *testing.T) {
+	cfg := LoadFromEnv()
+	cfg.GinMode = "release"
+	cfg.Tenancy = TenancyConfig{Mode: tenant.ModeHeader}
+	cfg.Admin.Token = ""
+	cfg.Pagination.CursorSecret = ""
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.Equal(t, cfg, cfg.WithDemo())
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		cfg.Demo.Enabled = true
+		demo := cfg.WithDemo()
+
+		assert.Equal(t, "debug", demo.GinMode)
+		assert.False(t, demo.Tenancy.Enabled())
+		assert.Equal(t, DemoAdminToken, demo.Admin.Token)
+		require.NoError(t, demo.Validate())
+	})
+
+	t.Run("keeps the admin token", func(t *testing.T) {
+		cfg.Demo.Enabled = true
+		cfg.Admin.Token = "custom-admin-token-of-32-characters!"
+
+		assert.Equal(t, cfg.Admin.Token, cfg.WithDemo().Admin.Token)
+	})
+}
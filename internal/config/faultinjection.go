@@ -0,0 +1,37 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/festy23/avito_internship/internal/middleware"
+)
+
+// FaultInjectionConfig holds configuration of the fault-injection middleware used in resilience tests.
+type FaultInjectionConfig struct {
+	// Rules lists faults injected into matching requests in the middleware.ParseFaultRules format;
+	// empty disables fault injection.
+	Rules string
+}
+
+// LoadFaultInjectionConfigFromEnv loads fault injection configuration from environment variables.
+func LoadFaultInjectionConfigFromEnv() FaultInjectionConfig {
+	return FaultInjectionConfig{
+		Rules: GetEnv("FAULT_INJECTION", ""),
+	}
+}
+
+// Enabled reports whether faults should be injected.
+func (c FaultInjectionConfig) Enabled() bool {
+	return c.Rules != ""
+}
+
+// Validate validates fault injection configuration.
+func (c FaultInjectionConfig) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if _, err := middleware.ParseFaultRules(c.Rules); err != nil {
+		return fmt.Errorf("FAULT_INJECTION: %w", err)
+	}
+	return nil
+}
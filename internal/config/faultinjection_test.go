@@ -0,0 +1,30 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadFaultInjectionConfigFromEnv(t *testing.T) {
+	t.Run("default values", func(t *testing.T) {
+		t.Setenv("FAULT_INJECTION", "")
+
+		cfg := LoadFaultInjectionConfigFromEnv()
+		assert.False(t, cfg.Enabled())
+		assert.NoError(t, cfg.Validate())
+	})
+
+	t.Run("custom values", func(t *testing.T) {
+		t.Setenv("FAULT_INJECTION", "POST /pullRequest/create latency=200ms; * status=503 percent=10")
+
+		cfg := LoadFaultInjectionConfigFromEnv()
+		assert.True(t, cfg.Enabled())
+		assert.NoError(t, cfg.Validate())
+	})
+}
+
+func TestFaultInjectionConfig_Validate(t *testing.T) {
+	cfg := FaultInjectionConfig{Rules: "* percent=10"}
+	assert.ErrorContains(t, cfg.Validate(), "FAULT_INJECTION")
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/festy23/avito_internship/internal/jobs"
+)
+
+// JobConfig holds scheduling configuration of a background job.
+type JobConfig struct {
+	// Enabled turns the job on or off.
+	Enabled bool
+	// Schedule is a cron expression, a descriptor such as @daily or "@every <duration>".
+	Schedule string
+	// Jitter is the maximum random delay added to every run.
+	Jitter time.Duration
+}
+
+// LoadJobConfigFromEnv loads job configuration from <PREFIX>_ENABLED, <PREFIX>_SCHEDULE and
+// <PREFIX>_JITTER environment variables, falling back to defaults.
+func LoadJobConfigFromEnv(prefix string, defaults JobConfig) JobConfig {
+	return JobConfig{
+		Enabled:  GetEnvBool(prefix+"_ENABLED", defaults.Enabled),
+		Schedule: GetEnv(prefix+"_SCHEDULE", defaults.Schedule),
+		Jitter:   GetEnvDuration(prefix+"_JITTER", defaults.Jitter),
+	}
+}
+
+// Validate validates job configuration; prefix is used in error messages.
+func (c JobConfig) Validate(prefix string) error {
+	if !c.Enabled {
+		return nil
+	}
+	if _, err := jobs.ParseSchedule(c.Schedule); err != nil {
+		return fmt.Errorf("%s_SCHEDULE: %w", prefix, err)
+	}
+	if c.Jitter < 0 {
+		return fmt.Errorf("%s_JITTER must not be negative", prefix)
+	}
+	return nil
+}
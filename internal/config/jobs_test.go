@@ -0,0 +1,53 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadJobConfigFromEnv(t *testing.T) {
+	defaults := JobConfig{Enabled: true, Schedule: "@daily", Jitter: time.Minute}
+
+	t.Run("default values", func(t *testing.T) {
+		t.Setenv("TEST_JOB_ENABLED", "")
+		t.Setenv("TEST_JOB_SCHEDULE", "")
+		t.Setenv("TEST_JOB_JITTER", "")
+
+		assert.Equal(t, defaults, LoadJobConfigFromEnv("TEST_JOB", defaults))
+	})
+
+	t.Run("custom values", func(t *testing.T) {
+		t.Setenv("TEST_JOB_ENABLED", "false")
+		t.Setenv("TEST_JOB_SCHEDULE", "*/15 9-18 * * 1-5")
+		t.Setenv("TEST_JOB_JITTER", "30s")
+
+		cfg := LoadJobConfigFromEnv("TEST_JOB", defaults)
+		assert.False(t, cfg.Enabled)
+		assert.Equal(t, "*/15 9-18 * * 1-5", cfg.Schedule)
+		assert.Equal(t, 30*time.Second, cfg.Jitter)
+	})
+}
+
+func TestJobConfig_Validate(t *testing.T) {
+	t.Run("disabled job is not validated", func(t *testing.T) {
+		assert.NoError(t, JobConfig{Schedule: "invalid"}.Validate("TEST_JOB"))
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		assert.NoError(t, JobConfig{Enabled: true, Schedule: "0 9 * * *"}.Validate("TEST_JOB"))
+	})
+
+	t.Run("invalid schedule", func(t *testing.T) {
+		err := JobConfig{Enabled: true, Schedule: "0 25 * * *"}.Validate("TEST_JOB")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "TEST_JOB_SCHEDULE")
+	})
+
+	t.Run("negative jitter", func(t *testing.T) {
+		err := JobConfig{Enabled: true, Schedule: "@hourly", Jitter: -time.Second}.Validate("TEST_JOB")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "TEST_JOB_JITTER")
+	})
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// LeaderElectionConfig holds configuration of leader election among replicas.
+type LeaderElectionConfig struct {
+	// Enabled runs scheduled background jobs only on the replica holding the leader lease.
+	Enabled bool
+	// ID identifies this replica in the lease; empty means "<hostname>-<pid>".
+	ID string
+	// LeaseDuration is how long the lease stays valid without renewal. Another replica takes over
+	// at most LeaseDuration + RenewInterval after the leader stops renewing it.
+	LeaseDuration time.Duration
+	// RenewInterval is how often the leader renews the lease and other replicas try to acquire it.
+	RenewInterval time.Duration
+}
+
+// LoadLeaderElectionConfigFromEnv loads leader election configuration from environment variables.
+func LoadLeaderElectionConfigFromEnv() LeaderElectionConfig {
+	return LeaderElectionConfig{
+		Enabled:       GetEnvBool("LEADER_ELECTION_ENABLED", false),
+		ID:            GetEnv("LEADER_ELECTION_ID", ""),
+		LeaseDuration: GetEnvDuration("LEADER_ELECTION_LEASE_DURATION", 15*time.Second),
+		RenewInterval: GetEnvDuration("LEADER_ELECTION_RENEW_INTERVAL", 5*time.Second),
+	}
+}
+
+// Validate validates leader election configuration.
+func (c LeaderElectionConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.LeaseDuration <= 0 {
+		return fmt.Errorf("LEADER_ELECTION_LEASE_DURATION must be positive")
+	}
+	if c.RenewInterval <= 0 {
+		return fmt.Errorf("LEADER_ELECTION_RENEW_INTERVAL must be positive")
+	}
+	if c.RenewInterval >= c.LeaseDuration {
+		return fmt.Errorf("LEADER_ELECTION_RENEW_INTERVAL must be less than LEADER_ELECTION_LEASE_DURATION")
+	}
+	return nil
+}
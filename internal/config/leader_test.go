@@ -0,0 +1,59 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadLeaderElectionConfigFromEnv(t *testing.T) {
+	t.Run("default values", func(t *testing.T) {
+		t.Setenv("LEADER_ELECTION_ENABLED", "")
+		t.Setenv("LEADER_ELECTION_ID", "")
+		t.Setenv("LEADER_ELECTION_LEASE_DURATION", "")
+		t.Setenv("LEADER_ELECTION_RENEW_INTERVAL", "")
+
+		cfg := LoadLeaderElectionConfigFromEnv()
+		assert.False(t, cfg.Enabled)
+		assert.Empty(t, cfg.ID)
+		assert.Equal(t, 15*time.Second, cfg.LeaseDuration)
+		assert.Equal(t, 5*time.Second, cfg.RenewInterval)
+		assert.NoError(t, cfg.Validate())
+	})
+
+	t.Run("custom values", func(t *testing.T) {
+		t.Setenv("LEADER_ELECTION_ENABLED", "true")
+		t.Setenv("LEADER_ELECTION_ID", "pod-1")
+		t.Setenv("LEADER_ELECTION_LEASE_DURATION", "30s")
+		t.Setenv("LEADER_ELECTION_RENEW_INTERVAL", "10s")
+
+		cfg := LoadLeaderElectionConfigFromEnv()
+		assert.True(t, cfg.Enabled)
+		assert.Equal(t, "pod-1", cfg.ID)
+		assert.Equal(t, 30*time.Second, cfg.LeaseDuration)
+		assert.Equal(t, 10*time.Second, cfg.RenewInterval)
+		assert.NoError(t, cfg.Validate())
+	})
+}
+
+func TestLeaderElectionConfig_Validate(t *testing.T) {
+	t.Run("disabled config is not validated", func(t *testing.T) {
+		assert.NoError(t, LeaderElectionConfig{}.Validate())
+	})
+
+	t.Run("non-positive lease duration", func(t *testing.T) {
+		cfg := LeaderElectionConfig{Enabled: true, RenewInterval: time.Second}
+		assert.ErrorContains(t, cfg.Validate(), "LEADER_ELECTION_LEASE_DURATION")
+	})
+
+	t.Run("non-positive renew interval", func(t *testing.T) {
+		cfg := LeaderElectionConfig{Enabled: true, LeaseDuration: time.Second}
+		assert.ErrorContains(t, cfg.Validate(), "LEADER_ELECTION_RENEW_INTERVAL")
+	})
+
+	t.Run("renew interval not less than lease duration", func(t *testing.T) {
+		cfg := LeaderElectionConfig{Enabled: true, LeaseDuration: time.Second, RenewInterval: time.Second}
+		assert.ErrorContains(t, cfg.Validate(), "must be less than")
+	})
+}
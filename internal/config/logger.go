@@ -10,6 +10,19 @@ type LoggerConfig struct {
 	Format string
 	// Output is the output destination (stdout, stderr, or file path).
 	Output string
+	// SamplingInitial is the number of entries with the same level and message logged per second
+	// before sampling starts; 0 disables sampling.
+	SamplingInitial int
+	// SamplingThereafter logs every Nth entry with the same level and message once sampling started.
+	SamplingThereafter int
+	// MaxSizeMB is the size in megabytes at which a log file is rotated.
+	MaxSizeMB int
+	// MaxAgeDays is the number of days rotated log files are kept; 0 keeps them regardless of age.
+	MaxAgeDays int
+	// MaxBackups is the number of rotated log files kept; 0 keeps all of them.
+	MaxBackups int
+	// Compress gzips rotated log files.
+	Compress bool
 }
 
 // LoadLoggerConfigFromEnv loads logger configuration from environment variables.
@@ -18,6 +31,14 @@ func LoadLoggerConfigFromEnv() LoggerConfig {
 		Level:  GetEnv("LOG_LEVEL", "info"),
 		Format: GetEnv("LOG_FORMAT", "json"),
 		Output: GetEnv("LOG_OUTPUT", "stdout"),
+
+		SamplingInitial:    GetEnvInt("LOG_SAMPLING_INITIAL", 100),
+		SamplingThereafter: GetEnvInt("LOG_SAMPLING_THEREAFTER", 100),
+
+		MaxSizeMB:  GetEnvInt("LOG_MAX_SIZE_MB", 100),
+		MaxAgeDays: GetEnvInt("LOG_MAX_AGE_DAYS", 0),
+		MaxBackups: GetEnvInt("LOG_MAX_BACKUPS", 0),
+		Compress:   GetEnvBool("LOG_COMPRESS", false),
 	}
 }
 
@@ -41,6 +62,25 @@ func (c LoggerConfig) Validate() error {
 		return fmt.Errorf("invalid log format: %s (must be: json, console)", c.Format)
 	}
 
+	if c.SamplingInitial < 0 {
+		return fmt.Errorf("LOG_SAMPLING_INITIAL must not be negative")
+	}
+	if c.SamplingInitial > 0 && c.SamplingThereafter < 1 {
+		return fmt.Errorf("LOG_SAMPLING_THEREAFTER must be greater than 0")
+	}
+
+	if c.IsFile() {
+		if c.MaxSizeMB < 1 {
+			return fmt.Errorf("LOG_MAX_SIZE_MB must be greater than 0")
+		}
+		if c.MaxAgeDays < 0 {
+			return fmt.Errorf("LOG_MAX_AGE_DAYS must not be negative")
+		}
+		if c.MaxBackups < 0 {
+			return fmt.Errorf("LOG_MAX_BACKUPS must not be negative")
+		}
+	}
+
 	return nil
 }
 
@@ -48,3 +88,8 @@ func (c LoggerConfig) Validate() error {
 func (c LoggerConfig) IsProduction() bool {
 	return c.Format == "json" && c.Level != "debug"
 }
+
+// IsFile reports whether logs are written to a file rather than to stdout or stderr.
+func (c LoggerConfig) IsFile() bool {
+	return c.Output != "" && c.Output != "stdout" && c.Output != "stderr"
+}
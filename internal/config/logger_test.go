@@ -39,6 +39,10 @@ func TestLoadLoggerConfigFromEnv_DefaultValues(t *testing.T) {
 	assert.Equal(t, "info", cfg.Level)
 	assert.Equal(t, "json", cfg.Format)
 	assert.Equal(t, "stdout", cfg.Output)
+	assert.Equal(t, 100, cfg.SamplingInitial)
+	assert.Equal(t, 100, cfg.SamplingThereafter)
+	assert.Equal(t, 100, cfg.MaxSizeMB)
+	assert.False(t, cfg.IsFile())
 }
 
 func TestLoadLoggerConfigFromEnv_CustomValues(t *testing.T) {
@@ -115,6 +119,37 @@ func TestLoggerConfig_Validate(t *testing.T) {
 			},
 			wantError: false,
 		},
+		{
+			name: "sampling without thereafter",
+			config: LoggerConfig{
+				Level:           "info",
+				Format:          "json",
+				Output:          "stdout",
+				SamplingInitial: 100,
+			},
+			wantError: true,
+		},
+		{
+			name: "file output with rotation",
+			config: LoggerConfig{
+				Level:      "info",
+				Format:     "json",
+				Output:     "/var/log/app/app.log",
+				MaxSizeMB:  100,
+				MaxAgeDays: 7,
+				MaxBackups: 5,
+			},
+			wantError: false,
+		},
+		{
+			name: "file output without size limit",
+			config: LoggerConfig{
+				Level:  "info",
+				Format: "json",
+				Output: "/var/log/app/app.log",
+			},
+			wantError: true,
+		},
 	}
 
 	for _, tt := range tests {
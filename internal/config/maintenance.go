@@ -0,0 +1,30 @@
+package config
+
+// MaintenanceConfig holds configuration for database maintenance.
+type MaintenanceConfig struct {
+	// AnalyzeAfterArchive refreshes planner statistics after the archival job changes any rows.
+	AnalyzeAfterArchive bool
+	// Job holds scheduling settings of the periodic ANALYZE job.
+	Job JobConfig
+}
+
+// LoadMaintenanceConfigFromEnv loads database maintenance configuration from environment variables.
+func LoadMaintenanceConfigFromEnv() MaintenanceConfig {
+	return MaintenanceConfig{
+		AnalyzeAfterArchive: GetEnvBool("MAINTENANCE_ANALYZE_AFTER_ARCHIVE", false),
+		Job: LoadJobConfigFromEnv("MAINTENANCE", JobConfig{
+			Enabled:  false,
+			Schedule: "@daily",
+		}),
+	}
+}
+
+// Enabled reports whether the periodic maintenance job should run.
+func (c MaintenanceConfig) Enabled() bool {
+	return c.Job.Enabled
+}
+
+// Validate validates database maintenance configuration.
+func (c MaintenanceConfig) Validate() error {
+	return c.Job.Validate("MAINTENANCE")
+}
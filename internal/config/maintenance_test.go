@@ -0,0 +1,47 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadMaintenanceConfigFromEnv(t *testing.T) {
+	t.Run("default values", func(t *testing.T) {
+		t.Setenv("MAINTENANCE_ANALYZE_AFTER_ARCHIVE", "")
+		t.Setenv("MAINTENANCE_ENABLED", "")
+		t.Setenv("MAINTENANCE_SCHEDULE", "")
+
+		cfg := LoadMaintenanceConfigFromEnv()
+		assert.False(t, cfg.AnalyzeAfterArchive)
+		assert.False(t, cfg.Enabled())
+		assert.Equal(t, "@daily", cfg.Job.Schedule)
+	})
+
+	t.Run("custom values", func(t *testing.T) {
+		t.Setenv("MAINTENANCE_ANALYZE_AFTER_ARCHIVE", "true")
+		t.Setenv("MAINTENANCE_ENABLED", "true")
+		t.Setenv("MAINTENANCE_SCHEDULE", "30 3 * * *")
+
+		cfg := LoadMaintenanceConfigFromEnv()
+		assert.True(t, cfg.AnalyzeAfterArchive)
+		assert.True(t, cfg.Enabled())
+		assert.Equal(t, "30 3 * * *", cfg.Job.Schedule)
+	})
+}
+
+func TestMaintenanceConfig_Validate(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		assert.NoError(t, MaintenanceConfig{}.Validate())
+	})
+
+	t.Run("valid enabled config", func(t *testing.T) {
+		assert.NoError(t, MaintenanceConfig{Job: JobConfig{Enabled: true, Schedule: "@daily"}}.Validate())
+	})
+
+	t.Run("invalid schedule", func(t *testing.T) {
+		err := MaintenanceConfig{Job: JobConfig{Enabled: true, Schedule: "nightly"}}.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "MAINTENANCE_SCHEDULE")
+	})
+}
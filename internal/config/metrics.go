@@ -0,0 +1,42 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/festy23/avito_internship/internal/middleware"
+)
+
+// MetricsConfig holds configuration of the Prometheus metrics.
+type MetricsConfig struct {
+	// DurationBuckets is a comma-separated list of upper bounds in seconds of the request duration
+	// histogram buckets. If empty, the Prometheus default buckets are used.
+	DurationBuckets string
+}
+
+// LoadMetricsConfigFromEnv loads metrics configuration from environment variables.
+func LoadMetricsConfigFromEnv() MetricsConfig {
+	return MetricsConfig{
+		DurationBuckets: GetEnv("METRICS_DURATION_BUCKETS", ""),
+	}
+}
+
+// Buckets returns the request duration histogram buckets, or nil for the default buckets.
+func (c MetricsConfig) Buckets() []float64 {
+	if c.DurationBuckets == "" {
+		return nil
+	}
+	// Validated together with the rest of the configuration
+	buckets, _ := middleware.ParseBuckets(c.DurationBuckets)
+	return buckets
+}
+
+// Validate validates metrics configuration.
+func (c MetricsConfig) Validate() error {
+	if c.DurationBuckets == "" {
+		return nil
+	}
+	if _, err := middleware.ParseBuckets(c.DurationBuckets); err != nil {
+		return fmt.Errorf("METRICS_DURATION_BUCKETS: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadMetricsConfigFromEnv(t *testing.T) {
+	t.Run("default values", func(t *testing.T) {
+		t.Setenv("METRICS_DURATION_BUCKETS", "")
+
+		cfg := LoadMetricsConfigFromEnv()
+		assert.Nil(t, cfg.Buckets())
+		assert.NoError(t, cfg.Validate())
+	})
+
+	t.Run("custom buckets", func(t *testing.T) {
+		t.Setenv("METRICS_DURATION_BUCKETS", "0.01, 0.1, 1")
+
+		cfg := LoadMetricsConfigFromEnv()
+		assert.Equal(t, []float64{0.01, 0.1, 1}, cfg.Buckets())
+		assert.NoError(t, cfg.Validate())
+	})
+}
+
+func TestMetricsConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		buckets string
+		err     string
+	}{
+		{"not a number", "0.1,slow", "METRICS_DURATION_BUCKETS: invalid bucket"},
+		{"not increasing", "1,0.1", "METRICS_DURATION_BUCKETS: buckets must be strictly increasing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := MetricsConfig{DurationBuckets: tt.buckets}.Validate()
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
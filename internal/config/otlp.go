@@ -0,0 +1,64 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/festy23/avito_internship/internal/otlp"
+)
+
+// OTLPConfig holds configuration of pushing metrics to an OpenTelemetry collector.
+type OTLPConfig struct {
+	// MetricsEndpoint is the base URL of the collector OTLP/HTTP receiver, e.g.
+	// http://collector:4318; empty disables the export. /metrics is served either way.
+	MetricsEndpoint string
+	// Headers is a comma-separated list of "<name>=<value>" headers added to export requests.
+	Headers string
+	// Interval is the period between pushes.
+	Interval time.Duration
+	// Timeout is the timeout of a single push.
+	Timeout time.Duration
+	// ServiceName is reported as the service.name resource attribute.
+	ServiceName string
+}
+
+// LoadOTLPConfigFromEnv loads OTLP export configuration from environment variables.
+func LoadOTLPConfigFromEnv() OTLPConfig {
+	return OTLPConfig{
+		MetricsEndpoint: GetEnv("OTLP_METRICS_ENDPOINT", ""),
+		Headers:         GetEnv("OTLP_HEADERS", ""),
+		Interval:        GetEnvDuration("OTLP_METRICS_INTERVAL", 30*time.Second),
+		Timeout:         GetEnvDuration("OTLP_TIMEOUT", 10*time.Second),
+		ServiceName:     GetEnv("OTLP_SERVICE_NAME", "avito-internship"),
+	}
+}
+
+// Enabled reports whether metrics should be pushed to a collector.
+func (c OTLPConfig) Enabled() bool {
+	return c.MetricsEndpoint != ""
+}
+
+// Validate validates OTLP export configuration. Errors never include headers, which may hold tokens.
+func (c OTLPConfig) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	u, err := url.Parse(c.MetricsEndpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("OTLP_METRICS_ENDPOINT must be an absolute http(s) URL")
+	}
+	if _, err := otlp.ParseHeaders(c.Headers); err != nil {
+		return fmt.Errorf("OTLP_HEADERS: %w", err)
+	}
+	if c.Interval <= 0 {
+		return fmt.Errorf("OTLP_METRICS_INTERVAL must be positive")
+	}
+	if c.Timeout <= 0 {
+		return fmt.Errorf("OTLP_TIMEOUT must be positive")
+	}
+	if c.ServiceName == "" {
+		return fmt.Errorf("OTLP_SERVICE_NAME must not be empty")
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadOTLPConfigFromEnv(t *testing.T) {
+	t.Run("default values", func(t *testing.T) {
+		t.Setenv("OTLP_METRICS_ENDPOINT", "")
+		t.Setenv("OTLP_METRICS_INTERVAL", "")
+
+		cfg := LoadOTLPConfigFromEnv()
+		assert.False(t, cfg.Enabled())
+		assert.Equal(t, 30*time.Second, cfg.Interval)
+		assert.Equal(t, "avito-internship", cfg.ServiceName)
+		assert.NoError(t, cfg.Validate())
+	})
+
+	t.Run("custom values", func(t *testing.T) {
+		t.Setenv("OTLP_METRICS_ENDPOINT", "http://collector:4318")
+		t.Setenv("OTLP_HEADERS", "Authorization=Bearer dG9rZW4=")
+		t.Setenv("OTLP_METRICS_INTERVAL", "10s")
+
+		cfg := LoadOTLPConfigFromEnv()
+		assert.True(t, cfg.Enabled())
+		assert.Equal(t, 10*time.Second, cfg.Interval)
+		assert.NoError(t, cfg.Validate())
+	})
+}
+
+func TestOTLPConfig_Validate(t *testing.T) {
+	valid := OTLPConfig{
+		MetricsEndpoint: "https://collector.example.com",
+		Interval:        time.Second,
+		Timeout:         time.Second,
+		ServiceName:     "reviews",
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*OTLPConfig)
+		want   string
+	}{
+		{"relative endpoint", func(c *OTLPConfig) { c.MetricsEndpoint = "collector:4318" }, "OTLP_METRICS_ENDPOINT"},
+		{"malformed headers", func(c *OTLPConfig) { c.Headers = "Bearer secret" }, "OTLP_HEADERS"},
+		{"non-positive interval", func(c *OTLPConfig) { c.Interval = 0 }, "OTLP_METRICS_INTERVAL"},
+		{"non-positive timeout", func(c *OTLPConfig) { c.Timeout = 0 }, "OTLP_TIMEOUT"},
+		{"empty service name", func(c *OTLPConfig) { c.ServiceName = "" }, "OTLP_SERVICE_NAME"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid
+			tt.modify(&cfg)
+			err := cfg.Validate()
+			assert.ErrorContains(t, err, tt.want)
+			if err != nil {
+				assert.NotContains(t, err.Error(), "secret")
+			}
+		})
+	}
+}
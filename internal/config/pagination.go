@@ -0,0 +1,62 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/festy23/avito_internship/pkg/pagination"
+)
+
+// minCursorSecretLength is the minimum length of the cursor signing secret.
+const minCursorSecretLength = 32
+
+// PaginationConfig holds configuration of paginated list endpoints.
+type PaginationConfig struct {
+	// CursorSecret encrypts pagination cursors. All replicas must share it. It is required with
+	// GIN_MODE=release; otherwise, if empty, each replica generates a random key on startup and
+	// its cursors are rejected by other replicas.
+	CursorSecret string
+	// DefaultLimit is the page size of list requests without a limit; 0 means pagination.DefaultLimit.
+	DefaultLimit int
+	// MaxLimit is the largest page size list endpoints accept, larger limits are rejected;
+	// 0 means pagination.DefaultMaxLimit.
+	MaxLimit int
+}
+
+// LoadPaginationConfigFromEnv loads pagination configuration from environment variables.
+func LoadPaginationConfigFromEnv() PaginationConfig {
+	return PaginationConfig{
+		CursorSecret: GetEnv("PAGINATION_CURSOR_SECRET", ""),
+		DefaultLimit: GetEnvInt("PAGINATION_DEFAULT_LIMIT", pagination.DefaultLimit),
+		MaxLimit:     GetEnvInt("PAGINATION_MAX_LIMIT", pagination.DefaultMaxLimit),
+	}
+}
+
+// Limits returns the page size limits of list endpoints; unset limits fall back to the defaults.
+func (c PaginationConfig) Limits() pagination.Limits {
+	limits := pagination.DefaultLimits()
+	if c.DefaultLimit > 0 {
+		limits.Default = c.DefaultLimit
+	}
+	if c.MaxLimit > 0 {
+		limits.Max = c.MaxLimit
+	}
+	return limits
+}
+
+// Validate validates pagination configuration. Errors never include the secret.
+func (c PaginationConfig) Validate() error {
+	if c.CursorSecret != "" && len(c.CursorSecret) < minCursorSecretLength {
+		return fmt.Errorf("PAGINATION_CURSOR_SECRET must be at least %d characters", minCursorSecretLength)
+	}
+	if c.DefaultLimit < 0 {
+		return errors.New("PAGINATION_DEFAULT_LIMIT must be positive")
+	}
+	if c.MaxLimit < 0 {
+		return errors.New("PAGINATION_MAX_LIMIT must be positive")
+	}
+	if limits := c.Limits(); limits.Default > limits.Max {
+		return fmt.Errorf("PAGINATION_DEFAULT_LIMIT (%d) must not exceed PAGINATION_MAX_LIMIT (%d)", limits.Default, limits.Max)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/festy23/avito_internship/pkg/pagination"
+)
+
+func TestLoadPaginationConfigFromEnv(t *testing.T) {
+	t.Run("default values", func(t *testing.T) {
+		t.Setenv("PAGINATION_CURSOR_SECRET", "")
+		t.Setenv("PAGINATION_DEFAULT_LIMIT", "")
+		t.Setenv("PAGINATION_MAX_LIMIT", "")
+
+		cfg := LoadPaginationConfigFromEnv()
+		assert.Empty(t, cfg.CursorSecret)
+		assert.Equal(t, pagination.DefaultLimits(), cfg.Limits())
+		assert.NoError(t, cfg.Validate())
+	})
+
+	t.Run("custom values", func(t *testing.T) {
+		secret := strings.Repeat("s", 32)
+		t.Setenv("PAGINATION_CURSOR_SECRET", secret)
+		t.Setenv("PAGINATION_DEFAULT_LIMIT", "20")
+		t.Setenv("PAGINATION_MAX_LIMIT", "500")
+
+		cfg := LoadPaginationConfigFromEnv()
+		assert.Equal(t, secret, cfg.CursorSecret)
+		assert.Equal(t, pagination.Limits{Default: 20, Max: 500}, cfg.Limits())
+		assert.NoError(t, cfg.Validate())
+	})
+}
+
+func TestPaginationConfig_Validate(t *testing.T) {
+	t.Run("short secret", func(t *testing.T) {
+		cfg := PaginationConfig{CursorSecret: "short-secret", DefaultLimit: 50, MaxLimit: 100}
+		err := cfg.Validate()
+		assert.ErrorContains(t, err, "PAGINATION_CURSOR_SECRET")
+		assert.NotContains(t, err.Error(), "short-secret")
+	})
+
+	t.Run("limits", func(t *testing.T) {
+		assert.NoError(t, PaginationConfig{}.Validate(), "unset limits fall back to the defaults")
+		assert.ErrorContains(t, PaginationConfig{DefaultLimit: -1}.Validate(), "PAGINATION_DEFAULT_LIMIT")
+		assert.ErrorContains(t, PaginationConfig{MaxLimit: -1}.Validate(), "PAGINATION_MAX_LIMIT")
+		assert.ErrorContains(t, PaginationConfig{DefaultLimit: 101, MaxLimit: 100}.Validate(), "must not exceed")
+		assert.ErrorContains(t, PaginationConfig{MaxLimit: 20}.Validate(), "PAGINATION_DEFAULT_LIMIT (50)")
+	})
+}
@@ -0,0 +1,59 @@
+package config
+
+import "fmt"
+
+// PullRequestConfig holds optional business rules for pull requests.
+type PullRequestConfig struct {
+	// BlockMergeOnConflicts rejects merging pull requests flagged with merge conflicts.
+	BlockMergeOnConflicts bool
+	// RejectDuplicates rejects creating a PR with the same name as an open PR of the same author.
+	RejectDuplicates bool
+	// AsyncAssignment creates PRs in ASSIGNING status and assigns reviewers in background workers.
+	AsyncAssignment bool
+	// AssignmentWorkers is the number of background assignment workers.
+	AssignmentWorkers int
+	// AssignmentQueueSize is the capacity of the assignment queue.
+	AssignmentQueueSize int
+	// DeterministicAssignment picks reviewers in user_id order instead of random order (for tests).
+	DeterministicAssignment bool
+	// ReviewerMaxLoad is the review load at which a reviewer can no longer be assigned manually;
+	// 0 means no limit.
+	ReviewerMaxLoad int
+	// MinReviewers is the number of reviewers a PR must keep when a reviewer is unassigned;
+	// 0 means no minimum.
+	MinReviewers int
+}
+
+// LoadPullRequestConfigFromEnv loads pull request configuration from environment variables.
+func LoadPullRequestConfigFromEnv() PullRequestConfig {
+	return PullRequestConfig{
+		BlockMergeOnConflicts:   GetEnvBool("MERGE_BLOCK_ON_CONFLICTS", false),
+		RejectDuplicates:        GetEnvBool("PR_DUPLICATE_STRICT", false),
+		AsyncAssignment:         GetEnvBool("PR_ASYNC_ASSIGNMENT", false),
+		AssignmentWorkers:       GetEnvInt("PR_ASSIGNMENT_WORKERS", 4),
+		AssignmentQueueSize:     GetEnvInt("PR_ASSIGNMENT_QUEUE_SIZE", 1000),
+		DeterministicAssignment: GetEnvBool("ASSIGNMENT_DETERMINISTIC", false),
+		ReviewerMaxLoad:         GetEnvInt("REVIEWER_MAX_LOAD", 0),
+		MinReviewers:            GetEnvInt("PR_MIN_REVIEWERS", 0),
+	}
+}
+
+// Validate validates pull request configuration.
+func (c PullRequestConfig) Validate() error {
+	if c.ReviewerMaxLoad < 0 {
+		return fmt.Errorf("REVIEWER_MAX_LOAD must not be negative")
+	}
+	if c.MinReviewers < 0 {
+		return fmt.Errorf("PR_MIN_REVIEWERS must not be negative")
+	}
+	if !c.AsyncAssignment {
+		return nil
+	}
+	if c.AssignmentWorkers < 1 {
+		return fmt.Errorf("PR_ASSIGNMENT_WORKERS must be greater than 0")
+	}
+	if c.AssignmentQueueSize < 1 {
+		return fmt.Errorf("PR_ASSIGNMENT_QUEUE_SIZE must be greater than 0")
+	}
+	return nil
+}
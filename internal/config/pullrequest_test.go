@@ -0,0 +1,86 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadPullRequestConfigFromEnv(t *testing.T) {
+	t.Run("default values", func(t *testing.T) {
+		t.Setenv("MERGE_BLOCK_ON_CONFLICTS", "")
+		t.Setenv("PR_DUPLICATE_STRICT", "")
+		t.Setenv("PR_ASYNC_ASSIGNMENT", "")
+		t.Setenv("PR_ASSIGNMENT_WORKERS", "")
+		t.Setenv("PR_ASSIGNMENT_QUEUE_SIZE", "")
+		t.Setenv("ASSIGNMENT_DETERMINISTIC", "")
+		t.Setenv("REVIEWER_MAX_LOAD", "")
+		t.Setenv("PR_MIN_REVIEWERS", "")
+
+		cfg := LoadPullRequestConfigFromEnv()
+		assert.False(t, cfg.BlockMergeOnConflicts)
+		assert.False(t, cfg.RejectDuplicates)
+		assert.False(t, cfg.AsyncAssignment)
+		assert.Equal(t, 4, cfg.AssignmentWorkers)
+		assert.Equal(t, 1000, cfg.AssignmentQueueSize)
+		assert.False(t, cfg.DeterministicAssignment)
+		assert.Zero(t, cfg.ReviewerMaxLoad)
+		assert.Zero(t, cfg.MinReviewers)
+	})
+
+	t.Run("custom values", func(t *testing.T) {
+		t.Setenv("MERGE_BLOCK_ON_CONFLICTS", "true")
+		t.Setenv("PR_DUPLICATE_STRICT", "true")
+		t.Setenv("PR_ASYNC_ASSIGNMENT", "true")
+		t.Setenv("PR_ASSIGNMENT_WORKERS", "8")
+		t.Setenv("PR_ASSIGNMENT_QUEUE_SIZE", "50")
+		t.Setenv("ASSIGNMENT_DETERMINISTIC", "true")
+		t.Setenv("REVIEWER_MAX_LOAD", "10")
+		t.Setenv("PR_MIN_REVIEWERS", "1")
+
+		cfg := LoadPullRequestConfigFromEnv()
+		assert.True(t, cfg.BlockMergeOnConflicts)
+		assert.True(t, cfg.RejectDuplicates)
+		assert.True(t, cfg.AsyncAssignment)
+		assert.Equal(t, 8, cfg.AssignmentWorkers)
+		assert.Equal(t, 50, cfg.AssignmentQueueSize)
+		assert.True(t, cfg.DeterministicAssignment)
+		assert.Equal(t, 10, cfg.ReviewerMaxLoad)
+		assert.Equal(t, 1, cfg.MinReviewers)
+	})
+}
+
+func TestPullRequestConfig_Validate(t *testing.T) {
+	t.Run("synchronous assignment", func(t *testing.T) {
+		assert.NoError(t, PullRequestConfig{}.Validate())
+	})
+
+	t.Run("valid async config", func(t *testing.T) {
+		cfg := PullRequestConfig{AsyncAssignment: true, AssignmentWorkers: 2, AssignmentQueueSize: 10}
+		assert.NoError(t, cfg.Validate())
+	})
+
+	t.Run("no workers", func(t *testing.T) {
+		err := PullRequestConfig{AsyncAssignment: true, AssignmentQueueSize: 10}.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "PR_ASSIGNMENT_WORKERS")
+	})
+
+	t.Run("negative reviewer load limit", func(t *testing.T) {
+		err := PullRequestConfig{ReviewerMaxLoad: -1}.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "REVIEWER_MAX_LOAD")
+	})
+
+	t.Run("negative minimum reviewers", func(t *testing.T) {
+		err := PullRequestConfig{MinReviewers: -1}.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "PR_MIN_REVIEWERS")
+	})
+
+	t.Run("no queue capacity", func(t *testing.T) {
+		err := PullRequestConfig{AsyncAssignment: true, AssignmentWorkers: 2}.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "PR_ASSIGNMENT_QUEUE_SIZE")
+	})
+}
@@ -0,0 +1,41 @@
+package config
+
+import "fmt"
+
+// RebalanceConfig holds configuration for the reviewer rebalancing job.
+type RebalanceConfig struct {
+	// Threshold is the number of open reviews above which a reviewer is considered overloaded.
+	Threshold int
+	// DryRun only reports proposed reassignments without applying them.
+	DryRun bool
+	// Job holds scheduling settings of the rebalancing job.
+	Job JobConfig
+}
+
+// LoadRebalanceConfigFromEnv loads rebalancing job configuration from environment variables.
+func LoadRebalanceConfigFromEnv() RebalanceConfig {
+	return RebalanceConfig{
+		Threshold: GetEnvInt("REBALANCE_THRESHOLD", 5),
+		DryRun:    GetEnvBool("REBALANCE_DRY_RUN", true),
+		Job: LoadJobConfigFromEnv("REBALANCE", JobConfig{
+			Enabled:  false,
+			Schedule: "@daily",
+		}),
+	}
+}
+
+// Enabled reports whether the rebalancing job should run.
+func (c RebalanceConfig) Enabled() bool {
+	return c.Job.Enabled
+}
+
+// Validate validates rebalancing job configuration.
+func (c RebalanceConfig) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if c.Threshold < 1 {
+		return fmt.Errorf("REBALANCE_THRESHOLD must be greater than 0")
+	}
+	return c.Job.Validate("REBALANCE")
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadRebalanceConfigFromEnv(t *testing.T) {
+	t.Run("default values", func(t *testing.T) {
+		t.Setenv("REBALANCE_THRESHOLD", "")
+		t.Setenv("REBALANCE_DRY_RUN", "")
+		t.Setenv("REBALANCE_ENABLED", "")
+		t.Setenv("REBALANCE_SCHEDULE", "")
+
+		cfg := LoadRebalanceConfigFromEnv()
+		assert.Equal(t, 5, cfg.Threshold)
+		assert.True(t, cfg.DryRun)
+		assert.Equal(t, "@daily", cfg.Job.Schedule)
+		assert.False(t, cfg.Enabled())
+	})
+
+	t.Run("custom values", func(t *testing.T) {
+		t.Setenv("REBALANCE_THRESHOLD", "3")
+		t.Setenv("REBALANCE_DRY_RUN", "false")
+		t.Setenv("REBALANCE_ENABLED", "true")
+		t.Setenv("REBALANCE_SCHEDULE", "0 6 * * 1-5")
+
+		cfg := LoadRebalanceConfigFromEnv()
+		assert.Equal(t, 3, cfg.Threshold)
+		assert.False(t, cfg.DryRun)
+		assert.Equal(t, "0 6 * * 1-5", cfg.Job.Schedule)
+		assert.True(t, cfg.Enabled())
+	})
+}
+
+func TestRebalanceConfig_Validate(t *testing.T) {
+	enabledJob := JobConfig{Enabled: true, Schedule: "@daily"}
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.NoError(t, RebalanceConfig{}.Validate())
+	})
+
+	t.Run("valid enabled config", func(t *testing.T) {
+		assert.NoError(t, RebalanceConfig{Threshold: 5, Job: enabledJob}.Validate())
+	})
+
+	t.Run("non-positive threshold", func(t *testing.T) {
+		err := RebalanceConfig{Job: enabledJob}.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "REBALANCE_THRESHOLD")
+	})
+
+	t.Run("invalid schedule", func(t *testing.T) {
+		err := RebalanceConfig{Threshold: 5, Job: JobConfig{Enabled: true, Schedule: "daily"}}.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "REBALANCE_SCHEDULE")
+	})
+}
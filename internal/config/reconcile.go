@@ -0,0 +1,22 @@
+package config
+
+// ReconcileConfig holds configuration for the startup data reconciliation.
+type ReconcileConfig struct {
+	// OnStartup checks reviewer assignments for inconsistencies before the server starts.
+	OnStartup bool
+	// Repair removes inconsistent reviewer rows; otherwise they are only reported.
+	Repair bool
+}
+
+// LoadReconcileConfigFromEnv loads reconciliation configuration from environment variables.
+func LoadReconcileConfigFromEnv() ReconcileConfig {
+	return ReconcileConfig{
+		OnStartup: GetEnvBool("RECONCILE_ON_STARTUP", false),
+		Repair:    GetEnvBool("RECONCILE_REPAIR", false),
+	}
+}
+
+// Enabled reports whether reconciliation should run on startup.
+func (c ReconcileConfig) Enabled() bool {
+	return c.OnStartup
+}
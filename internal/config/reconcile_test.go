@@ -0,0 +1,27 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadReconcileConfigFromEnv(t *testing.T) {
+	t.Run("default values", func(t *testing.T) {
+		t.Setenv("RECONCILE_ON_STARTUP", "")
+		t.Setenv("RECONCILE_REPAIR", "")
+
+		cfg := LoadReconcileConfigFromEnv()
+		assert.False(t, cfg.Enabled())
+		assert.False(t, cfg.Repair)
+	})
+
+	t.Run("custom values", func(t *testing.T) {
+		t.Setenv("RECONCILE_ON_STARTUP", "true")
+		t.Setenv("RECONCILE_REPAIR", "true")
+
+		cfg := LoadReconcileConfigFromEnv()
+		assert.True(t, cfg.Enabled())
+		assert.True(t, cfg.Repair)
+	})
+}
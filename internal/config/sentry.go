@@ -0,0 +1,46 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/festy23/avito_internship/internal/sentry"
+)
+
+// SentryConfig holds configuration of panic reporting to Sentry.
+type SentryConfig struct {
+	// DSN is the Sentry project DSN; empty disables reporting.
+	DSN string
+	// Environment tags reported events, e.g. "production".
+	Environment string
+	// Timeout is the timeout of a single report request.
+	Timeout time.Duration
+}
+
+// LoadSentryConfigFromEnv loads Sentry configuration from environment variables.
+func LoadSentryConfigFromEnv() SentryConfig {
+	return SentryConfig{
+		DSN:         GetEnv("SENTRY_DSN", ""),
+		Environment: GetEnv("SENTRY_ENVIRONMENT", ""),
+		Timeout:     GetEnvDuration("SENTRY_TIMEOUT", 5*time.Second),
+	}
+}
+
+// Enabled reports whether panics should be reported to Sentry.
+func (c SentryConfig) Enabled() bool {
+	return c.DSN != ""
+}
+
+// Validate validates Sentry configuration. Errors never include the DSN, which contains a key.
+func (c SentryConfig) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if _, err := sentry.ParseDSN(c.DSN); err != nil {
+		return fmt.Errorf("SENTRY_DSN: %w", err)
+	}
+	if c.Timeout <= 0 {
+		return fmt.Errorf("SENTRY_TIMEOUT must be positive")
+	}
+	return nil
+}
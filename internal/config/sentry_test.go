@@ -0,0 +1,47 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadSentryConfigFromEnv(t *testing.T) {
+	t.Run("default values", func(t *testing.T) {
+		t.Setenv("SENTRY_DSN", "")
+		t.Setenv("SENTRY_ENVIRONMENT", "")
+		t.Setenv("SENTRY_TIMEOUT", "")
+
+		cfg := LoadSentryConfigFromEnv()
+		assert.False(t, cfg.Enabled())
+		assert.Equal(t, 5*time.Second, cfg.Timeout)
+		assert.NoError(t, cfg.Validate())
+	})
+
+	t.Run("custom values", func(t *testing.T) {
+		t.Setenv("SENTRY_DSN", "https://key@o1.ingest.sentry.io/42")
+		t.Setenv("SENTRY_ENVIRONMENT", "production")
+		t.Setenv("SENTRY_TIMEOUT", "2s")
+
+		cfg := LoadSentryConfigFromEnv()
+		assert.True(t, cfg.Enabled())
+		assert.Equal(t, "production", cfg.Environment)
+		assert.Equal(t, 2*time.Second, cfg.Timeout)
+		assert.NoError(t, cfg.Validate())
+	})
+}
+
+func TestSentryConfig_Validate(t *testing.T) {
+	t.Run("invalid DSN", func(t *testing.T) {
+		cfg := SentryConfig{DSN: "https://secret@sentry.io/", Timeout: time.Second}
+		err := cfg.Validate()
+		assert.ErrorContains(t, err, "SENTRY_DSN")
+		assert.NotContains(t, err.Error(), "secret")
+	})
+
+	t.Run("non-positive timeout", func(t *testing.T) {
+		cfg := SentryConfig{DSN: "https://key@sentry.io/1"}
+		assert.ErrorContains(t, cfg.Validate(), "SENTRY_TIMEOUT")
+	})
+}
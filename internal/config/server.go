@@ -5,6 +5,9 @@ import (
 	"net"
 	"strings"
 	"time"
+
+	"github.com/festy23/avito_internship/internal/middleware"
+	"github.com/festy23/avito_internship/pkg/apierror"
 )
 
 // ServerConfig holds HTTP server configuration.
@@ -13,22 +16,44 @@ type ServerConfig struct {
 	Host string
 	// Port is the server port (e.g., ":8080" or "8080").
 	Port string
+	// Listen is an additional listener served alongside the TCP one: "unix:///path/to/socket"
+	// for a Unix domain socket. Empty means TCP only.
+	Listen string
 	// ReadTimeout is the maximum duration for reading the entire request.
 	ReadTimeout time.Duration
 	// WriteTimeout is the maximum duration before timing out writes.
 	WriteTimeout time.Duration
 	// IdleTimeout is the maximum amount of time to wait for the next request.
 	IdleTimeout time.Duration
+	// ShutdownDelay is how long the server keeps serving after a termination signal, so load
+	// balancers stop routing new requests to it before the listener is closed.
+	ShutdownDelay time.Duration
+	// ShutdownTimeout is the maximum duration to wait for in-flight requests on shutdown.
+	ShutdownTimeout time.Duration
+	// ErrorFormat is the format of error bodies: "default" (OpenAPI spec) or "problem" (RFC 7807).
+	ErrorFormat string
+	// JSONNaming is the default naming of JSON fields: "snake_case" (OpenAPI spec) or "camelCase".
+	// Clients can request either with the profile parameter of the Accept header.
+	JSONNaming string
+	// HandlerTimeouts sets time budgets of requests by route in the middleware.ParseTimeoutRules
+	// format; empty leaves requests without a budget.
+	HandlerTimeouts string
 }
 
 // LoadServerConfigFromEnv loads server configuration from environment variables.
 func LoadServerConfigFromEnv() ServerConfig {
 	return ServerConfig{
-		Host:         GetEnv("SERVER_HOST", ""),
-		Port:         GetEnv("SERVER_PORT", ":8080"),
-		ReadTimeout:  GetEnvDuration("SERVER_READ_TIMEOUT", 10*time.Second),
-		WriteTimeout: GetEnvDuration("SERVER_WRITE_TIMEOUT", 10*time.Second),
-		IdleTimeout:  GetEnvDuration("SERVER_IDLE_TIMEOUT", 120*time.Second),
+		Host:            GetEnv("SERVER_HOST", ""),
+		Port:            GetEnv("SERVER_PORT", ":8080"),
+		Listen:          GetEnv("SERVER_LISTEN", ""),
+		ReadTimeout:     GetEnvDuration("SERVER_READ_TIMEOUT", 10*time.Second),
+		WriteTimeout:    GetEnvDuration("SERVER_WRITE_TIMEOUT", 10*time.Second),
+		IdleTimeout:     GetEnvDuration("SERVER_IDLE_TIMEOUT", 120*time.Second),
+		ShutdownDelay:   GetEnvDuration("SERVER_SHUTDOWN_DELAY", 0),
+		ShutdownTimeout: GetEnvDuration("SERVER_SHUTDOWN_TIMEOUT", 5*time.Second),
+		ErrorFormat:     GetEnv("ERROR_FORMAT", string(apierror.FormatDefault)),
+		JSONNaming:      GetEnv("JSON_NAMING", string(middleware.NamingSnakeCase)),
+		HandlerTimeouts: GetEnv("SERVER_HANDLER_TIMEOUTS", ""),
 	}
 }
 
@@ -48,6 +73,14 @@ func (c ServerConfig) GetAddress() string {
 	return net.JoinHostPort(c.Host, port)
 }
 
+// unixScheme prefixes Unix domain socket addresses in Listen.
+const unixScheme = "unix://"
+
+// SocketPath returns the path of the Unix domain socket to listen on, or "" if Listen is not set.
+func (c ServerConfig) SocketPath() string {
+	return strings.TrimPrefix(c.Listen, unixScheme)
+}
+
 // Validate validates server configuration.
 func (c ServerConfig) Validate() error {
 	if c.ReadTimeout <= 0 {
@@ -59,5 +92,25 @@ func (c ServerConfig) Validate() error {
 	if c.IdleTimeout <= 0 {
 		return fmt.Errorf("IdleTimeout must be greater than 0")
 	}
+	if c.ShutdownDelay < 0 {
+		return fmt.Errorf("ShutdownDelay must not be negative")
+	}
+	if c.ShutdownTimeout <= 0 {
+		return fmt.Errorf("ShutdownTimeout must be greater than 0")
+	}
+	if c.Listen != "" && (!strings.HasPrefix(c.Listen, unixScheme) || c.SocketPath() == "") {
+		return fmt.Errorf("SERVER_LISTEN must be unix:///path/to/socket, got %q", c.Listen)
+	}
+	if _, err := apierror.ParseFormat(c.ErrorFormat); err != nil {
+		return fmt.Errorf("ERROR_FORMAT: %w", err)
+	}
+	if _, err := middleware.ParseNaming(c.JSONNaming); err != nil {
+		return fmt.Errorf("JSON_NAMING: %w", err)
+	}
+	if c.HandlerTimeouts != "" {
+		if _, err := middleware.ParseTimeoutRules(c.HandlerTimeouts); err != nil {
+			return fmt.Errorf("SERVER_HANDLER_TIMEOUTS: %w", err)
+		}
+	}
 	return nil
 }
@@ -15,9 +15,15 @@ func setupAndRestoreServerEnv(t *testing.T, envVars map[string]string) func() {
 	envKeys := []string{
 		"SERVER_HOST",
 		"SERVER_PORT",
+		"SERVER_LISTEN",
 		"SERVER_READ_TIMEOUT",
 		"SERVER_WRITE_TIMEOUT",
 		"SERVER_IDLE_TIMEOUT",
+		"SERVER_SHUTDOWN_DELAY",
+		"SERVER_SHUTDOWN_TIMEOUT",
+		"ERROR_FORMAT",
+		"JSON_NAMING",
+		"SERVER_HANDLER_TIMEOUTS",
 	}
 	for _, key := range envKeys {
 		originalEnv[key] = os.Getenv(key)
@@ -45,27 +51,46 @@ func TestLoadServerConfigFromEnv_DefaultValues(t *testing.T) {
 	cfg := LoadServerConfigFromEnv()
 	assert.Equal(t, "", cfg.Host)
 	assert.Equal(t, ":8080", cfg.Port)
+	assert.Equal(t, "", cfg.Listen)
 	assert.Equal(t, 10*time.Second, cfg.ReadTimeout)
 	assert.Equal(t, 10*time.Second, cfg.WriteTimeout)
 	assert.Equal(t, 120*time.Second, cfg.IdleTimeout)
+	assert.Equal(t, time.Duration(0), cfg.ShutdownDelay)
+	assert.Equal(t, 5*time.Second, cfg.ShutdownTimeout)
+	assert.Equal(t, "default", cfg.ErrorFormat)
+	assert.Equal(t, "snake_case", cfg.JSONNaming)
+	assert.Equal(t, "", cfg.HandlerTimeouts)
 }
 
 func TestLoadServerConfigFromEnv_CustomValues(t *testing.T) {
 	restore := setupAndRestoreServerEnv(t, map[string]string{
-		"SERVER_HOST":          "0.0.0.0",
-		"SERVER_PORT":          "9090",
-		"SERVER_READ_TIMEOUT":  "30s",
-		"SERVER_WRITE_TIMEOUT": "30s",
-		"SERVER_IDLE_TIMEOUT":  "300s",
+		"SERVER_HOST":             "0.0.0.0",
+		"SERVER_PORT":             "9090",
+		"SERVER_LISTEN":           "unix:///run/app/app.sock",
+		"SERVER_READ_TIMEOUT":     "30s",
+		"SERVER_WRITE_TIMEOUT":    "30s",
+		"SERVER_IDLE_TIMEOUT":     "300s",
+		"SERVER_SHUTDOWN_DELAY":   "15s",
+		"SERVER_SHUTDOWN_TIMEOUT": "30s",
+		"ERROR_FORMAT":            "problem",
+		"JSON_NAMING":             "camelCase",
+		"SERVER_HANDLER_TIMEOUTS": "GET * 2s",
 	})
 	defer restore()
 
 	cfg := LoadServerConfigFromEnv()
 	assert.Equal(t, "0.0.0.0", cfg.Host)
 	assert.Equal(t, "9090", cfg.Port)
+	assert.Equal(t, "unix:///run/app/app.sock", cfg.Listen)
+	assert.Equal(t, "/run/app/app.sock", cfg.SocketPath())
 	assert.Equal(t, 30*time.Second, cfg.ReadTimeout)
 	assert.Equal(t, 30*time.Second, cfg.WriteTimeout)
 	assert.Equal(t, 300*time.Second, cfg.IdleTimeout)
+	assert.Equal(t, 15*time.Second, cfg.ShutdownDelay)
+	assert.Equal(t, 30*time.Second, cfg.ShutdownTimeout)
+	assert.Equal(t, "problem", cfg.ErrorFormat)
+	assert.Equal(t, "camelCase", cfg.JSONNaming)
+	assert.Equal(t, "GET * 2s", cfg.HandlerTimeouts)
 }
 
 func TestServerConfig_GetAddress(t *testing.T) {
@@ -127,9 +152,10 @@ func TestServerConfig_GetAddress(t *testing.T) {
 func TestServerConfig_Validate(t *testing.T) {
 	t.Run("valid config", func(t *testing.T) {
 		cfg := ServerConfig{
-			ReadTimeout:  10 * time.Second,
-			WriteTimeout: 10 * time.Second,
-			IdleTimeout:  120 * time.Second,
+			ReadTimeout:     10 * time.Second,
+			WriteTimeout:    10 * time.Second,
+			IdleTimeout:     120 * time.Second,
+			ShutdownTimeout: 5 * time.Second,
 		}
 		err := cfg.Validate()
 		assert.NoError(t, err)
@@ -137,9 +163,10 @@ func TestServerConfig_Validate(t *testing.T) {
 
 	t.Run("invalid read timeout", func(t *testing.T) {
 		cfg := ServerConfig{
-			ReadTimeout:  0,
-			WriteTimeout: 10 * time.Second,
-			IdleTimeout:  120 * time.Second,
+			ReadTimeout:     0,
+			WriteTimeout:    10 * time.Second,
+			IdleTimeout:     120 * time.Second,
+			ShutdownTimeout: 5 * time.Second,
 		}
 		err := cfg.Validate()
 		assert.Error(t, err)
@@ -148,9 +175,10 @@ func TestServerConfig_Validate(t *testing.T) {
 
 	t.Run("invalid write timeout", func(t *testing.T) {
 		cfg := ServerConfig{
-			ReadTimeout:  10 * time.Second,
-			WriteTimeout: -1 * time.Second,
-			IdleTimeout:  120 * time.Second,
+			ReadTimeout:     10 * time.Second,
+			WriteTimeout:    -1 * time.Second,
+			IdleTimeout:     120 * time.Second,
+			ShutdownTimeout: 5 * time.Second,
 		}
 		err := cfg.Validate()
 		assert.Error(t, err)
@@ -158,13 +186,89 @@ func TestServerConfig_Validate(t *testing.T) {
 	})
 
 	t.Run("invalid idle timeout", func(t *testing.T) {
+		cfg := ServerConfig{
+			ReadTimeout:     10 * time.Second,
+			WriteTimeout:    10 * time.Second,
+			IdleTimeout:     0,
+			ShutdownTimeout: 5 * time.Second,
+		}
+		err := cfg.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "IdleTimeout")
+	})
+	t.Run("negative shutdown delay", func(t *testing.T) {
+		cfg := ServerConfig{
+			ReadTimeout:     10 * time.Second,
+			WriteTimeout:    10 * time.Second,
+			IdleTimeout:     120 * time.Second,
+			ShutdownDelay:   -1 * time.Second,
+			ShutdownTimeout: 5 * time.Second,
+		}
+		err := cfg.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "ShutdownDelay")
+	})
+
+	t.Run("invalid shutdown timeout", func(t *testing.T) {
 		cfg := ServerConfig{
 			ReadTimeout:  10 * time.Second,
 			WriteTimeout: 10 * time.Second,
-			IdleTimeout:  0,
+			IdleTimeout:  120 * time.Second,
 		}
 		err := cfg.Validate()
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "IdleTimeout")
+		assert.Contains(t, err.Error(), "ShutdownTimeout")
+	})
+
+	t.Run("invalid listen address", func(t *testing.T) {
+		for _, listen := range []string{"tcp://:8081", "/run/app.sock", "unix://"} {
+			cfg := ServerConfig{
+				ReadTimeout:     10 * time.Second,
+				WriteTimeout:    10 * time.Second,
+				IdleTimeout:     120 * time.Second,
+				ShutdownTimeout: 5 * time.Second,
+				Listen:          listen,
+			}
+			err := cfg.Validate()
+			assert.Error(t, err, listen)
+			assert.Contains(t, err.Error(), "SERVER_LISTEN")
+		}
+	})
+
+	t.Run("invalid error format", func(t *testing.T) {
+		cfg := ServerConfig{
+			ReadTimeout:     10 * time.Second,
+			WriteTimeout:    10 * time.Second,
+			IdleTimeout:     120 * time.Second,
+			ShutdownTimeout: 5 * time.Second,
+			ErrorFormat:     "xml",
+		}
+		err := cfg.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "ERROR_FORMAT")
+	})
+	t.Run("invalid JSON naming", func(t *testing.T) {
+		cfg := ServerConfig{
+			ReadTimeout:     10 * time.Second,
+			WriteTimeout:    10 * time.Second,
+			IdleTimeout:     120 * time.Second,
+			ShutdownTimeout: 5 * time.Second,
+			JSONNaming:      "kebab-case",
+		}
+		err := cfg.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "JSON_NAMING")
+	})
+	t.Run("invalid handler timeouts", func(t *testing.T) {
+		cfg := ServerConfig{
+			ReadTimeout:     10 * time.Second,
+			WriteTimeout:    10 * time.Second,
+			IdleTimeout:     120 * time.Second,
+			ShutdownTimeout: 5 * time.Second,
+			HandlerTimeouts: "GET * soon",
+		}
+		err := cfg.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "SERVER_HANDLER_TIMEOUTS")
 	})
 }
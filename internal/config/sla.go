@@ -0,0 +1,27 @@
+package config
+
+// SLAConfig holds configuration for the job checking team review SLAs.
+type SLAConfig struct {
+	// Job holds scheduling settings of the SLA job.
+	Job JobConfig
+}
+
+// LoadSLAConfigFromEnv loads SLA job configuration from environment variables.
+func LoadSLAConfigFromEnv() SLAConfig {
+	return SLAConfig{
+		Job: LoadJobConfigFromEnv("SLA", JobConfig{
+			Enabled:  false,
+			Schedule: "@every 15m",
+		}),
+	}
+}
+
+// Enabled reports whether the SLA job should run.
+func (c SLAConfig) Enabled() bool {
+	return c.Job.Enabled
+}
+
+// Validate validates SLA job configuration.
+func (c SLAConfig) Validate() error {
+	return c.Job.Validate("SLA")
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadSLAConfigFromEnv(t *testing.T) {
+	t.Run("default values", func(t *testing.T) {
+		t.Setenv("SLA_ENABLED", "")
+		t.Setenv("SLA_SCHEDULE", "")
+
+		cfg := LoadSLAConfigFromEnv()
+		assert.Equal(t, "@every 15m", cfg.Job.Schedule)
+		assert.False(t, cfg.Enabled())
+	})
+
+	t.Run("custom values", func(t *testing.T) {
+		t.Setenv("SLA_ENABLED", "true")
+		t.Setenv("SLA_SCHEDULE", "@hourly")
+
+		cfg := LoadSLAConfigFromEnv()
+		assert.Equal(t, "@hourly", cfg.Job.Schedule)
+		assert.True(t, cfg.Enabled())
+	})
+}
+
+func TestSLAConfig_Validate(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		assert.NoError(t, SLAConfig{}.Validate())
+	})
+
+	t.Run("valid enabled config", func(t *testing.T) {
+		assert.NoError(t, SLAConfig{Job: JobConfig{Enabled: true, Schedule: "@every 15m"}}.Validate())
+	})
+
+	t.Run("invalid schedule", func(t *testing.T) {
+		err := SLAConfig{Job: JobConfig{Enabled: true, Schedule: "often"}}.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "SLA_SCHEDULE")
+	})
+}
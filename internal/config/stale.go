@@ -0,0 +1,44 @@
+package config
+
+import "fmt"
+
+// MaxStaleCloseAfterDays is the longest inactivity period that can be configured, ten years.
+const MaxStaleCloseAfterDays = 3650
+
+// StaleConfig holds configuration for the job closing inactive pull requests.
+type StaleConfig struct {
+	// CloseAfterDays is the number of days without activity after which an open PR is closed
+	// (0 disables the job).
+	CloseAfterDays int
+	// Job holds scheduling settings of the stale pull request job.
+	Job JobConfig
+}
+
+// LoadStaleConfigFromEnv loads stale pull request job configuration from environment variables.
+func LoadStaleConfigFromEnv() StaleConfig {
+	closeAfterDays := GetEnvInt("STALE_CLOSE_AFTER_DAYS", 0)
+
+	return StaleConfig{
+		CloseAfterDays: closeAfterDays,
+		Job: LoadJobConfigFromEnv("STALE", JobConfig{
+			Enabled:  closeAfterDays > 0,
+			Schedule: "@every 1h",
+		}),
+	}
+}
+
+// Enabled reports whether the stale pull request job should run.
+func (c StaleConfig) Enabled() bool {
+	return c.Job.Enabled && c.CloseAfterDays > 0
+}
+
+// Validate validates stale pull request job configuration.
+func (c StaleConfig) Validate() error {
+	if c.CloseAfterDays < 0 || c.CloseAfterDays > MaxStaleCloseAfterDays {
+		return fmt.Errorf("STALE_CLOSE_AFTER_DAYS must be between 0 and %d", MaxStaleCloseAfterDays)
+	}
+	if !c.Enabled() {
+		return nil
+	}
+	return c.Job.Validate("STALE")
+}
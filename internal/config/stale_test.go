@@ -0,0 +1,64 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadStaleConfigFromEnv(t *testing.T) {
+	t.Run("default values", func(t *testing.T) {
+		t.Setenv("STALE_CLOSE_AFTER_DAYS", "")
+		t.Setenv("STALE_ENABLED", "")
+		t.Setenv("STALE_SCHEDULE", "")
+
+		cfg := LoadStaleConfigFromEnv()
+		assert.Equal(t, 0, cfg.CloseAfterDays)
+		assert.Equal(t, "@every 1h", cfg.Job.Schedule)
+		assert.False(t, cfg.Enabled())
+	})
+
+	t.Run("custom values", func(t *testing.T) {
+		t.Setenv("STALE_CLOSE_AFTER_DAYS", "30")
+		t.Setenv("STALE_ENABLED", "")
+		t.Setenv("STALE_SCHEDULE", "0 4 * * *")
+
+		cfg := LoadStaleConfigFromEnv()
+		assert.Equal(t, 30, cfg.CloseAfterDays)
+		assert.Equal(t, "0 4 * * *", cfg.Job.Schedule)
+		assert.True(t, cfg.Enabled())
+	})
+
+	t.Run("explicitly disabled", func(t *testing.T) {
+		t.Setenv("STALE_CLOSE_AFTER_DAYS", "30")
+		t.Setenv("STALE_ENABLED", "false")
+
+		cfg := LoadStaleConfigFromEnv()
+		assert.False(t, cfg.Enabled())
+	})
+}
+
+func TestStaleConfig_Validate(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		assert.NoError(t, StaleConfig{}.Validate())
+	})
+
+	t.Run("valid enabled config", func(t *testing.T) {
+		cfg := StaleConfig{CloseAfterDays: 30, Job: JobConfig{Enabled: true, Schedule: "@every 1h"}}
+		assert.NoError(t, cfg.Validate())
+	})
+
+	t.Run("days out of range", func(t *testing.T) {
+		for _, days := range []int{-1, MaxStaleCloseAfterDays + 1} {
+			err := StaleConfig{CloseAfterDays: days}.Validate()
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "STALE_CLOSE_AFTER_DAYS")
+		}
+	})
+
+	t.Run("invalid schedule", func(t *testing.T) {
+		err := StaleConfig{CloseAfterDays: 30, Job: JobConfig{Enabled: true, Schedule: "often"}}.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "STALE_SCHEDULE")
+	})
+}
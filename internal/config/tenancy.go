@@ -0,0 +1,44 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/festy23/avito_internship/internal/tenant"
+)
+
+// TenancyConfig holds multi-tenancy configuration.
+type TenancyConfig struct {
+	// Mode resolves the tenant of requests: "" (single tenant), "header" (X-Tenant-ID header)
+	// or "api_key" (X-API-Key header mapped by APIKeys).
+	Mode string
+	// APIKeys is a comma-separated list of "<api key>=<tenant ID>" pairs used in api_key mode.
+	APIKeys string
+}
+
+// LoadTenancyConfigFromEnv loads multi-tenancy configuration from environment variables.
+func LoadTenancyConfigFromEnv() TenancyConfig {
+	return TenancyConfig{
+		Mode:    GetEnv("TENANT_MODE", ""),
+		APIKeys: GetEnv("TENANT_API_KEYS", ""),
+	}
+}
+
+// Enabled reports whether requests are isolated by tenant.
+func (c TenancyConfig) Enabled() bool {
+	return c.Mode != ""
+}
+
+// Validate validates multi-tenancy configuration. Errors never include API keys.
+func (c TenancyConfig) Validate() error {
+	switch c.Mode {
+	case "", tenant.ModeHeader:
+		return nil
+	case tenant.ModeAPIKey:
+		if _, err := tenant.ParseAPIKeys(c.APIKeys); err != nil {
+			return fmt.Errorf("TENANT_API_KEYS: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid TENANT_MODE: %s (must be: %s, %s)", c.Mode, tenant.ModeHeader, tenant.ModeAPIKey)
+	}
+}
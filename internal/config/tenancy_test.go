@@ -0,0 +1,48 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadTenancyConfigFromEnv(t *testing.T) {
+	t.Run("default values", func(t *testing.T) {
+		t.Setenv("TENANT_MODE", "")
+		t.Setenv("TENANT_API_KEYS", "")
+
+		cfg := LoadTenancyConfigFromEnv()
+		assert.False(t, cfg.Enabled())
+		assert.NoError(t, cfg.Validate())
+	})
+
+	t.Run("custom values", func(t *testing.T) {
+		t.Setenv("TENANT_MODE", "api_key")
+		t.Setenv("TENANT_API_KEYS", "key-a=acme,key-b=globex")
+
+		cfg := LoadTenancyConfigFromEnv()
+		assert.True(t, cfg.Enabled())
+		assert.Equal(t, "key-a=acme,key-b=globex", cfg.APIKeys)
+		assert.NoError(t, cfg.Validate())
+	})
+}
+
+func TestTenancyConfig_Validate(t *testing.T) {
+	t.Run("header mode", func(t *testing.T) {
+		assert.NoError(t, TenancyConfig{Mode: "header"}.Validate())
+	})
+
+	t.Run("unknown mode", func(t *testing.T) {
+		assert.ErrorContains(t, TenancyConfig{Mode: "cookie"}.Validate(), "TENANT_MODE")
+	})
+
+	t.Run("api_key mode without keys", func(t *testing.T) {
+		assert.ErrorContains(t, TenancyConfig{Mode: "api_key"}.Validate(), "TENANT_API_KEYS")
+	})
+
+	t.Run("invalid tenant ID", func(t *testing.T) {
+		err := TenancyConfig{Mode: "api_key", APIKeys: "secret=Acme"}.Validate()
+		assert.ErrorContains(t, err, "TENANT_API_KEYS")
+		assert.NotContains(t, err.Error(), "secret")
+	})
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// Defaults of waiting for changes of the assigned PRs; they match those of the user service.
+const (
+	defaultReviewWaitTimeout  = 8 * time.Second
+	defaultReviewWaitInterval = time.Second
+)
+
+// UserConfig holds configuration of the user endpoints.
+type UserConfig struct {
+	// ReviewWaitTimeout is how long GET /users/getReview/wait holds a request without changes;
+	// zero means the default. It must stay below the server write timeout.
+	ReviewWaitTimeout time.Duration
+	// ReviewWaitInterval is how often a waiting request checks the assigned PRs; zero means the default.
+	ReviewWaitInterval time.Duration
+}
+
+// LoadUserConfigFromEnv loads user endpoint configuration from environment variables.
+func LoadUserConfigFromEnv() UserConfig {
+	return UserConfig{
+		ReviewWaitTimeout:  GetEnvDuration("USER_REVIEW_WAIT_TIMEOUT", defaultReviewWaitTimeout),
+		ReviewWaitInterval: GetEnvDuration("USER_REVIEW_WAIT_INTERVAL", defaultReviewWaitInterval),
+	}
+}
+
+// EffectiveReviewWaitTimeout returns how long a waiting request is held: ReviewWaitTimeout or, when it is
+// zero, the default.
+func (c UserConfig) EffectiveReviewWaitTimeout() time.Duration {
+	if c.ReviewWaitTimeout == 0 {
+		return defaultReviewWaitTimeout
+	}
+	return c.ReviewWaitTimeout
+}
+
+// Validate validates user endpoint configuration.
+func (c UserConfig) Validate() error {
+	if c.ReviewWaitTimeout < 0 {
+		return fmt.Errorf("USER_REVIEW_WAIT_TIMEOUT must not be negative")
+	}
+	if c.ReviewWaitInterval < 0 {
+		return fmt.Errorf("USER_REVIEW_WAIT_INTERVAL must not be negative")
+	}
+	if c.ReviewWaitInterval > c.EffectiveReviewWaitTimeout() {
+		return fmt.Errorf("USER_REVIEW_WAIT_INTERVAL must not exceed USER_REVIEW_WAIT_TIMEOUT")
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadUserConfigFromEnv(t *testing.T) {
+	t.Run("default values", func(t *testing.T) {
+		t.Setenv("USER_REVIEW_WAIT_TIMEOUT", "")
+		t.Setenv("USER_REVIEW_WAIT_INTERVAL", "")
+
+		cfg := LoadUserConfigFromEnv()
+		assert.Equal(t, 8*time.Second, cfg.ReviewWaitTimeout)
+		assert.Equal(t, time.Second, cfg.ReviewWaitInterval)
+		assert.NoError(t, cfg.Validate())
+	})
+
+	t.Run("custom values", func(t *testing.T) {
+		t.Setenv("USER_REVIEW_WAIT_TIMEOUT", "25s")
+		t.Setenv("USER_REVIEW_WAIT_INTERVAL", "500ms")
+
+		cfg := LoadUserConfigFromEnv()
+		assert.Equal(t, 25*time.Second, cfg.ReviewWaitTimeout)
+		assert.Equal(t, 500*time.Millisecond, cfg.ReviewWaitInterval)
+		assert.NoError(t, cfg.Validate())
+	})
+}
+
+func TestUserConfig_Validate(t *testing.T) {
+	t.Run("zero values mean defaults", func(t *testing.T) {
+		assert.NoError(t, UserConfig{}.Validate())
+	})
+
+	t.Run("negative timeout", func(t *testing.T) {
+		assert.ErrorContains(t, UserConfig{ReviewWaitTimeout: -time.Second}.Validate(), "USER_REVIEW_WAIT_TIMEOUT")
+	})
+
+	t.Run("negative interval", func(t *testing.T) {
+		assert.ErrorContains(t, UserConfig{ReviewWaitInterval: -time.Second}.Validate(), "USER_REVIEW_WAIT_INTERVAL")
+	})
+
+	t.Run("interval exceeds timeout", func(t *testing.T) {
+		cfg := UserConfig{ReviewWaitTimeout: time.Second, ReviewWaitInterval: 2 * time.Second}
+		assert.ErrorContains(t, cfg.Validate(), "must not exceed")
+	})
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// minWebhookSecretLength is the minimum length of a webhook signing secret.
+const minWebhookSecretLength = 16
+
+// WebhookConfig holds configuration for outbound webhook delivery.
+type WebhookConfig struct {
+	// URL is the endpoint receiving pull request events; empty disables webhooks.
+	URL string
+	// Secrets sign deliveries with HMAC-SHA256 (X-Signature header), one signature per secret;
+	// empty leaves deliveries unsigned. The current secret comes first, the previous ones are kept
+	// while receivers switch to it.
+	Secrets []string
+	// Workers is the number of delivery workers.
+	Workers int
+	// QueueSize is the capacity of the delivery queue.
+	QueueSize int
+	// MaxAttempts is the number of delivery attempts before a webhook is moved to the dead-letter table.
+	MaxAttempts int
+	// InitialBackoff bounds the delay before the first retry; the bound doubles after every failed
+	// attempt, and the actual delay is picked at random below it (full jitter).
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between retries.
+	MaxBackoff time.Duration
+	// Timeout is the timeout of a single delivery request.
+	Timeout time.Duration
+}
+
+// LoadWebhookConfigFromEnv loads webhook configuration from environment variables.
+func LoadWebhookConfigFromEnv() WebhookConfig {
+	return WebhookConfig{
+		URL:            GetEnv("WEBHOOK_URL", ""),
+		Secrets:        parseWebhookSecrets(GetEnv("WEBHOOK_SECRET", "")),
+		Workers:        GetEnvInt("WEBHOOK_WORKERS", 4),
+		QueueSize:      GetEnvInt("WEBHOOK_QUEUE_SIZE", 1000),
+		MaxAttempts:    GetEnvInt("WEBHOOK_MAX_ATTEMPTS", 5),
+		InitialBackoff: GetEnvDuration("WEBHOOK_INITIAL_BACKOFF", time.Second),
+		MaxBackoff:     GetEnvDuration("WEBHOOK_MAX_BACKOFF", time.Minute),
+		Timeout:        GetEnvDuration("WEBHOOK_TIMEOUT", 5*time.Second),
+	}
+}
+
+// Enabled reports whether webhooks should be delivered.
+func (c WebhookConfig) Enabled() bool {
+	return c.URL != ""
+}
+
+// Validate validates webhook configuration.
+func (c WebhookConfig) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	u, err := url.Parse(c.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("WEBHOOK_URL must be an absolute http(s) URL")
+	}
+	for _, secret := range c.Secrets {
+		if len(secret) < minWebhookSecretLength {
+			return fmt.Errorf("WEBHOOK_SECRET values must be at least %d characters", minWebhookSecretLength)
+		}
+	}
+	if c.Workers < 1 {
+		return fmt.Errorf("WEBHOOK_WORKERS must be greater than 0")
+	}
+	if c.QueueSize < 1 {
+		return fmt.Errorf("WEBHOOK_QUEUE_SIZE must be greater than 0")
+	}
+	if c.MaxAttempts < 1 {
+		return fmt.Errorf("WEBHOOK_MAX_ATTEMPTS must be greater than 0")
+	}
+	if c.InitialBackoff <= 0 {
+		return fmt.Errorf("WEBHOOK_INITIAL_BACKOFF must be positive")
+	}
+	if c.MaxBackoff < c.InitialBackoff {
+		return fmt.Errorf("WEBHOOK_MAX_BACKOFF must not be less than WEBHOOK_INITIAL_BACKOFF")
+	}
+	if c.Timeout <= 0 {
+		return fmt.Errorf("WEBHOOK_TIMEOUT must be positive")
+	}
+	return nil
+}
+
+// parseWebhookSecrets splits a comma-separated list of secrets, ignoring empty entries.
+func parseWebhookSecrets(s string) []string {
+	var secrets []string
+	for _, secret := range strings.Split(s, ",") {
+		if secret = strings.TrimSpace(secret); secret != "" {
+			secrets = append(secrets, secret)
+		}
+	}
+	return secrets
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadWebhookConfigFromEnv(t *testing.T) {
+	t.Run("default values", func(t *testing.T) {
+		t.Setenv("WEBHOOK_URL", "")
+		t.Setenv("WEBHOOK_SECRET", "")
+		t.Setenv("WEBHOOK_WORKERS", "")
+		t.Setenv("WEBHOOK_QUEUE_SIZE", "")
+		t.Setenv("WEBHOOK_MAX_ATTEMPTS", "")
+		t.Setenv("WEBHOOK_INITIAL_BACKOFF", "")
+		t.Setenv("WEBHOOK_MAX_BACKOFF", "")
+		t.Setenv("WEBHOOK_TIMEOUT", "")
+
+		cfg := LoadWebhookConfigFromEnv()
+		assert.False(t, cfg.Enabled())
+		assert.Empty(t, cfg.Secrets)
+		assert.Equal(t, 4, cfg.Workers)
+		assert.Equal(t, 1000, cfg.QueueSize)
+		assert.Equal(t, 5, cfg.MaxAttempts)
+		assert.Equal(t, time.Second, cfg.InitialBackoff)
+		assert.Equal(t, time.Minute, cfg.MaxBackoff)
+		assert.Equal(t, 5*time.Second, cfg.Timeout)
+	})
+
+	t.Run("custom values", func(t *testing.T) {
+		t.Setenv("WEBHOOK_URL", "https://hooks.example.com/pr")
+		t.Setenv("WEBHOOK_SECRET", "new-secret-0123456789, old-secret-0123456789,")
+		t.Setenv("WEBHOOK_WORKERS", "2")
+		t.Setenv("WEBHOOK_MAX_ATTEMPTS", "3")
+		t.Setenv("WEBHOOK_INITIAL_BACKOFF", "500ms")
+
+		cfg := LoadWebhookConfigFromEnv()
+		assert.True(t, cfg.Enabled())
+		assert.Equal(t, "https://hooks.example.com/pr", cfg.URL)
+		assert.Equal(t, []string{"new-secret-0123456789", "old-secret-0123456789"}, cfg.Secrets)
+		assert.Equal(t, 2, cfg.Workers)
+		assert.Equal(t, 3, cfg.MaxAttempts)
+		assert.Equal(t, 500*time.Millisecond, cfg.InitialBackoff)
+	})
+}
+
+func TestWebhookConfig_Validate(t *testing.T) {
+	valid := WebhookConfig{
+		URL:            "http://localhost:9000/hook",
+		Workers:        1,
+		QueueSize:      10,
+		MaxAttempts:    3,
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Minute,
+		Timeout:        time.Second,
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.NoError(t, WebhookConfig{}.Validate())
+	})
+
+	t.Run("valid enabled config", func(t *testing.T) {
+		assert.NoError(t, valid.Validate())
+	})
+
+	tests := []struct {
+		name   string
+		modify func(c *WebhookConfig)
+		errMsg string
+	}{
+		{"relative URL", func(c *WebhookConfig) { c.URL = "/hook" }, "WEBHOOK_URL"},
+		{"unsupported scheme", func(c *WebhookConfig) { c.URL = "ftp://example.com" }, "WEBHOOK_URL"},
+		{"short secret", func(c *WebhookConfig) { c.Secrets = []string{"new-secret-0123456789", "short"} }, "WEBHOOK_SECRET"},
+		{"no workers", func(c *WebhookConfig) { c.Workers = 0 }, "WEBHOOK_WORKERS"},
+		{"no queue", func(c *WebhookConfig) { c.QueueSize = 0 }, "WEBHOOK_QUEUE_SIZE"},
+		{"no attempts", func(c *WebhookConfig) { c.MaxAttempts = 0 }, "WEBHOOK_MAX_ATTEMPTS"},
+		{"zero backoff", func(c *WebhookConfig) { c.InitialBackoff = 0 }, "WEBHOOK_INITIAL_BACKOFF"},
+		{"max backoff below initial", func(c *WebhookConfig) { c.MaxBackoff = time.Millisecond }, "WEBHOOK_MAX_BACKOFF"},
+		{"zero timeout", func(c *WebhookConfig) { c.Timeout = 0 }, "WEBHOOK_TIMEOUT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid
+			tt.modify(&cfg)
+			err := cfg.Validate()
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
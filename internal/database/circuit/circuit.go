@@ -0,0 +1,119 @@
+// Package circuit guards database operations with circuit breakers, so that during a database outage
+// requests fail fast with 503 instead of holding goroutines and pool connections until they time out.
+package circuit
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+
+	"github.com/festy23/avito_internship/internal/database/dberror"
+	"github.com/festy23/avito_internship/pkg/breaker"
+	"github.com/festy23/avito_internship/pkg/clock"
+)
+
+// Operation classes have separate breakers: a database that still serves reads (e.g. a replica
+// being promoted, a full disk) keeps serving them while writes fail fast.
+const (
+	// ClassRead covers queries (Find, First, Count, Raw(...).Scan, Row).
+	ClassRead = "read"
+	// ClassWrite covers creates, updates, deletes and Exec.
+	ClassWrite = "write"
+)
+
+// instanceKey stores the breaker that let a statement through, so its outcome is recorded.
+const instanceKey = "circuit:breaker"
+
+var (
+	// State reports the state of each breaker: 0 closed, 1 half-open, 2 open.
+	// It is not registered by the package; the application registers it with its metrics registry.
+	State = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "db_circuit_breaker_state",
+		Help: "State of database circuit breakers by operation class: 0 closed, 1 half-open, 2 open.",
+	}, []string{"class"})
+	// TransitionsTotal counts state changes of each breaker.
+	// It is not registered by the package; the application registers it with its metrics registry.
+	TransitionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "db_circuit_breaker_transitions_total",
+		Help: "Number of state changes of database circuit breakers by operation class.",
+	}, []string{"class", "from", "to"})
+)
+
+// Config controls the database circuit breakers.
+type Config struct {
+	// FailureThreshold is the number of consecutive failed operations of a class that opens its
+	// breaker; 0 disables the breakers.
+	FailureThreshold int
+	// OpenTimeout is how long an open breaker rejects operations before a probe is let through.
+	OpenTimeout time.Duration
+}
+
+// Enabled reports whether the breakers are installed.
+func (c Config) Enabled() bool {
+	return c.FailureThreshold > 0
+}
+
+// Install registers gorm callbacks guarding every statement of db with the breaker of its class.
+// Statements rejected by an open breaker fail with a breaker.OpenError without reaching the database,
+// which API handlers answer as 503 SERVICE_UNAVAILABLE with Retry-After. Only failures showing that
+// the database is unavailable (dberror.IsUnavailable) count towards opening a breaker.
+//
+// Transactions are guarded by their statements; BEGIN itself is not guarded.
+func Install(db *gorm.DB, cfg Config) error {
+	if !cfg.Enabled() {
+		return nil
+	}
+	breakerCfg := breaker.Config{FailureThreshold: cfg.FailureThreshold, OpenTimeout: cfg.OpenTimeout}
+	read := breaker.New(ClassRead, breakerCfg, clock.New(), observe)
+	write := breaker.New(ClassWrite, breakerCfg, clock.New(), observe)
+	State.WithLabelValues(ClassRead).Set(float64(breaker.Closed))
+	State.WithLabelValues(ClassWrite).Set(float64(breaker.Closed))
+
+	callbacks := db.Callback()
+	return errors.Join(
+		callbacks.Query().Before("*").Register("circuit:before_query", before(read)),
+		callbacks.Query().After("*").Register("circuit:after_query", after),
+		callbacks.Row().Before("*").Register("circuit:before_row", before(read)),
+		callbacks.Row().After("*").Register("circuit:after_row", after),
+		callbacks.Create().Before("*").Register("circuit:before_create", before(write)),
+		callbacks.Create().After("*").Register("circuit:after_create", after),
+		callbacks.Update().Before("*").Register("circuit:before_update", before(write)),
+		callbacks.Update().After("*").Register("circuit:after_update", after),
+		callbacks.Delete().Before("*").Register("circuit:before_delete", before(write)),
+		callbacks.Delete().After("*").Register("circuit:after_delete", after),
+		callbacks.Raw().Before("*").Register("circuit:before_raw", before(write)),
+		callbacks.Raw().After("*").Register("circuit:after_raw", after),
+	)
+}
+
+// before rejects the statement if the breaker is open.
+func before(b *breaker.Breaker) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		// Reset a breaker left by an earlier execution of the same statement
+		db.InstanceSet(instanceKey, (*breaker.Breaker)(nil))
+		if db.Error != nil {
+			return
+		}
+		if err := b.Allow(); err != nil {
+			_ = db.AddError(err)
+			return
+		}
+		db.InstanceSet(instanceKey, b)
+	}
+}
+
+// after records the outcome of a statement let through by a breaker.
+func after(db *gorm.DB) {
+	value, _ := db.InstanceGet(instanceKey)
+	if b, _ := value.(*breaker.Breaker); b != nil {
+		b.Record(dberror.IsUnavailable(db.Error))
+	}
+}
+
+// observe records a state change of a breaker in metrics.
+func observe(class string, from, to breaker.State) {
+	State.WithLabelValues(class).Set(float64(to))
+	TransitionsTotal.WithLabelValues(class, from.String(), to.String()).Inc()
+}
@@ -0,0 +1,67 @@
+package circuit
+
+import (
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	dbtestutil "github.com/festy23/avito_internship/internal/testutil"
+	"github.com/festy23/avito_internship/pkg/breaker"
+)
+
+// failQueries makes every query of db fail as if the connection were lost until the returned
+// function is called.
+func failQueries(t *testing.T, db *gorm.DB) (restore func()) {
+	t.Helper()
+	query := db.Callback().Query().Get("gorm:query")
+	require.NoError(t, db.Callback().Query().Replace("gorm:query", func(tx *gorm.DB) {
+		_ = tx.AddError(driver.ErrBadConn)
+	}))
+	return func() {
+		require.NoError(t, db.Callback().Query().Replace("gorm:query", query))
+	}
+}
+
+func TestInstall(t *testing.T) {
+	db := dbtestutil.NewDB(t)
+	require.NoError(t, Install(db, Config{FailureThreshold: 2, OpenTimeout: 50 * time.Millisecond}))
+	opened := testutil.ToFloat64(TransitionsTotal.WithLabelValues(ClassRead, "closed", "open"))
+
+	var count int64
+	restore := failQueries(t, db)
+	for range 2 {
+		assert.ErrorIs(t, db.Table("teams").Count(&count).Error, driver.ErrBadConn)
+	}
+	restore()
+
+	t.Run("open breaker fails fast", func(t *testing.T) {
+		err := db.Table("teams").Count(&count).Error
+		require.ErrorIs(t, err, breaker.ErrOpen)
+		assert.Equal(t, float64(breaker.Open), testutil.ToFloat64(State.WithLabelValues(ClassRead)))
+		assert.Equal(t, opened+1, testutil.ToFloat64(TransitionsTotal.WithLabelValues(ClassRead, "closed", "open")))
+	})
+
+	t.Run("other classes are not affected", func(t *testing.T) {
+		assert.NoError(t, db.Exec("UPDATE teams SET lead_user_id = NULL").Error)
+	})
+
+	t.Run("database errors do not count", func(t *testing.T) {
+		require.Eventually(t, func() bool { return db.Table("teams").Count(&count).Error == nil },
+			time.Second, 10*time.Millisecond)
+		for range 3 {
+			assert.Error(t, db.Table("missing").Count(&count).Error)
+		}
+		assert.Equal(t, float64(breaker.Closed), testutil.ToFloat64(State.WithLabelValues(ClassRead)))
+	})
+}
+
+func TestInstall_Disabled(t *testing.T) {
+	db := dbtestutil.NewDB(t)
+	require.NoError(t, Install(db, Config{}))
+	assert.Nil(t, db.Callback().Query().Get("circuit:before_query"))
+}
@@ -4,15 +4,33 @@ package config
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/go-sql-driver/mysql"
+
+	"github.com/festy23/avito_internship/internal/database/circuit"
 	"github.com/festy23/avito_internship/pkg/retry"
 )
 
+// Supported database drivers.
+const (
+	DriverPostgres = "postgres"
+	DriverMySQL    = "mysql"
+)
+
+// defaultPorts holds the default DB_PORT of each driver.
+var defaultPorts = map[string]string{
+	DriverPostgres: "5432",
+	DriverMySQL:    "3306",
+}
+
 // Config holds database connection configuration.
 type Config struct {
+	// Driver selects the database: DriverPostgres (default) or DriverMySQL.
+	Driver   string
 	Host     string
 	User     string
 	Password string
@@ -20,6 +38,40 @@ type Config struct {
 	Port     string
 	SSLMode  string
 	TimeZone string
+	// Schema is an optional schema placed first in search_path (public stays as a fallback for extensions).
+	// PostgreSQL only: a MySQL database is selected by DBName.
+	Schema string
+}
+
+// schemaPattern matches unquoted PostgreSQL identifiers (at most 63 bytes), so a schema name can be
+// put into the DSN and SQL without quoting.
+var schemaPattern = regexp.MustCompile(`^[a-z_][a-z0-9_]{0,62}$`)
+
+// Validate validates database configuration.
+func (c Config) Validate() error {
+	if _, ok := defaultPorts[c.driver()]; !ok {
+		return fmt.Errorf("invalid DB_DRIVER %q: must be %s or %s", c.Driver, DriverPostgres, DriverMySQL)
+	}
+	if c.Schema != "" && c.driver() != DriverPostgres {
+		return fmt.Errorf("DB_SCHEMA is supported by %s only", DriverPostgres)
+	}
+	if c.Schema != "" && !schemaPattern.MatchString(c.Schema) {
+		return fmt.Errorf("invalid DB_SCHEMA %q: must be a lowercase identifier of letters, digits and underscores", c.Schema)
+	}
+	return nil
+}
+
+// driver returns the configured driver, PostgreSQL if none is set.
+func (c Config) driver() string {
+	if c.Driver == "" {
+		return DriverPostgres
+	}
+	return c.Driver
+}
+
+// IsMySQL reports whether the configuration selects MySQL.
+func (c Config) IsMySQL() bool {
+	return c.driver() == DriverMySQL
 }
 
 // GetEnv reads an environment variable with a default fallback.
@@ -30,22 +82,68 @@ func GetEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
-// BuildDSN constructs PostgreSQL DSN string from configuration.
+// BuildDSN constructs the DSN string of the configured driver.
 func BuildDSN(cfg Config) string {
-	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s TimeZone=%s",
-		cfg.Host, cfg.User, cfg.Password, cfg.DBName, cfg.Port, cfg.SSLMode, cfg.TimeZone)
+	return buildDSN(cfg, cfg.Password)
+}
+
+// buildDSN constructs the DSN string of the configured driver with the given password.
+func buildDSN(cfg Config, password string) string {
+	if cfg.IsMySQL() {
+		return buildMySQLDSN(cfg, password)
+	}
+	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s TimeZone=%s",
+		cfg.Host, cfg.User, password, cfg.DBName, cfg.Port, cfg.SSLMode, cfg.TimeZone)
+	if cfg.Schema != "" {
+		dsn += fmt.Sprintf(" search_path=%s,public", cfg.Schema)
+	}
+	return dsn
+}
+
+// buildMySQLDSN constructs MySQL DSN string with the given password. Timestamps are read in
+// TimeZone and identifiers are compared case-sensitively, as in PostgreSQL. Updates report matched
+// rather than changed rows, so an update to the current values is not taken for a missing row.
+// Migrations are applied over the same pool and need multi-statement queries.
+func buildMySQLDSN(cfg Config, password string) string {
+	loc, err := time.LoadLocation(cfg.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+	mysqlCfg := mysql.NewConfig()
+	mysqlCfg.User = cfg.User
+	mysqlCfg.Passwd = password
+	mysqlCfg.Net = "tcp"
+	mysqlCfg.Addr = cfg.Host + ":" + cfg.Port
+	mysqlCfg.DBName = cfg.DBName
+	mysqlCfg.ParseTime = true
+	mysqlCfg.Loc = loc
+	mysqlCfg.Collation = "utf8mb4_bin"
+	mysqlCfg.ClientFoundRows = true
+	mysqlCfg.MultiStatements = true
+	mysqlCfg.TLSConfig = mysqlTLS[cfg.SSLMode]
+	return mysqlCfg.FormatDSN()
+}
+
+// mysqlTLS maps DB_SSLMODE values to the tls parameter of the MySQL driver.
+var mysqlTLS = map[string]string{
+	"require":     "skip-verify",
+	"verify-ca":   "true",
+	"verify-full": "true",
 }
 
 // LoadConfigFromEnv loads database configuration from environment variables.
 func LoadConfigFromEnv() Config {
+	driver := GetEnv("DB_DRIVER", DriverPostgres)
 	return Config{
+		Driver:   driver,
 		Host:     GetEnv("DB_HOST", "localhost"),
 		User:     GetEnv("DB_USER", "postgres"),
 		Password: GetEnv("DB_PASSWORD", "postgres"),
 		DBName:   GetEnv("DB_NAME", "avito_internship"),
-		Port:     GetEnv("DB_PORT", "5432"),
+		Port:     GetEnv("DB_PORT", defaultPorts[driver]),
 		SSLMode:  GetEnv("DB_SSLMODE", "disable"),
 		TimeZone: GetEnv("DB_TIMEZONE", "UTC"),
+		Schema:   GetEnv("DB_SCHEMA", ""),
 	}
 }
 
@@ -60,8 +158,7 @@ func SanitizeError(err error, cfg Config) error {
 		errMsg = strings.ReplaceAll(errMsg, cfg.Password, "***")
 	}
 	// Also remove full DSN if it appears in error
-	safeDSN := fmt.Sprintf("host=%s user=%s password=*** dbname=%s port=%s sslmode=%s TimeZone=%s",
-		cfg.Host, cfg.User, cfg.DBName, cfg.Port, cfg.SSLMode, cfg.TimeZone)
+	safeDSN := buildDSN(cfg, "***")
 	dsn := BuildDSN(cfg)
 	errMsg = strings.ReplaceAll(errMsg, dsn, safeDSN)
 	return fmt.Errorf("failed to connect to database: %s", errMsg)
@@ -115,3 +212,11 @@ func LoadRetryConfigFromEnv() retry.Config {
 	cfg.Multiplier = getEnvFloat("DB_RETRY_MULTIPLIER", cfg.Multiplier)
 	return cfg
 }
+
+// LoadCircuitConfigFromEnv loads database circuit breaker configuration from environment variables.
+func LoadCircuitConfigFromEnv() circuit.Config {
+	return circuit.Config{
+		FailureThreshold: getEnvInt("DB_BREAKER_FAILURE_THRESHOLD", 5),
+		OpenTimeout:      getEnvDuration("DB_BREAKER_OPEN_TIMEOUT", 10*time.Second),
+	}
+}
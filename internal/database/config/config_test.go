@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -43,7 +44,7 @@ func unsetDBEnvVars(t *testing.T) map[string]string {
 	t.Helper()
 	envVarsToUnset := []string{
 		"DB_HOST", "DB_USER", "DB_PASSWORD", "DB_NAME",
-		"DB_PORT", "DB_SSLMODE", "DB_TIMEZONE",
+		"DB_PORT", "DB_SSLMODE", "DB_TIMEZONE", "DB_SCHEMA", "DB_DRIVER",
 	}
 	originalEnv := make(map[string]string)
 	for _, key := range envVarsToUnset {
@@ -71,6 +72,7 @@ func TestLoadConfigFromEnv(t *testing.T) {
 
 		cfg := LoadConfigFromEnv()
 		expected := Config{
+			Driver:   "postgres",
 			Host:     "localhost",
 			User:     "postgres",
 			Password: "postgres",
@@ -97,6 +99,7 @@ func TestLoadConfigFromEnv(t *testing.T) {
 
 		cfg := LoadConfigFromEnv()
 		expected := Config{
+			Driver:   "postgres",
 			Host:     "test-host",
 			User:     "test-user",
 			Password: "test-password",
@@ -110,7 +113,8 @@ func TestLoadConfigFromEnv(t *testing.T) {
 
 	t.Run("partial override", func(t *testing.T) {
 		envVarsToUnset := []string{
-			"DB_HOST", "DB_PORT", "DB_USER", "DB_PASSWORD", "DB_NAME", "DB_SSLMODE", "DB_TIMEZONE",
+			"DB_HOST", "DB_PORT", "DB_USER", "DB_PASSWORD", "DB_NAME", "DB_SSLMODE", "DB_TIMEZONE", "DB_SCHEMA",
+			"DB_DRIVER",
 		}
 		originalEnv := make(map[string]string)
 		for _, key := range envVarsToUnset {
@@ -125,6 +129,7 @@ func TestLoadConfigFromEnv(t *testing.T) {
 
 		cfg := LoadConfigFromEnv()
 		expected := Config{
+			Driver:   "postgres",
 			Host:     "custom-host",
 			User:     "postgres",
 			Password: "postgres",
@@ -135,6 +140,17 @@ func TestLoadConfigFromEnv(t *testing.T) {
 		}
 		assert.Equal(t, expected, cfg)
 	})
+
+	t.Run("mysql", func(t *testing.T) {
+		originalEnv := unsetDBEnvVars(t)
+		defer restoreDBEnvVars(originalEnv)
+		os.Setenv("DB_DRIVER", "mysql")
+
+		cfg := LoadConfigFromEnv()
+		assert.Equal(t, "mysql", cfg.Driver)
+		assert.Equal(t, "3306", cfg.Port)
+		assert.True(t, cfg.IsMySQL())
+	})
 }
 
 func TestBuildDSN(t *testing.T) {
@@ -169,6 +185,48 @@ func TestBuildDSN(t *testing.T) {
 			},
 			expected: "host=db.example.com user=admin password=secret123 dbname=production port=5433 sslmode=require TimeZone=Europe/Moscow",
 		},
+		{
+			name: "with schema",
+			config: Config{
+				Host:     "localhost",
+				User:     "postgres",
+				Password: "postgres",
+				DBName:   "testdb",
+				Port:     "5432",
+				SSLMode:  "disable",
+				TimeZone: "UTC",
+				Schema:   "e2e_1",
+			},
+			expected: "host=localhost user=postgres password=postgres dbname=testdb port=5432 sslmode=disable TimeZone=UTC search_path=e2e_1,public",
+		},
+		{
+			name: "mysql",
+			config: Config{
+				Driver:   "mysql",
+				Host:     "localhost",
+				User:     "root",
+				Password: "secret",
+				DBName:   "avito_internship",
+				Port:     "3306",
+				SSLMode:  "disable",
+				TimeZone: "UTC",
+			},
+			expected: "root:secret@tcp(localhost:3306)/avito_internship?clientFoundRows=true&collation=utf8mb4_bin&multiStatements=true&parseTime=true",
+		},
+		{
+			name: "mysql with tls",
+			config: Config{
+				Driver:   "mysql",
+				Host:     "db.example.com",
+				User:     "admin",
+				Password: "secret123",
+				DBName:   "production",
+				Port:     "3307",
+				SSLMode:  "verify-full",
+				TimeZone: "Europe/Moscow",
+			},
+			expected: "admin:secret123@tcp(db.example.com:3307)/production?clientFoundRows=true&collation=utf8mb4_bin&loc=Europe%2FMoscow&multiStatements=true&parseTime=true&tls=true",
+		},
 	}
 
 	for _, tt := range tests {
@@ -179,6 +237,39 @@ func TestBuildDSN(t *testing.T) {
 	}
 }
 
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		wantErr bool
+	}{
+		{"no schema", "", false},
+		{"identifier", "e2e_team_test_1", false},
+		{"uppercase", "Reviews", true},
+		{"leading digit", "1reviews", true},
+		{"dsn injection", "reviews sslmode=disable", true},
+		{"quoted", `"reviews"`, true},
+		{"too long", strings.Repeat("s", 64), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Config{Schema: tt.schema}.Validate()
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "invalid DB_SCHEMA")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("driver", func(t *testing.T) {
+		assert.NoError(t, Config{Driver: "postgres"}.Validate())
+		assert.NoError(t, Config{Driver: "mysql"}.Validate())
+		assert.ErrorContains(t, Config{Driver: "oracle"}.Validate(), "invalid DB_DRIVER")
+		assert.ErrorContains(t, Config{Driver: "mysql", Schema: "reviews"}.Validate(), "DB_SCHEMA is supported by postgres only")
+	})
+}
+
 func TestGetEnv(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -604,3 +695,32 @@ func TestLoadRetryConfigFromEnv(t *testing.T) {
 		assert.Equal(t, defaultCfg.Multiplier, cfg.Multiplier)
 	})
 }
+
+func TestLoadCircuitConfigFromEnv(t *testing.T) {
+	t.Run("default values", func(t *testing.T) {
+		envVars := map[string]string{
+			"DB_BREAKER_FAILURE_THRESHOLD": "",
+			"DB_BREAKER_OPEN_TIMEOUT":      "",
+		}
+		originalEnv := setupEnvVars(t, envVars)
+		defer restoreEnvVars(envVars, originalEnv)
+
+		cfg := LoadCircuitConfigFromEnv()
+		assert.True(t, cfg.Enabled())
+		assert.Equal(t, 5, cfg.FailureThreshold)
+		assert.Equal(t, 10*time.Second, cfg.OpenTimeout)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		envVars := map[string]string{
+			"DB_BREAKER_FAILURE_THRESHOLD": "0",
+			"DB_BREAKER_OPEN_TIMEOUT":      "30s",
+		}
+		originalEnv := setupEnvVars(t, envVars)
+		defer restoreEnvVars(envVars, originalEnv)
+
+		cfg := LoadCircuitConfigFromEnv()
+		assert.False(t, cfg.Enabled())
+		assert.Equal(t, 30*time.Second, cfg.OpenTimeout)
+	})
+}
@@ -1,16 +1,21 @@
-// Package database provides database connection management for PostgreSQL.
+// Package database provides database connection management for PostgreSQL and MySQL.
 package database
 
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"net"
 	"time"
 
+	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
+	"github.com/festy23/avito_internship/internal/database/circuit"
 	"github.com/festy23/avito_internship/internal/database/config"
+	"github.com/festy23/avito_internship/internal/database/dberror"
 	"github.com/festy23/avito_internship/internal/database/pool"
 	"github.com/festy23/avito_internship/pkg/retry"
 )
@@ -23,7 +28,12 @@ func New() (*gorm.DB, error) {
 
 // NewWithConfig creates a new database connection with custom configuration.
 func NewWithConfig(cfg config.Config) (*gorm.DB, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
 	retryCfg := config.LoadRetryConfigFromEnv()
+	// Driver errors are classified by type; the message patterns of the config remain a fallback
+	retryCfg.Retryable = isRetryableConnectError
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 	defer cancel()
 
@@ -32,21 +42,64 @@ func NewWithConfig(cfg config.Config) (*gorm.DB, error) {
 	var err error
 
 	db, err = retry.DoWithResult(ctx, retryCfg, func() (*gorm.DB, error) {
-		return gorm.Open(postgres.Open(dsn), &gorm.Config{})
+		return gorm.Open(dialector(cfg, dsn), &gorm.Config{})
 	})
 
 	if err != nil {
 		return nil, config.SanitizeError(err, cfg)
 	}
 
+	if err := checkSchema(ctx, db, cfg.Schema); err != nil {
+		return nil, err
+	}
+
 	// Setup default connection pool
 	if err := pool.SetupConnectionPool(db, pool.DefaultPoolConfig()); err != nil {
 		return nil, fmt.Errorf("failed to setup connection pool: %w", err)
 	}
 
+	// Fail fast during database outages instead of exhausting the pool
+	if err := circuit.Install(db, config.LoadCircuitConfigFromEnv()); err != nil {
+		return nil, fmt.Errorf("failed to install circuit breakers: %w", err)
+	}
+
 	return db, nil
 }
 
+// dialector returns the GORM dialector of the configured driver.
+func dialector(cfg config.Config, dsn string) gorm.Dialector {
+	if cfg.IsMySQL() {
+		return mysql.Open(dsn)
+	}
+	return postgres.Open(dsn)
+}
+
+// isRetryableConnectError reports whether a failed connection attempt may succeed later. Unknown
+// hosts are a configuration error rather than an outage and fail at once.
+func isRetryableConnectError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return false
+	}
+	return dberror.IsUnavailable(err)
+}
+
+// checkSchema verifies that schema exists. PostgreSQL skips missing schemas of search_path, so
+// without the check the service and its migrations would silently fall back to public.
+func checkSchema(ctx context.Context, db *gorm.DB, schema string) error {
+	if schema == "" {
+		return nil
+	}
+	var current sql.NullString
+	if err := db.WithContext(ctx).Raw("SELECT current_schema()").Row().Scan(&current); err != nil {
+		return fmt.Errorf("failed to check schema %s: %w", schema, err)
+	}
+	if current.String != schema {
+		return fmt.Errorf("schema %s does not exist, create it before starting the service", schema)
+	}
+	return nil
+}
+
 // HealthCheck verifies database connection availability.
 func HealthCheck(ctx context.Context, db *gorm.DB) error {
 	if db == nil {
@@ -2,10 +2,13 @@ package database
 
 import (
 	"context"
+	"errors"
+	"net"
 	"os"
 	"strings"
 	"testing"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gorm.io/driver/sqlite"
@@ -33,6 +36,34 @@ func TestNewWithConfig(t *testing.T) {
 			},
 			wantError: true, // Connection will fail
 		},
+		{
+			name: "invalid schema - rejected before connecting",
+			config: config.Config{
+				Host:     "localhost",
+				User:     "test",
+				Password: "test",
+				DBName:   "test_db",
+				Port:     "5432",
+				SSLMode:  "disable",
+				TimeZone: "UTC",
+				Schema:   "reviews sslmode=disable",
+			},
+			wantError: true,
+		},
+		{
+			name: "unknown driver - rejected before connecting",
+			config: config.Config{
+				Driver:   "oracle",
+				Host:     "localhost",
+				User:     "test",
+				Password: "test",
+				DBName:   "test_db",
+				Port:     "1521",
+				SSLMode:  "disable",
+				TimeZone: "UTC",
+			},
+			wantError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -55,6 +86,12 @@ func TestNewWithConfig(t *testing.T) {
 	}
 }
 
+func TestDialector(t *testing.T) {
+	assert.Equal(t, "postgres", dialector(config.Config{}, "").Name())
+	assert.Equal(t, "postgres", dialector(config.Config{Driver: config.DriverPostgres}, "").Name())
+	assert.Equal(t, "mysql", dialector(config.Config{Driver: config.DriverMySQL}, "").Name())
+}
+
 func TestHealthCheck(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -326,3 +363,10 @@ func TestGetStatsErrorPaths(t *testing.T) {
 		}
 	})
 }
+
+func TestIsRetryableConnectError(t *testing.T) {
+	assert.True(t, isRetryableConnectError(&net.OpError{Op: "dial", Err: errors.New("connection refused")}))
+	assert.True(t, isRetryableConnectError(&pgconn.PgError{Code: "57P03"}), "database is starting up")
+	assert.False(t, isRetryableConnectError(&net.DNSError{Name: "postgres", IsNotFound: true}))
+	assert.False(t, isRetryableConnectError(&pgconn.PgError{Code: "28P01"}), "authentication failed")
+}
@@ -0,0 +1,78 @@
+// Package dialect adapts SQL that differs between the supported databases: PostgreSQL, MySQL and
+// SQLite used in tests.
+package dialect
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/festy23/avito_internship/internal/database/dberror"
+)
+
+// mysqlDialector is the name of the GORM dialector of MySQL.
+const mysqlDialector = "mysql"
+
+// IsMySQL reports whether db is connected to MySQL.
+func IsMySQL(db *gorm.DB) bool {
+	return db.Dialector.Name() == mysqlDialector
+}
+
+// CastBigInt returns expr cast to a 64-bit integer.
+func CastBigInt(db *gorm.DB, expr string) string {
+	if IsMySQL(db) {
+		return "CAST(" + expr + " AS SIGNED)"
+	}
+	return "CAST(" + expr + " AS BIGINT)"
+}
+
+// IntDiv returns the integer division of dividend by divisor. MySQL divides integers into
+// decimals with /, so it needs DIV to truncate.
+func IntDiv(db *gorm.DB, dividend, divisor string) string {
+	if IsMySQL(db) {
+		return dividend + " DIV " + divisor
+	}
+	return dividend + " / " + divisor
+}
+
+// CastText returns expr cast to a string.
+func CastText(db *gorm.DB, expr string) string {
+	if IsMySQL(db) {
+		return "CAST(" + expr + " AS CHAR)"
+	}
+	return "CAST(" + expr + " AS TEXT)"
+}
+
+// LikeEscape returns the clause that makes backslash the escape character of a LIKE pattern.
+// MySQL escapes with backslash by default and reads the literal '\' as an escaped quote.
+func LikeEscape(db *gorm.DB) string {
+	if IsMySQL(db) {
+		return ""
+	}
+	return ` ESCAPE '\'`
+}
+
+// CreateOrSkip inserts value unless it conflicts with an existing row on columns (any unique
+// constraint if none are given) and reports whether the row was inserted. A skipped insert does not
+// abort the transaction of db.
+//
+// MySQL reports an update of a conflicting row to its current values as an affected row, so there
+// the insert is not skipped but checked for a duplicate key error, which keeps the transaction usable.
+func CreateOrSkip(db *gorm.DB, value any, columns ...string) (bool, error) {
+	if IsMySQL(db) {
+		err := db.Create(value).Error
+		if dberror.IsDuplicateKey(err) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+
+	onConflict := clause.OnConflict{DoNothing: true}
+	for _, column := range columns {
+		onConflict.Columns = append(onConflict.Columns, clause.Column{Name: column})
+	}
+	result := db.Clauses(onConflict).Create(value)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
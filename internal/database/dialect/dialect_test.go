@@ -0,0 +1,74 @@
+package dialect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// mysqlDB returns a MySQL session that is never connected, for building SQL only.
+func mysqlDB() *gorm.DB {
+	return &gorm.DB{Config: &gorm.Config{Dialector: mysql.Dialector{}}}
+}
+
+func sqliteDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	return db
+}
+
+func TestSQL(t *testing.T) {
+	db := sqliteDB(t)
+
+	assert.False(t, IsMySQL(db))
+	assert.True(t, IsMySQL(mysqlDB()))
+
+	assert.Equal(t, "CAST(n AS BIGINT)", CastBigInt(db, "n"))
+	assert.Equal(t, "CAST(n AS SIGNED)", CastBigInt(mysqlDB(), "n"))
+
+	assert.Equal(t, "a / ?", IntDiv(db, "a", "?"))
+	assert.Equal(t, "a DIV ?", IntDiv(mysqlDB(), "a", "?"))
+
+	assert.Equal(t, "CAST(payload AS TEXT)", CastText(db, "payload"))
+	assert.Equal(t, "CAST(payload AS CHAR)", CastText(mysqlDB(), "payload"))
+
+	assert.Equal(t, ` ESCAPE '\'`, LikeEscape(db))
+	assert.Empty(t, LikeEscape(mysqlDB()))
+}
+
+func TestSQL_RunsOnSQLite(t *testing.T) {
+	db := sqliteDB(t)
+
+	var weight int64
+	require.NoError(t, db.Raw("SELECT "+CastBigInt(db, IntDiv(db, "250", "?")), 100).Scan(&weight).Error)
+	assert.Equal(t, int64(2), weight)
+
+	var matched bool
+	require.NoError(t, db.Raw("SELECT '50%' LIKE ?"+LikeEscape(db), `50\%`).Scan(&matched).Error)
+	assert.True(t, matched)
+}
+
+func TestCreateOrSkip(t *testing.T) {
+	type watcher struct {
+		PullRequestID string `gorm:"primaryKey"`
+		UserID        string `gorm:"primaryKey"`
+	}
+	db := sqliteDB(t)
+	require.NoError(t, db.AutoMigrate(&watcher{}))
+
+	created, err := CreateOrSkip(db, &watcher{PullRequestID: "pr-1", UserID: "u1"})
+	require.NoError(t, err)
+	assert.True(t, created)
+
+	created, err = CreateOrSkip(db, &watcher{PullRequestID: "pr-1", UserID: "u1"}, "pull_request_id", "user_id")
+	require.NoError(t, err)
+	assert.False(t, created)
+
+	var count int64
+	require.NoError(t, db.Model(&watcher{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
@@ -0,0 +1,47 @@
+package maintenance
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/festy23/avito_internship/pkg/apierror"
+)
+
+// Handler exposes table health statistics over HTTP.
+type Handler struct {
+	maintainer *Maintainer
+}
+
+// NewHandler creates a new maintenance handler instance.
+func NewHandler(maintainer *Maintainer) *Handler {
+	return &Handler{maintainer: maintainer}
+}
+
+// TablesResponse represents the response with table statistics.
+type TablesResponse struct {
+	Tables []TableStats `json:"tables"`
+}
+
+// ErrorResponse represents error response structure.
+type ErrorResponse = apierror.Response
+
+// GetTables handles GET /maintenance/tables request.
+// @Summary Get table size and bloat statistics
+// @Tags Maintenance
+// @Produce json
+// @Success 200 {object} TablesResponse
+// @Failure 401 {object} ErrorResponse "Missing or invalid admin token"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /maintenance/tables [get] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) GetTables(c *gin.Context) {
+	stats, err := h.maintainer.GetTableStats(c.Request.Context())
+	if err != nil {
+		apierror.Fail(c, err)
+		return
+	}
+	if stats == nil {
+		stats = []TableStats{}
+	}
+	c.JSON(http.StatusOK, TablesResponse{Tables: stats})
+}
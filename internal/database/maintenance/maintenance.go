@@ -0,0 +1,109 @@
+// Package maintenance provides database maintenance tasks and table health statistics.
+package maintenance
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/festy23/avito_internship/internal/database/dialect"
+)
+
+// hotTables are the tables whose statistics change the most with archival and bulk updates.
+var hotTables = []string{"pull_requests", "pull_request_reviewers", "pull_request_events", "users"}
+
+// TableStats holds size and bloat indicators of a table.
+type TableStats struct {
+	TableName       string     `gorm:"column:table_name"        json:"table_name"`
+	LiveTuples      int64      `gorm:"column:live_tuples"       json:"live_tuples"`
+	DeadTuples      int64      `gorm:"column:dead_tuples"       json:"dead_tuples"`
+	DeadRatio       float64    `gorm:"column:dead_ratio"        json:"dead_ratio"`
+	TotalBytes      int64      `gorm:"column:total_bytes"       json:"total_bytes"`
+	LastVacuum      *time.Time `gorm:"column:last_vacuum"       json:"last_vacuum,omitempty"`
+	LastAutovacuum  *time.Time `gorm:"column:last_autovacuum"   json:"last_autovacuum,omitempty"`
+	LastAnalyze     *time.Time `gorm:"column:last_analyze"      json:"last_analyze,omitempty"`
+	LastAutoanalyze *time.Time `gorm:"column:last_autoanalyze"  json:"last_autoanalyze,omitempty"`
+}
+
+// Maintainer runs maintenance statements against the database.
+type Maintainer struct {
+	db     *gorm.DB
+	logger *zap.SugaredLogger
+}
+
+// New creates a new maintainer instance.
+func New(db *gorm.DB, logger *zap.SugaredLogger) *Maintainer {
+	return &Maintainer{db: db, logger: logger}
+}
+
+// Run refreshes planner statistics; it is registered in the background job scheduler.
+func (m *Maintainer) Run(ctx context.Context) error {
+	return m.Analyze(ctx)
+}
+
+// Analyze issues ANALYZE on the hot tables so the planner sees the effect of bulk changes.
+func (m *Maintainer) Analyze(ctx context.Context) error {
+	for _, table := range hotTables {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		started := time.Now()
+		statement := "ANALYZE " + table
+		if dialect.IsMySQL(m.db) {
+			statement = "ANALYZE TABLE " + table
+		}
+		if err := m.db.WithContext(ctx).Exec(statement).Error; err != nil {
+			m.logger.Errorw("failed to analyze table", "table", table, "error", err)
+			return err
+		}
+		m.logger.Infow("table analyzed", "table", table, "duration", time.Since(started))
+	}
+	return nil
+}
+
+// GetTableStats returns bloat indicators of all user tables, most dead tuples first. MySQL reports
+// the estimated row count and size only, ordered by table name.
+func (m *Maintainer) GetTableStats(ctx context.Context) ([]TableStats, error) {
+	query := postgresTableStatsQuery
+	if dialect.IsMySQL(m.db) {
+		query = mysqlTableStatsQuery
+	}
+
+	var stats []TableStats
+	if err := m.db.WithContext(ctx).Raw(query).Scan(&stats).Error; err != nil {
+		m.logger.Errorw("failed to get table statistics", "error", err)
+		return nil, err
+	}
+	return stats, nil
+}
+
+const (
+	postgresTableStatsQuery = `
+		SELECT
+			relname AS table_name,
+			n_live_tup AS live_tuples,
+			n_dead_tup AS dead_tuples,
+			CASE WHEN n_live_tup + n_dead_tup = 0 THEN 0
+				ELSE n_dead_tup::float8 / (n_live_tup + n_dead_tup) END AS dead_ratio,
+			pg_total_relation_size(relid) AS total_bytes,
+			last_vacuum,
+			last_autovacuum,
+			last_analyze,
+			last_autoanalyze
+		FROM pg_stat_user_tables
+		ORDER BY n_dead_tup DESC, relname
+	`
+	mysqlTableStatsQuery = `
+		SELECT
+			table_name AS table_name,
+			table_rows AS live_tuples,
+			0 AS dead_tuples,
+			0 AS dead_ratio,
+			data_length + index_length AS total_bytes
+		FROM information_schema.tables
+		WHERE table_schema = DATABASE() AND table_type = 'BASE TABLE'
+		ORDER BY table_name
+	`
+)
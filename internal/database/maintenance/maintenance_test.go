@@ -0,0 +1,70 @@
+package maintenance
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func setupTestDB(t *testing.T, tables ...string) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	for _, table := range tables {
+		require.NoError(t, db.Exec("CREATE TABLE "+table+" (id INTEGER PRIMARY KEY)").Error)
+	}
+	return db
+}
+
+func TestMaintainer_Analyze(t *testing.T) {
+	t.Run("analyzes hot tables", func(t *testing.T) {
+		core, logs := observer.New(zap.InfoLevel)
+		m := New(setupTestDB(t, hotTables...), zap.New(core).Sugar())
+
+		require.NoError(t, m.Analyze(context.Background()))
+
+		entries := logs.FilterMessage("table analyzed").All()
+		require.Len(t, entries, len(hotTables))
+		assert.Equal(t, "pull_requests", entries[0].ContextMap()["table"])
+	})
+
+	t.Run("stops on error", func(t *testing.T) {
+		m := New(setupTestDB(t), zap.NewNop().Sugar())
+
+		assert.Error(t, m.Analyze(context.Background()))
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		m := New(setupTestDB(t, hotTables...), zap.NewNop().Sugar())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		assert.ErrorIs(t, m.Analyze(ctx), context.Canceled)
+	})
+}
+
+func TestHandler_GetTables(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// pg_stat_user_tables is PostgreSQL-specific, so only the error path is covered here.
+	router := gin.New()
+	router.GET("/maintenance/tables", NewHandler(New(setupTestDB(t), zap.NewNop().Sugar())).GetTables)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/maintenance/tables", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "INTERNAL_ERROR")
+}
@@ -2,33 +2,116 @@
 package migrate
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 
 	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/database/mysql"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 
 	"github.com/festy23/avito_internship/internal/database/config"
+	"github.com/festy23/avito_internship/internal/database/dialect"
 )
 
-// GetMigrationsPath returns the default path to migrations directory.
+// DirtyError reports a schema left dirty by a migration that failed halfway. golang-migrate refuses
+// to apply further migrations until the version is forced.
+type DirtyError struct {
+	// Version is the version of the failed migration.
+	Version uint
+}
+
+// Error implements the error interface.
+func (e *DirtyError) Error() string {
+	return fmt.Sprintf("database schema is dirty at version %d", e.Version)
+}
+
+// GetMigrationsPath returns the path to migrations directory: MIGRATIONS_PATH, by default migrations
+// or migrations/mysql when DB_DRIVER selects MySQL.
 func GetMigrationsPath() string {
-	return config.GetEnv("MIGRATIONS_PATH", "migrations")
+	defaultPath := "migrations"
+	if config.LoadConfigFromEnv().IsMySQL() {
+		defaultPath = "migrations/mysql"
+	}
+	return config.GetEnv("MIGRATIONS_PATH", defaultPath)
+}
+
+// RepairDirtyEnabled reports whether MIGRATIONS_REPAIR_DIRTY allows forcing a dirty schema back to
+// the previous version before retrying migrations.
+func RepairDirtyEnabled() bool {
+	enabled, err := strconv.ParseBool(config.GetEnv("MIGRATIONS_REPAIR_DIRTY", "false"))
+	return err == nil && enabled
 }
 
 // Migrate applies database migrations from the migrations directory using golang-migrate.
+// A dirty schema is reported as *DirtyError.
 func Migrate(db *gorm.DB) error {
+	m, err := newMigrate(db)
+	if err != nil {
+		return err
+	}
+
+	// Apply all pending migrations
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		var dirty migrate.ErrDirty
+		if errors.As(err, &dirty) && dirty.Version >= 0 {
+			return &DirtyError{Version: uint(dirty.Version)}
+		}
+		return fmt.Errorf("failed to apply migrations: %w", err)
+	}
+
+	return nil
+}
+
+// MigrateWithRepair applies migrations like Migrate and logs the failed version of a dirty schema.
+// With repair set, the dirty schema is forced back to the previous version and migrations are
+// retried once. This relies on the failed migration having been rolled back, which holds for
+// PostgreSQL migrations without explicit transaction control. MySQL commits every DDL statement,
+// so there the schema is never repaired automatically.
+func MigrateWithRepair(db *gorm.DB, repair bool, log *zap.SugaredLogger) error {
+	err := Migrate(db)
+	var dirty *DirtyError
+	if !errors.As(err, &dirty) {
+		return err
+	}
+
+	log.Errorw("database schema is dirty, a migration failed halfway", "version", dirty.Version)
+	if !repair {
+		return fmt.Errorf("%w: fix the schema and force the version, or set MIGRATIONS_REPAIR_DIRTY=true", err)
+	}
+	if dialect.IsMySQL(db) {
+		return fmt.Errorf("%w: MySQL does not roll back failed DDL, fix the schema and force the version", err)
+	}
+
+	previous, err := previousVersion(dirty.Version)
+	if err != nil {
+		return err
+	}
+	if err := forceVersion(db, previous); err != nil {
+		return err
+	}
+	log.Warnw("dirty schema forced to the previous version, retrying migrations",
+		"failed_version", dirty.Version, "version", previous)
+
+	return Migrate(db)
+}
+
+// newMigrate creates a golang-migrate instance for the migrations directory.
+func newMigrate(db *gorm.DB) (*migrate.Migrate, error) {
 	if db == nil {
-		return fmt.Errorf("database connection is nil")
+		return nil, fmt.Errorf("database connection is nil")
 	}
 
 	sqlDB, err := db.DB()
 	if err != nil {
-		return fmt.Errorf("failed to get underlying sql.DB: %w", err)
+		return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
 	}
 
 	// Get migrations directory path
@@ -37,34 +120,62 @@ func Migrate(db *gorm.DB) error {
 	// Convert relative path to absolute if needed
 	migrationsPath, err := filepath.Abs(migrationsDir)
 	if err != nil {
-		return fmt.Errorf("failed to get absolute path for migrations: %w", err)
+		return nil, fmt.Errorf("failed to get absolute path for migrations: %w", err)
 	}
 
 	// Check if migrations directory exists
 	if _, statErr := os.Stat(migrationsPath); os.IsNotExist(statErr) {
-		return fmt.Errorf("migrations directory does not exist: %s", migrationsPath)
+		return nil, fmt.Errorf("migrations directory does not exist: %s", migrationsPath)
 	}
 
-	// Create postgres driver instance
-	driver, err := postgres.WithInstance(sqlDB, &postgres.Config{})
+	driverName, driver, err := newDriver(db, sqlDB)
 	if err != nil {
-		return fmt.Errorf("failed to create postgres driver: %w", err)
+		return nil, err
 	}
 
 	// Create migrate instance with file source
 	m, err := migrate.NewWithDatabaseInstance(
 		fmt.Sprintf("file://%s", migrationsPath),
-		"postgres",
+		driverName,
 		driver,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to create migrate instance: %w", err)
+		return nil, fmt.Errorf("failed to create migrate instance: %w", err)
 	}
+	return m, nil
+}
 
-	// Apply all pending migrations
-	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
-		return fmt.Errorf("failed to apply migrations: %w", err)
+// newDriver creates the golang-migrate driver of the database behind db.
+func newDriver(db *gorm.DB, sqlDB *sql.DB) (string, database.Driver, error) {
+	if dialect.IsMySQL(db) {
+		driver, err := mysql.WithInstance(sqlDB, &mysql.Config{})
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to create mysql driver: %w", err)
+		}
+		return config.DriverMySQL, driver, nil
+	}
+
+	driver, err := postgres.WithInstance(sqlDB, &postgres.Config{})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create postgres driver: %w", err)
 	}
+	return config.DriverPostgres, driver, nil
+}
 
+// forceVersion sets the applied version and clears the dirty flag without running migrations.
+// Version 0 means no migration has been applied.
+func forceVersion(db *gorm.DB, version uint) error {
+	m, err := newMigrate(db)
+	if err != nil {
+		return err
+	}
+
+	target := int(version)
+	if version == 0 {
+		target = database.NilVersion
+	}
+	if err := m.Force(target); err != nil {
+		return fmt.Errorf("failed to force schema version %d: %w", version, err)
+	}
 	return nil
 }
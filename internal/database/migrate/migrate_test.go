@@ -1,12 +1,14 @@
 package migrate
 
 import (
+	"fmt"
 	"os"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
@@ -41,6 +43,12 @@ func TestGetMigrationsPath(t *testing.T) {
 		path := GetMigrationsPath()
 		assert.Equal(t, "custom/migrations", path)
 	})
+
+	t.Run("mysql default path", func(t *testing.T) {
+		t.Setenv("MIGRATIONS_PATH", "")
+		t.Setenv("DB_DRIVER", "mysql")
+		assert.Equal(t, "migrations/mysql", GetMigrationsPath())
+	})
 }
 
 // setupMigrationsPath sets MIGRATIONS_PATH env var and returns cleanup function.
@@ -140,3 +148,28 @@ func TestMigrateHandlesErrNoChange(t *testing.T) {
 	// So ErrNoChange should return nil (success)
 	t.Skip("Requires real PostgreSQL database - covered in e2e tests")
 }
+
+func TestRepairDirtyEnabled(t *testing.T) {
+	t.Setenv("MIGRATIONS_REPAIR_DIRTY", "")
+	assert.False(t, RepairDirtyEnabled())
+
+	t.Setenv("MIGRATIONS_REPAIR_DIRTY", "true")
+	assert.True(t, RepairDirtyEnabled())
+
+	t.Setenv("MIGRATIONS_REPAIR_DIRTY", "invalid")
+	assert.False(t, RepairDirtyEnabled())
+}
+
+func TestDirtyError(t *testing.T) {
+	var err error = &DirtyError{Version: 12}
+	assert.EqualError(t, err, "database schema is dirty at version 12")
+
+	var dirty *DirtyError
+	require.ErrorAs(t, fmt.Errorf("startup: %w", err), &dirty)
+	assert.Equal(t, uint(12), dirty.Version)
+}
+
+func TestMigrateWithRepairPassesOtherErrors(t *testing.T) {
+	err := MigrateWithRepair(nil, true, zap.NewNop().Sugar())
+	assert.ErrorContains(t, err, "database connection is nil")
+}
@@ -0,0 +1,78 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/golang-migrate/migrate/v4/source"
+	"gorm.io/gorm"
+)
+
+// schemaMigrationsTable is the table golang-migrate records the applied version in.
+const schemaMigrationsTable = "schema_migrations"
+
+// LatestVersion returns the highest migration version in the migrations directory.
+func LatestVersion() (uint, error) {
+	entries, err := os.ReadDir(GetMigrationsPath())
+	if err != nil {
+		return 0, fmt.Errorf("failed to read migrations directory: %w", err)
+	}
+
+	var latest uint
+	for _, entry := range entries {
+		migration, err := source.DefaultParse(entry.Name())
+		if err != nil {
+			continue
+		}
+		latest = max(latest, migration.Version)
+	}
+	if latest == 0 {
+		return 0, fmt.Errorf("no migrations found in %s", GetMigrationsPath())
+	}
+	return latest, nil
+}
+
+// CurrentVersion returns the applied schema version and whether the last migration failed
+// halfway (dirty). Version 0 means no migration has been applied.
+func CurrentVersion(ctx context.Context, db *gorm.DB) (version uint, dirty bool, err error) {
+	if db == nil {
+		return 0, false, fmt.Errorf("database connection is nil")
+	}
+
+	db = db.WithContext(ctx)
+	if !db.Migrator().HasTable(schemaMigrationsTable) {
+		return 0, false, nil
+	}
+
+	var row struct {
+		Version int64
+		Dirty   bool
+	}
+	if err := db.Table(schemaMigrationsTable).Select("version", "dirty").Limit(1).Scan(&row).Error; err != nil {
+		return 0, false, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if row.Version < 0 {
+		return 0, row.Dirty, nil
+	}
+	return uint(row.Version), row.Dirty, nil
+}
+
+// previousVersion returns the highest migration version in the migrations directory below version,
+// or 0 if there is none.
+func previousVersion(version uint) (uint, error) {
+	entries, err := os.ReadDir(GetMigrationsPath())
+	if err != nil {
+		return 0, fmt.Errorf("failed to read migrations directory: %w", err)
+	}
+
+	var previous uint
+	for _, entry := range entries {
+		migration, err := source.DefaultParse(entry.Name())
+		if err != nil || migration.Version >= version {
+			continue
+		}
+		previous = max(previous, migration.Version)
+	}
+	return previous, nil
+}
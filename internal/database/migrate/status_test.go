@@ -0,0 +1,124 @@
+package migrate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatestVersion(t *testing.T) {
+	t.Run("highest version in directory", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		for _, name := range []string{
+			"000001_create_teams.up.sql",
+			"000001_create_teams.down.sql",
+			"000012_add_events.up.sql",
+			"000012_add_events.down.sql",
+			"README.md",
+		} {
+			require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name), nil, 0o600))
+		}
+		cleanup := setupMigrationsPath(t, tmpDir)
+		defer cleanup()
+
+		version, err := LatestVersion()
+		require.NoError(t, err)
+		assert.Equal(t, uint(12), version)
+	})
+
+	t.Run("repository migrations", func(t *testing.T) {
+		cleanup := setupMigrationsPath(t, "../../../migrations")
+		defer cleanup()
+
+		version, err := LatestVersion()
+		require.NoError(t, err)
+		assert.Positive(t, version)
+	})
+
+	t.Run("empty directory", func(t *testing.T) {
+		cleanup := setupMigrationsPath(t, t.TempDir())
+		defer cleanup()
+
+		_, err := LatestVersion()
+		assert.ErrorContains(t, err, "no migrations found")
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		cleanup := setupMigrationsPath(t, "/non/existent/path")
+		defer cleanup()
+
+		_, err := LatestVersion()
+		assert.Error(t, err)
+	})
+}
+
+func TestCurrentVersion(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no migrations applied", func(t *testing.T) {
+		db := createTestDB(t)
+		defer closeTestDB(t, db)
+
+		version, dirty, err := CurrentVersion(ctx, db)
+		require.NoError(t, err)
+		assert.Zero(t, version)
+		assert.False(t, dirty)
+	})
+
+	t.Run("applied version", func(t *testing.T) {
+		db := createTestDB(t)
+		defer closeTestDB(t, db)
+		require.NoError(t, db.Exec(
+			"CREATE TABLE schema_migrations (version bigint NOT NULL, dirty boolean NOT NULL)",
+		).Error)
+		require.NoError(t, db.Exec("INSERT INTO schema_migrations (version, dirty) VALUES (7, true)").Error)
+
+		version, dirty, err := CurrentVersion(ctx, db)
+		require.NoError(t, err)
+		assert.Equal(t, uint(7), version)
+		assert.True(t, dirty)
+	})
+
+	t.Run("nil database", func(t *testing.T) {
+		_, _, err := CurrentVersion(ctx, nil)
+		assert.ErrorContains(t, err, "database connection is nil")
+	})
+}
+
+func TestPreviousVersion(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, name := range []string{
+		"000001_create_teams.up.sql",
+		"000001_create_teams.down.sql",
+		"000003_add_users.up.sql",
+		"000007_add_events.up.sql",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name), nil, 0o600))
+	}
+	cleanup := setupMigrationsPath(t, tmpDir)
+	defer cleanup()
+
+	tests := []struct {
+		version  uint
+		expected uint
+	}{
+		{version: 7, expected: 3},
+		{version: 5, expected: 3},
+		{version: 3, expected: 1},
+		{version: 1, expected: 0},
+	}
+	for _, tt := range tests {
+		previous, err := previousVersion(tt.version)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, previous, "version %d", tt.version)
+	}
+
+	cleanupMissing := setupMigrationsPath(t, "/non/existent/path")
+	defer cleanupMissing()
+	_, err := previousVersion(7)
+	assert.Error(t, err)
+}
@@ -0,0 +1,287 @@
+// Package export dumps and restores teams, users, pull requests and reviewer assignments for backup
+// or migration between environments.
+package export
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/festy23/avito_internship/pkg/clock"
+)
+
+// Export formats.
+const (
+	// FormatJSON is a single JSON document with an array per table.
+	FormatJSON = "json"
+	// FormatNDJSON is a stream of JSON lines, one per record, ending with an "end" line.
+	FormatNDJSON = "ndjson"
+)
+
+// ErrInvalidFormat indicates that the requested export format is not supported.
+var ErrInvalidFormat = errors.New("format must be json or ndjson")
+
+// bufferSize is the size of the write buffer between the database cursor and the client.
+const bufferSize = 32 << 10
+
+// Team is an exported team.
+type Team struct {
+	TeamName            string    `gorm:"column:team_name"                       json:"team_name"`
+	LeadUserID          *string   `gorm:"column:lead_user_id"                    json:"lead_user_id"`
+	SLAFirstReviewHours *int      `gorm:"column:sla_first_review_hours"          json:"sla_first_review_hours"`
+	SLAMergeHours       *int      `gorm:"column:sla_merge_hours"                 json:"sla_merge_hours"`
+	AutoCloseDisabled   bool      `gorm:"column:auto_close_disabled" json:"auto_close_disabled"`
+	TenantID            string    `gorm:"column:tenant_id"                       json:"tenant_id"`
+	CreatedAt           time.Time `gorm:"column:created_at;autoCreateTime:false" json:"created_at"`
+	UpdatedAt           time.Time `gorm:"column:updated_at;autoUpdateTime:false" json:"updated_at"`
+}
+
+// User is an exported user.
+type User struct {
+	UserID    string    `gorm:"column:user_id"                         json:"user_id"`
+	Username  string    `gorm:"column:username"                        json:"username"`
+	TeamName  string    `gorm:"column:team_name"                       json:"team_name"`
+	IsActive  bool      `gorm:"column:is_active"                       json:"is_active"`
+	TenantID  string    `gorm:"column:tenant_id"                       json:"tenant_id"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime:false" json:"created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime:false" json:"updated_at"`
+}
+
+// PullRequest is an exported pull request.
+type PullRequest struct {
+	PullRequestID   string     `gorm:"column:pull_request_id"                 json:"pull_request_id"`
+	PullRequestName string     `gorm:"column:pull_request_name"               json:"pull_request_name"`
+	AuthorID        string     `gorm:"column:author_id"                       json:"author_id"`
+	Status          string     `gorm:"column:status"                          json:"status"`
+	SourceBranch    *string    `gorm:"column:source_branch"                   json:"source_branch"`
+	TargetBranch    *string    `gorm:"column:target_branch"                   json:"target_branch"`
+	PullRequestURL  *string    `gorm:"column:pull_request_url"                json:"pull_request_url"`
+	LinesAdded      int        `gorm:"column:lines_added"                     json:"lines_added"`
+	LinesRemoved    int        `gorm:"column:lines_removed"                   json:"lines_removed"`
+	HasConflicts    bool       `gorm:"column:has_conflicts"                   json:"has_conflicts"`
+	TenantID        string     `gorm:"column:tenant_id"                       json:"tenant_id"`
+	TeamName        *string    `gorm:"column:team_name"                       json:"team_name"`
+	CreatedAt       time.Time  `gorm:"column:created_at;autoCreateTime:false" json:"created_at"`
+	MergedAt        *time.Time `gorm:"column:merged_at"                       json:"merged_at"`
+	ArchivedAt      *time.Time `gorm:"column:archived_at"                     json:"archived_at"`
+	CloseReason     *string    `gorm:"column:close_reason"                    json:"close_reason"`
+	CloseNote       *string    `gorm:"column:close_note"                      json:"close_note"`
+}
+
+// Reviewer is an exported reviewer assignment.
+type Reviewer struct {
+	PullRequestID string    `gorm:"column:pull_request_id" json:"pull_request_id"`
+	UserID        string    `gorm:"column:user_id"         json:"user_id"`
+	AssignedAt    time.Time `gorm:"column:assigned_at"     json:"assigned_at"`
+}
+
+// Counts holds the number of exported records per table.
+type Counts struct {
+	Teams        int64 `json:"teams"`
+	Users        int64 `json:"users"`
+	PullRequests int64 `json:"pull_requests"`
+	Reviewers    int64 `json:"reviewers"`
+}
+
+// section describes how one table is exported.
+type section struct {
+	// name is the key of the table in the JSON document.
+	name string
+	// kind is the record type of the table's lines in NDJSON.
+	kind   string
+	table  string
+	order  string
+	record func() any
+	count  func(c *Counts) *int64
+}
+
+// sections lists exported tables in the order they can be imported: referenced rows come first.
+var sections = []section{
+	{
+		name: "teams", kind: "team", table: "teams", order: "team_name",
+		record: func() any { return &Team{} },
+		count:  func(c *Counts) *int64 { return &c.Teams },
+	},
+	{
+		name: "users", kind: "user", table: "users", order: "user_id",
+		record: func() any { return &User{} },
+		count:  func(c *Counts) *int64 { return &c.Users },
+	},
+	{
+		name: "pull_requests", kind: "pull_request", table: "pull_requests", order: "pull_request_id",
+		record: func() any { return &PullRequest{} },
+		count:  func(c *Counts) *int64 { return &c.PullRequests },
+	},
+	{
+		name: "reviewers", kind: "reviewer", table: "pull_request_reviewers", order: "pull_request_id, id",
+		record: func() any { return &Reviewer{} },
+		count:  func(c *Counts) *int64 { return &c.Reviewers },
+	},
+}
+
+// Exporter writes dumps of all tenants' data.
+type Exporter struct {
+	db     *gorm.DB
+	clock  clock.Clock
+	logger *zap.SugaredLogger
+}
+
+// New creates a new exporter instance.
+func New(db *gorm.DB, logger *zap.SugaredLogger) *Exporter {
+	return NewWithClock(db, clock.New(), logger)
+}
+
+// NewWithClock creates a new exporter instance that takes the export time from clk.
+func NewWithClock(db *gorm.DB, clk clock.Clock, logger *zap.SugaredLogger) *Exporter {
+	return &Exporter{db: db, clock: clk, logger: logger}
+}
+
+// ValidateFormat checks that format is FormatJSON or FormatNDJSON.
+func ValidateFormat(format string) error {
+	if format != FormatJSON && format != FormatNDJSON {
+		return ErrInvalidFormat
+	}
+	return nil
+}
+
+// Export writes a dump in format to w and returns the number of exported records.
+// Tables are read in one read-only transaction, so the dump is a consistent snapshot, and streamed
+// row by row through a small buffer, so memory use does not depend on the size of the data.
+// Nothing is written to w if the export fails before the buffer is first flushed.
+func (e *Exporter) Export(ctx context.Context, w io.Writer, format string) (Counts, error) {
+	var counts Counts
+	if err := ValidateFormat(format); err != nil {
+		return counts, err
+	}
+
+	bw := bufio.NewWriterSize(w, bufferSize)
+	enc := &encoder{w: bw, enc: json.NewEncoder(bw), ndjson: format == FormatNDJSON}
+	snapshot := &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+
+	err := e.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := enc.begin(e.clock.Now().UTC()); err != nil {
+			return err
+		}
+		for _, s := range sections {
+			n, err := exportSection(tx, enc, s)
+			*s.count(&counts) = n
+			if err != nil {
+				return err
+			}
+		}
+		return enc.end(counts)
+	}, snapshot)
+	if err == nil {
+		err = bw.Flush()
+	}
+	if err != nil {
+		e.logger.Errorw("export failed", "format", format, "error", err)
+		return counts, err
+	}
+
+	e.logger.Infow("export completed", "format", format, "counts", counts)
+	return counts, nil
+}
+
+// exportSection streams the rows of a table to enc and returns their number.
+func exportSection(tx *gorm.DB, enc *encoder, s section) (int64, error) {
+	rows, err := tx.Table(s.table).Order(s.order).Rows()
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	if err = enc.beginSection(s); err != nil {
+		return 0, err
+	}
+	var n int64
+	for rows.Next() {
+		record := s.record()
+		if err = tx.ScanRows(rows, record); err != nil {
+			return n, err
+		}
+		if err = enc.record(s, record, n == 0); err != nil {
+			return n, err
+		}
+		n++
+	}
+	if err = rows.Err(); err != nil {
+		return n, err
+	}
+	return n, enc.endSection()
+}
+
+// encoder writes the parts of a dump in the JSON or NDJSON format.
+type encoder struct {
+	w      *bufio.Writer
+	enc    *json.Encoder
+	ndjson bool
+}
+
+// ndjsonLine is a line of an NDJSON dump.
+type ndjsonLine struct {
+	Type       string     `json:"type"`
+	ExportedAt *time.Time `json:"exported_at,omitempty"`
+	Data       any        `json:"data,omitempty"`
+	Counts     *Counts    `json:"counts,omitempty"`
+}
+
+func (e *encoder) begin(exportedAt time.Time) error {
+	if e.ndjson {
+		return e.enc.Encode(ndjsonLine{Type: "header", ExportedAt: &exportedAt})
+	}
+	if _, err := e.w.WriteString(`{"exported_at":`); err != nil {
+		return err
+	}
+	return e.enc.Encode(exportedAt)
+}
+
+func (e *encoder) beginSection(s section) error {
+	if e.ndjson {
+		return nil
+	}
+	_, err := e.w.WriteString(`,"` + s.name + `":[`)
+	return err
+}
+
+func (e *encoder) record(s section, record any, first bool) error {
+	if e.ndjson {
+		return e.enc.Encode(ndjsonLine{Type: s.kind, Data: record})
+	}
+	if !first {
+		if err := e.w.WriteByte(','); err != nil {
+			return err
+		}
+	}
+	return e.enc.Encode(record)
+}
+
+func (e *encoder) endSection() error {
+	if e.ndjson {
+		return nil
+	}
+	return e.w.WriteByte(']')
+}
+
+// end writes the record counts; a dump without them is incomplete.
+func (e *encoder) end(counts Counts) error {
+	if e.ndjson {
+		return e.enc.Encode(ndjsonLine{Type: "end", Counts: &counts})
+	}
+	if _, err := e.w.WriteString(`,"counts":`); err != nil {
+		return err
+	}
+	if err := e.enc.Encode(counts); err != nil {
+		return err
+	}
+	return e.w.WriteByte('}')
+}
@@ -0,0 +1,142 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/festy23/avito_internship/internal/testutil"
+	"github.com/festy23/avito_internship/pkg/clock"
+)
+
+var exportedAt = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func setupExporter(t *testing.T) (*Exporter, *gorm.DB) {
+	db := testutil.NewDB(t)
+	testutil.NewTeam().WithMembers(3).WithLead("u1").WithSLA(4, 48).Create(t, db)
+	testutil.NewTeam().Named("frontend").WithMemberPrefix("f").WithMembers(1).Create(t, db)
+	testutil.NewPR().WithID("pr-1").ByAuthor("u1").WithReviewers("u2", "u3").Create(t, db)
+	testutil.NewPR().WithID("pr-2").ByAuthor("u2").WithReviewers("u3").Merged().Create(t, db)
+	return NewWithClock(db, clock.NewFake(exportedAt), zap.NewNop().Sugar()), db
+}
+
+// dump mirrors the JSON export format.
+type dump struct {
+	ExportedAt   time.Time     `json:"exported_at"`
+	Teams        []Team        `json:"teams"`
+	Users        []User        `json:"users"`
+	PullRequests []PullRequest `json:"pull_requests"`
+	Reviewers    []Reviewer    `json:"reviewers"`
+	Counts       Counts        `json:"counts"`
+}
+
+var wantCounts = Counts{Teams: 2, Users: 4, PullRequests: 2, Reviewers: 3}
+
+func TestExporter_ExportJSON(t *testing.T) {
+	exporter, _ := setupExporter(t)
+
+	var buf bytes.Buffer
+	counts, err := exporter.Export(context.Background(), &buf, FormatJSON)
+	require.NoError(t, err)
+	assert.Equal(t, wantCounts, counts)
+
+	var got dump
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got), buf.String())
+	assert.True(t, exportedAt.Equal(got.ExportedAt))
+	assert.Equal(t, wantCounts, got.Counts)
+
+	require.Len(t, got.Teams, 2)
+	assert.Equal(t, "backend", got.Teams[0].TeamName)
+	require.NotNil(t, got.Teams[0].LeadUserID)
+	assert.Equal(t, "u1", *got.Teams[0].LeadUserID)
+	require.NotNil(t, got.Teams[0].SLAMergeHours)
+	assert.Equal(t, 48, *got.Teams[0].SLAMergeHours)
+	assert.Equal(t, "default", got.Teams[0].TenantID)
+
+	require.Len(t, got.Users, 4)
+	assert.Equal(t, []string{"f1", "u1", "u2", "u3"}, []string{
+		got.Users[0].UserID, got.Users[1].UserID, got.Users[2].UserID, got.Users[3].UserID,
+	})
+
+	require.Len(t, got.PullRequests, 2)
+	assert.Equal(t, "OPEN", got.PullRequests[0].Status)
+	assert.Equal(t, "MERGED", got.PullRequests[1].Status)
+	assert.NotNil(t, got.PullRequests[1].MergedAt)
+
+	require.Len(t, got.Reviewers, 3)
+	assert.Equal(t, Reviewer{PullRequestID: "pr-1", UserID: "u2"}, Reviewer{
+		PullRequestID: got.Reviewers[0].PullRequestID, UserID: got.Reviewers[0].UserID,
+	})
+}
+
+func TestExporter_ExportNDJSON(t *testing.T) {
+	exporter, _ := setupExporter(t)
+
+	var buf bytes.Buffer
+	counts, err := exporter.Export(context.Background(), &buf, FormatNDJSON)
+	require.NoError(t, err)
+	assert.Equal(t, wantCounts, counts)
+
+	var types []string
+	var last map[string]json.RawMessage
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var line map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line), scanner.Text())
+		var lineType string
+		require.NoError(t, json.Unmarshal(line["type"], &lineType))
+		types = append(types, lineType)
+		last = line
+	}
+	require.NoError(t, scanner.Err())
+
+	assert.Equal(t, []string{
+		"header", "team", "team", "user", "user", "user", "user",
+		"pull_request", "pull_request", "reviewer", "reviewer", "reviewer", "end",
+	}, types)
+	var endCounts Counts
+	require.NoError(t, json.Unmarshal(last["counts"], &endCounts))
+	assert.Equal(t, wantCounts, endCounts)
+}
+
+func TestExporter_ExportEmpty(t *testing.T) {
+	exporter := NewWithClock(testutil.NewDB(t), clock.NewFake(exportedAt), zap.NewNop().Sugar())
+
+	var buf bytes.Buffer
+	_, err := exporter.Export(context.Background(), &buf, FormatJSON)
+	require.NoError(t, err)
+
+	var got map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got), buf.String())
+	assert.JSONEq(t, `[]`, string(got["teams"]))
+	assert.JSONEq(t, `{"teams":0,"users":0,"pull_requests":0,"reviewers":0}`, string(got["counts"]))
+}
+
+func TestExporter_ExportErrors(t *testing.T) {
+	t.Run("invalid format", func(t *testing.T) {
+		exporter, _ := setupExporter(t)
+		var buf bytes.Buffer
+		_, err := exporter.Export(context.Background(), &buf, "csv")
+		assert.ErrorIs(t, err, ErrInvalidFormat)
+		assert.Zero(t, buf.Len())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		exporter, db := setupExporter(t)
+		require.NoError(t, db.Migrator().DropTable("pull_request_reviewers"))
+
+		var buf bytes.Buffer
+		counts, err := exporter.Export(context.Background(), &buf, FormatNDJSON)
+		require.Error(t, err)
+		assert.Equal(t, int64(2), counts.PullRequests, "sections before the failure are counted")
+		assert.Zero(t, buf.Len(), "a small dump is not written before the failure")
+	})
+}
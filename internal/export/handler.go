@@ -0,0 +1,110 @@
+package export
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/festy23/avito_internship/pkg/apierror"
+)
+
+// Handler exposes the data export and import over HTTP.
+type Handler struct {
+	exporter      *Exporter
+	importer      *Importer
+	maxImportSize int64
+}
+
+// NewHandler creates a new export handler instance; import request bodies are limited to maxImportSize bytes.
+func NewHandler(exporter *Exporter, importer *Importer, maxImportSize int64) *Handler {
+	return &Handler{exporter: exporter, importer: importer, maxImportSize: maxImportSize}
+}
+
+// ImportResponse represents the response of a completed import.
+type ImportResponse struct {
+	Mode   string `json:"mode"`
+	Counts Counts `json:"counts"`
+}
+
+// ErrorResponse represents error response structure.
+type ErrorResponse = apierror.Response
+
+// errorRegistry maps errors of the import endpoint.
+var errorRegistry = apierror.Registry{}.
+	Register(ErrInvalidFormat, apierror.InvalidField("format", "oneof", "")).
+	Register(ErrInvalidMode, apierror.InvalidField("mode", "oneof", "")).
+	Register(ErrInvalidDump, apierror.InvalidRequest("")).
+	RegisterFunc(func(err error) bool {
+		var tooLarge *http.MaxBytesError
+		return errors.As(err, &tooLarge)
+	}, apierror.New(apierror.CodePayloadTooLarge, http.StatusRequestEntityTooLarge, "dump is too large"))
+
+// contentTypes maps export formats to the content types of responses.
+var contentTypes = map[string]string{
+	FormatJSON:   "application/json; charset=utf-8",
+	FormatNDJSON: "application/x-ndjson",
+}
+
+// Export handles GET /admin/export request.
+// The dump is streamed as it is read; an error after the first bytes are sent can only be
+// reported by closing the connection, so clients must check for the trailing counts.
+// @Summary Export all data
+// @Tags Admin
+// @Produce json
+// @Produce x-ndjson
+// @Param format query string false "Dump format" Enums(json, ndjson) default(json)
+// @Success 200 {file} file "Dump of teams, users, pull requests and reviewer assignments"
+// @Failure 400 {object} ErrorResponse "Invalid format"
+// @Failure 401 {object} ErrorResponse "Missing or invalid admin token"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/export [get] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) Export(c *gin.Context) {
+	format := c.DefaultQuery("format", FormatJSON)
+	if err := ValidateFormat(format); err != nil {
+		errorRegistry.Fail(c, err)
+		return
+	}
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", contentTypes[format])
+	header.Set("Content-Disposition", `attachment; filename="export.`+format+`"`)
+	c.Status(http.StatusOK)
+
+	if _, err := h.exporter.Export(c.Request.Context(), c.Writer, format); err != nil {
+		if !c.Writer.Written() {
+			header.Del("Content-Type")
+			header.Del("Content-Disposition")
+			apierror.Fail(c, err)
+			return
+		}
+		_ = c.Error(err)
+		c.Abort()
+	}
+}
+
+// Import handles POST /admin/import request.
+// The body is a dump produced by GET /admin/export; it is validated and loaded in one transaction.
+// @Summary Import data
+// @Tags Admin
+// @Accept json
+// @Accept x-ndjson
+// @Produce json
+// @Param format query string false "Dump format" Enums(json, ndjson) default(json)
+// @Param mode query string false "Merge into existing data or replace all of it" Enums(merge, replace) default(merge)
+// @Success 200 {object} ImportResponse
+// @Failure 400 {object} ErrorResponse "Invalid format, mode or dump"
+// @Failure 401 {object} ErrorResponse "Missing or invalid admin token"
+// @Failure 413 {object} ErrorResponse "Dump is too large"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/import [post] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) Import(c *gin.Context) {
+	mode := c.DefaultQuery("mode", ModeMerge)
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, h.maxImportSize)
+	counts, err := h.importer.Import(c.Request.Context(), body, c.DefaultQuery("format", FormatJSON), mode)
+	if err != nil {
+		errorRegistry.Fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ImportResponse{Mode: mode, Counts: counts})
+}
@@ -0,0 +1,111 @@
+package export
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func setupRouter(t *testing.T) (*gin.Engine, *Exporter) {
+	gin.SetMode(gin.TestMode)
+	exporter, _ := setupExporter(t)
+	r := gin.New()
+	handler := NewHandler(exporter, NewImporter(exporter.db, zap.NewNop().Sugar()), 1<<20)
+	r.GET("/admin/export", handler.Export)
+	r.POST("/admin/import", handler.Import)
+	return r, exporter
+}
+
+func TestHandler_Export(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		contentType string
+		filename    string
+	}{
+		{name: "default json", query: "", contentType: "application/json; charset=utf-8", filename: "export.json"},
+		{name: "ndjson", query: "?format=ndjson", contentType: "application/x-ndjson", filename: "export.ndjson"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := setupRouter(t)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/export"+tt.query, nil))
+
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+			assert.Equal(t, tt.contentType, w.Header().Get("Content-Type"))
+			assert.Contains(t, w.Header().Get("Content-Disposition"), tt.filename)
+			assert.Contains(t, w.Body.String(), `"counts":{"teams":2,"users":4,"pull_requests":2,"reviewers":3}`)
+		})
+	}
+}
+
+func TestHandler_ExportErrors(t *testing.T) {
+	t.Run("invalid format", func(t *testing.T) {
+		r, _ := setupRouter(t)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/export?format=csv", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `"field":"format"`)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		r, exporter := setupRouter(t)
+		require.NoError(t, exporter.db.Migrator().DropTable("teams"))
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/export", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.True(t, strings.HasPrefix(w.Header().Get("Content-Type"), "application/json"))
+		assert.Empty(t, w.Header().Get("Content-Disposition"))
+		assert.Contains(t, w.Body.String(), `"code":"INTERNAL_ERROR"`)
+	})
+}
+
+func TestHandler_Import(t *testing.T) {
+	r, exporter := setupRouter(t)
+	dump := exportDump(t, exporter.db, FormatNDJSON)
+
+	tests := []struct {
+		name   string
+		query  string
+		body   string
+		status int
+		want   string
+	}{
+		{
+			name: "ndjson replace", query: "?format=ndjson&mode=replace", body: string(dump), status: http.StatusOK,
+			want: `{"mode":"replace","counts":{"teams":2,"users":4,"pull_requests":2,"reviewers":3}}`,
+		},
+		{name: "invalid mode", query: "?mode=append", body: "{}", status: http.StatusBadRequest, want: `"field":"mode"`},
+		{
+			name: "invalid format", query: "?format=csv", body: "{}", status: http.StatusBadRequest,
+			want: `"field":"format"`,
+		},
+		{
+			name: "incomplete dump", query: "?format=ndjson", body: string(dump[:len(dump)/2]),
+			status: http.StatusBadRequest, want: "invalid dump",
+		},
+		{
+			name: "dump too large", query: "", body: `{"teams":[` + strings.Repeat(" ", 1<<20) + `]}`,
+			status: http.StatusRequestEntityTooLarge, want: `"code":"PAYLOAD_TOO_LARGE"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/import"+tt.query, strings.NewReader(tt.body)))
+
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+			assert.Contains(t, w.Body.String(), tt.want)
+		})
+	}
+}
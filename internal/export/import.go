@@ -0,0 +1,389 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	pullrequestModel "github.com/festy23/avito_internship/internal/pullrequest/model"
+	"github.com/festy23/avito_internship/internal/tenant"
+)
+
+// Import modes.
+const (
+	// ModeMerge inserts records of the dump and updates existing ones; rows absent from the dump are kept.
+	// Reviewers of imported pull requests are replaced by those of the dump.
+	ModeMerge = "merge"
+	// ModeReplace deletes all teams, users and pull requests, including their watchers, comments,
+	// activity log and SLA violations, and loads the dump in their place.
+	ModeReplace = "replace"
+)
+
+// Import errors.
+var (
+	// ErrInvalidMode indicates that the requested import mode is not supported.
+	ErrInvalidMode = errors.New("mode must be merge or replace")
+	// ErrInvalidDump indicates that the dump is malformed, incomplete or breaks referential integrity.
+	ErrInvalidDump = errors.New("invalid dump")
+)
+
+// importBatchSize is the number of records inserted by one statement.
+const importBatchSize = 500
+
+// wipedTables lists the tables cleared in ModeReplace, referencing rows first.
+// Team leads reference users and are unset before users are deleted.
+var wipedTables = []string{
+	"sla_violations", "pull_request_events", "pull_request_comments", "pull_request_watchers",
+	"pull_request_reviewers", "pull_requests", "user_activation_history", "users", "teams",
+}
+
+// Dump is a decoded export in either format.
+type Dump struct {
+	ExportedAt   time.Time     `json:"exported_at"`
+	Teams        []Team        `json:"teams"`
+	Users        []User        `json:"users"`
+	PullRequests []PullRequest `json:"pull_requests"`
+	Reviewers    []Reviewer    `json:"reviewers"`
+	// Counts is written last by the exporter; a dump without it was cut off.
+	Counts *Counts `json:"counts"`
+}
+
+// Importer loads dumps produced by Exporter.
+type Importer struct {
+	db     *gorm.DB
+	logger *zap.SugaredLogger
+}
+
+// NewImporter creates a new importer instance.
+func NewImporter(db *gorm.DB, logger *zap.SugaredLogger) *Importer {
+	return &Importer{db: db, logger: logger}
+}
+
+// ValidateMode checks that mode is ModeMerge or ModeReplace.
+func ValidateMode(mode string) error {
+	if mode != ModeMerge && mode != ModeReplace {
+		return ErrInvalidMode
+	}
+	return nil
+}
+
+// Import reads a dump in format from r, validates it and loads it in mode in one transaction:
+// either the whole dump is loaded or nothing changes. The dump is held in memory while it is validated.
+func (i *Importer) Import(ctx context.Context, r io.Reader, format, mode string) (Counts, error) {
+	if err := ValidateFormat(format); err != nil {
+		return Counts{}, err
+	}
+	if err := ValidateMode(mode); err != nil {
+		return Counts{}, err
+	}
+
+	dump, err := Decode(r, format)
+	if err != nil {
+		return Counts{}, err
+	}
+	if err = dump.Validate(); err != nil {
+		return Counts{}, err
+	}
+
+	err = i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if mode == ModeReplace {
+			if wipeErr := wipe(tx); wipeErr != nil {
+				return wipeErr
+			}
+		}
+		return load(tx, dump, mode == ModeMerge)
+	})
+	if err != nil {
+		i.logger.Errorw("import failed", "mode", mode, "error", err)
+		return Counts{}, err
+	}
+
+	i.logger.Infow("import completed", "mode", mode, "exported_at", dump.ExportedAt, "counts", *dump.Counts)
+	return *dump.Counts, nil
+}
+
+// Decode reads a dump in format from r. The dump is not validated.
+func Decode(r io.Reader, format string) (*Dump, error) {
+	if format == FormatNDJSON {
+		return decodeNDJSON(r)
+	}
+	var dump Dump
+	if err := json.NewDecoder(r).Decode(&dump); err != nil {
+		return nil, fmt.Errorf("%w: malformed JSON: %w", ErrInvalidDump, err)
+	}
+	return &dump, nil
+}
+
+// ndjsonRecord is a line of an NDJSON dump with undecoded data.
+type ndjsonRecord struct {
+	Type       string          `json:"type"`
+	ExportedAt time.Time       `json:"exported_at"`
+	Data       json.RawMessage `json:"data"`
+	Counts     *Counts         `json:"counts"`
+}
+
+func decodeNDJSON(r io.Reader) (*Dump, error) {
+	dump := &Dump{}
+	dec := json.NewDecoder(r)
+	for line := 1; ; line++ {
+		var record ndjsonRecord
+		err := dec.Decode(&record)
+		if errors.Is(err, io.EOF) {
+			return dump, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: malformed JSON: %w", ErrInvalidDump, line, err)
+		}
+		if dump.Counts != nil {
+			return nil, invalidDump("line %d: record after the end line", line)
+		}
+		if err = dump.add(record); err != nil {
+			return nil, invalidDump("line %d: %v", line, err)
+		}
+	}
+}
+
+// add adds a decoded NDJSON line to the dump.
+func (d *Dump) add(record ndjsonRecord) error {
+	var err error
+	switch record.Type {
+	case "header":
+		d.ExportedAt = record.ExportedAt
+	case "team":
+		d.Teams, err = appendRecord(d.Teams, record.Data)
+	case "user":
+		d.Users, err = appendRecord(d.Users, record.Data)
+	case "pull_request":
+		d.PullRequests, err = appendRecord(d.PullRequests, record.Data)
+	case "reviewer":
+		d.Reviewers, err = appendRecord(d.Reviewers, record.Data)
+	case "end":
+		if record.Counts == nil {
+			return errors.New("end line without counts")
+		}
+		d.Counts = record.Counts
+	default:
+		return fmt.Errorf("unknown record type %q", record.Type)
+	}
+	return err
+}
+
+func appendRecord[T any](records []T, data json.RawMessage) ([]T, error) {
+	var record T
+	if err := json.Unmarshal(data, &record); err != nil {
+		return records, fmt.Errorf("malformed data: %w", err)
+	}
+	return append(records, record), nil
+}
+
+// Validate checks that the dump is complete and that its records reference each other consistently.
+// References to rows that are in the database but not in the dump are rejected: a dump is self-contained.
+func (d *Dump) Validate() error {
+	got := Counts{
+		Teams:        int64(len(d.Teams)),
+		Users:        int64(len(d.Users)),
+		PullRequests: int64(len(d.PullRequests)),
+		Reviewers:    int64(len(d.Reviewers)),
+	}
+	if d.Counts == nil {
+		return invalidDump("counts are missing, the dump is incomplete")
+	}
+	if *d.Counts != got {
+		return invalidDump("counts %+v do not match the records %+v, the dump is incomplete", *d.Counts, got)
+	}
+
+	teams, err := d.validateTeams()
+	if err != nil {
+		return err
+	}
+	users, err := d.validateUsers(teams)
+	if err != nil {
+		return err
+	}
+	for _, team := range d.Teams {
+		if team.LeadUserID != nil && !users[*team.LeadUserID] {
+			return invalidDump("team %q: lead %q is not in the dump", team.TeamName, *team.LeadUserID)
+		}
+	}
+	pullRequests, err := d.validatePullRequests(users)
+	if err != nil {
+		return err
+	}
+	return d.validateReviewers(users, pullRequests)
+}
+
+func (d *Dump) validateTeams() (map[string]bool, error) {
+	teams := make(map[string]bool, len(d.Teams))
+	for _, team := range d.Teams {
+		if team.TeamName == "" {
+			return nil, invalidDump("team without team_name")
+		}
+		if teams[team.TeamName] {
+			return nil, invalidDump("duplicate team %q", team.TeamName)
+		}
+		teams[team.TeamName] = true
+	}
+	return teams, nil
+}
+
+func (d *Dump) validateUsers(teams map[string]bool) (map[string]bool, error) {
+	users := make(map[string]bool, len(d.Users))
+	for _, user := range d.Users {
+		if user.UserID == "" {
+			return nil, invalidDump("user without user_id")
+		}
+		if users[user.UserID] {
+			return nil, invalidDump("duplicate user %q", user.UserID)
+		}
+		if !teams[user.TeamName] {
+			return nil, invalidDump("user %q: team %q is not in the dump", user.UserID, user.TeamName)
+		}
+		users[user.UserID] = true
+	}
+	return users, nil
+}
+
+func (d *Dump) validatePullRequests(users map[string]bool) (map[string]bool, error) {
+	pullRequests := make(map[string]bool, len(d.PullRequests))
+	for _, pr := range d.PullRequests {
+		if pr.PullRequestID == "" {
+			return nil, invalidDump("pull request without pull_request_id")
+		}
+		if pullRequests[pr.PullRequestID] {
+			return nil, invalidDump("duplicate pull request %q", pr.PullRequestID)
+		}
+		if !users[pr.AuthorID] {
+			return nil, invalidDump("pull request %q: author %q is not in the dump", pr.PullRequestID, pr.AuthorID)
+		}
+		if err := pullrequestModel.ValidateStatus(pr.Status); err != nil {
+			return nil, invalidDump("pull request %q: %v", pr.PullRequestID, err)
+		}
+		if (pr.Status == pullrequestModel.StatusMERGED) != (pr.MergedAt != nil) {
+			return nil, invalidDump("pull request %q: merged_at must be set exactly for merged pull requests",
+				pr.PullRequestID)
+		}
+		pullRequests[pr.PullRequestID] = true
+	}
+	return pullRequests, nil
+}
+
+func (d *Dump) validateReviewers(users, pullRequests map[string]bool) error {
+	assigned := make(map[Reviewer]bool, len(d.Reviewers))
+	for _, reviewer := range d.Reviewers {
+		if !pullRequests[reviewer.PullRequestID] {
+			return invalidDump("reviewer %q: pull request %q is not in the dump",
+				reviewer.UserID, reviewer.PullRequestID)
+		}
+		if !users[reviewer.UserID] {
+			return invalidDump("pull request %q: reviewer %q is not in the dump",
+				reviewer.PullRequestID, reviewer.UserID)
+		}
+		key := Reviewer{PullRequestID: reviewer.PullRequestID, UserID: reviewer.UserID}
+		if assigned[key] {
+			return invalidDump("pull request %q: duplicate reviewer %q", reviewer.PullRequestID, reviewer.UserID)
+		}
+		assigned[key] = true
+	}
+	return nil
+}
+
+func invalidDump(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidDump, fmt.Sprintf(format, args...))
+}
+
+// wipe deletes all rows of the tables restored by import.
+func wipe(tx *gorm.DB) error {
+	if err := tx.Exec("UPDATE teams SET lead_user_id = NULL").Error; err != nil {
+		return err
+	}
+	for _, table := range wipedTables {
+		if err := tx.Exec("DELETE FROM " + table).Error; err != nil {
+			return fmt.Errorf("clear %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// load inserts the records of a validated dump; with upsert, existing rows are updated.
+// Timestamps are restored from the dump rather than set to the time of the import.
+func load(tx *gorm.DB, dump *Dump, upsert bool) error {
+	// Users reference teams and team leads reference users, so leads are set once users exist
+	teams := make([]Team, len(dump.Teams))
+	for i, team := range dump.Teams {
+		team.LeadUserID = nil
+		team.TenantID = tenantOrDefault(team.TenantID)
+		teams[i] = team
+	}
+	if err := insert(tx, "teams", teams, "team_name", upsert); err != nil {
+		return err
+	}
+
+	users := make([]User, len(dump.Users))
+	for i, user := range dump.Users {
+		user.TenantID = tenantOrDefault(user.TenantID)
+		users[i] = user
+	}
+	if err := insert(tx, "users", users, "user_id", upsert); err != nil {
+		return err
+	}
+	for _, team := range dump.Teams {
+		if team.LeadUserID == nil {
+			continue
+		}
+		err := tx.Table("teams").Where("team_name = ?", team.TeamName).Update("lead_user_id", team.LeadUserID).Error
+		if err != nil {
+			return fmt.Errorf("set lead of team %s: %w", team.TeamName, err)
+		}
+	}
+
+	pullRequests := make([]PullRequest, len(dump.PullRequests))
+	ids := make([]string, len(dump.PullRequests))
+	for i, pr := range dump.PullRequests {
+		pr.TenantID = tenantOrDefault(pr.TenantID)
+		pullRequests[i] = pr
+		ids[i] = pr.PullRequestID
+	}
+	if err := insert(tx, "pull_requests", pullRequests, "pull_request_id", upsert); err != nil {
+		return err
+	}
+
+	if upsert {
+		for start := 0; start < len(ids); start += importBatchSize {
+			batch := ids[start:min(start+importBatchSize, len(ids))]
+			err := tx.Exec("DELETE FROM pull_request_reviewers WHERE pull_request_id IN ?", batch).Error
+			if err != nil {
+				return fmt.Errorf("clear reviewers: %w", err)
+			}
+		}
+	}
+	return insert(tx, "pull_request_reviewers", dump.Reviewers, "", false)
+}
+
+// insert inserts records into table in batches. With upsert, rows conflicting on key are overwritten.
+func insert[T any](tx *gorm.DB, table string, records []T, key string, upsert bool) error {
+	if len(records) == 0 {
+		return nil
+	}
+	query := tx.Table(table)
+	if upsert {
+		query = query.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: key}}, UpdateAll: true})
+	}
+	if err := query.CreateInBatches(records, importBatchSize).Error; err != nil {
+		return fmt.Errorf("insert %s: %w", table, err)
+	}
+	return nil
+}
+
+func tenantOrDefault(id string) string {
+	if id == "" {
+		return tenant.Default
+	}
+	return id
+}
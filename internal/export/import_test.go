@@ -0,0 +1,214 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/festy23/avito_internship/internal/testutil"
+	"github.com/festy23/avito_internship/pkg/clock"
+)
+
+func exportDump(t *testing.T, db *gorm.DB, format string) []byte {
+	var buf bytes.Buffer
+	exporter := NewWithClock(db, clock.NewFake(exportedAt), zap.NewNop().Sugar())
+	_, err := exporter.Export(context.Background(), &buf, format)
+	require.NoError(t, err)
+	return buf.Bytes()
+}
+
+func TestImporter_RoundTrip(t *testing.T) {
+	for _, format := range []string{FormatJSON, FormatNDJSON} {
+		t.Run(format, func(t *testing.T) {
+			_, source := setupExporter(t)
+			dump := exportDump(t, source, format)
+
+			target := testutil.NewDB(t)
+			counts, err := NewImporter(target, zap.NewNop().Sugar()).
+				Import(context.Background(), bytes.NewReader(dump), format, ModeReplace)
+			require.NoError(t, err)
+			assert.Equal(t, wantCounts, counts)
+
+			assert.Equal(t, string(dump), string(exportDump(t, target, format)), "restored data is identical")
+		})
+	}
+}
+
+func TestImporter_Modes(t *testing.T) {
+	_, source := setupExporter(t)
+	dump := exportDump(t, source, FormatJSON)
+
+	setupTarget := func(t *testing.T) *gorm.DB {
+		target := testutil.NewDB(t)
+		testutil.NewTeam().Named("legacy").WithMemberPrefix("l").WithMembers(2).Create(t, target)
+		testutil.NewTeam().WithMembers(3).WithLead("u3").Create(t, target)
+		testutil.NewPR().WithID("pr-1").ByAuthor("u1").WithReviewers("u2").Create(t, target)
+		testutil.NewPR().WithID("pr-legacy").ByAuthor("l1").WithReviewers("l2").Create(t, target)
+		return target
+	}
+
+	t.Run("replace deletes data absent from the dump", func(t *testing.T) {
+		target := setupTarget(t)
+		_, err := NewImporter(target, zap.NewNop().Sugar()).
+			Import(context.Background(), bytes.NewReader(dump), FormatJSON, ModeReplace)
+		require.NoError(t, err)
+
+		assert.Equal(t, string(dump), string(exportDump(t, target, FormatJSON)))
+	})
+
+	t.Run("merge keeps data absent from the dump", func(t *testing.T) {
+		target := setupTarget(t)
+		_, err := NewImporter(target, zap.NewNop().Sugar()).
+			Import(context.Background(), bytes.NewReader(dump), FormatJSON, ModeMerge)
+		require.NoError(t, err)
+
+		var lead string
+		require.NoError(t, target.Table("teams").Where("team_name = ?", "backend").
+			Pluck("lead_user_id", &lead).Error)
+		assert.Equal(t, "u1", lead, "records of the dump overwrite existing ones")
+
+		var reviewers []string
+		require.NoError(t, target.Table("pull_request_reviewers").Where("pull_request_id = ?", "pr-1").
+			Order("user_id").Pluck("user_id", &reviewers).Error)
+		assert.Equal(t, []string{"u2", "u3"}, reviewers, "reviewers of imported pull requests are replaced")
+
+		var counts [3]int64
+		require.NoError(t, target.Table("teams").Count(&counts[0]).Error)
+		require.NoError(t, target.Table("users").Count(&counts[1]).Error)
+		require.NoError(t, target.Table("pull_requests").Count(&counts[2]).Error)
+		assert.Equal(t, [3]int64{3, 6, 3}, counts)
+	})
+}
+
+func TestImporter_InvalidDump(t *testing.T) {
+	const (
+		teams = `"teams":[{"team_name":"backend","lead_user_id":null}]`
+		users = `"users":[{"user_id":"u1","team_name":"backend"},{"user_id":"u2","team_name":"backend"}]`
+		open  = `{"pull_request_id":"pr-1","author_id":"u1","status":"OPEN"}`
+	)
+	dump := func(teams, users, pullRequests, reviewers, counts string) string {
+		return `{` + teams + `,` + users + `,"pull_requests":[` + pullRequests + `],"reviewers":[` + reviewers +
+			`],"counts":` + counts + `}`
+	}
+
+	tests := []struct {
+		name   string
+		format string
+		body   string
+		want   string
+	}{
+		{name: "malformed JSON", format: FormatJSON, body: `{"teams":[`, want: "malformed JSON"},
+		{
+			name: "missing counts", format: FormatJSON,
+			body: `{` + teams + `,` + users + `}`, want: "counts are missing",
+		},
+		{
+			name: "counts mismatch", format: FormatJSON,
+			body: dump(teams, users, "", "", `{"teams":1,"users":3}`), want: "do not match",
+		},
+		{
+			name: "duplicate team", format: FormatJSON,
+			body: dump(`"teams":[{"team_name":"backend"},{"team_name":"backend"}]`, `"users":[]`, "", "",
+				`{"teams":2}`),
+			want: `duplicate team "backend"`,
+		},
+		{
+			name: "unknown team of user", format: FormatJSON,
+			body: dump(teams, `"users":[{"user_id":"u1","team_name":"frontend"}]`, "", "", `{"teams":1,"users":1}`),
+			want: `team "frontend" is not in the dump`,
+		},
+		{
+			name: "unknown lead", format: FormatJSON,
+			body: dump(`"teams":[{"team_name":"backend","lead_user_id":"u9"}]`, users, "", "",
+				`{"teams":1,"users":2}`),
+			want: `lead "u9" is not in the dump`,
+		},
+		{
+			name: "unknown author", format: FormatJSON,
+			body: dump(teams, users, `{"pull_request_id":"pr-1","author_id":"u9","status":"OPEN"}`, "",
+				`{"teams":1,"users":2,"pull_requests":1}`),
+			want: `author "u9" is not in the dump`,
+		},
+		{
+			name: "invalid status", format: FormatJSON,
+			body: dump(teams, users, `{"pull_request_id":"pr-1","author_id":"u1","status":"DECLINED"}`, "",
+				`{"teams":1,"users":2,"pull_requests":1}`),
+			want: "invalid status",
+		},
+		{
+			name: "merged without merged_at", format: FormatJSON,
+			body: dump(teams, users, `{"pull_request_id":"pr-1","author_id":"u1","status":"MERGED"}`, "",
+				`{"teams":1,"users":2,"pull_requests":1}`),
+			want: "merged_at",
+		},
+		{
+			name: "unknown pull request of reviewer", format: FormatJSON,
+			body: dump(teams, users, open, `{"pull_request_id":"pr-9","user_id":"u2"}`,
+				`{"teams":1,"users":2,"pull_requests":1,"reviewers":1}`),
+			want: `pull request "pr-9" is not in the dump`,
+		},
+		{
+			name: "duplicate reviewer", format: FormatJSON,
+			body: dump(teams, users, open,
+				`{"pull_request_id":"pr-1","user_id":"u2"},{"pull_request_id":"pr-1","user_id":"u2"}`,
+				`{"teams":1,"users":2,"pull_requests":1,"reviewers":2}`),
+			want: `duplicate reviewer "u2"`,
+		},
+		{
+			name: "ndjson without end line", format: FormatNDJSON,
+			body: `{"type":"header"}` + "\n" + `{"type":"team","data":{"team_name":"backend"}}` + "\n",
+			want: "counts are missing",
+		},
+		{
+			name: "ndjson unknown type", format: FormatNDJSON,
+			body: `{"type":"header"}` + "\n" + `{"type":"watcher","data":{}}` + "\n",
+			want: `line 2: unknown record type "watcher"`,
+		},
+		{
+			name: "ndjson record after end line", format: FormatNDJSON,
+			body: `{"type":"end","counts":{}}` + "\n" + `{"type":"team","data":{"team_name":"backend"}}` + "\n",
+			want: "line 2: record after the end line",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := testutil.NewDB(t)
+			_, err := NewImporter(db, zap.NewNop().Sugar()).
+				Import(context.Background(), strings.NewReader(tt.body), tt.format, ModeReplace)
+			require.ErrorIs(t, err, ErrInvalidDump)
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestImporter_Errors(t *testing.T) {
+	importer := NewImporter(testutil.NewDB(t), zap.NewNop().Sugar())
+
+	_, err := importer.Import(context.Background(), strings.NewReader("{}"), "csv", ModeMerge)
+	assert.ErrorIs(t, err, ErrInvalidFormat)
+	_, err = importer.Import(context.Background(), strings.NewReader("{}"), FormatJSON, "append")
+	assert.ErrorIs(t, err, ErrInvalidMode)
+
+	t.Run("failed import changes nothing", func(t *testing.T) {
+		_, source := setupExporter(t)
+		dump := exportDump(t, source, FormatJSON)
+
+		target := testutil.NewDB(t)
+		testutil.NewTeam().Named("legacy").WithMembers(1).Create(t, target)
+		require.NoError(t, target.Migrator().DropTable("pull_request_reviewers"))
+
+		_, err := NewImporter(target, zap.NewNop().Sugar()).
+			Import(context.Background(), bytes.NewReader(dump), FormatJSON, ModeMerge)
+		require.Error(t, err)
+
+		var teams []string
+		require.NoError(t, target.Table("teams").Pluck("team_name", &teams).Error)
+		assert.Equal(t, []string{"legacy"}, teams)
+	})
+}
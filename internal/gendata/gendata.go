@@ -0,0 +1,324 @@
+// Package gendata generates synthetic teams, users and pull requests for performance testing.
+package gendata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	pullrequestModel "github.com/festy23/avito_internship/internal/pullrequest/model"
+	teamModel "github.com/festy23/avito_internship/internal/team/model"
+	userModel "github.com/festy23/avito_internship/internal/user/model"
+)
+
+// Config controls the volume and shape of generated data.
+type Config struct {
+	// Prefix is prepended to all generated identifiers so generated rows do not collide with real ones.
+	Prefix string
+	// Teams is the number of teams to generate.
+	Teams int
+	// MinTeamSize and MaxTeamSize bound the team size. Sizes follow a right-skewed distribution:
+	// most teams are small, a few are large.
+	MinTeamSize int
+	MaxTeamSize int
+	// ActiveRatio is the probability of a user being active.
+	ActiveRatio float64
+	// PullRequests is the total number of pull requests to generate. Authors follow a Zipf
+	// distribution within the generated users, so a few users author most of the PRs.
+	PullRequests int
+	// MergedRatio is the probability of a pull request being merged.
+	MergedRatio float64
+	// Period spreads PR creation times over the given duration before now.
+	Period time.Duration
+	// BatchSize is the number of rows per INSERT.
+	BatchSize int
+	// Seed makes the output reproducible; 0 uses the current time.
+	Seed int64
+}
+
+// DefaultConfig returns a configuration producing a medium-sized dataset.
+func DefaultConfig() Config {
+	return Config{
+		Prefix:       "gen",
+		Teams:        20,
+		MinTeamSize:  3,
+		MaxTeamSize:  30,
+		ActiveRatio:  0.85,
+		PullRequests: 5000,
+		MergedRatio:  0.7,
+		Period:       90 * 24 * time.Hour,
+		BatchSize:    500,
+	}
+}
+
+// Validate validates the generator configuration.
+func (c Config) Validate() error {
+	if c.Prefix == "" {
+		return errors.New("prefix must not be empty")
+	}
+	if c.Teams < 1 {
+		return errors.New("teams must be greater than 0")
+	}
+	if c.MinTeamSize < 1 || c.MaxTeamSize < c.MinTeamSize {
+		return errors.New("team size bounds must satisfy 1 <= min <= max")
+	}
+	if c.ActiveRatio < 0 || c.ActiveRatio > 1 {
+		return errors.New("active ratio must be between 0 and 1")
+	}
+	if c.PullRequests < 0 {
+		return errors.New("pull requests must not be negative")
+	}
+	if c.MergedRatio < 0 || c.MergedRatio > 1 {
+		return errors.New("merged ratio must be between 0 and 1")
+	}
+	if c.Period <= 0 {
+		return errors.New("period must be positive")
+	}
+	if c.BatchSize < 1 {
+		return errors.New("batch size must be greater than 0")
+	}
+	return nil
+}
+
+// Stats reports the number of generated rows.
+type Stats struct {
+	Teams        int
+	Users        int
+	ActiveUsers  int
+	PullRequests int
+	Merged       int
+	Reviewers    int
+	Events       int
+}
+
+// Generator writes synthetic data into the database.
+type Generator struct {
+	db     *gorm.DB
+	cfg    Config
+	now    time.Time
+	rand   *rand.Rand
+	logger *zap.SugaredLogger
+}
+
+// New creates a new generator.
+func New(db *gorm.DB, cfg Config, logger *zap.SugaredLogger) *Generator {
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &Generator{
+		db:  db,
+		cfg: cfg,
+		now: time.Now().UTC(),
+		//nolint:gosec // G404: synthetic data does not need a cryptographic source
+		rand:   rand.New(rand.NewSource(seed)),
+		logger: logger,
+	}
+}
+
+// Run generates the configured data in a single transaction.
+func (g *Generator) Run(ctx context.Context) (Stats, error) {
+	if err := g.cfg.Validate(); err != nil {
+		return Stats{}, fmt.Errorf("invalid config: %w", err)
+	}
+
+	teams, users := g.generateTeams()
+	prs, reviewers, events := g.generatePullRequests(users)
+
+	stats := Stats{
+		Teams:        len(teams),
+		Users:        len(users),
+		PullRequests: len(prs),
+		Reviewers:    len(reviewers),
+		Events:       len(events),
+	}
+	var inactiveIDs []string
+	for _, u := range users {
+		if u.IsActive {
+			stats.ActiveUsers++
+		} else {
+			inactiveIDs = append(inactiveIDs, u.UserID)
+		}
+	}
+	for _, pr := range prs {
+		if pr.Status == pullrequestModel.StatusMERGED {
+			stats.Merged++
+		}
+	}
+
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		batch := g.cfg.BatchSize
+		if err := tx.CreateInBatches(teams, batch).Error; err != nil {
+			return fmt.Errorf("insert teams: %w", err)
+		}
+		if err := tx.CreateInBatches(users, batch).Error; err != nil {
+			return fmt.Errorf("insert users: %w", err)
+		}
+		if err := deactivate(tx, inactiveIDs, batch); err != nil {
+			return fmt.Errorf("deactivate users: %w", err)
+		}
+		if err := tx.CreateInBatches(prs, batch).Error; err != nil {
+			return fmt.Errorf("insert pull requests: %w", err)
+		}
+		if err := tx.CreateInBatches(reviewers, batch).Error; err != nil {
+			return fmt.Errorf("insert reviewers: %w", err)
+		}
+		if err := tx.CreateInBatches(events, batch).Error; err != nil {
+			return fmt.Errorf("insert events: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		g.logger.Errorw("Data generation database error", "error", err)
+		return Stats{}, err
+	}
+
+	g.logger.Infow("Data generation completed",
+		"teams", stats.Teams, "users", stats.Users, "active_users", stats.ActiveUsers,
+		"pull_requests", stats.PullRequests, "merged", stats.Merged,
+		"reviewers", stats.Reviewers, "events", stats.Events,
+	)
+	return stats, nil
+}
+
+// deactivate marks users inactive. GORM replaces a false is_active with the column default (true)
+// on insert (and in the inserted structs), so inactive users are updated after being created.
+func deactivate(tx *gorm.DB, ids []string, batch int) error {
+	for start := 0; start < len(ids); start += batch {
+		end := min(start+batch, len(ids))
+		err := tx.Model(&userModel.User{}).Where("user_id IN ?", ids[start:end]).Update("is_active", false).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// teamSize draws a team size from a right-skewed (exponential) distribution clipped to the bounds.
+func (g *Generator) teamSize() int {
+	spread := g.cfg.MaxTeamSize - g.cfg.MinTeamSize
+	if spread == 0 {
+		return g.cfg.MinTeamSize
+	}
+	// Mean of the extra members is a quarter of the spread.
+	extra := int(g.rand.ExpFloat64() * float64(spread) / 4)
+	return g.cfg.MinTeamSize + min(extra, spread)
+}
+
+func (g *Generator) generateTeams() ([]*teamModel.Team, []*userModel.User) {
+	teams := make([]*teamModel.Team, 0, g.cfg.Teams)
+	var users []*userModel.User
+
+	for i := 1; i <= g.cfg.Teams; i++ {
+		name := fmt.Sprintf("%s-team-%04d", g.cfg.Prefix, i)
+		teams = append(teams, &teamModel.Team{TeamName: name, CreatedAt: g.now, UpdatedAt: g.now})
+
+		for j := g.teamSize(); j > 0; j-- {
+			n := len(users) + 1
+			users = append(users, &userModel.User{
+				UserID:    fmt.Sprintf("%s-u-%06d", g.cfg.Prefix, n),
+				Username:  fmt.Sprintf("User %d", n),
+				TeamName:  name,
+				IsActive:  g.rand.Float64() < g.cfg.ActiveRatio,
+				CreatedAt: g.now,
+				UpdatedAt: g.now,
+			})
+		}
+	}
+
+	return teams, users
+}
+
+//nolint:gocognit // Straight-line generation of a PR with its reviewers and events
+func (g *Generator) generatePullRequests(users []*userModel.User) (
+	[]*pullrequestModel.PullRequest,
+	[]*pullrequestModel.PullRequestReviewer,
+	[]*pullrequestModel.PullRequestEvent,
+) {
+	if g.cfg.PullRequests == 0 {
+		return nil, nil, nil
+	}
+
+	activeByTeam := make(map[string][]string)
+	for _, u := range users {
+		if u.IsActive {
+			activeByTeam[u.TeamName] = append(activeByTeam[u.TeamName], u.UserID)
+		}
+	}
+
+	// Authors are picked by rank in a shuffled user list, so prolific authors are spread across teams.
+	order := g.rand.Perm(len(users))
+	authors := rand.NewZipf(g.rand, 1.2, 1, uint64(len(users)-1))
+
+	prs := make([]*pullrequestModel.PullRequest, 0, g.cfg.PullRequests)
+	var reviewers []*pullrequestModel.PullRequestReviewer
+	var events []*pullrequestModel.PullRequestEvent
+
+	for i := 1; i <= g.cfg.PullRequests; i++ {
+		author := users[order[authors.Uint64()]]
+		createdAt := g.now.Add(-time.Duration(g.rand.Int63n(int64(g.cfg.Period))))
+		pr := &pullrequestModel.PullRequest{
+			PullRequestID:   fmt.Sprintf("%s-pr-%07d", g.cfg.Prefix, i),
+			PullRequestName: fmt.Sprintf("Change %d", i),
+			AuthorID:        author.UserID,
+			Status:          pullrequestModel.StatusOPEN,
+			CreatedAt:       createdAt,
+			// Change sizes are log-normal: mostly small diffs with a long tail.
+			LinesAdded:   int(math.Exp(3 + 1.5*g.rand.NormFloat64())),
+			LinesRemoved: int(math.Exp(2 + 1.5*g.rand.NormFloat64())),
+		}
+		events = append(events, g.event(pr.PullRequestID, pullrequestModel.EventCreated, author.UserID, createdAt))
+
+		for _, reviewerID := range g.pickReviewers(activeByTeam[author.TeamName], author.UserID) {
+			reviewers = append(reviewers, &pullrequestModel.PullRequestReviewer{
+				PullRequestID: pr.PullRequestID,
+				UserID:        reviewerID,
+				AssignedAt:    createdAt,
+			})
+			assigned := g.event(pr.PullRequestID, pullrequestModel.EventReviewerAssigned, reviewerID, createdAt)
+			source := pullrequestModel.SourceAuto
+			assigned.Source = &source
+			events = append(events, assigned)
+		}
+
+		if g.rand.Float64() < g.cfg.MergedRatio {
+			mergedAt := createdAt.Add(time.Duration(g.rand.ExpFloat64() * float64(24*time.Hour)))
+			if mergedAt.After(g.now) {
+				mergedAt = g.now
+			}
+			pr.Status = pullrequestModel.StatusMERGED
+			pr.MergedAt = &mergedAt
+			events = append(events, g.event(pr.PullRequestID, pullrequestModel.EventMerged, "", mergedAt))
+		}
+
+		prs = append(prs, pr)
+	}
+
+	return prs, reviewers, events
+}
+
+// pickReviewers picks up to MaxReviewersPerPR random candidates other than the author.
+func (g *Generator) pickReviewers(candidates []string, authorID string) []string {
+	picked := make([]string, 0, pullrequestModel.MaxReviewersPerPR)
+	for _, i := range g.rand.Perm(len(candidates)) {
+		if len(picked) == pullrequestModel.MaxReviewersPerPR {
+			break
+		}
+		if candidates[i] != authorID {
+			picked = append(picked, candidates[i])
+		}
+	}
+	return picked
+}
+
+func (g *Generator) event(prID, eventType, userID string, at time.Time) *pullrequestModel.PullRequestEvent {
+	event := pullrequestModel.NewPullRequestEvent(prID, eventType, userID, "")
+	event.CreatedAt = at
+	return event
+}
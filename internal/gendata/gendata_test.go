@@ -0,0 +1,155 @@
+package gendata
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	pullrequestModel "github.com/festy23/avito_internship/internal/pullrequest/model"
+	"github.com/festy23/avito_internship/internal/testutil"
+	userModel "github.com/festy23/avito_internship/internal/user/model"
+)
+
+func testConfig() Config {
+	cfg := DefaultConfig()
+	cfg.Teams = 5
+	cfg.MinTeamSize = 2
+	cfg.MaxTeamSize = 10
+	cfg.PullRequests = 200
+	cfg.BatchSize = 50
+	cfg.Seed = 42
+	return cfg
+}
+
+func TestGenerator_Run(t *testing.T) {
+	db := testutil.NewDB(t)
+	cfg := testConfig()
+
+	stats, err := New(db, cfg, zap.NewNop().Sugar()).Run(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, stats.Teams)
+	assert.Equal(t, 200, stats.PullRequests)
+	assert.GreaterOrEqual(t, stats.Users, 5*cfg.MinTeamSize)
+	assert.LessOrEqual(t, stats.Users, 5*cfg.MaxTeamSize)
+
+	var users []userModel.User
+	require.NoError(t, db.Find(&users).Error)
+	require.Len(t, users, stats.Users)
+	byID := make(map[string]userModel.User, len(users))
+	active := 0
+	for _, u := range users {
+		byID[u.UserID] = u
+		if u.IsActive {
+			active++
+		}
+	}
+	assert.Equal(t, stats.ActiveUsers, active, "inactive users must be stored as inactive")
+
+	var prs []pullrequestModel.PullRequest
+	require.NoError(t, db.Find(&prs).Error)
+	require.Len(t, prs, stats.PullRequests)
+	merged := 0
+	for _, pr := range prs {
+		if pr.Status == pullrequestModel.StatusMERGED {
+			merged++
+			require.NotNil(t, pr.MergedAt)
+			assert.False(t, pr.MergedAt.Before(pr.CreatedAt))
+		} else {
+			assert.Nil(t, pr.MergedAt)
+		}
+		assert.WithinDuration(t, time.Now(), pr.CreatedAt, cfg.Period+time.Minute)
+	}
+	assert.Equal(t, stats.Merged, merged)
+
+	var reviewers []pullrequestModel.PullRequestReviewer
+	require.NoError(t, db.Find(&reviewers).Error)
+	require.Len(t, reviewers, stats.Reviewers)
+	authors := make(map[string]string, len(prs))
+	for _, pr := range prs {
+		authors[pr.PullRequestID] = pr.AuthorID
+	}
+	perPR := make(map[string]int)
+	for _, r := range reviewers {
+		author := byID[authors[r.PullRequestID]]
+		reviewer := byID[r.UserID]
+		assert.NotEqual(t, author.UserID, reviewer.UserID)
+		assert.Equal(t, author.TeamName, reviewer.TeamName)
+		assert.True(t, reviewer.IsActive)
+		perPR[r.PullRequestID]++
+	}
+	for _, n := range perPR {
+		assert.LessOrEqual(t, n, pullrequestModel.MaxReviewersPerPR)
+	}
+
+	var events int64
+	require.NoError(t, db.Model(&pullrequestModel.PullRequestEvent{}).Count(&events).Error)
+	assert.Equal(t, int64(stats.PullRequests+stats.Reviewers+stats.Merged), events)
+	assert.Equal(t, stats.Events, int(events))
+}
+
+func TestGenerator_Seed(t *testing.T) {
+	run := func() ([]pullrequestModel.PullRequestReviewer, Stats) {
+		db := testutil.NewDB(t)
+		stats, err := New(db, testConfig(), zap.NewNop().Sugar()).Run(context.Background())
+		require.NoError(t, err)
+		var reviewers []pullrequestModel.PullRequestReviewer
+		require.NoError(t, db.Order("id").Find(&reviewers).Error)
+		for i := range reviewers {
+			reviewers[i].AssignedAt = time.Time{}
+		}
+		return reviewers, stats
+	}
+
+	first, firstStats := run()
+	second, secondStats := run()
+
+	assert.Equal(t, firstStats, secondStats)
+	assert.Equal(t, first, second)
+}
+
+func TestGenerator_SingleUser(t *testing.T) {
+	db := testutil.NewDB(t)
+	cfg := testConfig()
+	cfg.Teams = 1
+	cfg.MinTeamSize = 1
+	cfg.MaxTeamSize = 1
+	cfg.PullRequests = 3
+
+	stats, err := New(db, cfg, zap.NewNop().Sugar()).Run(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, stats.Users)
+	assert.Equal(t, 3, stats.PullRequests)
+	assert.Zero(t, stats.Reviewers)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	assert.NoError(t, DefaultConfig().Validate())
+
+	tests := []struct {
+		name   string
+		modify func(*Config)
+	}{
+		{"empty prefix", func(c *Config) { c.Prefix = "" }},
+		{"no teams", func(c *Config) { c.Teams = 0 }},
+		{"zero min team size", func(c *Config) { c.MinTeamSize = 0 }},
+		{"max below min", func(c *Config) { c.MaxTeamSize = c.MinTeamSize - 1 }},
+		{"active ratio above 1", func(c *Config) { c.ActiveRatio = 1.5 }},
+		{"negative pull requests", func(c *Config) { c.PullRequests = -1 }},
+		{"negative merged ratio", func(c *Config) { c.MergedRatio = -0.1 }},
+		{"zero period", func(c *Config) { c.Period = 0 }},
+		{"zero batch size", func(c *Config) { c.BatchSize = 0 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			tt.modify(&cfg)
+			assert.Error(t, cfg.Validate())
+		})
+	}
+}
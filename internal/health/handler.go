@@ -4,6 +4,7 @@ package health
 import (
 	"context"
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -11,12 +12,15 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/festy23/avito_internship/internal/database/database"
+	"github.com/festy23/avito_internship/internal/database/migrate"
 )
 
 // Handler handles health check requests.
 type Handler struct {
-	db     *gorm.DB
-	logger *zap.SugaredLogger
+	db            *gorm.DB
+	latestVersion uint
+	logger        *zap.SugaredLogger
+	draining      atomic.Bool
 }
 
 // New creates a new health handler instance.
@@ -27,13 +31,56 @@ func New(db *gorm.DB, logger *zap.SugaredLogger) *Handler {
 	}
 }
 
+// NewWithSchema creates a health handler that also reports the schema version of the database
+// against latestVersion, the newest migration shipped with the service.
+func NewWithSchema(db *gorm.DB, latestVersion uint, logger *zap.SugaredLogger) *Handler {
+	h := New(db, logger)
+	h.latestVersion = latestVersion
+	return h
+}
+
 // Response represents health check response.
 type Response struct {
-	Status string `json:"status"`
+	Status string  `json:"status"`
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+// Schema describes the migration state of the database.
+type Schema struct {
+	// Version is the applied migration version; 0 means no migration has been applied.
+	Version uint `json:"version"`
+	// Dirty reports that the last migration failed halfway and needs manual repair.
+	Dirty bool `json:"dirty"`
+	// LatestVersion is the newest migration shipped with the service.
+	LatestVersion uint `json:"latest_version"`
+	// Pending reports that the database is behind the service.
+	Pending bool `json:"pending"`
+}
+
+// Drain makes health checks fail, so load balancers stop routing requests to the instance
+// while it is shutting down. Requests are still served.
+func (h *Handler) Drain() {
+	h.draining.Store(true)
+}
+
+// Ping handles GET /ping request: a liveness probe answering without touching dependencies, so
+// container healthchecks do not restart the service because of a database outage. Ping keeps
+// answering while the instance is draining; readiness is reported by Check.
+func (h *Handler) Ping(c *gin.Context) {
+	c.JSON(http.StatusOK, Response{
+		Status: "ok",
+	})
 }
 
-// Check handles GET /health request.
+// Check handles GET /health and GET /health/ready requests: a readiness probe checking the database.
 func (h *Handler) Check(c *gin.Context) {
+	if h.draining.Load() {
+		c.JSON(http.StatusServiceUnavailable, Response{
+			Status: "shutting down",
+		})
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
@@ -48,5 +95,25 @@ func (h *Handler) Check(c *gin.Context) {
 
 	c.JSON(http.StatusOK, Response{
 		Status: "ok",
+		Schema: h.schema(ctx),
 	})
 }
+
+// schema returns the migration state, or nil if it is not reported or cannot be read.
+// A failure to read the version does not make the service unhealthy.
+func (h *Handler) schema(ctx context.Context) *Schema {
+	if h.latestVersion == 0 {
+		return nil
+	}
+	version, dirty, err := migrate.CurrentVersion(ctx, h.db)
+	if err != nil {
+		h.logger.Warnw("failed to read schema version", "error", err)
+		return nil
+	}
+	return &Schema{
+		Version:       version,
+		Dirty:         dirty,
+		LatestVersion: h.latestVersion,
+		Pending:       version < h.latestVersion,
+	}
+}
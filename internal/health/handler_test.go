@@ -2,6 +2,7 @@ package health
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -33,6 +34,7 @@ func setupRouter(handler *Handler) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
 	router.GET("/health", handler.Check)
+	router.GET("/ping", handler.Ping)
 	return router
 }
 
@@ -251,3 +253,91 @@ func TestHealthCheckTimeout(t *testing.T) {
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
 }
+
+func TestHandler_Drain(t *testing.T) {
+	db := setupTestDB(t)
+	handler := New(db, zap.NewNop().Sugar())
+	router := setupRouter(handler)
+
+	handler.Drain()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/health", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"shutting down"`)
+}
+
+func TestHandler_Ping(t *testing.T) {
+	db := setupTestDB(t)
+	handler := New(db, zap.NewNop().Sugar())
+	router := setupRouter(handler)
+
+	// Ping does not depend on the database
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	require.NoError(t, sqlDB.Close())
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/ping", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, w.Body.String())
+
+	// Draining fails readiness, not liveness
+	handler.Drain()
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestHandler_CheckSchema(t *testing.T) {
+	createSchemaMigrations := func(t *testing.T, db *gorm.DB, version int) {
+		t.Helper()
+		require.NoError(t, db.Exec(
+			"CREATE TABLE schema_migrations (version bigint NOT NULL, dirty boolean NOT NULL)",
+		).Error)
+		require.NoError(t, db.Exec("INSERT INTO schema_migrations (version, dirty) VALUES (?, false)", version).Error)
+	}
+
+	tests := []struct {
+		name    string
+		applied int
+		pending bool
+	}{
+		{name: "up to date", applied: 16, pending: false},
+		{name: "pending migrations", applied: 15, pending: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			createSchemaMigrations(t, db, tt.applied)
+			router := setupRouter(NewWithSchema(db, 16, zap.NewNop().Sugar()))
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/health", nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			var resp Response
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			require.NotNil(t, resp.Schema)
+			assert.Equal(t, uint(tt.applied), resp.Schema.Version)
+			assert.Equal(t, uint(16), resp.Schema.LatestVersion)
+			assert.Equal(t, tt.pending, resp.Schema.Pending)
+			assert.False(t, resp.Schema.Dirty)
+		})
+	}
+
+	t.Run("not reported without schema", func(t *testing.T) {
+		router := setupRouter(New(setupTestDB(t), zap.NewNop().Sugar()))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/health", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "schema")
+	})
+}
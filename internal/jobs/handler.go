@@ -0,0 +1,32 @@
+package jobs
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler exposes scheduler metrics over HTTP.
+type Handler struct {
+	scheduler *Scheduler
+}
+
+// NewHandler creates a new jobs handler instance.
+func NewHandler(scheduler *Scheduler) *Handler {
+	return &Handler{scheduler: scheduler}
+}
+
+// StatsResponse represents the response with background job metrics.
+type StatsResponse struct {
+	Jobs []Stats `json:"jobs"`
+}
+
+// GetStats handles GET /jobs request.
+// @Summary Get background job run metrics
+// @Tags Jobs
+// @Produce json
+// @Success 200 {object} StatsResponse
+// @Router /jobs [get] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) GetStats(c *gin.Context) {
+	c.JSON(http.StatusOK, StatsResponse{Jobs: h.scheduler.Stats()})
+}
@@ -0,0 +1,39 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestHandler_GetStats(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	s := New(zap.NewNop().Sugar())
+	require.NoError(t, s.Register(Job{
+		Name:     "archive",
+		Schedule: mustParse(t, "@hourly"),
+		Run:      func(context.Context) error { return nil },
+	}))
+
+	router := gin.New()
+	router.GET("/jobs", NewHandler(s).GetStats)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/jobs", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response StatsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Jobs, 1)
+	assert.Equal(t, "archive", response.Jobs[0].Name)
+	assert.Zero(t, response.Jobs[0].Runs)
+}
@@ -0,0 +1,215 @@
+package jobs
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidSchedule indicates that a schedule expression cannot be parsed.
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+// Schedule computes activation times of a job.
+type Schedule interface {
+	// Next returns the first activation time strictly after t, or zero time if there is none.
+	Next(t time.Time) time.Time
+}
+
+// descriptors maps predefined schedule names to equivalent cron expressions.
+var descriptors = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+}
+
+// ParseSchedule parses a schedule expression.
+// Supported forms are standard five-field cron expressions (minute hour day-of-month month day-of-week),
+// the descriptors @hourly, @daily, @midnight, @weekly, @monthly and "@every <duration>".
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+
+	if rest, ok := strings.CutPrefix(spec, "@every "); ok {
+		interval, err := time.ParseDuration(strings.TrimSpace(rest))
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("%w: %q: interval must be a positive duration", ErrInvalidSchedule, spec)
+		}
+		return intervalSchedule{interval: interval}, nil
+	}
+
+	if expr, ok := descriptors[spec]; ok {
+		spec = expr
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w: %q: expected 5 fields, got %d", ErrInvalidSchedule, spec, len(fields))
+	}
+
+	var (
+		sched cronSchedule
+		err   error
+	)
+	if sched.minute, err = parseField(fields[0], 0, 59); err != nil {
+		return nil, fmt.Errorf("%w: %q: minute: %w", ErrInvalidSchedule, spec, err)
+	}
+	if sched.hour, err = parseField(fields[1], 0, 23); err != nil {
+		return nil, fmt.Errorf("%w: %q: hour: %w", ErrInvalidSchedule, spec, err)
+	}
+	if sched.dom, err = parseField(fields[2], 1, 31); err != nil {
+		return nil, fmt.Errorf("%w: %q: day of month: %w", ErrInvalidSchedule, spec, err)
+	}
+	if sched.month, err = parseField(fields[3], 1, 12); err != nil {
+		return nil, fmt.Errorf("%w: %q: month: %w", ErrInvalidSchedule, spec, err)
+	}
+	if sched.dow, err = parseField(fields[4], 0, 7); err != nil {
+		return nil, fmt.Errorf("%w: %q: day of week: %w", ErrInvalidSchedule, spec, err)
+	}
+	// Both 0 and 7 mean Sunday
+	if sched.dow&(1<<7) != 0 {
+		sched.dow |= 1
+	}
+	sched.domAny = fields[2] == "*"
+	sched.dowAny = fields[4] == "*"
+
+	return sched, nil
+}
+
+// intervalSchedule activates a job at a fixed interval.
+type intervalSchedule struct {
+	interval time.Duration
+}
+
+// Next returns t plus the interval.
+func (s intervalSchedule) Next(t time.Time) time.Time {
+	return t.Add(s.interval)
+}
+
+// cronSchedule activates a job at times matching a cron expression.
+// Each field is a bit set of allowed values.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool
+}
+
+// cronSearchLimit bounds the search for the next activation of expressions that never match (e.g. Feb 30).
+const cronSearchLimit = 5 * 366 * 24 * time.Hour
+
+// Next returns the first minute after t matching the expression.
+func (s cronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).Add(time.Minute)
+	limit := t.Add(cronSearchLimit)
+
+	for t.Before(limit) {
+		if !has(s.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !has(s.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if !has(s.minute, t.Minute()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, loc)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+// dayMatches applies cron day semantics: when both day fields are restricted, either may match.
+func (s cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := has(s.dom, t.Day())
+	dowMatch := has(s.dow, int(t.Weekday()))
+	if s.domAny || s.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// has reports whether value is set in the bit set.
+func has(set uint64, value int) bool {
+	return set&(1<<uint(value)) != 0
+}
+
+// parseField parses a comma-separated list of values, ranges and steps into a bit set.
+func parseField(field string, minValue, maxValue int) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		bits, err := parseRange(part, minValue, maxValue)
+		if err != nil {
+			return 0, err
+		}
+		set |= bits
+	}
+	return set, nil
+}
+
+// parseRange parses a single "*", "a", "a-b" item with an optional "/step" suffix.
+func parseRange(part string, minValue, maxValue int) (uint64, error) {
+	rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+	step := 1
+	if hasStep {
+		var err error
+		step, err = strconv.Atoi(stepPart)
+		if err != nil || step <= 0 {
+			return 0, fmt.Errorf("invalid step %q", stepPart)
+		}
+	}
+
+	start, end := minValue, maxValue
+	switch {
+	case rangePart == "*":
+	case strings.Contains(rangePart, "-"):
+		from, to, _ := strings.Cut(rangePart, "-")
+		var err error
+		if start, err = parseValue(from, minValue, maxValue); err != nil {
+			return 0, err
+		}
+		if end, err = parseValue(to, minValue, maxValue); err != nil {
+			return 0, err
+		}
+		if start > end {
+			return 0, fmt.Errorf("invalid range %q", rangePart)
+		}
+	default:
+		value, err := parseValue(rangePart, minValue, maxValue)
+		if err != nil {
+			return 0, err
+		}
+		start = value
+		// "a/step" means from a to the maximum value
+		if !hasStep {
+			end = value
+		}
+	}
+
+	var bits uint64
+	for v := start; v <= end; v += step {
+		bits |= 1 << uint(v)
+	}
+	return bits, nil
+}
+
+// parseValue parses a numeric field value within bounds.
+func parseValue(s string, minValue, maxValue int) (int, error) {
+	value, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	if value < minValue || value > maxValue {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", value, minValue, maxValue)
+	}
+	return value, nil
+}
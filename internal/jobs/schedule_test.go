@@ -0,0 +1,80 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule_Next(t *testing.T) {
+	// Wednesday
+	base := time.Date(2025, 6, 4, 10, 30, 15, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		spec     string
+		expected time.Time
+	}{
+		{"every interval", "@every 90s", base.Add(90 * time.Second)},
+		{"hourly", "@hourly", time.Date(2025, 6, 4, 11, 0, 0, 0, time.UTC)},
+		{"daily", "@daily", time.Date(2025, 6, 5, 0, 0, 0, 0, time.UTC)},
+		{"weekly", "@weekly", time.Date(2025, 6, 8, 0, 0, 0, 0, time.UTC)},
+		{"monthly", "@monthly", time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)},
+		{"every minute", "* * * * *", time.Date(2025, 6, 4, 10, 31, 0, 0, time.UTC)},
+		{"step", "*/15 * * * *", time.Date(2025, 6, 4, 10, 45, 0, 0, time.UTC)},
+		{"list", "5,40 * * * *", time.Date(2025, 6, 4, 10, 40, 0, 0, time.UTC)},
+		{"range with step", "0 9-17/4 * * *", time.Date(2025, 6, 4, 13, 0, 0, 0, time.UTC)},
+		{"later today", "0 18 * * *", time.Date(2025, 6, 4, 18, 0, 0, 0, time.UTC)},
+		{"tomorrow", "0 9 * * *", time.Date(2025, 6, 5, 9, 0, 0, 0, time.UTC)},
+		{"weekdays skip weekend", "0 9 * * 1-5", time.Date(2025, 6, 5, 9, 0, 0, 0, time.UTC)},
+		{"sunday as 7", "0 9 * * 7", time.Date(2025, 6, 8, 9, 0, 0, 0, time.UTC)},
+		{"day of month", "0 0 15 * *", time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)},
+		{"month", "0 0 1 1 *", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"day of month or weekday", "0 0 20 * 5", time.Date(2025, 6, 6, 0, 0, 0, 0, time.UTC)},
+		{"leap day", "0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, schedule.Next(base))
+		})
+	}
+}
+
+func TestParseSchedule_NeverMatches(t *testing.T) {
+	schedule, err := ParseSchedule("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.True(t, schedule.Next(time.Now()).IsZero())
+}
+
+func TestParseSchedule_Invalid(t *testing.T) {
+	specs := []string{
+		"",
+		"@yearly",
+		"@every",
+		"@every 0s",
+		"@every -1m",
+		"@every soon",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"10-5 * * * *",
+		"a * * * *",
+	}
+
+	for _, spec := range specs {
+		t.Run(spec, func(t *testing.T) {
+			_, err := ParseSchedule(spec)
+			assert.ErrorIs(t, err, ErrInvalidSchedule)
+		})
+	}
+}
@@ -0,0 +1,235 @@
+// Package jobs provides an embedded scheduler for background jobs.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ErrInvalidJob indicates that a job definition is incomplete or duplicates another job.
+var ErrInvalidJob = errors.New("invalid job")
+
+// Job describes a background task run by the Scheduler.
+type Job struct {
+	// Name identifies the job in logs and statistics; must be unique.
+	Name string
+	// Schedule defines when the job runs.
+	Schedule Schedule
+	// Jitter is the maximum random delay added to every activation.
+	Jitter time.Duration
+	// Run executes the job. The context is canceled when the scheduler stops.
+	Run func(ctx context.Context) error
+}
+
+// Stats holds run metrics of a scheduled job.
+type Stats struct {
+	Name           string     `json:"name"`
+	Runs           int64      `json:"runs"`
+	Failures       int64      `json:"failures"`
+	Skipped        int64      `json:"skipped"`
+	Standby        int64      `json:"standby"`
+	Running        bool       `json:"running"`
+	LastStartedAt  *time.Time `json:"last_started_at,omitempty"`
+	LastDurationMs int64      `json:"last_duration_ms"`
+	LastError      string     `json:"last_error,omitempty"`
+	NextRunAt      *time.Time `json:"next_run_at,omitempty"`
+}
+
+// Scheduler runs registered jobs according to their schedules.
+// A job never overlaps with itself: activations that fire while the previous run
+// is still in progress are skipped and counted in Stats.Skipped.
+type Scheduler struct {
+	logger  *zap.SugaredLogger
+	mu      sync.Mutex
+	entries []*entry
+	started bool
+	active  func() bool
+	wg      sync.WaitGroup
+	now     func() time.Time
+}
+
+type entry struct {
+	job   Job
+	mu    sync.Mutex
+	stats Stats
+}
+
+// New creates a new scheduler instance.
+func New(logger *zap.SugaredLogger) *Scheduler {
+	return &Scheduler{
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// Register adds a job to the scheduler. Jobs must be registered before Start.
+func (s *Scheduler) Register(job Job) error {
+	if job.Name == "" || job.Schedule == nil || job.Run == nil {
+		return fmt.Errorf("%w: name, schedule and run function are required", ErrInvalidJob)
+	}
+	if job.Jitter < 0 {
+		return fmt.Errorf("%w: %s: jitter must not be negative", ErrInvalidJob, job.Name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return fmt.Errorf("%w: %s: scheduler already started", ErrInvalidJob, job.Name)
+	}
+	for _, e := range s.entries {
+		if e.job.Name == job.Name {
+			return fmt.Errorf("%w: %s: duplicate job name", ErrInvalidJob, job.Name)
+		}
+	}
+
+	s.entries = append(s.entries, &entry{job: job, stats: Stats{Name: job.Name}})
+	s.logger.Infow("job registered", "job", job.Name, "jitter", job.Jitter)
+	return nil
+}
+
+// RunOnlyWhen makes jobs run only while active returns true, e.g. while this replica is the elected
+// leader. Other activations are skipped and counted in Stats.Standby. Must be called before Start.
+func (s *Scheduler) RunOnlyWhen(active func() bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.active = active
+}
+
+// Start launches all registered jobs. They stop when ctx is canceled; use Wait to block until
+// running jobs have finished.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return
+	}
+	s.started = true
+
+	for _, e := range s.entries {
+		s.wg.Add(1)
+		go s.loop(ctx, e)
+	}
+}
+
+// Wait blocks until all job loops and in-flight runs have finished.
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+// Stats returns run metrics of all registered jobs in registration order.
+func (s *Scheduler) Stats() []Stats {
+	s.mu.Lock()
+	entries := make([]*entry, len(s.entries))
+	copy(entries, s.entries)
+	s.mu.Unlock()
+
+	stats := make([]Stats, 0, len(entries))
+	for _, e := range entries {
+		e.mu.Lock()
+		stats = append(stats, e.stats)
+		e.mu.Unlock()
+	}
+	return stats
+}
+
+// loop waits for each activation of the job and triggers it until ctx is canceled.
+func (s *Scheduler) loop(ctx context.Context, e *entry) {
+	defer s.wg.Done()
+
+	for {
+		next := e.job.Schedule.Next(s.now())
+		if next.IsZero() {
+			s.logger.Warnw("job has no further activations", "job", e.job.Name)
+			return
+		}
+		next = next.Add(jitter(e.job.Jitter))
+
+		e.mu.Lock()
+		e.stats.NextRunAt = &next
+		e.mu.Unlock()
+
+		timer := time.NewTimer(next.Sub(s.now()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		s.trigger(ctx, e)
+	}
+}
+
+// trigger starts a run of the job unless the previous one is still in progress or the scheduler
+// is on standby.
+func (s *Scheduler) trigger(ctx context.Context, e *entry) {
+	e.mu.Lock()
+	if s.active != nil && !s.active() {
+		e.stats.Standby++
+		e.mu.Unlock()
+		s.logger.Debugw("job run skipped, scheduler is on standby", "job", e.job.Name)
+		return
+	}
+	if e.stats.Running {
+		e.stats.Skipped++
+		e.mu.Unlock()
+		s.logger.Warnw("job run skipped, previous run still in progress", "job", e.job.Name)
+		return
+	}
+	started := s.now()
+	e.stats.Running = true
+	e.stats.LastStartedAt = &started
+	e.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		err := s.run(ctx, e)
+		duration := s.now().Sub(started)
+
+		e.mu.Lock()
+		e.stats.Running = false
+		e.stats.Runs++
+		e.stats.LastDurationMs = duration.Milliseconds()
+		e.stats.LastError = ""
+		if err != nil {
+			e.stats.Failures++
+			e.stats.LastError = err.Error()
+		}
+		e.mu.Unlock()
+
+		if err != nil {
+			s.logger.Errorw("job run failed", "job", e.job.Name, "duration", duration, "error", err)
+			return
+		}
+		s.logger.Debugw("job run completed", "job", e.job.Name, "duration", duration)
+	}()
+}
+
+// run executes the job, converting panics into errors so that one job cannot crash the process.
+func (s *Scheduler) run(ctx context.Context, e *entry) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return e.job.Run(ctx)
+}
+
+// jitter returns a random delay in [0, maxJitter).
+func jitter(maxJitter time.Duration) time.Duration {
+	if maxJitter <= 0 {
+		return 0
+	}
+	//nolint:gosec // G404: math/rand is sufficient for spreading job start times
+	return time.Duration(rand.Int63n(int64(maxJitter)))
+}
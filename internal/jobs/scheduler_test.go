@@ -0,0 +1,216 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func mustParse(t *testing.T, spec string) Schedule {
+	t.Helper()
+	schedule, err := ParseSchedule(spec)
+	require.NoError(t, err)
+	return schedule
+}
+
+func TestScheduler_Register(t *testing.T) {
+	run := func(context.Context) error { return nil }
+
+	t.Run("valid job", func(t *testing.T) {
+		s := New(zap.NewNop().Sugar())
+		err := s.Register(Job{Name: "archive", Schedule: mustParse(t, "@hourly"), Run: run})
+
+		require.NoError(t, err)
+		stats := s.Stats()
+		require.Len(t, stats, 1)
+		assert.Equal(t, "archive", stats[0].Name)
+	})
+
+	t.Run("incomplete job", func(t *testing.T) {
+		s := New(zap.NewNop().Sugar())
+
+		assert.ErrorIs(t, s.Register(Job{Schedule: mustParse(t, "@hourly"), Run: run}), ErrInvalidJob)
+		assert.ErrorIs(t, s.Register(Job{Name: "a", Run: run}), ErrInvalidJob)
+		assert.ErrorIs(t, s.Register(Job{Name: "a", Schedule: mustParse(t, "@hourly")}), ErrInvalidJob)
+		assert.ErrorIs(t, s.Register(Job{
+			Name: "a", Schedule: mustParse(t, "@hourly"), Jitter: -time.Second, Run: run,
+		}), ErrInvalidJob)
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		s := New(zap.NewNop().Sugar())
+		job := Job{Name: "archive", Schedule: mustParse(t, "@hourly"), Run: run}
+
+		require.NoError(t, s.Register(job))
+		assert.ErrorIs(t, s.Register(job), ErrInvalidJob)
+	})
+
+	t.Run("after start", func(t *testing.T) {
+		s := New(zap.NewNop().Sugar())
+		ctx, cancel := context.WithCancel(context.Background())
+		s.Start(ctx)
+		cancel()
+		s.Wait()
+
+		err := s.Register(Job{Name: "archive", Schedule: mustParse(t, "@hourly"), Run: run})
+		assert.ErrorIs(t, err, ErrInvalidJob)
+	})
+}
+
+func TestScheduler_RunsJobs(t *testing.T) {
+	s := New(zap.NewNop().Sugar())
+
+	var okRuns atomic.Int64
+	require.NoError(t, s.Register(Job{
+		Name:     "ok",
+		Schedule: mustParse(t, "@every 5ms"),
+		Jitter:   time.Millisecond,
+		Run: func(context.Context) error {
+			okRuns.Add(1)
+			return nil
+		},
+	}))
+	require.NoError(t, s.Register(Job{
+		Name:     "failing",
+		Schedule: mustParse(t, "@every 5ms"),
+		Run:      func(context.Context) error { return errors.New("boom") },
+	}))
+	require.NoError(t, s.Register(Job{
+		Name:     "panicking",
+		Schedule: mustParse(t, "@every 5ms"),
+		Run:      func(context.Context) error { panic("boom") },
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.Start(ctx)
+
+	require.Eventually(t, func() bool {
+		for _, st := range s.Stats() {
+			if st.Runs < 2 {
+				return false
+			}
+		}
+		return true
+	}, 2*time.Second, 5*time.Millisecond)
+
+	cancel()
+	s.Wait()
+
+	stats := s.Stats()
+	require.Len(t, stats, 3)
+
+	assert.Equal(t, okRuns.Load(), stats[0].Runs)
+	assert.Zero(t, stats[0].Failures)
+	assert.Empty(t, stats[0].LastError)
+	assert.NotNil(t, stats[0].LastStartedAt)
+	assert.NotNil(t, stats[0].NextRunAt)
+
+	assert.Equal(t, stats[1].Runs, stats[1].Failures)
+	assert.Equal(t, "boom", stats[1].LastError)
+
+	assert.Equal(t, stats[2].Runs, stats[2].Failures)
+	assert.Contains(t, stats[2].LastError, "panicked")
+}
+
+func TestScheduler_SkipsOverlappingRuns(t *testing.T) {
+	s := New(zap.NewNop().Sugar())
+
+	release := make(chan struct{})
+	var runs atomic.Int64
+	require.NoError(t, s.Register(Job{
+		Name:     "slow",
+		Schedule: mustParse(t, "@every 5ms"),
+		Run: func(ctx context.Context) error {
+			runs.Add(1)
+			select {
+			case <-release:
+			case <-ctx.Done():
+			}
+			return nil
+		},
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.Start(ctx)
+
+	require.Eventually(t, func() bool {
+		return s.Stats()[0].Skipped >= 2
+	}, 2*time.Second, 5*time.Millisecond)
+
+	stats := s.Stats()[0]
+	assert.True(t, stats.Running)
+	assert.Equal(t, int64(1), runs.Load())
+
+	close(release)
+	cancel()
+	s.Wait()
+
+	assert.False(t, s.Stats()[0].Running)
+}
+
+func TestScheduler_RunOnlyWhen(t *testing.T) {
+	s := New(zap.NewNop().Sugar())
+
+	var active atomic.Bool
+	var runs atomic.Int64
+	require.NoError(t, s.Register(Job{
+		Name:     "leader-only",
+		Schedule: mustParse(t, "@every 5ms"),
+		Run: func(context.Context) error {
+			runs.Add(1)
+			return nil
+		},
+	}))
+	s.RunOnlyWhen(active.Load)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.Start(ctx)
+
+	require.Eventually(t, func() bool {
+		return s.Stats()[0].Standby >= 2
+	}, 2*time.Second, 5*time.Millisecond)
+	assert.Zero(t, runs.Load())
+
+	active.Store(true)
+	require.Eventually(t, func() bool {
+		return runs.Load() >= 1
+	}, 2*time.Second, 5*time.Millisecond)
+
+	cancel()
+	s.Wait()
+}
+
+func TestScheduler_WaitsForRunningJobs(t *testing.T) {
+	s := New(zap.NewNop().Sugar())
+
+	started := make(chan struct{})
+	var finished atomic.Bool
+	require.NoError(t, s.Register(Job{
+		Name:     "graceful",
+		Schedule: mustParse(t, "@every 1ms"),
+		Run: func(ctx context.Context) error {
+			if finished.Load() {
+				return nil
+			}
+			close(started)
+			<-ctx.Done()
+			time.Sleep(10 * time.Millisecond)
+			finished.Store(true)
+			return ctx.Err()
+		},
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.Start(ctx)
+	<-started
+	cancel()
+	s.Wait()
+
+	assert.True(t, finished.Load())
+}
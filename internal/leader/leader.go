@@ -0,0 +1,202 @@
+// Package leader elects a single replica to run scheduled background jobs.
+//
+// The leader holds a lease: a row of the leader_leases table with the holder ID and an expiry time.
+// The leader renews the lease every renew interval; other replicas try to take it over at the same
+// interval and succeed once it has expired, so a crashed leader is replaced after at most lease
+// duration + renew interval. Lease expiry is compared with the clocks of the replicas, which must be
+// synchronized (NTP) to well within the lease duration.
+package leader
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/festy23/avito_internship/internal/config"
+	"github.com/festy23/avito_internship/internal/database/dberror"
+	"github.com/festy23/avito_internship/internal/database/dialect"
+	"github.com/festy23/avito_internship/pkg/clock"
+)
+
+// JobsLease is the name of the lease held by the replica running scheduled background jobs.
+const JobsLease = "jobs"
+
+// Lease is a row of the leader_leases table.
+type Lease struct {
+	Name      string    `gorm:"primaryKey;column:name"`
+	Holder    string    `gorm:"column:holder;not null"`
+	ExpiresAt time.Time `gorm:"column:expires_at;not null"`
+}
+
+// TableName specifies the table name for GORM.
+func (Lease) TableName() string {
+	return "leader_leases"
+}
+
+// Elector campaigns for a lease and reports whether this replica currently holds it.
+type Elector struct {
+	db            *gorm.DB
+	name          string
+	id            string
+	leaseDuration time.Duration
+	renewInterval time.Duration
+	clock         clock.Clock
+	logger        *zap.SugaredLogger
+	leader        atomic.Bool
+	wg            sync.WaitGroup
+}
+
+// New creates an elector campaigning for the named lease.
+func New(db *gorm.DB, name string, cfg config.LeaderElectionConfig, logger *zap.SugaredLogger) *Elector {
+	return NewWithClock(db, name, cfg, clock.New(), logger)
+}
+
+// NewWithClock creates an elector that takes lease times from clk.
+func NewWithClock(
+	db *gorm.DB,
+	name string,
+	cfg config.LeaderElectionConfig,
+	clk clock.Clock,
+	logger *zap.SugaredLogger,
+) *Elector {
+	id := cfg.ID
+	if id == "" {
+		id = defaultID()
+	}
+	return &Elector{
+		db:            db,
+		name:          name,
+		id:            id,
+		leaseDuration: cfg.LeaseDuration,
+		renewInterval: cfg.RenewInterval,
+		clock:         clk,
+		logger:        logger,
+	}
+}
+
+// defaultID identifies the replica by host name (the pod name in Kubernetes) and process ID.
+func defaultID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
+
+// ID returns the holder ID of this replica.
+func (e *Elector) ID() string {
+	return e.id
+}
+
+// IsLeader reports whether this replica holds the lease.
+func (e *Elector) IsLeader() bool {
+	return e.leader.Load()
+}
+
+// Start campaigns for the lease until ctx is canceled. The first attempt is made before Start
+// returns, so a single replica is the leader right away. Use Wait to release the lease on shutdown.
+func (e *Elector) Start(ctx context.Context) {
+	e.campaign(ctx)
+
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		ticker := time.NewTicker(e.renewInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				e.release()
+				return
+			case <-ticker.C:
+				e.campaign(ctx)
+			}
+		}
+	}()
+}
+
+// Wait blocks until the campaign has stopped and the lease, if held, has been released.
+func (e *Elector) Wait() {
+	e.wg.Wait()
+}
+
+// campaign acquires or renews the lease and logs leadership changes. A replica that fails
+// to renew its lease steps down, since it cannot know whether the lease is still valid.
+func (e *Elector) campaign(ctx context.Context) {
+	acquired, err := e.tryAcquire(ctx)
+	if err != nil {
+		e.logger.Warnw("leader lease campaign failed", "lease", e.name, "id", e.id, "error", err)
+	}
+
+	switch wasLeader := e.leader.Swap(acquired); {
+	case acquired && !wasLeader:
+		e.logger.Infow("became leader", "lease", e.name, "id", e.id)
+	case !acquired && wasLeader:
+		e.logger.Warnw("lost leadership", "lease", e.name, "id", e.id)
+	}
+}
+
+// tryAcquire takes the lease if it is free or expired, or renews it if this replica holds it.
+func (e *Elector) tryAcquire(ctx context.Context) (bool, error) {
+	now := e.clock.Now().UTC()
+	if dialect.IsMySQL(e.db) {
+		return e.tryAcquireMySQL(ctx, now)
+	}
+	result := e.db.WithContext(ctx).Exec(
+		"INSERT INTO leader_leases (name, holder, expires_at) VALUES (?, ?, ?) "+
+			"ON CONFLICT (name) DO UPDATE SET holder = excluded.holder, expires_at = excluded.expires_at "+
+			"WHERE leader_leases.holder = excluded.holder OR leader_leases.expires_at < ?",
+		e.name, e.id, now.Add(e.leaseDuration), now,
+	)
+	if result.Error != nil {
+		return false, dberror.Wrap(result.Error, "acquire leader lease", e.name)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// tryAcquireMySQL is tryAcquire for MySQL, which has no conditional upsert and counts a row left
+// unchanged as affected. The holder is assigned first, so expires_at follows the new holder, and
+// the holder is read back to tell whether the lease was taken.
+func (e *Elector) tryAcquireMySQL(ctx context.Context, now time.Time) (bool, error) {
+	db := e.db.WithContext(ctx)
+	err := db.Exec(
+		"INSERT INTO leader_leases (name, holder, expires_at) VALUES (?, ?, ?) "+
+			"ON DUPLICATE KEY UPDATE "+
+			"holder = IF(holder = VALUES(holder) OR expires_at < ?, VALUES(holder), holder), "+
+			"expires_at = IF(holder = VALUES(holder), VALUES(expires_at), expires_at)",
+		e.name, e.id, now.Add(e.leaseDuration), now,
+	).Error
+	if err != nil {
+		return false, dberror.Wrap(err, "acquire leader lease", e.name)
+	}
+
+	var holder string
+	if err := db.Table("leader_leases").Select("holder").Where("name = ?", e.name).Row().Scan(&holder); err != nil {
+		return false, dberror.Wrap(err, "get leader lease holder", e.name)
+	}
+	return holder == e.id, nil
+}
+
+// release gives up the lease, so another replica can take over without waiting for it to expire.
+func (e *Elector) release() {
+	if !e.leader.Swap(false) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.renewInterval)
+	defer cancel()
+	err := e.db.WithContext(ctx).
+		Where("name = ? AND holder = ?", e.name, e.id).
+		Delete(&Lease{}).Error
+	if err != nil {
+		e.logger.Warnw("failed to release leader lease", "lease", e.name, "id", e.id, "error", err)
+		return
+	}
+	e.logger.Infow("released leader lease", "lease", e.name, "id", e.id)
+}
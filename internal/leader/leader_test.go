@@ -0,0 +1,119 @@
+package leader
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/festy23/avito_internship/internal/config"
+	"github.com/festy23/avito_internship/pkg/clock"
+)
+
+func setupDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&Lease{}))
+	return db
+}
+
+func newElector(db *gorm.DB, id string, clk clock.Clock) *Elector {
+	cfg := config.LeaderElectionConfig{
+		Enabled:       true,
+		ID:            id,
+		LeaseDuration: 15 * time.Second,
+		RenewInterval: 5 * time.Second,
+	}
+	return NewWithClock(db, JobsLease, cfg, clk, zap.NewNop().Sugar())
+}
+
+func TestElector_Campaign(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("single replica becomes leader", func(t *testing.T) {
+		db := setupDB(t)
+		e := newElector(db, "pod-1", clock.NewFake(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)))
+
+		e.campaign(ctx)
+
+		assert.True(t, e.IsLeader())
+		var lease Lease
+		require.NoError(t, db.First(&lease, "name = ?", JobsLease).Error)
+		assert.Equal(t, "pod-1", lease.Holder)
+	})
+
+	t.Run("only one replica holds the lease", func(t *testing.T) {
+		db := setupDB(t)
+		clk := clock.NewFake(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+		first, second := newElector(db, "pod-1", clk), newElector(db, "pod-2", clk)
+
+		first.campaign(ctx)
+		second.campaign(ctx)
+		assert.True(t, first.IsLeader())
+		assert.False(t, second.IsLeader())
+
+		// Renewals keep the lease with the leader
+		clk.Advance(10 * time.Second)
+		first.campaign(ctx)
+		clk.Advance(10 * time.Second)
+		second.campaign(ctx)
+		assert.True(t, first.IsLeader())
+		assert.False(t, second.IsLeader())
+	})
+
+	t.Run("expired lease fails over", func(t *testing.T) {
+		db := setupDB(t)
+		clk := clock.NewFake(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+		first, second := newElector(db, "pod-1", clk), newElector(db, "pod-2", clk)
+
+		first.campaign(ctx)
+		clk.Advance(16 * time.Second)
+		second.campaign(ctx)
+		assert.True(t, second.IsLeader())
+
+		// The previous leader steps down on its next renewal
+		first.campaign(ctx)
+		assert.False(t, first.IsLeader())
+	})
+
+	t.Run("steps down when renewal fails", func(t *testing.T) {
+		db := setupDB(t)
+		e := newElector(db, "pod-1", clock.NewFake(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)))
+
+		e.campaign(ctx)
+		require.True(t, e.IsLeader())
+		require.NoError(t, db.Migrator().DropTable(&Lease{}))
+
+		e.campaign(ctx)
+		assert.False(t, e.IsLeader())
+	})
+}
+
+func TestElector_Start(t *testing.T) {
+	db := setupDB(t)
+	clk := clock.NewFake(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+	first, second := newElector(db, "pod-1", clk), newElector(db, "pod-2", clk)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first.Start(ctx)
+	assert.True(t, first.IsLeader(), "first campaign runs before Start returns")
+
+	cancel()
+	first.Wait()
+	assert.False(t, first.IsLeader())
+
+	// The released lease is taken over without waiting for it to expire
+	second.campaign(context.Background())
+	assert.True(t, second.IsLeader())
+}
+
+func TestNew_DefaultID(t *testing.T) {
+	e := New(setupDB(t), JobsLease, config.LeaderElectionConfig{}, zap.NewNop().Sugar())
+	assert.NotEmpty(t, e.ID())
+}
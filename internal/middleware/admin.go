@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/festy23/avito_internship/pkg/apierror"
+)
+
+// AdminToken returns a middleware admitting only requests carrying token in the
+// "Authorization: Bearer <token>" header; other requests are rejected with 401.
+func AdminToken(token string) gin.HandlerFunc {
+	expected := []byte(token)
+	return func(c *gin.Context) {
+		provided, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(provided), expected) != 1 {
+			c.Header("WWW-Authenticate", `Bearer realm="admin"`)
+			apierror.Abort(c, apierror.Unauthorized("missing or invalid admin token"))
+			return
+		}
+		c.Next()
+	}
+}
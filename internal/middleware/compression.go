@@ -0,0 +1,178 @@
+package middleware
+
+import (
+	"bufio"
+	"compress/gzip"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CompressionOptions configures the Compression middleware.
+type CompressionOptions struct {
+	// MinSize is the response size in bytes from which responses are compressed.
+	MinSize int
+	// Level is the gzip compression level, from gzip.BestSpeed to gzip.BestCompression.
+	Level int
+	// ExcludedContentTypes lists content type prefixes (e.g. "image/") of responses sent uncompressed.
+	ExcludedContentTypes []string
+}
+
+// Compression returns a middleware compressing responses with gzip for clients accepting it.
+// The response is buffered up to MinSize bytes: smaller responses, responses of excluded content
+// types and responses already carrying a Content-Encoding are sent as is.
+func Compression(opts CompressionOptions) gin.HandlerFunc {
+	pool := &sync.Pool{New: func() any {
+		// The level is validated together with the rest of the configuration
+		w, _ := gzip.NewWriterLevel(nil, opts.Level)
+		return w
+	}}
+
+	return func(c *gin.Context) {
+		// Caches must store compressed and uncompressed variants separately
+		c.Header("Vary", "Accept-Encoding")
+		if c.Request.Method == http.MethodHead || !acceptsGzip(c.GetHeader("Accept-Encoding")) {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		cw := &compressWriter{ResponseWriter: original, opts: &opts, pool: pool}
+		c.Writer = cw
+		defer func() {
+			cw.finish()
+			c.Writer = original
+		}()
+
+		c.Next()
+	}
+}
+
+// acceptsGzip reports whether an Accept-Encoding header value allows gzip.
+func acceptsGzip(header string) bool {
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding != "gzip" && coding != "*" {
+			continue
+		}
+		q, found := strings.CutPrefix(strings.TrimSpace(params), "q=")
+		if !found {
+			return true
+		}
+		weight, err := strconv.ParseFloat(q, 64)
+		return err == nil && weight > 0
+	}
+	return false
+}
+
+// compressWriter buffers the beginning of a response to decide whether to compress it.
+type compressWriter struct {
+	gin.ResponseWriter
+	opts    *CompressionOptions
+	pool    *sync.Pool
+	buf     []byte
+	decided bool
+	gz      *gzip.Writer
+}
+
+// Write buffers p until the compression decision is made, then writes through the chosen path.
+func (w *compressWriter) Write(p []byte) (int, error) {
+	if !w.decided {
+		w.buf = append(w.buf, p...)
+		if len(w.buf) < w.opts.MinSize {
+			return len(p), nil
+		}
+		if err := w.decide(w.compressible()); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+	if w.gz != nil {
+		return w.gz.Write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// WriteString writes s; the embedded implementation would bypass the buffer.
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush sends the buffered response; a response that is not compressed yet is streamed as is.
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		_ = w.decide(false)
+	}
+	if w.gz != nil {
+		_ = w.gz.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// Hijack takes over the connection; the buffered response is discarded.
+func (w *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.decided = true
+	w.buf = nil
+	return w.ResponseWriter.Hijack()
+}
+
+// compressible reports whether the response may be compressed, judging by its status and headers.
+func (w *compressWriter) compressible() bool {
+	status := w.Status()
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified {
+		return false
+	}
+	header := w.Header()
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+	contentType := strings.ToLower(header.Get("Content-Type"))
+	for _, excluded := range w.opts.ExcludedContentTypes {
+		if strings.HasPrefix(contentType, excluded) {
+			return false
+		}
+	}
+	return true
+}
+
+// decide chooses whether to compress the response and writes the buffered part of it.
+func (w *compressWriter) decide(compress bool) error {
+	w.decided = true
+	if compress {
+		header := w.Header()
+		header.Set("Content-Encoding", "gzip")
+		header.Del("Content-Length")
+		w.gz = w.pool.Get().(*gzip.Writer)
+		w.gz.Reset(w.ResponseWriter)
+	}
+
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	var err error
+	if w.gz != nil {
+		_, err = w.gz.Write(buf)
+	} else {
+		_, err = w.ResponseWriter.Write(buf)
+	}
+	return err
+}
+
+// finish writes a response that stayed below MinSize and completes the gzip stream.
+func (w *compressWriter) finish() {
+	if !w.decided {
+		_ = w.decide(false)
+	}
+	if w.gz != nil {
+		_ = w.gz.Close()
+		w.pool.Put(w.gz)
+		w.gz = nil
+	}
+}
@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupCompressionRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Compression(CompressionOptions{MinSize: 100, Level: gzip.DefaultCompression,
+		ExcludedContentTypes: []string{"image/"}}))
+	large := func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"items": strings.Repeat("item,", 100)})
+	}
+	r.GET("/large", large)
+	r.HEAD("/large", large)
+	r.GET("/small", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"items": "item"})
+	})
+	r.GET("/chunks", func(c *gin.Context) {
+		c.Header("Content-Type", "text/plain")
+		for range 50 {
+			_, _ = c.Writer.WriteString("chunk\n")
+		}
+	})
+	r.GET("/image", func(c *gin.Context) {
+		c.Data(http.StatusOK, "image/png", make([]byte, 1000))
+	})
+	return r
+}
+
+func serveEncoded(r *gin.Engine, method, path, acceptEncoding string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func gunzip(t *testing.T, w *httptest.ResponseRecorder) string {
+	zr, err := gzip.NewReader(w.Body)
+	require.NoError(t, err)
+	body, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestCompression(t *testing.T) {
+	r := setupCompressionRouter()
+
+	t.Run("large response is compressed", func(t *testing.T) {
+		w := serveEncoded(r, http.MethodGet, "/large", "br, gzip;q=0.8")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+		assert.Empty(t, w.Header().Get("Content-Length"))
+		assert.Equal(t, `{"items":"`+strings.Repeat("item,", 100)+`"}`, gunzip(t, w))
+	})
+
+	t.Run("response written in chunks is compressed", func(t *testing.T) {
+		w := serveEncoded(r, http.MethodGet, "/chunks", "gzip")
+
+		assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+		assert.Equal(t, strings.Repeat("chunk\n", 50), gunzip(t, w))
+	})
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		acceptEncoding string
+	}{
+		{"below minimum size", http.MethodGet, "/small", "gzip"},
+		{"gzip not accepted", http.MethodGet, "/large", ""},
+		{"gzip refused", http.MethodGet, "/large", "gzip;q=0, identity"},
+		{"excluded content type", http.MethodGet, "/image", "gzip"},
+		{"head request", http.MethodHead, "/large", "gzip"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plain := serveEncoded(r, tt.method, tt.path, "")
+			w := serveEncoded(r, tt.method, tt.path, tt.acceptEncoding)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Empty(t, w.Header().Get("Content-Encoding"))
+			assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+			assert.Equal(t, plain.Body.String(), w.Body.String())
+		})
+	}
+}
+
+func TestAcceptsGzip(t *testing.T) {
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{"gzip", true},
+		{"deflate, GZIP", true},
+		{"gzip;q=0.5", true},
+		{"*", true},
+		{"gzip;q=0", false},
+		{"gzip;q=abc", false},
+		{"br, deflate", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, acceptsGzip(tt.header), tt.header)
+	}
+}
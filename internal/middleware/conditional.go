@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// cacheControl makes clients and proxies revalidate every response with the server:
+// the data changes at any time and differs between tenants.
+const cacheControl = "private, no-cache"
+
+// ConditionalGet returns a middleware adding an ETag to successful responses of read endpoints and
+// answering 304 Not Modified when it matches the If-None-Match header of the request. The response
+// is still built on every request, but polling clients receive its body only when it has changed.
+func ConditionalGet() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		original := c.Writer
+		bw := &bufferWriter{ResponseWriter: original}
+		c.Writer = bw
+		defer func() {
+			c.Writer = original
+		}()
+
+		c.Next()
+
+		if original.Status() != http.StatusOK {
+			_, _ = original.Write(bw.buf)
+			return
+		}
+
+		etag := computeETag(bw.buf)
+		header := original.Header()
+		header.Set("ETag", etag)
+		header.Set("Cache-Control", cacheControl)
+		if etagMatches(c.GetHeader("If-None-Match"), etag) {
+			header.Del("Content-Type")
+			header.Del("Content-Length")
+			original.WriteHeader(http.StatusNotModified)
+			original.WriteHeaderNow()
+			return
+		}
+		_, _ = original.Write(bw.buf)
+	}
+}
+
+// computeETag returns a weak entity tag of a response body. The tag is weak because the body may be
+// sent compressed, which changes its bytes but not its meaning.
+func computeETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `W/"` + base64.RawURLEncoding.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches reports whether an If-None-Match header value matches etag, using the weak comparison.
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// bufferWriter holds the response body until the handler chain completes.
+type bufferWriter struct {
+	gin.ResponseWriter
+	buf []byte
+}
+
+// Write buffers p.
+func (w *bufferWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	return len(p), nil
+}
+
+// WriteString buffers s; the embedded implementation would bypass the buffer.
+func (w *bufferWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Written reports whether the handler has produced a response, even if it is still buffered.
+func (w *bufferWriter) Written() bool {
+	return len(w.buf) > 0 || w.ResponseWriter.Written()
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveConditional(r *gin.Engine, path, ifNoneMatch string, acceptGzip bool) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	if acceptGzip {
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestConditionalGet(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Compression(CompressionOptions{MinSize: 10, Level: 5}))
+	version := "v1"
+	r.GET("/team", ConditionalGet(), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"version": version, "members": strings.Repeat("u,", 20)})
+	})
+	r.GET("/missing", ConditionalGet(), func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+	})
+
+	first := serveConditional(r, "/team", "", false)
+	require.Equal(t, http.StatusOK, first.Code)
+	etag := first.Header().Get("ETag")
+	assert.True(t, strings.HasPrefix(etag, `W/"`), etag)
+	assert.Equal(t, "private, no-cache", first.Header().Get("Cache-Control"))
+	assert.Contains(t, first.Body.String(), `"version":"v1"`)
+
+	t.Run("unchanged response is not modified", func(t *testing.T) {
+		for _, ifNoneMatch := range []string{etag, `"other", ` + strings.TrimPrefix(etag, "W/"), "*"} {
+			w := serveConditional(r, "/team", ifNoneMatch, true)
+
+			assert.Equal(t, http.StatusNotModified, w.Code, ifNoneMatch)
+			assert.Empty(t, w.Body.String())
+			assert.Equal(t, etag, w.Header().Get("ETag"))
+			assert.Empty(t, w.Header().Get("Content-Encoding"))
+		}
+	})
+
+	t.Run("compressed response has the same tag", func(t *testing.T) {
+		w := serveConditional(r, "/team", "", true)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+		assert.Equal(t, etag, w.Header().Get("ETag"))
+	})
+
+	t.Run("changed response is sent in full", func(t *testing.T) {
+		version = "v2"
+		defer func() { version = "v1" }()
+
+		w := serveConditional(r, "/team", etag, false)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotEqual(t, etag, w.Header().Get("ETag"))
+		assert.Contains(t, w.Body.String(), `"version":"v2"`)
+	})
+
+	t.Run("errors are not tagged", func(t *testing.T) {
+		w := serveConditional(r, "/missing", "*", false)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Empty(t, w.Header().Get("ETag"))
+		assert.Contains(t, w.Body.String(), "not found")
+	})
+}
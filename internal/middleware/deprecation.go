@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Deprecation describes a deprecated route.
+type Deprecation struct {
+	// Since is when the route was deprecated (Deprecation header, RFC 9745).
+	Since time.Time
+	// Sunset is when the route stops working (Sunset header, RFC 8594); zero if not decided yet.
+	Sunset time.Time
+	// Successor is the path of the route replacing it, if any.
+	Successor string
+	// Message is a human readable notice for the Warning header, e.g. what to migrate to.
+	Message string
+}
+
+// RouteKey returns the key of a route in the map passed to Deprecations, e.g. "GET /team/get".
+func RouteKey(method, path string) string {
+	return method + " " + path
+}
+
+// Deprecations returns a middleware announcing deprecated routes to clients. Responses of the routes
+// in deprecated, keyed by RouteKey with the registered path (/users/:id rather than /users/u1), get
+// the Deprecation, Sunset and Link headers, plus a Warning header with the message, so clients learn
+// about a change before the route is removed. Other responses are not changed.
+func Deprecations(deprecated map[string]Deprecation) gin.HandlerFunc {
+	headers := make(map[string]http.Header, len(deprecated))
+	for key, d := range deprecated {
+		headers[key] = d.headers()
+	}
+	return func(c *gin.Context) {
+		if h, ok := headers[RouteKey(c.Request.Method, c.FullPath())]; ok {
+			for name, values := range h {
+				c.Writer.Header()[name] = values
+			}
+		}
+		c.Next()
+	}
+}
+
+// headers returns the response headers announcing the deprecation.
+func (d Deprecation) headers() http.Header {
+	h := http.Header{}
+	h.Set("Deprecation", "@"+strconv.FormatInt(d.Since.Unix(), 10))
+	if !d.Sunset.IsZero() {
+		h.Set("Sunset", d.Sunset.UTC().Format(http.TimeFormat))
+	}
+	if d.Successor != "" {
+		h.Set("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, d.Successor))
+	}
+	message := d.Message
+	if message == "" {
+		message = "Deprecated API"
+	}
+	// Quotes and backslashes are escaped as in any quoted-string
+	message = strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(message)
+	h.Set("Warning", `299 - "`+message+`"`)
+	return h
+}
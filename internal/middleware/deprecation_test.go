@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecations(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Deprecations(map[string]Deprecation{
+		RouteKey(http.MethodGet, "/users/:id"): {
+			Since:     time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			Sunset:    time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC),
+			Successor: "/users/get",
+			Message:   `use "/users/get"`,
+		},
+		RouteKey(http.MethodGet, "/old"): {Since: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.GET("/users/:id", ok)
+	r.POST("/users/:id", ok)
+	r.GET("/old", ok)
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		return w
+	}
+
+	t.Run("deprecated route", func(t *testing.T) {
+		w := serve(http.MethodGet, "/users/u1")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "@1767225600", w.Header().Get("Deprecation"))
+		assert.Equal(t, "Wed, 01 Jul 2026 00:00:00 GMT", w.Header().Get("Sunset"))
+		assert.Equal(t, `</users/get>; rel="successor-version"`, w.Header().Get("Link"))
+		assert.Equal(t, `299 - "use \"/users/get\""`, w.Header().Get("Warning"))
+	})
+
+	t.Run("without sunset and successor", func(t *testing.T) {
+		w := serve(http.MethodGet, "/old")
+
+		assert.Equal(t, "@1767225600", w.Header().Get("Deprecation"))
+		assert.Empty(t, w.Header().Get("Sunset"))
+		assert.Empty(t, w.Header().Get("Link"))
+		assert.Equal(t, `299 - "Deprecated API"`, w.Header().Get("Warning"))
+	})
+
+	t.Run("other method of the path", func(t *testing.T) {
+		w := serve(http.MethodPost, "/users/u1")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("Deprecation"))
+		assert.Empty(t, w.Header().Get("Warning"))
+	})
+}
@@ -0,0 +1,175 @@
+package middleware
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/festy23/avito_internship/pkg/apierror"
+)
+
+// codeFaultInjected is the error code of injected errors.
+const codeFaultInjected = "FAULT_INJECTED"
+
+// FaultRule describes faults injected into requests matching a route.
+type FaultRule struct {
+	// Method is the HTTP method to match; empty matches any method.
+	Method string
+	// Path is the request path to match; "*" matches any path.
+	Path string
+	// Latency delays matching requests.
+	Latency time.Duration
+	// Status aborts matching requests with the given status code; 0 lets them through.
+	Status int
+	// Percent is the percentage of matching requests affected.
+	Percent float64
+}
+
+// Matches reports whether the rule applies to the request.
+func (r FaultRule) Matches(method, path string) bool {
+	if r.Method != "" && r.Method != method {
+		return false
+	}
+	return r.Path == "*" || r.Path == path
+}
+
+// ParseFaultRules parses rules separated by ";". A rule is a route ("*", "/path" or "METHOD /path")
+// followed by options: latency=<duration>, status=<4xx|5xx>, percent=<0-100> (default 100).
+//
+// Example: "POST /pullRequest/create latency=500ms percent=50; * status=503 percent=5".
+func ParseFaultRules(s string) ([]FaultRule, error) {
+	var rules []FaultRule
+	for _, part := range strings.Split(s, ";") {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			continue
+		}
+		rule, err := parseFaultRule(fields)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %w", strings.TrimSpace(part), err)
+		}
+		rules = append(rules, rule)
+	}
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("no rules")
+	}
+	return rules, nil
+}
+
+//nolint:gocyclo // One branch per option
+func parseFaultRule(fields []string) (FaultRule, error) {
+	rule := FaultRule{Percent: 100}
+	var err error
+	if rule.Method, rule.Path, fields, err = parseRoute(fields); err != nil {
+		return rule, err
+	}
+
+	for _, field := range fields {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return rule, fmt.Errorf("option %q must be key=value", field)
+		}
+		switch key {
+		case "latency":
+			rule.Latency, err = time.ParseDuration(value)
+		case "status":
+			rule.Status, err = strconv.Atoi(value)
+		case "percent":
+			rule.Percent, err = strconv.ParseFloat(value, 64)
+		default:
+			return rule, fmt.Errorf("unknown option %q", key)
+		}
+		if err != nil {
+			return rule, fmt.Errorf("invalid %s: %w", key, err)
+		}
+	}
+
+	if rule.Latency < 0 {
+		return rule, fmt.Errorf("latency must not be negative")
+	}
+	if rule.Status != 0 && (rule.Status < 400 || rule.Status > 599) {
+		return rule, fmt.Errorf("status must be between 400 and 599")
+	}
+	if rule.Percent < 0 || rule.Percent > 100 {
+		return rule, fmt.Errorf("percent must be between 0 and 100")
+	}
+	if rule.Latency == 0 && rule.Status == 0 {
+		return rule, fmt.Errorf("rule injects neither latency nor status")
+	}
+	return rule, nil
+}
+
+// FaultInjection returns a middleware that delays or fails requests according to rules, so client
+// retries and timeouts can be exercised. The first matching rule applies. For tests only.
+func FaultInjection(rules []FaultRule, logger *zap.SugaredLogger) gin.HandlerFunc {
+	//nolint:gosec // G404: fault sampling does not need a cryptographic source
+	return faultInjection(rules, rand.Float64, logger)
+}
+
+func faultInjection(rules []FaultRule, roll func() float64, logger *zap.SugaredLogger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		method, path := c.Request.Method, c.Request.URL.Path
+		rule, ok := matchFaultRule(rules, method, path)
+		if !ok || roll()*100 >= rule.Percent {
+			c.Next()
+			return
+		}
+
+		if rule.Latency > 0 {
+			logger.Debugw("fault injected", "fault", "latency", "latency", rule.Latency,
+				"path", path, "method", method)
+			select {
+			case <-time.After(rule.Latency):
+			case <-c.Request.Context().Done():
+				c.Abort()
+				return
+			}
+		}
+
+		if rule.Status == 0 {
+			c.Next()
+			return
+		}
+
+		logger.Debugw("fault injected", "fault", "error", "status", rule.Status,
+			"path", path, "method", method)
+		c.Header("X-Fault-Injected", "true")
+		apiErr := apierror.New(codeFaultInjected, rule.Status, "injected fault: "+http.StatusText(rule.Status))
+		// Simulated throttling and unavailability ask clients to back off, like real ones
+		if rule.Status == http.StatusTooManyRequests || rule.Status == http.StatusServiceUnavailable {
+			apiErr = apiErr.WithRetryAfter(time.Second)
+		}
+		apierror.Abort(c, apiErr)
+	}
+}
+
+// parseRoute parses the route of a rule ("*", "/path" or "METHOD /path") from the leading fields
+// and returns the remaining fields.
+func parseRoute(fields []string) (method, path string, rest []string, err error) {
+	path, rest = fields[0], fields[1:]
+	if path != "*" && !strings.HasPrefix(path, "/") {
+		if len(rest) == 0 {
+			return "", "", nil, fmt.Errorf("missing path")
+		}
+		method, path, rest = strings.ToUpper(path), rest[0], rest[1:]
+	}
+	if path != "*" && !strings.HasPrefix(path, "/") {
+		return "", "", nil, fmt.Errorf("path must be * or start with /")
+	}
+	return method, path, rest, nil
+}
+
+func matchFaultRule(rules []FaultRule, method, path string) (FaultRule, bool) {
+	for _, rule := range rules {
+		if rule.Matches(method, path) {
+			return rule, true
+		}
+	}
+	return FaultRule{}, false
+}
@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func setupFaultInjectionRouter(t *testing.T, rules string, roll float64) *gin.Engine {
+	parsed, err := ParseFaultRules(rules)
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(faultInjection(parsed, func() float64 { return roll }, zaptest.NewLogger(t).Sugar()))
+	r.GET("/ok", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "ok"})
+	})
+	r.POST("/ok", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "ok"})
+	})
+	return r
+}
+
+func serve(r *gin.Engine, method, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+	return w
+}
+
+func TestParseFaultRules(t *testing.T) {
+	t.Run("valid rules", func(t *testing.T) {
+		rules, err := ParseFaultRules("post /pullRequest/create latency=200ms percent=50; * status=503")
+		require.NoError(t, err)
+		assert.Equal(t, []FaultRule{
+			{Method: "POST", Path: "/pullRequest/create", Latency: 200 * time.Millisecond, Percent: 50},
+			{Path: "*", Status: 503, Percent: 100},
+		}, rules)
+	})
+
+	tests := []struct {
+		name  string
+		rules string
+		err   string
+	}{
+		{"empty", " ; ", "no rules"},
+		{"missing path", "POST", "missing path"},
+		{"invalid path", "POST create status=500", "path must be"},
+		{"unknown option", "* delay=1s", "unknown option"},
+		{"not key=value", "* status", "must be key=value"},
+		{"invalid latency", "* latency=soon", "invalid latency"},
+		{"status out of range", "* status=200", "status must be between"},
+		{"percent out of range", "* status=500 percent=101", "percent must be between"},
+		{"no fault", "* percent=10", "neither latency nor status"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseFaultRules(tt.rules)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestFaultInjection_Middleware(t *testing.T) {
+	t.Run("injects error", func(t *testing.T) {
+		r := setupFaultInjectionRouter(t, "GET /ok status=503", 0)
+
+		w := serve(r, http.MethodGet, "/ok")
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Contains(t, w.Body.String(), "FAULT_INJECTED")
+		assert.Equal(t, "true", w.Header().Get("X-Fault-Injected"))
+		assert.Equal(t, "1", w.Header().Get("Retry-After"))
+		assert.Contains(t, w.Body.String(), `"retryable":true`)
+	})
+
+	t.Run("non-retryable error has no Retry-After", func(t *testing.T) {
+		r := setupFaultInjectionRouter(t, "GET /ok status=500", 0)
+
+		w := serve(r, http.MethodGet, "/ok")
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Empty(t, w.Header().Get("Retry-After"))
+	})
+
+	t.Run("other method is not affected", func(t *testing.T) {
+		r := setupFaultInjectionRouter(t, "GET /ok status=503", 0)
+
+		w := serve(r, http.MethodPost, "/ok")
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("request outside percentage is not affected", func(t *testing.T) {
+		r := setupFaultInjectionRouter(t, "* status=500 percent=30", 0.5)
+
+		w := serve(r, http.MethodGet, "/ok")
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("injects latency", func(t *testing.T) {
+		r := setupFaultInjectionRouter(t, "/ok latency=50ms", 0)
+
+		start := time.Now()
+		w := serve(r, http.MethodGet, "/ok")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	})
+
+	t.Run("first matching rule wins", func(t *testing.T) {
+		r := setupFaultInjectionRouter(t, "/ok status=429; * status=500", 0)
+
+		assert.Equal(t, http.StatusTooManyRequests, serve(r, http.MethodGet, "/ok").Code)
+		assert.Equal(t, http.StatusInternalServerError, serve(r, http.MethodGet, "/other").Code)
+	})
+}
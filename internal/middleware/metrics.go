@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// unmatchedRoute labels requests that matched no route, whatever their path.
+	unmatchedRoute = "unmatched"
+	// otherMethod labels requests with a non-standard method.
+	otherMethod = "OTHER"
+)
+
+// knownMethods are the methods used as labels as is; other methods are labeled otherMethod.
+var knownMethods = map[string]bool{
+	http.MethodGet: true, http.MethodHead: true, http.MethodPost: true, http.MethodPut: true,
+	http.MethodPatch: true, http.MethodDelete: true, http.MethodOptions: true,
+}
+
+// HTTPMetrics holds the request metrics of the HTTP server. It is a prometheus.Collector, so all
+// instruments are registered together.
+type HTTPMetrics struct {
+	// Requests counts requests by method, route template and status code.
+	Requests *prometheus.CounterVec
+	// Duration observes the request duration in seconds by method and route template.
+	Duration *prometheus.HistogramVec
+}
+
+// NewHTTPMetrics creates request metrics with the given duration histogram buckets in seconds;
+// nil uses the Prometheus default buckets.
+func NewHTTPMetrics(buckets []float64) *HTTPMetrics {
+	if buckets == nil {
+		buckets = prometheus.DefBuckets
+	}
+	return &HTTPMetrics{
+		Requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Number of HTTP requests by method, route and status code.",
+		}, []string{"method", "route", "status"}),
+		Duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Duration of HTTP requests in seconds by method and route.",
+			Buckets: buckets,
+		}, []string{"method", "route"}),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (m *HTTPMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.Requests.Describe(ch)
+	m.Duration.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (m *HTTPMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.Requests.Collect(ch)
+	m.Duration.Collect(ch)
+}
+
+// Metrics returns a middleware recording the number and duration of requests. Routes are labeled by
+// their template (/users/:id rather than /users/u1), requests matching no route share a single
+// "unmatched" label and non-standard methods are labeled "OTHER", so clients cannot grow the label
+// cardinality. It goes before the recovery middleware to count the 500 responses to panics.
+func Metrics(m *HTTPMetrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		method := c.Request.Method
+		if !knownMethods[method] {
+			method = otherMethod
+		}
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		m.Requests.WithLabelValues(method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		m.Duration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+	}
+}
+
+// ParseBuckets parses comma-separated histogram bucket upper bounds in seconds, e.g.
+// "0.01,0.05,0.1,0.5,1". Bounds must be positive and strictly increasing.
+func ParseBuckets(s string) ([]float64, error) {
+	var buckets []float64
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		bound, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bucket %q", part)
+		}
+		if bound <= 0 {
+			return nil, fmt.Errorf("bucket %q must be positive", part)
+		}
+		if len(buckets) > 0 && bound <= buckets[len(buckets)-1] {
+			return nil, fmt.Errorf("buckets must be strictly increasing, got %q after %v", part, buckets[len(buckets)-1])
+		}
+		buckets = append(buckets, bound)
+	}
+	if len(buckets) == 0 {
+		return nil, fmt.Errorf("no buckets")
+	}
+	return buckets, nil
+}
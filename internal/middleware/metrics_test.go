@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMetrics(t *testing.T) {
+	m := NewHTTPMetrics([]float64{0.1, 1})
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Metrics(m), Recovery(zap.NewNop().Sugar()))
+	r.GET("/users/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.POST("/panic", func(*gin.Context) { panic("boom") })
+
+	serve(r, http.MethodGet, "/users/u1")
+	serve(r, http.MethodGet, "/users/u2")
+	serve(r, http.MethodPost, "/panic")
+	serve(r, http.MethodGet, "/missing/1")
+	serve(r, http.MethodGet, "/missing/2")
+	serve(r, "PROPFIND", "/users/u1")
+
+	t.Run("route templates", func(t *testing.T) {
+		assert.Equal(t, 2.0, testutil.ToFloat64(m.Requests.WithLabelValues("GET", "/users/:id", "200")))
+		assert.Equal(t, 1.0, testutil.ToFloat64(m.Requests.WithLabelValues("POST", "/panic", "500")))
+	})
+
+	t.Run("bounded cardinality", func(t *testing.T) {
+		assert.Equal(t, 2.0, testutil.ToFloat64(m.Requests.WithLabelValues("GET", unmatchedRoute, "404")))
+		assert.Equal(t, 1.0, testutil.ToFloat64(m.Requests.WithLabelValues(otherMethod, unmatchedRoute, "404")))
+		assert.Equal(t, 4, testutil.CollectAndCount(m.Requests))
+	})
+
+	t.Run("duration histogram", func(t *testing.T) {
+		assert.Equal(t, 4, testutil.CollectAndCount(m.Duration))
+	})
+
+	t.Run("registers as one collector", func(t *testing.T) {
+		require.NoError(t, prometheus.NewRegistry().Register(m))
+	})
+}
+
+func TestParseBuckets(t *testing.T) {
+	buckets, err := ParseBuckets(" 0.005, 0.05 ,0.5,5 ")
+	require.NoError(t, err)
+	assert.Equal(t, []float64{0.005, 0.05, 0.5, 5}, buckets)
+
+	tests := []struct {
+		name    string
+		buckets string
+		err     string
+	}{
+		{"empty", " , ", "no buckets"},
+		{"not a number", "0.1,fast", "invalid bucket"},
+		{"not positive", "0,1", "must be positive"},
+		{"not increasing", "0.5,0.5", "strictly increasing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseBuckets(tt.buckets)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
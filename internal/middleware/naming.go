@@ -0,0 +1,273 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Naming is a convention of JSON field names.
+type Naming string
+
+const (
+	// NamingSnakeCase is the snake_case naming of the OpenAPI spec (pull_request_id).
+	NamingSnakeCase Naming = "snake_case"
+	// NamingCamelCase is the camelCase naming (pullRequestId).
+	NamingCamelCase Naming = "camelCase"
+)
+
+// ParseNaming parses a naming convention name; empty means NamingSnakeCase.
+func ParseNaming(s string) (Naming, error) {
+	switch Naming(s) {
+	case "", NamingSnakeCase:
+		return NamingSnakeCase, nil
+	case NamingCamelCase:
+		return NamingCamelCase, nil
+	}
+	return "", fmt.Errorf("unknown naming %q (must be: %s, %s)", s, NamingSnakeCase, NamingCamelCase)
+}
+
+// FieldNaming returns a middleware renaming JSON fields for clients preferring camelCase. Models keep
+// their snake_case tags: with camelCase in effect, field names of JSON request bodies are converted
+// to snake_case before handlers read them and field names of JSON responses are converted to
+// camelCase. Field order and values are kept; fields already in camelCase (createdAt) stay as they are.
+//
+// The convention is naming unless the request asks for one with a profile parameter of the Accept
+// header, e.g. `Accept: application/json; profile="camelCase"`. JSON responses are buffered when
+// they are converted, so the conversion is meant for API responses rather than large exports.
+func FieldNaming(naming Naming) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Caches must store the variants of a response separately
+		c.Writer.Header().Add("Vary", "Accept")
+		if requestedNaming(c.GetHeader("Accept"), naming) != NamingCamelCase {
+			c.Next()
+			return
+		}
+
+		if isJSON(c.ContentType()) && c.Request.Body != nil {
+			renameRequestBody(c.Request)
+		}
+
+		original := c.Writer
+		nw := &namingWriter{ResponseWriter: original}
+		c.Writer = nw
+		defer func() {
+			nw.finish()
+			c.Writer = original
+		}()
+
+		c.Next()
+	}
+}
+
+// maxRenamedRequestBody is the size of request bodies up to which field names are converted. Larger
+// bodies (e.g. admin imports) are passed on as they are, so they are not read into memory here
+// before the handler applies its own size limit.
+const maxRenamedRequestBody = 1 << 20
+
+// renameRequestBody converts field names of a JSON request body to snake_case.
+func renameRequestBody(r *http.Request) {
+	original := r.Body
+	body, err := io.ReadAll(io.LimitReader(original, maxRenamedRequestBody+1))
+	if err != nil || len(body) > maxRenamedRequestBody {
+		r.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(body), original), Closer: original}
+		return
+	}
+	_ = original.Close()
+	// Invalid JSON is passed on as is, so binding reports it like any other malformed body
+	if renamed, err := renameKeys(body, toSnakeCase); err == nil {
+		body = renamed
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+}
+
+// readCloser combines a reader with the closer of the body it continues.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// requestedNaming returns the naming requested by the profile parameter of an Accept header value,
+// or fallback.
+func requestedNaming(accept string, fallback Naming) Naming {
+	for _, mediaRange := range strings.Split(accept, ",") {
+		_, params, _ := strings.Cut(mediaRange, ";")
+		for _, param := range strings.Split(params, ";") {
+			name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || !strings.EqualFold(name, "profile") {
+				continue
+			}
+			if naming, err := ParseNaming(strings.Trim(value, `"`)); err == nil {
+				return naming
+			}
+		}
+	}
+	return fallback
+}
+
+// isJSON reports whether a media type is JSON, including structured types like application/problem+json.
+func isJSON(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// namingWriter buffers JSON responses to rename their fields; other responses are passed through.
+type namingWriter struct {
+	gin.ResponseWriter
+	buf       bytes.Buffer
+	decided   bool
+	buffering bool
+}
+
+// Write buffers p if the response is JSON and writes it through otherwise.
+func (w *namingWriter) Write(p []byte) (int, error) {
+	if !w.decided {
+		w.decided = true
+		w.buffering = isJSON(w.Header().Get("Content-Type"))
+	}
+	if !w.buffering {
+		return w.ResponseWriter.Write(p)
+	}
+	return w.buf.Write(p)
+}
+
+// WriteString writes s like Write; the embedded implementation would bypass the buffer.
+func (w *namingWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Written reports whether the handler has produced a response, even if it is still buffered.
+func (w *namingWriter) Written() bool {
+	return w.buf.Len() > 0 || w.ResponseWriter.Written()
+}
+
+// Flush is a no-op while the response is buffered.
+func (w *namingWriter) Flush() {
+	if !w.buffering {
+		w.ResponseWriter.Flush()
+	}
+}
+
+// finish writes the buffered response with renamed fields.
+func (w *namingWriter) finish() {
+	if !w.buffering {
+		return
+	}
+	body := w.buf.Bytes()
+	if renamed, err := renameKeys(body, toCamelCase); err == nil {
+		body = renamed
+	}
+	w.Header().Del("Content-Length")
+	_, _ = w.ResponseWriter.Write(body)
+}
+
+// renameKeys rewrites the object keys of a JSON document with rename, keeping the order of fields
+// and the representation of numbers.
+func renameKeys(data []byte, rename func(string) string) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	// container is an open object or array; count is the number of tokens written into it
+	type container struct {
+		object bool
+		count  int
+	}
+	var (
+		out   bytes.Buffer
+		stack []container
+	)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			// The decoder reports the end of input inside an unterminated document as EOF too
+			if len(stack) > 0 {
+				return nil, io.ErrUnexpectedEOF
+			}
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if delim, ok := tok.(json.Delim); ok && (delim == '}' || delim == ']') {
+			stack = stack[:len(stack)-1]
+			out.WriteRune(rune(delim))
+			continue
+		}
+
+		isKey := false
+		if n := len(stack); n > 0 {
+			top := &stack[n-1]
+			switch {
+			case top.object && top.count%2 == 1:
+				out.WriteByte(':')
+			case top.count > 0:
+				out.WriteByte(',')
+			}
+			isKey = top.object && top.count%2 == 0
+			top.count++
+		}
+
+		switch v := tok.(type) {
+		case json.Delim:
+			out.WriteRune(rune(v))
+			stack = append(stack, container{object: v == '{'})
+		case string:
+			if isKey {
+				v = rename(v)
+			}
+			encoded, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			out.Write(encoded)
+		case json.Number:
+			out.WriteString(v.String())
+		case bool:
+			fmt.Fprint(&out, v)
+		case nil:
+			out.WriteString("null")
+		}
+	}
+	return out.Bytes(), nil
+}
+
+// toCamelCase converts a snake_case name to camelCase: pull_request_id becomes pullRequestId.
+func toCamelCase(name string) string {
+	if strings.HasPrefix(name, "_") || !strings.Contains(name, "_") {
+		return name
+	}
+	parts := strings.Split(name, "_")
+	var b strings.Builder
+	b.WriteString(parts[0])
+	for _, part := range parts[1:] {
+		if part == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+// toSnakeCase converts a camelCase name to snake_case: pullRequestId becomes pull_request_id.
+func toSnakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
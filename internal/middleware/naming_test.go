@@ -0,0 +1,155 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupNamingRouter(naming Naming) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(FieldNaming(naming))
+	r.GET("/pr", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"pr": gin.H{
+			"pull_request_id":    "pr-1",
+			"assigned_reviewers": []string{"u2", "u3"},
+			"createdAt":          "2025-11-01T10:00:00Z",
+		}})
+	})
+	r.POST("/echo", func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.Data(http.StatusOK, "application/json", body)
+	})
+	r.GET("/text", func(c *gin.Context) {
+		c.String(http.StatusOK, "pull_request_id")
+	})
+	return r
+}
+
+func serveNaming(r *gin.Engine, method, path, accept, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestFieldNaming(t *testing.T) {
+	const snake = `{"pr":{"assigned_reviewers":["u2","u3"],"createdAt":"2025-11-01T10:00:00Z","pull_request_id":"pr-1"}}`
+	const camel = `{"pr":{"assignedReviewers":["u2","u3"],"createdAt":"2025-11-01T10:00:00Z","pullRequestId":"pr-1"}}`
+
+	t.Run("snake_case by default", func(t *testing.T) {
+		w := serveNaming(setupNamingRouter(NamingSnakeCase), http.MethodGet, "/pr", "", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "Accept", w.Header().Get("Vary"))
+		assert.JSONEq(t, snake, w.Body.String())
+	})
+
+	t.Run("camelCase requested with Accept profile", func(t *testing.T) {
+		w := serveNaming(setupNamingRouter(NamingSnakeCase), http.MethodGet, "/pr",
+			`application/json; profile="camelCase"`, "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, camel, w.Body.String())
+	})
+
+	t.Run("camelCase by configuration", func(t *testing.T) {
+		w := serveNaming(setupNamingRouter(NamingCamelCase), http.MethodGet, "/pr", "", "")
+
+		assert.Equal(t, camel, w.Body.String())
+	})
+
+	t.Run("snake_case requested when camelCase is configured", func(t *testing.T) {
+		w := serveNaming(setupNamingRouter(NamingCamelCase), http.MethodGet, "/pr",
+			"application/json;profile=snake_case", "")
+
+		assert.JSONEq(t, snake, w.Body.String())
+	})
+
+	t.Run("request body is converted to snake_case", func(t *testing.T) {
+		w := serveNaming(setupNamingRouter(NamingCamelCase), http.MethodPost, "/echo", "",
+			`{"pullRequestId":"pr-1","authorId":"u1","count":1.50}`)
+
+		// Echoed back, the body is converted to camelCase again; the handler saw snake_case
+		assert.Equal(t, `{"pullRequestId":"pr-1","authorId":"u1","count":1.50}`, w.Body.String())
+	})
+
+	t.Run("invalid request body is passed on", func(t *testing.T) {
+		w := serveNaming(setupNamingRouter(NamingCamelCase), http.MethodPost, "/echo", "", `{"pullRequestId":`)
+
+		assert.Equal(t, `{"pullRequestId":`, w.Body.String())
+	})
+
+	t.Run("non-JSON response is passed through", func(t *testing.T) {
+		w := serveNaming(setupNamingRouter(NamingCamelCase), http.MethodGet, "/text", "", "")
+
+		assert.Equal(t, "pull_request_id", w.Body.String())
+	})
+}
+
+func TestRenameRequestBody(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"userId":"u1","isActive":true}`))
+
+	renameRequestBody(req)
+
+	body, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"user_id":"u1","is_active":true}`, string(body))
+	assert.Equal(t, int64(len(body)), req.ContentLength)
+}
+
+func TestRenameKeys(t *testing.T) {
+	got, err := renameKeys([]byte(`{"b_key":[{"c_d":null},2e3,"x_y"],"a_key":{}}`), toCamelCase)
+
+	require.NoError(t, err)
+	// Field order, numbers and string values are kept
+	assert.Equal(t, `{"bKey":[{"cD":null},2e3,"x_y"],"aKey":{}}`, string(got))
+}
+
+func TestToCamelCase(t *testing.T) {
+	tests := map[string]string{
+		"pull_request_id": "pullRequestId",
+		"status":          "status",
+		"createdAt":       "createdAt",
+		"_internal":       "_internal",
+		"trailing_":       "trailing",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, toCamelCase(in), in)
+	}
+}
+
+func TestToSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"pullRequestId": "pull_request_id",
+		"status":        "status",
+		"user_id":       "user_id",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, toSnakeCase(in), in)
+	}
+}
+
+func TestParseNaming(t *testing.T) {
+	for in, want := range map[string]Naming{"": NamingSnakeCase, "snake_case": NamingSnakeCase, "camelCase": NamingCamelCase} {
+		got, err := ParseNaming(in)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	_, err := ParseNaming("kebab-case")
+	assert.Error(t, err)
+}
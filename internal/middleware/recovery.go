@@ -2,40 +2,66 @@
 package middleware
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
 	"net/http"
 	"runtime/debug"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
+
+	"github.com/festy23/avito_internship/pkg/apierror"
 )
 
+// PanicReporter reports recovered panics to an external error tracker.
+type PanicReporter interface {
+	// ReportPanic reports a panic; it must not block the response.
+	ReportPanic(errorID string, recovered any, stack []byte, r *http.Request)
+}
+
 // Recovery returns a middleware that recovers from panics and logs them.
 func Recovery(logger *zap.SugaredLogger) gin.HandlerFunc {
+	return RecoveryWithReporter(logger, nil)
+}
+
+// RecoveryWithReporter returns a middleware that recovers from panics, logs them and, if reporter
+// is not nil, reports them. Every panic gets an error ID that is logged, reported and returned in
+// the 500 response, so a bug report can be matched with the stack trace without exposing it.
+func RecoveryWithReporter(logger *zap.SugaredLogger, reporter PanicReporter) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		defer func() {
 			if err := recover(); err != nil {
+				errorID := newErrorID()
+				stack := debug.Stack()
+
 				// Log panic with stack trace
 				logger.Errorw("panic recovered",
+					"error_id", errorID,
 					"error", err,
 					"path", c.Request.URL.Path,
 					"method", c.Request.Method,
 					"client_ip", c.ClientIP(),
-					"stack", string(debug.Stack()),
+					"stack", string(stack),
 				)
+				if reporter != nil {
+					reporter.ReportPanic(errorID, err, stack, c.Request)
+				}
 
-				// Return 500 Internal Server Error
-				c.JSON(http.StatusInternalServerError, gin.H{
-					"error": gin.H{
-						"code":    "INTERNAL_ERROR",
-						"message": "internal server error",
-					},
-				})
-
-				// Abort request processing
-				c.Abort()
+				// Return 500 Internal Server Error and abort request processing
+				apiErr := apierror.Internal(fmt.Errorf("panic: %v", err))
+				apiErr.ErrorID = errorID
+				apierror.Abort(c, apiErr)
 			}
 		}()
 
 		c.Next()
 	}
 }
+
+// newErrorID returns a random 32-character hex ID, which is also a valid Sentry event ID.
+func newErrorID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
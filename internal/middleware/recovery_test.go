@@ -2,14 +2,20 @@
 package middleware
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/festy23/avito_internship/pkg/apierror"
 )
 
 func setupRecoveryRouter(logger *zap.SugaredLogger) *gin.Engine {
@@ -77,3 +83,64 @@ func TestRecovery_AbortsRequest(t *testing.T) {
 	// After panic, request should be aborted and not continue processing
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 }
+
+type panicReport struct {
+	errorID   string
+	recovered any
+	path      string
+}
+
+type recordingReporter struct {
+	reports []panicReport
+}
+
+func (r *recordingReporter) ReportPanic(errorID string, recovered any, _ []byte, req *http.Request) {
+	r.reports = append(r.reports, panicReport{errorID: errorID, recovered: recovered, path: req.URL.Path})
+}
+
+func TestRecovery_ErrorID(t *testing.T) {
+	core, logs := observer.New(zapcore.ErrorLevel)
+	reporter := &recordingReporter{}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RecoveryWithReporter(zap.New(core).Sugar(), reporter))
+	router.GET("/panic", func(c *gin.Context) {
+		panic("secret connection string")
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.NotContains(t, w.Body.String(), "secret")
+
+	var resp apierror.Response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, apierror.CodeInternal, resp.Error.Code)
+	assert.Len(t, resp.Error.ErrorID, 32)
+
+	// The ID in the response matches the log entry and the report.
+	entries := logs.FilterMessage("panic recovered").All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, resp.Error.ErrorID, entries[0].ContextMap()["error_id"])
+
+	require.Len(t, reporter.reports, 1)
+	assert.Equal(t, panicReport{errorID: resp.Error.ErrorID, recovered: "secret connection string", path: "/panic"},
+		reporter.reports[0])
+}
+
+func TestRecovery_UniqueErrorIDs(t *testing.T) {
+	router := setupRecoveryRouter(zap.NewNop().Sugar())
+
+	ids := make(map[string]bool)
+	for i := 0; i < 3; i++ {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+		var resp apierror.Response
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		ids[resp.Error.ErrorID] = true
+	}
+	assert.Len(t, ids, 3)
+}
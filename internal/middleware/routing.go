@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/festy23/avito_internship/pkg/apierror"
+)
+
+// NoRoute handles requests to unknown paths with 404 NOT_FOUND.
+func NoRoute(c *gin.Context) {
+	apierror.Abort(c, apierror.NotFound(fmt.Sprintf("route %s %s not found", c.Request.Method, c.Request.URL.Path)))
+}
+
+// NoMethod handles requests to known paths with a method the path is not routed for: OPTIONS requests
+// are answered with 204, others with 405 METHOD_NOT_ALLOWED. Both carry the Allow header listing the
+// methods of the path; Gin sets it before calling NoMethod (Engine.HandleMethodNotAllowed).
+func NoMethod(c *gin.Context) {
+	c.Header("Allow", withOptions(c.Writer.Header().Get("Allow")))
+	if c.Request.Method == http.MethodOptions {
+		c.AbortWithStatus(http.StatusNoContent)
+		return
+	}
+	apierror.Abort(c, apierror.MethodNotAllowed(fmt.Sprintf("method %s is not allowed", c.Request.Method)))
+}
+
+// withOptions adds OPTIONS to the Allow list unless a route of the path already handles it
+// (e.g. a CORS preflight handler), so the method is not listed twice.
+func withOptions(allow string) string {
+	if allow == "" {
+		return http.MethodOptions
+	}
+	for _, method := range strings.Split(allow, ",") {
+		if strings.TrimSpace(method) == http.MethodOptions {
+			return allow
+		}
+	}
+	return allow + ", " + http.MethodOptions
+}
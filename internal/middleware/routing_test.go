@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/festy23/avito_internship/pkg/apierror"
+)
+
+func setupRoutingRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.HandleMethodNotAllowed = true
+	r.NoRoute(NoRoute)
+	r.NoMethod(NoMethod)
+	r.GET("/team/get", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"team_name": "backend"})
+	})
+	r.POST("/pullRequest/merge", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "MERGED"})
+	})
+	r.PUT("/pullRequest/merge", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+	r.OPTIONS("/team/add", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+	r.POST("/team/add", func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+	return r
+}
+
+func errorCode(t *testing.T, body []byte) string {
+	var resp struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(body, &resp))
+	return resp.Error.Code
+}
+
+func TestNoMethod(t *testing.T) {
+	r := setupRoutingRouter()
+
+	t.Run("method not allowed", func(t *testing.T) {
+		w := serve(r, http.MethodDelete, "/pullRequest/merge")
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+		assert.ElementsMatch(t, []string{"POST", "PUT", "OPTIONS"}, splitAllow(w.Header().Get("Allow")))
+		assert.Equal(t, apierror.CodeMethodNotAllowed, errorCode(t, w.Body.Bytes()))
+	})
+
+	t.Run("path with an OPTIONS route", func(t *testing.T) {
+		w := serve(r, http.MethodDelete, "/team/add")
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+		assert.ElementsMatch(t, []string{"POST", "OPTIONS"}, splitAllow(w.Header().Get("Allow")))
+	})
+
+	t.Run("options", func(t *testing.T) {
+		w := serve(r, http.MethodOptions, "/team/get")
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, "GET, OPTIONS", w.Header().Get("Allow"))
+		assert.Empty(t, w.Body.String())
+	})
+
+	t.Run("routed method is served", func(t *testing.T) {
+		w := serve(r, http.MethodGet, "/team/get")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("Allow"))
+	})
+}
+
+func TestNoRoute(t *testing.T) {
+	r := setupRoutingRouter()
+
+	for _, method := range []string{http.MethodGet, http.MethodOptions} {
+		w := serve(r, method, "/team/unknown")
+
+		assert.Equal(t, http.StatusNotFound, w.Code, method)
+		assert.Equal(t, apierror.CodeNotFound, errorCode(t, w.Body.Bytes()), method)
+	}
+}
+
+func splitAllow(header string) []string {
+	var methods []string
+	for _, method := range strings.Split(header, ",") {
+		methods = append(methods, strings.TrimSpace(method))
+	}
+	return methods
+}
@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TimeoutRule sets the time budget of requests matching a route.
+type TimeoutRule struct {
+	// Method is the HTTP method to match; empty matches any method.
+	Method string
+	// Path is the route to match as registered (/users/:id rather than /users/u1); "*" matches any route.
+	Path string
+	// Timeout is the time budget of matching requests.
+	Timeout time.Duration
+}
+
+// Matches reports whether the rule applies to the request.
+func (r TimeoutRule) Matches(method, path string) bool {
+	if r.Method != "" && r.Method != method {
+		return false
+	}
+	return r.Path == "*" || r.Path == path
+}
+
+// ParseTimeoutRules parses rules separated by ";". A rule is a route ("*", "/path" or "METHOD /path")
+// followed by a duration; the first matching rule applies, so catch-all rules go last.
+//
+// Example: "/admin/export 30s; POST * 5s; GET * 2s".
+func ParseTimeoutRules(s string) ([]TimeoutRule, error) {
+	var rules []TimeoutRule
+	for _, part := range strings.Split(s, ";") {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			continue
+		}
+		rule, err := parseTimeoutRule(fields)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %w", strings.TrimSpace(part), err)
+		}
+		rules = append(rules, rule)
+	}
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("no rules")
+	}
+	return rules, nil
+}
+
+func parseTimeoutRule(fields []string) (TimeoutRule, error) {
+	var (
+		rule TimeoutRule
+		err  error
+	)
+	if rule.Method, rule.Path, fields, err = parseRoute(fields); err != nil {
+		return rule, err
+	}
+	if len(fields) != 1 {
+		return rule, fmt.Errorf("expected a single duration after the route")
+	}
+	if rule.Timeout, err = time.ParseDuration(fields[0]); err != nil {
+		return rule, fmt.Errorf("invalid duration: %w", err)
+	}
+	if rule.Timeout <= 0 {
+		return rule, fmt.Errorf("duration must be positive")
+	}
+	return rule, nil
+}
+
+// Timeouts returns a middleware setting the deadline of requests to the budget of the first rule
+// matching their route. Database queries and other calls honouring the request context fail once
+// the budget is spent, and the error is answered as a retryable 503 TIMEOUT. Handlers are not
+// interrupted otherwise, so the budget bounds the waiting on dependencies rather than the response
+// time. Requests matching no rule are not limited.
+func Timeouts(rules []TimeoutRule) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rule, ok := matchTimeoutRule(rules, c.Request.Method, c.FullPath())
+		if !ok {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), rule.Timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+func matchTimeoutRule(rules []TimeoutRule, method, path string) (TimeoutRule, bool) {
+	for _, rule := range rules {
+		if rule.Matches(method, path) {
+			return rule, true
+		}
+	}
+	return TimeoutRule{}, false
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/festy23/avito_internship/pkg/apierror"
+)
+
+func TestParseTimeoutRules(t *testing.T) {
+	t.Run("valid rules", func(t *testing.T) {
+		rules, err := ParseTimeoutRules("/admin/export 30s; post * 5s; GET * 2s")
+		require.NoError(t, err)
+		assert.Equal(t, []TimeoutRule{
+			{Path: "/admin/export", Timeout: 30 * time.Second},
+			{Method: "POST", Path: "*", Timeout: 5 * time.Second},
+			{Method: "GET", Path: "*", Timeout: 2 * time.Second},
+		}, rules)
+	})
+
+	tests := []struct {
+		name  string
+		rules string
+		err   string
+	}{
+		{"empty", " ; ", "no rules"},
+		{"invalid path", "GET export 1s", "path must be"},
+		{"missing duration", "GET /team/get", "single duration"},
+		{"extra fields", "* 1s 2s", "single duration"},
+		{"invalid duration", "* soon", "invalid duration"},
+		{"zero duration", "* 0s", "must be positive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseTimeoutRules(tt.rules)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestTimeouts(t *testing.T) {
+	rules, err := ParseTimeoutRules("GET /users/:id 20ms; GET * 1h")
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Timeouts(rules))
+	budget := func(c *gin.Context) {
+		deadline, ok := c.Request.Context().Deadline()
+		if !ok {
+			c.String(http.StatusOK, "none")
+			return
+		}
+		c.String(http.StatusOK, time.Until(deadline).Round(time.Hour).String())
+	}
+	r.GET("/users/:id", func(c *gin.Context) {
+		<-c.Request.Context().Done()
+		apierror.Fail(c, c.Request.Context().Err())
+	})
+	r.GET("/team/get", budget)
+	r.POST("/team/add", budget)
+
+	t.Run("exceeded budget is a timeout", func(t *testing.T) {
+		w := serve(r, http.MethodGet, "/users/u1")
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Contains(t, w.Body.String(), apierror.CodeTimeout)
+		assert.Equal(t, "1", w.Header().Get("Retry-After"))
+	})
+
+	t.Run("catch-all rule", func(t *testing.T) {
+		assert.Equal(t, "1h0m0s", serve(r, http.MethodGet, "/team/get").Body.String())
+	})
+
+	t.Run("no matching rule", func(t *testing.T) {
+		assert.Equal(t, "none", serve(r, http.MethodPost, "/team/add").Body.String())
+	})
+}
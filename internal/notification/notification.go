@@ -0,0 +1,123 @@
+// Package notification provides delivery of pull request lifecycle notifications.
+package notification
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+)
+
+// Event identifies a pull request lifecycle event.
+type Event string
+
+// Pull request lifecycle events.
+const (
+	// EventPullRequestCreated is sent when a pull request is created.
+	EventPullRequestCreated Event = "pull_request.created"
+	// EventPullRequestMerged is sent when a pull request is merged.
+	EventPullRequestMerged Event = "pull_request.merged"
+	// EventPullRequestClosed is sent when a pull request is closed without merging.
+	EventPullRequestClosed Event = "pull_request.closed"
+	// EventPullRequestReopened is sent when a closed pull request is opened again.
+	EventPullRequestReopened Event = "pull_request.reopened"
+	// EventReviewerAssigned is sent when a reviewer is assigned to a pull request manually.
+	EventReviewerAssigned Event = "pull_request.reviewer_assigned"
+	// EventReviewerUnassigned is sent when a reviewer is removed from a pull request without replacement.
+	EventReviewerUnassigned Event = "pull_request.reviewer_unassigned"
+	// EventAuthorChanged is sent when authorship of a pull request is transferred to another user.
+	EventAuthorChanged Event = "pull_request.author_changed"
+	// EventTeamTransferred is sent when reviewers of a pull request are re-assigned from another team.
+	EventTeamTransferred Event = "pull_request.team_transferred"
+	// EventReviewerReassigned is sent when a reviewer of a pull request is replaced.
+	EventReviewerReassigned Event = "pull_request.reviewer_reassigned"
+	// EventSLAViolated is sent to the team lead when a pull request exceeds a review SLA of the team.
+	EventSLAViolated Event = "pull_request.sla_violated"
+	// EventPullRequestAutoClosed is sent to the author and the team lead when a pull request is closed
+	// for inactivity.
+	EventPullRequestAutoClosed Event = "pull_request.auto_closed"
+)
+
+// Notification describes a single event addressed to a set of users.
+type Notification struct {
+	Event         Event
+	PullRequestID string
+	Recipients    []string
+	// Details holds event-specific attributes (e.g. old and new reviewer).
+	Details map[string]string
+}
+
+// Notifier delivers notifications to their recipients.
+type Notifier interface {
+	// Notify delivers the notification. Implementations must not block for long.
+	Notify(ctx context.Context, n Notification) error
+}
+
+type logNotifier struct {
+	logger *zap.SugaredLogger
+}
+
+// NewLogNotifier creates a notifier that writes notifications to the log.
+func NewLogNotifier(logger *zap.SugaredLogger) Notifier {
+	return &logNotifier{logger: logger}
+}
+
+// Notify writes the notification to the log.
+func (n *logNotifier) Notify(_ context.Context, notification Notification) error {
+	if len(notification.Recipients) == 0 {
+		return nil
+	}
+	n.logger.Infow("notification",
+		"event", notification.Event,
+		"pull_request_id", notification.PullRequestID,
+		"recipients", notification.Recipients,
+		"details", notification.Details,
+	)
+	return nil
+}
+
+type nopNotifier struct{}
+
+// NewNop creates a notifier that discards all notifications.
+func NewNop() Notifier {
+	return nopNotifier{}
+}
+
+// Notify discards the notification.
+func (nopNotifier) Notify(context.Context, Notification) error {
+	return nil
+}
+
+type multiNotifier []Notifier
+
+// NewMulti creates a notifier that delivers each notification to all given notifiers.
+func NewMulti(notifiers ...Notifier) Notifier {
+	return multiNotifier(notifiers)
+}
+
+// Notify delivers the notification to every notifier and joins their errors.
+func (m multiNotifier) Notify(ctx context.Context, n Notification) error {
+	errs := make([]error, 0)
+	for _, notifier := range m {
+		if err := notifier.Notify(ctx, n); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Recipients merges user ID lists into a deduplicated list, preserving order.
+func Recipients(lists ...[]string) []string {
+	seen := make(map[string]struct{})
+	result := make([]string, 0)
+	for _, list := range lists {
+		for _, id := range list {
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+			result = append(result, id)
+		}
+	}
+	return result
+}
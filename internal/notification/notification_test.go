@@ -0,0 +1,84 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogNotifier_Notify(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	notifier := NewLogNotifier(zap.New(core).Sugar())
+
+	t.Run("logs notification", func(t *testing.T) {
+		err := notifier.Notify(context.Background(), Notification{
+			Event:         EventPullRequestMerged,
+			PullRequestID: "pr-1",
+			Recipients:    []string{"u2", "u3"},
+		})
+
+		assert.NoError(t, err)
+		entries := logs.TakeAll()
+		assert.Len(t, entries, 1)
+		assert.Equal(t, "notification", entries[0].Message)
+		assert.Equal(t, "pr-1", entries[0].ContextMap()["pull_request_id"])
+	})
+
+	t.Run("skips notification without recipients", func(t *testing.T) {
+		err := notifier.Notify(context.Background(), Notification{
+			Event:         EventPullRequestCreated,
+			PullRequestID: "pr-1",
+		})
+
+		assert.NoError(t, err)
+		assert.Empty(t, logs.TakeAll())
+	})
+}
+
+func TestNop_Notify(t *testing.T) {
+	assert.NoError(t, NewNop().Notify(context.Background(), Notification{Recipients: []string{"u1"}}))
+}
+
+func TestRecipients(t *testing.T) {
+	assert.Equal(t, []string{"u1", "u2", "u3"}, Recipients([]string{"u1", "u2"}, []string{"u2", "u3"}))
+	assert.Empty(t, Recipients())
+	assert.NotNil(t, Recipients(nil))
+}
+
+type recordingNotifier struct {
+	received []Notification
+	err      error
+}
+
+func (r *recordingNotifier) Notify(_ context.Context, n Notification) error {
+	r.received = append(r.received, n)
+	return r.err
+}
+
+func TestMultiNotifier_Notify(t *testing.T) {
+	n := Notification{Event: EventPullRequestCreated, PullRequestID: "pr-1", Recipients: []string{"u2"}}
+
+	t.Run("delivers to all notifiers", func(t *testing.T) {
+		first, second := &recordingNotifier{}, &recordingNotifier{}
+
+		err := NewMulti(first, second).Notify(context.Background(), n)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Notification{n}, first.received)
+		assert.Equal(t, []Notification{n}, second.received)
+	})
+
+	t.Run("continues after failure and returns error", func(t *testing.T) {
+		failErr := errors.New("delivery failed")
+		failing, ok := &recordingNotifier{err: failErr}, &recordingNotifier{}
+
+		err := NewMulti(failing, ok).Notify(context.Background(), n)
+
+		assert.ErrorIs(t, err, failErr)
+		assert.Len(t, ok.received, 1)
+	})
+}
@@ -0,0 +1,371 @@
+// Package otlp pushes metrics to an OpenTelemetry collector through the OTLP/HTTP JSON protocol.
+//
+// Metrics are read from a Prometheus gatherer, so the instruments served on /metrics are exported
+// as they are: counters as monotonic cumulative sums, gauges as gauges, histograms as explicit
+// bucket histograms and summaries as summaries.
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.uber.org/zap"
+
+	"github.com/festy23/avito_internship/pkg/httpclient"
+)
+
+// metricsPath is the OTLP/HTTP path of metrics export, appended to the collector endpoint.
+const metricsPath = "/v1/metrics"
+
+// ParseHeaders parses a comma-separated list of "<name>=<value>" request headers, e.g.
+// "Authorization=Bearer token,X-Scope-OrgID=reviews".
+func ParseHeaders(s string) (map[string]string, error) {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("entry must be <name>=<value>")
+		}
+		headers[name] = value
+	}
+	return headers, nil
+}
+
+// Exporter periodically pushes gathered metrics to a collector.
+type Exporter struct {
+	url         string
+	headers     map[string]string
+	interval    time.Duration
+	serviceName string
+	gatherer    prometheus.Gatherer
+	client      *httpclient.Client
+	start       time.Time
+	logger      *zap.SugaredLogger
+	wg          sync.WaitGroup
+}
+
+// New creates an exporter pushing metrics of gatherer to the collector at endpoint (e.g.
+// http://collector:4318) every interval. Headers are added to every request, e.g. for
+// authentication; serviceName is reported as the service.name resource attribute.
+func New(
+	endpoint string,
+	headers map[string]string,
+	interval, timeout time.Duration,
+	serviceName string,
+	gatherer prometheus.Gatherer,
+	logger *zap.SugaredLogger,
+) *Exporter {
+	return &Exporter{
+		url:         endpoint + metricsPath,
+		headers:     headers,
+		interval:    interval,
+		serviceName: serviceName,
+		gatherer:    gatherer,
+		client:      httpclient.New(httpclient.Options{Name: "otlp", Timeout: timeout, Retry: httpclient.DefaultRetry()}),
+		start:       time.Now(),
+		logger:      logger,
+	}
+}
+
+// Start pushes metrics every interval until ctx is canceled; the last push happens on cancellation,
+// so the final values of a stopping replica are not lost.
+func (e *Exporter) Start(ctx context.Context) {
+	e.logger.Infow("OTLP metrics export started", "url", e.url, "interval", e.interval)
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		ticker := time.NewTicker(e.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				e.push(context.WithoutCancel(ctx))
+				return
+			case <-ticker.C:
+				e.push(ctx)
+			}
+		}
+	}()
+}
+
+// Wait blocks until the export loop has stopped.
+func (e *Exporter) Wait() {
+	e.wg.Wait()
+}
+
+// push exports the current metrics; failures are logged, the next push sends cumulative values anyway.
+func (e *Exporter) push(ctx context.Context) {
+	if err := e.Push(ctx); err != nil {
+		e.logger.Warnw("failed to push metrics", "url", e.url, "error", err)
+	}
+}
+
+// Push gathers metrics and sends them to the collector once.
+func (e *Exporter) Push(ctx context.Context) error {
+	families, err := e.gatherer.Gather()
+	if err != nil {
+		return fmt.Errorf("gather metrics: %w", err)
+	}
+	body, err := json.Marshal(e.request(families, time.Now()))
+	if err != nil {
+		return fmt.Errorf("encode metrics: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range e.headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// The types below are the subset of the OTLP JSON encoding used by the exporter. 64-bit integers
+// are strings, as the protobuf JSON mapping requires.
+
+type exportRequest struct {
+	ResourceMetrics []resourceMetrics `json:"resourceMetrics"`
+}
+
+type resourceMetrics struct {
+	Resource     resource       `json:"resource"`
+	ScopeMetrics []scopeMetrics `json:"scopeMetrics"`
+}
+
+type resource struct {
+	Attributes []attribute `json:"attributes"`
+}
+
+type scopeMetrics struct {
+	Scope   scope    `json:"scope"`
+	Metrics []metric `json:"metrics"`
+}
+
+type scope struct {
+	Name string `json:"name"`
+}
+
+type metric struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Sum         *sum       `json:"sum,omitempty"`
+	Gauge       *gauge     `json:"gauge,omitempty"`
+	Histogram   *histogram `json:"histogram,omitempty"`
+	Summary     *summary   `json:"summary,omitempty"`
+}
+
+// aggregationCumulative is AGGREGATION_TEMPORALITY_CUMULATIVE: Prometheus values are totals since start.
+const aggregationCumulative = 2
+
+type sum struct {
+	DataPoints             []numberPoint `json:"dataPoints"`
+	AggregationTemporality int           `json:"aggregationTemporality"`
+	IsMonotonic            bool          `json:"isMonotonic"`
+}
+
+type gauge struct {
+	DataPoints []numberPoint `json:"dataPoints"`
+}
+
+type histogram struct {
+	DataPoints             []histogramPoint `json:"dataPoints"`
+	AggregationTemporality int              `json:"aggregationTemporality"`
+}
+
+type summary struct {
+	DataPoints []summaryPoint `json:"dataPoints"`
+}
+
+type numberPoint struct {
+	Attributes        []attribute `json:"attributes,omitempty"`
+	StartTimeUnixNano string      `json:"startTimeUnixNano,omitempty"`
+	TimeUnixNano      string      `json:"timeUnixNano"`
+	AsDouble          float64     `json:"asDouble"`
+}
+
+type histogramPoint struct {
+	Attributes        []attribute `json:"attributes,omitempty"`
+	StartTimeUnixNano string      `json:"startTimeUnixNano"`
+	TimeUnixNano      string      `json:"timeUnixNano"`
+	Count             string      `json:"count"`
+	Sum               float64     `json:"sum"`
+	BucketCounts      []string    `json:"bucketCounts"`
+	ExplicitBounds    []float64   `json:"explicitBounds"`
+}
+
+type summaryPoint struct {
+	Attributes        []attribute     `json:"attributes,omitempty"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	TimeUnixNano      string          `json:"timeUnixNano"`
+	Count             string          `json:"count"`
+	Sum               float64         `json:"sum"`
+	QuantileValues    []quantileValue `json:"quantileValues"`
+}
+
+type quantileValue struct {
+	Quantile float64 `json:"quantile"`
+	Value    float64 `json:"value"`
+}
+
+type attribute struct {
+	Key   string         `json:"key"`
+	Value attributeValue `json:"value"`
+}
+
+type attributeValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+// request converts gathered metric families into an export request observed at now.
+func (e *Exporter) request(families []*dto.MetricFamily, now time.Time) exportRequest {
+	start, at := unixNano(e.start), unixNano(now)
+	metrics := make([]metric, 0, len(families))
+	for _, family := range families {
+		m := metric{Name: family.GetName(), Description: family.GetHelp()}
+		switch family.GetType() {
+		case dto.MetricType_COUNTER:
+			m.Sum = &sum{AggregationTemporality: aggregationCumulative, IsMonotonic: true}
+			for _, sample := range family.GetMetric() {
+				if !finite(sample.GetCounter().GetValue()) {
+					continue
+				}
+				m.Sum.DataPoints = append(m.Sum.DataPoints, numberPoint{
+					Attributes:        attributes(sample.GetLabel()),
+					StartTimeUnixNano: start,
+					TimeUnixNano:      at,
+					AsDouble:          sample.GetCounter().GetValue(),
+				})
+			}
+		case dto.MetricType_GAUGE, dto.MetricType_UNTYPED:
+			m.Gauge = &gauge{}
+			for _, sample := range family.GetMetric() {
+				value := sample.GetGauge().GetValue()
+				if family.GetType() == dto.MetricType_UNTYPED {
+					value = sample.GetUntyped().GetValue()
+				}
+				if !finite(value) {
+					continue
+				}
+				m.Gauge.DataPoints = append(m.Gauge.DataPoints, numberPoint{
+					Attributes:   attributes(sample.GetLabel()),
+					TimeUnixNano: at,
+					AsDouble:     value,
+				})
+			}
+		case dto.MetricType_HISTOGRAM, dto.MetricType_GAUGE_HISTOGRAM:
+			m.Histogram = &histogram{AggregationTemporality: aggregationCumulative}
+			for _, sample := range family.GetMetric() {
+				m.Histogram.DataPoints = append(m.Histogram.DataPoints,
+					histogramDataPoint(sample, start, at))
+			}
+		case dto.MetricType_SUMMARY:
+			m.Summary = &summary{}
+			for _, sample := range family.GetMetric() {
+				s := sample.GetSummary()
+				point := summaryPoint{
+					Attributes:        attributes(sample.GetLabel()),
+					StartTimeUnixNano: start,
+					TimeUnixNano:      at,
+					Count:             strconv.FormatUint(s.GetSampleCount(), 10),
+					Sum:               s.GetSampleSum(),
+				}
+				for _, q := range s.GetQuantile() {
+					if !finite(q.GetValue()) {
+						continue
+					}
+					point.QuantileValues = append(point.QuantileValues,
+						quantileValue{Quantile: q.GetQuantile(), Value: q.GetValue()})
+				}
+				m.Summary.DataPoints = append(m.Summary.DataPoints, point)
+			}
+		default:
+			continue
+		}
+		metrics = append(metrics, m)
+	}
+
+	return exportRequest{ResourceMetrics: []resourceMetrics{{
+		Resource: resource{Attributes: []attribute{
+			{Key: "service.name", Value: attributeValue{StringValue: e.serviceName}},
+		}},
+		ScopeMetrics: []scopeMetrics{{Scope: scope{Name: "prometheus"}, Metrics: metrics}},
+	}}}
+}
+
+// histogramDataPoint converts a Prometheus histogram. Prometheus buckets are cumulative, OTLP bucket
+// counts are per bucket with an implicit +Inf bucket after the last bound.
+func histogramDataPoint(sample *dto.Metric, start, at string) histogramPoint {
+	h := sample.GetHistogram()
+	point := histogramPoint{
+		Attributes:        attributes(sample.GetLabel()),
+		StartTimeUnixNano: start,
+		TimeUnixNano:      at,
+		Count:             strconv.FormatUint(h.GetSampleCount(), 10),
+		Sum:               h.GetSampleSum(),
+		BucketCounts:      []string{},
+		ExplicitBounds:    []float64{},
+	}
+	var previous uint64
+	for _, bucket := range h.GetBucket() {
+		if math.IsInf(bucket.GetUpperBound(), 1) {
+			continue
+		}
+		point.ExplicitBounds = append(point.ExplicitBounds, bucket.GetUpperBound())
+		point.BucketCounts = append(point.BucketCounts,
+			strconv.FormatUint(bucket.GetCumulativeCount()-previous, 10))
+		previous = bucket.GetCumulativeCount()
+	}
+	point.BucketCounts = append(point.BucketCounts, strconv.FormatUint(h.GetSampleCount()-previous, 10))
+	return point
+}
+
+func attributes(labels []*dto.LabelPair) []attribute {
+	if len(labels) == 0 {
+		return nil
+	}
+	attrs := make([]attribute, len(labels))
+	for i, label := range labels {
+		attrs[i] = attribute{Key: label.GetName(), Value: attributeValue{StringValue: label.GetValue()}}
+	}
+	return attrs
+}
+
+// finite reports whether v can be encoded: JSON has no representation of NaN and infinities.
+func finite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+func unixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
@@ -0,0 +1,129 @@
+package otlp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// collector records export requests.
+type collector struct {
+	requests chan exportRequest
+	headers  chan http.Header
+}
+
+func newCollector(t *testing.T, status int) (*collector, *httptest.Server) {
+	t.Helper()
+	c := &collector{requests: make(chan exportRequest, 10), headers: make(chan http.Header, 10)}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/metrics", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		var req exportRequest
+		assert.NoError(t, json.Unmarshal(body, &req))
+		c.requests <- req
+		c.headers <- r.Header
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return c, srv
+}
+
+func newRegistry(t *testing.T) *prometheus.Registry {
+	t.Helper()
+	reg := prometheus.NewRegistry()
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "errors_total", Help: "Errors."}, []string{"code"})
+	counter.WithLabelValues("NOT_FOUND").Add(3)
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "queue_size"})
+	gauge.Set(7)
+	hist := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "duration_seconds", Buckets: []float64{0.1, 1}})
+	for _, v := range []float64{0.05, 0.5, 0.7, 5} {
+		hist.Observe(v)
+	}
+	reg.MustRegister(counter, gauge, hist)
+	return reg
+}
+
+func findMetric(t *testing.T, req exportRequest, name string) metric {
+	t.Helper()
+	require.Len(t, req.ResourceMetrics, 1)
+	require.Len(t, req.ResourceMetrics[0].ScopeMetrics, 1)
+	for _, m := range req.ResourceMetrics[0].ScopeMetrics[0].Metrics {
+		if m.Name == name {
+			return m
+		}
+	}
+	t.Fatalf("metric %s not exported", name)
+	return metric{}
+}
+
+func TestExporter_Push(t *testing.T) {
+	c, srv := newCollector(t, http.StatusOK)
+	e := New(srv.URL, map[string]string{"Authorization": "Bearer token"}, time.Minute, time.Second,
+		"reviews", newRegistry(t), zap.NewNop().Sugar())
+
+	require.NoError(t, e.Push(context.Background()))
+
+	req := <-c.requests
+	assert.Equal(t, "Bearer token", (<-c.headers).Get("Authorization"))
+	assert.Equal(t, []attribute{{Key: "service.name", Value: attributeValue{StringValue: "reviews"}}},
+		req.ResourceMetrics[0].Resource.Attributes)
+
+	counter := findMetric(t, req, "errors_total")
+	require.NotNil(t, counter.Sum)
+	assert.True(t, counter.Sum.IsMonotonic)
+	assert.Equal(t, aggregationCumulative, counter.Sum.AggregationTemporality)
+	require.Len(t, counter.Sum.DataPoints, 1)
+	assert.InDelta(t, 3, counter.Sum.DataPoints[0].AsDouble, 0)
+	assert.Equal(t, []attribute{{Key: "code", Value: attributeValue{StringValue: "NOT_FOUND"}}},
+		counter.Sum.DataPoints[0].Attributes)
+
+	gauge := findMetric(t, req, "queue_size")
+	require.NotNil(t, gauge.Gauge)
+	assert.InDelta(t, 7, gauge.Gauge.DataPoints[0].AsDouble, 0)
+
+	hist := findMetric(t, req, "duration_seconds")
+	require.NotNil(t, hist.Histogram)
+	point := hist.Histogram.DataPoints[0]
+	assert.Equal(t, "4", point.Count)
+	assert.Equal(t, []float64{0.1, 1}, point.ExplicitBounds)
+	assert.Equal(t, []string{"1", "2", "1"}, point.BucketCounts, "per-bucket counts with +Inf bucket")
+}
+
+func TestExporter_PushFailure(t *testing.T) {
+	_, srv := newCollector(t, http.StatusServiceUnavailable)
+	e := New(srv.URL, nil, time.Minute, time.Second, "reviews", newRegistry(t), zap.NewNop().Sugar())
+
+	assert.ErrorContains(t, e.Push(context.Background()), "503")
+}
+
+func TestExporter_PushesOnStop(t *testing.T) {
+	c, srv := newCollector(t, http.StatusOK)
+	e := New(srv.URL, nil, time.Hour, time.Second, "reviews", newRegistry(t), zap.NewNop().Sugar())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	e.Start(ctx)
+	cancel()
+	e.Wait()
+
+	assert.Len(t, c.requests, 1, "final values are pushed on stop")
+}
+
+func TestParseHeaders(t *testing.T) {
+	headers, err := ParseHeaders("Authorization=Bearer dG9rZW4=, X-Scope-OrgID = reviews,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Authorization": "Bearer dG9rZW4=", "X-Scope-OrgID": "reviews"}, headers)
+
+	_, err = ParseHeaders("Authorization")
+	assert.Error(t, err)
+}
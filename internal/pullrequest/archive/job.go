@@ -0,0 +1,69 @@
+// Package archive provides the background job that archives old merged pull requests.
+package archive
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/festy23/avito_internship/internal/config"
+	"github.com/festy23/avito_internship/internal/pullrequest/repository"
+)
+
+// Job marks merged pull requests older than the retention period as archived.
+type Job struct {
+	repo         repository.Repository
+	retention    time.Duration
+	afterArchive func(ctx context.Context) error
+	logger       *zap.SugaredLogger
+	now          func() time.Time
+}
+
+// New creates a new archival job instance.
+func New(repo repository.Repository, cfg config.ArchiveConfig, logger *zap.SugaredLogger) *Job {
+	return NewWithAfterArchive(repo, cfg, nil, logger)
+}
+
+// NewWithAfterArchive creates a new archival job instance that calls afterArchive
+// once a pass has archived at least one pull request (e.g. to refresh table statistics).
+func NewWithAfterArchive(
+	repo repository.Repository,
+	cfg config.ArchiveConfig,
+	afterArchive func(ctx context.Context) error,
+	logger *zap.SugaredLogger,
+) *Job {
+	return &Job{
+		repo:         repo,
+		retention:    cfg.Retention(),
+		afterArchive: afterArchive,
+		logger:       logger,
+		now:          time.Now,
+	}
+}
+
+// Run executes a single archival pass; it is registered in the background job scheduler.
+func (j *Job) Run(ctx context.Context) error {
+	_, err := j.RunOnce(ctx)
+	return err
+}
+
+// RunOnce archives merged PRs older than the retention period and returns their count.
+func (j *Job) RunOnce(ctx context.Context) (int64, error) {
+	cutoff := j.now().Add(-j.retention)
+
+	archived, err := j.repo.ArchiveMergedBefore(ctx, cutoff)
+	if err != nil {
+		return 0, err
+	}
+
+	if archived > 0 {
+		j.logger.Infow("archived merged pull requests", "count", archived, "cutoff", cutoff)
+		if j.afterArchive != nil {
+			if hookErr := j.afterArchive(ctx); hookErr != nil {
+				j.logger.Warnw("post-archival hook failed", "error", hookErr)
+			}
+		}
+	}
+	return archived, nil
+}
@@ -0,0 +1,111 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/festy23/avito_internship/internal/config"
+	"github.com/festy23/avito_internship/internal/pullrequest/repository"
+)
+
+// mockRepository implements only the repository methods used by the job.
+type mockRepository struct {
+	repository.Repository
+	mock.Mock
+}
+
+func (m *mockRepository) ArchiveMergedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	args := m.Called(ctx, cutoff)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestJob_RunOnce(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	cfg := config.ArchiveConfig{RetentionDays: 30}
+
+	t.Run("archives PRs merged before retention cutoff", func(t *testing.T) {
+		repo := new(mockRepository)
+		job := New(repo, cfg, zap.NewNop().Sugar())
+		job.now = func() time.Time { return now }
+
+		repo.On("ArchiveMergedBefore", ctx, now.Add(-30*24*time.Hour)).Return(int64(3), nil)
+
+		archived, err := job.RunOnce(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), archived)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := new(mockRepository)
+		job := New(repo, cfg, zap.NewNop().Sugar())
+		job.now = func() time.Time { return now }
+
+		dbErr := errors.New("database error")
+		repo.On("ArchiveMergedBefore", ctx, mock.Anything).Return(int64(0), dbErr)
+
+		archived, err := job.RunOnce(ctx)
+
+		assert.ErrorIs(t, err, dbErr)
+		assert.Equal(t, int64(0), archived)
+	})
+}
+
+func TestJob_Run(t *testing.T) {
+	ctx := context.Background()
+	repo := new(mockRepository)
+	job := New(repo, config.ArchiveConfig{RetentionDays: 1}, zap.NewNop().Sugar())
+
+	dbErr := errors.New("database error")
+	repo.On("ArchiveMergedBefore", ctx, mock.Anything).Return(int64(0), dbErr).Once()
+
+	assert.ErrorIs(t, job.Run(ctx), dbErr)
+	repo.AssertExpectations(t)
+}
+
+func TestJob_AfterArchive(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.ArchiveConfig{RetentionDays: 30}
+
+	t.Run("called after archiving", func(t *testing.T) {
+		repo := new(mockRepository)
+		calls := 0
+		job := NewWithAfterArchive(repo, cfg, func(context.Context) error {
+			calls++
+			return errors.New("analyze failed")
+		}, zap.NewNop().Sugar())
+
+		repo.On("ArchiveMergedBefore", ctx, mock.Anything).Return(int64(2), nil)
+
+		archived, err := job.RunOnce(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), archived)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("not called when nothing archived", func(t *testing.T) {
+		repo := new(mockRepository)
+		calls := 0
+		job := NewWithAfterArchive(repo, cfg, func(context.Context) error {
+			calls++
+			return nil
+		}, zap.NewNop().Sugar())
+
+		repo.On("ArchiveMergedBefore", ctx, mock.Anything).Return(int64(0), nil)
+
+		_, err := job.RunOnce(ctx)
+
+		require.NoError(t, err)
+		assert.Zero(t, calls)
+	})
+}
@@ -0,0 +1,70 @@
+// Package assignment provides the worker pool that assigns reviewers to pull requests asynchronously.
+package assignment
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// AssignFunc assigns reviewers to a pull request waiting in ASSIGNING status.
+type AssignFunc func(ctx context.Context, prID string) error
+
+// Worker processes queued pull request IDs with a fixed number of goroutines.
+// The queue is bounded; Enqueue never blocks.
+type Worker struct {
+	queue   chan string
+	workers int
+	logger  *zap.SugaredLogger
+	wg      sync.WaitGroup
+}
+
+// New creates a new worker pool with the given number of workers and queue capacity.
+func New(workers, queueSize int, logger *zap.SugaredLogger) *Worker {
+	return &Worker{
+		queue:   make(chan string, queueSize),
+		workers: workers,
+		logger:  logger,
+	}
+}
+
+// Enqueue schedules reviewer assignment for a pull request.
+// Returns false if the queue is full.
+func (w *Worker) Enqueue(prID string) bool {
+	select {
+	case w.queue <- prID:
+		return true
+	default:
+		w.logger.Warnw("assignment queue is full", "pull_request_id", prID)
+		return false
+	}
+}
+
+// Start launches the workers. They stop when ctx is canceled; PRs left in the queue stay in
+// ASSIGNING status and are picked up again on the next start.
+func (w *Worker) Start(ctx context.Context, assign AssignFunc) {
+	w.logger.Infow("assignment workers started", "workers", w.workers, "queue_size", cap(w.queue))
+
+	for i := 0; i < w.workers; i++ {
+		w.wg.Add(1)
+		go func() {
+			defer w.wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case prID := <-w.queue:
+					if err := assign(ctx, prID); err != nil {
+						w.logger.Errorw("async reviewer assignment failed", "pull_request_id", prID, "error", err)
+					}
+				}
+			}
+		}()
+	}
+}
+
+// Wait blocks until all workers have stopped.
+func (w *Worker) Wait() {
+	w.wg.Wait()
+}
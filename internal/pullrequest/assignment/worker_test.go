@@ -0,0 +1,57 @@
+package assignment
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestWorker_Enqueue(t *testing.T) {
+	w := New(1, 2, zap.NewNop().Sugar())
+
+	assert.True(t, w.Enqueue("pr-1"))
+	assert.True(t, w.Enqueue("pr-2"))
+	assert.False(t, w.Enqueue("pr-3"))
+}
+
+func TestWorker_Start(t *testing.T) {
+	w := New(2, 10, zap.NewNop().Sugar())
+
+	var (
+		mu        sync.Mutex
+		processed []string
+	)
+	assign := func(_ context.Context, prID string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		processed = append(processed, prID)
+		if prID == "pr-2" {
+			return errors.New("assignment failed")
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w.Start(ctx, assign)
+
+	for _, id := range []string{"pr-1", "pr-2", "pr-3"} {
+		require.True(t, w.Enqueue(id))
+	}
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(processed) == 3
+	}, time.Second, 5*time.Millisecond)
+
+	cancel()
+	w.Wait()
+
+	assert.ElementsMatch(t, []string{"pr-1", "pr-2", "pr-3"}, processed)
+}
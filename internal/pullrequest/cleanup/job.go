@@ -0,0 +1,70 @@
+// Package cleanup provides the background job that enforces retention of the pull request activity log.
+package cleanup
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/festy23/avito_internship/internal/config"
+	"github.com/festy23/avito_internship/internal/pullrequest/repository"
+)
+
+// Job deletes activity events older than the retention period in small batches.
+type Job struct {
+	repo       repository.Repository
+	retention  time.Duration
+	batchSize  int
+	batchPause time.Duration
+	logger     *zap.SugaredLogger
+	now        func() time.Time
+}
+
+// New creates a new cleanup job instance.
+func New(repo repository.Repository, cfg config.CleanupConfig, logger *zap.SugaredLogger) *Job {
+	return &Job{
+		repo:       repo,
+		retention:  cfg.EventRetention(),
+		batchSize:  cfg.BatchSize,
+		batchPause: cfg.BatchPause,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// Run executes a single cleanup pass; it is registered in the background job scheduler.
+func (j *Job) Run(ctx context.Context) error {
+	_, err := j.RunOnce(ctx)
+	return err
+}
+
+// RunOnce deletes expired activity events batch by batch and returns their total count.
+// Stops early when ctx is canceled; already deleted batches stay deleted.
+func (j *Job) RunOnce(ctx context.Context) (int64, error) {
+	cutoff := j.now().Add(-j.retention)
+
+	var total int64
+	for {
+		deleted, err := j.repo.DeleteEventsBefore(ctx, cutoff, j.batchSize)
+		if err != nil {
+			return total, err
+		}
+		total += deleted
+
+		if deleted < int64(j.batchSize) {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return total, ctx.Err()
+		case <-time.After(j.batchPause):
+		}
+	}
+
+	if total > 0 {
+		j.logger.Infow("deleted expired activity events", "count", total, "cutoff", cutoff)
+	}
+	return total, nil
+}
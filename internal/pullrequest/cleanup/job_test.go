@@ -0,0 +1,82 @@
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/festy23/avito_internship/internal/config"
+	"github.com/festy23/avito_internship/internal/pullrequest/repository"
+)
+
+// mockRepository implements only the repository methods used by the job.
+type mockRepository struct {
+	repository.Repository
+	mock.Mock
+}
+
+func (m *mockRepository) DeleteEventsBefore(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	args := m.Called(ctx, cutoff, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestJob_RunOnce(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	cfg := config.CleanupConfig{EventRetentionDays: 30, BatchSize: 2}
+	cutoff := now.Add(-30 * 24 * time.Hour)
+
+	t.Run("deletes in batches until a partial batch", func(t *testing.T) {
+		ctx := context.Background()
+		repo := new(mockRepository)
+		job := New(repo, cfg, zap.NewNop().Sugar())
+		job.now = func() time.Time { return now }
+
+		repo.On("DeleteEventsBefore", ctx, cutoff, 2).Return(int64(2), nil).Twice()
+		repo.On("DeleteEventsBefore", ctx, cutoff, 2).Return(int64(1), nil).Once()
+
+		deleted, err := job.RunOnce(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), deleted)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		ctx := context.Background()
+		repo := new(mockRepository)
+		job := New(repo, cfg, zap.NewNop().Sugar())
+		job.now = func() time.Time { return now }
+
+		dbErr := errors.New("database error")
+		repo.On("DeleteEventsBefore", ctx, cutoff, 2).Return(int64(2), nil).Once()
+		repo.On("DeleteEventsBefore", ctx, cutoff, 2).Return(int64(0), dbErr).Once()
+
+		deleted, err := job.RunOnce(ctx)
+
+		assert.ErrorIs(t, err, dbErr)
+		assert.Equal(t, int64(2), deleted)
+	})
+
+	t.Run("stops between batches on cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		repo := new(mockRepository)
+		job := New(repo, config.CleanupConfig{EventRetentionDays: 30, BatchSize: 2, BatchPause: time.Hour},
+			zap.NewNop().Sugar())
+
+		repo.On("DeleteEventsBefore", ctx, mock.Anything, 2).
+			Run(func(mock.Arguments) { cancel() }).
+			Return(int64(2), nil).Once()
+
+		deleted, err := job.RunOnce(ctx)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, int64(2), deleted)
+		repo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/festy23/avito_internship/internal/database/dberror"
+	pullrequestModel "github.com/festy23/avito_internship/internal/pullrequest/model"
+	teamModel "github.com/festy23/avito_internship/internal/team/model"
+	"github.com/festy23/avito_internship/internal/tenant"
+	"github.com/festy23/avito_internship/pkg/apierror"
+	"github.com/festy23/avito_internship/pkg/cursor"
+	"github.com/festy23/avito_internship/pkg/pagination"
+	"github.com/festy23/avito_internship/pkg/sortparam"
+)
+
+// errorRegistry maps errors shared by all pull request endpoints.
+var errorRegistry = apierror.Registry{}.
+	Register(pullrequestModel.ErrPullRequestNotFound, apierror.NotFound("pull request not found")).
+	Register(pullrequestModel.ErrAuthorNotFound, apierror.NotFound("user not found")).
+	Register(pullrequestModel.ErrInvalidPullRequestID, apierror.InvalidRequest("")).
+	Register(sortparam.ErrInvalidField, apierror.InvalidField("sort", "enum", "")).
+	Register(sortparam.ErrInvalidOrder, apierror.InvalidField("order", "enum", "")).
+	RegisterFunc(dberror.IsTransient, apierror.ConcurrentUpdate())
+
+var createErrors = errorRegistry.
+	Register(pullrequestModel.ErrPullRequestExists, apierror.Conflict(apierror.CodePRExists, "PR id already exists")).
+	Register(tenant.ErrIDUnavailable, apierror.Conflict(apierror.CodeIDUnavailable, "")).
+	Register(pullrequestModel.ErrDuplicatePullRequest, apierror.Conflict(apierror.CodePRDuplicate, "")).
+	Register(pullrequestModel.ErrAuthorNotFound, apierror.NotFound("author not found")).
+	Register(pullrequestModel.ErrInvalidPullRequestURL,
+		apierror.InvalidField("pull_request_url", "url", pullrequestModel.ErrInvalidPullRequestURL.Error())).
+	RegisterFunc(mentions("pull_request_name", "required"), apierror.InvalidRequest(""))
+
+var mergeErrors = errorRegistry.
+	Register(pullrequestModel.ErrAssignmentPending,
+		apierror.Conflict(apierror.CodeAssignmentPending, "reviewer assignment is in progress")).
+	Register(pullrequestModel.ErrInvalidPullRequestID, apierror.InvalidRequest("pull_request_id is required")).
+	Register(pullrequestModel.ErrPullRequestHasConflicts,
+		apierror.Conflict(apierror.CodePRHasConflicts, "cannot merge PR with conflicts")).
+	Register(pullrequestModel.ErrNotTeamLead, apierror.Forbidden("")).
+	Register(pullrequestModel.ErrOverrideReasonRequired,
+		apierror.InvalidField("reason", "required", pullrequestModel.ErrOverrideReasonRequired.Error())).
+	Register(pullrequestModel.ErrOverrideNotNeeded, apierror.InvalidRequest("")).
+	Register(pullrequestModel.ErrPullRequestClosed,
+		apierror.Conflict(apierror.CodePRClosed, "cannot merge closed PR")).
+	RegisterFunc(mentions("merged_by"), apierror.InvalidRequest(""))
+
+var closeErrors = errorRegistry.
+	Register(pullrequestModel.ErrPullRequestMerged,
+		apierror.Conflict(apierror.CodePRMerged, "cannot close merged PR")).
+	Register(pullrequestModel.ErrInvalidCloseReason, apierror.InvalidField("close_reason", "enum", "")).
+	Register(pullrequestModel.ErrAssignmentPending,
+		apierror.Conflict(apierror.CodeAssignmentPending, "reviewer assignment is in progress")).
+	Register(pullrequestModel.ErrInvalidPullRequestID, apierror.InvalidRequest("pull_request_id is required"))
+
+var reopenErrors = errorRegistry.
+	Register(pullrequestModel.ErrPullRequestMerged,
+		apierror.Conflict(apierror.CodePRMerged, "cannot reopen merged PR")).
+	Register(pullrequestModel.ErrInvalidPullRequestID, apierror.InvalidRequest("pull_request_id is required"))
+
+var reassignErrors = errorRegistry.
+	Register(pullrequestModel.ErrPullRequestMerged,
+		apierror.Conflict(apierror.CodePRMerged, "cannot reassign on merged PR")).
+	Register(pullrequestModel.ErrPullRequestClosed,
+		apierror.Conflict(apierror.CodePRClosed, "cannot reassign on closed PR")).
+	Register(pullrequestModel.ErrReviewerNotAssigned,
+		apierror.Conflict(apierror.CodeNotAssigned, "reviewer is not assigned to this PR")).
+	Register(pullrequestModel.ErrNoCandidate,
+		apierror.Conflict(apierror.CodeNoCandidate, "no active replacement candidate in team")).
+	RegisterFunc(mentions("old_user_id", "required"), apierror.InvalidRequest(""))
+
+var reassignAllErrors = errorRegistry.
+	RegisterFunc(mentions("old_user_id"), apierror.InvalidRequest(""))
+
+var assignErrors = errorRegistry.
+	Register(pullrequestModel.ErrPullRequestMerged,
+		apierror.Conflict(apierror.CodePRMerged, "cannot assign reviewers on merged PR")).
+	Register(pullrequestModel.ErrPullRequestClosed,
+		apierror.Conflict(apierror.CodePRClosed, "cannot assign reviewers on closed PR")).
+	Register(pullrequestModel.ErrAssignmentPending,
+		apierror.Conflict(apierror.CodeAssignmentPending, "reviewer assignment is in progress")).
+	Register(pullrequestModel.ErrNotTeamLead, apierror.Forbidden("")).
+	Register(pullrequestModel.ErrReviewerAlreadyAssigned,
+		apierror.Conflict(apierror.CodeAlreadyAssigned, "reviewer is already assigned to this PR")).
+	Register(pullrequestModel.ErrMaxReviewersExceeded,
+		apierror.Conflict(apierror.CodeTooManyReviewers, "PR already has the maximum number of reviewers")).
+	Register(pullrequestModel.ErrReviewerOverloaded,
+		apierror.Conflict(apierror.CodeReviewerOverloaded, "reviewer has reached the review load limit")).
+	Register(pullrequestModel.ErrAuthorCannotBeReviewer, apierror.InvalidRequest("")).
+	Register(pullrequestModel.ErrReviewerNotInTeam, apierror.InvalidRequest("")).
+	Register(pullrequestModel.ErrReviewerInactive, apierror.InvalidRequest("")).
+	RegisterFunc(mentions("user_id", "assigned_by"), apierror.InvalidRequest(""))
+
+var unassignErrors = errorRegistry.
+	Register(pullrequestModel.ErrPullRequestMerged,
+		apierror.Conflict(apierror.CodePRMerged, "cannot unassign reviewers on merged PR")).
+	Register(pullrequestModel.ErrPullRequestClosed,
+		apierror.Conflict(apierror.CodePRClosed, "cannot unassign reviewers on closed PR")).
+	Register(pullrequestModel.ErrAssignmentPending,
+		apierror.Conflict(apierror.CodeAssignmentPending, "reviewer assignment is in progress")).
+	Register(pullrequestModel.ErrReviewerNotAssigned,
+		apierror.Conflict(apierror.CodeNotAssigned, "reviewer is not assigned to this PR")).
+	Register(pullrequestModel.ErrTooFewReviewers,
+		apierror.Conflict(apierror.CodeTooFewReviewers, "PR must keep the minimum number of reviewers")).
+	RegisterFunc(mentions("user_id"), apierror.InvalidRequest(""))
+
+var changeAuthorErrors = errorRegistry.
+	Register(pullrequestModel.ErrPullRequestMerged,
+		apierror.Conflict(apierror.CodePRMerged, "cannot change author of merged PR")).
+	Register(pullrequestModel.ErrPullRequestClosed,
+		apierror.Conflict(apierror.CodePRClosed, "cannot change author of closed PR")).
+	Register(pullrequestModel.ErrAssignmentPending,
+		apierror.Conflict(apierror.CodeAssignmentPending, "reviewer assignment is in progress")).
+	Register(pullrequestModel.ErrInvalidAuthorID, apierror.InvalidRequest(""))
+
+var transferTeamErrors = errorRegistry.
+	Register(pullrequestModel.ErrPullRequestMerged,
+		apierror.Conflict(apierror.CodePRMerged, "cannot transfer merged PR")).
+	Register(pullrequestModel.ErrPullRequestClosed,
+		apierror.Conflict(apierror.CodePRClosed, "cannot transfer closed PR")).
+	Register(pullrequestModel.ErrAssignmentPending,
+		apierror.Conflict(apierror.CodeAssignmentPending, "reviewer assignment is in progress")).
+	Register(teamModel.ErrTeamNotFound, apierror.NotFound("team not found")).
+	RegisterFunc(mentions("team_name"), apierror.InvalidRequest(""))
+
+var watchErrors = errorRegistry.
+	RegisterFunc(mentions("user_id"), apierror.InvalidRequest(""))
+
+var listErrors = errorRegistry.
+	Register(pullrequestModel.ErrInvalidStatus, apierror.InvalidField("status", "enum", "")).
+	Register(pagination.ErrInvalidLimit, apierror.InvalidField("limit", "range", "")).
+	Register(cursor.ErrInvalid, apierror.InvalidField("cursor", "format", "invalid cursor or changed filters"))
+
+var setConflictsErrors = errorRegistry.
+	Register(pullrequestModel.ErrPullRequestMerged,
+		apierror.Conflict(apierror.CodePRMerged, "cannot update conflicts on merged PR")).
+	Register(pullrequestModel.ErrPullRequestClosed,
+		apierror.Conflict(apierror.CodePRClosed, "cannot update conflicts on closed PR"))
+
+var candidatesErrors = errorRegistry.
+	Register(pullrequestModel.ErrPullRequestMerged,
+		apierror.Conflict(apierror.CodePRMerged, "cannot assign reviewers on merged PR")).
+	Register(pullrequestModel.ErrPullRequestClosed,
+		apierror.Conflict(apierror.CodePRClosed, "cannot assign reviewers on closed PR"))
+
+var previewErrors = errorRegistry.
+	Register(pullrequestModel.ErrAuthorNotFound, apierror.NotFound("author not found")).
+	Register(pullrequestModel.ErrInvalidAuthorID, apierror.InvalidRequest(""))
+
+// mentions matches service validation errors (string length, required fields) that have no sentinel.
+func mentions(fields ...string) func(error) bool {
+	return func(err error) bool {
+		for _, field := range fields {
+			if strings.Contains(err.Error(), field) {
+				return true
+			}
+		}
+		return false
+	}
+}
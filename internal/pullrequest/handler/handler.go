@@ -2,26 +2,33 @@
 package handler
 
 import (
-	"errors"
+	"fmt"
 	"net/http"
-	"strings"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
-	"go.uber.org/zap"
 
 	pullrequestModel "github.com/festy23/avito_internship/internal/pullrequest/model"
 	"github.com/festy23/avito_internship/internal/pullrequest/service"
+	"github.com/festy23/avito_internship/pkg/apierror"
+	"github.com/festy23/avito_internship/pkg/bind"
+	"github.com/festy23/avito_internship/pkg/cursor"
 )
 
 // Handler handles HTTP requests for pullrequest endpoints.
 type Handler struct {
 	service service.Service
-	logger  *zap.SugaredLogger
+	cursors *cursor.Codec
 }
 
-// New creates a new pullrequest handler instance.
-func New(svc service.Service, logger *zap.SugaredLogger) *Handler {
-	return &Handler{service: svc, logger: logger}
+// New creates a new pullrequest handler instance. Its pagination cursors are valid only within this process.
+func New(svc service.Service) *Handler {
+	return NewWithCursors(svc, cursor.New(""))
+}
+
+// NewWithCursors creates a new pullrequest handler instance signing pagination cursors with cursors.
+func NewWithCursors(svc service.Service, cursors *cursor.Codec) *Handler {
+	return &Handler{service: svc, cursors: cursors}
 }
 
 // CreatePullRequest handles POST /pullRequest/create request.
@@ -33,38 +40,18 @@ func New(svc service.Service, logger *zap.SugaredLogger) *Handler {
 // @Success 201 {object} map[string]pullrequestModel.PullRequestResponse "Response wrapped in pr object"
 // @Failure 400 {object} ErrorResponse "Bad request (INVALID_REQUEST)"
 // @Failure 404 {object} ErrorResponse "Author/team not found"
-// @Failure 409 {object} ErrorResponse "PR already exists (PR_EXISTS)"
+// @Failure 409 {object} ErrorResponse "PR already exists (PR_EXISTS) or duplicate in strict mode (PR_DUPLICATE)"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /pullRequest/create [post] //nolint:godot // Swagger annotation should not end with period
 func (h *Handler) CreatePullRequest(c *gin.Context) {
 	var req pullrequestModel.CreatePullRequestRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errorResponse(c, "INVALID_REQUEST", "invalid request body", http.StatusBadRequest)
+	if !bind.JSON(c, &req) {
 		return
 	}
 
 	resp, err := h.service.CreatePullRequest(c.Request.Context(), &req)
 	if err != nil {
-		if errors.Is(err, pullrequestModel.ErrPullRequestExists) {
-			errorResponse(c, "PR_EXISTS", "PR id already exists", http.StatusConflict)
-			return
-		}
-		if errors.Is(err, pullrequestModel.ErrAuthorNotFound) {
-			notFoundResponse(c, "author not found")
-			return
-		}
-		if errors.Is(err, pullrequestModel.ErrInvalidPullRequestID) {
-			errorResponse(c, "INVALID_REQUEST", err.Error(), http.StatusBadRequest)
-			return
-		}
-		// Check for validation errors (string length, required fields)
-		if err.Error() != "" &&
-			(strings.Contains(err.Error(), "pull_request_name") || strings.Contains(err.Error(), "required")) {
-			errorResponse(c, "INVALID_REQUEST", err.Error(), http.StatusBadRequest)
-			return
-		}
-		h.logger.Errorw("error creating pull request", "error", err)
-		errorResponse(c, "INTERNAL_ERROR", "internal server error", http.StatusInternalServerError)
+		createErrors.Fail(c, err)
 		return
 	}
 
@@ -74,40 +61,89 @@ func (h *Handler) CreatePullRequest(c *gin.Context) {
 }
 
 // MergePullRequest handles POST /pullRequest/merge request.
+// With override=true the lead of the team owning the PR can merge a PR blocked by merge rules.
 // @Summary Mark a pull request as MERGED (idempotent operation)
 // @Tags PullRequests
 // @Accept json
 // @Produce json
 // @Param request body pullrequestModel.MergePullRequestRequest true "Request"
 // @Success 200 {object} map[string]pullrequestModel.PullRequestResponse "Response wrapped in pr object"
-// @Failure 400 {object} ErrorResponse "Bad request (INVALID_REQUEST)"
+// @Failure 400 {object} ErrorResponse "Bad request or override of a merge no rule blocks (INVALID_REQUEST)"
+// @Failure 403 {object} ErrorResponse "Override by someone other than the team lead (FORBIDDEN)"
 // @Failure 404 {object} ErrorResponse "PR not found"
+// @Failure 409 {object} ErrorResponse "PR has conflicts (PR_HAS_CONFLICTS), is closed (PR_CLOSED) or is being assigned (ASSIGNMENT_PENDING)"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /pullRequest/merge [post] //nolint:godot // Swagger annotation should not end with period
 func (h *Handler) MergePullRequest(c *gin.Context) {
 	var req pullrequestModel.MergePullRequestRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errorResponse(c, "INVALID_REQUEST", "invalid request body", http.StatusBadRequest)
+	if !bind.JSON(c, &req) {
 		return
 	}
 
 	resp, err := h.service.MergePullRequest(c.Request.Context(), &req)
 	if err != nil {
-		if errors.Is(err, pullrequestModel.ErrPullRequestNotFound) {
-			notFoundResponse(c, "pull request not found")
-			return
-		}
-		if errors.Is(err, pullrequestModel.ErrInvalidPullRequestID) {
-			errorResponse(
-				c,
-				"INVALID_REQUEST",
-				"pull_request_id is required",
-				http.StatusBadRequest,
-			)
-			return
-		}
-		h.logger.Errorw("error merging pull request", "error", err)
-		errorResponse(c, "INTERNAL_ERROR", "internal server error", http.StatusInternalServerError)
+		mergeErrors.Fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"pr": resp,
+	})
+}
+
+// ClosePullRequest handles POST /pullRequest/close request.
+// closed_by and reason are optional and recorded in the activity log.
+// @Summary Mark a pull request as CLOSED without merging (idempotent operation)
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Param request body pullrequestModel.ClosePullRequestRequest true "Request"
+// @Success 200 {object} map[string]pullrequestModel.PullRequestResponse "Response wrapped in pr object"
+// @Failure 400 {object} ErrorResponse "Bad request (INVALID_REQUEST)"
+// @Failure 404 {object} ErrorResponse "PR not found"
+// @Failure 409 {object} ErrorResponse "PR is merged (PR_MERGED) or is being assigned (ASSIGNMENT_PENDING)"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pullRequest/close [post] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) ClosePullRequest(c *gin.Context) {
+	var req pullrequestModel.ClosePullRequestRequest
+	if !bind.JSON(c, &req) {
+		return
+	}
+
+	resp, err := h.service.ClosePullRequest(c.Request.Context(), &req)
+	if err != nil {
+		closeErrors.Fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"pr": resp,
+	})
+}
+
+// ReopenPullRequest handles POST /pullRequest/reopen request.
+// Reviewers deactivated while the PR was closed are replaced; reopened_by and reason are optional and
+// recorded in the activity log.
+// @Summary Move a CLOSED pull request back to OPEN (idempotent operation)
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Param request body pullrequestModel.ReopenPullRequestRequest true "Request"
+// @Success 200 {object} map[string]pullrequestModel.PullRequestResponse "Response wrapped in pr object"
+// @Failure 400 {object} ErrorResponse "Bad request (INVALID_REQUEST)"
+// @Failure 404 {object} ErrorResponse "PR not found"
+// @Failure 409 {object} ErrorResponse "PR is merged (PR_MERGED)"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pullRequest/reopen [post] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) ReopenPullRequest(c *gin.Context) {
+	var req pullrequestModel.ReopenPullRequestRequest
+	if !bind.JSON(c, &req) {
+		return
+	}
+
+	resp, err := h.service.ReopenPullRequest(c.Request.Context(), &req)
+	if err != nil {
+		reopenErrors.Fail(c, err)
 		return
 	}
 
@@ -125,67 +161,442 @@ func (h *Handler) MergePullRequest(c *gin.Context) {
 // @Success 200 {object} pullrequestModel.ReassignReviewerResponse "Response with pr and replaced_by"
 // @Failure 400 {object} ErrorResponse "Bad request (INVALID_REQUEST)"
 // @Failure 404 {object} ErrorResponse "PR or user not found"
-// @Failure 409 {object} ErrorResponse "Domain rule violation (PR_MERGED, NOT_ASSIGNED, NO_CANDIDATE)"
+// @Failure 409 {object} ErrorResponse "Domain rule violation (PR_MERGED, PR_CLOSED, NOT_ASSIGNED, NO_CANDIDATE)"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /pullRequest/reassign [post] //nolint:godot // Swagger annotation should not end with period
 func (h *Handler) ReassignReviewer(c *gin.Context) {
 	var req pullrequestModel.ReassignReviewerRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errorResponse(c, "INVALID_REQUEST", "invalid request body", http.StatusBadRequest)
+	if !bind.JSON(c, &req) {
 		return
 	}
 
 	resp, err := h.service.ReassignReviewer(c.Request.Context(), &req)
 	if err != nil {
-		h.handleReassignError(c, err)
+		reassignErrors.Fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ReassignAll handles POST /pullRequest/reassignAll request.
+// Intended for departing reviewers: every open PR they review gets a replacement.
+// @Summary Replace a reviewer in all of their open pull requests
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Param request body pullrequestModel.ReassignAllRequest true "Request"
+// @Success 200 {object} pullrequestModel.ReassignAllResponse "Per-PR results, NO_CANDIDATE for PRs left unchanged"
+// @Failure 400 {object} ErrorResponse "Bad request (INVALID_REQUEST)"
+// @Failure 404 {object} ErrorResponse "User not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pullRequest/reassignAll [post] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) ReassignAll(c *gin.Context) {
+	var req pullrequestModel.ReassignAllRequest
+	if !bind.JSON(c, &req) {
+		return
+	}
+
+	resp, err := h.service.ReassignAll(c.Request.Context(), &req)
+	if err != nil {
+		reassignAllErrors.Fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// AssignReviewer handles POST /pullRequest/assign request.
+// Only the lead of the team owning the PR may assign a reviewer manually.
+// @Summary Assign a named team member as reviewer of a pull request
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Param request body pullrequestModel.AssignReviewerRequest true "Request"
+// @Success 200 {object} map[string]pullrequestModel.PullRequestResponse "Response wrapped in pr object"
+// @Failure 400 {object} ErrorResponse "Bad request (INVALID_REQUEST)"
+// @Failure 403 {object} ErrorResponse "Caller is not the lead of the team owning the PR (FORBIDDEN)"
+// @Failure 404 {object} ErrorResponse "PR or user not found"
+// @Failure 409 {object} ErrorResponse "Domain rule violation (PR_MERGED, PR_CLOSED, ALREADY_ASSIGNED, TOO_MANY_REVIEWERS, ...)"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pullRequest/assign [post] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) AssignReviewer(c *gin.Context) {
+	var req pullrequestModel.AssignReviewerRequest
+	if !bind.JSON(c, &req) {
+		return
+	}
+
+	resp, err := h.service.AssignReviewer(c.Request.Context(), &req)
+	if err != nil {
+		assignErrors.Fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"pr": resp,
+	})
+}
+
+// UnassignReviewer handles POST /pullRequest/unassign request.
+// Intended for reviews that are no longer needed: no replacement is assigned.
+// @Summary Remove a reviewer from a pull request without replacement
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Param request body pullrequestModel.UnassignReviewerRequest true "Request"
+// @Success 200 {object} map[string]pullrequestModel.PullRequestResponse "Response wrapped in pr object"
+// @Failure 400 {object} ErrorResponse "Bad request (INVALID_REQUEST)"
+// @Failure 404 {object} ErrorResponse "PR not found"
+// @Failure 409 {object} ErrorResponse "Domain rule violation (PR_MERGED, PR_CLOSED, NOT_ASSIGNED, TOO_FEW_REVIEWERS, ...)"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pullRequest/unassign [post] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) UnassignReviewer(c *gin.Context) {
+	var req pullrequestModel.UnassignReviewerRequest
+	if !bind.JSON(c, &req) {
+		return
+	}
+
+	resp, err := h.service.UnassignReviewer(c.Request.Context(), &req)
+	if err != nil {
+		unassignErrors.Fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"pr": resp,
+	})
+}
+
+// ChangeAuthor handles POST /pullRequest/changeAuthor request.
+// Intended for handing over open PRs, e.g. when the author leaves the team.
+// @Summary Transfer authorship of an open pull request
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Param request body pullrequestModel.ChangeAuthorRequest true "Request"
+// @Success 200 {object} map[string]pullrequestModel.PullRequestResponse "Response wrapped in pr object"
+// @Failure 400 {object} ErrorResponse "Bad request (INVALID_REQUEST)"
+// @Failure 404 {object} ErrorResponse "PR or user not found"
+// @Failure 409 {object} ErrorResponse "Domain rule violation (PR_MERGED, PR_CLOSED, ASSIGNMENT_PENDING)"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pullRequest/changeAuthor [post] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) ChangeAuthor(c *gin.Context) {
+	var req pullrequestModel.ChangeAuthorRequest
+	if !bind.JSON(c, &req) {
+		return
+	}
+
+	resp, err := h.service.ChangeAuthor(c.Request.Context(), &req)
+	if err != nil {
+		changeAuthorErrors.Fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"pr": resp,
+	})
+}
+
+// TransferTeam handles POST /pullRequest/transferTeam request.
+// The current reviewers are replaced by members of the destination team.
+// @Summary Re-assign reviewers of an open pull request from another team
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Param request body pullrequestModel.TransferTeamRequest true "Request"
+// @Success 200 {object} map[string]pullrequestModel.PullRequestResponse "Response wrapped in pr object"
+// @Failure 400 {object} ErrorResponse "Bad request (INVALID_REQUEST)"
+// @Failure 404 {object} ErrorResponse "PR or team not found"
+// @Failure 409 {object} ErrorResponse "Domain rule violation (PR_MERGED, PR_CLOSED, ASSIGNMENT_PENDING)"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pullRequest/transferTeam [post] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) TransferTeam(c *gin.Context) {
+	var req pullrequestModel.TransferTeamRequest
+	if !bind.JSON(c, &req) {
+		return
+	}
+
+	resp, err := h.service.TransferTeam(c.Request.Context(), &req)
+	if err != nil {
+		transferTeamErrors.Fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"pr": resp,
+	})
+}
+
+// WatchPullRequest handles POST /pullRequest/watch request.
+// @Summary Subscribe a user to lifecycle notifications of a pull request
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Param request body pullrequestModel.WatchPullRequestRequest true "Request"
+// @Success 200 {object} map[string]pullrequestModel.PullRequestResponse "Response wrapped in pr object"
+// @Failure 400 {object} ErrorResponse "Bad request (INVALID_REQUEST)"
+// @Failure 404 {object} ErrorResponse "PR or user not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pullRequest/watch [post] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) WatchPullRequest(c *gin.Context) {
+	var req pullrequestModel.WatchPullRequestRequest
+	if !bind.JSON(c, &req) {
+		return
+	}
+
+	resp, err := h.service.WatchPullRequest(c.Request.Context(), &req)
+	if err != nil {
+		watchErrors.Fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"pr": resp,
+	})
+}
+
+// SetConflicts handles POST /pullRequest/setConflicts request.
+// Intended for CI/VCS integrations reporting merge-conflict status.
+// @Summary Update merge-conflict flag of a pull request
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Param request body pullrequestModel.SetConflictsRequest true "Request"
+// @Success 200 {object} map[string]pullrequestModel.PullRequestResponse "Response wrapped in pr object"
+// @Failure 400 {object} ErrorResponse "Bad request (INVALID_REQUEST)"
+// @Failure 404 {object} ErrorResponse "PR not found"
+// @Failure 409 {object} ErrorResponse "PR already merged (PR_MERGED) or closed (PR_CLOSED)"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pullRequest/setConflicts [post] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) SetConflicts(c *gin.Context) {
+	var req pullrequestModel.SetConflictsRequest
+	if !bind.JSON(c, &req) {
+		return
+	}
+
+	resp, err := h.service.SetConflicts(c.Request.Context(), &req)
+	if err != nil {
+		setConflictsErrors.Fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"pr": resp,
+	})
+}
+
+// GetActivity handles GET /pullRequest/activity request.
+// @Summary Get ordered activity log of a pull request
+// @Tags PullRequests
+// @Produce json
+// @Param pull_request_id query string true "Pull request ID"
+// @Param sort query string false "Sort field" Enums(created_at)
+// @Param order query string false "Sort order, asc (chronological) by default" Enums(asc, desc)
+// @Success 200 {object} pullrequestModel.PullRequestActivityResponse
+// @Failure 400 {object} ErrorResponse "Bad request (INVALID_REQUEST)"
+// @Failure 404 {object} ErrorResponse "PR not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pullRequest/activity [get] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) GetActivity(c *gin.Context) {
+	prID := c.Query("pull_request_id")
+	if prID == "" {
+		apierror.Fail(c, apierror.InvalidField("pull_request_id", "required", "pull_request_id parameter is required"))
+		return
+	}
+
+	srt, err := pullrequestModel.EventSort.Parse(c.Query("sort"), c.Query("order"))
+	if err != nil {
+		errorRegistry.Fail(c, err)
+		return
+	}
+
+	resp, err := h.service.GetActivity(c.Request.Context(), prID, srt)
+	if err != nil {
+		errorRegistry.Fail(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, resp)
 }
 
-// handleReassignError handles errors from ReassignReviewer service method.
-func (h *Handler) handleReassignError(c *gin.Context, err error) {
-	if errors.Is(err, pullrequestModel.ErrPullRequestNotFound) {
-		notFoundResponse(c, "pull request not found")
+// ListPullRequests handles GET /pullRequest/list request.
+// Filters are combined; PRs are returned newest first in pages: next_cursor of a page is passed as cursor
+// to get the next one.
+// @Summary List pull requests
+// @Tags PullRequests
+// @Produce json
+// @Param status query string false "PR status" Enums(OPEN, MERGED, ASSIGNING, CLOSED)
+// @Param author_id query string false "Author ID"
+// @Param team_name query string false "Team owning the PR"
+// @Param reviewer_id query string false "Assigned reviewer ID"
+// @Param limit query int false "Page size (up to PAGINATION_MAX_LIMIT), PAGINATION_DEFAULT_LIMIT when omitted"
+// @Param cursor query string false "next_cursor of the previous page, with the same filters"
+// @Success 200 {object} pullrequestModel.ListPullRequestsResponse
+// @Failure 400 {object} ErrorResponse "Bad request (INVALID_REQUEST)"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pullRequest/list [get] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) ListPullRequests(c *gin.Context) {
+	query, err := h.parseListQuery(c)
+	if err != nil {
+		listErrors.Fail(c, err)
 		return
 	}
-	if errors.Is(err, pullrequestModel.ErrAuthorNotFound) {
-		notFoundResponse(c, "user not found")
+
+	resp, err := h.service.ListPullRequests(c.Request.Context(), query)
+	if err != nil {
+		listErrors.Fail(c, err)
+		return
+	}
+
+	if resp.Next != nil {
+		resp.NextCursor, err = h.cursors.Encode(listCursorScope(query), resp.Next)
+		if err != nil {
+			listErrors.Fail(c, err)
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// parseListQuery parses the query parameters of ListPullRequests.
+func (h *Handler) parseListQuery(c *gin.Context) (pullrequestModel.ListQuery, error) {
+	query := pullrequestModel.ListQuery{
+		Status:     c.Query("status"),
+		AuthorID:   c.Query("author_id"),
+		TeamName:   c.Query("team_name"),
+		ReviewerID: c.Query("reviewer_id"),
+	}
+
+	if raw := c.Query("limit"); raw != "" {
+		var err error
+		if query.Limit, err = strconv.Atoi(raw); err != nil {
+			return query, apierror.InvalidField("limit", "type", "limit must be an integer")
+		}
+	}
+
+	if token := c.Query("cursor"); token != "" {
+		var after pullrequestModel.ListPosition
+		if err := h.cursors.Decode(listCursorScope(query), token, &after); err != nil {
+			return query, err
+		}
+		query.After = &after
+	}
+	return query, nil
+}
+
+// listCursorScope binds ListPullRequests cursors to the filters of the listing.
+// The page size is not part of the scope, so clients may change it between pages.
+func listCursorScope(query pullrequestModel.ListQuery) string {
+	return fmt.Sprintf("listPullRequests %q %q %q %q", query.Status, query.AuthorID, query.TeamName, query.ReviewerID)
+}
+
+// GetAssignmentStatus handles GET /pullRequest/assignment request.
+// @Summary Get reviewer assignment state of a pull request
+// @Tags PullRequests
+// @Produce json
+// @Param pull_request_id query string true "Pull request ID"
+// @Success 200 {object} pullrequestModel.AssignmentStatusResponse
+// @Failure 400 {object} ErrorResponse "Bad request (INVALID_REQUEST)"
+// @Failure 404 {object} ErrorResponse "PR not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pullRequest/assignment [get] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) GetAssignmentStatus(c *gin.Context) {
+	prID := c.Query("pull_request_id")
+	if prID == "" {
+		apierror.Fail(c, apierror.InvalidField("pull_request_id", "required", "pull_request_id parameter is required"))
+		return
+	}
+
+	resp, err := h.service.GetAssignmentStatus(c.Request.Context(), prID)
+	if err != nil {
+		errorRegistry.Fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetCandidates handles GET /pullRequest/candidates request.
+// Intended for UIs offering manual reviewer selection.
+// @Summary List team members who can be assigned as reviewers of a pull request
+// @Tags PullRequests
+// @Produce json
+// @Param pull_request_id query string true "Pull request ID"
+// @Success 200 {object} pullrequestModel.PullRequestCandidatesResponse
+// @Failure 400 {object} ErrorResponse "Bad request (INVALID_REQUEST)"
+// @Failure 404 {object} ErrorResponse "PR not found"
+// @Failure 409 {object} ErrorResponse "PR already merged (PR_MERGED) or closed (PR_CLOSED)"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pullRequest/candidates [get] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) GetCandidates(c *gin.Context) {
+	prID := c.Query("pull_request_id")
+	if prID == "" {
+		apierror.Fail(c, apierror.InvalidField("pull_request_id", "required", "pull_request_id parameter is required"))
 		return
 	}
-	if errors.Is(err, pullrequestModel.ErrPullRequestMerged) {
-		errorResponse(c, "PR_MERGED", "cannot reassign on merged PR", http.StatusConflict)
+
+	resp, err := h.service.GetCandidates(c.Request.Context(), prID)
+	if err != nil {
+		candidatesErrors.Fail(c, err)
 		return
 	}
-	if errors.Is(err, pullrequestModel.ErrReviewerNotAssigned) {
-		errorResponse(
-			c,
-			"NOT_ASSIGNED",
-			"reviewer is not assigned to this PR",
-			http.StatusConflict,
-		)
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// SuggestReviewers handles GET /pullRequest/suggestReviewers request.
+// Intended to assist manual assignment: candidates familiar with the author's changes and the target
+// branch come first, heavily loaded candidates last.
+// @Summary Rank reviewer candidates of a pull request by review history and load
+// @Tags PullRequests
+// @Produce json
+// @Param pull_request_id query string true "Pull request ID"
+// @Success 200 {object} pullrequestModel.SuggestReviewersResponse
+// @Failure 400 {object} ErrorResponse "Bad request (INVALID_REQUEST)"
+// @Failure 404 {object} ErrorResponse "PR not found"
+// @Failure 409 {object} ErrorResponse "PR already merged (PR_MERGED) or closed (PR_CLOSED)"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pullRequest/suggestReviewers [get] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) SuggestReviewers(c *gin.Context) {
+	prID := c.Query("pull_request_id")
+	if prID == "" {
+		apierror.Fail(c, apierror.InvalidField("pull_request_id", "required", "pull_request_id parameter is required"))
 		return
 	}
-	if errors.Is(err, pullrequestModel.ErrNoCandidate) {
-		errorResponse(
-			c,
-			"NO_CANDIDATE",
-			"no active replacement candidate in team",
-			http.StatusConflict,
-		)
+
+	resp, err := h.service.SuggestReviewers(c.Request.Context(), prID)
+	if err != nil {
+		candidatesErrors.Fail(c, err)
 		return
 	}
-	if errors.Is(err, pullrequestModel.ErrInvalidPullRequestID) {
-		errorResponse(c, "INVALID_REQUEST", err.Error(), http.StatusBadRequest)
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// PreviewAssignment handles POST /pullRequest/previewAssignment request.
+// Intended for debugging team setups: nothing is written.
+// @Summary Preview reviewers a new pull request of the author would get
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Param request body pullrequestModel.PreviewAssignmentRequest true "Request"
+// @Success 200 {object} pullrequestModel.PreviewAssignmentResponse
+// @Failure 400 {object} ErrorResponse "Bad request (INVALID_REQUEST)"
+// @Failure 404 {object} ErrorResponse "Author not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pullRequest/previewAssignment [post] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) PreviewAssignment(c *gin.Context) {
+	var req pullrequestModel.PreviewAssignmentRequest
+	if !bind.JSON(c, &req) {
 		return
 	}
-	// Check for validation errors (string length, required fields)
-	if err.Error() != "" &&
-		(strings.Contains(err.Error(), "old_user_id") || strings.Contains(err.Error(), "required")) {
-		errorResponse(c, "INVALID_REQUEST", err.Error(), http.StatusBadRequest)
+
+	resp, err := h.service.PreviewAssignment(c.Request.Context(), &req)
+	if err != nil {
+		previewErrors.Fail(c, err)
 		return
 	}
-	h.logger.Errorw("error reassigning reviewer", "error", err)
-	errorResponse(c, "INTERNAL_ERROR", "internal server error", http.StatusInternalServerError)
+
+	c.JSON(http.StatusOK, resp)
 }
@@ -5,18 +5,24 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
-	"go.uber.org/zap"
+	"gorm.io/gorm"
 
 	pullrequestModel "github.com/festy23/avito_internship/internal/pullrequest/model"
 	"github.com/festy23/avito_internship/internal/pullrequest/service"
+	teamModel "github.com/festy23/avito_internship/internal/team/model"
+	"github.com/festy23/avito_internship/pkg/pagination"
+	"github.com/festy23/avito_internship/pkg/sortparam"
 )
 
 type mockService struct {
@@ -45,6 +51,28 @@ func (m *mockService) MergePullRequest(
 	return args.Get(0).(*pullrequestModel.PullRequestResponse), args.Error(1)
 }
 
+func (m *mockService) ClosePullRequest(
+	ctx context.Context,
+	req *pullrequestModel.ClosePullRequestRequest,
+) (*pullrequestModel.PullRequestResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pullrequestModel.PullRequestResponse), args.Error(1)
+}
+
+func (m *mockService) ReopenPullRequest(
+	ctx context.Context,
+	req *pullrequestModel.ReopenPullRequestRequest,
+) (*pullrequestModel.PullRequestResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pullrequestModel.PullRequestResponse), args.Error(1)
+}
+
 func (m *mockService) ReassignReviewer(
 	ctx context.Context,
 	req *pullrequestModel.ReassignReviewerRequest,
@@ -56,6 +84,171 @@ func (m *mockService) ReassignReviewer(
 	return args.Get(0).(*pullrequestModel.ReassignReviewerResponse), args.Error(1)
 }
 
+func (m *mockService) ReassignAll(
+	ctx context.Context,
+	req *pullrequestModel.ReassignAllRequest,
+) (*pullrequestModel.ReassignAllResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pullrequestModel.ReassignAllResponse), args.Error(1)
+}
+
+func (m *mockService) ReplaceDeactivatedReviewers(
+	ctx context.Context,
+	deactivate func(tx *gorm.DB) ([]string, error),
+) ([]string, error) {
+	args := m.Called(ctx, deactivate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *mockService) AssignReviewer(
+	ctx context.Context,
+	req *pullrequestModel.AssignReviewerRequest,
+) (*pullrequestModel.PullRequestResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pullrequestModel.PullRequestResponse), args.Error(1)
+}
+
+func (m *mockService) UnassignReviewer(
+	ctx context.Context,
+	req *pullrequestModel.UnassignReviewerRequest,
+) (*pullrequestModel.PullRequestResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pullrequestModel.PullRequestResponse), args.Error(1)
+}
+
+func (m *mockService) ChangeAuthor(
+	ctx context.Context,
+	req *pullrequestModel.ChangeAuthorRequest,
+) (*pullrequestModel.PullRequestResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pullrequestModel.PullRequestResponse), args.Error(1)
+}
+
+func (m *mockService) TransferTeam(
+	ctx context.Context,
+	req *pullrequestModel.TransferTeamRequest,
+) (*pullrequestModel.PullRequestResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pullrequestModel.PullRequestResponse), args.Error(1)
+}
+
+func (m *mockService) WatchPullRequest(
+	ctx context.Context,
+	req *pullrequestModel.WatchPullRequestRequest,
+) (*pullrequestModel.PullRequestResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pullrequestModel.PullRequestResponse), args.Error(1)
+}
+
+func (m *mockService) GetActivity(
+	ctx context.Context,
+	prID string,
+	srt sortparam.Sort,
+) (*pullrequestModel.PullRequestActivityResponse, error) {
+	args := m.Called(ctx, prID, srt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pullrequestModel.PullRequestActivityResponse), args.Error(1)
+}
+
+func (m *mockService) ListPullRequests(
+	ctx context.Context,
+	query pullrequestModel.ListQuery,
+) (*pullrequestModel.ListPullRequestsResponse, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pullrequestModel.ListPullRequestsResponse), args.Error(1)
+}
+
+func (m *mockService) SetConflicts(
+	ctx context.Context,
+	req *pullrequestModel.SetConflictsRequest,
+) (*pullrequestModel.PullRequestResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pullrequestModel.PullRequestResponse), args.Error(1)
+}
+
+func (m *mockService) AssignReviewers(ctx context.Context, prID string) error {
+	args := m.Called(ctx, prID)
+	return args.Error(0)
+}
+
+func (m *mockService) ResumeAssignments(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *mockService) GetAssignmentStatus(
+	ctx context.Context,
+	prID string,
+) (*pullrequestModel.AssignmentStatusResponse, error) {
+	args := m.Called(ctx, prID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pullrequestModel.AssignmentStatusResponse), args.Error(1)
+}
+
+func (m *mockService) GetCandidates(
+	ctx context.Context,
+	prID string,
+) (*pullrequestModel.PullRequestCandidatesResponse, error) {
+	args := m.Called(ctx, prID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pullrequestModel.PullRequestCandidatesResponse), args.Error(1)
+}
+
+func (m *mockService) SuggestReviewers(
+	ctx context.Context,
+	prID string,
+) (*pullrequestModel.SuggestReviewersResponse, error) {
+	args := m.Called(ctx, prID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pullrequestModel.SuggestReviewersResponse), args.Error(1)
+}
+
+func (m *mockService) PreviewAssignment(
+	ctx context.Context,
+	req *pullrequestModel.PreviewAssignmentRequest,
+) (*pullrequestModel.PreviewAssignmentResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pullrequestModel.PreviewAssignmentResponse), args.Error(1)
+}
+
 var _ service.Service = (*mockService)(nil)
 
 func setupRouter() *gin.Engine {
@@ -66,7 +259,7 @@ func setupRouter() *gin.Engine {
 func TestHandler_CreatePullRequest(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/pullRequest/create", handler.CreatePullRequest)
 
@@ -102,7 +295,7 @@ func TestHandler_CreatePullRequest(t *testing.T) {
 
 	t.Run("duplicate pull request", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/pullRequest/create", handler.CreatePullRequest)
 
@@ -131,7 +324,7 @@ func TestHandler_CreatePullRequest(t *testing.T) {
 
 	t.Run("author not found", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/pullRequest/create", handler.CreatePullRequest)
 
@@ -158,9 +351,121 @@ func TestHandler_CreatePullRequest(t *testing.T) {
 		mockSvc.AssertExpectations(t)
 	})
 
+	t.Run("with branches", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.POST("/pullRequest/create", handler.CreatePullRequest)
+
+		req := &pullrequestModel.CreatePullRequestRequest{
+			PullRequestID:   "pr-1",
+			PullRequestName: "Add feature",
+			AuthorID:        "u1",
+			SourceBranch:    "feature/login",
+			TargetBranch:    "main",
+		}
+		resp := &pullrequestModel.PullRequestResponse{
+			PullRequestID:     "pr-1",
+			PullRequestName:   "Add feature",
+			AuthorID:          "u1",
+			Status:            pullrequestModel.StatusOPEN,
+			AssignedReviewers: []string{},
+			SourceBranch:      "feature/login",
+			TargetBranch:      "main",
+		}
+
+		mockSvc.On("CreatePullRequest", mock.Anything, req).Return(resp, nil)
+
+		body, _ := json.Marshal(req)
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/pullRequest/create", bytes.NewBuffer(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var response map[string]pullrequestModel.PullRequestResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, "feature/login", response["pr"].SourceBranch)
+		assert.Equal(t, "main", response["pr"].TargetBranch)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("branch name too long", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.POST("/pullRequest/create", handler.CreatePullRequest)
+
+		body, _ := json.Marshal(pullrequestModel.CreatePullRequestRequest{
+			PullRequestID:   "pr-1",
+			PullRequestName: "Add feature",
+			AuthorID:        "u1",
+			TargetBranch:    strings.Repeat("b", 256),
+		})
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/pullRequest/create", bytes.NewBuffer(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var response ErrorResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, "INVALID_REQUEST", response.Error.Code)
+		require.Len(t, response.Error.Details, 1)
+		assert.Equal(t, "target_branch", response.Error.Details[0].Field)
+		assert.Equal(t, "max", response.Error.Details[0].Rule)
+		mockSvc.AssertNotCalled(t, "CreatePullRequest", mock.Anything, mock.Anything)
+	})
+
+	t.Run("negative line count", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.POST("/pullRequest/create", handler.CreatePullRequest)
+
+		body := []byte(`{"pull_request_id":"pr-1","pull_request_name":"Add feature","author_id":"u1","lines_added":-1}`)
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/pullRequest/create", bytes.NewBuffer(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var response ErrorResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+		require.Len(t, response.Error.Details, 1)
+		assert.Equal(t, "lines_added", response.Error.Details[0].Field)
+		assert.Equal(t, "min", response.Error.Details[0].Rule)
+		mockSvc.AssertNotCalled(t, "CreatePullRequest", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid pull request URL", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.POST("/pullRequest/create", handler.CreatePullRequest)
+
+		mockSvc.On("CreatePullRequest", mock.Anything, mock.Anything).
+			Return(nil, pullrequestModel.ErrInvalidPullRequestURL)
+
+		body := []byte(`{"pull_request_id":"pr-1","pull_request_name":"Add feature","author_id":"u1"}`)
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/pullRequest/create", bytes.NewBuffer(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var response ErrorResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, "INVALID_REQUEST", response.Error.Code)
+	})
+
 	t.Run("invalid request body", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/pullRequest/create", handler.CreatePullRequest)
 
@@ -181,7 +486,7 @@ func TestHandler_CreatePullRequest(t *testing.T) {
 func TestHandler_MergePullRequest(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/pullRequest/merge", handler.MergePullRequest)
 
@@ -216,7 +521,7 @@ func TestHandler_MergePullRequest(t *testing.T) {
 
 	t.Run("pull request not found", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/pullRequest/merge", handler.MergePullRequest)
 
@@ -243,7 +548,7 @@ func TestHandler_MergePullRequest(t *testing.T) {
 
 	t.Run("invalid request body", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/pullRequest/merge", handler.MergePullRequest)
 
@@ -264,7 +569,7 @@ func TestHandler_MergePullRequest(t *testing.T) {
 func TestHandler_ReassignReviewer(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/pullRequest/reassign", handler.ReassignReviewer)
 
@@ -303,7 +608,7 @@ func TestHandler_ReassignReviewer(t *testing.T) {
 
 	t.Run("pull request merged", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/pullRequest/reassign", handler.ReassignReviewer)
 
@@ -331,7 +636,7 @@ func TestHandler_ReassignReviewer(t *testing.T) {
 
 	t.Run("reviewer not assigned", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/pullRequest/reassign", handler.ReassignReviewer)
 
@@ -359,7 +664,7 @@ func TestHandler_ReassignReviewer(t *testing.T) {
 
 	t.Run("no candidate", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/pullRequest/reassign", handler.ReassignReviewer)
 
@@ -387,7 +692,7 @@ func TestHandler_ReassignReviewer(t *testing.T) {
 
 	t.Run("invalid request body", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/pullRequest/reassign", handler.ReassignReviewer)
 
@@ -406,7 +711,7 @@ func TestHandler_ReassignReviewer(t *testing.T) {
 
 	t.Run("invalid pull_request_id from service", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/pullRequest/create", handler.CreatePullRequest)
 
@@ -435,7 +740,7 @@ func TestHandler_ReassignReviewer(t *testing.T) {
 
 	t.Run("validation error - pull_request_name too long", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/pullRequest/create", handler.CreatePullRequest)
 
@@ -465,7 +770,7 @@ func TestHandler_ReassignReviewer(t *testing.T) {
 
 	t.Run("validation error - required field missing", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/pullRequest/create", handler.CreatePullRequest)
 
@@ -495,7 +800,7 @@ func TestHandler_ReassignReviewer(t *testing.T) {
 
 	t.Run("internal server error in create", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/pullRequest/create", handler.CreatePullRequest)
 
@@ -523,7 +828,7 @@ func TestHandler_ReassignReviewer(t *testing.T) {
 	})
 
 	t.Run("malformed JSON in create", func(t *testing.T) {
-		handler := New(new(mockService), zap.NewNop().Sugar())
+		handler := New(new(mockService))
 		router := setupRouter()
 		router.POST("/pullRequest/create", handler.CreatePullRequest)
 
@@ -537,7 +842,7 @@ func TestHandler_ReassignReviewer(t *testing.T) {
 	})
 
 	t.Run("empty request body in create", func(t *testing.T) {
-		handler := New(new(mockService), zap.NewNop().Sugar())
+		handler := New(new(mockService))
 		router := setupRouter()
 		router.POST("/pullRequest/create", handler.CreatePullRequest)
 
@@ -551,7 +856,7 @@ func TestHandler_ReassignReviewer(t *testing.T) {
 
 	t.Run("invalid pull_request_id from service in merge", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/pullRequest/merge", handler.MergePullRequest)
 
@@ -579,7 +884,7 @@ func TestHandler_ReassignReviewer(t *testing.T) {
 
 	t.Run("internal server error in merge", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/pullRequest/merge", handler.MergePullRequest)
 
@@ -606,7 +911,7 @@ func TestHandler_ReassignReviewer(t *testing.T) {
 
 	t.Run("idempotency - already merged", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/pullRequest/merge", handler.MergePullRequest)
 
@@ -640,7 +945,7 @@ func TestHandler_ReassignReviewer(t *testing.T) {
 
 	t.Run("invalid pull_request_id from service in reassign", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/pullRequest/reassign", handler.ReassignReviewer)
 
@@ -668,7 +973,7 @@ func TestHandler_ReassignReviewer(t *testing.T) {
 
 	t.Run("validation error - old_user_id required", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/pullRequest/reassign", handler.ReassignReviewer)
 
@@ -697,7 +1002,7 @@ func TestHandler_ReassignReviewer(t *testing.T) {
 
 	t.Run("validation error - old_user_id length", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/pullRequest/reassign", handler.ReassignReviewer)
 
@@ -725,7 +1030,7 @@ func TestHandler_ReassignReviewer(t *testing.T) {
 
 	t.Run("internal server error in reassign", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/pullRequest/reassign", handler.ReassignReviewer)
 
@@ -787,7 +1092,7 @@ func TestHandler_ReassignReviewer(t *testing.T) {
 		for _, tc := range testCases {
 			t.Run(tc.name, func(t *testing.T) {
 				mockSvc := new(mockService)
-				handler := New(mockSvc, zap.NewNop().Sugar())
+				handler := New(mockSvc)
 				router := setupRouter()
 				router.POST("/pullRequest/reassign", handler.ReassignReviewer)
 
@@ -816,7 +1121,7 @@ func TestHandler_ReassignReviewer(t *testing.T) {
 
 	t.Run("concurrent create requests", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/pullRequest/create", handler.CreatePullRequest)
 
@@ -854,3 +1159,1257 @@ func TestHandler_ReassignReviewer(t *testing.T) {
 		mockSvc.AssertExpectations(t)
 	})
 }
+
+func TestHandler_ReassignAll(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.POST("/pullRequest/reassignAll", handler.ReassignAll)
+
+		req := &pullrequestModel.ReassignAllRequest{OldUserID: "u2"}
+		resp := &pullrequestModel.ReassignAllResponse{
+			OldUserID: "u2",
+			Results: []pullrequestModel.ReassignAllResult{
+				{PullRequestID: "pr-1", ReplacedBy: "u3"},
+				{PullRequestID: "pr-2", Error: pullrequestModel.ReassignResultNoCandidate},
+			},
+		}
+		mockSvc.On("ReassignAll", mock.Anything, req).Return(resp, nil)
+
+		body, _ := json.Marshal(req)
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/pullRequest/reassignAll", bytes.NewBuffer(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"old_user_id":"u2","results":[
+			{"pull_request_id":"pr-1","replaced_by":"u3"},
+			{"pull_request_id":"pr-2","error":"NO_CANDIDATE"}
+		]}`, w.Body.String())
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.POST("/pullRequest/reassignAll", handler.ReassignAll)
+
+		mockSvc.On("ReassignAll", mock.Anything, mock.Anything).Return(nil, pullrequestModel.ErrAuthorNotFound)
+
+		body := []byte(`{"old_user_id":"ghost"}`)
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/pullRequest/reassignAll", bytes.NewBuffer(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("missing old_user_id", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.POST("/pullRequest/reassignAll", handler.ReassignAll)
+
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/pullRequest/reassignAll", bytes.NewBufferString(`{}`))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockSvc.AssertNotCalled(t, "ReassignAll")
+	})
+}
+
+func TestHandler_AssignReviewer(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.POST("/pullRequest/assign", handler.AssignReviewer)
+
+		req := &pullrequestModel.AssignReviewerRequest{PullRequestID: "pr-1", UserID: "u3", AssignedBy: "lead"}
+		resp := &pullrequestModel.PullRequestResponse{
+			PullRequestID:     "pr-1",
+			PullRequestName:   "Add feature",
+			AuthorID:          "u1",
+			Status:            pullrequestModel.StatusOPEN,
+			AssignedReviewers: []string{"u2", "u3"},
+		}
+		mockSvc.On("AssignReviewer", mock.Anything, req).Return(resp, nil)
+
+		body, _ := json.Marshal(req)
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/pullRequest/assign", bytes.NewBuffer(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response map[string]pullrequestModel.PullRequestResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u2", "u3"}, response["pr"].AssignedReviewers)
+		mockSvc.AssertExpectations(t)
+	})
+
+	errorCases := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"not team lead", pullrequestModel.ErrNotTeamLead, http.StatusForbidden, "FORBIDDEN"},
+		{"already assigned", pullrequestModel.ErrReviewerAlreadyAssigned, http.StatusConflict, "ALREADY_ASSIGNED"},
+		{"max reviewers", pullrequestModel.ErrMaxReviewersExceeded, http.StatusConflict, "TOO_MANY_REVIEWERS"},
+		{"overloaded", pullrequestModel.ErrReviewerOverloaded, http.StatusConflict, "REVIEWER_OVERLOADED"},
+		{"merged", pullrequestModel.ErrPullRequestMerged, http.StatusConflict, "PR_MERGED"},
+		{"not in team", pullrequestModel.ErrReviewerNotInTeam, http.StatusBadRequest, "INVALID_REQUEST"},
+		{"pull request not found", pullrequestModel.ErrPullRequestNotFound, http.StatusNotFound, "NOT_FOUND"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockSvc := new(mockService)
+			handler := New(mockSvc)
+			router := setupRouter()
+			router.POST("/pullRequest/assign", handler.AssignReviewer)
+
+			mockSvc.On("AssignReviewer", mock.Anything, mock.Anything).Return(nil, tc.err)
+
+			body := []byte(`{"pull_request_id":"pr-1","user_id":"u3","assigned_by":"lead"}`)
+			w := httptest.NewRecorder()
+			httpReq, _ := http.NewRequest("POST", "/pullRequest/assign", bytes.NewBuffer(body))
+			httpReq.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, httpReq)
+
+			assert.Equal(t, tc.wantStatus, w.Code)
+			var response ErrorResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantCode, response.Error.Code)
+		})
+	}
+
+	t.Run("missing assigned_by", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.POST("/pullRequest/assign", handler.AssignReviewer)
+
+		body := []byte(`{"pull_request_id":"pr-1","user_id":"u3"}`)
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/pullRequest/assign", bytes.NewBuffer(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockSvc.AssertNotCalled(t, "AssignReviewer")
+	})
+}
+
+func TestHandler_UnassignReviewer(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.POST("/pullRequest/unassign", handler.UnassignReviewer)
+
+		req := &pullrequestModel.UnassignReviewerRequest{PullRequestID: "pr-1", UserID: "u2"}
+		resp := &pullrequestModel.PullRequestResponse{
+			PullRequestID:     "pr-1",
+			PullRequestName:   "Add feature",
+			AuthorID:          "u1",
+			Status:            pullrequestModel.StatusOPEN,
+			AssignedReviewers: []string{"u3"},
+		}
+		mockSvc.On("UnassignReviewer", mock.Anything, req).Return(resp, nil)
+
+		body, _ := json.Marshal(req)
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/pullRequest/unassign", bytes.NewBuffer(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response map[string]pullrequestModel.PullRequestResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u3"}, response["pr"].AssignedReviewers)
+		mockSvc.AssertExpectations(t)
+	})
+
+	errorCases := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"not assigned", pullrequestModel.ErrReviewerNotAssigned, http.StatusConflict, "NOT_ASSIGNED"},
+		{"too few reviewers", pullrequestModel.ErrTooFewReviewers, http.StatusConflict, "TOO_FEW_REVIEWERS"},
+		{"merged", pullrequestModel.ErrPullRequestMerged, http.StatusConflict, "PR_MERGED"},
+		{"pull request not found", pullrequestModel.ErrPullRequestNotFound, http.StatusNotFound, "NOT_FOUND"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockSvc := new(mockService)
+			handler := New(mockSvc)
+			router := setupRouter()
+			router.POST("/pullRequest/unassign", handler.UnassignReviewer)
+
+			mockSvc.On("UnassignReviewer", mock.Anything, mock.Anything).Return(nil, tc.err)
+
+			body := []byte(`{"pull_request_id":"pr-1","user_id":"u2"}`)
+			w := httptest.NewRecorder()
+			httpReq, _ := http.NewRequest("POST", "/pullRequest/unassign", bytes.NewBuffer(body))
+			httpReq.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, httpReq)
+
+			assert.Equal(t, tc.wantStatus, w.Code)
+			var response ErrorResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantCode, response.Error.Code)
+		})
+	}
+
+	t.Run("missing user_id", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.POST("/pullRequest/unassign", handler.UnassignReviewer)
+
+		body := []byte(`{"pull_request_id":"pr-1"}`)
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/pullRequest/unassign", bytes.NewBuffer(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockSvc.AssertNotCalled(t, "UnassignReviewer")
+	})
+}
+
+func TestHandler_ChangeAuthor(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.POST("/pullRequest/changeAuthor", handler.ChangeAuthor)
+
+		req := &pullrequestModel.ChangeAuthorRequest{PullRequestID: "pr-1", AuthorID: "u2"}
+		resp := &pullrequestModel.PullRequestResponse{
+			PullRequestID:     "pr-1",
+			PullRequestName:   "Add feature",
+			AuthorID:          "u2",
+			Status:            pullrequestModel.StatusOPEN,
+			AssignedReviewers: []string{"u3"},
+		}
+		mockSvc.On("ChangeAuthor", mock.Anything, req).Return(resp, nil)
+
+		body, _ := json.Marshal(req)
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/pullRequest/changeAuthor", bytes.NewBuffer(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response map[string]pullrequestModel.PullRequestResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, "u2", response["pr"].AuthorID)
+		mockSvc.AssertExpectations(t)
+	})
+
+	errorCases := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"merged", pullrequestModel.ErrPullRequestMerged, http.StatusConflict, "PR_MERGED"},
+		{"assignment pending", pullrequestModel.ErrAssignmentPending, http.StatusConflict, "ASSIGNMENT_PENDING"},
+		{"user not found", pullrequestModel.ErrAuthorNotFound, http.StatusNotFound, "NOT_FOUND"},
+		{"pull request not found", pullrequestModel.ErrPullRequestNotFound, http.StatusNotFound, "NOT_FOUND"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockSvc := new(mockService)
+			handler := New(mockSvc)
+			router := setupRouter()
+			router.POST("/pullRequest/changeAuthor", handler.ChangeAuthor)
+
+			mockSvc.On("ChangeAuthor", mock.Anything, mock.Anything).Return(nil, tc.err)
+
+			body := []byte(`{"pull_request_id":"pr-1","author_id":"u2"}`)
+			w := httptest.NewRecorder()
+			httpReq, _ := http.NewRequest("POST", "/pullRequest/changeAuthor", bytes.NewBuffer(body))
+			httpReq.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, httpReq)
+
+			assert.Equal(t, tc.wantStatus, w.Code)
+			var response ErrorResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantCode, response.Error.Code)
+		})
+	}
+
+	t.Run("missing author_id", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.POST("/pullRequest/changeAuthor", handler.ChangeAuthor)
+
+		body := []byte(`{"pull_request_id":"pr-1"}`)
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/pullRequest/changeAuthor", bytes.NewBuffer(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockSvc.AssertNotCalled(t, "ChangeAuthor")
+	})
+}
+
+func TestHandler_TransferTeam(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.POST("/pullRequest/transferTeam", handler.TransferTeam)
+
+		req := &pullrequestModel.TransferTeamRequest{PullRequestID: "pr-1", TeamName: "frontend"}
+		resp := &pullrequestModel.PullRequestResponse{
+			PullRequestID:     "pr-1",
+			PullRequestName:   "Add feature",
+			AuthorID:          "u1",
+			Status:            pullrequestModel.StatusOPEN,
+			AssignedReviewers: []string{"f1", "f2"},
+		}
+		mockSvc.On("TransferTeam", mock.Anything, req).Return(resp, nil)
+
+		body, _ := json.Marshal(req)
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/pullRequest/transferTeam", bytes.NewBuffer(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response map[string]pullrequestModel.PullRequestResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"f1", "f2"}, response["pr"].AssignedReviewers)
+		mockSvc.AssertExpectations(t)
+	})
+
+	errorCases := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"team not found", teamModel.ErrTeamNotFound, http.StatusNotFound, "NOT_FOUND"},
+		{"merged", pullrequestModel.ErrPullRequestMerged, http.StatusConflict, "PR_MERGED"},
+		{"assignment pending", pullrequestModel.ErrAssignmentPending, http.StatusConflict, "ASSIGNMENT_PENDING"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockSvc := new(mockService)
+			handler := New(mockSvc)
+			router := setupRouter()
+			router.POST("/pullRequest/transferTeam", handler.TransferTeam)
+
+			mockSvc.On("TransferTeam", mock.Anything, mock.Anything).Return(nil, tc.err)
+
+			body := []byte(`{"pull_request_id":"pr-1","team_name":"frontend"}`)
+			w := httptest.NewRecorder()
+			httpReq, _ := http.NewRequest("POST", "/pullRequest/transferTeam", bytes.NewBuffer(body))
+			httpReq.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, httpReq)
+
+			assert.Equal(t, tc.wantStatus, w.Code)
+			var response ErrorResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantCode, response.Error.Code)
+		})
+	}
+}
+
+func TestHandler_WatchPullRequest(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.POST("/pullRequest/watch", handler.WatchPullRequest)
+
+		req := &pullrequestModel.WatchPullRequestRequest{PullRequestID: "pr-1", UserID: "u3"}
+		resp := &pullrequestModel.PullRequestResponse{
+			PullRequestID:     "pr-1",
+			PullRequestName:   "Add feature",
+			AuthorID:          "u1",
+			Status:            pullrequestModel.StatusOPEN,
+			AssignedReviewers: []string{"u2"},
+			Watchers:          []string{"u3"},
+		}
+		mockSvc.On("WatchPullRequest", mock.Anything, req).Return(resp, nil)
+
+		body, _ := json.Marshal(req)
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/pullRequest/watch", bytes.NewBuffer(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response map[string]pullrequestModel.PullRequestResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u3"}, response["pr"].Watchers)
+		mockSvc.AssertExpectations(t)
+	})
+
+	notFoundCases := []struct {
+		name string
+		err  error
+	}{
+		{"pull request not found", pullrequestModel.ErrPullRequestNotFound},
+		{"user not found", pullrequestModel.ErrAuthorNotFound},
+	}
+	for _, tc := range notFoundCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockSvc := new(mockService)
+			handler := New(mockSvc)
+			router := setupRouter()
+			router.POST("/pullRequest/watch", handler.WatchPullRequest)
+
+			mockSvc.On("WatchPullRequest", mock.Anything, mock.Anything).Return(nil, tc.err)
+
+			body := []byte(`{"pull_request_id":"pr-1","user_id":"u3"}`)
+			w := httptest.NewRecorder()
+			httpReq, _ := http.NewRequest("POST", "/pullRequest/watch", bytes.NewBuffer(body))
+			httpReq.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, httpReq)
+
+			assert.Equal(t, http.StatusNotFound, w.Code)
+			var response ErrorResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+			assert.Equal(t, "NOT_FOUND", response.Error.Code)
+		})
+	}
+
+	t.Run("invalid request body", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.POST("/pullRequest/watch", handler.WatchPullRequest)
+
+		body := []byte(`{"pull_request_id":"pr-1"}`)
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/pullRequest/watch", bytes.NewBuffer(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockSvc.AssertNotCalled(t, "WatchPullRequest")
+	})
+}
+
+func TestHandler_GetActivity(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.GET("/pullRequest/activity", handler.GetActivity)
+
+		resp := &pullrequestModel.PullRequestActivityResponse{
+			PullRequestID: "pr-1",
+			Events: []pullrequestModel.PullRequestEventResponse{
+				{Type: pullrequestModel.EventCreated, UserID: "u1", CreatedAt: "2025-01-01T00:00:00Z"},
+				{Type: pullrequestModel.EventMerged, CreatedAt: "2025-01-02T00:00:00Z"},
+			},
+		}
+		mockSvc.On("GetActivity", mock.Anything, "pr-1", sortparam.Sort{}).Return(resp, nil)
+
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("GET", "/pullRequest/activity?pull_request_id=pr-1", nil)
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response pullrequestModel.PullRequestActivityResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, *resp, response)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("reverse order", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.GET("/pullRequest/activity", handler.GetActivity)
+
+		resp := &pullrequestModel.PullRequestActivityResponse{PullRequestID: "pr-1"}
+		srt := sortparam.Sort{Field: "created_at", Order: sortparam.Desc}
+		mockSvc.On("GetActivity", mock.Anything, "pr-1", srt).Return(resp, nil)
+
+		w := httptest.NewRecorder()
+		url := "/pullRequest/activity?pull_request_id=pr-1&sort=created_at&order=desc"
+		httpReq, _ := http.NewRequest("GET", url, nil)
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("unsupported sort field", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.GET("/pullRequest/activity", handler.GetActivity)
+
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("GET", "/pullRequest/activity?pull_request_id=pr-1&sort=event_type", nil)
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var response ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "INVALID_REQUEST", response.Error.Code)
+		mockSvc.AssertNotCalled(t, "GetActivity")
+	})
+
+	t.Run("missing pull_request_id", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.GET("/pullRequest/activity", handler.GetActivity)
+
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("GET", "/pullRequest/activity", nil)
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockSvc.AssertNotCalled(t, "GetActivity")
+	})
+
+	t.Run("pull request not found", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.GET("/pullRequest/activity", handler.GetActivity)
+
+		mockSvc.On("GetActivity", mock.Anything, "nonexistent", sortparam.Sort{}).
+			Return(nil, pullrequestModel.ErrPullRequestNotFound)
+
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("GET", "/pullRequest/activity?pull_request_id=nonexistent", nil)
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		var response ErrorResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, "NOT_FOUND", response.Error.Code)
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.GET("/pullRequest/activity", handler.GetActivity)
+
+		mockSvc.On("GetActivity", mock.Anything, "pr-1", sortparam.Sort{}).Return(nil, errors.New("database error"))
+
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("GET", "/pullRequest/activity?pull_request_id=pr-1", nil)
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestHandler_SetConflicts(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.POST("/pullRequest/setConflicts", handler.SetConflicts)
+
+		resp := &pullrequestModel.PullRequestResponse{
+			PullRequestID:     "pr-1",
+			PullRequestName:   "Add feature",
+			AuthorID:          "u1",
+			Status:            pullrequestModel.StatusOPEN,
+			AssignedReviewers: []string{},
+			HasConflicts:      false,
+		}
+		mockSvc.On("SetConflicts", mock.Anything, mock.MatchedBy(func(req *pullrequestModel.SetConflictsRequest) bool {
+			return req.PullRequestID == "pr-1" && req.HasConflicts != nil && !*req.HasConflicts
+		})).Return(resp, nil)
+
+		body := []byte(`{"pull_request_id":"pr-1","has_conflicts":false}`)
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/pullRequest/setConflicts", bytes.NewBuffer(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"has_conflicts":false`)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("missing has_conflicts", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.POST("/pullRequest/setConflicts", handler.SetConflicts)
+
+		body := []byte(`{"pull_request_id":"pr-1"}`)
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/pullRequest/setConflicts", bytes.NewBuffer(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockSvc.AssertNotCalled(t, "SetConflicts")
+	})
+
+	errorCases := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{"pull request not found", pullrequestModel.ErrPullRequestNotFound, http.StatusNotFound, "NOT_FOUND"},
+		{"merged pull request", pullrequestModel.ErrPullRequestMerged, http.StatusConflict, "PR_MERGED"},
+		{"internal error", errors.New("database error"), http.StatusInternalServerError, "INTERNAL_ERROR"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockSvc := new(mockService)
+			handler := New(mockSvc)
+			router := setupRouter()
+			router.POST("/pullRequest/setConflicts", handler.SetConflicts)
+
+			mockSvc.On("SetConflicts", mock.Anything, mock.Anything).Return(nil, tc.err)
+
+			body := []byte(`{"pull_request_id":"pr-1","has_conflicts":true}`)
+			w := httptest.NewRecorder()
+			httpReq, _ := http.NewRequest("POST", "/pullRequest/setConflicts", bytes.NewBuffer(body))
+			httpReq.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, httpReq)
+
+			assert.Equal(t, tc.status, w.Code)
+			var response ErrorResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.code, response.Error.Code)
+		})
+	}
+}
+
+func TestHandler_ClosePullRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		setupMock  func(m *mockService)
+		wantStatus int
+		wantCode   string
+		wantField  string
+	}{
+		{
+			name: "success",
+			body: `{"pull_request_id":"pr-1","closed_by":"u1","reason":"superseded"}`,
+			setupMock: func(m *mockService) {
+				req := &pullrequestModel.ClosePullRequestRequest{PullRequestID: "pr-1", ClosedBy: "u1", Reason: "superseded"}
+				m.On("ClosePullRequest", mock.Anything, req).Return(&pullrequestModel.PullRequestResponse{
+					PullRequestID: "pr-1",
+					Status:        pullrequestModel.StatusCLOSED,
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing pull_request_id",
+			body:       `{}`,
+			setupMock:  func(*mockService) {},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "INVALID_REQUEST",
+		},
+		{
+			name: "invalid close_reason",
+			body: `{"pull_request_id":"pr-1","close_reason":"BORED"}`,
+			setupMock: func(m *mockService) {
+				m.On("ClosePullRequest", mock.Anything, mock.Anything).Return(nil, pullrequestModel.ErrInvalidCloseReason)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "INVALID_REQUEST",
+			wantField:  "close_reason",
+		},
+		{
+			name: "merged pull request",
+			body: `{"pull_request_id":"pr-1"}`,
+			setupMock: func(m *mockService) {
+				m.On("ClosePullRequest", mock.Anything, mock.Anything).Return(nil, pullrequestModel.ErrPullRequestMerged)
+			},
+			wantStatus: http.StatusConflict,
+			wantCode:   "PR_MERGED",
+		},
+		{
+			name: "pull request being assigned",
+			body: `{"pull_request_id":"pr-1"}`,
+			setupMock: func(m *mockService) {
+				m.On("ClosePullRequest", mock.Anything, mock.Anything).Return(nil, pullrequestModel.ErrAssignmentPending)
+			},
+			wantStatus: http.StatusConflict,
+			wantCode:   "ASSIGNMENT_PENDING",
+		},
+		{
+			name: "pull request not found",
+			body: `{"pull_request_id":"pr-1"}`,
+			setupMock: func(m *mockService) {
+				m.On("ClosePullRequest", mock.Anything, mock.Anything).Return(nil, pullrequestModel.ErrPullRequestNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantCode:   "NOT_FOUND",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mockService)
+			tt.setupMock(mockSvc)
+			router := setupRouter()
+			router.POST("/pullRequest/close", New(mockSvc).ClosePullRequest)
+
+			w := httptest.NewRecorder()
+			httpReq, _ := http.NewRequest("POST", "/pullRequest/close", bytes.NewBufferString(tt.body))
+			httpReq.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, httpReq)
+
+			assert.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+			if tt.wantCode != "" {
+				var response ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.wantCode, response.Error.Code)
+				if tt.wantField != "" {
+					require.Len(t, response.Error.Details, 1)
+					assert.Equal(t, tt.wantField, response.Error.Details[0].Field)
+				}
+			} else {
+				var response map[string]pullrequestModel.PullRequestResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, pullrequestModel.StatusCLOSED, response["pr"].Status)
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_ReopenPullRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		setupMock  func(m *mockService)
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name: "success",
+			body: `{"pull_request_id":"pr-1","reopened_by":"u1","reason":"still needed"}`,
+			setupMock: func(m *mockService) {
+				req := &pullrequestModel.ReopenPullRequestRequest{PullRequestID: "pr-1", ReopenedBy: "u1", Reason: "still needed"}
+				m.On("ReopenPullRequest", mock.Anything, req).Return(&pullrequestModel.PullRequestResponse{
+					PullRequestID: "pr-1",
+					Status:        pullrequestModel.StatusOPEN,
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing pull_request_id",
+			body:       `{}`,
+			setupMock:  func(*mockService) {},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "INVALID_REQUEST",
+		},
+		{
+			name: "merged pull request",
+			body: `{"pull_request_id":"pr-1"}`,
+			setupMock: func(m *mockService) {
+				m.On("ReopenPullRequest", mock.Anything, mock.Anything).Return(nil, pullrequestModel.ErrPullRequestMerged)
+			},
+			wantStatus: http.StatusConflict,
+			wantCode:   "PR_MERGED",
+		},
+		{
+			name: "pull request not found",
+			body: `{"pull_request_id":"pr-1"}`,
+			setupMock: func(m *mockService) {
+				m.On("ReopenPullRequest", mock.Anything, mock.Anything).Return(nil, pullrequestModel.ErrPullRequestNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantCode:   "NOT_FOUND",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mockService)
+			tt.setupMock(mockSvc)
+			router := setupRouter()
+			router.POST("/pullRequest/reopen", New(mockSvc).ReopenPullRequest)
+
+			w := httptest.NewRecorder()
+			httpReq, _ := http.NewRequest("POST", "/pullRequest/reopen", bytes.NewBufferString(tt.body))
+			httpReq.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, httpReq)
+
+			assert.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+			if tt.wantCode != "" {
+				var response ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.wantCode, response.Error.Code)
+			} else {
+				var response map[string]pullrequestModel.PullRequestResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, pullrequestModel.StatusOPEN, response["pr"].Status)
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_ClosedPullRequestConflicts(t *testing.T) {
+	mockSvc := new(mockService)
+	router := setupRouter()
+	router.POST("/pullRequest/merge", New(mockSvc).MergePullRequest)
+	mockSvc.On("MergePullRequest", mock.Anything, mock.Anything).Return(nil, pullrequestModel.ErrPullRequestClosed)
+
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("POST", "/pullRequest/merge", bytes.NewBufferString(`{"pull_request_id":"pr-1"}`))
+	httpReq.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, httpReq)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "PR_CLOSED", response.Error.Code)
+}
+
+func TestHandler_MergePullRequest_Conflicts(t *testing.T) {
+	mockSvc := new(mockService)
+	handler := New(mockSvc)
+	router := setupRouter()
+	router.POST("/pullRequest/merge", handler.MergePullRequest)
+
+	mockSvc.On("MergePullRequest", mock.Anything, mock.Anything).
+		Return(nil, pullrequestModel.ErrPullRequestHasConflicts)
+
+	body := []byte(`{"pull_request_id":"pr-1"}`)
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("POST", "/pullRequest/merge", bytes.NewBuffer(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, httpReq)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	var response ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "PR_HAS_CONFLICTS", response.Error.Code)
+}
+
+func TestHandler_MergePullRequest_Override(t *testing.T) {
+	errorCases := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"not team lead", pullrequestModel.ErrNotTeamLead, http.StatusForbidden, "FORBIDDEN"},
+		{"missing reason", pullrequestModel.ErrOverrideReasonRequired, http.StatusBadRequest, "INVALID_REQUEST"},
+		{"nothing to override", pullrequestModel.ErrOverrideNotNeeded, http.StatusBadRequest, "INVALID_REQUEST"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockSvc := new(mockService)
+			handler := New(mockSvc)
+			router := setupRouter()
+			router.POST("/pullRequest/merge", handler.MergePullRequest)
+
+			mockSvc.On("MergePullRequest", mock.Anything, mock.Anything).Return(nil, tc.err)
+
+			body := []byte(`{"pull_request_id":"pr-1","override":true,"merged_by":"u2"}`)
+			w := httptest.NewRecorder()
+			httpReq, _ := http.NewRequest("POST", "/pullRequest/merge", bytes.NewBuffer(body))
+			httpReq.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, httpReq)
+
+			assert.Equal(t, tc.wantStatus, w.Code)
+			var response ErrorResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantCode, response.Error.Code)
+		})
+	}
+}
+
+func TestHandler_CreatePullRequest_Duplicate(t *testing.T) {
+	mockSvc := new(mockService)
+	handler := New(mockSvc)
+	router := setupRouter()
+	router.POST("/pullRequest/create", handler.CreatePullRequest)
+
+	mockSvc.On("CreatePullRequest", mock.Anything, mock.Anything).
+		Return(nil, pullrequestModel.ErrDuplicatePullRequest)
+
+	body := []byte(`{"pull_request_id":"pr-2","pull_request_name":"Add feature","author_id":"u1"}`)
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("POST", "/pullRequest/create", bytes.NewBuffer(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, httpReq)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	var response ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "PR_DUPLICATE", response.Error.Code)
+}
+
+func TestHandler_GetCandidates(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.GET("/pullRequest/candidates", handler.GetCandidates)
+
+		resp := &pullrequestModel.PullRequestCandidatesResponse{
+			PullRequestID: "pr-1",
+			Candidates:    []pullrequestModel.AssignmentCandidate{{UserID: "u3", ReviewLoad: 1}},
+		}
+		mockSvc.On("GetCandidates", mock.Anything, "pr-1").Return(resp, nil)
+
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("GET", "/pullRequest/candidates?pull_request_id=pr-1", nil)
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response pullrequestModel.PullRequestCandidatesResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, *resp, response)
+	})
+
+	t.Run("missing pull_request_id", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.GET("/pullRequest/candidates", handler.GetCandidates)
+
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("GET", "/pullRequest/candidates", nil)
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockSvc.AssertNotCalled(t, "GetCandidates")
+	})
+
+	t.Run("merged pull request", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.GET("/pullRequest/candidates", handler.GetCandidates)
+
+		mockSvc.On("GetCandidates", mock.Anything, "pr-1").Return(nil, pullrequestModel.ErrPullRequestMerged)
+
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("GET", "/pullRequest/candidates?pull_request_id=pr-1", nil)
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		var response ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "PR_MERGED", response.Error.Code)
+	})
+}
+
+func TestHandler_PreviewAssignment(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.POST("/pullRequest/previewAssignment", handler.PreviewAssignment)
+
+		req := &pullrequestModel.PreviewAssignmentRequest{AuthorID: "u1"}
+		resp := &pullrequestModel.PreviewAssignmentResponse{
+			AuthorID:          "u1",
+			TeamName:          "backend",
+			AssignedReviewers: []string{"u2"},
+			Candidates:        []pullrequestModel.AssignmentCandidate{{UserID: "u2", ReviewLoad: 0}},
+		}
+		mockSvc.On("PreviewAssignment", mock.Anything, req).Return(resp, nil)
+
+		body, _ := json.Marshal(req)
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/pullRequest/previewAssignment", bytes.NewBuffer(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response pullrequestModel.PreviewAssignmentResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, *resp, response)
+	})
+
+	t.Run("missing author_id", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.POST("/pullRequest/previewAssignment", handler.PreviewAssignment)
+
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/pullRequest/previewAssignment", bytes.NewBufferString(`{}`))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockSvc.AssertNotCalled(t, "PreviewAssignment")
+	})
+
+	t.Run("author not found", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.POST("/pullRequest/previewAssignment", handler.PreviewAssignment)
+
+		mockSvc.On("PreviewAssignment", mock.Anything, mock.Anything).
+			Return(nil, pullrequestModel.ErrAuthorNotFound)
+
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/pullRequest/previewAssignment",
+			bytes.NewBufferString(`{"author_id":"ghost"}`))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		var response ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "author not found", response.Error.Message)
+	})
+}
+
+func TestHandler_ListPullRequests(t *testing.T) {
+	t.Run("filters and pages", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.GET("/pullRequest/list", handler.ListPullRequests)
+
+		query := pullrequestModel.ListQuery{
+			Status:     pullrequestModel.StatusOPEN,
+			AuthorID:   "u1",
+			TeamName:   "backend",
+			ReviewerID: "u2",
+			Limit:      1,
+		}
+		next := &pullrequestModel.ListPosition{
+			CreatedAt:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			PullRequestID: "pr-2",
+		}
+		mockSvc.On("ListPullRequests", mock.Anything, query).Return(&pullrequestModel.ListPullRequestsResponse{
+			PullRequests: []pullrequestModel.PullRequestResponse{{PullRequestID: "pr-2", AssignedReviewers: []string{"u2"}}},
+			Next:         next,
+		}, nil)
+		secondQuery := query
+		secondQuery.After = next
+		mockSvc.On("ListPullRequests", mock.Anything, secondQuery).Return(&pullrequestModel.ListPullRequestsResponse{
+			PullRequests: []pullrequestModel.PullRequestResponse{{PullRequestID: "pr-1", AssignedReviewers: []string{"u2"}}},
+		}, nil)
+
+		url := "/pullRequest/list?status=OPEN&author_id=u1&team_name=backend&reviewer_id=u2&limit=1"
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("GET", url, nil)
+		router.ServeHTTP(w, httpReq)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var first pullrequestModel.ListPullRequestsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &first))
+		require.Len(t, first.PullRequests, 1)
+		assert.Equal(t, "pr-2", first.PullRequests[0].PullRequestID)
+		require.NotEmpty(t, first.NextCursor)
+
+		w = httptest.NewRecorder()
+		httpReq, _ = http.NewRequest("GET", url+"&cursor="+first.NextCursor, nil)
+		router.ServeHTTP(w, httpReq)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var second pullrequestModel.ListPullRequestsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &second))
+		assert.Equal(t, "pr-1", second.PullRequests[0].PullRequestID)
+		assert.Empty(t, second.NextCursor)
+		mockSvc.AssertExpectations(t)
+	})
+
+	tests := []struct {
+		name      string
+		url       string
+		err       error
+		wantField string
+	}{
+		{name: "limit is not a number", url: "/pullRequest/list?limit=ten", wantField: "limit"},
+		{name: "malformed cursor", url: "/pullRequest/list?cursor=abc", wantField: "cursor"},
+		{
+			name:      "invalid status",
+			url:       "/pullRequest/list?status=open",
+			err:       pullrequestModel.ErrInvalidStatus,
+			wantField: "status",
+		},
+		{
+			name:      "limit out of range",
+			url:       "/pullRequest/list?limit=1000",
+			err:       fmt.Errorf("%w: must be between 1 and 100, got 1000", pagination.ErrInvalidLimit),
+			wantField: "limit",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mockService)
+			handler := New(mockSvc)
+			router := setupRouter()
+			router.GET("/pullRequest/list", handler.ListPullRequests)
+			if tt.err != nil {
+				mockSvc.On("ListPullRequests", mock.Anything, mock.Anything).Return(nil, tt.err)
+			}
+
+			w := httptest.NewRecorder()
+			httpReq, _ := http.NewRequest("GET", tt.url, nil)
+			router.ServeHTTP(w, httpReq)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			var response ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, "INVALID_REQUEST", response.Error.Code)
+			require.Len(t, response.Error.Details, 1)
+			assert.Equal(t, tt.wantField, response.Error.Details[0].Field)
+			if tt.err == nil {
+				mockSvc.AssertNotCalled(t, "ListPullRequests")
+			}
+		})
+	}
+}
+
+func TestHandler_GetAssignmentStatus(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.GET("/pullRequest/assignment", handler.GetAssignmentStatus)
+
+		resp := &pullrequestModel.AssignmentStatusResponse{
+			PullRequestID:     "pr-1",
+			Status:            pullrequestModel.StatusASSIGNING,
+			Pending:           true,
+			AssignedReviewers: []string{},
+		}
+		mockSvc.On("GetAssignmentStatus", mock.Anything, "pr-1").Return(resp, nil)
+
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("GET", "/pullRequest/assignment?pull_request_id=pr-1", nil)
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response pullrequestModel.AssignmentStatusResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, *resp, response)
+	})
+
+	t.Run("missing pull_request_id", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.GET("/pullRequest/assignment", handler.GetAssignmentStatus)
+
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("GET", "/pullRequest/assignment", nil)
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockSvc.AssertNotCalled(t, "GetAssignmentStatus")
+	})
+
+	t.Run("pull request not found", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.GET("/pullRequest/assignment", handler.GetAssignmentStatus)
+
+		mockSvc.On("GetAssignmentStatus", mock.Anything, "nonexistent").
+			Return(nil, pullrequestModel.ErrPullRequestNotFound)
+
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("GET", "/pullRequest/assignment?pull_request_id=nonexistent", nil)
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestHandler_SuggestReviewers(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.GET("/pullRequest/suggestReviewers", handler.SuggestReviewers)
+
+		resp := &pullrequestModel.SuggestReviewersResponse{
+			PullRequestID: "pr-1",
+			Suggestions: []pullrequestModel.ReviewerSuggestion{
+				{UserID: "u2", Score: 3, AuthorReviews: 1, BranchReviews: 1},
+				{UserID: "u3", Score: -2, ReviewLoad: 1},
+			},
+		}
+		mockSvc.On("SuggestReviewers", mock.Anything, "pr-1").Return(resp, nil)
+
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("GET", "/pullRequest/suggestReviewers?pull_request_id=pr-1", nil)
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response pullrequestModel.SuggestReviewersResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, *resp, response)
+	})
+
+	t.Run("missing pull_request_id", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.GET("/pullRequest/suggestReviewers", handler.SuggestReviewers)
+
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("GET", "/pullRequest/suggestReviewers", nil)
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockSvc.AssertNotCalled(t, "SuggestReviewers")
+	})
+
+	t.Run("merged pull request", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.GET("/pullRequest/suggestReviewers", handler.SuggestReviewers)
+
+		mockSvc.On("SuggestReviewers", mock.Anything, "pr-1").Return(nil, pullrequestModel.ErrPullRequestMerged)
+
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("GET", "/pullRequest/suggestReviewers?pull_request_id=pr-1", nil)
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		var response ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "PR_MERGED", response.Error.Code)
+	})
+}
@@ -1,22 +1,107 @@
 // Package model provides data transfer objects and domain models for the pullrequest module.
 package model
 
+import (
+	"time"
+
+	"github.com/festy23/avito_internship/pkg/sortparam"
+)
+
 // CreatePullRequestRequest represents the request to create a pull request.
+// Lengths match the CHECK constraints of the pull_requests table; line counts are bounded by MaxLines.
 type CreatePullRequestRequest struct {
-	PullRequestID   string `json:"pull_request_id"   binding:"required"`
-	PullRequestName string `json:"pull_request_name" binding:"required"`
-	AuthorID        string `json:"author_id"         binding:"required"`
+	PullRequestID   string `json:"pull_request_id"            binding:"required,max=255"`
+	PullRequestName string `json:"pull_request_name"          binding:"required,max=255"`
+	AuthorID        string `json:"author_id"                  binding:"required,max=255"`
+	SourceBranch    string `json:"source_branch,omitempty"    binding:"max=255"`
+	TargetBranch    string `json:"target_branch,omitempty"    binding:"max=255"`
+	PullRequestURL  string `json:"pull_request_url,omitempty"`
+	LinesAdded      int    `json:"lines_added,omitempty"      binding:"min=0,max=10000000"`
+	LinesRemoved    int    `json:"lines_removed,omitempty"    binding:"min=0,max=10000000"`
 }
 
 // MergePullRequestRequest represents the request to merge a pull request.
+// Override lets the lead of the team owning the PR (MergedBy) merge a PR blocked by merge rules;
+// Reason is then required and recorded in the activity log. Override of a merge no rule blocks is rejected.
 type MergePullRequestRequest struct {
-	PullRequestID string `json:"pull_request_id" binding:"required"`
+	PullRequestID string `json:"pull_request_id" binding:"required,max=255"`
+	Override      bool   `json:"override"`
+	MergedBy      string `json:"merged_by"       binding:"max=255"`
+	Reason        string `json:"reason"          binding:"max=1000"`
+}
+
+// ClosePullRequestRequest represents the request to close a pull request without merging.
+// CloseReason (one of the close reasons, CloseReasonOther when empty) and Note are kept on the pull
+// request; they are recorded in the activity log together with ClosedBy. Reason is the former name
+// of Note, used when Note is empty.
+type ClosePullRequestRequest struct {
+	PullRequestID string `json:"pull_request_id" binding:"required,max=255"`
+	ClosedBy      string `json:"closed_by"       binding:"max=255"`
+	CloseReason   string `json:"close_reason"    binding:"max=32"`
+	Note          string `json:"note"            binding:"max=1000"`
+	Reason        string `json:"reason"          binding:"max=1000"`
+}
+
+// ReopenPullRequestRequest represents the request to reopen a closed pull request.
+// ReopenedBy and Reason are recorded in the activity log.
+type ReopenPullRequestRequest struct {
+	PullRequestID string `json:"pull_request_id" binding:"required,max=255"`
+	ReopenedBy    string `json:"reopened_by"     binding:"max=255"`
+	Reason        string `json:"reason"          binding:"max=1000"`
 }
 
 // ReassignReviewerRequest represents the request to reassign a reviewer.
 type ReassignReviewerRequest struct {
-	PullRequestID string `json:"pull_request_id" binding:"required"`
-	OldUserID     string `json:"old_user_id"     binding:"required"`
+	PullRequestID string `json:"pull_request_id" binding:"required,max=255"`
+	OldUserID     string `json:"old_user_id"     binding:"required,max=255"`
+}
+
+// WatchPullRequestRequest represents the request to watch a pull request.
+type WatchPullRequestRequest struct {
+	PullRequestID string `json:"pull_request_id" binding:"required,max=255"`
+	UserID        string `json:"user_id"         binding:"required,max=255"`
+}
+
+// SetConflictsRequest represents the request to update the merge-conflict flag of a pull request.
+// HasConflicts is a pointer so that an explicit false passes the required check.
+type SetConflictsRequest struct {
+	PullRequestID string `json:"pull_request_id" binding:"required,max=255"`
+	HasConflicts  *bool  `json:"has_conflicts"   binding:"required"`
+}
+
+// AssignReviewerRequest represents the request of a team lead to assign a named reviewer.
+type AssignReviewerRequest struct {
+	PullRequestID string `json:"pull_request_id" binding:"required,max=255"`
+	UserID        string `json:"user_id"         binding:"required,max=255"`
+	AssignedBy    string `json:"assigned_by"     binding:"required,max=255"`
+}
+
+// UnassignReviewerRequest represents the request to remove a reviewer without replacement.
+type UnassignReviewerRequest struct {
+	PullRequestID string `json:"pull_request_id" binding:"required,max=255"`
+	UserID        string `json:"user_id"         binding:"required,max=255"`
+}
+
+// ChangeAuthorRequest represents the request to transfer authorship of a pull request.
+type ChangeAuthorRequest struct {
+	PullRequestID string `json:"pull_request_id" binding:"required,max=255"`
+	AuthorID      string `json:"author_id"       binding:"required,max=255"`
+}
+
+// TransferTeamRequest represents the request to re-assign reviewers of a pull request from another team.
+type TransferTeamRequest struct {
+	PullRequestID string `json:"pull_request_id" binding:"required,max=255"`
+	TeamName      string `json:"team_name"       binding:"required,max=255"`
+}
+
+// ReassignAllRequest represents the request to replace a reviewer in all of their open pull requests.
+type ReassignAllRequest struct {
+	OldUserID string `json:"old_user_id" binding:"required,max=255"`
+}
+
+// PreviewAssignmentRequest represents the request to preview reviewer assignment for a hypothetical PR.
+type PreviewAssignmentRequest struct {
+	AuthorID string `json:"author_id" binding:"required,max=255"`
 }
 
 // PullRequestResponse represents the response after creating or merging a pull request.
@@ -26,8 +111,27 @@ type PullRequestResponse struct {
 	AuthorID          string   `json:"author_id"`
 	Status            string   `json:"status"`
 	AssignedReviewers []string `json:"assigned_reviewers"`
+	Watchers          []string `json:"watchers,omitempty"`
 	CreatedAt         string   `json:"createdAt,omitempty"`
 	MergedAt          string   `json:"mergedAt,omitempty"`
+	ArchivedAt        string   `json:"archivedAt,omitempty"`
+	SourceBranch      string   `json:"source_branch,omitempty"`
+	TargetBranch      string   `json:"target_branch,omitempty"`
+	PullRequestURL    string   `json:"pull_request_url,omitempty"`
+	LinesAdded        int      `json:"lines_added,omitempty"`
+	LinesRemoved      int      `json:"lines_removed,omitempty"`
+	HasConflicts      bool     `json:"has_conflicts"`
+	CloseReason       string   `json:"close_reason,omitempty"`
+	CloseNote         string   `json:"close_note,omitempty"`
+	Warning           string   `json:"warning,omitempty"`
+	// MergeOverride is set in the response to a merge that bypassed merge rules.
+	MergeOverride *MergeOverrideResponse `json:"merge_override,omitempty"`
+}
+
+// MergeOverrideResponse describes a merge forced by a team lead.
+type MergeOverrideResponse struct {
+	MergedBy string `json:"merged_by"`
+	Reason   string `json:"reason"`
 }
 
 // ReassignReviewerResponse represents the response after reassigning a reviewer.
@@ -35,3 +139,120 @@ type ReassignReviewerResponse struct {
 	PR         *PullRequestResponse `json:"pr"`
 	ReplacedBy string               `json:"replaced_by"`
 }
+
+// ReassignAllResponse represents the outcome of replacing a reviewer in all of their open pull requests.
+type ReassignAllResponse struct {
+	OldUserID string              `json:"old_user_id"`
+	Results   []ReassignAllResult `json:"results"`
+}
+
+// ReassignAllResult represents the outcome for a single pull request: the replacement reviewer, or
+// an error code (NO_CANDIDATE) if the reviewer was kept.
+type ReassignAllResult struct {
+	PullRequestID string `json:"pull_request_id"`
+	ReplacedBy    string `json:"replaced_by,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+// ReassignResultNoCandidate is the error of a ReassignAllResult without a replacement candidate.
+const ReassignResultNoCandidate = "NO_CANDIDATE"
+
+// PullRequestEventResponse represents a single entry of the pull request activity log.
+type PullRequestEventResponse struct {
+	Type           string `json:"type"`
+	UserID         string `json:"user_id,omitempty"`
+	PreviousUserID string `json:"previous_user_id,omitempty"`
+	ActorID        string `json:"actor_id,omitempty"`
+	Reason         string `json:"reason,omitempty"`
+	Source         string `json:"source,omitempty"`
+	CreatedAt      string `json:"createdAt"`
+}
+
+// EventSort declares the sort fields of the activity log: chronological order by default.
+// Events of one transaction share created_at, so id keeps them in the order they were recorded.
+var EventSort = sortparam.Spec{
+	Columns:      map[string][]string{"created_at": {"created_at", "id"}},
+	DefaultField: "created_at",
+	DefaultOrder: sortparam.Asc,
+}
+
+// PullRequestActivityResponse represents the ordered activity log of a pull request.
+type PullRequestActivityResponse struct {
+	PullRequestID string                     `json:"pull_request_id"`
+	Events        []PullRequestEventResponse `json:"events"`
+}
+
+// ListQuery holds the filters of ListPullRequests; empty filters match every pull request.
+// TeamName matches the team owning the PR. A zero Limit means the default page size; After is the
+// position of the previous page.
+type ListQuery struct {
+	Status     string
+	AuthorID   string
+	TeamName   string
+	ReviewerID string
+	Limit      int
+	After      *ListPosition
+}
+
+// ListPosition is the keyset of the last PR of a ListPullRequests page, encoded in its cursor.
+// Pages are ordered newest first.
+type ListPosition struct {
+	CreatedAt     time.Time `json:"t"`
+	PullRequestID string    `json:"id"`
+}
+
+// ListPullRequestsResponse represents a page of pull requests matching the filters.
+// NextCursor is set when more PRs follow the page; Next is its position before encoding.
+type ListPullRequestsResponse struct {
+	PullRequests []PullRequestResponse `json:"pull_requests"`
+	NextCursor   string                `json:"next_cursor,omitempty"`
+	Next         *ListPosition         `json:"-"`
+}
+
+// AssignmentStatusResponse represents the reviewer assignment state of a pull request.
+// Pending is true while the PR is in ASSIGNING status.
+type AssignmentStatusResponse struct {
+	PullRequestID     string   `json:"pull_request_id"`
+	Status            string   `json:"status"`
+	Pending           bool     `json:"pending"`
+	AssignedReviewers []string `json:"assigned_reviewers"`
+}
+
+// AssignmentCandidate represents a team member eligible for review with their current review load.
+type AssignmentCandidate struct {
+	UserID     string `json:"user_id"`
+	ReviewLoad int    `json:"review_load"`
+}
+
+// PreviewAssignmentResponse represents the reviewers a new PR of the author would get.
+// Candidates lists all eligible team members, least loaded first.
+type PreviewAssignmentResponse struct {
+	AuthorID          string                `json:"author_id"`
+	TeamName          string                `json:"team_name"`
+	AssignedReviewers []string              `json:"assigned_reviewers"`
+	Candidates        []AssignmentCandidate `json:"candidates"`
+}
+
+// PullRequestCandidatesResponse represents the team members who can be assigned as reviewers of a PR,
+// least loaded first.
+type PullRequestCandidatesResponse struct {
+	PullRequestID string                `json:"pull_request_id"`
+	Candidates    []AssignmentCandidate `json:"candidates"`
+}
+
+// ReviewerSuggestion represents a reviewer candidate ranked by review history, with the signals behind the rank.
+type ReviewerSuggestion struct {
+	UserID string `json:"user_id"`
+	Score  int    `json:"score"`
+	// AuthorReviews counts recent reviews of pull requests by the same author.
+	AuthorReviews int `json:"author_reviews"`
+	// BranchReviews counts recent reviews of pull requests into the same target branch.
+	BranchReviews int `json:"branch_reviews"`
+	ReviewLoad    int `json:"review_load"`
+}
+
+// SuggestReviewersResponse represents the reviewer candidates of a pull request, best suited first.
+type SuggestReviewersResponse struct {
+	PullRequestID string               `json:"pull_request_id"`
+	Suggestions   []ReviewerSuggestion `json:"suggestions"`
+}
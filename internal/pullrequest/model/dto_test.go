@@ -8,6 +8,9 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/festy23/avito_internship/pkg/apierror"
+	"github.com/festy23/avito_internship/pkg/bind"
 )
 
 func TestCreatePullRequestRequest_JSONSerialization(t *testing.T) {
@@ -79,6 +82,68 @@ func TestCreatePullRequestRequest_Validation(t *testing.T) {
 	})
 }
 
+func TestCreatePullRequestRequest_BindingTags(t *testing.T) {
+	valid := func() CreatePullRequestRequest {
+		return CreatePullRequestRequest{PullRequestID: "pr-1", PullRequestName: "Add feature", AuthorID: "u1"}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(req *CreatePullRequestRequest)
+		field  string
+		rule   string
+	}{
+		{"valid", func(*CreatePullRequestRequest) {}, "", ""},
+		{"max length", func(req *CreatePullRequestRequest) {
+			req.PullRequestID = strings.Repeat("a", 255)
+		}, "", ""},
+		{"missing author_id", func(req *CreatePullRequestRequest) { req.AuthorID = "" }, "author_id", "required"},
+		{"pull_request_id too long", func(req *CreatePullRequestRequest) {
+			req.PullRequestID = strings.Repeat("a", 256)
+		}, "pull_request_id", "max"},
+		{"pull_request_name too long", func(req *CreatePullRequestRequest) {
+			req.PullRequestName = strings.Repeat("a", 256)
+		}, "pull_request_name", "max"},
+		{"source_branch too long", func(req *CreatePullRequestRequest) {
+			req.SourceBranch = strings.Repeat("b", 256)
+		}, "source_branch", "max"},
+		{"negative lines_removed", func(req *CreatePullRequestRequest) { req.LinesRemoved = -1 }, "lines_removed", "min"},
+		{"maximum lines", func(req *CreatePullRequestRequest) {
+			req.LinesAdded, req.LinesRemoved = MaxLines, MaxLines
+		}, "", ""},
+		{"too many lines_added", func(req *CreatePullRequestRequest) { req.LinesAdded = MaxLines + 1 }, "lines_added", "max"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.modify(&req)
+
+			err := bind.Struct(&req)
+			if tt.field == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			var apiErr *apierror.Error
+			require.ErrorAs(t, err, &apiErr)
+			require.Len(t, apiErr.Details, 1)
+			assert.Equal(t, tt.field, apiErr.Details[0].Field)
+			assert.Equal(t, tt.rule, apiErr.Details[0].Rule)
+		})
+	}
+}
+
+func TestReassignReviewerRequest_BindingTags(t *testing.T) {
+	err := bind.Struct(&ReassignReviewerRequest{PullRequestID: "pr-1", OldUserID: strings.Repeat("u", 256)})
+
+	var apiErr *apierror.Error
+	require.ErrorAs(t, err, &apiErr)
+	require.Len(t, apiErr.Details, 1)
+	assert.Equal(t, "old_user_id", apiErr.Details[0].Field)
+	assert.Equal(t, "old_user_id must be at most 255 characters", apiErr.Details[0].Message)
+}
+
 func TestMergePullRequestRequest_JSONSerialization(t *testing.T) {
 	t.Run("marshal to JSON", func(t *testing.T) {
 		req := MergePullRequestRequest{
@@ -5,10 +5,16 @@ import "errors"
 var (
 	// ErrPullRequestExists indicates that a pull request with the given ID already exists.
 	ErrPullRequestExists = errors.New("pull request already exists")
+	// ErrDuplicatePullRequest indicates that the author already has an open PR with the same name.
+	ErrDuplicatePullRequest = errors.New("author already has an open pull request with the same name")
 	// ErrPullRequestNotFound indicates that the requested pull request does not exist.
 	ErrPullRequestNotFound = errors.New("pull request not found")
 	// ErrPullRequestMerged indicates that the pull request is already merged and cannot be modified.
 	ErrPullRequestMerged = errors.New("pull request is merged")
+	// ErrPullRequestClosed indicates that the pull request is closed without merging and cannot be modified.
+	ErrPullRequestClosed = errors.New("pull request is closed")
+	// ErrPullRequestHasConflicts indicates that the pull request has merge conflicts and cannot be merged.
+	ErrPullRequestHasConflicts = errors.New("pull request has merge conflicts")
 	// ErrReviewerNotAssigned indicates that the user is not assigned as a reviewer for this PR.
 	ErrReviewerNotAssigned = errors.New("reviewer is not assigned to this PR")
 	// ErrNoCandidate indicates that there are no available candidates for assignment.
@@ -17,12 +23,36 @@ var (
 	ErrAuthorNotFound = errors.New("author not found")
 	// ErrInvalidPullRequestID indicates that the provided pull request ID is invalid (e.g., empty).
 	ErrInvalidPullRequestID = errors.New("invalid pull request ID")
-	// ErrInvalidAuthorID indicates that the provided author ID is invalid (empty or too long).
+	// ErrInvalidAuthorID indicates that the provided author ID is empty.
 	ErrInvalidAuthorID = errors.New("author_id must be between 1 and 255 characters")
+	// ErrInvalidStatus indicates that the status is not one of the pull request statuses.
+	ErrInvalidStatus = errors.New("invalid status: must be OPEN, MERGED, ASSIGNING or CLOSED")
+	// ErrInvalidCloseReason indicates that the close reason is not one of the close reasons.
+	ErrInvalidCloseReason = errors.New(
+		"invalid close_reason: must be ABANDONED, SUPERSEDED, DUPLICATE, REJECTED, STALE or OTHER",
+	)
+	// ErrInvalidPullRequestURL indicates that the provided pull request URL is not a valid http(s) URL.
+	ErrInvalidPullRequestURL = errors.New("pull_request_url must be a valid http or https URL")
 	// ErrMaxReviewersExceeded indicates that the maximum number of reviewers (2) has been exceeded.
 	ErrMaxReviewersExceeded = errors.New("maximum 2 reviewers allowed per pull request")
 	// ErrReviewerAlreadyAssigned indicates that the reviewer is already assigned to this pull request.
 	ErrReviewerAlreadyAssigned = errors.New("reviewer already assigned to this pull request")
+	// ErrNotTeamLead indicates that a manual action is requested by someone other than the lead of the PR's team.
+	ErrNotTeamLead = errors.New("only the lead of the pull request's team can perform this action")
+	// ErrReviewerNotInTeam indicates that the reviewer is not a member of the PR's team.
+	ErrReviewerNotInTeam = errors.New("reviewer must be a member of the pull request's team")
+	// ErrReviewerInactive indicates that the reviewer is not active.
+	ErrReviewerInactive = errors.New("reviewer is not active")
+	// ErrReviewerOverloaded indicates that the reviewer's review load has reached the configured limit.
+	ErrReviewerOverloaded = errors.New("reviewer has reached the review load limit")
+	// ErrTooFewReviewers indicates that unassigning the reviewer would leave fewer reviewers than required.
+	ErrTooFewReviewers = errors.New("pull request must keep the minimum number of reviewers")
+	// ErrOverrideReasonRequired indicates that a merge override is requested without a reason.
+	ErrOverrideReasonRequired = errors.New("reason is required to override merge rules")
+	// ErrOverrideNotNeeded indicates that a merge override is requested while no merge rule blocks the merge.
+	ErrOverrideNotNeeded = errors.New("override is allowed only when merge rules block the merge")
+	// ErrAssignmentPending indicates that reviewers of the pull request are still being assigned.
+	ErrAssignmentPending = errors.New("reviewer assignment is in progress")
 	// ErrAuthorCannotBeReviewer indicates that the author cannot be assigned as a reviewer.
 	ErrAuthorCannotBeReviewer = errors.New("author cannot be assigned as reviewer")
 )
@@ -14,13 +14,19 @@ func TestErrors_Definition(t *testing.T) {
 		expected string
 	}{
 		{"ErrPullRequestExists", ErrPullRequestExists, "pull request already exists"},
+		{"ErrDuplicatePullRequest", ErrDuplicatePullRequest, "author already has an open pull request with the same name"},
 		{"ErrPullRequestNotFound", ErrPullRequestNotFound, "pull request not found"},
 		{"ErrPullRequestMerged", ErrPullRequestMerged, "pull request is merged"},
+		{"ErrPullRequestHasConflicts", ErrPullRequestHasConflicts, "pull request has merge conflicts"},
 		{"ErrReviewerNotAssigned", ErrReviewerNotAssigned, "reviewer is not assigned to this PR"},
 		{"ErrNoCandidate", ErrNoCandidate, "no active replacement candidate in team"},
 		{"ErrAuthorNotFound", ErrAuthorNotFound, "author not found"},
 		{"ErrInvalidPullRequestID", ErrInvalidPullRequestID, "invalid pull request ID"},
 		{"ErrInvalidAuthorID", ErrInvalidAuthorID, "author_id must be between 1 and 255 characters"},
+		{"ErrInvalidStatus", ErrInvalidStatus, "invalid status: must be OPEN, MERGED, ASSIGNING or CLOSED"},
+		{"ErrInvalidCloseReason", ErrInvalidCloseReason,
+			"invalid close_reason: must be ABANDONED, SUPERSEDED, DUPLICATE, REJECTED, STALE or OTHER"},
+		{"ErrInvalidPullRequestURL", ErrInvalidPullRequestURL, "pull_request_url must be a valid http or https URL"},
 		{"ErrMaxReviewersExceeded", ErrMaxReviewersExceeded, "maximum 2 reviewers allowed per pull request"},
 		{"ErrReviewerAlreadyAssigned", ErrReviewerAlreadyAssigned, "reviewer already assigned to this pull request"},
 		{"ErrAuthorCannotBeReviewer", ErrAuthorCannotBeReviewer, "author cannot be assigned as reviewer"},
@@ -38,13 +44,16 @@ func TestErrors_Uniqueness(t *testing.T) {
 	t.Run("all errors are unique", func(t *testing.T) {
 		errorList := []error{
 			ErrPullRequestExists,
+			ErrDuplicatePullRequest,
 			ErrPullRequestNotFound,
 			ErrPullRequestMerged,
+			ErrPullRequestHasConflicts,
 			ErrReviewerNotAssigned,
 			ErrNoCandidate,
 			ErrAuthorNotFound,
 			ErrInvalidPullRequestID,
 			ErrInvalidAuthorID,
+			ErrInvalidPullRequestURL,
 			ErrMaxReviewersExceeded,
 			ErrReviewerAlreadyAssigned,
 			ErrAuthorCannotBeReviewer,
@@ -1,7 +1,8 @@
 package model
 
 import (
-	"errors"
+	"net/url"
+	"strings"
 	"time"
 )
 
@@ -11,19 +12,140 @@ const (
 	StatusOPEN = "OPEN"
 	// StatusMERGED represents a merged pull request.
 	StatusMERGED = "MERGED"
+	// StatusASSIGNING represents a pull request waiting for asynchronous reviewer assignment.
+	StatusASSIGNING = "ASSIGNING"
+	// StatusCLOSED represents a pull request closed without merging.
+	StatusCLOSED = "CLOSED"
+)
+
+// Activity event types.
+const (
+	// EventCreated is recorded when a pull request is created.
+	EventCreated = "CREATED"
+	// EventReviewerAssigned is recorded when a reviewer is assigned to a pull request.
+	EventReviewerAssigned = "REVIEWER_ASSIGNED"
+	// EventReviewerAssignedManually is recorded when a team lead assigns a reviewer by name.
+	EventReviewerAssignedManually = "REVIEWER_ASSIGNED_MANUALLY"
+	// EventReviewerReplaced is recorded when a reviewer is replaced by another one.
+	EventReviewerReplaced = "REVIEWER_REPLACED"
+	// EventReviewerRemoved is recorded when a reviewer is removed without replacement.
+	EventReviewerRemoved = "REVIEWER_REMOVED"
+	// EventAuthorChanged is recorded when authorship is transferred; the previous author is kept
+	// as the previous user.
+	EventAuthorChanged = "AUTHOR_CHANGED"
+	// EventMerged is recorded when a pull request is merged.
+	EventMerged = "MERGED"
+	// EventClosed is recorded when a pull request is closed without merging.
+	EventClosed = "CLOSED"
+	// EventReopened is recorded when a closed pull request is opened again.
+	EventReopened = "REOPENED"
+)
+
+// Reasons of closing a pull request without merging, kept on the pull request for reporting.
+const (
+	// CloseReasonAbandoned marks work the author gave up.
+	CloseReasonAbandoned = "ABANDONED"
+	// CloseReasonSuperseded marks a pull request replaced by another one.
+	CloseReasonSuperseded = "SUPERSEDED"
+	// CloseReasonDuplicate marks a pull request duplicating another one.
+	CloseReasonDuplicate = "DUPLICATE"
+	// CloseReasonRejected marks a change the team decided not to accept.
+	CloseReasonRejected = "REJECTED"
+	// CloseReasonStale marks a pull request closed for inactivity.
+	CloseReasonStale = "STALE"
+	// CloseReasonOther marks any other reason; it is used when none is given.
+	CloseReasonOther = "OTHER"
+)
+
+// Sources of reviewer events: the path through which a reviewer was assigned, replaced or removed.
+const (
+	// SourceAuto marks reviewers selected automatically when a pull request is created.
+	SourceAuto = "AUTO"
+	// SourceManual marks reviewers assigned or unassigned by name.
+	SourceManual = "MANUAL"
+	// SourceReassign marks reviewers replaced by /pullRequest/reassign and /pullRequest/reassignAll.
+	SourceReassign = "REASSIGN"
+	// SourceAuthorChange marks a reviewer replaced because they became the author.
+	SourceAuthorChange = "AUTHOR_CHANGE"
+	// SourceTeamTransfer marks reviewers swapped when a pull request is transferred to another team.
+	SourceTeamTransfer = "TEAM_TRANSFER"
+	// SourceDeactivation marks deactivated reviewers replaced or removed.
+	SourceDeactivation = "DEACTIVATION"
+	// SourceRebalance marks reviewers moved by the rebalancing job.
+	SourceRebalance = "REBALANCE"
+	// SourceReconcile marks inconsistent reviewers removed by reconciliation.
+	SourceReconcile = "RECONCILE"
 )
 
 // MaxReviewersPerPR is the maximum number of reviewers allowed per pull request.
 const MaxReviewersPerPR = 2
 
+// ReviewWeightLinesPerUnit is the number of changed lines that adds one unit of review weight.
+const ReviewWeightLinesPerUnit = 100
+
+// MaxLines is the maximum number of added or removed lines of a pull request. It keeps line counts far
+// below the range of the INTEGER columns storing them.
+const MaxLines = 10000000
+
+// MaxPullRequestURLLength is the maximum length of an external pull request URL.
+const MaxPullRequestURLLength = 2048
+
+// Weights of reviewer suggestion signals: familiarity with the author's changes counts most, the current
+// review load counts against a candidate.
+const (
+	// SuggestionAuthorWeight is added per recent review of a pull request by the same author.
+	SuggestionAuthorWeight = 2
+	// SuggestionBranchWeight is added per recent review of a pull request into the same target branch.
+	SuggestionBranchWeight = 1
+	// SuggestionLoadWeight is subtracted per unit of current review load.
+	SuggestionLoadWeight = 2
+)
+
+// SuggestionHistoryWindow is how far back reviews count towards reviewer suggestions.
+const SuggestionHistoryWindow = 90 * 24 * time.Hour
+
 // ValidateStatus validates that the status is one of the allowed values.
 func ValidateStatus(status string) error {
-	if status != StatusOPEN && status != StatusMERGED {
-		return errors.New("invalid status: must be OPEN or MERGED")
+	if status != StatusOPEN && status != StatusMERGED && status != StatusASSIGNING && status != StatusCLOSED {
+		return ErrInvalidStatus
 	}
 	return nil
 }
 
+// ValidateCloseReason validates that the value is one of the close reasons.
+func ValidateCloseReason(reason string) error {
+	switch reason {
+	case CloseReasonAbandoned, CloseReasonSuperseded, CloseReasonDuplicate, CloseReasonRejected,
+		CloseReasonStale, CloseReasonOther:
+		return nil
+	}
+	return ErrInvalidCloseReason
+}
+
+// ValidatePullRequestURL validates that the value is an absolute http(s) URL.
+func ValidatePullRequestURL(raw string) error {
+	if len(raw) > MaxPullRequestURLLength {
+		return ErrInvalidPullRequestURL
+	}
+	u, err := url.ParseRequestURI(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidPullRequestURL
+	}
+	return nil
+}
+
+// NormalizePullRequestName returns a canonical form of a PR name used for duplicate detection:
+// case-insensitive with surrounding and repeated whitespace collapsed.
+func NormalizePullRequestName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// ReviewWeight returns the review weight of a change: one unit per pull request
+// plus one unit per ReviewWeightLinesPerUnit changed lines.
+func ReviewWeight(linesAdded, linesRemoved int) int {
+	return 1 + (linesAdded+linesRemoved)/ReviewWeightLinesPerUnit
+}
+
 // PullRequest represents a pull request entity in the system.
 // Matches the pull_requests table schema.
 type PullRequest struct {
@@ -33,6 +155,20 @@ type PullRequest struct {
 	Status          string     `gorm:"column:status;type:pr_status_enum;not null;index:idx_pull_requests_status"     json:"status"`
 	CreatedAt       time.Time  `gorm:"column:created_at;type:timestamptz;not null;default:now()"                     json:"createdAt"`
 	MergedAt        *time.Time `gorm:"column:merged_at;type:timestamptz"                                             json:"mergedAt,omitempty"`
+	ArchivedAt      *time.Time `gorm:"column:archived_at;type:timestamptz"                                           json:"archivedAt,omitempty"`
+	SourceBranch    *string    `gorm:"column:source_branch;type:varchar(255)"                                        json:"source_branch,omitempty"`
+	TargetBranch    *string    `gorm:"column:target_branch;type:varchar(255);index:idx_pull_requests_target_branch"  json:"target_branch,omitempty"`
+	LinesAdded      int        `gorm:"column:lines_added;type:integer;not null;default:0"                            json:"lines_added"`
+	LinesRemoved    int        `gorm:"column:lines_removed;type:integer;not null;default:0"                          json:"lines_removed"`
+	HasConflicts    bool       `gorm:"column:has_conflicts;type:boolean;not null;default:false"                      json:"has_conflicts"`
+	PullRequestURL  *string    `gorm:"column:pull_request_url;type:varchar(2048)"                                    json:"pull_request_url,omitempty"`
+	TenantID        string     `gorm:"column:tenant_id;type:varchar(255);not null;default:'default'"                 json:"-"`
+	// TeamName is the team owning the pull request: the author's team at creation, changed by a
+	// team transfer. Nil for rows created before the column existed; the author's team applies then.
+	TeamName *string `gorm:"column:team_name;type:varchar(255);index:idx_pull_requests_team_name" json:"team_name,omitempty"`
+	// CloseReason and CloseNote explain why a CLOSED pull request was closed; nil for other statuses.
+	CloseReason *string `gorm:"column:close_reason;type:varchar(32)" json:"close_reason,omitempty"`
+	CloseNote   *string `gorm:"column:close_note;type:text"          json:"close_note,omitempty"`
 }
 
 // TableName specifies the table name for GORM.
@@ -53,3 +189,126 @@ type PullRequestReviewer struct {
 func (PullRequestReviewer) TableName() string {
 	return "pull_request_reviewers"
 }
+
+// PullRequestWatcher represents a user subscribed to a pull request's lifecycle events.
+// Matches the pull_request_watchers table schema.
+type PullRequestWatcher struct {
+	ID            int64     `gorm:"primaryKey;column:id;type:bigserial"                                               json:"id"`
+	PullRequestID string    `gorm:"column:pull_request_id;type:varchar(255);not null;uniqueIndex:uq_watchers_pr_user" json:"pull_request_id"`
+	UserID        string    `gorm:"column:user_id;type:varchar(255);not null;uniqueIndex:uq_watchers_pr_user"         json:"user_id"`
+	CreatedAt     time.Time `gorm:"column:created_at;type:timestamptz;not null;default:now()"                         json:"created_at"`
+}
+
+// TableName specifies the table name for GORM.
+func (PullRequestWatcher) TableName() string {
+	return "pull_request_watchers"
+}
+
+// PullRequestEvent represents an entry in the activity log of a pull request.
+// Matches the pull_request_events table schema.
+type PullRequestEvent struct {
+	ID             int64     `gorm:"primaryKey;column:id;type:bigserial"                                                json:"id"`
+	PullRequestID  string    `gorm:"column:pull_request_id;type:varchar(255);not null;index:idx_events_pull_request_id" json:"pull_request_id"`
+	EventType      string    `gorm:"column:event_type;type:varchar(32);not null"                                        json:"event_type"`
+	UserID         *string   `gorm:"column:user_id;type:varchar(255)"                                                   json:"user_id,omitempty"`
+	PreviousUserID *string   `gorm:"column:previous_user_id;type:varchar(255)"                                          json:"previous_user_id,omitempty"`
+	ActorID        *string   `gorm:"column:actor_id;type:varchar(255)"                                                  json:"actor_id,omitempty"`
+	Reason         *string   `gorm:"column:reason;type:text"                                                            json:"reason,omitempty"`
+	Source         *string   `gorm:"column:source;type:varchar(32)"                                                     json:"source,omitempty"`
+	CreatedAt      time.Time `gorm:"column:created_at;type:timestamptz;not null;default:now()"                          json:"created_at"`
+}
+
+// NewPullRequestEvent builds an activity event; empty user IDs are stored as NULL.
+func NewPullRequestEvent(prID, eventType, userID, previousUserID string) *PullRequestEvent {
+	event := &PullRequestEvent{
+		PullRequestID: prID,
+		EventType:     eventType,
+	}
+	if userID != "" {
+		event.UserID = &userID
+	}
+	if previousUserID != "" {
+		event.PreviousUserID = &previousUserID
+	}
+	return event
+}
+
+// NewReviewerEvent builds a reviewer event recorded through source (one of the Source constants).
+func NewReviewerEvent(prID, eventType, source, userID, previousUserID string) *PullRequestEvent {
+	event := NewPullRequestEvent(prID, eventType, userID, previousUserID)
+	event.Source = &source
+	return event
+}
+
+// TableName specifies the table name for GORM.
+func (PullRequestEvent) TableName() string {
+	return "pull_request_events"
+}
+
+// SLA violation kinds.
+const (
+	// SLAFirstReview is violated by an open pull request left without reviewers longer than its team allows.
+	SLAFirstReview = "FIRST_REVIEW"
+	// SLAMerge is violated by a pull request left unmerged longer than its team allows.
+	SLAMerge = "MERGE"
+)
+
+// SLAViolation represents a pull request that exceeded a review SLA of its author's team.
+// Matches the sla_violations table schema.
+type SLAViolation struct {
+	ID             int64     `gorm:"primaryKey;column:id;type:bigserial"                                                     json:"id"`
+	PullRequestID  string    `gorm:"column:pull_request_id;type:varchar(255);not null;uniqueIndex:uq_sla_violations_pr_kind" json:"pull_request_id"`
+	TeamName       string    `gorm:"column:team_name;type:varchar(255);not null"                                             json:"team_name"`
+	Kind           string    `gorm:"column:kind;type:varchar(32);not null;uniqueIndex:uq_sla_violations_pr_kind"             json:"kind"`
+	ThresholdHours int       `gorm:"column:threshold_hours;type:integer;not null"                                            json:"threshold_hours"`
+	DetectedAt     time.Time `gorm:"column:detected_at;type:timestamptz;not null;default:now()"                              json:"detected_at"`
+}
+
+// TableName specifies the table name for GORM.
+func (SLAViolation) TableName() string {
+	return "sla_violations"
+}
+
+// SLACandidate describes an open pull request whose author's team has a review SLA.
+type SLACandidate struct {
+	PullRequestID string    `gorm:"column:pull_request_id"`
+	CreatedAt     time.Time `gorm:"column:created_at"`
+	HasReviewers  bool      `gorm:"column:has_reviewers"`
+	TeamName      string    `gorm:"column:team_name"`
+	LeadUserID    *string   `gorm:"column:lead_user_id"`
+	// FirstReviewHours and MergeHours are the SLA of the team; nil when the check is disabled.
+	FirstReviewHours *int `gorm:"column:sla_first_review_hours"`
+	MergeHours       *int `gorm:"column:sla_merge_hours"`
+}
+
+// StaleCandidate describes an open pull request without activity since the stale cutoff whose
+// team has not opted out of closing inactive pull requests.
+type StaleCandidate struct {
+	PullRequestID string    `gorm:"column:pull_request_id"`
+	AuthorID      string    `gorm:"column:author_id"`
+	TenantID      string    `gorm:"column:tenant_id"`
+	CreatedAt     time.Time `gorm:"column:created_at"`
+	TeamName      *string   `gorm:"column:team_name"`
+	LeadUserID    *string   `gorm:"column:lead_user_id"`
+}
+
+// ReviewAssignment describes a reviewer assigned to an open pull request.
+type ReviewAssignment struct {
+	PullRequestID string `gorm:"column:pull_request_id"`
+	AuthorID      string `gorm:"column:author_id"`
+	ReviewerID    string `gorm:"column:reviewer_id"`
+	TeamName      string `gorm:"column:team_name"`
+}
+
+// ReviewHistory counts the recent reviews of a user relevant to a pull request.
+type ReviewHistory struct {
+	AuthorReviews int `gorm:"column:author_reviews"`
+	BranchReviews int `gorm:"column:branch_reviews"`
+}
+
+// SuggestionScore combines the review history and the current review load of a candidate into a
+// rank: the higher, the better suited.
+func SuggestionScore(history ReviewHistory, load int) int {
+	return SuggestionAuthorWeight*history.AuthorReviews + SuggestionBranchWeight*history.BranchReviews -
+		SuggestionLoadWeight*load
+}
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -256,7 +257,18 @@ func setupTestDB(t *testing.T) *gorm.DB {
 			author_id VARCHAR(255) NOT NULL,
 			status VARCHAR(10) NOT NULL,
 			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
-			merged_at TIMESTAMP
+			merged_at TIMESTAMP,
+			archived_at TIMESTAMP,
+			source_branch VARCHAR(255),
+			target_branch VARCHAR(255),
+			pull_request_url VARCHAR(2048),
+			lines_added INTEGER NOT NULL DEFAULT 0,
+			lines_removed INTEGER NOT NULL DEFAULT 0,
+			has_conflicts BOOLEAN NOT NULL DEFAULT FALSE,
+			close_reason VARCHAR(32),
+			close_note TEXT,
+			tenant_id VARCHAR(255) NOT NULL DEFAULT 'default',
+			team_name VARCHAR(255)
 		)
 	`).Error
 	require.NoError(t, err)
@@ -313,6 +325,16 @@ func TestValidateStatus(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("valid ASSIGNING status", func(t *testing.T) {
+		err := ValidateStatus(StatusASSIGNING)
+		assert.NoError(t, err)
+	})
+
+	t.Run("valid CLOSED status", func(t *testing.T) {
+		err := ValidateStatus(StatusCLOSED)
+		assert.NoError(t, err)
+	})
+
 	t.Run("invalid status - empty string", func(t *testing.T) {
 		err := ValidateStatus("")
 		assert.Error(t, err)
@@ -349,3 +371,63 @@ func TestValidateStatus(t *testing.T) {
 		assert.Contains(t, err.Error(), "invalid status")
 	})
 }
+
+func TestValidatePullRequestURL(t *testing.T) {
+	valid := []string{
+		"https://github.com/org/repo/pull/42",
+		"http://gitlab.local/group/project/-/merge_requests/7",
+	}
+	for _, raw := range valid {
+		t.Run("valid "+raw, func(t *testing.T) {
+			assert.NoError(t, ValidatePullRequestURL(raw))
+		})
+	}
+
+	invalid := []string{
+		"",
+		"github.com/org/repo/pull/42",
+		"/org/repo/pull/42",
+		"ftp://example.com/pr/1",
+		"https://",
+		"https://example.com/" + strings.Repeat("a", MaxPullRequestURLLength),
+	}
+	for _, raw := range invalid {
+		t.Run("invalid", func(t *testing.T) {
+			assert.ErrorIs(t, ValidatePullRequestURL(raw), ErrInvalidPullRequestURL)
+		})
+	}
+}
+
+func TestReviewWeight(t *testing.T) {
+	assert.Equal(t, 1, ReviewWeight(0, 0))
+	assert.Equal(t, 1, ReviewWeight(60, 39))
+	assert.Equal(t, 2, ReviewWeight(60, 40))
+	assert.Equal(t, 11, ReviewWeight(1000, 50))
+}
+
+func TestSuggestionScore(t *testing.T) {
+	assert.Equal(t, 0, SuggestionScore(ReviewHistory{}, 0))
+	assert.Equal(t, 5, SuggestionScore(ReviewHistory{AuthorReviews: 2, BranchReviews: 1}, 0))
+	assert.Equal(t, 1, SuggestionScore(ReviewHistory{AuthorReviews: 2, BranchReviews: 1}, 2))
+	assert.Equal(t, -4, SuggestionScore(ReviewHistory{}, 2))
+}
+
+func TestNormalizePullRequestName(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"already normalized", "add feature", "add feature"},
+		{"case", "Add Feature", "add feature"},
+		{"surrounding whitespace", "  Add feature\t", "add feature"},
+		{"repeated whitespace", "Add   \n feature", "add feature"},
+		{"empty", "   ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NormalizePullRequestName(tt.input))
+		})
+	}
+}
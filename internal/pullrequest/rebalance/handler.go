@@ -0,0 +1,62 @@
+package rebalance
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/festy23/avito_internship/pkg/apierror"
+)
+
+// errorRegistry maps rebalancing errors to API errors.
+var errorRegistry = apierror.Registry{}.
+	Register(ErrTeamNotFound, apierror.NotFound("team not found")).
+	Register(ErrInProgress, apierror.Conflict(apierror.CodeConcurrentUpdate, "").WithRetryAfter(10*time.Second))
+
+// Handler exposes rebalancing on demand over HTTP.
+type Handler struct {
+	job *Job
+}
+
+// NewHandler creates a new rebalancing handler instance.
+func NewHandler(job *Job) *Handler {
+	return &Handler{job: job}
+}
+
+// ErrorResponse represents error response structure.
+type ErrorResponse = apierror.Response
+
+// Rebalance handles POST /admin/rebalance request.
+// @Summary Rebalance reviewer load, by default only proposing the moves
+// @Tags Admin
+// @Produce json
+// @Param team_name query string false "Rebalance only this team (default all teams)"
+// @Param dry_run query bool false "Only report the proposed moves (default true)"
+// @Success 200 {object} Report
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/rebalance [post] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) Rebalance(c *gin.Context) {
+	// Moves change review assignments of real people, so applying them must be asked for explicitly
+	dryRun := true
+	if rawDryRun := c.Query("dry_run"); rawDryRun != "" {
+		parsed, err := strconv.ParseBool(rawDryRun)
+		if err != nil {
+			apierror.Fail(c, apierror.InvalidField("dry_run", "type", "dry_run must be a boolean"))
+			return
+		}
+		dryRun = parsed
+	}
+
+	report, err := h.job.Rebalance(c.Request.Context(), c.Query("team_name"), dryRun)
+	if err != nil {
+		errorRegistry.Fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
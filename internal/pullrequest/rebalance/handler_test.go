@@ -0,0 +1,94 @@
+package rebalance
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/festy23/avito_internship/internal/config"
+	"github.com/festy23/avito_internship/internal/testutil"
+	"github.com/festy23/avito_internship/pkg/lock"
+)
+
+func setupRouter(t *testing.T) (*gin.Engine, *gorm.DB) {
+	gin.SetMode(gin.TestMode)
+	db := testutil.NewDB(t)
+	seedOverloadedTeam(t, db, 5)
+	r := gin.New()
+	r.POST("/admin/rebalance", NewHandler(newJob(db, config.RebalanceConfig{Threshold: 3})).Rebalance)
+	return r, db
+}
+
+func serve(r *gin.Engine, target string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, target, nil))
+	return w
+}
+
+func countReviews(db *gorm.DB, userID string) int64 {
+	var count int64
+	db.Table("pull_request_reviewers").Where("user_id = ?", userID).Count(&count)
+	return count
+}
+
+func TestHandler_Rebalance(t *testing.T) {
+	t.Run("dry run by default", func(t *testing.T) {
+		r, db := setupRouter(t)
+
+		w := serve(r, "/admin/rebalance?team_name=backend")
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), `"dry_run":true`)
+		assert.Contains(t, w.Body.String(),
+			`{"pull_request_id":"pr-5","team_name":"backend","from_user_id":"u2","to_user_id":"u3","applied":false}`)
+		assert.Equal(t, int64(5), countReviews(db, "u2"))
+	})
+
+	t.Run("applies moves", func(t *testing.T) {
+		r, db := setupRouter(t)
+
+		w := serve(r, "/admin/rebalance?dry_run=false")
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), `"dry_run":false`)
+		assert.NotContains(t, w.Body.String(), `"applied":false`)
+		assert.Equal(t, int64(3), countReviews(db, "u2"))
+	})
+
+	t.Run("invalid dry_run", func(t *testing.T) {
+		r, _ := setupRouter(t)
+
+		w := serve(r, "/admin/rebalance?dry_run=maybe")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `"field":"dry_run"`)
+	})
+
+	t.Run("unknown team", func(t *testing.T) {
+		r, _ := setupRouter(t)
+
+		w := serve(r, "/admin/rebalance?team_name=missing")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("in progress", func(t *testing.T) {
+		r, db := setupRouter(t)
+		unlock, acquired, err := lock.New(db).TryLock(context.Background(), lockName)
+		require.NoError(t, err)
+		require.True(t, acquired)
+		defer func() { _ = unlock() }()
+
+		w := serve(r, "/admin/rebalance")
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, "10", w.Header().Get("Retry-After"))
+		assert.Contains(t, w.Body.String(), "rebalancing is already in progress")
+	})
+}
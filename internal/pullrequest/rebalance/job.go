@@ -0,0 +1,348 @@
+// Package rebalance provides the background job that evens out extreme reviewer load within teams.
+package rebalance
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/festy23/avito_internship/internal/config"
+	"github.com/festy23/avito_internship/internal/notification"
+	pullrequestModel "github.com/festy23/avito_internship/internal/pullrequest/model"
+	"github.com/festy23/avito_internship/internal/pullrequest/repository"
+	userModel "github.com/festy23/avito_internship/internal/user/model"
+	"github.com/festy23/avito_internship/pkg/lock"
+)
+
+// lockName is the name of the lock that lets a single replica rebalance at a time.
+const lockName = "job:rebalance"
+
+var (
+	// ErrInProgress is returned by Rebalance while another pass holds the rebalancing lock.
+	ErrInProgress = errors.New("rebalancing is already in progress")
+	// ErrTeamNotFound is returned by Rebalance for a team that does not exist.
+	ErrTeamNotFound = errors.New("team not found")
+)
+
+// Move is a reassignment of one open review from an overloaded reviewer to an idle teammate.
+type Move struct {
+	PullRequestID string `json:"pull_request_id"`
+	TeamName      string `json:"team_name"`
+	FromUserID    string `json:"from_user_id"`
+	ToUserID      string `json:"to_user_id"`
+	// Applied is false in dry-run mode and for moves invalidated by concurrent changes.
+	Applied bool `json:"applied"`
+}
+
+// Report summarizes a single rebalancing pass.
+type Report struct {
+	DryRun bool   `json:"dry_run"`
+	Moves  []Move `json:"moves"`
+}
+
+// Job detects reviewers holding more than threshold open reviews while active teammates
+// have none, and proposes or applies reassignments to those teammates.
+type Job struct {
+	repo      repository.Repository
+	db        *gorm.DB
+	locker    *lock.Locker
+	threshold int
+	dryRun    bool
+	notifier  notification.Notifier
+	logger    *zap.SugaredLogger
+}
+
+// New creates a new rebalancing job instance.
+func New(
+	repo repository.Repository,
+	db *gorm.DB,
+	cfg config.RebalanceConfig,
+	notifier notification.Notifier,
+	logger *zap.SugaredLogger,
+) *Job {
+	return &Job{
+		repo:      repo,
+		db:        db,
+		locker:    lock.New(db),
+		threshold: cfg.Threshold,
+		dryRun:    cfg.DryRun,
+		notifier:  notifier,
+		logger:    logger,
+	}
+}
+
+// Run executes a single rebalancing pass; it is registered in the background job scheduler.
+func (j *Job) Run(ctx context.Context) error {
+	_, err := j.RunOnce(ctx)
+	return err
+}
+
+// RunOnce plans reassignments in all teams, applies them unless in dry-run mode and logs the report.
+// The pass is skipped with an empty report while another replica is rebalancing.
+func (j *Job) RunOnce(ctx context.Context) (*Report, error) {
+	report, err := j.Rebalance(ctx, "", j.dryRun)
+	if errors.Is(err, ErrInProgress) {
+		j.logger.Infow("rebalance skipped, another replica is rebalancing")
+		return &Report{DryRun: j.dryRun, Moves: []Move{}}, nil
+	}
+	return report, err
+}
+
+// Rebalance plans reassignments in teamName (all teams if empty), applies them unless dryRun and
+// logs the report. Returns ErrInProgress while another pass is running.
+func (j *Job) Rebalance(ctx context.Context, teamName string, dryRun bool) (*Report, error) {
+	if teamName != "" {
+		exists, err := j.repo.TeamExists(ctx, teamName)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, ErrTeamNotFound
+		}
+	}
+
+	unlock, acquired, err := j.locker.TryLock(ctx, lockName)
+	if err != nil {
+		return nil, err
+	}
+	if !acquired {
+		return nil, ErrInProgress
+	}
+	defer func() {
+		if unlockErr := unlock(); unlockErr != nil {
+			j.logger.Warnw("failed to release rebalance lock", "error", unlockErr)
+		}
+	}()
+
+	moves, err := j.plan(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{DryRun: dryRun, Moves: moves}
+	if !dryRun {
+		for i := range report.Moves {
+			applied, applyErr := j.apply(ctx, report.Moves[i])
+			if applyErr != nil {
+				return nil, applyErr
+			}
+			report.Moves[i].Applied = applied
+		}
+	}
+
+	for _, m := range report.Moves {
+		j.logger.Infow("rebalance move",
+			"dry_run", report.DryRun,
+			"team_name", m.TeamName,
+			"pull_request_id", m.PullRequestID,
+			"from_user_id", m.FromUserID,
+			"to_user_id", m.ToUserID,
+			"applied", m.Applied,
+		)
+	}
+	j.logger.Infow("rebalance report", "dry_run", report.DryRun, "move_count", len(report.Moves))
+
+	return report, nil
+}
+
+// Plan computes reassignments without changing any data.
+// Each idle teammate receives at most one review per pass; the newest reviews are moved first.
+func (j *Job) Plan(ctx context.Context) ([]Move, error) {
+	return j.plan(ctx, "")
+}
+
+// plan computes reassignments in teamName, or in all teams if it is empty.
+func (j *Job) plan(ctx context.Context, teamName string) ([]Move, error) {
+	assignments, err := j.repo.GetOpenReviewAssignments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byTeam := make(map[string][]pullrequestModel.ReviewAssignment)
+	teams := make([]string, 0)
+	for _, a := range assignments {
+		if teamName != "" && a.TeamName != teamName {
+			continue
+		}
+		if _, ok := byTeam[a.TeamName]; !ok {
+			teams = append(teams, a.TeamName)
+		}
+		byTeam[a.TeamName] = append(byTeam[a.TeamName], a)
+	}
+	sort.Strings(teams)
+
+	moves := make([]Move, 0)
+	for _, team := range teams {
+		members, membersErr := j.repo.GetActiveTeamMembers(ctx, team, "")
+		if membersErr != nil {
+			return nil, membersErr
+		}
+		moves = append(moves, j.planTeam(team, byTeam[team], userIDs(members))...)
+	}
+
+	return moves, nil
+}
+
+// planTeam computes reassignments within a single team.
+func (j *Job) planTeam(
+	team string,
+	assignments []pullrequestModel.ReviewAssignment,
+	activeMembers []string,
+) []Move {
+	reviews := make(map[string][]pullrequestModel.ReviewAssignment)
+	reviewers := make(map[string]map[string]bool)
+	for _, a := range assignments {
+		reviews[a.ReviewerID] = append(reviews[a.ReviewerID], a)
+		if reviewers[a.PullRequestID] == nil {
+			reviewers[a.PullRequestID] = make(map[string]bool)
+		}
+		reviewers[a.PullRequestID][a.ReviewerID] = true
+	}
+
+	idle := make([]string, 0)
+	for _, id := range activeMembers {
+		if len(reviews[id]) == 0 {
+			idle = append(idle, id)
+		}
+	}
+
+	overloaded := make([]string, 0)
+	for id, list := range reviews {
+		if len(list) > j.threshold {
+			overloaded = append(overloaded, id)
+		}
+	}
+	sort.Slice(overloaded, func(a, b int) bool {
+		la, lb := len(reviews[overloaded[a]]), len(reviews[overloaded[b]])
+		if la != lb {
+			return la > lb
+		}
+		return overloaded[a] < overloaded[b]
+	})
+
+	moves := make([]Move, 0)
+	for _, from := range overloaded {
+		count := len(reviews[from])
+		list := reviews[from]
+		for i := len(list) - 1; i >= 0 && count > j.threshold && len(idle) > 0; i-- {
+			a := list[i]
+			for k, to := range idle {
+				if to == a.AuthorID || reviewers[a.PullRequestID][to] {
+					continue
+				}
+				moves = append(moves, Move{
+					PullRequestID: a.PullRequestID,
+					TeamName:      team,
+					FromUserID:    from,
+					ToUserID:      to,
+				})
+				reviewers[a.PullRequestID][to] = true
+				delete(reviewers[a.PullRequestID], from)
+				idle = append(idle[:k], idle[k+1:]...)
+				count--
+				break
+			}
+		}
+	}
+
+	return moves
+}
+
+// apply performs a planned move in a transaction. Returns false when the move is no longer valid.
+func (j *Job) apply(ctx context.Context, m Move) (bool, error) {
+	var (
+		applied     bool
+		reviewerIDs []string
+		watcherIDs  []string
+	)
+	err := j.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txRepo := repository.New(tx, j.logger)
+
+		pr, txErr := txRepo.GetByID(ctx, m.PullRequestID)
+		if txErr != nil {
+			return txErr
+		}
+		if pr.Status != pullrequestModel.StatusOPEN {
+			return nil
+		}
+
+		current, getErr := txRepo.GetReviewers(ctx, m.PullRequestID)
+		if getErr != nil {
+			return getErr
+		}
+		if !contains(current, m.FromUserID) || contains(current, m.ToUserID) {
+			return nil
+		}
+
+		if removeErr := txRepo.RemoveReviewer(ctx, m.PullRequestID, m.FromUserID); removeErr != nil {
+			return removeErr
+		}
+		if assignErr := txRepo.AssignReviewer(ctx, m.PullRequestID, m.ToUserID); assignErr != nil {
+			return assignErr
+		}
+		event := pullrequestModel.NewReviewerEvent(
+			m.PullRequestID, pullrequestModel.EventReviewerReplaced, pullrequestModel.SourceRebalance,
+			m.ToUserID, m.FromUserID,
+		)
+		if eventErr := txRepo.AddEvent(ctx, event); eventErr != nil {
+			return eventErr
+		}
+
+		var err error
+		if reviewerIDs, err = txRepo.GetReviewers(ctx, m.PullRequestID); err != nil {
+			return err
+		}
+		if watcherIDs, err = txRepo.GetWatchers(ctx, m.PullRequestID); err != nil {
+			return err
+		}
+		applied = true
+		return nil
+	})
+	if err != nil {
+		j.logger.Errorw("rebalance move failed", "pull_request_id", m.PullRequestID, "error", err)
+		return false, err
+	}
+
+	if !applied {
+		j.logger.Warnw("rebalance move skipped, pull request changed", "pull_request_id", m.PullRequestID)
+		return false, nil
+	}
+
+	n := notification.Notification{
+		Event:         notification.EventReviewerReassigned,
+		PullRequestID: m.PullRequestID,
+		Recipients:    notification.Recipients([]string{m.FromUserID}, reviewerIDs, watcherIDs),
+		Details: map[string]string{
+			"old_user_id": m.FromUserID,
+			"new_user_id": m.ToUserID,
+			"reason":      "rebalance",
+		},
+	}
+	if notifyErr := j.notifier.Notify(ctx, n); notifyErr != nil {
+		j.logger.Warnw("failed to send notification", "pull_request_id", m.PullRequestID, "error", notifyErr)
+	}
+
+	return true, nil
+}
+
+// contains checks if a user ID is in the list.
+func contains(ids []string, id string) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+// userIDs extracts user IDs from a list of users.
+func userIDs(users []userModel.User) []string {
+	ids := make([]string, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.UserID)
+	}
+	return ids
+}
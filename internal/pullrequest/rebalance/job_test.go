@@ -0,0 +1,246 @@
+package rebalance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/festy23/avito_internship/internal/config"
+	"github.com/festy23/avito_internship/internal/notification"
+	pullrequestModel "github.com/festy23/avito_internship/internal/pullrequest/model"
+	"github.com/festy23/avito_internship/internal/pullrequest/repository"
+	"github.com/festy23/avito_internship/internal/testutil"
+	"github.com/festy23/avito_internship/pkg/lock"
+	"github.com/festy23/avito_internship/pkg/sortparam"
+)
+
+// seedOverloadedTeam creates team "backend" where u2 reviews prCount open PRs of u1 and u3, u4 review nothing.
+func seedOverloadedTeam(t *testing.T, db *gorm.DB, prCount int) {
+	testutil.NewTeam().WithMembers(5).Inactive(1).Create(t, db)
+	base := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	for i := 1; i <= prCount; i++ {
+		testutil.NewPR().
+			WithID(fmt.Sprintf("pr-%d", i)).
+			ByAuthor("u1").
+			WithReviewers("u2").
+			CreatedAt(base.Add(time.Duration(i)*time.Hour)).
+			Create(t, db)
+	}
+}
+
+func newJob(db *gorm.DB, cfg config.RebalanceConfig) *Job {
+	logger := zap.NewNop().Sugar()
+	return New(repository.New(db, logger), db, cfg, notification.NewNop(), logger)
+}
+
+func TestJob_Plan(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("moves newest reviews to idle active teammates", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		seedOverloadedTeam(t, db, 5)
+		job := newJob(db, config.RebalanceConfig{Threshold: 3, DryRun: true})
+
+		moves, err := job.Plan(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, []Move{
+			{PullRequestID: "pr-5", TeamName: "backend", FromUserID: "u2", ToUserID: "u3"},
+			{PullRequestID: "pr-4", TeamName: "backend", FromUserID: "u2", ToUserID: "u4"},
+		}, moves)
+	})
+
+	t.Run("each idle teammate receives at most one review", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		seedOverloadedTeam(t, db, 8)
+		job := newJob(db, config.RebalanceConfig{Threshold: 3, DryRun: true})
+
+		moves, err := job.Plan(ctx)
+
+		require.NoError(t, err)
+		assert.Len(t, moves, 2)
+	})
+
+	t.Run("no moves below threshold", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		seedOverloadedTeam(t, db, 3)
+		job := newJob(db, config.RebalanceConfig{Threshold: 3, DryRun: true})
+
+		moves, err := job.Plan(ctx)
+
+		require.NoError(t, err)
+		assert.Empty(t, moves)
+	})
+
+	t.Run("no moves without idle teammates", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		seedOverloadedTeam(t, db, 5)
+		db.Exec("UPDATE users SET is_active = ? WHERE user_id IN ?", false, []string{"u3", "u4"})
+		job := newJob(db, config.RebalanceConfig{Threshold: 3, DryRun: true})
+
+		moves, err := job.Plan(ctx)
+
+		require.NoError(t, err)
+		assert.Empty(t, moves)
+	})
+
+	t.Run("author is never proposed as reviewer", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		seedOverloadedTeam(t, db, 5)
+		db.Exec("UPDATE pull_requests SET author_id = ? WHERE pull_request_id = ?", "u3", "pr-5")
+		job := newJob(db, config.RebalanceConfig{Threshold: 3, DryRun: true})
+
+		moves, err := job.Plan(ctx)
+
+		require.NoError(t, err)
+		require.Len(t, moves, 2)
+		assert.Equal(t, Move{PullRequestID: "pr-5", TeamName: "backend", FromUserID: "u2", ToUserID: "u1"}, moves[0])
+		assert.Equal(t, Move{PullRequestID: "pr-4", TeamName: "backend", FromUserID: "u2", ToUserID: "u3"}, moves[1])
+	})
+}
+
+func TestJob_Rebalance(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("applies moves of the given team despite dry-run config", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		seedOverloadedTeam(t, db, 5)
+		job := newJob(db, config.RebalanceConfig{Threshold: 3, DryRun: true})
+
+		report, err := job.Rebalance(ctx, "backend", false)
+
+		require.NoError(t, err)
+		assert.False(t, report.DryRun)
+		require.Len(t, report.Moves, 2)
+		assert.True(t, report.Moves[0].Applied)
+	})
+
+	t.Run("other teams are not rebalanced", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		seedOverloadedTeam(t, db, 5)
+		testutil.NewTeam().Named("frontend").WithMemberPrefix("f").WithMembers(2).Create(t, db)
+		job := newJob(db, config.RebalanceConfig{Threshold: 3})
+
+		report, err := job.Rebalance(ctx, "frontend", false)
+
+		require.NoError(t, err)
+		assert.Empty(t, report.Moves)
+	})
+
+	t.Run("unknown team", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		job := newJob(db, config.RebalanceConfig{Threshold: 3})
+
+		_, err := job.Rebalance(ctx, "missing", true)
+
+		assert.ErrorIs(t, err, ErrTeamNotFound)
+	})
+
+	t.Run("in progress", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		job := newJob(db, config.RebalanceConfig{Threshold: 3})
+		unlock, acquired, err := lock.New(db).TryLock(ctx, lockName)
+		require.NoError(t, err)
+		require.True(t, acquired)
+		defer func() { _ = unlock() }()
+
+		_, err = job.Rebalance(ctx, "", true)
+
+		assert.ErrorIs(t, err, ErrInProgress)
+	})
+}
+
+func TestJob_RunOnce(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("dry run does not change assignments", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		seedOverloadedTeam(t, db, 5)
+		job := newJob(db, config.RebalanceConfig{Threshold: 3, DryRun: true})
+
+		report, err := job.RunOnce(ctx)
+
+		require.NoError(t, err)
+		assert.True(t, report.DryRun)
+		require.Len(t, report.Moves, 2)
+		assert.False(t, report.Moves[0].Applied)
+
+		var count int64
+		db.Table("pull_request_reviewers").Where("user_id = ?", "u2").Count(&count)
+		assert.Equal(t, int64(5), count)
+	})
+
+	t.Run("applies moves and records activity", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		seedOverloadedTeam(t, db, 5)
+		job := newJob(db, config.RebalanceConfig{Threshold: 3})
+
+		report, err := job.RunOnce(ctx)
+
+		require.NoError(t, err)
+		assert.False(t, report.DryRun)
+		require.Len(t, report.Moves, 2)
+		assert.True(t, report.Moves[0].Applied)
+		assert.True(t, report.Moves[1].Applied)
+
+		repo := repository.New(db, zap.NewNop().Sugar())
+		reviewers, err := repo.GetReviewers(ctx, "pr-5")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u3"}, reviewers)
+
+		events, err := repo.GetEvents(ctx, "pr-5", sortparam.Sort{})
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, pullrequestModel.EventReviewerReplaced, events[0].EventType)
+		assert.Equal(t, "u2", *events[0].PreviousUserID)
+		require.NotNil(t, events[0].Source)
+		assert.Equal(t, pullrequestModel.SourceRebalance, *events[0].Source)
+
+		// Second pass finds nothing to move
+		report, err = job.RunOnce(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, report.Moves)
+	})
+
+	t.Run("skipped while another replica is rebalancing", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		seedOverloadedTeam(t, db, 5)
+		job := newJob(db, config.RebalanceConfig{Threshold: 3})
+
+		unlock, acquired, err := lock.New(db).TryLock(ctx, lockName)
+		require.NoError(t, err)
+		require.True(t, acquired)
+		defer func() { _ = unlock() }()
+
+		report, err := job.RunOnce(ctx)
+
+		require.NoError(t, err)
+		assert.Empty(t, report.Moves)
+
+		var count int64
+		db.Table("pull_request_reviewers").Where("user_id = ?", "u2").Count(&count)
+		assert.Equal(t, int64(5), count)
+	})
+
+	t.Run("skips moves invalidated by concurrent changes", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		seedOverloadedTeam(t, db, 5)
+		job := newJob(db, config.RebalanceConfig{Threshold: 3})
+
+		moves, err := job.Plan(ctx)
+		require.NoError(t, err)
+		db.Exec("UPDATE pull_requests SET status = ?, merged_at = ? WHERE pull_request_id = ?",
+			pullrequestModel.StatusMERGED, time.Now(), moves[0].PullRequestID)
+
+		applied, err := job.apply(ctx, moves[0])
+
+		require.NoError(t, err)
+		assert.False(t, applied)
+	})
+}
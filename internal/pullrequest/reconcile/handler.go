@@ -0,0 +1,52 @@
+package reconcile
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/festy23/avito_internship/pkg/apierror"
+)
+
+// Handler exposes the consistency check over HTTP.
+type Handler struct {
+	reconciler *Reconciler
+}
+
+// NewHandler creates a new consistency check handler instance.
+func NewHandler(reconciler *Reconciler) *Handler {
+	return &Handler{reconciler: reconciler}
+}
+
+// ErrorResponse represents error response structure.
+type ErrorResponse = apierror.Response
+
+// Check handles POST /admin/consistencyCheck request.
+// @Summary Check data consistency, optionally repairing the issues found
+// @Tags Admin
+// @Produce json
+// @Param fix query bool false "Repair the issues found (default false)"
+// @Success 200 {object} Report
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/consistencyCheck [post] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) Check(c *gin.Context) {
+	fix := false
+	if rawFix := c.Query("fix"); rawFix != "" {
+		parsed, err := strconv.ParseBool(rawFix)
+		if err != nil {
+			apierror.Fail(c, apierror.InvalidField("fix", "type", "fix must be a boolean"))
+			return
+		}
+		fix = parsed
+	}
+
+	report, err := h.reconciler.Check(c.Request.Context(), fix)
+	if err != nil {
+		apierror.Fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,67 @@
+package reconcile
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/festy23/avito_internship/internal/config"
+	"github.com/festy23/avito_internship/internal/pullrequest/repository"
+	"github.com/festy23/avito_internship/internal/testutil"
+)
+
+func setupRouter(t *testing.T) (*gin.Engine, *gorm.DB) {
+	gin.SetMode(gin.TestMode)
+	db := testutil.NewDB(t)
+	seedInconsistentData(t, db)
+	reconciler := New(repository.New(db, zap.NewNop().Sugar()), db, config.ReconcileConfig{}, zap.NewNop().Sugar())
+	r := gin.New()
+	r.POST("/admin/consistencyCheck", NewHandler(reconciler).Check)
+	return r, db
+}
+
+func TestHandler_Check(t *testing.T) {
+	t.Run("report only", func(t *testing.T) {
+		r, db := setupRouter(t)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/consistencyCheck", nil))
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), `"repair":false`)
+		assert.Contains(t, w.Body.String(),
+			`{"kind":"USER_WITHOUT_TEAM","user_id":"u9","team_name":"removed","tenant_id":"acme","repaired":false}`)
+
+		var count int64
+		db.Table("teams").Where("team_name = ?", "removed").Count(&count)
+		assert.Zero(t, count)
+	})
+
+	t.Run("fix", func(t *testing.T) {
+		r, db := setupRouter(t)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/consistencyCheck?fix=true", nil))
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), `"repair":true`)
+		assert.NotContains(t, w.Body.String(), `"repaired":false`)
+
+		var count int64
+		db.Table("teams").Where("team_name = ?", "removed").Count(&count)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("invalid fix", func(t *testing.T) {
+		r, _ := setupRouter(t)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/consistencyCheck?fix=maybe", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `"field":"fix"`)
+	})
+}
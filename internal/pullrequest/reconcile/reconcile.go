@@ -0,0 +1,189 @@
+// Package reconcile detects and optionally repairs inconsistent reviewer assignments and users
+// referencing missing teams.
+package reconcile
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/festy23/avito_internship/internal/config"
+	pullrequestModel "github.com/festy23/avito_internship/internal/pullrequest/model"
+	"github.com/festy23/avito_internship/internal/pullrequest/repository"
+)
+
+// IssueKind identifies a type of inconsistency.
+type IssueKind string
+
+// Detected inconsistencies.
+const (
+	// IssueOrphanedReviewer is a reviewer row referencing a missing PR or user.
+	IssueOrphanedReviewer IssueKind = "ORPHANED_REVIEWER"
+	// IssueAuthorReviewer is a PR assigned to its own author for review.
+	IssueAuthorReviewer IssueKind = "AUTHOR_REVIEWER"
+	// IssueExcessReviewer is a reviewer beyond MaxReviewersPerPR; the latest assignments are reported.
+	IssueExcessReviewer IssueKind = "EXCESS_REVIEWER"
+	// IssueUserWithoutTeam is a user whose team does not exist.
+	IssueUserWithoutTeam IssueKind = "USER_WITHOUT_TEAM"
+)
+
+// Issue is a single inconsistency. Repairing a reviewer issue removes the reviewer row; repairing
+// a USER_WITHOUT_TEAM issue creates the missing team, empty and without a lead, in the user's tenant.
+type Issue struct {
+	Kind          IssueKind `json:"kind"`
+	PullRequestID string    `json:"pull_request_id,omitempty"`
+	UserID        string    `json:"user_id"`
+	TeamName      string    `json:"team_name,omitempty"`
+	TenantID      string    `json:"tenant_id,omitempty"`
+	Repaired      bool      `json:"repaired"`
+}
+
+// Report summarizes a reconciliation pass.
+type Report struct {
+	Repair bool    `json:"repair"`
+	Issues []Issue `json:"issues"`
+}
+
+// Reconciler checks reviewer assignments and team membership for states that the service never produces itself,
+// such as leftovers of manual data fixes or interrupted migrations.
+type Reconciler struct {
+	repo   repository.Repository
+	db     *gorm.DB
+	repair bool
+	logger *zap.SugaredLogger
+}
+
+// New creates a new reconciler instance.
+func New(
+	repo repository.Repository,
+	db *gorm.DB,
+	cfg config.ReconcileConfig,
+	logger *zap.SugaredLogger,
+) *Reconciler {
+	return &Reconciler{
+		repo:   repo,
+		db:     db,
+		repair: cfg.Repair,
+		logger: logger,
+	}
+}
+
+// Run detects inconsistencies, repairs them unless in report-only mode and logs the report.
+func (r *Reconciler) Run(ctx context.Context) (*Report, error) {
+	return r.Check(ctx, r.repair)
+}
+
+// Check detects inconsistencies, repairs them if repair is set and logs the report.
+func (r *Reconciler) Check(ctx context.Context, repair bool) (*Report, error) {
+	issues, err := r.Detect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Repair: repair, Issues: issues}
+	if repair && len(issues) > 0 {
+		if repairErr := r.apply(ctx, report.Issues); repairErr != nil {
+			return nil, repairErr
+		}
+	}
+
+	for _, issue := range report.Issues {
+		r.logger.Warnw("reconciliation issue",
+			"kind", issue.Kind,
+			"pull_request_id", issue.PullRequestID,
+			"user_id", issue.UserID,
+			"team_name", issue.TeamName,
+			"repaired", issue.Repaired,
+		)
+	}
+	r.logger.Infow("reconciliation report", "repair", report.Repair, "issue_count", len(report.Issues))
+
+	return report, nil
+}
+
+// Detect finds inconsistent reviewer rows without changing any data.
+// A row matching several checks is reported once, under the first matching kind.
+func (r *Reconciler) Detect(ctx context.Context) ([]Issue, error) {
+	orphaned, err := r.repo.GetOrphanedReviewers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	authors, err := r.repo.GetAuthorReviewers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	excess, err := r.repo.GetExcessReviewers(ctx, pullrequestModel.MaxReviewersPerPR)
+	if err != nil {
+		return nil, err
+	}
+	users, err := r.repo.GetUsersWithoutTeam(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := make([]Issue, 0)
+	seen := make(map[int64]bool)
+	add := func(kind IssueKind, rows []pullrequestModel.PullRequestReviewer) {
+		for _, row := range rows {
+			if seen[row.ID] {
+				continue
+			}
+			seen[row.ID] = true
+			issues = append(issues, Issue{Kind: kind, PullRequestID: row.PullRequestID, UserID: row.UserID})
+		}
+	}
+	add(IssueOrphanedReviewer, orphaned)
+	add(IssueAuthorReviewer, authors)
+	add(IssueExcessReviewer, excess)
+	for _, user := range users {
+		issues = append(issues, Issue{
+			Kind:     IssueUserWithoutTeam,
+			UserID:   user.UserID,
+			TeamName: user.TeamName,
+			TenantID: user.TenantID,
+		})
+	}
+
+	return issues, nil
+}
+
+// apply repairs the issues in a single transaction. Removals from existing PRs are recorded in their
+// activity log.
+func (r *Reconciler) apply(ctx context.Context, issues []Issue) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txRepo := repository.New(tx, r.logger)
+
+		for _, issue := range issues {
+			if issue.Kind == IssueUserWithoutTeam {
+				// Members of one missing team share a single restored team
+				if err := txRepo.RestoreTeam(ctx, issue.TeamName, issue.TenantID); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := txRepo.RemoveReviewer(ctx, issue.PullRequestID, issue.UserID); err != nil {
+				return err
+			}
+			if issue.Kind != IssueOrphanedReviewer {
+				event := pullrequestModel.NewReviewerEvent(
+					issue.PullRequestID, pullrequestModel.EventReviewerRemoved, pullrequestModel.SourceReconcile,
+					issue.UserID, "",
+				)
+				if err := txRepo.AddEvent(ctx, event); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		r.logger.Errorw("reconciliation repair failed", "error", err)
+		return err
+	}
+
+	for i := range issues {
+		issues[i].Repaired = true
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/festy23/avito_internship/internal/config"
+	pullrequestModel "github.com/festy23/avito_internship/internal/pullrequest/model"
+	"github.com/festy23/avito_internship/internal/pullrequest/repository"
+	teamModel "github.com/festy23/avito_internship/internal/team/model"
+	"github.com/festy23/avito_internship/internal/testutil"
+	userModel "github.com/festy23/avito_internship/internal/user/model"
+	"github.com/festy23/avito_internship/pkg/sortparam"
+)
+
+// seedInconsistentData creates one issue of each kind next to a consistent PR.
+// Inconsistent rows are assigned directly, bypassing the service rules.
+func seedInconsistentData(t *testing.T, db *gorm.DB) {
+	testutil.NewTeam().WithMembers(4).Create(t, db)
+	testutil.NewPR().WithID("pr-ok").WithReviewers("u2", "u3").Create(t, db)
+	testutil.NewPR().WithID("pr-excess").WithReviewers("u2", "u3", "u4").Create(t, db)
+	testutil.NewPR().WithID("pr-author").WithReviewers("u1").Merged().Create(t, db)
+
+	repo := repository.New(db, zap.NewNop().Sugar())
+	require.NoError(t, repo.AssignReviewer(context.Background(), "pr-deleted", "u2"))
+	orphan := userModel.User{UserID: "u9", Username: "Ghost", TeamName: "removed", TenantID: "acme"}
+	require.NoError(t, db.Create(&orphan).Error)
+}
+
+func TestReconciler_Run(t *testing.T) {
+	ctx := context.Background()
+	expected := []Issue{
+		{Kind: IssueOrphanedReviewer, PullRequestID: "pr-deleted", UserID: "u2"},
+		{Kind: IssueAuthorReviewer, PullRequestID: "pr-author", UserID: "u1"},
+		{Kind: IssueExcessReviewer, PullRequestID: "pr-excess", UserID: "u4"},
+		{Kind: IssueUserWithoutTeam, UserID: "u9", TeamName: "removed", TenantID: "acme"},
+	}
+
+	t.Run("report only", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		seedInconsistentData(t, db)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		r := New(repo, db, config.ReconcileConfig{OnStartup: true}, zap.NewNop().Sugar())
+
+		report, err := r.Run(ctx)
+
+		require.NoError(t, err)
+		assert.False(t, report.Repair)
+		assert.Equal(t, expected, report.Issues)
+
+		var count int64
+		db.Table("pull_request_reviewers").Count(&count)
+		assert.Equal(t, int64(7), count)
+	})
+
+	t.Run("repair", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		seedInconsistentData(t, db)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		r := New(repo, db, config.ReconcileConfig{OnStartup: true, Repair: true}, zap.NewNop().Sugar())
+
+		report, err := r.Run(ctx)
+
+		require.NoError(t, err)
+		require.Len(t, report.Issues, 4)
+		for _, issue := range report.Issues {
+			assert.True(t, issue.Repaired)
+		}
+
+		reviewers, err := repo.GetReviewers(ctx, "pr-excess")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"u2", "u3"}, reviewers)
+
+		reviewers, err = repo.GetReviewers(ctx, "pr-author")
+		require.NoError(t, err)
+		assert.Empty(t, reviewers)
+
+		events, err := repo.GetEvents(ctx, "pr-excess", sortparam.Sort{})
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, pullrequestModel.EventReviewerRemoved, events[0].EventType)
+		require.NotNil(t, events[0].Source)
+		assert.Equal(t, pullrequestModel.SourceReconcile, *events[0].Source)
+
+		var team teamModel.Team
+		require.NoError(t, db.First(&team, "team_name = ?", "removed").Error)
+		assert.Equal(t, "acme", team.TenantID)
+
+		// A second pass finds nothing
+		report, err = r.Run(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, report.Issues)
+	})
+
+	t.Run("consistent data", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		r := New(repo, db, config.ReconcileConfig{OnStartup: true, Repair: true}, zap.NewNop().Sugar())
+
+		report, err := r.Run(ctx)
+
+		require.NoError(t, err)
+		assert.Empty(t, report.Issues)
+	})
+}
@@ -8,18 +8,25 @@ import (
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	"github.com/festy23/avito_internship/internal/database/dberror"
+	"github.com/festy23/avito_internship/internal/database/dialect"
 	pullrequestModel "github.com/festy23/avito_internship/internal/pullrequest/model"
+	"github.com/festy23/avito_internship/internal/tenant"
 	userModel "github.com/festy23/avito_internship/internal/user/model"
+	"github.com/festy23/avito_internship/pkg/clock"
+	"github.com/festy23/avito_internship/pkg/sortparam"
 )
 
 // Repository defines the interface for pullrequest data access operations.
+//
+// Queries of pull requests and users are restricted to the tenant of the context (see tenant.Scope).
+// Reviewer, watcher and event rows are accessed by the ID of a PR already resolved in the tenant.
+// The maintenance queries of background jobs (archive, cleanup, rebalance, reconcile) cover all tenants.
 type Repository interface {
-	// Create creates a new pull request.
-	Create(
-		ctx context.Context,
-		prID, prName, authorID string,
-	) (*pullrequestModel.PullRequest, error)
+	// Create creates a new OPEN (or ASSIGNING, if requested) pull request from the given entity.
+	Create(ctx context.Context, pr *pullrequestModel.PullRequest) (*pullrequestModel.PullRequest, error)
 
 	// GetByID finds pull request by pull_request_id.
 	GetByID(ctx context.Context, prID string) (*pullrequestModel.PullRequest, error)
@@ -27,6 +34,24 @@ type Repository interface {
 	// UpdateStatus updates pull request status and merged_at timestamp.
 	UpdateStatus(ctx context.Context, prID string, status string, mergedAt *time.Time) error
 
+	// TransitionStatus changes status from one value to another; returns false if the PR is not in from status.
+	TransitionStatus(ctx context.Context, prID, from, to string) (bool, error)
+
+	// GetIDsByStatus returns IDs of pull requests with the given status ordered by creation time.
+	GetIDsByStatus(ctx context.Context, status string) ([]string, error)
+
+	// SetHasConflicts updates the merge-conflict flag of a pull request.
+	SetHasConflicts(ctx context.Context, prID string, hasConflicts bool) error
+
+	// SetCloseReason stores why a pull request was closed; nil values clear them.
+	SetCloseReason(ctx context.Context, prID string, reason, note *string) error
+
+	// UpdateAuthor transfers authorship of a pull request.
+	UpdateAuthor(ctx context.Context, prID, authorID string) error
+
+	// UpdateTeam transfers a pull request to another team.
+	UpdateTeam(ctx context.Context, prID, teamName string) error
+
 	// AssignReviewer assigns a reviewer to a pull request.
 	AssignReviewer(ctx context.Context, prID, userID string) error
 
@@ -36,6 +61,19 @@ type Repository interface {
 	// GetReviewers returns list of user_id reviewers for a pull request.
 	GetReviewers(ctx context.Context, prID string) ([]string, error)
 
+	// AddWatcher subscribes a user to a pull request (idempotent).
+	AddWatcher(ctx context.Context, prID, userID string) error
+
+	// GetWatchers returns list of user_id watchers for a pull request.
+	GetWatchers(ctx context.Context, prID string) ([]string, error)
+
+	// AddEvent appends an entry to the activity log of a pull request.
+	AddEvent(ctx context.Context, event *pullrequestModel.PullRequestEvent) error
+
+	// GetEvents returns the activity log of a pull request ordered by srt (see pullrequestModel.EventSort).
+	// The zero srt means chronological order.
+	GetEvents(ctx context.Context, prID string, srt sortparam.Sort) ([]pullrequestModel.PullRequestEvent, error)
+
 	// GetActiveTeamMembers returns active team members excluding specified user.
 	GetActiveTeamMembers(
 		ctx context.Context,
@@ -46,78 +84,145 @@ type Repository interface {
 	// GetUserTeam returns team name for a user.
 	GetUserTeam(ctx context.Context, userID string) (string, error)
 
+	// GetUser finds a user by user_id.
+	GetUser(ctx context.Context, userID string) (*userModel.User, error)
+
+	// GetTeamLead returns the lead of a team, or an empty string if the team has no lead.
+	GetTeamLead(ctx context.Context, teamName string) (string, error)
+
+	// TeamExists reports whether a team exists.
+	TeamExists(ctx context.Context, teamName string) (bool, error)
+
 	// GetOpenPRsWithReviewers returns open PRs that have reviewers from the given user IDs.
 	GetOpenPRsWithReviewers(ctx context.Context, reviewerIDs []string) ([]string, error)
 
+	// GetOpenByAuthor returns open (including ASSIGNING) pull requests created by the given author.
+	GetOpenByAuthor(ctx context.Context, authorID string) ([]pullrequestModel.PullRequest, error)
+
+	// List returns pull requests matching the filters of query, newest first. A positive query.Limit
+	// bounds the number of rows; query.After starts the list after the given position.
+	List(ctx context.Context, query pullrequestModel.ListQuery) ([]pullrequestModel.PullRequest, error)
+
+	// GetReviewersByPullRequests returns the reviewers of each given pull request in assignment order.
+	// Pull requests without reviewers are absent from the map.
+	GetReviewersByPullRequests(ctx context.Context, prIDs []string) (map[string][]string, error)
+
 	// GetOpenPRsWithAuthors returns open PRs with their authors for given reviewer IDs.
 	GetOpenPRsWithAuthors(ctx context.Context, reviewerIDs []string) (map[string]string, error)
+
+	// ArchiveMergedBefore marks merged PRs with merged_at before cutoff as archived.
+	ArchiveMergedBefore(ctx context.Context, cutoff time.Time) (int64, error)
+
+	// DeleteEventsBefore deletes up to limit activity events created before cutoff.
+	DeleteEventsBefore(ctx context.Context, cutoff time.Time, limit int) (int64, error)
+
+	// GetReviewLoad returns the total review weight of open PRs assigned to each given user.
+	GetReviewLoad(ctx context.Context, userIDs []string) (map[string]int, error)
+
+	// GetReviewHistory counts reviews assigned to each given user since the given time: of PRs by
+	// authorID and of PRs into targetBranch.
+	GetReviewHistory(
+		ctx context.Context,
+		userIDs []string,
+		authorID, targetBranch string,
+		since time.Time,
+	) (map[string]pullrequestModel.ReviewHistory, error)
+
+	// GetOpenReviewAssignments returns reviewer assignments of all open PRs with reviewer teams.
+	GetOpenReviewAssignments(ctx context.Context) ([]pullrequestModel.ReviewAssignment, error)
+
+	// GetAuthorReviewers returns reviewer rows that assign a PR to its own author.
+	GetAuthorReviewers(ctx context.Context) ([]pullrequestModel.PullRequestReviewer, error)
+
+	// GetOrphanedReviewers returns reviewer rows referencing a missing PR or user.
+	GetOrphanedReviewers(ctx context.Context) ([]pullrequestModel.PullRequestReviewer, error)
+
+	// GetExcessReviewers returns reviewer rows beyond the first maxReviewers of each PR.
+	GetExcessReviewers(ctx context.Context, maxReviewers int) ([]pullrequestModel.PullRequestReviewer, error)
+
+	// GetUsersWithoutTeam returns users whose team does not exist.
+	GetUsersWithoutTeam(ctx context.Context) ([]userModel.User, error)
+
+	// RestoreTeam creates an empty team in tenantID for users left without their team.
+	RestoreTeam(ctx context.Context, teamName, tenantID string) error
+
+	// GetSLACandidates returns open (including ASSIGNING) PRs whose author's team has a review SLA.
+	GetSLACandidates(ctx context.Context) ([]pullrequestModel.SLACandidate, error)
+
+	// GetStaleCandidates returns open PRs without activity since cutoff whose team has not opted out
+	// of closing inactive pull requests.
+	GetStaleCandidates(ctx context.Context, cutoff time.Time) ([]pullrequestModel.StaleCandidate, error)
+
+	// RecordSLAViolation stores an SLA violation; returns false if it has already been recorded.
+	RecordSLAViolation(ctx context.Context, violation *pullrequestModel.SLAViolation) (bool, error)
+
+	// ReplaceUser replaces oldUserID with newUserID as author, reviewer, watcher, commenter and in the activity log.
+	ReplaceUser(ctx context.Context, oldUserID, newUserID string) error
 }
 
 type repository struct {
 	db     *gorm.DB
+	clock  clock.Clock
 	logger *zap.SugaredLogger
 }
 
 // New creates a new pullrequest repository instance.
 func New(db *gorm.DB, logger *zap.SugaredLogger) Repository {
-	return &repository{db: db, logger: logger}
+	return NewWithClock(db, clock.New(), logger)
+}
+
+// NewWithClock creates a new pullrequest repository instance that takes timestamps from clk.
+func NewWithClock(db *gorm.DB, clk clock.Clock, logger *zap.SugaredLogger) Repository {
+	return &repository{db: db, clock: clk, logger: logger}
 }
 
-// Create creates a new pull request.
+// Create creates a new pull request from the given entity.
+// Status is OPEN unless ASSIGNING is requested; created_at and merged_at are always set by the repository.
 func (r *repository) Create(
 	ctx context.Context,
-	prID, prName, authorID string,
+	pr *pullrequestModel.PullRequest,
 ) (*pullrequestModel.PullRequest, error) {
+	prID, authorID := pr.PullRequestID, pr.AuthorID
 	r.logger.Infow("Creating pull request", "pull_request_id", prID, "author_id", authorID)
 
-	now := time.Now()
-	pr := &pullrequestModel.PullRequest{
-		PullRequestID:   prID,
-		PullRequestName: prName,
-		AuthorID:        authorID,
-		Status:          pullrequestModel.StatusOPEN,
-		CreatedAt:       now,
-		MergedAt:        nil,
+	if pr.Status != pullrequestModel.StatusASSIGNING {
+		pr.Status = pullrequestModel.StatusOPEN
 	}
+	pr.CreatedAt = r.clock.Now()
+	pr.MergedAt = nil
+	pr.TenantID = tenant.ID(ctx)
 
-	err := r.db.WithContext(ctx).Create(pr).Error
+	// A conflicting insert is skipped rather than failed, so the transaction can still tell whose PR it is
+	created, err := dialect.CreateOrSkip(r.db.WithContext(ctx), pr, "pull_request_id")
 	if err != nil {
-		// Check for unique constraint violation
-		if errors.Is(err, gorm.ErrDuplicatedKey) || isDuplicateError(err) {
-			r.logger.Debugw("Create pull request duplicate key", "pull_request_id", prID)
-			return nil, pullrequestModel.ErrPullRequestExists
-		}
 		r.logger.Errorw("Failed to create pull request", "pull_request_id", prID, "error", err)
-		return nil, err
+		return nil, dberror.Wrap(err, "create pull request", prID)
+	}
+	if !created {
+		r.logger.Debugw("Create pull request duplicate key", "pull_request_id", prID)
+		return nil, r.existingPullRequestError(ctx, prID)
 	}
 
 	r.logger.Infow("Pull request created", "pull_request_id", prID, "author_id", authorID)
 	return pr, nil
 }
 
-// isDuplicateError checks if error is a duplicate key error.
-func isDuplicateError(err error) bool {
-	if err == nil {
-		return false
+// existingPullRequestError returns ErrPullRequestExists if the pull request exists in the tenant of ctx
+// and tenant.ErrIDUnavailable if the ID is taken by another tenant.
+func (r *repository) existingPullRequestError(ctx context.Context, prID string) error {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&pullrequestModel.PullRequest{}).
+		Scopes(tenant.Scope(ctx, "pull_requests")).
+		Where("pull_request_id = ?", prID).
+		Count(&count).Error
+	if err != nil {
+		return dberror.Wrap(err, "check existing pull request", prID)
 	}
-	errStr := err.Error()
-	return errors.Is(err, gorm.ErrDuplicatedKey) ||
-		(errStr != "" && (contains(errStr, "duplicate key") ||
-			contains(errStr, "UNIQUE constraint")))
-}
-
-func contains(s, substr string) bool {
-	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
-		(len(s) > 0 && len(substr) > 0 && findSubstring(s, substr)))
-}
-
-func findSubstring(s, substr string) bool {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
-		}
+	if count == 0 {
+		return tenant.ErrIDUnavailable
 	}
-	return false
+	return pullrequestModel.ErrPullRequestExists
 }
 
 // GetByID finds pull request by pull_request_id.
@@ -129,6 +234,7 @@ func (r *repository) GetByID(
 
 	var pr pullrequestModel.PullRequest
 	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "pull_requests")).
 		Where("pull_request_id = ?", prID).
 		First(&pr).Error
 
@@ -138,12 +244,122 @@ func (r *repository) GetByID(
 			return nil, pullrequestModel.ErrPullRequestNotFound
 		}
 		r.logger.Errorw("GetByID database error", "pull_request_id", prID, "error", err)
-		return nil, err
+		return nil, dberror.Wrap(err, "get pull request", prID)
 	}
 
 	return &pr, nil
 }
 
+// GetOpenByAuthor returns open (including ASSIGNING) pull requests created by the given author.
+func (r *repository) GetOpenByAuthor(
+	ctx context.Context,
+	authorID string,
+) ([]pullrequestModel.PullRequest, error) {
+	r.logger.Debugw("GetOpenByAuthor called", "author_id", authorID)
+
+	prs := []pullrequestModel.PullRequest{}
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "pull_requests")).
+		Where("author_id = ? AND status IN ?", authorID,
+			[]string{pullrequestModel.StatusOPEN, pullrequestModel.StatusASSIGNING}).
+		Order("created_at ASC, pull_request_id ASC").
+		Find(&prs).Error
+
+	if err != nil {
+		r.logger.Errorw("GetOpenByAuthor database error", "author_id", authorID, "error", err)
+		return nil, dberror.Wrap(err, "get open pull requests by author", authorID)
+	}
+
+	r.logger.Debugw("GetOpenByAuthor completed", "author_id", authorID, "pr_count", len(prs))
+	return prs, nil
+}
+
+// List returns pull requests matching the filters of query, newest first.
+// A PR created before team_name was recorded belongs to the team of its author.
+func (r *repository) List(
+	ctx context.Context,
+	query pullrequestModel.ListQuery,
+) ([]pullrequestModel.PullRequest, error) {
+	r.logger.Debugw("List called",
+		"status", query.Status, "author_id", query.AuthorID, "team_name", query.TeamName,
+		"reviewer_id", query.ReviewerID, "limit", query.Limit)
+
+	db := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "pull_requests"))
+	if query.Status != "" {
+		db = db.Where("pull_requests.status = ?", query.Status)
+	}
+	if query.AuthorID != "" {
+		db = db.Where("pull_requests.author_id = ?", query.AuthorID)
+	}
+	if query.TeamName != "" {
+		db = db.Where(
+			"pull_requests.team_name = ? OR (pull_requests.team_name IS NULL AND pull_requests.author_id IN "+
+				"(SELECT user_id FROM users WHERE team_name = ?))",
+			query.TeamName, query.TeamName,
+		)
+	}
+	if query.ReviewerID != "" {
+		db = db.Where(
+			"EXISTS (SELECT 1 FROM pull_request_reviewers WHERE "+
+				"pull_request_reviewers.pull_request_id = pull_requests.pull_request_id AND "+
+				"pull_request_reviewers.user_id = ?)",
+			query.ReviewerID,
+		)
+	}
+
+	// Keyset of the newest first order: created_at descending, then pull_request_id ascending
+	if query.After != nil {
+		after := query.After.CreatedAt
+		db = db.Where(
+			"pull_requests.created_at < ? OR "+
+				"(pull_requests.created_at = ? AND pull_requests.pull_request_id > ?)",
+			after, after, query.After.PullRequestID,
+		)
+	}
+	if query.Limit > 0 {
+		db = db.Limit(query.Limit)
+	}
+
+	prs := []pullrequestModel.PullRequest{}
+	err := db.Order("pull_requests.created_at DESC, pull_requests.pull_request_id ASC").Find(&prs).Error
+	if err != nil {
+		r.logger.Errorw("List database error", "error", err)
+		return nil, dberror.Wrap(err, "list pull requests")
+	}
+
+	r.logger.Debugw("List completed", "pr_count", len(prs))
+	return prs, nil
+}
+
+// GetReviewersByPullRequests returns the reviewers of each given pull request in assignment order.
+func (r *repository) GetReviewersByPullRequests(
+	ctx context.Context,
+	prIDs []string,
+) (map[string][]string, error) {
+	r.logger.Debugw("GetReviewersByPullRequests called", "pr_count", len(prIDs))
+
+	result := make(map[string][]string)
+	if len(prIDs) == 0 {
+		return result, nil
+	}
+
+	var reviewers []pullrequestModel.PullRequestReviewer
+	err := r.db.WithContext(ctx).
+		Where("pull_request_id IN ?", prIDs).
+		Order("assigned_at ASC, id ASC").
+		Find(&reviewers).Error
+	if err != nil {
+		r.logger.Errorw("GetReviewersByPullRequests database error", "error", err)
+		return nil, dberror.Wrap(err, "get reviewers of pull requests")
+	}
+
+	for _, reviewer := range reviewers {
+		result[reviewer.PullRequestID] = append(result[reviewer.PullRequestID], reviewer.UserID)
+	}
+	return result, nil
+}
+
 // UpdateStatus updates pull request status and merged_at timestamp.
 func (r *repository) UpdateStatus(
 	ctx context.Context,
@@ -179,6 +395,7 @@ func (r *repository) UpdateStatus(
 
 	result := r.db.WithContext(ctx).
 		Model(&pullrequestModel.PullRequest{}).
+		Scopes(tenant.Scope(ctx, "pull_requests")).
 		Where("pull_request_id = ?", prID).
 		Updates(updates)
 
@@ -190,7 +407,7 @@ func (r *repository) UpdateStatus(
 			"error",
 			result.Error,
 		)
-		return result.Error
+		return dberror.Wrap(result.Error, "update pull request status", prID)
 	}
 
 	if result.RowsAffected == 0 {
@@ -202,6 +419,143 @@ func (r *repository) UpdateStatus(
 	return nil
 }
 
+// TransitionStatus changes status from one value to another in a single conditional update,
+// so concurrent callers cannot both perform the same transition.
+func (r *repository) TransitionStatus(ctx context.Context, prID, from, to string) (bool, error) {
+	if err := pullrequestModel.ValidateStatus(to); err != nil {
+		return false, err
+	}
+
+	r.logger.Debugw("TransitionStatus called", "pull_request_id", prID, "from", from, "to", to)
+
+	result := r.db.WithContext(ctx).
+		Model(&pullrequestModel.PullRequest{}).
+		Scopes(tenant.Scope(ctx, "pull_requests")).
+		Where("pull_request_id = ? AND status = ?", prID, from).
+		Update("status", to)
+
+	if result.Error != nil {
+		r.logger.Errorw("TransitionStatus database error", "pull_request_id", prID, "error", result.Error)
+		return false, dberror.Wrap(result.Error, "transition pull request status", prID)
+	}
+
+	r.logger.Debugw("TransitionStatus completed", "pull_request_id", prID, "changed", result.RowsAffected > 0)
+	return result.RowsAffected > 0, nil
+}
+
+// GetIDsByStatus returns IDs of pull requests with the given status ordered by creation time.
+func (r *repository) GetIDsByStatus(ctx context.Context, status string) ([]string, error) {
+	r.logger.Debugw("GetIDsByStatus called", "status", status)
+
+	ids := []string{}
+	err := r.db.WithContext(ctx).
+		Model(&pullrequestModel.PullRequest{}).
+		Scopes(tenant.Scope(ctx, "pull_requests")).
+		Where("status = ?", status).
+		Order("created_at ASC, pull_request_id ASC").
+		Pluck("pull_request_id", &ids).Error
+
+	if err != nil {
+		r.logger.Errorw("GetIDsByStatus database error", "status", status, "error", err)
+		return nil, dberror.Wrap(err, "get pull request IDs by status", status)
+	}
+
+	r.logger.Debugw("GetIDsByStatus completed", "status", status, "pr_count", len(ids))
+	return ids, nil
+}
+
+// SetHasConflicts updates the merge-conflict flag of a pull request.
+func (r *repository) SetHasConflicts(ctx context.Context, prID string, hasConflicts bool) error {
+	r.logger.Infow("SetHasConflicts called", "pull_request_id", prID, "has_conflicts", hasConflicts)
+
+	result := r.db.WithContext(ctx).
+		Model(&pullrequestModel.PullRequest{}).
+		Scopes(tenant.Scope(ctx, "pull_requests")).
+		Where("pull_request_id = ?", prID).
+		Update("has_conflicts", hasConflicts)
+
+	if result.Error != nil {
+		r.logger.Errorw("SetHasConflicts database error", "pull_request_id", prID, "error", result.Error)
+		return dberror.Wrap(result.Error, "set pull request conflicts", prID)
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.Debugw("SetHasConflicts pull request not found", "pull_request_id", prID)
+		return pullrequestModel.ErrPullRequestNotFound
+	}
+
+	return nil
+}
+
+// SetCloseReason stores why a pull request was closed; nil values clear them.
+func (r *repository) SetCloseReason(ctx context.Context, prID string, reason, note *string) error {
+	r.logger.Infow("SetCloseReason called", "pull_request_id", prID, "close_reason", reason)
+
+	result := r.db.WithContext(ctx).
+		Model(&pullrequestModel.PullRequest{}).
+		Scopes(tenant.Scope(ctx, "pull_requests")).
+		Where("pull_request_id = ?", prID).
+		Updates(map[string]interface{}{"close_reason": reason, "close_note": note})
+
+	if result.Error != nil {
+		r.logger.Errorw("SetCloseReason database error", "pull_request_id", prID, "error", result.Error)
+		return dberror.Wrap(result.Error, "set pull request close reason", prID)
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.Debugw("SetCloseReason pull request not found", "pull_request_id", prID)
+		return pullrequestModel.ErrPullRequestNotFound
+	}
+
+	return nil
+}
+
+// UpdateAuthor transfers authorship of a pull request.
+func (r *repository) UpdateAuthor(ctx context.Context, prID, authorID string) error {
+	r.logger.Infow("UpdateAuthor called", "pull_request_id", prID, "author_id", authorID)
+
+	result := r.db.WithContext(ctx).
+		Model(&pullrequestModel.PullRequest{}).
+		Scopes(tenant.Scope(ctx, "pull_requests")).
+		Where("pull_request_id = ?", prID).
+		Update("author_id", authorID)
+
+	if result.Error != nil {
+		r.logger.Errorw("UpdateAuthor database error", "pull_request_id", prID, "error", result.Error)
+		return dberror.Wrap(result.Error, "update pull request author", prID)
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.Debugw("UpdateAuthor pull request not found", "pull_request_id", prID)
+		return pullrequestModel.ErrPullRequestNotFound
+	}
+
+	return nil
+}
+
+// UpdateTeam transfers a pull request to another team.
+func (r *repository) UpdateTeam(ctx context.Context, prID, teamName string) error {
+	r.logger.Infow("UpdateTeam called", "pull_request_id", prID, "team_name", teamName)
+
+	result := r.db.WithContext(ctx).
+		Model(&pullrequestModel.PullRequest{}).
+		Scopes(tenant.Scope(ctx, "pull_requests")).
+		Where("pull_request_id = ?", prID).
+		Update("team_name", teamName)
+
+	if result.Error != nil {
+		r.logger.Errorw("UpdateTeam database error", "pull_request_id", prID, "error", result.Error)
+		return dberror.Wrap(result.Error, "update pull request team", prID)
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.Debugw("UpdateTeam pull request not found", "pull_request_id", prID)
+		return pullrequestModel.ErrPullRequestNotFound
+	}
+
+	return nil
+}
+
 // AssignReviewer assigns a reviewer to a pull request.
 // Business rules validation should be done in service layer before calling this method.
 func (r *repository) AssignReviewer(ctx context.Context, prID, userID string) error {
@@ -232,20 +586,20 @@ func (r *repository) AssignReviewer(ctx context.Context, prID, userID string) er
 			"user_id", userID,
 			"error", err,
 		)
-		return err
+		return dberror.Wrap(err, "check assigned reviewer", prID, userID)
 	}
 
 	// Reviewer doesn't exist, proceed with creation
 	reviewer := &pullrequestModel.PullRequestReviewer{
 		PullRequestID: prID,
 		UserID:        userID,
-		AssignedAt:    time.Now(),
+		AssignedAt:    r.clock.Now(),
 	}
 
 	err = r.db.WithContext(ctx).Create(reviewer).Error
 	if err != nil {
 		// Check for unique constraint violation (in case check above didn't catch it)
-		if errors.Is(err, gorm.ErrDuplicatedKey) || isDuplicateError(err) {
+		if dberror.IsDuplicateKey(err) {
 			r.logger.Debugw(
 				"AssignReviewer duplicate key constraint on create",
 				"pull_request_id",
@@ -266,7 +620,7 @@ func (r *repository) AssignReviewer(ctx context.Context, prID, userID string) er
 			"error",
 			err,
 		)
-		return err
+		return dberror.Wrap(err, "assign reviewer", prID, userID)
 	}
 
 	r.logger.Infow("AssignReviewer completed", "pull_request_id", prID, "user_id", userID)
@@ -291,7 +645,7 @@ func (r *repository) RemoveReviewer(ctx context.Context, prID, userID string) er
 			"error",
 			result.Error,
 		)
-		return result.Error
+		return dberror.Wrap(result.Error, "remove reviewer", prID, userID)
 	}
 
 	if result.RowsAffected == 0 {
@@ -321,7 +675,7 @@ func (r *repository) GetReviewers(ctx context.Context, prID string) ([]string, e
 
 	if err != nil {
 		r.logger.Errorw("GetReviewers database error", "pull_request_id", prID, "error", err)
-		return nil, err
+		return nil, dberror.Wrap(err, "get reviewers", prID)
 	}
 
 	userIDs := make([]string, 0, len(reviewers))
@@ -343,6 +697,92 @@ func (r *repository) GetReviewers(ctx context.Context, prID string) ([]string, e
 	return userIDs, nil
 }
 
+// AddWatcher subscribes a user to a pull request.
+// Watching an already watched pull request is a no-op.
+func (r *repository) AddWatcher(ctx context.Context, prID, userID string) error {
+	r.logger.Infow("AddWatcher called", "pull_request_id", prID, "user_id", userID)
+
+	watcher := &pullrequestModel.PullRequestWatcher{
+		PullRequestID: prID,
+		UserID:        userID,
+		CreatedAt:     r.clock.Now(),
+	}
+
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(watcher).Error
+	if err != nil {
+		r.logger.Errorw("AddWatcher database error", "pull_request_id", prID, "user_id", userID, "error", err)
+		return dberror.Wrap(err, "add watcher", prID, userID)
+	}
+
+	return nil
+}
+
+// GetWatchers returns list of user_id watchers for a pull request.
+func (r *repository) GetWatchers(ctx context.Context, prID string) ([]string, error) {
+	r.logger.Debugw("GetWatchers called", "pull_request_id", prID)
+
+	userIDs := []string{}
+	err := r.db.WithContext(ctx).
+		Model(&pullrequestModel.PullRequestWatcher{}).
+		Where("pull_request_id = ?", prID).
+		Order("created_at ASC, id ASC").
+		Pluck("user_id", &userIDs).Error
+
+	if err != nil {
+		r.logger.Errorw("GetWatchers database error", "pull_request_id", prID, "error", err)
+		return nil, dberror.Wrap(err, "get watchers", prID)
+	}
+
+	r.logger.Debugw("GetWatchers completed", "pull_request_id", prID, "watcher_count", len(userIDs))
+	return userIDs, nil
+}
+
+// AddEvent appends an entry to the activity log of a pull request.
+// created_at is always set by the repository.
+func (r *repository) AddEvent(ctx context.Context, event *pullrequestModel.PullRequestEvent) error {
+	r.logger.Debugw("AddEvent called", "pull_request_id", event.PullRequestID, "event_type", event.EventType)
+
+	event.CreatedAt = r.clock.Now()
+
+	err := r.db.WithContext(ctx).Create(event).Error
+	if err != nil {
+		r.logger.Errorw("AddEvent database error",
+			"pull_request_id", event.PullRequestID,
+			"event_type", event.EventType,
+			"error", err,
+		)
+		return dberror.Wrap(err, "add pull request event", event.PullRequestID)
+	}
+
+	return nil
+}
+
+// GetEvents returns the activity log of a pull request ordered by srt (see pullrequestModel.EventSort).
+// The zero srt means chronological order.
+func (r *repository) GetEvents(
+	ctx context.Context,
+	prID string,
+	srt sortparam.Sort,
+) ([]pullrequestModel.PullRequestEvent, error) {
+	r.logger.Debugw("GetEvents called", "pull_request_id", prID)
+
+	events := []pullrequestModel.PullRequestEvent{}
+	err := r.db.WithContext(ctx).
+		Where("pull_request_id = ?", prID).
+		Order(pullrequestModel.EventSort.OrderBy(srt)).
+		Find(&events).Error
+
+	if err != nil {
+		r.logger.Errorw("GetEvents database error", "pull_request_id", prID, "error", err)
+		return nil, dberror.Wrap(err, "get pull request events", prID)
+	}
+
+	r.logger.Debugw("GetEvents completed", "pull_request_id", prID, "event_count", len(events))
+	return events, nil
+}
+
 // GetActiveTeamMembers returns active team members excluding specified user.
 func (r *repository) GetActiveTeamMembers(
 	ctx context.Context,
@@ -359,6 +799,7 @@ func (r *repository) GetActiveTeamMembers(
 
 	var users []userModel.User
 	query := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "users")).
 		Where("team_name = ? AND is_active = ?", teamName, true)
 
 	if excludeUserID != "" {
@@ -369,7 +810,7 @@ func (r *repository) GetActiveTeamMembers(
 
 	if err != nil {
 		r.logger.Errorw("GetActiveTeamMembers database error", "team_name", teamName, "error", err)
-		return nil, err
+		return nil, dberror.Wrap(err, "get active team members", teamName)
 	}
 
 	if users == nil {
@@ -402,12 +843,13 @@ func (r *repository) GetOpenPRsWithReviewers(
 		Table("pull_request_reviewers").
 		Select("DISTINCT pull_request_reviewers.pull_request_id").
 		Joins("JOIN pull_requests ON pull_request_reviewers.pull_request_id = pull_requests.pull_request_id").
+		Scopes(tenant.Scope(ctx, "pull_requests")).
 		Where("pull_request_reviewers.user_id IN ? AND pull_requests.status = ?", reviewerIDs, pullrequestModel.StatusOPEN).
 		Pluck("pull_request_reviewers.pull_request_id", &prIDs).Error
 
 	if err != nil {
 		r.logger.Errorw("GetOpenPRsWithReviewers database error", "error", err)
-		return nil, err
+		return nil, dberror.Wrap(err, "get open pull requests with reviewers")
 	}
 
 	if prIDs == nil {
@@ -440,12 +882,13 @@ func (r *repository) GetOpenPRsWithAuthors(
 		Table("pull_request_reviewers").
 		Select("DISTINCT pull_request_reviewers.pull_request_id, pull_requests.author_id").
 		Joins("JOIN pull_requests ON pull_request_reviewers.pull_request_id = pull_requests.pull_request_id").
+		Scopes(tenant.Scope(ctx, "pull_requests")).
 		Where("pull_request_reviewers.user_id IN ? AND pull_requests.status = ?", reviewerIDs, pullrequestModel.StatusOPEN).
 		Scan(&prAuthors).Error
 
 	if err != nil {
 		r.logger.Errorw("GetOpenPRsWithAuthors database error", "error", err)
-		return nil, err
+		return nil, dberror.Wrap(err, "get open pull requests with authors")
 	}
 
 	result := make(map[string]string, len(prAuthors))
@@ -457,12 +900,305 @@ func (r *repository) GetOpenPRsWithAuthors(
 	return result, nil
 }
 
+// ArchiveMergedBefore marks merged PRs with merged_at before cutoff as archived.
+// Returns the number of archived PRs.
+func (r *repository) ArchiveMergedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	r.logger.Debugw("ArchiveMergedBefore called", "cutoff", cutoff)
+
+	result := r.db.WithContext(ctx).
+		Model(&pullrequestModel.PullRequest{}).
+		Where("status = ? AND merged_at < ? AND archived_at IS NULL", pullrequestModel.StatusMERGED, cutoff).
+		Update("archived_at", r.clock.Now())
+
+	if result.Error != nil {
+		r.logger.Errorw("ArchiveMergedBefore database error", "error", result.Error)
+		return 0, dberror.Wrap(result.Error, "archive merged pull requests")
+	}
+
+	r.logger.Debugw("ArchiveMergedBefore completed", "archived_count", result.RowsAffected)
+	return result.RowsAffected, nil
+}
+
+// DeleteEventsBefore deletes up to limit activity events created before cutoff, oldest first.
+// Deleting in bounded batches keeps transactions short and avoids long table locks.
+// Returns the number of deleted events.
+func (r *repository) DeleteEventsBefore(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	r.logger.Debugw("DeleteEventsBefore called", "cutoff", cutoff, "limit", limit)
+
+	batch := r.db.WithContext(ctx).
+		Model(&pullrequestModel.PullRequestEvent{}).
+		Select("id").
+		Where("created_at < ?", cutoff).
+		Order("id ASC").
+		Limit(limit)
+
+	result := r.db.WithContext(ctx).
+		Where("id IN (?)", batch).
+		Delete(&pullrequestModel.PullRequestEvent{})
+
+	if result.Error != nil {
+		r.logger.Errorw("DeleteEventsBefore database error", "error", result.Error)
+		return 0, dberror.Wrap(result.Error, "delete pull request events")
+	}
+
+	r.logger.Debugw("DeleteEventsBefore completed", "deleted_count", result.RowsAffected)
+	return result.RowsAffected, nil
+}
+
+// GetReviewLoad returns the total review weight of open PRs assigned to each given user.
+// Users without open reviews are absent from the result.
+func (r *repository) GetReviewLoad(ctx context.Context, userIDs []string) (map[string]int, error) {
+	r.logger.Debugw("GetReviewLoad called", "user_count", len(userIDs))
+
+	if len(userIDs) == 0 {
+		return map[string]int{}, nil
+	}
+
+	type userLoad struct {
+		UserID string `gorm:"column:user_id"`
+		Load   int    `gorm:"column:review_load"`
+	}
+
+	// Line counts are summed as BIGINT: the sum of two INTEGER columns may overflow them,
+	// and SUM of BIGINT is NUMERIC in PostgreSQL (DECIMAL in MySQL)
+	lines := "(" + dialect.CastBigInt(r.db, "pull_requests.lines_added") + " + pull_requests.lines_removed)"
+	weight := dialect.CastBigInt(r.db, "SUM(1 + "+dialect.IntDiv(r.db, lines, "?")+")")
+
+	var loads []userLoad
+	err := r.db.WithContext(ctx).
+		Table("pull_request_reviewers").
+		Select("pull_request_reviewers.user_id, "+weight+" AS review_load", pullrequestModel.ReviewWeightLinesPerUnit).
+		Joins("JOIN pull_requests ON pull_request_reviewers.pull_request_id = pull_requests.pull_request_id").
+		Scopes(tenant.Scope(ctx, "pull_requests")).
+		Where("pull_request_reviewers.user_id IN ? AND pull_requests.status = ?", userIDs, pullrequestModel.StatusOPEN).
+		Group("pull_request_reviewers.user_id").
+		Scan(&loads).Error
+
+	if err != nil {
+		r.logger.Errorw("GetReviewLoad database error", "error", err)
+		return nil, dberror.Wrap(err, "get review load")
+	}
+
+	result := make(map[string]int, len(loads))
+	for _, l := range loads {
+		result[l.UserID] = l.Load
+	}
+
+	r.logger.Debugw("GetReviewLoad completed", "user_count", len(result))
+	return result, nil
+}
+
+// GetReviewHistory counts reviews assigned to each given user since the given time: of PRs by
+// authorID and of PRs into targetBranch (none if it is empty). Users without such reviews are absent
+// from the result.
+func (r *repository) GetReviewHistory(
+	ctx context.Context,
+	userIDs []string,
+	authorID, targetBranch string,
+	since time.Time,
+) (map[string]pullrequestModel.ReviewHistory, error) {
+	r.logger.Debugw("GetReviewHistory called", "user_count", len(userIDs), "author_id", authorID)
+
+	if len(userIDs) == 0 {
+		return map[string]pullrequestModel.ReviewHistory{}, nil
+	}
+
+	type userHistory struct {
+		UserID string `gorm:"column:user_id"`
+		pullrequestModel.ReviewHistory
+	}
+
+	var histories []userHistory
+	err := r.db.WithContext(ctx).
+		Table("pull_request_reviewers").
+		Select(
+			"pull_request_reviewers.user_id, "+
+				"SUM(CASE WHEN pull_requests.author_id = ? THEN 1 ELSE 0 END) AS author_reviews, "+
+				"SUM(CASE WHEN pull_requests.target_branch = ? THEN 1 ELSE 0 END) AS branch_reviews",
+			authorID, targetBranch,
+		).
+		Joins("JOIN pull_requests ON pull_request_reviewers.pull_request_id = pull_requests.pull_request_id").
+		Scopes(tenant.Scope(ctx, "pull_requests")).
+		Where("pull_request_reviewers.user_id IN ? AND pull_request_reviewers.assigned_at >= ?", userIDs, since).
+		Where("pull_requests.author_id = ? OR pull_requests.target_branch = ?", authorID, targetBranch).
+		Group("pull_request_reviewers.user_id").
+		Scan(&histories).Error
+
+	if err != nil {
+		r.logger.Errorw("GetReviewHistory database error", "error", err)
+		return nil, dberror.Wrap(err, "get review history")
+	}
+
+	result := make(map[string]pullrequestModel.ReviewHistory, len(histories))
+	for _, h := range histories {
+		result[h.UserID] = h.ReviewHistory
+	}
+
+	r.logger.Debugw("GetReviewHistory completed", "user_count", len(result))
+	return result, nil
+}
+
+// GetOpenReviewAssignments returns reviewer assignments of all open PRs with reviewer teams.
+// Assignments are ordered by team, reviewer and PR creation time.
+func (r *repository) GetOpenReviewAssignments(ctx context.Context) ([]pullrequestModel.ReviewAssignment, error) {
+	r.logger.Debugw("GetOpenReviewAssignments called")
+
+	assignments := []pullrequestModel.ReviewAssignment{}
+	err := r.db.WithContext(ctx).
+		Table("pull_request_reviewers").
+		Select(
+			"pull_request_reviewers.pull_request_id, pull_requests.author_id, "+
+				"pull_request_reviewers.user_id AS reviewer_id, users.team_name",
+		).
+		Joins("JOIN pull_requests ON pull_request_reviewers.pull_request_id = pull_requests.pull_request_id").
+		Joins("JOIN users ON pull_request_reviewers.user_id = users.user_id").
+		Where("pull_requests.status = ?", pullrequestModel.StatusOPEN).
+		Order("users.team_name ASC, pull_request_reviewers.user_id ASC, " +
+			"pull_requests.created_at ASC, pull_request_reviewers.pull_request_id ASC").
+		Scan(&assignments).Error
+
+	if err != nil {
+		r.logger.Errorw("GetOpenReviewAssignments database error", "error", err)
+		return nil, dberror.Wrap(err, "get open review assignments")
+	}
+
+	r.logger.Debugw("GetOpenReviewAssignments completed", "assignment_count", len(assignments))
+	return assignments, nil
+}
+
+// GetAuthorReviewers returns reviewer rows that assign a PR to its own author.
+func (r *repository) GetAuthorReviewers(ctx context.Context) ([]pullrequestModel.PullRequestReviewer, error) {
+	r.logger.Debugw("GetAuthorReviewers called")
+
+	reviewers := []pullrequestModel.PullRequestReviewer{}
+	err := r.db.WithContext(ctx).
+		Table("pull_request_reviewers").
+		Select("pull_request_reviewers.*").
+		Joins("JOIN pull_requests ON pull_request_reviewers.pull_request_id = pull_requests.pull_request_id").
+		Where("pull_request_reviewers.user_id = pull_requests.author_id").
+		Order("pull_request_reviewers.id ASC").
+		Scan(&reviewers).Error
+
+	if err != nil {
+		r.logger.Errorw("GetAuthorReviewers database error", "error", err)
+		return nil, dberror.Wrap(err, "get author reviewers")
+	}
+
+	r.logger.Debugw("GetAuthorReviewers completed", "reviewer_count", len(reviewers))
+	return reviewers, nil
+}
+
+// GetOrphanedReviewers returns reviewer rows referencing a missing PR or user.
+func (r *repository) GetOrphanedReviewers(ctx context.Context) ([]pullrequestModel.PullRequestReviewer, error) {
+	r.logger.Debugw("GetOrphanedReviewers called")
+
+	reviewers := []pullrequestModel.PullRequestReviewer{}
+	err := r.db.WithContext(ctx).
+		Table("pull_request_reviewers").
+		Select("pull_request_reviewers.*").
+		Joins("LEFT JOIN pull_requests ON pull_request_reviewers.pull_request_id = pull_requests.pull_request_id").
+		Joins("LEFT JOIN users ON pull_request_reviewers.user_id = users.user_id").
+		Where("pull_requests.pull_request_id IS NULL OR users.user_id IS NULL").
+		Order("pull_request_reviewers.id ASC").
+		Scan(&reviewers).Error
+
+	if err != nil {
+		r.logger.Errorw("GetOrphanedReviewers database error", "error", err)
+		return nil, dberror.Wrap(err, "get orphaned reviewers")
+	}
+
+	r.logger.Debugw("GetOrphanedReviewers completed", "reviewer_count", len(reviewers))
+	return reviewers, nil
+}
+
+// GetExcessReviewers returns reviewer rows beyond the first maxReviewers of each PR in assignment order.
+// Author and orphaned rows are not counted, since they are reported separately.
+func (r *repository) GetExcessReviewers(
+	ctx context.Context,
+	maxReviewers int,
+) ([]pullrequestModel.PullRequestReviewer, error) {
+	r.logger.Debugw("GetExcessReviewers called", "max_reviewers", maxReviewers)
+
+	ranked := r.db.
+		Table("pull_request_reviewers").
+		Select(
+			"pull_request_reviewers.*, ROW_NUMBER() OVER (" +
+				"PARTITION BY pull_request_reviewers.pull_request_id " +
+				"ORDER BY pull_request_reviewers.assigned_at, pull_request_reviewers.id) AS position",
+		).
+		Joins("JOIN pull_requests ON pull_request_reviewers.pull_request_id = pull_requests.pull_request_id").
+		Joins("JOIN users ON pull_request_reviewers.user_id = users.user_id").
+		Where("pull_request_reviewers.user_id <> pull_requests.author_id")
+
+	reviewers := []pullrequestModel.PullRequestReviewer{}
+	err := r.db.WithContext(ctx).
+		Table("(?) AS ranked", ranked).
+		Select("id, pull_request_id, user_id, assigned_at").
+		Where("position > ?", maxReviewers).
+		Order("id ASC").
+		Scan(&reviewers).Error
+
+	if err != nil {
+		r.logger.Errorw("GetExcessReviewers database error", "error", err)
+		return nil, dberror.Wrap(err, "get excess reviewers")
+	}
+
+	r.logger.Debugw("GetExcessReviewers completed", "reviewer_count", len(reviewers))
+	return reviewers, nil
+}
+
+// GetUsersWithoutTeam returns users whose team does not exist, ordered by user_id.
+func (r *repository) GetUsersWithoutTeam(ctx context.Context) ([]userModel.User, error) {
+	r.logger.Debugw("GetUsersWithoutTeam called")
+
+	users := []userModel.User{}
+	err := r.db.WithContext(ctx).
+		Table("users").
+		Select("users.*").
+		Joins("LEFT JOIN teams ON users.team_name = teams.team_name").
+		Where("teams.team_name IS NULL").
+		Order("users.user_id ASC").
+		Scan(&users).Error
+
+	if err != nil {
+		r.logger.Errorw("GetUsersWithoutTeam database error", "error", err)
+		return nil, dberror.Wrap(err, "get users without team")
+	}
+
+	r.logger.Debugw("GetUsersWithoutTeam completed", "user_count", len(users))
+	return users, nil
+}
+
+// RestoreTeam creates an empty team in tenantID for users left without their team.
+// A team created in the meantime is kept as is.
+func (r *repository) RestoreTeam(ctx context.Context, teamName, tenantID string) error {
+	r.logger.Infow("RestoreTeam called", "team_name", teamName, "tenant_id", tenantID)
+
+	now := r.clock.Now()
+	err := r.db.WithContext(ctx).
+		Table("teams").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(map[string]interface{}{
+			"team_name":  teamName,
+			"tenant_id":  tenantID,
+			"created_at": now,
+			"updated_at": now,
+		}).Error
+
+	if err != nil {
+		r.logger.Errorw("RestoreTeam database error", "team_name", teamName, "error", err)
+		return dberror.Wrap(err, "restore team", teamName)
+	}
+	return nil
+}
+
 // GetUserTeam returns team name for a user.
 func (r *repository) GetUserTeam(ctx context.Context, userID string) (string, error) {
 	r.logger.Debugw("GetUserTeam called", "user_id", userID)
 
 	var user userModel.User
 	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "users")).
 		Where("user_id = ?", userID).
 		First(&user).Error
 
@@ -472,9 +1208,195 @@ func (r *repository) GetUserTeam(ctx context.Context, userID string) (string, er
 			return "", pullrequestModel.ErrAuthorNotFound
 		}
 		r.logger.Errorw("GetUserTeam database error", "user_id", userID, "error", err)
-		return "", err
+		return "", dberror.Wrap(err, "get user team", userID)
 	}
 
 	r.logger.Debugw("GetUserTeam completed", "user_id", userID, "team_name", user.TeamName)
 	return user.TeamName, nil
 }
+
+// GetUser finds a user by user_id.
+func (r *repository) GetUser(ctx context.Context, userID string) (*userModel.User, error) {
+	r.logger.Debugw("GetUser called", "user_id", userID)
+
+	var user userModel.User
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "users")).
+		Where("user_id = ?", userID).
+		First(&user).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, pullrequestModel.ErrAuthorNotFound
+		}
+		r.logger.Errorw("GetUser database error", "user_id", userID, "error", err)
+		return nil, dberror.Wrap(err, "get user", userID)
+	}
+
+	return &user, nil
+}
+
+// TeamExists reports whether a team exists.
+func (r *repository) TeamExists(ctx context.Context, teamName string) (bool, error) {
+	r.logger.Debugw("TeamExists called", "team_name", teamName)
+
+	var count int64
+	err := r.db.WithContext(ctx).
+		Table("teams").
+		Scopes(tenant.Scope(ctx, "teams")).
+		Where("team_name = ?", teamName).
+		Count(&count).Error
+
+	if err != nil {
+		r.logger.Errorw("TeamExists database error", "team_name", teamName, "error", err)
+		return false, dberror.Wrap(err, "check team exists", teamName)
+	}
+
+	return count > 0, nil
+}
+
+// GetTeamLead returns the lead of a team, or an empty string if the team has no lead.
+func (r *repository) GetTeamLead(ctx context.Context, teamName string) (string, error) {
+	r.logger.Debugw("GetTeamLead called", "team_name", teamName)
+
+	var leads []*string
+	err := r.db.WithContext(ctx).
+		Table("teams").
+		Scopes(tenant.Scope(ctx, "teams")).
+		Where("team_name = ?", teamName).
+		Pluck("lead_user_id", &leads).Error
+
+	if err != nil {
+		r.logger.Errorw("GetTeamLead database error", "team_name", teamName, "error", err)
+		return "", dberror.Wrap(err, "get team lead", teamName)
+	}
+
+	if len(leads) == 0 || leads[0] == nil {
+		return "", nil
+	}
+	return *leads[0], nil
+}
+
+// GetSLACandidates returns open (including ASSIGNING) PRs whose author's team has a review SLA,
+// oldest first.
+func (r *repository) GetSLACandidates(ctx context.Context) ([]pullrequestModel.SLACandidate, error) {
+	r.logger.Debugw("GetSLACandidates called")
+
+	candidates := []pullrequestModel.SLACandidate{}
+	err := r.db.WithContext(ctx).
+		Table("pull_requests").
+		Select(
+			"pull_requests.pull_request_id, pull_requests.created_at, "+
+				"EXISTS (SELECT 1 FROM pull_request_reviewers "+
+				"WHERE pull_request_reviewers.pull_request_id = pull_requests.pull_request_id) AS has_reviewers, "+
+				"teams.team_name, teams.lead_user_id, teams.sla_first_review_hours, teams.sla_merge_hours",
+		).
+		Joins("JOIN users ON pull_requests.author_id = users.user_id").
+		Joins("JOIN teams ON users.team_name = teams.team_name").
+		Scopes(tenant.Scope(ctx, "pull_requests")).
+		Where("pull_requests.status IN ?", []string{pullrequestModel.StatusOPEN, pullrequestModel.StatusASSIGNING}).
+		Where("teams.sla_first_review_hours IS NOT NULL OR teams.sla_merge_hours IS NOT NULL").
+		Order("pull_requests.created_at ASC, pull_requests.pull_request_id ASC").
+		Scan(&candidates).Error
+
+	if err != nil {
+		r.logger.Errorw("GetSLACandidates database error", "error", err)
+		return nil, dberror.Wrap(err, "get SLA candidates")
+	}
+
+	r.logger.Debugw("GetSLACandidates completed", "candidate_count", len(candidates))
+	return candidates, nil
+}
+
+// GetStaleCandidates returns open PRs without activity since cutoff whose team has not opted out of
+// closing inactive pull requests, oldest first. A pull request is active while it is younger than
+// cutoff or has an activity log entry or a comment made since. The team is the owning team of the PR,
+// or the author's team for PRs created before teams owned them; PRs of no team are candidates too.
+func (r *repository) GetStaleCandidates(
+	ctx context.Context,
+	cutoff time.Time,
+) ([]pullrequestModel.StaleCandidate, error) {
+	r.logger.Debugw("GetStaleCandidates called", "cutoff", cutoff)
+
+	candidates := []pullrequestModel.StaleCandidate{}
+	err := r.db.WithContext(ctx).
+		Table("pull_requests").
+		Select(
+			"pull_requests.pull_request_id, pull_requests.author_id, pull_requests.tenant_id, "+
+				"pull_requests.created_at, teams.team_name, teams.lead_user_id",
+		).
+		Joins("LEFT JOIN users ON pull_requests.author_id = users.user_id").
+		Joins("LEFT JOIN teams ON teams.team_name = COALESCE(pull_requests.team_name, users.team_name)").
+		Scopes(tenant.Scope(ctx, "pull_requests")).
+		Where("pull_requests.status = ?", pullrequestModel.StatusOPEN).
+		Where("pull_requests.created_at < ?", cutoff).
+		Where("teams.auto_close_disabled IS NULL OR teams.auto_close_disabled = ?", false).
+		Where("NOT EXISTS (SELECT 1 FROM pull_request_events "+
+			"WHERE pull_request_events.pull_request_id = pull_requests.pull_request_id "+
+			"AND pull_request_events.created_at >= ?)", cutoff).
+		Where("NOT EXISTS (SELECT 1 FROM pull_request_comments "+
+			"WHERE pull_request_comments.pull_request_id = pull_requests.pull_request_id "+
+			"AND pull_request_comments.created_at >= ?)", cutoff).
+		Order("pull_requests.created_at ASC, pull_requests.pull_request_id ASC").
+		Scan(&candidates).Error
+
+	if err != nil {
+		r.logger.Errorw("GetStaleCandidates database error", "error", err)
+		return nil, dberror.Wrap(err, "get stale candidates")
+	}
+
+	r.logger.Debugw("GetStaleCandidates completed", "candidate_count", len(candidates))
+	return candidates, nil
+}
+
+// RecordSLAViolation stores an SLA violation; returns false if the pull request has already violated
+// the same SLA, so that every violation is reported once.
+func (r *repository) RecordSLAViolation(
+	ctx context.Context,
+	violation *pullrequestModel.SLAViolation,
+) (bool, error) {
+	r.logger.Debugw("RecordSLAViolation called",
+		"pull_request_id", violation.PullRequestID, "kind", violation.Kind)
+
+	if violation.DetectedAt.IsZero() {
+		violation.DetectedAt = r.clock.Now()
+	}
+
+	created, err := dialect.CreateOrSkip(r.db.WithContext(ctx), violation)
+	if err != nil {
+		r.logger.Errorw("RecordSLAViolation database error",
+			"pull_request_id", violation.PullRequestID, "kind", violation.Kind, "error", err)
+		return false, dberror.Wrap(err, "record SLA violation", violation.PullRequestID)
+	}
+
+	return created, nil
+}
+
+// userColumns lists the columns of pull request tables referencing users.
+var userColumns = []struct{ table, column string }{
+	{"pull_requests", "author_id"},
+	{"pull_request_reviewers", "user_id"},
+	{"pull_request_watchers", "user_id"},
+	{"pull_request_events", "user_id"},
+	{"pull_request_events", "previous_user_id"},
+	{"pull_request_events", "actor_id"},
+	{"pull_request_comments", "user_id"},
+}
+
+// ReplaceUser replaces oldUserID with newUserID as author, reviewer, watcher, commenter and in the activity log.
+// Both users must exist while references are moved; run it in a transaction.
+func (r *repository) ReplaceUser(ctx context.Context, oldUserID, newUserID string) error {
+	r.logger.Infow("ReplaceUser called", "new_user_id", newUserID)
+
+	for _, ref := range userColumns {
+		err := r.db.WithContext(ctx).
+			Table(ref.table).
+			Where(ref.column+" = ?", oldUserID).
+			Update(ref.column, newUserID).Error
+		if err != nil {
+			r.logger.Errorw("ReplaceUser database error", "table", ref.table, "column", ref.column, "error", err)
+			return dberror.Wrap(err, "replace user in "+ref.table, newUserID)
+		}
+	}
+	return nil
+}
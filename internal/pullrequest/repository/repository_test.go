@@ -12,6 +12,8 @@ import (
 	"gorm.io/gorm"
 
 	pullrequestModel "github.com/festy23/avito_internship/internal/pullrequest/model"
+	"github.com/festy23/avito_internship/internal/tenant"
+	"github.com/festy23/avito_internship/pkg/sortparam"
 )
 
 type testPullRequest struct {
@@ -21,6 +23,17 @@ type testPullRequest struct {
 	Status          string     `gorm:"column:status;not null"`
 	CreatedAt       time.Time  `gorm:"column:created_at"`
 	MergedAt        *time.Time `gorm:"column:merged_at"`
+	ArchivedAt      *time.Time `gorm:"column:archived_at"`
+	SourceBranch    *string    `gorm:"column:source_branch"`
+	TargetBranch    *string    `gorm:"column:target_branch"`
+	PullRequestURL  *string    `gorm:"column:pull_request_url"`
+	LinesAdded      int        `gorm:"column:lines_added;not null;default:0"`
+	LinesRemoved    int        `gorm:"column:lines_removed;not null;default:0"`
+	HasConflicts    bool       `gorm:"column:has_conflicts;not null;default:false"`
+	CloseReason     *string    `gorm:"column:close_reason"`
+	CloseNote       *string    `gorm:"column:close_note"`
+	TeamName        *string    `gorm:"column:team_name"`
+	TenantID        string     `gorm:"column:tenant_id;not null;default:'default'"`
 }
 
 func (testPullRequest) TableName() string {
@@ -38,10 +51,38 @@ func (testPullRequestReviewer) TableName() string {
 	return "pull_request_reviewers"
 }
 
+type testPullRequestWatcher struct {
+	ID            int64     `gorm:"primaryKey;column:id"`
+	PullRequestID string    `gorm:"column:pull_request_id;not null;uniqueIndex:uq_watchers_pr_user"`
+	UserID        string    `gorm:"column:user_id;not null;uniqueIndex:uq_watchers_pr_user"`
+	CreatedAt     time.Time `gorm:"column:created_at"`
+}
+
+func (testPullRequestWatcher) TableName() string {
+	return "pull_request_watchers"
+}
+
+type testPullRequestEvent struct {
+	ID             int64     `gorm:"primaryKey;column:id"`
+	PullRequestID  string    `gorm:"column:pull_request_id;not null"`
+	EventType      string    `gorm:"column:event_type;not null"`
+	UserID         *string   `gorm:"column:user_id"`
+	PreviousUserID *string   `gorm:"column:previous_user_id"`
+	ActorID        *string   `gorm:"column:actor_id"`
+	Reason         *string   `gorm:"column:reason"`
+	Source         *string   `gorm:"column:source"`
+	CreatedAt      time.Time `gorm:"column:created_at"`
+}
+
+func (testPullRequestEvent) TableName() string {
+	return "pull_request_events"
+}
+
 type testTeam struct {
 	TeamName  string    `gorm:"primaryKey;column:team_name"`
 	CreatedAt time.Time `gorm:"column:created_at"`
 	UpdatedAt time.Time `gorm:"column:updated_at"`
+	TenantID  string    `gorm:"column:tenant_id;not null;default:'default'"`
 }
 
 func (testTeam) TableName() string {
@@ -55,6 +96,7 @@ type testUser struct {
 	IsActive  bool      `gorm:"column:is_active;not null;default:true"`
 	CreatedAt time.Time `gorm:"column:created_at"`
 	UpdatedAt time.Time `gorm:"column:updated_at"`
+	TenantID  string    `gorm:"column:tenant_id;not null;default:'default'"`
 }
 
 func (testUser) TableName() string {
@@ -65,12 +107,20 @@ func setupTestDB(t *testing.T) *gorm.DB {
 	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
 	require.NoError(t, err)
 
-	err = db.AutoMigrate(&testPullRequest{}, &testPullRequestReviewer{}, &testTeam{}, &testUser{})
+	err = db.AutoMigrate(&testPullRequest{}, &testPullRequestReviewer{}, &testPullRequestWatcher{}, &testPullRequestEvent{}, &testTeam{}, &testUser{})
 	require.NoError(t, err)
 
 	return db
 }
 
+func newPR(prID, prName, authorID string) *pullrequestModel.PullRequest {
+	return &pullrequestModel.PullRequest{
+		PullRequestID:   prID,
+		PullRequestName: prName,
+		AuthorID:        authorID,
+	}
+}
+
 func TestRepository_Create(t *testing.T) {
 	ctx := context.Background()
 
@@ -81,7 +131,7 @@ func TestRepository_Create(t *testing.T) {
 		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
 			"u1", "Alice", "backend", true)
 
-		pr, err := repo.Create(ctx, "pr-1", "Add feature", "u1")
+		pr, err := repo.Create(ctx, newPR("pr-1", "Add feature", "u1"))
 
 		require.NoError(t, err)
 		assert.Equal(t, "pr-1", pr.PullRequestID)
@@ -97,6 +147,26 @@ func TestRepository_Create(t *testing.T) {
 		assert.Equal(t, pullrequestModel.StatusOPEN, dbPR.Status)
 	})
 
+	t.Run("with branches", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := New(db, zap.NewNop().Sugar())
+
+		source, target := "feature/login", "main"
+		pr := newPR("pr-1", "Add feature", "u1")
+		pr.SourceBranch = &source
+		pr.TargetBranch = &target
+
+		_, err := repo.Create(ctx, pr)
+		require.NoError(t, err)
+
+		found, err := repo.GetByID(ctx, "pr-1")
+		require.NoError(t, err)
+		require.NotNil(t, found.SourceBranch)
+		require.NotNil(t, found.TargetBranch)
+		assert.Equal(t, "feature/login", *found.SourceBranch)
+		assert.Equal(t, "main", *found.TargetBranch)
+	})
+
 	t.Run("duplicate pull request ID", func(t *testing.T) {
 		db := setupTestDB(t)
 		repo := New(db, zap.NewNop().Sugar())
@@ -111,12 +181,28 @@ func TestRepository_Create(t *testing.T) {
 			pullrequestModel.StatusOPEN,
 		)
 
-		pr, err := repo.Create(ctx, "pr-1", "New PR", "u1")
+		pr, err := repo.Create(ctx, newPR("pr-1", "New PR", "u1"))
 
 		assert.Nil(t, pr)
 		assert.ErrorIs(t, err, pullrequestModel.ErrPullRequestExists)
 	})
 
+	t.Run("pull request ID of another tenant", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := New(db, zap.NewNop().Sugar())
+		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
+		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
+			"u1", "Alice", "backend", true)
+		_, err := repo.Create(tenant.WithID(ctx, "acme"), newPR("pr-1", "Existing PR", "u1"))
+		require.NoError(t, err)
+
+		pr, err := repo.Create(tenant.WithID(ctx, "globex"), newPR("pr-1", "New PR", "u1"))
+
+		assert.Nil(t, pr)
+		assert.ErrorIs(t, err, tenant.ErrIDUnavailable)
+		assert.NotErrorIs(t, err, pullrequestModel.ErrPullRequestExists)
+	})
+
 	t.Run("invalid author_id", func(t *testing.T) {
 		db := setupTestDB(t)
 		repo := New(db, zap.NewNop().Sugar())
@@ -124,7 +210,7 @@ func TestRepository_Create(t *testing.T) {
 		// Repository doesn't validate author existence - that's service layer responsibility
 		// In SQLite, foreign key constraints are not enforced by default
 		// This test verifies that repository allows creating PR with non-existent author
-		pr, err := repo.Create(ctx, "pr-1", "Add feature", "nonexistent")
+		pr, err := repo.Create(ctx, newPR("pr-1", "Add feature", "nonexistent"))
 
 		// Repository should succeed (author validation is done at service layer)
 		require.NoError(t, err)
@@ -654,7 +740,7 @@ func TestRepository_Create_Extended(t *testing.T) {
 		sqlDB, _ := db.DB()
 		sqlDB.Close()
 
-		pr, err := repo.Create(ctx, "pr-1", "Add feature", "u1")
+		pr, err := repo.Create(ctx, newPR("pr-1", "Add feature", "u1"))
 		assert.Nil(t, pr)
 		assert.Error(t, err)
 	})
@@ -673,7 +759,7 @@ func TestRepository_Create_Extended(t *testing.T) {
 			pullrequestModel.StatusOPEN,
 		)
 
-		pr, err := repo.Create(ctx, "pr-1", "Duplicate", "u1")
+		pr, err := repo.Create(ctx, newPR("pr-1", "Duplicate", "u1"))
 		assert.Nil(t, pr)
 		assert.ErrorIs(t, err, pullrequestModel.ErrPullRequestExists)
 	})
@@ -1015,3 +1101,562 @@ func TestRepository_GetActiveTeamMembers_Extended(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestRepository_GetReviewLoad(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("sums weights of open PRs only", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := New(db, zap.NewNop().Sugar())
+
+		db.Exec(
+			"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, lines_added, lines_removed) VALUES (?, ?, ?, ?, ?, ?)",
+			"pr-1", "Small", "u1", pullrequestModel.StatusOPEN, 10, 5,
+		)
+		db.Exec(
+			"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, lines_added, lines_removed) VALUES (?, ?, ?, ?, ?, ?)",
+			"pr-2", "Huge", "u1", pullrequestModel.StatusOPEN, 800, 250,
+		)
+		db.Exec(
+			"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, lines_added, lines_removed) VALUES (?, ?, ?, ?, ?, ?)",
+			"pr-3", "Merged", "u1", pullrequestModel.StatusMERGED, 500, 0,
+		)
+		db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)", "pr-1", "u2")
+		db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)", "pr-2", "u2")
+		db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)", "pr-1", "u3")
+		db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)", "pr-3", "u4")
+
+		loads, err := repo.GetReviewLoad(ctx, []string{"u2", "u3", "u4"})
+
+		require.NoError(t, err)
+		assert.Equal(t, 12, loads["u2"])
+		assert.Equal(t, 1, loads["u3"])
+		_, ok := loads["u4"]
+		assert.False(t, ok)
+	})
+
+	t.Run("line counts near the column limit", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := New(db, zap.NewNop().Sugar())
+
+		db.Exec(
+			"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, lines_added, lines_removed) VALUES (?, ?, ?, ?, ?, ?)",
+			"pr-1", "Huge", "u1", pullrequestModel.StatusOPEN, 2147483647, 2147483647,
+		)
+		db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)", "pr-1", "u2")
+
+		loads, err := repo.GetReviewLoad(ctx, []string{"u2"})
+
+		require.NoError(t, err)
+		assert.Equal(t, 42949673, loads["u2"])
+	})
+
+	t.Run("empty user list", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := New(db, zap.NewNop().Sugar())
+
+		loads, err := repo.GetReviewLoad(ctx, []string{})
+
+		require.NoError(t, err)
+		assert.Empty(t, loads)
+	})
+}
+
+func TestRepository_GetReviewHistory(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("counts recent reviews by author and target branch", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := New(db, zap.NewNop().Sugar())
+
+		db.Exec(
+			"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, target_branch) VALUES (?, ?, ?, ?, ?)",
+			"pr-1", "Same author and branch", "u1", pullrequestModel.StatusMERGED, "main",
+		)
+		db.Exec(
+			"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, target_branch) VALUES (?, ?, ?, ?, ?)",
+			"pr-2", "Same branch", "u5", pullrequestModel.StatusOPEN, "main",
+		)
+		db.Exec(
+			"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES (?, ?, ?, ?)",
+			"pr-3", "Same author", "u1", pullrequestModel.StatusOPEN,
+		)
+		db.Exec(
+			"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES (?, ?, ?, ?)",
+			"pr-4", "Unrelated", "u5", pullrequestModel.StatusOPEN,
+		)
+		now := time.Now()
+		for _, reviewer := range []struct {
+			prID, userID string
+			assignedAt   time.Time
+		}{
+			{"pr-1", "u2", now},
+			{"pr-2", "u2", now},
+			{"pr-3", "u2", now},
+			{"pr-4", "u3", now},
+			{"pr-3", "u4", now.Add(-48 * time.Hour)},
+		} {
+			db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id, assigned_at) VALUES (?, ?, ?)",
+				reviewer.prID, reviewer.userID, reviewer.assignedAt)
+		}
+
+		history, err := repo.GetReviewHistory(ctx, []string{"u2", "u3", "u4"}, "u1", "main", now.Add(-24*time.Hour))
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]pullrequestModel.ReviewHistory{
+			"u2": {AuthorReviews: 2, BranchReviews: 2},
+		}, history)
+	})
+
+	t.Run("empty user list", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := New(db, zap.NewNop().Sugar())
+
+		history, err := repo.GetReviewHistory(ctx, []string{}, "u1", "", time.Now())
+
+		require.NoError(t, err)
+		assert.Empty(t, history)
+	})
+}
+
+func TestRepository_ArchiveMergedBefore(t *testing.T) {
+	ctx := context.Background()
+
+	db := setupTestDB(t)
+	repo := New(db, zap.NewNop().Sugar())
+
+	now := time.Now()
+	old := now.Add(-100 * 24 * time.Hour)
+	recent := now.Add(-time.Hour)
+	db.Exec(
+		"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at, merged_at) VALUES (?, ?, ?, ?, ?, ?)",
+		"pr-old", "Old", "u1", pullrequestModel.StatusMERGED, old, old,
+	)
+	db.Exec(
+		"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at, merged_at) VALUES (?, ?, ?, ?, ?, ?)",
+		"pr-recent", "Recent", "u1", pullrequestModel.StatusMERGED, recent, recent,
+	)
+	db.Exec(
+		"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at) VALUES (?, ?, ?, ?, ?)",
+		"pr-open", "Open", "u1", pullrequestModel.StatusOPEN, old,
+	)
+
+	cutoff := now.Add(-30 * 24 * time.Hour)
+	archived, err := repo.ArchiveMergedBefore(ctx, cutoff)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), archived)
+
+	pr, err := repo.GetByID(ctx, "pr-old")
+	require.NoError(t, err)
+	assert.NotNil(t, pr.ArchivedAt)
+
+	for _, id := range []string{"pr-recent", "pr-open"} {
+		pr, err = repo.GetByID(ctx, id)
+		require.NoError(t, err)
+		assert.Nil(t, pr.ArchivedAt, id)
+	}
+
+	// Second run is a no-op
+	archived, err = repo.ArchiveMergedBefore(ctx, cutoff)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), archived)
+}
+
+func TestRepository_Watchers(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("add watcher is idempotent", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := New(db, zap.NewNop().Sugar())
+
+		require.NoError(t, repo.AddWatcher(ctx, "pr-1", "u2"))
+		require.NoError(t, repo.AddWatcher(ctx, "pr-1", "u3"))
+		require.NoError(t, repo.AddWatcher(ctx, "pr-1", "u2"))
+		require.NoError(t, repo.AddWatcher(ctx, "pr-2", "u2"))
+
+		watchers, err := repo.GetWatchers(ctx, "pr-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u2", "u3"}, watchers)
+	})
+
+	t.Run("no watchers", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := New(db, zap.NewNop().Sugar())
+
+		watchers, err := repo.GetWatchers(ctx, "pr-1")
+
+		require.NoError(t, err)
+		assert.NotNil(t, watchers)
+		assert.Empty(t, watchers)
+	})
+}
+
+func TestRepository_Events(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repo := New(db, zap.NewNop().Sugar())
+
+	require.NoError(t, repo.AddEvent(ctx, pullrequestModel.NewPullRequestEvent(
+		"pr-1", pullrequestModel.EventCreated, "u1", "",
+	)))
+	require.NoError(t, repo.AddEvent(ctx, pullrequestModel.NewPullRequestEvent(
+		"pr-2", pullrequestModel.EventCreated, "u1", "",
+	)))
+	require.NoError(t, repo.AddEvent(ctx, pullrequestModel.NewPullRequestEvent(
+		"pr-1", pullrequestModel.EventReviewerReplaced, "u3", "u2",
+	)))
+
+	events, err := repo.GetEvents(ctx, "pr-1", sortparam.Sort{})
+
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, pullrequestModel.EventCreated, events[0].EventType)
+	assert.Nil(t, events[0].PreviousUserID)
+	assert.Equal(t, pullrequestModel.EventReviewerReplaced, events[1].EventType)
+	require.NotNil(t, events[1].UserID)
+	require.NotNil(t, events[1].PreviousUserID)
+	assert.Equal(t, "u3", *events[1].UserID)
+	assert.Equal(t, "u2", *events[1].PreviousUserID)
+
+	// Newest events first
+	events, err = repo.GetEvents(ctx, "pr-1", sortparam.Sort{Order: sortparam.Desc})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, pullrequestModel.EventReviewerReplaced, events[0].EventType)
+	assert.Equal(t, pullrequestModel.EventCreated, events[1].EventType)
+
+	events, err = repo.GetEvents(ctx, "pr-3", sortparam.Sort{})
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestRepository_SetHasConflicts(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("set and clear", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := New(db, zap.NewNop().Sugar())
+		db.Exec(
+			"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES (?, ?, ?, ?)",
+			"pr-1",
+			"Add feature",
+			"u1",
+			pullrequestModel.StatusOPEN,
+		)
+
+		require.NoError(t, repo.SetHasConflicts(ctx, "pr-1", true))
+		pr, err := repo.GetByID(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.True(t, pr.HasConflicts)
+
+		require.NoError(t, repo.SetHasConflicts(ctx, "pr-1", false))
+		pr, err = repo.GetByID(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.False(t, pr.HasConflicts)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := New(db, zap.NewNop().Sugar())
+
+		err := repo.SetHasConflicts(ctx, "nonexistent", true)
+
+		assert.ErrorIs(t, err, pullrequestModel.ErrPullRequestNotFound)
+	})
+}
+
+func TestRepository_UpdateAuthor(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repo := New(db, zap.NewNop().Sugar())
+	db.Exec(
+		"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES (?, ?, ?, ?)",
+		"pr-1",
+		"Add feature",
+		"u1",
+		pullrequestModel.StatusOPEN,
+	)
+
+	require.NoError(t, repo.UpdateAuthor(ctx, "pr-1", "u2"))
+	pr, err := repo.GetByID(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, "u2", pr.AuthorID)
+
+	assert.ErrorIs(t, repo.UpdateAuthor(ctx, "nonexistent", "u2"), pullrequestModel.ErrPullRequestNotFound)
+}
+
+func TestRepository_UpdateTeam(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repo := New(db, zap.NewNop().Sugar())
+	db.Exec(
+		"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES (?, ?, ?, ?)",
+		"pr-1",
+		"Add feature",
+		"u1",
+		pullrequestModel.StatusOPEN,
+	)
+
+	require.NoError(t, repo.UpdateTeam(ctx, "pr-1", "frontend"))
+	pr, err := repo.GetByID(ctx, "pr-1")
+	require.NoError(t, err)
+	require.NotNil(t, pr.TeamName)
+	assert.Equal(t, "frontend", *pr.TeamName)
+
+	assert.ErrorIs(t, repo.UpdateTeam(ctx, "nonexistent", "frontend"), pullrequestModel.ErrPullRequestNotFound)
+}
+
+func TestRepository_TeamExists(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repo := New(db, zap.NewNop().Sugar())
+	db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
+
+	exists, err := repo.TeamExists(ctx, "backend")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = repo.TeamExists(ctx, "frontend")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestRepository_GetOpenByAuthor(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repo := New(db, zap.NewNop().Sugar())
+
+	for _, pr := range []struct{ id, author, status string }{
+		{"pr-1", "u1", pullrequestModel.StatusOPEN},
+		{"pr-2", "u1", pullrequestModel.StatusMERGED},
+		{"pr-3", "u2", pullrequestModel.StatusOPEN},
+	} {
+		db.Exec(
+			"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES (?, ?, ?, ?)",
+			pr.id, "Add feature", pr.author, pr.status,
+		)
+	}
+
+	prs, err := repo.GetOpenByAuthor(ctx, "u1")
+
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	assert.Equal(t, "pr-1", prs[0].PullRequestID)
+
+	prs, err = repo.GetOpenByAuthor(ctx, "nobody")
+	require.NoError(t, err)
+	assert.Empty(t, prs)
+}
+
+func TestRepository_List(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repo := New(db, zap.NewNop().Sugar())
+	db.Exec("INSERT INTO users (user_id, username, team_name) VALUES (?, ?, ?), (?, ?, ?)",
+		"u1", "Alice", "backend", "u2", "Bob", "frontend")
+
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	backend := "backend"
+	for i, pr := range []testPullRequest{
+		{PullRequestID: "pr-1", AuthorID: "u1", Status: pullrequestModel.StatusOPEN},
+		{PullRequestID: "pr-2", AuthorID: "u1", Status: pullrequestModel.StatusMERGED, TeamName: &backend},
+		{PullRequestID: "pr-3", AuthorID: "u2", Status: pullrequestModel.StatusOPEN, TeamName: &backend},
+		{PullRequestID: "pr-4", AuthorID: "u2", Status: pullrequestModel.StatusCLOSED},
+	} {
+		pr.PullRequestName = "Add feature"
+		pr.CreatedAt = base.Add(time.Duration(i) * time.Hour)
+		require.NoError(t, db.Create(&pr).Error)
+	}
+	db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?), (?, ?)",
+		"pr-1", "u2", "pr-3", "u2")
+
+	ids := func(query pullrequestModel.ListQuery) []string {
+		t.Helper()
+		prs, err := repo.List(ctx, query)
+		require.NoError(t, err)
+		result := []string{}
+		for _, pr := range prs {
+			result = append(result, pr.PullRequestID)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"pr-4", "pr-3", "pr-2", "pr-1"}, ids(pullrequestModel.ListQuery{}))
+	assert.Equal(t, []string{"pr-3", "pr-1"}, ids(pullrequestModel.ListQuery{Status: pullrequestModel.StatusOPEN}))
+	assert.Equal(t, []string{"pr-2", "pr-1"}, ids(pullrequestModel.ListQuery{AuthorID: "u1"}))
+	assert.Equal(t, []string{"pr-3", "pr-2", "pr-1"}, ids(pullrequestModel.ListQuery{TeamName: "backend"}),
+		"PRs without team_name belong to the team of the author")
+	assert.Equal(t, []string{"pr-3", "pr-1"}, ids(pullrequestModel.ListQuery{ReviewerID: "u2"}))
+	assert.Equal(t, []string{"pr-3"}, ids(pullrequestModel.ListQuery{AuthorID: "u2", ReviewerID: "u2"}))
+
+	t.Run("pages", func(t *testing.T) {
+		assert.Equal(t, []string{"pr-4", "pr-3"}, ids(pullrequestModel.ListQuery{Limit: 2}))
+		after := &pullrequestModel.ListPosition{CreatedAt: base.Add(2 * time.Hour), PullRequestID: "pr-3"}
+		assert.Equal(t, []string{"pr-2", "pr-1"}, ids(pullrequestModel.ListQuery{Limit: 2, After: after}))
+	})
+
+	t.Run("reviewers", func(t *testing.T) {
+		reviewers, err := repo.GetReviewersByPullRequests(ctx, []string{"pr-1", "pr-2", "pr-3"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]string{"pr-1": {"u2"}, "pr-3": {"u2"}}, reviewers)
+	})
+}
+
+func TestRepository_GetOpenReviewAssignments(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repo := New(db, zap.NewNop().Sugar())
+
+	db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)", "u1", "Alice", "backend", true)
+	db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)", "u2", "Bob", "backend", true)
+	db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)", "u3", "Carol", "frontend", true)
+	db.Exec(
+		"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES (?, ?, ?, ?)",
+		"pr-1", "Open", "u1", pullrequestModel.StatusOPEN,
+	)
+	db.Exec(
+		"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES (?, ?, ?, ?)",
+		"pr-2", "Merged", "u1", pullrequestModel.StatusMERGED,
+	)
+	db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)", "pr-1", "u3")
+	db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)", "pr-1", "u2")
+	db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)", "pr-2", "u2")
+
+	assignments, err := repo.GetOpenReviewAssignments(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, []pullrequestModel.ReviewAssignment{
+		{PullRequestID: "pr-1", AuthorID: "u1", ReviewerID: "u2", TeamName: "backend"},
+		{PullRequestID: "pr-1", AuthorID: "u1", ReviewerID: "u3", TeamName: "frontend"},
+	}, assignments)
+}
+
+func TestRepository_ReviewerAnomalies(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repo := New(db, zap.NewNop().Sugar())
+
+	for _, id := range []string{"u1", "u2", "u3", "u4"} {
+		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)", id, id, "backend", true)
+	}
+	db.Exec(
+		"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES (?, ?, ?, ?)",
+		"pr-1", "Too many reviewers", "u1", pullrequestModel.StatusOPEN,
+	)
+	db.Exec(
+		"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES (?, ?, ?, ?)",
+		"pr-2", "Author reviews", "u1", pullrequestModel.StatusOPEN,
+	)
+	base := time.Now().Add(-time.Hour)
+	insertReviewer := func(prID, userID string, offset time.Duration) {
+		db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id, assigned_at) VALUES (?, ?, ?)",
+			prID, userID, base.Add(offset))
+	}
+	insertReviewer("pr-1", "u2", 0)
+	insertReviewer("pr-1", "u3", time.Minute)
+	insertReviewer("pr-1", "u4", 2*time.Minute)
+	insertReviewer("pr-2", "u1", 0)
+	insertReviewer("pr-2", "u2", time.Minute)
+	insertReviewer("pr-2", "u3", 2*time.Minute)
+	insertReviewer("pr-missing", "u2", 0)
+	insertReviewer("pr-1", "ghost", 3*time.Minute)
+
+	t.Run("author reviewers", func(t *testing.T) {
+		reviewers, err := repo.GetAuthorReviewers(ctx)
+
+		require.NoError(t, err)
+		require.Len(t, reviewers, 1)
+		assert.Equal(t, "pr-2", reviewers[0].PullRequestID)
+		assert.Equal(t, "u1", reviewers[0].UserID)
+	})
+
+	t.Run("orphaned reviewers", func(t *testing.T) {
+		reviewers, err := repo.GetOrphanedReviewers(ctx)
+
+		require.NoError(t, err)
+		require.Len(t, reviewers, 2)
+		assert.Equal(t, "pr-missing", reviewers[0].PullRequestID)
+		assert.Equal(t, "ghost", reviewers[1].UserID)
+	})
+
+	t.Run("excess reviewers exclude author and orphaned rows", func(t *testing.T) {
+		reviewers, err := repo.GetExcessReviewers(ctx, pullrequestModel.MaxReviewersPerPR)
+
+		require.NoError(t, err)
+		require.Len(t, reviewers, 1)
+		assert.Equal(t, "pr-1", reviewers[0].PullRequestID)
+		assert.Equal(t, "u4", reviewers[0].UserID)
+	})
+}
+
+func TestRepository_DeleteEventsBefore(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repo := New(db, zap.NewNop().Sugar())
+
+	now := time.Now()
+	old := now.Add(-100 * 24 * time.Hour)
+	for i := 0; i < 3; i++ {
+		db.Exec("INSERT INTO pull_request_events (pull_request_id, event_type, created_at) VALUES (?, ?, ?)",
+			"pr-1", pullrequestModel.EventCreated, old)
+	}
+	db.Exec("INSERT INTO pull_request_events (pull_request_id, event_type, created_at) VALUES (?, ?, ?)",
+		"pr-1", pullrequestModel.EventMerged, now)
+
+	cutoff := now.Add(-30 * 24 * time.Hour)
+
+	deleted, err := repo.DeleteEventsBefore(ctx, cutoff, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	deleted, err = repo.DeleteEventsBefore(ctx, cutoff, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	deleted, err = repo.DeleteEventsBefore(ctx, cutoff, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), deleted)
+
+	events, err := repo.GetEvents(ctx, "pr-1", sortparam.Sort{})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, pullrequestModel.EventMerged, events[0].EventType)
+}
+
+func TestRepository_TransitionStatus(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repo := New(db, zap.NewNop().Sugar())
+
+	_, err := repo.Create(ctx, &pullrequestModel.PullRequest{
+		PullRequestID:   "pr-1",
+		PullRequestName: "Add feature",
+		AuthorID:        "u1",
+		Status:          pullrequestModel.StatusASSIGNING,
+	})
+	require.NoError(t, err)
+
+	ids, err := repo.GetIDsByStatus(ctx, pullrequestModel.StatusASSIGNING)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pr-1"}, ids)
+
+	changed, err := repo.TransitionStatus(ctx, "pr-1", pullrequestModel.StatusASSIGNING, pullrequestModel.StatusOPEN)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	changed, err = repo.TransitionStatus(ctx, "pr-1", pullrequestModel.StatusASSIGNING, pullrequestModel.StatusOPEN)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	pr, err := repo.GetByID(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, pullrequestModel.StatusOPEN, pr.Status)
+
+	ids, err = repo.GetIDsByStatus(ctx, pullrequestModel.StatusASSIGNING)
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+
+	_, err = repo.TransitionStatus(ctx, "pr-1", pullrequestModel.StatusOPEN, "INVALID")
+	assert.Error(t, err)
+}
@@ -6,18 +6,61 @@ import (
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 
+	"github.com/festy23/avito_internship/internal/config"
+	"github.com/festy23/avito_internship/internal/middleware"
+	"github.com/festy23/avito_internship/internal/notification"
 	"github.com/festy23/avito_internship/internal/pullrequest/handler"
 	"github.com/festy23/avito_internship/internal/pullrequest/repository"
 	"github.com/festy23/avito_internship/internal/pullrequest/service"
+	"github.com/festy23/avito_internship/pkg/cursor"
+	"github.com/festy23/avito_internship/pkg/pagination"
 )
 
 // RegisterRoutes registers pullrequest module routes.
-func RegisterRoutes(r *gin.Engine, db *gorm.DB, logger *zap.SugaredLogger) {
+// A non-nil queue enables asynchronous reviewer assignment; the returned service is used to run the queue workers.
+// The notifier receives pull request lifecycle events; cursors signs pagination cursors and limits bound
+// the page size of list endpoints.
+func RegisterRoutes(
+	r gin.IRouter,
+	db *gorm.DB,
+	cfg config.PullRequestConfig,
+	queue service.AssignmentQueue,
+	notifier notification.Notifier,
+	cursors *cursor.Codec,
+	limits pagination.Limits,
+	logger *zap.SugaredLogger,
+) service.Service {
 	repo := repository.New(db, logger)
-	svc := service.New(repo, db, logger)
-	h := handler.New(svc, logger)
+	policy := service.Policy{
+		BlockMergeOnConflicts:   cfg.BlockMergeOnConflicts,
+		RejectDuplicates:        cfg.RejectDuplicates,
+		DeterministicAssignment: cfg.DeterministicAssignment,
+		ReviewerMaxLoad:         cfg.ReviewerMaxLoad,
+		MinReviewers:            cfg.MinReviewers,
+	}
+	svc := service.New(repo, db, logger,
+		service.WithNotifier(notifier), service.WithPolicy(policy), service.WithAssignmentQueue(queue),
+		service.WithPageLimits(limits))
+	h := handler.NewWithCursors(svc, cursors)
 
 	r.POST("/pullRequest/create", h.CreatePullRequest)
 	r.POST("/pullRequest/merge", h.MergePullRequest)
+	r.POST("/pullRequest/close", h.ClosePullRequest)
+	r.POST("/pullRequest/reopen", h.ReopenPullRequest)
 	r.POST("/pullRequest/reassign", h.ReassignReviewer)
+	r.POST("/pullRequest/reassignAll", h.ReassignAll)
+	r.POST("/pullRequest/assign", h.AssignReviewer)
+	r.POST("/pullRequest/unassign", h.UnassignReviewer)
+	r.POST("/pullRequest/changeAuthor", h.ChangeAuthor)
+	r.POST("/pullRequest/transferTeam", h.TransferTeam)
+	r.POST("/pullRequest/watch", h.WatchPullRequest)
+	r.POST("/pullRequest/setConflicts", h.SetConflicts)
+	r.GET("/pullRequest/list", h.ListPullRequests)
+	r.GET("/pullRequest/activity", h.GetActivity)
+	r.GET("/pullRequest/assignment", middleware.ConditionalGet(), h.GetAssignmentStatus)
+	r.GET("/pullRequest/candidates", h.GetCandidates)
+	r.GET("/pullRequest/suggestReviewers", h.SuggestReviewers)
+	r.POST("/pullRequest/previewAssignment", h.PreviewAssignment)
+
+	return svc
 }
@@ -17,7 +17,11 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 
+	"github.com/festy23/avito_internship/internal/config"
+	"github.com/festy23/avito_internship/internal/notification"
 	pullrequestModel "github.com/festy23/avito_internship/internal/pullrequest/model"
+	"github.com/festy23/avito_internship/pkg/cursor"
+	"github.com/festy23/avito_internship/pkg/pagination"
 )
 
 // ErrorResponse represents error response structure matching OpenAPI spec.
@@ -35,6 +39,17 @@ type testPullRequest struct {
 	Status          string     `gorm:"column:status;not null"`
 	CreatedAt       time.Time  `gorm:"column:created_at"`
 	MergedAt        *time.Time `gorm:"column:merged_at"`
+	ArchivedAt      *time.Time `gorm:"column:archived_at"`
+	SourceBranch    *string    `gorm:"column:source_branch"`
+	TargetBranch    *string    `gorm:"column:target_branch"`
+	PullRequestURL  *string    `gorm:"column:pull_request_url"`
+	LinesAdded      int        `gorm:"column:lines_added;not null;default:0"`
+	LinesRemoved    int        `gorm:"column:lines_removed;not null;default:0"`
+	HasConflicts    bool       `gorm:"column:has_conflicts;not null;default:false"`
+	CloseReason     *string    `gorm:"column:close_reason"`
+	CloseNote       *string    `gorm:"column:close_note"`
+	TeamName        *string    `gorm:"column:team_name"`
+	TenantID        string     `gorm:"column:tenant_id;not null;default:'default'"`
 }
 
 func (testPullRequest) TableName() string {
@@ -56,6 +71,7 @@ type testTeam struct {
 	TeamName  string    `gorm:"primaryKey;column:team_name"`
 	CreatedAt time.Time `gorm:"column:created_at"`
 	UpdatedAt time.Time `gorm:"column:updated_at"`
+	TenantID  string    `gorm:"column:tenant_id;not null;default:'default'"`
 }
 
 func (testTeam) TableName() string {
@@ -69,12 +85,40 @@ type testUser struct {
 	IsActive  bool      `gorm:"column:is_active;not null"`
 	CreatedAt time.Time `gorm:"column:created_at"`
 	UpdatedAt time.Time `gorm:"column:updated_at"`
+	TenantID  string    `gorm:"column:tenant_id;not null;default:'default'"`
 }
 
 func (testUser) TableName() string {
 	return "users"
 }
 
+type testPullRequestWatcher struct {
+	ID            int64     `gorm:"primaryKey;column:id"`
+	PullRequestID string    `gorm:"column:pull_request_id;not null;uniqueIndex:uq_watchers_pr_user"`
+	UserID        string    `gorm:"column:user_id;not null;uniqueIndex:uq_watchers_pr_user"`
+	CreatedAt     time.Time `gorm:"column:created_at"`
+}
+
+func (testPullRequestWatcher) TableName() string {
+	return "pull_request_watchers"
+}
+
+type testPullRequestEvent struct {
+	ID             int64     `gorm:"primaryKey;column:id"`
+	PullRequestID  string    `gorm:"column:pull_request_id;not null"`
+	EventType      string    `gorm:"column:event_type;not null"`
+	UserID         *string   `gorm:"column:user_id"`
+	PreviousUserID *string   `gorm:"column:previous_user_id"`
+	ActorID        *string   `gorm:"column:actor_id"`
+	Reason         *string   `gorm:"column:reason"`
+	Source         *string   `gorm:"column:source"`
+	CreatedAt      time.Time `gorm:"column:created_at"`
+}
+
+func (testPullRequestEvent) TableName() string {
+	return "pull_request_events"
+}
+
 func setupIntegrationDB(t *testing.T) *gorm.DB {
 	// Use unique in-memory DB for each test to ensure isolation
 	// Each call to Open(":memory:") creates a new in-memory database
@@ -89,7 +133,7 @@ func setupIntegrationDB(t *testing.T) *gorm.DB {
 	require.NoError(t, err)
 	sqlDB.SetMaxOpenConns(1)
 
-	err = db.AutoMigrate(&testTeam{}, &testUser{}, &testPullRequest{}, &testPullRequestReviewer{})
+	err = db.AutoMigrate(&testTeam{}, &testUser{}, &testPullRequest{}, &testPullRequestReviewer{}, &testPullRequestWatcher{}, &testPullRequestEvent{})
 	require.NoError(t, err)
 
 	return db
@@ -98,7 +142,8 @@ func setupIntegrationDB(t *testing.T) *gorm.DB {
 func setupRouter(db *gorm.DB) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
-	RegisterRoutes(r, db, zap.NewNop().Sugar())
+	RegisterRoutes(r, db, config.PullRequestConfig{}, nil, notification.NewNop(),
+		cursor.New(""), pagination.DefaultLimits(), zap.NewNop().Sugar())
 	return r
 }
 
@@ -606,3 +651,188 @@ func TestIntegration_FullFlow(t *testing.T) {
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
 }
+
+func TestIntegration_WatchPullRequest(t *testing.T) {
+	db := setupIntegrationDB(t)
+	router := setupRouter(db)
+
+	db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
+	db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
+		"u1", "Alice", "backend", true)
+	db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
+		"u2", "Bob", "backend", true)
+	db.Exec(
+		"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES (?, ?, ?, ?)",
+		"pr-1",
+		"Add feature",
+		"u1",
+		pullrequestModel.StatusOPEN,
+	)
+
+	body := []byte(`{"pull_request_id":"pr-1","user_id":"u2"}`)
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("POST", "/pullRequest/watch", bytes.NewBuffer(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, httpReq)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// Watchers are listed in subsequent PR responses
+	body = []byte(`{"pull_request_id":"pr-1"}`)
+	w = httptest.NewRecorder()
+	httpReq, _ = http.NewRequest("POST", "/pullRequest/merge", bytes.NewBuffer(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, httpReq)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response map[string]pullrequestModel.PullRequestResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2"}, response["pr"].Watchers)
+}
+
+func TestIntegration_GetActivity(t *testing.T) {
+	db := setupIntegrationDB(t)
+	router := setupRouter(db)
+
+	db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
+	db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
+		"u1", "Alice", "backend", true)
+	db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
+		"u2", "Bob", "backend", true)
+
+	body := []byte(`{"pull_request_id":"pr-1","pull_request_name":"Add feature","author_id":"u1"}`)
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("POST", "/pullRequest/create", bytes.NewBuffer(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, httpReq)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	body = []byte(`{"pull_request_id":"pr-1"}`)
+	w = httptest.NewRecorder()
+	httpReq, _ = http.NewRequest("POST", "/pullRequest/merge", bytes.NewBuffer(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, httpReq)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	httpReq, _ = http.NewRequest("GET", "/pullRequest/activity?pull_request_id=pr-1", nil)
+	router.ServeHTTP(w, httpReq)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response pullrequestModel.PullRequestActivityResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	require.Len(t, response.Events, 3)
+	assert.Equal(t, pullrequestModel.EventCreated, response.Events[0].Type)
+	assert.Equal(t, pullrequestModel.EventReviewerAssigned, response.Events[1].Type)
+	assert.Equal(t, "u2", response.Events[1].UserID)
+	assert.Equal(t, pullrequestModel.EventMerged, response.Events[2].Type)
+
+	w = httptest.NewRecorder()
+	httpReq, _ = http.NewRequest("GET", "/pullRequest/activity?pull_request_id=unknown", nil)
+	router.ServeHTTP(w, httpReq)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestIntegration_SetConflicts(t *testing.T) {
+	db := setupIntegrationDB(t)
+	router := setupRouter(db)
+
+	db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
+	db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
+		"u1", "Alice", "backend", true)
+	db.Exec(
+		"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES (?, ?, ?, ?)",
+		"pr-1",
+		"Add feature",
+		"u1",
+		pullrequestModel.StatusOPEN,
+	)
+
+	body := []byte(`{"pull_request_id":"pr-1","has_conflicts":true}`)
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("POST", "/pullRequest/setConflicts", bytes.NewBuffer(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, httpReq)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response map[string]pullrequestModel.PullRequestResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.True(t, response["pr"].HasConflicts)
+
+	// Merge is not blocked by default
+	body = []byte(`{"pull_request_id":"pr-1"}`)
+	w = httptest.NewRecorder()
+	httpReq, _ = http.NewRequest("POST", "/pullRequest/merge", bytes.NewBuffer(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, httpReq)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	body = []byte(`{"pull_request_id":"pr-1","has_conflicts":false}`)
+	w = httptest.NewRecorder()
+	httpReq, _ = http.NewRequest("POST", "/pullRequest/setConflicts", bytes.NewBuffer(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, httpReq)
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestIntegration_CreatePullRequest_DuplicateWarning(t *testing.T) {
+	db := setupIntegrationDB(t)
+	router := setupRouter(db)
+
+	db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
+	db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
+		"u1", "Alice", "backend", true)
+
+	create := func(id string) *httptest.ResponseRecorder {
+		body := []byte(`{"pull_request_id":"` + id + `","pull_request_name":"Add feature","author_id":"u1"}`)
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/pullRequest/create", bytes.NewBuffer(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+		return w
+	}
+
+	w := create("pr-1")
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.NotContains(t, w.Body.String(), "warning")
+
+	w = create("pr-2")
+	require.Equal(t, http.StatusCreated, w.Code)
+	var response map[string]pullrequestModel.PullRequestResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Contains(t, response["pr"].Warning, "pr-1")
+}
+
+func TestIntegration_GetAssignmentStatus(t *testing.T) {
+	db := setupIntegrationDB(t)
+	router := setupRouter(db)
+
+	db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
+	db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
+		"u1", "Alice", "backend", true)
+	db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
+		"u2", "Bob", "backend", true)
+
+	body := []byte(`{"pull_request_id":"pr-1","pull_request_name":"Add feature","author_id":"u1"}`)
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("POST", "/pullRequest/create", bytes.NewBuffer(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, httpReq)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	w = httptest.NewRecorder()
+	httpReq, _ = http.NewRequest("GET", "/pullRequest/assignment?pull_request_id=pr-1", nil)
+	router.ServeHTTP(w, httpReq)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response pullrequestModel.AssignmentStatusResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, pullrequestModel.StatusOPEN, response.Status)
+	assert.False(t, response.Pending)
+	assert.Equal(t, []string{"u2"}, response.AssignedReviewers)
+}
@@ -5,14 +5,25 @@ import (
 	"context"
 	"errors"
 	"math/rand"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
 	"gorm.io/gorm"
 
+	"github.com/festy23/avito_internship/internal/notification"
 	pullrequestModel "github.com/festy23/avito_internship/internal/pullrequest/model"
 	"github.com/festy23/avito_internship/internal/pullrequest/repository"
+	teamModel "github.com/festy23/avito_internship/internal/team/model"
+	"github.com/festy23/avito_internship/internal/tenant"
 	userModel "github.com/festy23/avito_internship/internal/user/model"
+	"github.com/festy23/avito_internship/pkg/clock"
+	"github.com/festy23/avito_internship/pkg/lock"
+	"github.com/festy23/avito_internship/pkg/pagination"
+	"github.com/festy23/avito_internship/pkg/sortparam"
 )
 
 // Service defines the interface for pullrequest business logic operations.
@@ -29,29 +40,223 @@ type Service interface {
 		req *pullrequestModel.MergePullRequestRequest,
 	) (*pullrequestModel.PullRequestResponse, error)
 
+	// ClosePullRequest marks an open pull request as CLOSED without merging (idempotent operation).
+	ClosePullRequest(
+		ctx context.Context,
+		req *pullrequestModel.ClosePullRequestRequest,
+	) (*pullrequestModel.PullRequestResponse, error)
+
+	// ReopenPullRequest moves a closed pull request back to OPEN (idempotent operation). Reviewers
+	// deactivated while the PR was closed are replaced as on deactivation.
+	ReopenPullRequest(
+		ctx context.Context,
+		req *pullrequestModel.ReopenPullRequestRequest,
+	) (*pullrequestModel.PullRequestResponse, error)
+
 	// ReassignReviewer reassigns a reviewer to another from the same team.
 	ReassignReviewer(
 		ctx context.Context,
 		req *pullrequestModel.ReassignReviewerRequest,
 	) (*pullrequestModel.ReassignReviewerResponse, error)
+
+	// ReassignAll replaces a reviewer in every open pull request they review within one transaction.
+	// Pull requests without a replacement candidate keep the reviewer and are reported as NO_CANDIDATE.
+	ReassignAll(
+		ctx context.Context,
+		req *pullrequestModel.ReassignAllRequest,
+	) (*pullrequestModel.ReassignAllResponse, error)
+
+	// AssignReviewer assigns a named reviewer to an open pull request on behalf of the lead of the
+	// team owning the pull request. The action is recorded in the activity log together with the lead.
+	AssignReviewer(
+		ctx context.Context,
+		req *pullrequestModel.AssignReviewerRequest,
+	) (*pullrequestModel.PullRequestResponse, error)
+
+	// UnassignReviewer removes a reviewer from an open pull request without assigning a replacement.
+	UnassignReviewer(
+		ctx context.Context,
+		req *pullrequestModel.UnassignReviewerRequest,
+	) (*pullrequestModel.PullRequestResponse, error)
+
+	// ReplaceDeactivatedReviewers runs deactivate in a transaction and, in the same transaction, replaces
+	// the users it returns in every open pull request they review by the least loaded active member of
+	// their team, or removes them if there is no candidate. Reassignment notifications are sent after
+	// the transaction commits. Returns the IDs of the affected pull requests in ascending order.
+	ReplaceDeactivatedReviewers(
+		ctx context.Context,
+		deactivate func(tx *gorm.DB) ([]string, error),
+	) ([]string, error)
+
+	// ChangeAuthor transfers authorship of an open pull request. If the new author is one of the
+	// reviewers, they are replaced by another member of their team, or removed if there is none.
+	ChangeAuthor(
+		ctx context.Context,
+		req *pullrequestModel.ChangeAuthorRequest,
+	) (*pullrequestModel.PullRequestResponse, error)
+
+	// TransferTeam moves an open pull request to another team and re-runs reviewer assignment: the
+	// current reviewers are replaced by the least loaded active members of the destination team.
+	TransferTeam(
+		ctx context.Context,
+		req *pullrequestModel.TransferTeamRequest,
+	) (*pullrequestModel.PullRequestResponse, error)
+
+	// WatchPullRequest subscribes a user to lifecycle notifications of a pull request.
+	WatchPullRequest(
+		ctx context.Context,
+		req *pullrequestModel.WatchPullRequestRequest,
+	) (*pullrequestModel.PullRequestResponse, error)
+
+	// GetActivity returns the activity log of a pull request ordered by srt.
+	GetActivity(
+		ctx context.Context,
+		prID string,
+		srt sortparam.Sort,
+	) (*pullrequestModel.PullRequestActivityResponse, error)
+
+	// ListPullRequests returns a page of pull requests matching the filters of query, newest first.
+	ListPullRequests(
+		ctx context.Context,
+		query pullrequestModel.ListQuery,
+	) (*pullrequestModel.ListPullRequestsResponse, error)
+
+	// SetConflicts updates the merge-conflict flag of an open pull request.
+	SetConflicts(
+		ctx context.Context,
+		req *pullrequestModel.SetConflictsRequest,
+	) (*pullrequestModel.PullRequestResponse, error)
+
+	// AssignReviewers assigns reviewers to a pull request in ASSIGNING status and moves it to OPEN.
+	// Does nothing if the pull request is no longer waiting for assignment.
+	AssignReviewers(ctx context.Context, prID string) error
+
+	// ResumeAssignments enqueues all pull requests left in ASSIGNING status and returns their count.
+	ResumeAssignments(ctx context.Context) (int, error)
+
+	// GetAssignmentStatus returns the reviewer assignment state of a pull request.
+	GetAssignmentStatus(ctx context.Context, prID string) (*pullrequestModel.AssignmentStatusResponse, error)
+
+	// GetCandidates returns active members of the team owning an open pull request who can be assigned
+	// as its reviewers: everyone except the author and the reviewers already assigned.
+	GetCandidates(ctx context.Context, prID string) (*pullrequestModel.PullRequestCandidatesResponse, error)
+
+	// SuggestReviewers ranks the candidates of GetCandidates by review history: recent reviews of the
+	// author's pull requests and of pull requests into the same target branch count for a candidate,
+	// the current review load counts against them (see pullrequestModel.SuggestionScore).
+	SuggestReviewers(ctx context.Context, prID string) (*pullrequestModel.SuggestReviewersResponse, error)
+
+	// PreviewAssignment runs reviewer selection for a hypothetical PR of the author without writing
+	// anything. Unless assignment is deterministic, ties between equally loaded candidates are broken
+	// randomly, so the selected reviewers may differ from those of an actual PR.
+	PreviewAssignment(
+		ctx context.Context,
+		req *pullrequestModel.PreviewAssignmentRequest,
+	) (*pullrequestModel.PreviewAssignmentResponse, error)
+}
+
+// AssignmentQueue accepts pull requests for asynchronous reviewer assignment.
+type AssignmentQueue interface {
+	// Enqueue schedules assignment; returns false if the queue cannot accept more work.
+	Enqueue(prID string) bool
+}
+
+// Policy holds optional business rules of the service.
+type Policy struct {
+	// BlockMergeOnConflicts rejects merging pull requests flagged with merge conflicts.
+	BlockMergeOnConflicts bool
+	// RejectDuplicates rejects creating a PR when the author already has an open PR with the same
+	// normalized name. When disabled such PRs are created with a warning.
+	RejectDuplicates bool
+	// DeterministicAssignment orders equally loaded candidates by user_id instead of shuffling them,
+	// so tests can assert exact reviewer sets.
+	DeterministicAssignment bool
+	// ReviewerMaxLoad is the review load at which a reviewer can no longer be assigned manually;
+	// 0 means no limit. Automatic assignment picks the least loaded reviewers and ignores it.
+	ReviewerMaxLoad int
+	// MinReviewers is the number of reviewers a PR must keep when a reviewer is unassigned;
+	// 0 means no minimum. Reassignment keeps the number of reviewers and ignores it.
+	MinReviewers int
 }
 
 type service struct {
-	repo   repository.Repository
-	db     *gorm.DB
-	logger *zap.SugaredLogger
+	repo     repository.Repository
+	db       *gorm.DB
+	notifier notification.Notifier
+	policy   Policy
+	queue    AssignmentQueue
+	limits   pagination.Limits
+	clock    clock.Clock
+	logger   *zap.SugaredLogger
+	creates  singleflight.Group
+}
+
+// Option configures an optional dependency or business rule of the service.
+type Option func(*service)
+
+// WithNotifier reports pull request lifecycle events to notifier.
+func WithNotifier(notifier notification.Notifier) Option {
+	return func(s *service) {
+		s.notifier = notifier
+	}
+}
+
+// WithPolicy enables optional business rules.
+func WithPolicy(policy Policy) Option {
+	return func(s *service) {
+		s.policy = policy
+	}
+}
+
+// WithAssignmentQueue creates PRs in ASSIGNING status and hands reviewer assignment to queue.
+// A nil queue assigns reviewers synchronously.
+func WithAssignmentQueue(queue AssignmentQueue) Option {
+	return func(s *service) {
+		s.queue = queue
+	}
+}
+
+// WithPageLimits bounds the page size of list operations by limits instead of pagination.DefaultLimits.
+func WithPageLimits(limits pagination.Limits) Option {
+	return func(s *service) {
+		s.limits = limits
+	}
 }
 
-// New creates a new pullrequest service instance.
-func New(repo repository.Repository, db *gorm.DB, logger *zap.SugaredLogger) Service {
-	return &service{
-		repo:   repo,
-		db:     db,
-		logger: logger,
+// WithClock takes timestamps (created_at, assigned_at, merged_at) from clk, so time-dependent
+// behavior can be tested deterministically. repo should be created with the same clock
+// (see repository.NewWithClock).
+func WithClock(clk clock.Clock) Option {
+	return func(s *service) {
+		s.clock = clk
 	}
 }
 
+// New creates a new pullrequest service instance. Without options it sends no notifications,
+// enforces no optional rules and assigns reviewers synchronously.
+func New(repo repository.Repository, db *gorm.DB, logger *zap.SugaredLogger, opts ...Option) Service {
+	s := &service{
+		repo:     repo,
+		db:       db,
+		notifier: notification.NewNop(),
+		limits:   pagination.DefaultLimits(),
+		clock:    clock.New(),
+		logger:   logger,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// txRepository returns a repository bound to the transaction tx that shares the service clock.
+func (s *service) txRepository(tx *gorm.DB) repository.Repository {
+	return repository.NewWithClock(tx, s.clock, s.logger)
+}
+
 // CreatePullRequest creates a new pull request with automatic reviewer assignment.
+// Identical concurrent requests (client retries, double submits) share one creation and its result
+// instead of all but one failing with PR_EXISTS.
 func (s *service) CreatePullRequest(
 	ctx context.Context,
 	req *pullrequestModel.CreatePullRequestRequest,
@@ -60,27 +265,79 @@ func (s *service) CreatePullRequest(
 		return nil, err
 	}
 
+	// The creation is shared by every concurrent request for the same pull_request_id, so it runs
+	// detached from the cancellation of the request that started it: the others still wait for it
+	key := tenant.ID(ctx) + "\x00" + req.PullRequestID
+	result, err, shared := s.creates.Do(key, func() (any, error) {
+		resp, err := s.createPullRequest(context.WithoutCancel(ctx), req)
+		return createFlight{req: *req, resp: resp}, err
+	})
+	if shared {
+		s.logger.Debugw("shared in-flight pull request creation", "pull_request_id", req.PullRequestID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	flight := result.(createFlight)
+	// Only a retry of the same request gets the result; a different body for the same ID is a conflict
+	if flight.req != *req {
+		return nil, pullrequestModel.ErrPullRequestExists
+	}
+	return clonePullRequestResponse(flight.resp), nil
+}
+
+// createFlight is the result of a creation shared by concurrent requests for one pull_request_id.
+type createFlight struct {
+	req  pullrequestModel.CreatePullRequestRequest
+	resp *pullrequestModel.PullRequestResponse
+}
+
+// clonePullRequestResponse copies resp so callers sharing one creation do not share its slices.
+func clonePullRequestResponse(resp *pullrequestModel.PullRequestResponse) *pullrequestModel.PullRequestResponse {
+	clone := *resp
+	clone.AssignedReviewers = slices.Clone(resp.AssignedReviewers)
+	clone.Watchers = slices.Clone(resp.Watchers)
+	if resp.MergeOverride != nil {
+		override := *resp.MergeOverride
+		clone.MergeOverride = &override
+	}
+	return &clone
+}
+
+// createPullRequest creates a validated pull request.
+func (s *service) createPullRequest(
+	ctx context.Context,
+	req *pullrequestModel.CreatePullRequestRequest,
+) (*pullrequestModel.PullRequestResponse, error) {
 	// Get author's team (before transaction to fail fast if author doesn't exist)
 	teamName, err := s.repo.GetUserTeam(ctx, req.AuthorID)
 	if err != nil {
 		return nil, err
 	}
 
+	if s.queue != nil {
+		return s.createPullRequestAsync(ctx, req, teamName)
+	}
+
 	// Get active team members excluding author (before transaction to fail fast)
 	candidates, err := s.repo.GetActiveTeamMembers(ctx, teamName, req.AuthorID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Select up to MaxReviewersPerPR random reviewers
-	selectedReviewers := selectRandomReviewers(candidates, pullrequestModel.MaxReviewersPerPR)
+	// Select up to MaxReviewersPerPR least loaded reviewers (random among equal load)
+	loads, err := s.repo.GetReviewLoad(ctx, userIDs(candidates))
+	if err != nil {
+		return nil, err
+	}
+	selectedReviewers := s.selectReviewers(candidates, loads, pullrequestModel.MaxReviewersPerPR)
 
 	// Use transaction to ensure atomicity
 	// Check for existing PR inside transaction to prevent race condition
 	var result *pullrequestModel.PullRequestResponse
 	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var txErr error
-		result, txErr = s.createPRInTransaction(ctx, tx, req, selectedReviewers)
+		result, txErr = s.createPRInTransaction(ctx, tx, req, teamName, pullrequestModel.StatusOPEN, selectedReviewers)
 		return txErr
 	})
 
@@ -88,10 +345,56 @@ func (s *service) CreatePullRequest(
 		return nil, err
 	}
 
+	s.notify(ctx, notification.Notification{
+		Event:         notification.EventPullRequestCreated,
+		PullRequestID: result.PullRequestID,
+		Recipients:    result.AssignedReviewers,
+	})
+
 	return result, nil
 }
 
-// validateCreateRequest validates the create pull request request.
+// createPullRequestAsync persists the PR in ASSIGNING status and enqueues reviewer assignment.
+// When the queue is full, reviewers are assigned inline so that no PR is left unassigned.
+func (s *service) createPullRequestAsync(
+	ctx context.Context,
+	req *pullrequestModel.CreatePullRequestRequest,
+	teamName string,
+) (*pullrequestModel.PullRequestResponse, error) {
+	var result *pullrequestModel.PullRequestResponse
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var txErr error
+		result, txErr = s.createPRInTransaction(ctx, tx, req, teamName, pullrequestModel.StatusASSIGNING, nil)
+		return txErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if s.queue.Enqueue(result.PullRequestID) {
+		return result, nil
+	}
+
+	s.logger.Warnw("assigning reviewers inline", "pull_request_id", result.PullRequestID)
+	if err = s.AssignReviewers(ctx, result.PullRequestID); err != nil {
+		return nil, err
+	}
+
+	pr, err := s.repo.GetByID(ctx, result.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	reviewerIDs, err := s.repo.GetReviewers(ctx, result.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := newPullRequestResponse(pr, reviewerIDs)
+	resp.Warning = result.Warning
+	return resp, nil
+}
+
+// validateCreateRequest checks the invariants the service relies on; format rules live in binding tags.
 func (s *service) validateCreateRequest(req *pullrequestModel.CreatePullRequestRequest) error {
 	if req.PullRequestID == "" {
 		return pullrequestModel.ErrInvalidPullRequestID
@@ -99,31 +402,32 @@ func (s *service) validateCreateRequest(req *pullrequestModel.CreatePullRequestR
 	if req.PullRequestName == "" {
 		return errors.New("pull_request_name is required")
 	}
-
-	// Validate string lengths (CHECK constraints: 1-255)
-	if len(req.PullRequestID) == 0 || len(req.PullRequestID) > 255 {
-		return pullrequestModel.ErrInvalidPullRequestID
-	}
-	if len(req.PullRequestName) == 0 || len(req.PullRequestName) > 255 {
-		return errors.New("pull_request_name must be between 1 and 255 characters")
-	}
-	if len(req.AuthorID) == 0 || len(req.AuthorID) > 255 {
+	if req.AuthorID == "" {
 		return pullrequestModel.ErrInvalidAuthorID
 	}
 
+	// Lengths and line counts are validated by binding tags of the request (see pkg/bind)
+	if req.PullRequestURL != "" {
+		if err := pullrequestModel.ValidatePullRequestURL(req.PullRequestURL); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-// createPRInTransaction creates PR and assigns reviewers within a transaction.
+// createPRInTransaction creates PR owned by teamName and assigns reviewers within a transaction.
 //
 //nolint:gocognit // Complex business logic with multiple validation steps
 func (s *service) createPRInTransaction(
 	ctx context.Context,
 	tx *gorm.DB,
 	req *pullrequestModel.CreatePullRequestRequest,
+	teamName string,
+	status string,
 	selectedReviewers []userModel.User,
 ) (*pullrequestModel.PullRequestResponse, error) {
-	txRepo := repository.New(tx, s.logger)
+	txRepo := s.txRepository(tx)
 
 	// Check if PR already exists (inside transaction to prevent race condition)
 	existingPR, checkErr := txRepo.GetByID(ctx, req.PullRequestID)
@@ -134,62 +438,130 @@ func (s *service) createPRInTransaction(
 		return nil, pullrequestModel.ErrPullRequestExists
 	}
 
+	// Concurrent creates of one author are serialized across replicas, so two requests with the same
+	// name cannot both pass the duplicate check
+	if lockErr := lock.XactLock(tx, "pullrequest:create:"+req.AuthorID); lockErr != nil {
+		return nil, lockErr
+	}
+	duplicateID, dupErr := findDuplicate(ctx, txRepo, req.AuthorID, req.PullRequestName)
+	if dupErr != nil {
+		return nil, dupErr
+	}
+	if duplicateID != "" && s.policy.RejectDuplicates {
+		return nil, pullrequestModel.ErrDuplicatePullRequest
+	}
+
 	// Create PR
-	pr, createErr := txRepo.Create(ctx, req.PullRequestID, req.PullRequestName, req.AuthorID)
+	pr, createErr := txRepo.Create(ctx, &pullrequestModel.PullRequest{
+		PullRequestID:   req.PullRequestID,
+		PullRequestName: req.PullRequestName,
+		AuthorID:        req.AuthorID,
+		Status:          status,
+		SourceBranch:    optionalString(req.SourceBranch),
+		TargetBranch:    optionalString(req.TargetBranch),
+		PullRequestURL:  optionalString(req.PullRequestURL),
+		LinesAdded:      req.LinesAdded,
+		LinesRemoved:    req.LinesRemoved,
+		TeamName:        &teamName,
+	})
 	if createErr != nil {
 		return nil, createErr
 	}
 
+	createdEvent := pullrequestModel.NewPullRequestEvent(pr.PullRequestID, pullrequestModel.EventCreated, pr.AuthorID, "")
+	if eventErr := txRepo.AddEvent(ctx, createdEvent); eventErr != nil {
+		return nil, eventErr
+	}
+
+	if assignErr := assignSelectedReviewers(ctx, txRepo, pr, selectedReviewers); assignErr != nil {
+		return nil, assignErr
+	}
+
+	// Get assigned reviewers
+	reviewerIDs, getErr := txRepo.GetReviewers(ctx, req.PullRequestID)
+	if getErr != nil {
+		return nil, getErr
+	}
+
+	resp := newPullRequestResponse(pr, reviewerIDs)
+	if duplicateID != "" {
+		resp.Warning = "possible duplicate of open pull request " + duplicateID
+	}
+	return resp, nil
+}
+
+// assignSelectedReviewers assigns the selected reviewers to a pull request and records activity events.
+func assignSelectedReviewers(
+	ctx context.Context,
+	txRepo repository.Repository,
+	pr *pullrequestModel.PullRequest,
+	selectedReviewers []userModel.User,
+) error {
 	// Validate business rules before assigning reviewers
 	// Business rules: max 2 reviewers, author cannot be reviewer
 	if len(selectedReviewers) > pullrequestModel.MaxReviewersPerPR {
-		return nil, pullrequestModel.ErrMaxReviewersExceeded
+		return pullrequestModel.ErrMaxReviewersExceeded
 	}
 
 	// Assign reviewers with business rule validation
 	for _, reviewer := range selectedReviewers {
 		// Validate: author cannot be reviewer
-		if reviewer.UserID == req.AuthorID {
-			return nil, pullrequestModel.ErrAuthorCannotBeReviewer
+		if reviewer.UserID == pr.AuthorID {
+			return pullrequestModel.ErrAuthorCannotBeReviewer
 		}
 
 		// Check current reviewer count before assignment
-		currentReviewers, getErr := txRepo.GetReviewers(ctx, req.PullRequestID)
+		currentReviewers, getErr := txRepo.GetReviewers(ctx, pr.PullRequestID)
 		if getErr != nil {
-			return nil, getErr
+			return getErr
 		}
 
 		// Validate: max reviewers limit
 		if len(currentReviewers) >= pullrequestModel.MaxReviewersPerPR {
-			return nil, pullrequestModel.ErrMaxReviewersExceeded
+			return pullrequestModel.ErrMaxReviewersExceeded
 		}
 
 		// Validate: duplicate reviewer
 		for _, reviewerID := range currentReviewers {
 			if reviewerID == reviewer.UserID {
-				return nil, pullrequestModel.ErrReviewerAlreadyAssigned
+				return pullrequestModel.ErrReviewerAlreadyAssigned
 			}
 		}
 
-		if assignErr := txRepo.AssignReviewer(ctx, req.PullRequestID, reviewer.UserID); assignErr != nil {
-			return nil, assignErr
+		if assignErr := txRepo.AssignReviewer(ctx, pr.PullRequestID, reviewer.UserID); assignErr != nil {
+			return assignErr
+		}
+
+		assignedEvent := pullrequestModel.NewReviewerEvent(
+			pr.PullRequestID, pullrequestModel.EventReviewerAssigned, pullrequestModel.SourceAuto, reviewer.UserID, "",
+		)
+		if eventErr := txRepo.AddEvent(ctx, assignedEvent); eventErr != nil {
+			return eventErr
 		}
 	}
 
-	// Get assigned reviewers
-	reviewerIDs, getErr := txRepo.GetReviewers(ctx, req.PullRequestID)
-	if getErr != nil {
-		return nil, getErr
+	return nil
+}
+
+// findDuplicate returns the ID of an open PR of the author with the same normalized name, if any.
+func findDuplicate(
+	ctx context.Context,
+	repo repository.Repository,
+	authorID string,
+	name string,
+) (string, error) {
+	openPRs, err := repo.GetOpenByAuthor(ctx, authorID)
+	if err != nil {
+		return "", err
 	}
 
-	return &pullrequestModel.PullRequestResponse{
-		PullRequestID:     pr.PullRequestID,
-		PullRequestName:   pr.PullRequestName,
-		AuthorID:          pr.AuthorID,
-		Status:            pr.Status,
-		AssignedReviewers: reviewerIDs,
-		CreatedAt:         pr.CreatedAt.Format(time.RFC3339),
-	}, nil
+	normalized := pullrequestModel.NormalizePullRequestName(name)
+	for _, pr := range openPRs {
+		if pullrequestModel.NormalizePullRequestName(pr.PullRequestName) == normalized {
+			return pr.PullRequestID, nil
+		}
+	}
+	return "", nil
 }
 
 // MergePullRequest marks a pull request as MERGED (idempotent operation).
@@ -203,11 +575,20 @@ func (s *service) MergePullRequest(
 	if req.PullRequestID == "" {
 		return nil, pullrequestModel.ErrInvalidPullRequestID
 	}
+	if req.Override {
+		if req.MergedBy == "" {
+			return nil, errors.New("merged_by is required to override merge rules")
+		}
+		if strings.TrimSpace(req.Reason) == "" {
+			return nil, pullrequestModel.ErrOverrideReasonRequired
+		}
+	}
 
 	// Use transaction to ensure atomicity of status update and data retrieval
 	var result *pullrequestModel.PullRequestResponse
+	justMerged := false
 	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		txRepo := repository.New(tx, s.logger)
+		txRepo := s.txRepository(tx)
 
 		// Get PR (inside transaction)
 		pr, txErr := txRepo.GetByID(ctx, req.PullRequestID)
@@ -215,6 +596,11 @@ func (s *service) MergePullRequest(
 			return txErr
 		}
 
+		watcherIDs, txErr := txRepo.GetWatchers(ctx, req.PullRequestID)
+		if txErr != nil {
+			return txErr
+		}
+
 		// If already MERGED, return current state (idempotent)
 		if pr.Status == pullrequestModel.StatusMERGED {
 			reviewerIDs, getErr := txRepo.GetReviewers(ctx, req.PullRequestID)
@@ -222,30 +608,41 @@ func (s *service) MergePullRequest(
 				return getErr
 			}
 
-			mergedAt := ""
-			if pr.MergedAt != nil {
-				mergedAt = pr.MergedAt.Format(time.RFC3339)
-			}
-
-			result = &pullrequestModel.PullRequestResponse{
-				PullRequestID:     pr.PullRequestID,
-				PullRequestName:   pr.PullRequestName,
-				AuthorID:          pr.AuthorID,
-				Status:            pr.Status,
-				AssignedReviewers: reviewerIDs,
-				CreatedAt:         pr.CreatedAt.Format(time.RFC3339),
-				MergedAt:          mergedAt,
-			}
+			result = newPullRequestResponse(pr, reviewerIDs)
+			result.Watchers = watcherIDs
 			return nil
 		}
 
+		// Reviewers of a PR still being assigned would be assigned to a merged PR
+		switch pr.Status {
+		case pullrequestModel.StatusASSIGNING:
+			return pullrequestModel.ErrAssignmentPending
+		case pullrequestModel.StatusCLOSED:
+			return pullrequestModel.ErrPullRequestClosed
+		}
+
+		override, txErr := s.checkMergeRules(ctx, txRepo, pr, req)
+		if txErr != nil {
+			return txErr
+		}
+
 		// Update status to MERGED
-		now := time.Now()
+		now := s.clock.Now()
 		txErr = txRepo.UpdateStatus(ctx, req.PullRequestID, pullrequestModel.StatusMERGED, &now)
 		if txErr != nil {
 			return txErr
 		}
 
+		mergedEvent := pullrequestModel.NewPullRequestEvent(req.PullRequestID, pullrequestModel.EventMerged, "", "")
+		if override != nil {
+			mergedEvent.ActorID = &override.MergedBy
+			mergedEvent.Reason = &override.Reason
+		}
+		txErr = txRepo.AddEvent(ctx, mergedEvent)
+		if txErr != nil {
+			return txErr
+		}
+
 		// Get updated PR (inside transaction)
 		var mergedPR *pullrequestModel.PullRequest
 		mergedPR, txErr = txRepo.GetByID(ctx, req.PullRequestID)
@@ -259,20 +656,10 @@ func (s *service) MergePullRequest(
 			return txErr
 		}
 
-		mergedAt := ""
-		if mergedPR.MergedAt != nil {
-			mergedAt = mergedPR.MergedAt.Format(time.RFC3339)
-		}
-
-		result = &pullrequestModel.PullRequestResponse{
-			PullRequestID:     mergedPR.PullRequestID,
-			PullRequestName:   mergedPR.PullRequestName,
-			AuthorID:          mergedPR.AuthorID,
-			Status:            mergedPR.Status,
-			AssignedReviewers: reviewerIDs,
-			CreatedAt:         mergedPR.CreatedAt.Format(time.RFC3339),
-			MergedAt:          mergedAt,
-		}
+		result = newPullRequestResponse(mergedPR, reviewerIDs)
+		result.Watchers = watcherIDs
+		result.MergeOverride = override
+		justMerged = true
 		return nil
 	})
 
@@ -280,63 +667,383 @@ func (s *service) MergePullRequest(
 		return nil, err
 	}
 
+	if justMerged {
+		s.notify(ctx, notification.Notification{
+			Event:         notification.EventPullRequestMerged,
+			PullRequestID: result.PullRequestID,
+			Recipients:    notification.Recipients(result.AssignedReviewers, result.Watchers),
+		})
+	}
+
 	return result, nil
 }
 
-// ReassignReviewer reassigns a reviewer to another from the same team.
-func (s *service) ReassignReviewer(
+// ClosePullRequest marks an open pull request as CLOSED without merging (idempotent operation).
+// Reviewers stay assigned for the history, but a closed PR no longer counts towards their review load.
+// The close reason and note are kept on the PR; closing an already closed PR does not change them.
+func (s *service) ClosePullRequest(
 	ctx context.Context,
-	req *pullrequestModel.ReassignReviewerRequest,
-) (*pullrequestModel.ReassignReviewerResponse, error) {
-	if err := s.validateReassignRequest(req); err != nil {
+	req *pullrequestModel.ClosePullRequestRequest,
+) (*pullrequestModel.PullRequestResponse, error) {
+	if req.PullRequestID == "" {
+		return nil, pullrequestModel.ErrInvalidPullRequestID
+	}
+	closeReason := req.CloseReason
+	if closeReason == "" {
+		closeReason = pullrequestModel.CloseReasonOther
+	}
+	if err := pullrequestModel.ValidateCloseReason(closeReason); err != nil {
 		return nil, err
 	}
+	note := strings.TrimSpace(req.Note)
+	if note == "" {
+		note = strings.TrimSpace(req.Reason)
+	}
 
-	// Use transaction to ensure atomicity
-	// All checks and operations inside transaction to prevent race conditions
-	var result *pullrequestModel.ReassignReviewerResponse
+	var result *pullrequestModel.PullRequestResponse
+	justClosed := false
 	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		var txErr error
-		result, txErr = s.reassignInTransaction(ctx, tx, req)
-		return txErr
-	})
+		txRepo := s.txRepository(tx)
+
+		pr, txErr := txRepo.GetByID(ctx, req.PullRequestID)
+		if txErr != nil {
+			return txErr
+		}
+
+		switch pr.Status {
+		case pullrequestModel.StatusMERGED:
+			return pullrequestModel.ErrPullRequestMerged
+		case pullrequestModel.StatusASSIGNING:
+			return pullrequestModel.ErrAssignmentPending
+		case pullrequestModel.StatusOPEN:
+			if txErr = txRepo.UpdateStatus(ctx, req.PullRequestID, pullrequestModel.StatusCLOSED, nil); txErr != nil {
+				return txErr
+			}
+			var notePtr *string
+			if note != "" {
+				notePtr = &note
+			}
+			if txErr = txRepo.SetCloseReason(ctx, req.PullRequestID, &closeReason, notePtr); txErr != nil {
+				return txErr
+			}
+			closedEvent := pullrequestModel.NewPullRequestEvent(req.PullRequestID, pullrequestModel.EventClosed, "", "")
+			if req.ClosedBy != "" {
+				closedEvent.ActorID = &req.ClosedBy
+			}
+			// The activity log keeps the reason of every close, also after the PR is reopened
+			eventReason := closeReason
+			if note != "" {
+				eventReason += ": " + note
+			}
+			closedEvent.Reason = &eventReason
+			if txErr = txRepo.AddEvent(ctx, closedEvent); txErr != nil {
+				return txErr
+			}
+			if pr, txErr = txRepo.GetByID(ctx, req.PullRequestID); txErr != nil {
+				return txErr
+			}
+			justClosed = true
+		}
+
+		// An already closed PR is returned as is
+		reviewerIDs, txErr := txRepo.GetReviewers(ctx, req.PullRequestID)
+		if txErr != nil {
+			return txErr
+		}
+		watcherIDs, txErr := txRepo.GetWatchers(ctx, req.PullRequestID)
+		if txErr != nil {
+			return txErr
+		}
 
+		result = newPullRequestResponse(pr, reviewerIDs)
+		result.Watchers = watcherIDs
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	if justClosed {
+		s.notify(ctx, notification.Notification{
+			Event:         notification.EventPullRequestClosed,
+			PullRequestID: result.PullRequestID,
+			Recipients:    notification.Recipients(result.AssignedReviewers, result.Watchers),
+		})
+	}
+
 	return result, nil
 }
 
-// validateReassignRequest validates the reassign reviewer request.
-func (s *service) validateReassignRequest(req *pullrequestModel.ReassignReviewerRequest) error {
+// ReopenPullRequest moves a closed pull request back to OPEN (idempotent operation).
+// Deactivation replaces reviewers only in open PRs, so reviewers deactivated while the PR was closed are
+// replaced now by the least loaded active members of their team, or removed if there is none.
+func (s *service) ReopenPullRequest(
+	ctx context.Context,
+	req *pullrequestModel.ReopenPullRequestRequest,
+) (*pullrequestModel.PullRequestResponse, error) {
 	if req.PullRequestID == "" {
-		return pullrequestModel.ErrInvalidPullRequestID
-	}
-	if req.OldUserID == "" {
-		return errors.New("old_user_id is required")
+		return nil, pullrequestModel.ErrInvalidPullRequestID
 	}
 
-	// Validate string lengths (CHECK constraints: 1-255)
-	if len(req.PullRequestID) == 0 || len(req.PullRequestID) > 255 {
-		return pullrequestModel.ErrInvalidPullRequestID
-	}
-	if len(req.OldUserID) == 0 || len(req.OldUserID) > 255 {
-		return errors.New("old_user_id must be between 1 and 255 characters")
-	}
+	var (
+		result       *pullrequestModel.PullRequestResponse
+		changes      []reviewerChange
+		justReopened bool
+	)
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txRepo := s.txRepository(tx)
 
-	return nil
-}
+		pr, txErr := txRepo.GetByID(ctx, req.PullRequestID)
+		if txErr != nil {
+			return txErr
+		}
 
-// reassignInTransaction performs reassignment within a transaction.
-//
-//nolint:funlen,gocognit,gocyclo // Complex business logic with multiple validation steps
-func (s *service) reassignInTransaction(
+		reviewers, txErr := txRepo.GetReviewers(ctx, req.PullRequestID)
+		if txErr != nil {
+			return txErr
+		}
+
+		switch pr.Status {
+		case pullrequestModel.StatusMERGED:
+			return pullrequestModel.ErrPullRequestMerged
+		case pullrequestModel.StatusCLOSED:
+			if txErr = txRepo.UpdateStatus(ctx, req.PullRequestID, pullrequestModel.StatusOPEN, nil); txErr != nil {
+				return txErr
+			}
+			if txErr = txRepo.SetCloseReason(ctx, req.PullRequestID, nil, nil); txErr != nil {
+				return txErr
+			}
+			reopenedEvent := pullrequestModel.NewPullRequestEvent(req.PullRequestID, pullrequestModel.EventReopened, "", "")
+			if req.ReopenedBy != "" {
+				reopenedEvent.ActorID = &req.ReopenedBy
+			}
+			if reason := strings.TrimSpace(req.Reason); reason != "" {
+				reopenedEvent.Reason = &reason
+			}
+			if txErr = txRepo.AddEvent(ctx, reopenedEvent); txErr != nil {
+				return txErr
+			}
+			if reviewers, changes, txErr = s.replaceInactiveReviewers(ctx, txRepo, pr, reviewers); txErr != nil {
+				return txErr
+			}
+			if pr, txErr = txRepo.GetByID(ctx, req.PullRequestID); txErr != nil {
+				return txErr
+			}
+			justReopened = true
+		}
+
+		// An open (or ASSIGNING) PR is returned as is
+		watcherIDs, txErr := txRepo.GetWatchers(ctx, req.PullRequestID)
+		if txErr != nil {
+			return txErr
+		}
+
+		result = newPullRequestResponse(pr, reviewers)
+		result.Watchers = watcherIDs
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if justReopened {
+		s.notify(ctx, notification.Notification{
+			Event:         notification.EventPullRequestReopened,
+			PullRequestID: result.PullRequestID,
+			Recipients:    notification.Recipients(result.AssignedReviewers, result.Watchers),
+		})
+		for i := range changes {
+			changes[i].pr = result
+		}
+		s.notifyReviewerChanges(ctx, changes)
+	}
+
+	return result, nil
+}
+
+// replaceInactiveReviewers replaces the reviewers of the pull request who are no longer active, as
+// deactivation would have. Returns the resulting reviewers and the changes made.
+func (s *service) replaceInactiveReviewers(
+	ctx context.Context,
+	txRepo repository.Repository,
+	pr *pullrequestModel.PullRequest,
+	reviewers []string,
+) ([]string, []reviewerChange, error) {
+	var changes []reviewerChange
+	for _, reviewerID := range slices.Clone(reviewers) {
+		reviewer, err := txRepo.GetUser(ctx, reviewerID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if reviewer.IsActive {
+			continue
+		}
+		replacedBy, err := s.replaceDeactivatedReviewer(ctx, txRepo, pr, reviewers, reviewerID)
+		if err != nil {
+			return nil, nil, err
+		}
+		reviewers = replaceReviewer(reviewers, reviewerID, replacedBy)
+		changes = append(changes, reviewerChange{oldUserID: reviewerID, replacedBy: replacedBy})
+	}
+	return reviewers, changes, nil
+}
+
+// checkMergeRules returns an error if merge rules block the pull request, unless the request
+// overrides them on behalf of the lead of the team owning the pull request. Returns the applied override, if any.
+func (s *service) checkMergeRules(
+	ctx context.Context,
+	repo repository.Repository,
+	pr *pullrequestModel.PullRequest,
+	req *pullrequestModel.MergePullRequestRequest,
+) (*pullrequestModel.MergeOverrideResponse, error) {
+	if !s.policy.BlockMergeOnConflicts || !pr.HasConflicts {
+		// An override is recorded as bypassing merge rules, so it must bypass one
+		if req.Override {
+			return nil, pullrequestModel.ErrOverrideNotNeeded
+		}
+		return nil, nil
+	}
+	if !req.Override {
+		return nil, pullrequestModel.ErrPullRequestHasConflicts
+	}
+
+	teamName, err := pullRequestTeam(ctx, repo, pr)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.checkTeamLead(ctx, repo, teamName, req.MergedBy); err != nil {
+		return nil, err
+	}
+	return &pullrequestModel.MergeOverrideResponse{MergedBy: req.MergedBy, Reason: strings.TrimSpace(req.Reason)}, nil
+}
+
+// ReassignReviewer reassigns a reviewer to another from the same team.
+func (s *service) ReassignReviewer(
+	ctx context.Context,
+	req *pullrequestModel.ReassignReviewerRequest,
+) (*pullrequestModel.ReassignReviewerResponse, error) {
+	if err := s.validateReassignRequest(req); err != nil {
+		return nil, err
+	}
+
+	// Use transaction to ensure atomicity
+	// All checks and operations inside transaction to prevent race conditions
+	var result *pullrequestModel.ReassignReviewerResponse
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var txErr error
+		result, txErr = s.reassignInTransaction(ctx, tx, req)
+		return txErr
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.notify(ctx, notification.Notification{
+		Event:         notification.EventReviewerReassigned,
+		PullRequestID: result.PR.PullRequestID,
+		Recipients: notification.Recipients(
+			[]string{req.OldUserID}, result.PR.AssignedReviewers, result.PR.Watchers,
+		),
+		Details: map[string]string{
+			"old_user_id": req.OldUserID,
+			"new_user_id": result.ReplacedBy,
+		},
+	})
+
+	return result, nil
+}
+
+// ReassignAll replaces a reviewer in every open pull request they review.
+func (s *service) ReassignAll(
+	ctx context.Context,
+	req *pullrequestModel.ReassignAllRequest,
+) (*pullrequestModel.ReassignAllResponse, error) {
+	if req.OldUserID == "" {
+		return nil, errors.New("old_user_id is required")
+	}
+
+	var reassigned []*pullrequestModel.ReassignReviewerResponse
+	resp := &pullrequestModel.ReassignAllResponse{
+		OldUserID: req.OldUserID,
+		Results:   []pullrequestModel.ReassignAllResult{},
+	}
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txRepo := s.txRepository(tx)
+		if _, err := txRepo.GetUser(ctx, req.OldUserID); err != nil {
+			return err
+		}
+		prIDs, err := txRepo.GetOpenPRsWithReviewers(ctx, []string{req.OldUserID})
+		if err != nil {
+			return err
+		}
+		sort.Strings(prIDs)
+
+		for _, prID := range prIDs {
+			result, reassignErr := s.reassignInTransaction(ctx, tx, &pullrequestModel.ReassignReviewerRequest{
+				PullRequestID: prID,
+				OldUserID:     req.OldUserID,
+			})
+			if errors.Is(reassignErr, pullrequestModel.ErrNoCandidate) {
+				resp.Results = append(resp.Results, pullrequestModel.ReassignAllResult{
+					PullRequestID: prID,
+					Error:         pullrequestModel.ReassignResultNoCandidate,
+				})
+				continue
+			}
+			if reassignErr != nil {
+				return reassignErr
+			}
+			reassigned = append(reassigned, result)
+			resp.Results = append(resp.Results, pullrequestModel.ReassignAllResult{
+				PullRequestID: prID,
+				ReplacedBy:    result.ReplacedBy,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range reassigned {
+		s.notify(ctx, notification.Notification{
+			Event:         notification.EventReviewerReassigned,
+			PullRequestID: result.PR.PullRequestID,
+			Recipients: notification.Recipients(
+				[]string{req.OldUserID}, result.PR.AssignedReviewers, result.PR.Watchers,
+			),
+			Details: map[string]string{
+				"old_user_id": req.OldUserID,
+				"new_user_id": result.ReplacedBy,
+			},
+		})
+	}
+
+	return resp, nil
+}
+
+// validateReassignRequest validates the reassign reviewer request.
+func (s *service) validateReassignRequest(req *pullrequestModel.ReassignReviewerRequest) error {
+	if req.PullRequestID == "" {
+		return pullrequestModel.ErrInvalidPullRequestID
+	}
+	if req.OldUserID == "" {
+		return errors.New("old_user_id is required")
+	}
+
+	return nil
+}
+
+// reassignInTransaction performs reassignment within a transaction.
+//
+//nolint:funlen,gocognit,gocyclo // Complex business logic with multiple validation steps
+func (s *service) reassignInTransaction(
 	ctx context.Context,
 	tx *gorm.DB,
 	req *pullrequestModel.ReassignReviewerRequest,
 ) (*pullrequestModel.ReassignReviewerResponse, error) {
-	txRepo := repository.New(tx, s.logger)
+	txRepo := s.txRepository(tx)
 
 	// Get PR (inside transaction)
 	pr, txErr := txRepo.GetByID(ctx, req.PullRequestID)
@@ -344,9 +1051,12 @@ func (s *service) reassignInTransaction(
 		return nil, txErr
 	}
 
-	// Check if PR is already merged (inside transaction)
-	if pr.Status == pullrequestModel.StatusMERGED {
+	// Check if PR is already merged or closed (inside transaction)
+	switch pr.Status {
+	case pullrequestModel.StatusMERGED:
 		return nil, pullrequestModel.ErrPullRequestMerged
+	case pullrequestModel.StatusCLOSED:
+		return nil, pullrequestModel.ErrPullRequestClosed
 	}
 
 	// Get old reviewer's team first to check if user exists
@@ -395,8 +1105,12 @@ func (s *service) reassignInTransaction(
 		return nil, pullrequestModel.ErrNoCandidate
 	}
 
-	// Select random replacement
-	selected := selectRandomReviewers(finalCandidates, 1)
+	// Select least loaded replacement (random among equal load)
+	loads, loadErr := txRepo.GetReviewLoad(ctx, userIDs(finalCandidates))
+	if loadErr != nil {
+		return nil, loadErr
+	}
+	selected := s.selectReviewers(finalCandidates, loads, 1)
 	if len(selected) == 0 {
 		return nil, pullrequestModel.ErrNoCandidate
 	}
@@ -437,6 +1151,14 @@ func (s *service) reassignInTransaction(
 		return nil, assignErr
 	}
 
+	replacedEvent := pullrequestModel.NewReviewerEvent(
+		req.PullRequestID, pullrequestModel.EventReviewerReplaced, pullrequestModel.SourceReassign,
+		newReviewerID, req.OldUserID,
+	)
+	if eventErr := txRepo.AddEvent(ctx, replacedEvent); eventErr != nil {
+		return nil, eventErr
+	}
+
 	// Get updated reviewers list
 	reviewerIDs, reviewersErr := txRepo.GetReviewers(ctx, req.PullRequestID)
 	if reviewersErr != nil {
@@ -449,69 +1171,1372 @@ func (s *service) reassignInTransaction(
 		return nil, updatedErr
 	}
 
-	mergedAt := ""
-	if updatedPR.MergedAt != nil {
-		mergedAt = updatedPR.MergedAt.Format(time.RFC3339)
+	watcherIDs, watchersErr := txRepo.GetWatchers(ctx, req.PullRequestID)
+	if watchersErr != nil {
+		return nil, watchersErr
 	}
 
+	prResp := newPullRequestResponse(updatedPR, reviewerIDs)
+	prResp.Watchers = watcherIDs
+
 	return &pullrequestModel.ReassignReviewerResponse{
-		PR: &pullrequestModel.PullRequestResponse{
-			PullRequestID:     updatedPR.PullRequestID,
-			PullRequestName:   updatedPR.PullRequestName,
-			AuthorID:          updatedPR.AuthorID,
-			Status:            updatedPR.Status,
-			AssignedReviewers: reviewerIDs,
-			CreatedAt:         updatedPR.CreatedAt.Format(time.RFC3339),
-			MergedAt:          mergedAt,
-		},
+		PR:         prResp,
 		ReplacedBy: newReviewerID,
 	}, nil
 }
 
-// isReviewerAssigned checks if a user is assigned as reviewer.
-func isReviewerAssigned(reviewers []string, userID string) bool {
-	for _, reviewerID := range reviewers {
-		if reviewerID == userID {
-			return true
-		}
+// AssignReviewer assigns a named reviewer to an open pull request on behalf of the lead of its team.
+func (s *service) AssignReviewer(
+	ctx context.Context,
+	req *pullrequestModel.AssignReviewerRequest,
+) (*pullrequestModel.PullRequestResponse, error) {
+	if req.PullRequestID == "" {
+		return nil, pullrequestModel.ErrInvalidPullRequestID
 	}
-	return false
+	if req.UserID == "" || req.AssignedBy == "" {
+		return nil, errors.New("user_id and assigned_by are required")
+	}
+
+	var result *pullrequestModel.PullRequestResponse
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var txErr error
+		result, txErr = s.assignInTransaction(ctx, tx, req)
+		return txErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.notify(ctx, notification.Notification{
+		Event:         notification.EventReviewerAssigned,
+		PullRequestID: result.PullRequestID,
+		Recipients:    notification.Recipients([]string{req.UserID}, result.Watchers),
+		Details:       map[string]string{"user_id": req.UserID, "assigned_by": req.AssignedBy},
+	})
+
+	return result, nil
 }
 
-// filterCandidates filters out the PR author from candidate list.
-func filterCandidates(candidates []userModel.User, authorID string) []userModel.User {
-	filtered := make([]userModel.User, 0, len(candidates))
-	for _, candidate := range candidates {
-		if candidate.UserID != authorID {
-			filtered = append(filtered, candidate)
+// assignInTransaction validates and performs a manual reviewer assignment within a transaction.
+//
+//nolint:gocyclo // One check per business rule
+func (s *service) assignInTransaction(
+	ctx context.Context,
+	tx *gorm.DB,
+	req *pullrequestModel.AssignReviewerRequest,
+) (*pullrequestModel.PullRequestResponse, error) {
+	txRepo := s.txRepository(tx)
+
+	pr, err := txRepo.GetByID(ctx, req.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	switch pr.Status {
+	case pullrequestModel.StatusMERGED:
+		return nil, pullrequestModel.ErrPullRequestMerged
+	case pullrequestModel.StatusASSIGNING:
+		return nil, pullrequestModel.ErrAssignmentPending
+	case pullrequestModel.StatusCLOSED:
+		return nil, pullrequestModel.ErrPullRequestClosed
+	}
+
+	teamName, err := pullRequestTeam(ctx, txRepo, pr)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.checkTeamLead(ctx, txRepo, teamName, req.AssignedBy); err != nil {
+		return nil, err
+	}
+
+	if req.UserID == pr.AuthorID {
+		return nil, pullrequestModel.ErrAuthorCannotBeReviewer
+	}
+	reviewer, err := txRepo.GetUser(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if reviewer.TeamName != teamName {
+		return nil, pullrequestModel.ErrReviewerNotInTeam
+	}
+	if !reviewer.IsActive {
+		return nil, pullrequestModel.ErrReviewerInactive
+	}
+
+	reviewers, err := txRepo.GetReviewers(ctx, req.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	if isReviewerAssigned(reviewers, req.UserID) {
+		return nil, pullrequestModel.ErrReviewerAlreadyAssigned
+	}
+	if len(reviewers) >= pullrequestModel.MaxReviewersPerPR {
+		return nil, pullrequestModel.ErrMaxReviewersExceeded
+	}
+	if s.policy.ReviewerMaxLoad > 0 {
+		loads, loadErr := txRepo.GetReviewLoad(ctx, []string{req.UserID})
+		if loadErr != nil {
+			return nil, loadErr
+		}
+		if loads[req.UserID] >= s.policy.ReviewerMaxLoad {
+			return nil, pullrequestModel.ErrReviewerOverloaded
 		}
 	}
-	return filtered
+
+	if err = txRepo.AssignReviewer(ctx, req.PullRequestID, req.UserID); err != nil {
+		return nil, err
+	}
+	event := pullrequestModel.NewReviewerEvent(
+		req.PullRequestID, pullrequestModel.EventReviewerAssignedManually, pullrequestModel.SourceManual, req.UserID, "",
+	)
+	event.ActorID = &req.AssignedBy
+	if err = txRepo.AddEvent(ctx, event); err != nil {
+		return nil, err
+	}
+
+	watcherIDs, err := txRepo.GetWatchers(ctx, req.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	resp := newPullRequestResponse(pr, append(reviewers, req.UserID))
+	resp.Watchers = watcherIDs
+	return resp, nil
 }
 
-// selectRandomReviewers selects up to maxCount random reviewers from candidates.
-func selectRandomReviewers(candidates []userModel.User, maxCount int) []userModel.User {
-	if len(candidates) == 0 {
-		return []userModel.User{}
+// pullRequestTeam returns the team owning the pull request. Pull requests created before the owning
+// team was recorded belong to the author's team.
+func pullRequestTeam(ctx context.Context, repo repository.Repository, pr *pullrequestModel.PullRequest) (string, error) {
+	if pr.TeamName != nil {
+		return *pr.TeamName, nil
 	}
+	return repo.GetUserTeam(ctx, pr.AuthorID)
+}
 
-	count := maxCount
-	if len(candidates) < maxCount {
-		count = len(candidates)
+// checkTeamLead checks that the user performing a manual action leads the team.
+func (s *service) checkTeamLead(ctx context.Context, repo repository.Repository, teamName, userID string) error {
+	lead, err := repo.GetTeamLead(ctx, teamName)
+	if err != nil {
+		return err
+	}
+	if lead == "" || lead != userID {
+		return pullrequestModel.ErrNotTeamLead
 	}
+	return nil
+}
 
-	// Create a copy to avoid modifying original slice
-	candidatesCopy := make([]userModel.User, len(candidates))
-	copy(candidatesCopy, candidates)
+// UnassignReviewer removes a reviewer from an open pull request without assigning a replacement.
+func (s *service) UnassignReviewer(
+	ctx context.Context,
+	req *pullrequestModel.UnassignReviewerRequest,
+) (*pullrequestModel.PullRequestResponse, error) {
+	if req.PullRequestID == "" {
+		return nil, pullrequestModel.ErrInvalidPullRequestID
+	}
+	if req.UserID == "" {
+		return nil, errors.New("user_id is required")
+	}
 
-	// Shuffle using Fisher-Yates algorithm
-	// Using math/rand is acceptable for this use case (non-cryptographic, low RPS)
-	//nolint:gosec // G404: math/rand is sufficient for reviewer selection
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-	for i := len(candidatesCopy) - 1; i > 0; i-- {
-		j := r.Intn(i + 1)
-		candidatesCopy[i], candidatesCopy[j] = candidatesCopy[j], candidatesCopy[i]
+	var result *pullrequestModel.PullRequestResponse
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var txErr error
+		result, txErr = s.unassignInTransaction(ctx, tx, req)
+		return txErr
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return candidatesCopy[:count]
+	s.notify(ctx, notification.Notification{
+		Event:         notification.EventReviewerUnassigned,
+		PullRequestID: result.PullRequestID,
+		Recipients:    notification.Recipients([]string{req.UserID}, result.Watchers),
+		Details:       map[string]string{"user_id": req.UserID},
+	})
+
+	return result, nil
+}
+
+// unassignInTransaction validates and removes a reviewer within a transaction.
+func (s *service) unassignInTransaction(
+	ctx context.Context,
+	tx *gorm.DB,
+	req *pullrequestModel.UnassignReviewerRequest,
+) (*pullrequestModel.PullRequestResponse, error) {
+	txRepo := s.txRepository(tx)
+
+	pr, err := txRepo.GetByID(ctx, req.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	switch pr.Status {
+	case pullrequestModel.StatusMERGED:
+		return nil, pullrequestModel.ErrPullRequestMerged
+	case pullrequestModel.StatusASSIGNING:
+		return nil, pullrequestModel.ErrAssignmentPending
+	case pullrequestModel.StatusCLOSED:
+		return nil, pullrequestModel.ErrPullRequestClosed
+	}
+
+	reviewers, err := txRepo.GetReviewers(ctx, req.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	if !isReviewerAssigned(reviewers, req.UserID) {
+		return nil, pullrequestModel.ErrReviewerNotAssigned
+	}
+	if len(reviewers)-1 < s.policy.MinReviewers {
+		return nil, pullrequestModel.ErrTooFewReviewers
+	}
+
+	if err = txRepo.RemoveReviewer(ctx, req.PullRequestID, req.UserID); err != nil {
+		return nil, err
+	}
+	event := pullrequestModel.NewReviewerEvent(
+		req.PullRequestID, pullrequestModel.EventReviewerRemoved, pullrequestModel.SourceManual, req.UserID, "",
+	)
+	if err = txRepo.AddEvent(ctx, event); err != nil {
+		return nil, err
+	}
+
+	watcherIDs, err := txRepo.GetWatchers(ctx, req.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	remaining := make([]string, 0, len(reviewers)-1)
+	for _, id := range reviewers {
+		if id != req.UserID {
+			remaining = append(remaining, id)
+		}
+	}
+	resp := newPullRequestResponse(pr, remaining)
+	resp.Watchers = watcherIDs
+	return resp, nil
+}
+
+// reviewerChange is a deactivated reviewer replaced in a pull request, or removed when replacedBy is empty.
+type reviewerChange struct {
+	pr         *pullrequestModel.PullRequestResponse
+	oldUserID  string
+	replacedBy string
+}
+
+// ReplaceDeactivatedReviewers runs deactivate and replaces the users it returns in their open reviews
+// in one transaction.
+func (s *service) ReplaceDeactivatedReviewers(
+	ctx context.Context,
+	deactivate func(tx *gorm.DB) ([]string, error),
+) ([]string, error) {
+	var changes []reviewerChange
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		userIDs, txErr := deactivate(tx)
+		if txErr != nil || len(userIDs) == 0 {
+			return txErr
+		}
+		changes, txErr = s.replaceDeactivatedInTransaction(ctx, tx, userIDs)
+		return txErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	prIDs := make([]string, 0, len(changes))
+	for _, change := range changes {
+		if len(prIDs) == 0 || prIDs[len(prIDs)-1] != change.pr.PullRequestID {
+			prIDs = append(prIDs, change.pr.PullRequestID)
+		}
+	}
+	s.notifyReviewerChanges(ctx, changes)
+	return prIDs, nil
+}
+
+// notifyReviewerChanges notifies the replaced or removed reviewers, the remaining reviewers and the
+// watchers of each change.
+func (s *service) notifyReviewerChanges(ctx context.Context, changes []reviewerChange) {
+	for _, change := range changes {
+		if change.replacedBy == "" {
+			s.notify(ctx, notification.Notification{
+				Event:         notification.EventReviewerUnassigned,
+				PullRequestID: change.pr.PullRequestID,
+				Recipients:    notification.Recipients([]string{change.oldUserID}, change.pr.Watchers),
+				Details:       map[string]string{"user_id": change.oldUserID},
+			})
+			continue
+		}
+		s.notify(ctx, notification.Notification{
+			Event:         notification.EventReviewerReassigned,
+			PullRequestID: change.pr.PullRequestID,
+			Recipients: notification.Recipients(
+				[]string{change.oldUserID}, change.pr.AssignedReviewers, change.pr.Watchers,
+			),
+			Details: map[string]string{
+				"old_user_id": change.oldUserID,
+				"new_user_id": change.replacedBy,
+			},
+		})
+	}
+}
+
+// replaceDeactivatedInTransaction replaces userIDs in the open pull requests they review, in order of
+// pull_request_id, and returns the changes made.
+func (s *service) replaceDeactivatedInTransaction(
+	ctx context.Context,
+	tx *gorm.DB,
+	userIDs []string,
+) ([]reviewerChange, error) {
+	txRepo := s.txRepository(tx)
+
+	prIDs, err := txRepo.GetOpenPRsWithReviewers(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(prIDs)
+
+	var changes []reviewerChange
+	for _, prID := range prIDs {
+		pr, getErr := txRepo.GetByID(ctx, prID)
+		if getErr != nil {
+			return nil, getErr
+		}
+		reviewers, getErr := txRepo.GetReviewers(ctx, prID)
+		if getErr != nil {
+			return nil, getErr
+		}
+
+		var prChanges []reviewerChange
+		for _, userID := range userIDs {
+			if !isReviewerAssigned(reviewers, userID) {
+				continue
+			}
+			replacedBy, replaceErr := s.replaceDeactivatedReviewer(ctx, txRepo, pr, reviewers, userID)
+			if replaceErr != nil {
+				return nil, replaceErr
+			}
+			reviewers = replaceReviewer(reviewers, userID, replacedBy)
+			prChanges = append(prChanges, reviewerChange{oldUserID: userID, replacedBy: replacedBy})
+		}
+
+		watcherIDs, getErr := txRepo.GetWatchers(ctx, prID)
+		if getErr != nil {
+			return nil, getErr
+		}
+		resp := newPullRequestResponse(pr, reviewers)
+		resp.Watchers = watcherIDs
+		for i := range prChanges {
+			prChanges[i].pr = resp
+		}
+		changes = append(changes, prChanges...)
+	}
+	return changes, nil
+}
+
+// replaceDeactivatedReviewer removes a deactivated reviewer from the pull request and assigns the least
+// loaded active member of their team who is neither the author nor a reviewer instead, if there is one.
+// Returns the replacement, or an empty string if the reviewer was only removed.
+func (s *service) replaceDeactivatedReviewer(
+	ctx context.Context,
+	txRepo repository.Repository,
+	pr *pullrequestModel.PullRequest,
+	reviewers []string,
+	userID string,
+) (string, error) {
+	if err := txRepo.RemoveReviewer(ctx, pr.PullRequestID, userID); err != nil {
+		return "", err
+	}
+
+	teamName, err := txRepo.GetUserTeam(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	members, err := txRepo.GetActiveTeamMembers(ctx, teamName, pr.AuthorID)
+	if err != nil {
+		return "", err
+	}
+	candidates := make([]userModel.User, 0, len(members))
+	for _, member := range members {
+		if !isReviewerAssigned(reviewers, member.UserID) {
+			candidates = append(candidates, member)
+		}
+	}
+
+	var selected []userModel.User
+	if len(candidates) > 0 {
+		loads, loadErr := txRepo.GetReviewLoad(ctx, userIDs(candidates))
+		if loadErr != nil {
+			return "", loadErr
+		}
+		selected = s.selectReviewers(candidates, loads, 1)
+	}
+	if len(selected) == 0 {
+		return "", txRepo.AddEvent(ctx, pullrequestModel.NewReviewerEvent(
+			pr.PullRequestID, pullrequestModel.EventReviewerRemoved, pullrequestModel.SourceDeactivation, userID, "",
+		))
+	}
+
+	replacedBy := selected[0].UserID
+	if err = txRepo.AssignReviewer(ctx, pr.PullRequestID, replacedBy); err != nil {
+		return "", err
+	}
+	return replacedBy, txRepo.AddEvent(ctx, pullrequestModel.NewReviewerEvent(
+		pr.PullRequestID, pullrequestModel.EventReviewerReplaced, pullrequestModel.SourceDeactivation,
+		replacedBy, userID,
+	))
+}
+
+// replaceReviewer returns reviewers with oldUserID replaced by newUserID, or removed if newUserID is empty.
+func replaceReviewer(reviewers []string, oldUserID, newUserID string) []string {
+	replaced := make([]string, 0, len(reviewers))
+	for _, id := range reviewers {
+		if id != oldUserID {
+			replaced = append(replaced, id)
+		}
+	}
+	if newUserID != "" {
+		replaced = append(replaced, newUserID)
+	}
+	return replaced
+}
+
+// ChangeAuthor transfers authorship of an open pull request.
+func (s *service) ChangeAuthor(
+	ctx context.Context,
+	req *pullrequestModel.ChangeAuthorRequest,
+) (*pullrequestModel.PullRequestResponse, error) {
+	if req.PullRequestID == "" {
+		return nil, pullrequestModel.ErrInvalidPullRequestID
+	}
+	if req.AuthorID == "" {
+		return nil, pullrequestModel.ErrInvalidAuthorID
+	}
+
+	var (
+		result         *pullrequestModel.PullRequestResponse
+		previousAuthor string
+	)
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var txErr error
+		result, previousAuthor, txErr = s.changeAuthorInTransaction(ctx, tx, req)
+		return txErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if previousAuthor != req.AuthorID {
+		s.notify(ctx, notification.Notification{
+			Event:         notification.EventAuthorChanged,
+			PullRequestID: result.PullRequestID,
+			Recipients: notification.Recipients(
+				[]string{req.AuthorID, previousAuthor}, result.AssignedReviewers, result.Watchers,
+			),
+			Details: map[string]string{"author_id": req.AuthorID, "previous_author_id": previousAuthor},
+		})
+	}
+
+	return result, nil
+}
+
+// changeAuthorInTransaction transfers authorship within a transaction and returns the updated PR
+// together with the previous author. Transferring to the current author changes nothing.
+func (s *service) changeAuthorInTransaction(
+	ctx context.Context,
+	tx *gorm.DB,
+	req *pullrequestModel.ChangeAuthorRequest,
+) (*pullrequestModel.PullRequestResponse, string, error) {
+	txRepo := s.txRepository(tx)
+
+	pr, err := txRepo.GetByID(ctx, req.PullRequestID)
+	if err != nil {
+		return nil, "", err
+	}
+	switch pr.Status {
+	case pullrequestModel.StatusMERGED:
+		return nil, "", pullrequestModel.ErrPullRequestMerged
+	case pullrequestModel.StatusASSIGNING:
+		return nil, "", pullrequestModel.ErrAssignmentPending
+	case pullrequestModel.StatusCLOSED:
+		return nil, "", pullrequestModel.ErrPullRequestClosed
+	}
+	previousAuthor := pr.AuthorID
+
+	if previousAuthor != req.AuthorID {
+		author, userErr := txRepo.GetUser(ctx, req.AuthorID)
+		if userErr != nil {
+			return nil, "", userErr
+		}
+		if err = txRepo.UpdateAuthor(ctx, req.PullRequestID, req.AuthorID); err != nil {
+			return nil, "", err
+		}
+		event := pullrequestModel.NewPullRequestEvent(
+			req.PullRequestID, pullrequestModel.EventAuthorChanged, req.AuthorID, previousAuthor,
+		)
+		if err = txRepo.AddEvent(ctx, event); err != nil {
+			return nil, "", err
+		}
+		if err = s.replaceAuthorAsReviewer(ctx, txRepo, req.PullRequestID, author, previousAuthor); err != nil {
+			return nil, "", err
+		}
+		pr.AuthorID = req.AuthorID
+	}
+
+	reviewers, err := txRepo.GetReviewers(ctx, req.PullRequestID)
+	if err != nil {
+		return nil, "", err
+	}
+	watcherIDs, err := txRepo.GetWatchers(ctx, req.PullRequestID)
+	if err != nil {
+		return nil, "", err
+	}
+	resp := newPullRequestResponse(pr, reviewers)
+	resp.Watchers = watcherIDs
+	return resp, previousAuthor, nil
+}
+
+// replaceAuthorAsReviewer removes the new author from the reviewers of the pull request and assigns
+// the least loaded active member of the author's team instead, if there is one. The previous author
+// wrote the changes and is not a candidate.
+func (s *service) replaceAuthorAsReviewer(
+	ctx context.Context,
+	txRepo repository.Repository,
+	prID string,
+	author *userModel.User,
+	previousAuthor string,
+) error {
+	reviewers, err := txRepo.GetReviewers(ctx, prID)
+	if err != nil {
+		return err
+	}
+	if !isReviewerAssigned(reviewers, author.UserID) {
+		return nil
+	}
+	if err = txRepo.RemoveReviewer(ctx, prID, author.UserID); err != nil {
+		return err
+	}
+
+	members, err := txRepo.GetActiveTeamMembers(ctx, author.TeamName, author.UserID)
+	if err != nil {
+		return err
+	}
+	candidates := make([]userModel.User, 0, len(members))
+	for _, member := range members {
+		if member.UserID != previousAuthor && !isReviewerAssigned(reviewers, member.UserID) {
+			candidates = append(candidates, member)
+		}
+	}
+
+	var selected []userModel.User
+	if len(candidates) > 0 {
+		loads, loadErr := txRepo.GetReviewLoad(ctx, userIDs(candidates))
+		if loadErr != nil {
+			return loadErr
+		}
+		selected = s.selectReviewers(candidates, loads, 1)
+	}
+	if len(selected) == 0 {
+		return txRepo.AddEvent(ctx, pullrequestModel.NewReviewerEvent(
+			prID, pullrequestModel.EventReviewerRemoved, pullrequestModel.SourceAuthorChange, author.UserID, "",
+		))
+	}
+
+	if err := txRepo.AssignReviewer(ctx, prID, selected[0].UserID); err != nil {
+		return err
+	}
+	return txRepo.AddEvent(ctx, pullrequestModel.NewReviewerEvent(
+		prID, pullrequestModel.EventReviewerReplaced, pullrequestModel.SourceAuthorChange,
+		selected[0].UserID, author.UserID,
+	))
+}
+
+// TransferTeam replaces the reviewers of an open pull request with members of another team.
+func (s *service) TransferTeam(
+	ctx context.Context,
+	req *pullrequestModel.TransferTeamRequest,
+) (*pullrequestModel.PullRequestResponse, error) {
+	if req.PullRequestID == "" {
+		return nil, pullrequestModel.ErrInvalidPullRequestID
+	}
+	if req.TeamName == "" {
+		return nil, errors.New("team_name is required")
+	}
+
+	var (
+		result  *pullrequestModel.PullRequestResponse
+		removed []string
+	)
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var txErr error
+		result, removed, txErr = s.transferTeamInTransaction(ctx, tx, req)
+		return txErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Infow("pull request transferred to team",
+		"pull_request_id", req.PullRequestID,
+		"team_name", req.TeamName,
+		"removed_reviewers", removed,
+		"assigned_reviewers", result.AssignedReviewers,
+	)
+	s.notify(ctx, notification.Notification{
+		Event:         notification.EventTeamTransferred,
+		PullRequestID: result.PullRequestID,
+		Recipients:    notification.Recipients(result.AssignedReviewers, removed, result.Watchers),
+		Details:       map[string]string{"team_name": req.TeamName},
+	})
+
+	return result, nil
+}
+
+// transferTeamInTransaction selects reviewers from the destination team and swaps them with the
+// current reviewers within a transaction. Reviewers selected again are kept. Returns the updated PR
+// and the removed reviewers.
+func (s *service) transferTeamInTransaction(
+	ctx context.Context,
+	tx *gorm.DB,
+	req *pullrequestModel.TransferTeamRequest,
+) (*pullrequestModel.PullRequestResponse, []string, error) {
+	txRepo := s.txRepository(tx)
+
+	pr, err := txRepo.GetByID(ctx, req.PullRequestID)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch pr.Status {
+	case pullrequestModel.StatusMERGED:
+		return nil, nil, pullrequestModel.ErrPullRequestMerged
+	case pullrequestModel.StatusASSIGNING:
+		return nil, nil, pullrequestModel.ErrAssignmentPending
+	case pullrequestModel.StatusCLOSED:
+		return nil, nil, pullrequestModel.ErrPullRequestClosed
+	}
+
+	exists, err := txRepo.TeamExists(ctx, req.TeamName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !exists {
+		return nil, nil, teamModel.ErrTeamNotFound
+	}
+
+	candidates, err := txRepo.GetActiveTeamMembers(ctx, req.TeamName, pr.AuthorID)
+	if err != nil {
+		return nil, nil, err
+	}
+	loads, err := txRepo.GetReviewLoad(ctx, userIDs(candidates))
+	if err != nil {
+		return nil, nil, err
+	}
+	selected := userIDs(s.selectReviewers(candidates, loads, pullrequestModel.MaxReviewersPerPR))
+
+	if err = txRepo.UpdateTeam(ctx, req.PullRequestID, req.TeamName); err != nil {
+		return nil, nil, err
+	}
+	pr.TeamName = &req.TeamName
+
+	current, err := txRepo.GetReviewers(ctx, req.PullRequestID)
+	if err != nil {
+		return nil, nil, err
+	}
+	removed, err := s.swapReviewers(ctx, txRepo, req.PullRequestID, current, selected)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	watcherIDs, err := txRepo.GetWatchers(ctx, req.PullRequestID)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp := newPullRequestResponse(pr, selected)
+	resp.Watchers = watcherIDs
+	return resp, removed, nil
+}
+
+// swapReviewers removes current reviewers missing from selected and assigns the selected ones not yet
+// assigned, recording each change in the activity log. Returns the removed reviewers.
+func (s *service) swapReviewers(
+	ctx context.Context,
+	txRepo repository.Repository,
+	prID string,
+	current, selected []string,
+) ([]string, error) {
+	removed := make([]string, 0, len(current))
+	for _, userID := range current {
+		if isReviewerAssigned(selected, userID) {
+			continue
+		}
+		if err := txRepo.RemoveReviewer(ctx, prID, userID); err != nil {
+			return nil, err
+		}
+		event := pullrequestModel.NewReviewerEvent(
+			prID, pullrequestModel.EventReviewerRemoved, pullrequestModel.SourceTeamTransfer, userID, "",
+		)
+		if err := txRepo.AddEvent(ctx, event); err != nil {
+			return nil, err
+		}
+		removed = append(removed, userID)
+	}
+
+	for _, userID := range selected {
+		if isReviewerAssigned(current, userID) {
+			continue
+		}
+		if err := txRepo.AssignReviewer(ctx, prID, userID); err != nil {
+			return nil, err
+		}
+		event := pullrequestModel.NewReviewerEvent(
+			prID, pullrequestModel.EventReviewerAssigned, pullrequestModel.SourceTeamTransfer, userID, "",
+		)
+		if err := txRepo.AddEvent(ctx, event); err != nil {
+			return nil, err
+		}
+	}
+	return removed, nil
+}
+
+// WatchPullRequest subscribes a user to lifecycle notifications of a pull request.
+func (s *service) WatchPullRequest(
+	ctx context.Context,
+	req *pullrequestModel.WatchPullRequestRequest,
+) (*pullrequestModel.PullRequestResponse, error) {
+	if req.PullRequestID == "" {
+		return nil, pullrequestModel.ErrInvalidPullRequestID
+	}
+	if req.UserID == "" {
+		return nil, errors.New("user_id is required")
+	}
+
+	var result *pullrequestModel.PullRequestResponse
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txRepo := s.txRepository(tx)
+
+		pr, txErr := txRepo.GetByID(ctx, req.PullRequestID)
+		if txErr != nil {
+			return txErr
+		}
+
+		// Ensure the watcher exists
+		if _, txErr = txRepo.GetUserTeam(ctx, req.UserID); txErr != nil {
+			return txErr
+		}
+
+		if txErr = txRepo.AddWatcher(ctx, req.PullRequestID, req.UserID); txErr != nil {
+			return txErr
+		}
+
+		reviewerIDs, txErr := txRepo.GetReviewers(ctx, req.PullRequestID)
+		if txErr != nil {
+			return txErr
+		}
+
+		watcherIDs, txErr := txRepo.GetWatchers(ctx, req.PullRequestID)
+		if txErr != nil {
+			return txErr
+		}
+
+		result = newPullRequestResponse(pr, reviewerIDs)
+		result.Watchers = watcherIDs
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetActivity returns the activity log of a pull request ordered by srt.
+func (s *service) GetActivity(
+	ctx context.Context,
+	prID string,
+	srt sortparam.Sort,
+) (*pullrequestModel.PullRequestActivityResponse, error) {
+	if prID == "" || len(prID) > 255 {
+		return nil, pullrequestModel.ErrInvalidPullRequestID
+	}
+
+	// Check that PR exists to distinguish unknown PRs from PRs without events
+	if _, err := s.repo.GetByID(ctx, prID); err != nil {
+		return nil, err
+	}
+
+	events, err := s.repo.GetEvents(ctx, prID, srt)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pullrequestModel.PullRequestActivityResponse{
+		PullRequestID: prID,
+		Events:        make([]pullrequestModel.PullRequestEventResponse, 0, len(events)),
+	}
+	for _, event := range events {
+		item := pullrequestModel.PullRequestEventResponse{
+			Type:      event.EventType,
+			CreatedAt: event.CreatedAt.Format(time.RFC3339),
+		}
+		if event.UserID != nil {
+			item.UserID = *event.UserID
+		}
+		if event.PreviousUserID != nil {
+			item.PreviousUserID = *event.PreviousUserID
+		}
+		if event.ActorID != nil {
+			item.ActorID = *event.ActorID
+		}
+		if event.Reason != nil {
+			item.Reason = *event.Reason
+		}
+		if event.Source != nil {
+			item.Source = *event.Source
+		}
+		resp.Events = append(resp.Events, item)
+	}
+
+	return resp, nil
+}
+
+// ListPullRequests returns a page of pull requests matching the filters of query, newest first.
+func (s *service) ListPullRequests(
+	ctx context.Context,
+	query pullrequestModel.ListQuery,
+) (*pullrequestModel.ListPullRequestsResponse, error) {
+	s.logger.Debugw("ListPullRequests called",
+		"status", query.Status, "author_id", query.AuthorID, "team_name", query.TeamName,
+		"reviewer_id", query.ReviewerID, "limit", query.Limit)
+
+	if query.Status != "" {
+		if err := pullrequestModel.ValidateStatus(query.Status); err != nil {
+			return nil, err
+		}
+	}
+	limit, err := s.limits.Resolve(query.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// One extra PR tells whether another page follows
+	repoQuery := query
+	repoQuery.Limit = limit + 1
+	prs, err := s.repo.List(ctx, repoQuery)
+	if err != nil {
+		s.logger.Errorw("ListPullRequests failed", "error", err)
+		return nil, err
+	}
+
+	resp := &pullrequestModel.ListPullRequestsResponse{}
+	if len(prs) > limit {
+		prs = prs[:limit]
+		last := prs[limit-1]
+		resp.Next = &pullrequestModel.ListPosition{CreatedAt: last.CreatedAt, PullRequestID: last.PullRequestID}
+	}
+
+	prIDs := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		prIDs = append(prIDs, pr.PullRequestID)
+	}
+	reviewers, err := s.repo.GetReviewersByPullRequests(ctx, prIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.PullRequests = make([]pullrequestModel.PullRequestResponse, 0, len(prs))
+	for i := range prs {
+		reviewerIDs := reviewers[prs[i].PullRequestID]
+		if reviewerIDs == nil {
+			reviewerIDs = []string{}
+		}
+		resp.PullRequests = append(resp.PullRequests, *newPullRequestResponse(&prs[i], reviewerIDs))
+	}
+
+	s.logger.Debugw("ListPullRequests completed", "pr_count", len(resp.PullRequests))
+	return resp, nil
+}
+
+// SetConflicts updates the merge-conflict flag of an open pull request.
+func (s *service) SetConflicts(
+	ctx context.Context,
+	req *pullrequestModel.SetConflictsRequest,
+) (*pullrequestModel.PullRequestResponse, error) {
+	if req.PullRequestID == "" {
+		return nil, pullrequestModel.ErrInvalidPullRequestID
+	}
+	if req.HasConflicts == nil {
+		return nil, errors.New("has_conflicts is required")
+	}
+
+	var result *pullrequestModel.PullRequestResponse
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txRepo := s.txRepository(tx)
+
+		pr, txErr := txRepo.GetByID(ctx, req.PullRequestID)
+		if txErr != nil {
+			return txErr
+		}
+
+		// Conflicts are irrelevant once the PR is merged or closed
+		switch pr.Status {
+		case pullrequestModel.StatusMERGED:
+			return pullrequestModel.ErrPullRequestMerged
+		case pullrequestModel.StatusCLOSED:
+			return pullrequestModel.ErrPullRequestClosed
+		}
+
+		if txErr = txRepo.SetHasConflicts(ctx, req.PullRequestID, *req.HasConflicts); txErr != nil {
+			return txErr
+		}
+		pr.HasConflicts = *req.HasConflicts
+
+		reviewerIDs, txErr := txRepo.GetReviewers(ctx, req.PullRequestID)
+		if txErr != nil {
+			return txErr
+		}
+		watcherIDs, txErr := txRepo.GetWatchers(ctx, req.PullRequestID)
+		if txErr != nil {
+			return txErr
+		}
+
+		result = newPullRequestResponse(pr, reviewerIDs)
+		result.Watchers = watcherIDs
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// AssignReviewers assigns reviewers to a pull request in ASSIGNING status and moves it to OPEN.
+func (s *service) AssignReviewers(ctx context.Context, prID string) error {
+	var result *pullrequestModel.PullRequestResponse
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var txErr error
+		result, txErr = s.assignPendingInTransaction(ctx, tx, prID)
+		return txErr
+	})
+	if err != nil {
+		return err
+	}
+
+	// Already assigned by another worker or merged in the meantime
+	if result == nil {
+		return nil
+	}
+
+	s.notify(ctx, notification.Notification{
+		Event:         notification.EventPullRequestCreated,
+		PullRequestID: result.PullRequestID,
+		Recipients:    result.AssignedReviewers,
+	})
+	return nil
+}
+
+// assignPendingInTransaction selects and assigns reviewers for a pull request waiting in ASSIGNING status.
+// The status transition happens first so that concurrent workers cannot assign the same PR twice.
+func (s *service) assignPendingInTransaction(
+	ctx context.Context,
+	tx *gorm.DB,
+	prID string,
+) (*pullrequestModel.PullRequestResponse, error) {
+	txRepo := s.txRepository(tx)
+
+	changed, err := txRepo.TransitionStatus(ctx, prID, pullrequestModel.StatusASSIGNING, pullrequestModel.StatusOPEN)
+	if err != nil {
+		return nil, err
+	}
+	if !changed {
+		return nil, nil
+	}
+
+	pr, err := txRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	teamName, err := pullRequestTeam(ctx, txRepo, pr)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := txRepo.GetActiveTeamMembers(ctx, teamName, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	loads, err := txRepo.GetReviewLoad(ctx, userIDs(candidates))
+	if err != nil {
+		return nil, err
+	}
+	selected := s.selectReviewers(candidates, loads, pullrequestModel.MaxReviewersPerPR)
+
+	if err = assignSelectedReviewers(ctx, txRepo, pr, selected); err != nil {
+		return nil, err
+	}
+
+	reviewerIDs, err := txRepo.GetReviewers(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	return newPullRequestResponse(pr, reviewerIDs), nil
+}
+
+// ResumeAssignments enqueues all pull requests left in ASSIGNING status (e.g. after a restart).
+func (s *service) ResumeAssignments(ctx context.Context) (int, error) {
+	if s.queue == nil {
+		return 0, nil
+	}
+
+	ids, err := s.repo.GetIDsByStatus(ctx, pullrequestModel.StatusASSIGNING)
+	if err != nil {
+		return 0, err
+	}
+
+	enqueued := 0
+	for _, id := range ids {
+		if !s.queue.Enqueue(id) {
+			break
+		}
+		enqueued++
+	}
+	return enqueued, nil
+}
+
+// GetAssignmentStatus returns the reviewer assignment state of a pull request.
+func (s *service) GetAssignmentStatus(
+	ctx context.Context,
+	prID string,
+) (*pullrequestModel.AssignmentStatusResponse, error) {
+	if prID == "" || len(prID) > 255 {
+		return nil, pullrequestModel.ErrInvalidPullRequestID
+	}
+
+	pr, err := s.repo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewerIDs, err := s.repo.GetReviewers(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pullrequestModel.AssignmentStatusResponse{
+		PullRequestID:     pr.PullRequestID,
+		Status:            pr.Status,
+		Pending:           pr.Status == pullrequestModel.StatusASSIGNING,
+		AssignedReviewers: reviewerIDs,
+	}, nil
+}
+
+// PreviewAssignment runs reviewer selection for a hypothetical PR of the author without writing anything.
+func (s *service) PreviewAssignment(
+	ctx context.Context,
+	req *pullrequestModel.PreviewAssignmentRequest,
+) (*pullrequestModel.PreviewAssignmentResponse, error) {
+	if req.AuthorID == "" {
+		return nil, pullrequestModel.ErrInvalidAuthorID
+	}
+
+	teamName, err := s.repo.GetUserTeam(ctx, req.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := s.repo.GetActiveTeamMembers(ctx, teamName, req.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	loads, err := s.repo.GetReviewLoad(ctx, userIDs(candidates))
+	if err != nil {
+		return nil, err
+	}
+	selected := s.selectReviewers(candidates, loads, pullrequestModel.MaxReviewersPerPR)
+
+	return &pullrequestModel.PreviewAssignmentResponse{
+		AuthorID:          req.AuthorID,
+		TeamName:          teamName,
+		AssignedReviewers: userIDs(selected),
+		Candidates:        rankCandidates(candidates, loads),
+	}, nil
+}
+
+// GetCandidates returns active members of the team owning a PR who can be assigned as its reviewers.
+func (s *service) GetCandidates(
+	ctx context.Context,
+	prID string,
+) (*pullrequestModel.PullRequestCandidatesResponse, error) {
+	_, candidates, loads, err := s.candidates(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pullrequestModel.PullRequestCandidatesResponse{
+		PullRequestID: prID,
+		Candidates:    rankCandidates(candidates, loads),
+	}, nil
+}
+
+// SuggestReviewers ranks the candidates of a PR by review history and current load.
+func (s *service) SuggestReviewers(
+	ctx context.Context,
+	prID string,
+) (*pullrequestModel.SuggestReviewersResponse, error) {
+	pr, candidates, loads, err := s.candidates(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	targetBranch := ""
+	if pr.TargetBranch != nil {
+		targetBranch = *pr.TargetBranch
+	}
+	since := s.clock.Now().Add(-pullrequestModel.SuggestionHistoryWindow)
+	history, err := s.repo.GetReviewHistory(ctx, userIDs(candidates), pr.AuthorID, targetBranch, since)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]pullrequestModel.ReviewerSuggestion, 0, len(candidates))
+	for _, candidate := range candidates {
+		h := history[candidate.UserID]
+		suggestions = append(suggestions, pullrequestModel.ReviewerSuggestion{
+			UserID:        candidate.UserID,
+			Score:         pullrequestModel.SuggestionScore(h, loads[candidate.UserID]),
+			AuthorReviews: h.AuthorReviews,
+			BranchReviews: h.BranchReviews,
+			ReviewLoad:    loads[candidate.UserID],
+		})
+	}
+	// Best score first; less loaded and then by user_id among equals
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.ReviewLoad != b.ReviewLoad {
+			return a.ReviewLoad < b.ReviewLoad
+		}
+		return a.UserID < b.UserID
+	})
+
+	return &pullrequestModel.SuggestReviewersResponse{
+		PullRequestID: prID,
+		Suggestions:   suggestions,
+	}, nil
+}
+
+// candidates returns an open PR with the active members of its team who can be assigned as its
+// reviewers (everyone except the author and the assigned reviewers) and their review load.
+func (s *service) candidates(
+	ctx context.Context,
+	prID string,
+) (*pullrequestModel.PullRequest, []userModel.User, map[string]int, error) {
+	if prID == "" || len(prID) > 255 {
+		return nil, nil, nil, pullrequestModel.ErrInvalidPullRequestID
+	}
+
+	pr, err := s.repo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	switch pr.Status {
+	case pullrequestModel.StatusMERGED:
+		return nil, nil, nil, pullrequestModel.ErrPullRequestMerged
+	case pullrequestModel.StatusCLOSED:
+		return nil, nil, nil, pullrequestModel.ErrPullRequestClosed
+	}
+
+	teamName, err := pullRequestTeam(ctx, s.repo, pr)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	members, err := s.repo.GetActiveTeamMembers(ctx, teamName, pr.AuthorID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	reviewers, err := s.repo.GetReviewers(ctx, prID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	candidates := make([]userModel.User, 0, len(members))
+	for _, member := range members {
+		if !isReviewerAssigned(reviewers, member.UserID) {
+			candidates = append(candidates, member)
+		}
+	}
+	loads, err := s.repo.GetReviewLoad(ctx, userIDs(candidates))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return pr, candidates, loads, nil
+}
+
+// notify sends a notification; delivery failures are logged and never fail the operation.
+func (s *service) notify(ctx context.Context, n notification.Notification) {
+	if err := s.notifier.Notify(ctx, n); err != nil {
+		s.logger.Warnw("failed to send notification",
+			"event", n.Event,
+			"pull_request_id", n.PullRequestID,
+			"error", err,
+		)
+	}
+}
+
+// newPullRequestResponse builds the API representation of a pull request.
+func newPullRequestResponse(
+	pr *pullrequestModel.PullRequest,
+	reviewerIDs []string,
+) *pullrequestModel.PullRequestResponse {
+	resp := &pullrequestModel.PullRequestResponse{
+		PullRequestID:     pr.PullRequestID,
+		PullRequestName:   pr.PullRequestName,
+		AuthorID:          pr.AuthorID,
+		Status:            pr.Status,
+		AssignedReviewers: reviewerIDs,
+		CreatedAt:         pr.CreatedAt.Format(time.RFC3339),
+		LinesAdded:        pr.LinesAdded,
+		LinesRemoved:      pr.LinesRemoved,
+		HasConflicts:      pr.HasConflicts,
+	}
+	if pr.MergedAt != nil {
+		resp.MergedAt = pr.MergedAt.Format(time.RFC3339)
+	}
+	if pr.ArchivedAt != nil {
+		resp.ArchivedAt = pr.ArchivedAt.Format(time.RFC3339)
+	}
+	if pr.SourceBranch != nil {
+		resp.SourceBranch = *pr.SourceBranch
+	}
+	if pr.TargetBranch != nil {
+		resp.TargetBranch = *pr.TargetBranch
+	}
+	if pr.PullRequestURL != nil {
+		resp.PullRequestURL = *pr.PullRequestURL
+	}
+	if pr.CloseReason != nil {
+		resp.CloseReason = *pr.CloseReason
+	}
+	if pr.CloseNote != nil {
+		resp.CloseNote = *pr.CloseNote
+	}
+	return resp
+}
+
+// optionalString converts an empty string to nil for nullable columns.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// isReviewerAssigned checks if a user is assigned as reviewer.
+func isReviewerAssigned(reviewers []string, userID string) bool {
+	for _, reviewerID := range reviewers {
+		if reviewerID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// filterCandidates filters out the PR author from candidate list.
+func filterCandidates(candidates []userModel.User, authorID string) []userModel.User {
+	filtered := make([]userModel.User, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate.UserID != authorID {
+			filtered = append(filtered, candidate)
+		}
+	}
+	return filtered
+}
+
+// selectRandomReviewers selects up to maxCount random reviewers from candidates.
+func selectRandomReviewers(candidates []userModel.User, maxCount int) []userModel.User {
+	if len(candidates) == 0 {
+		return []userModel.User{}
+	}
+
+	count := maxCount
+	if len(candidates) < maxCount {
+		count = len(candidates)
+	}
+
+	// Create a copy to avoid modifying original slice
+	candidatesCopy := make([]userModel.User, len(candidates))
+	copy(candidatesCopy, candidates)
+
+	// Shuffle using Fisher-Yates algorithm
+	// Using math/rand is acceptable for this use case (non-cryptographic, low RPS)
+	//nolint:gosec // G404: math/rand is sufficient for reviewer selection
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for i := len(candidatesCopy) - 1; i > 0; i-- {
+		j := r.Intn(i + 1)
+		candidatesCopy[i], candidatesCopy[j] = candidatesCopy[j], candidatesCopy[i]
+	}
+
+	return candidatesCopy[:count]
+}
+
+// sortByUserID returns a copy of candidates ordered by user_id.
+func sortByUserID(candidates []userModel.User) []userModel.User {
+	sorted := make([]userModel.User, len(candidates))
+	copy(sorted, candidates)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].UserID < sorted[j].UserID
+	})
+	return sorted
+}
+
+// selectReviewers selects up to maxCount least loaded candidates, breaking ties according to the policy.
+func (s *service) selectReviewers(
+	candidates []userModel.User,
+	loads map[string]int,
+	maxCount int,
+) []userModel.User {
+	if s.policy.DeterministicAssignment {
+		return leastLoaded(sortByUserID(candidates), loads, maxCount)
+	}
+	return selectLeastLoadedReviewers(candidates, loads, maxCount)
+}
+
+// selectLeastLoadedReviewers selects up to maxCount candidates with the lowest review load.
+// Candidates with equal load are picked in random order.
+func selectLeastLoadedReviewers(
+	candidates []userModel.User,
+	loads map[string]int,
+	maxCount int,
+) []userModel.User {
+	return leastLoaded(selectRandomReviewers(candidates, len(candidates)), loads, maxCount)
+}
+
+// leastLoaded stably sorts ordered by review load and returns up to maxCount first candidates.
+func leastLoaded(ordered []userModel.User, loads map[string]int, maxCount int) []userModel.User {
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return loads[ordered[i].UserID] < loads[ordered[j].UserID]
+	})
+
+	if len(ordered) > maxCount {
+		return ordered[:maxCount]
+	}
+	return ordered
+}
+
+// rankCandidates lists candidates with their review load, least loaded first and by user_id among equals.
+func rankCandidates(candidates []userModel.User, loads map[string]int) []pullrequestModel.AssignmentCandidate {
+	ranked := make([]pullrequestModel.AssignmentCandidate, 0, len(candidates))
+	for _, candidate := range leastLoaded(sortByUserID(candidates), loads, len(candidates)) {
+		ranked = append(ranked, pullrequestModel.AssignmentCandidate{
+			UserID:     candidate.UserID,
+			ReviewLoad: loads[candidate.UserID],
+		})
+	}
+	return ranked
+}
+
+// userIDs extracts user IDs from a list of users.
+func userIDs(users []userModel.User) []string {
+	ids := make([]string, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.UserID)
+	}
+	return ids
 }
@@ -2,6 +2,9 @@ package service
 
 import (
 	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -9,12 +12,17 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
-	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 
+	"github.com/festy23/avito_internship/internal/notification"
 	pullrequestModel "github.com/festy23/avito_internship/internal/pullrequest/model"
 	"github.com/festy23/avito_internship/internal/pullrequest/repository"
+	teamModel "github.com/festy23/avito_internship/internal/team/model"
+	"github.com/festy23/avito_internship/internal/testutil"
 	userModel "github.com/festy23/avito_internship/internal/user/model"
+	"github.com/festy23/avito_internship/pkg/clock"
+	"github.com/festy23/avito_internship/pkg/pagination"
+	"github.com/festy23/avito_internship/pkg/sortparam"
 )
 
 // mockRepository is a mock implementation of repository.Repository for unit tests.
@@ -24,9 +32,9 @@ type mockRepository struct {
 
 func (m *mockRepository) Create(
 	ctx context.Context,
-	prID, prName, authorID string,
+	pr *pullrequestModel.PullRequest,
 ) (*pullrequestModel.PullRequest, error) {
-	args := m.Called(ctx, prID, prName, authorID)
+	args := m.Called(ctx, pr)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -54,6 +62,69 @@ func (m *mockRepository) UpdateStatus(
 	return args.Error(0)
 }
 
+func (m *mockRepository) TransitionStatus(ctx context.Context, prID, from, to string) (bool, error) {
+	args := m.Called(ctx, prID, from, to)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockRepository) GetIDsByStatus(ctx context.Context, status string) ([]string, error) {
+	args := m.Called(ctx, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *mockRepository) SetHasConflicts(ctx context.Context, prID string, hasConflicts bool) error {
+	args := m.Called(ctx, prID, hasConflicts)
+	return args.Error(0)
+}
+
+func (m *mockRepository) SetCloseReason(ctx context.Context, prID string, reason, note *string) error {
+	args := m.Called(ctx, prID, reason, note)
+	return args.Error(0)
+}
+
+func (m *mockRepository) UpdateAuthor(ctx context.Context, prID, authorID string) error {
+	args := m.Called(ctx, prID, authorID)
+	return args.Error(0)
+}
+
+func (m *mockRepository) UpdateTeam(ctx context.Context, prID, teamName string) error {
+	args := m.Called(ctx, prID, teamName)
+	return args.Error(0)
+}
+
+func (m *mockRepository) GetOpenByAuthor(
+	ctx context.Context,
+	authorID string,
+) ([]pullrequestModel.PullRequest, error) {
+	args := m.Called(ctx, authorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]pullrequestModel.PullRequest), args.Error(1)
+}
+
+func (m *mockRepository) List(
+	ctx context.Context,
+	query pullrequestModel.ListQuery,
+) ([]pullrequestModel.PullRequest, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]pullrequestModel.PullRequest), args.Error(1)
+}
+
+func (m *mockRepository) GetReviewersByPullRequests(ctx context.Context, prIDs []string) (map[string][]string, error) {
+	args := m.Called(ctx, prIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string][]string), args.Error(1)
+}
+
 func (m *mockRepository) AssignReviewer(ctx context.Context, prID, userID string) error {
 	args := m.Called(ctx, prID, userID)
 	return args.Error(0)
@@ -72,6 +143,36 @@ func (m *mockRepository) GetReviewers(ctx context.Context, prID string) ([]strin
 	return args.Get(0).([]string), args.Error(1)
 }
 
+func (m *mockRepository) AddWatcher(ctx context.Context, prID, userID string) error {
+	args := m.Called(ctx, prID, userID)
+	return args.Error(0)
+}
+
+func (m *mockRepository) GetWatchers(ctx context.Context, prID string) ([]string, error) {
+	args := m.Called(ctx, prID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *mockRepository) AddEvent(ctx context.Context, event *pullrequestModel.PullRequestEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *mockRepository) GetEvents(
+	ctx context.Context,
+	prID string,
+	srt sortparam.Sort,
+) ([]pullrequestModel.PullRequestEvent, error) {
+	args := m.Called(ctx, prID, srt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]pullrequestModel.PullRequestEvent), args.Error(1)
+}
+
 func (m *mockRepository) GetActiveTeamMembers(
 	ctx context.Context,
 	teamName string,
@@ -105,62 +206,147 @@ func (m *mockRepository) GetOpenPRsWithAuthors(ctx context.Context, reviewerIDs
 	return args.Get(0).(map[string]string), args.Error(1)
 }
 
-func setupTestDB(t *testing.T) *gorm.DB {
-	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
-	require.NoError(t, err)
+func (m *mockRepository) ArchiveMergedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	args := m.Called(ctx, cutoff)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *mockRepository) GetOpenReviewAssignments(
+	ctx context.Context,
+) ([]pullrequestModel.ReviewAssignment, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]pullrequestModel.ReviewAssignment), args.Error(1)
+}
+
+func (m *mockRepository) GetSLACandidates(ctx context.Context) ([]pullrequestModel.SLACandidate, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]pullrequestModel.SLACandidate), args.Error(1)
+}
 
-	// Define test models
-	type Team struct {
-		TeamName  string    `gorm:"primaryKey;column:team_name"`
-		CreatedAt time.Time `gorm:"column:created_at"`
-		UpdatedAt time.Time `gorm:"column:updated_at"`
+func (m *mockRepository) GetStaleCandidates(
+	ctx context.Context,
+	cutoff time.Time,
+) ([]pullrequestModel.StaleCandidate, error) {
+	args := m.Called(ctx, cutoff)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	type User struct {
-		UserID    string    `gorm:"primaryKey;column:user_id"`
-		Username  string    `gorm:"column:username"`
-		TeamName  string    `gorm:"column:team_name"`
-		IsActive  bool      `gorm:"column:is_active;not null"`
-		CreatedAt time.Time `gorm:"column:created_at"`
-		UpdatedAt time.Time `gorm:"column:updated_at"`
+	return args.Get(0).([]pullrequestModel.StaleCandidate), args.Error(1)
+}
+
+func (m *mockRepository) RecordSLAViolation(
+	ctx context.Context,
+	violation *pullrequestModel.SLAViolation,
+) (bool, error) {
+	args := m.Called(ctx, violation)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockRepository) ReplaceUser(ctx context.Context, oldUserID, newUserID string) error {
+	args := m.Called(ctx, oldUserID, newUserID)
+	return args.Error(0)
+}
+
+func (m *mockRepository) GetAuthorReviewers(ctx context.Context) ([]pullrequestModel.PullRequestReviewer, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	type PullRequest struct {
-		PullRequestID   string     `gorm:"primaryKey;column:pull_request_id"`
-		PullRequestName string     `gorm:"column:pull_request_name;not null"`
-		AuthorID        string     `gorm:"column:author_id;not null"`
-		Status          string     `gorm:"column:status;not null"`
-		CreatedAt       time.Time  `gorm:"column:created_at"`
-		MergedAt        *time.Time `gorm:"column:merged_at"`
+	return args.Get(0).([]pullrequestModel.PullRequestReviewer), args.Error(1)
+}
+
+func (m *mockRepository) GetOrphanedReviewers(ctx context.Context) ([]pullrequestModel.PullRequestReviewer, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
-	type PullRequestReviewer struct {
-		ID            int64     `gorm:"primaryKey;column:id"`
-		PullRequestID string    `gorm:"column:pull_request_id;not null"`
-		UserID        string    `gorm:"column:user_id;not null"`
-		AssignedAt    time.Time `gorm:"column:assigned_at"`
+	return args.Get(0).([]pullrequestModel.PullRequestReviewer), args.Error(1)
+}
+
+func (m *mockRepository) GetExcessReviewers(
+	ctx context.Context,
+	maxReviewers int,
+) ([]pullrequestModel.PullRequestReviewer, error) {
+	args := m.Called(ctx, maxReviewers)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
+	return args.Get(0).([]pullrequestModel.PullRequestReviewer), args.Error(1)
+}
 
-	// Migrate all tables
-	err = db.AutoMigrate(&Team{}, &User{}, &PullRequest{}, &PullRequestReviewer{})
-	require.NoError(t, err)
+func (m *mockRepository) GetUsersWithoutTeam(ctx context.Context) ([]userModel.User, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]userModel.User), args.Error(1)
+}
+
+func (m *mockRepository) RestoreTeam(ctx context.Context, teamName, tenantID string) error {
+	args := m.Called(ctx, teamName, tenantID)
+	return args.Error(0)
+}
+
+func (m *mockRepository) DeleteEventsBefore(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	args := m.Called(ctx, cutoff, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *mockRepository) GetUser(ctx context.Context, userID string) (*userModel.User, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userModel.User), args.Error(1)
+}
+
+func (m *mockRepository) GetTeamLead(ctx context.Context, teamName string) (string, error) {
+	args := m.Called(ctx, teamName)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockRepository) TeamExists(ctx context.Context, teamName string) (bool, error) {
+	args := m.Called(ctx, teamName)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockRepository) GetReviewLoad(ctx context.Context, userIDs []string) (map[string]int, error) {
+	args := m.Called(ctx, userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int), args.Error(1)
+}
 
-	return db
+func (m *mockRepository) GetReviewHistory(
+	ctx context.Context,
+	userIDs []string,
+	authorID, targetBranch string,
+	since time.Time,
+) (map[string]pullrequestModel.ReviewHistory, error) {
+	args := m.Called(ctx, userIDs, authorID, targetBranch, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]pullrequestModel.ReviewHistory), args.Error(1)
 }
 
 func TestService_CreatePullRequest(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("success with 2 reviewers", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		repo := repository.New(db, zap.NewNop().Sugar())
 		svc := New(repo, db, zap.NewNop().Sugar())
 
 		// Setup test data
-		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u1", "Alice", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u2", "Bob", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u3", "Charlie", "backend", true)
+		testutil.NewTeam().WithMembers(3).Create(t, db)
 
 		req := &pullrequestModel.CreatePullRequestRequest{
 			PullRequestID:   "pr-1",
@@ -182,15 +368,11 @@ func TestService_CreatePullRequest(t *testing.T) {
 	})
 
 	t.Run("success with 1 reviewer", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		repo := repository.New(db, zap.NewNop().Sugar())
 		svc := New(repo, db, zap.NewNop().Sugar())
 
-		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u1", "Alice", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u2", "Bob", "backend", true)
+		testutil.NewTeam().WithMembers(2).Create(t, db)
 
 		req := &pullrequestModel.CreatePullRequestRequest{
 			PullRequestID:   "pr-1",
@@ -205,14 +387,59 @@ func TestService_CreatePullRequest(t *testing.T) {
 		assert.Equal(t, "u2", resp.AssignedReviewers[0])
 	})
 
+	t.Run("with branches", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		svc := New(repo, db, zap.NewNop().Sugar())
+
+		testutil.NewTeam().WithMembers(1).Create(t, db)
+
+		req := &pullrequestModel.CreatePullRequestRequest{
+			PullRequestID:   "pr-1",
+			PullRequestName: "Add feature",
+			AuthorID:        "u1",
+			SourceBranch:    "feature/login",
+			TargetBranch:    "main",
+			PullRequestURL:  "https://github.com/org/repo/pull/1",
+		}
+
+		resp, err := svc.CreatePullRequest(ctx, req)
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://github.com/org/repo/pull/1", resp.PullRequestURL)
+		assert.Equal(t, "feature/login", resp.SourceBranch)
+		assert.Equal(t, "main", resp.TargetBranch)
+
+		merged, err := svc.MergePullRequest(ctx, &pullrequestModel.MergePullRequestRequest{PullRequestID: "pr-1"})
+		require.NoError(t, err)
+		assert.Equal(t, "feature/login", merged.SourceBranch)
+		assert.Equal(t, "main", merged.TargetBranch)
+	})
+
+	t.Run("invalid pull request URL", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		svc := New(repo, db, zap.NewNop().Sugar())
+
+		req := &pullrequestModel.CreatePullRequestRequest{
+			PullRequestID:   "pr-1",
+			PullRequestName: "Add feature",
+			AuthorID:        "u1",
+			PullRequestURL:  "not a url",
+		}
+
+		resp, err := svc.CreatePullRequest(ctx, req)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, pullrequestModel.ErrInvalidPullRequestURL)
+	})
+
 	t.Run("success without reviewers", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		repo := repository.New(db, zap.NewNop().Sugar())
 		svc := New(repo, db, zap.NewNop().Sugar())
 
-		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u1", "Alice", "backend", true)
+		testutil.NewTeam().WithMembers(1).Create(t, db)
 
 		req := &pullrequestModel.CreatePullRequestRequest{
 			PullRequestID:   "pr-1",
@@ -231,20 +458,12 @@ func TestService_MergePullRequest(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("merge pull request succeeds", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		repo := repository.New(db, zap.NewNop().Sugar())
 		svc := New(repo, db, zap.NewNop().Sugar())
 
-		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u1", "Alice", "backend", true)
-		db.Exec(
-			"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES (?, ?, ?, ?)",
-			"pr-1",
-			"Add feature",
-			"u1",
-			pullrequestModel.StatusOPEN,
-		)
+		testutil.NewTeam().WithMembers(1).Create(t, db)
+		testutil.NewPR().WithID("pr-1").Named("Add feature").ByAuthor("u1").Create(t, db)
 
 		req := &pullrequestModel.MergePullRequestRequest{
 			PullRequestID: "pr-1",
@@ -258,33 +477,44 @@ func TestService_MergePullRequest(t *testing.T) {
 	})
 }
 
+func TestService_Clock(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.NewDB(t)
+	created := time.Date(2025, 11, 3, 9, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(created)
+	repo := repository.NewWithClock(db, clk, zap.NewNop().Sugar())
+	svc := New(repo, db, zap.NewNop().Sugar(), WithClock(clk))
+
+	testutil.NewTeam().WithMembers(2).Create(t, db)
+
+	resp, err := svc.CreatePullRequest(ctx, &pullrequestModel.CreatePullRequestRequest{
+		PullRequestID:   "pr-1",
+		PullRequestName: "Add feature",
+		AuthorID:        "u1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, created.Format(time.RFC3339), resp.CreatedAt)
+
+	var reviewer pullrequestModel.PullRequestReviewer
+	require.NoError(t, db.Where("pull_request_id = ?", "pr-1").First(&reviewer).Error)
+	assert.True(t, created.Equal(reviewer.AssignedAt))
+
+	clk.Advance(26 * time.Hour)
+	resp, err = svc.MergePullRequest(ctx, &pullrequestModel.MergePullRequestRequest{PullRequestID: "pr-1"})
+	require.NoError(t, err)
+	assert.Equal(t, created.Add(26*time.Hour).Format(time.RFC3339), resp.MergedAt)
+}
+
 func TestService_ReassignReviewer(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("reassign reviewer idempotent", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		repo := repository.New(db, zap.NewNop().Sugar())
 		svc := New(repo, db, zap.NewNop().Sugar())
 
-		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u1", "Alice", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u2", "Bob", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u3", "Charlie", "backend", true)
-		db.Exec(
-			"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES (?, ?, ?, ?)",
-			"pr-1",
-			"Add feature",
-			"u1",
-			pullrequestModel.StatusOPEN,
-		)
-		db.Exec(
-			"INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)",
-			"pr-1",
-			"u2",
-		)
+		testutil.NewTeam().WithMembers(3).Create(t, db)
+		testutil.NewPR().WithID("pr-1").Named("Add feature").ByAuthor("u1").WithReviewers("u2").Create(t, db)
 
 		req := &pullrequestModel.ReassignReviewerRequest{
 			PullRequestID: "pr-1",
@@ -300,27 +530,12 @@ func TestService_ReassignReviewer(t *testing.T) {
 	})
 
 	t.Run("reassign reviewer no candidates (merged)", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		repo := repository.New(db, zap.NewNop().Sugar())
 		svc := New(repo, db, zap.NewNop().Sugar())
 
-		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u1", "Alice", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u2", "Bob", "backend", true)
-		db.Exec(
-			"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES (?, ?, ?, ?)",
-			"pr-1",
-			"Add feature",
-			"u1",
-			pullrequestModel.StatusOPEN,
-		)
-		db.Exec(
-			"INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)",
-			"pr-1",
-			"u2",
-		)
+		testutil.NewTeam().WithMembers(2).Create(t, db)
+		testutil.NewPR().WithID("pr-1").Named("Add feature").ByAuthor("u1").WithReviewers("u2").Create(t, db)
 
 		req := &pullrequestModel.ReassignReviewerRequest{
 			PullRequestID: "pr-1",
@@ -334,7 +549,7 @@ func TestService_ReassignReviewer(t *testing.T) {
 	})
 
 	t.Run("pull request not found", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		repo := repository.New(db, zap.NewNop().Sugar())
 		svc := New(repo, db, zap.NewNop().Sugar())
 
@@ -406,28 +621,6 @@ func TestService_CreatePullRequest_Unit(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 
-	t.Run("validation - pull_request_id too long", func(t *testing.T) {
-		mockRepo := new(mockRepository)
-		svc := New(mockRepo, nil, zap.NewNop().Sugar())
-
-		longID := make([]byte, 256)
-		for i := range longID {
-			longID[i] = 'a'
-		}
-
-		req := &pullrequestModel.CreatePullRequestRequest{
-			PullRequestID:   string(longID),
-			PullRequestName: "Add feature",
-			AuthorID:        "u1",
-		}
-
-		resp, err := svc.CreatePullRequest(ctx, req)
-
-		assert.Nil(t, resp)
-		assert.ErrorIs(t, err, pullrequestModel.ErrInvalidPullRequestID)
-		mockRepo.AssertExpectations(t)
-	})
-
 	t.Run("author not found", func(t *testing.T) {
 		mockRepo := new(mockRepository)
 		svc := New(mockRepo, nil, zap.NewNop().Sugar())
@@ -438,7 +631,7 @@ func TestService_CreatePullRequest_Unit(t *testing.T) {
 			AuthorID:        "nonexistent",
 		}
 
-		mockRepo.On("GetUserTeam", ctx, "nonexistent").Return("", pullrequestModel.ErrAuthorNotFound)
+		mockRepo.On("GetUserTeam", mock.Anything, "nonexistent").Return("", pullrequestModel.ErrAuthorNotFound)
 
 		resp, err := svc.CreatePullRequest(ctx, req)
 
@@ -454,15 +647,11 @@ func TestService_CreatePullRequest_TransactionErrors(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("PR already exists (race condition)", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		repo := repository.New(db, zap.NewNop().Sugar())
 		svc := New(repo, db, zap.NewNop().Sugar())
 
-		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u1", "Alice", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u2", "Bob", "backend", true)
+		testutil.NewTeam().WithMembers(2).Create(t, db)
 
 		// Create PR first time
 		req := &pullrequestModel.CreatePullRequestRequest{
@@ -484,13 +673,11 @@ func TestService_CreatePullRequest_TransactionErrors(t *testing.T) {
 		// This scenario is hard to simulate with real DB, but we can test
 		// that the error path exists by checking the code coverage
 		// In real scenario, this would be a database connection error
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		repo := repository.New(db, zap.NewNop().Sugar())
 		svc := New(repo, db, zap.NewNop().Sugar())
 
-		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u1", "Alice", "backend", true)
+		testutil.NewTeam().WithMembers(1).Create(t, db)
 
 		// Close DB to simulate error
 		sqlDB, _ := db.DB()
@@ -508,63 +695,39 @@ func TestService_CreatePullRequest_TransactionErrors(t *testing.T) {
 	})
 
 	t.Run("error when creating PR in transaction", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		repo := repository.New(db, zap.NewNop().Sugar())
 		svc := New(repo, db, zap.NewNop().Sugar())
 
-		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u1", "Alice", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u2", "Bob", "backend", true)
+		testutil.NewTeam().WithMembers(2).Create(t, db)
 
-		// Create PR with invalid data that will cause error
-		// Use very long PR ID that exceeds DB constraint
-		longID := make([]byte, 300)
-		for i := range longID {
-			longID[i] = 'a'
-		}
+		// Reviewer assignment fails inside the transaction, so the PR insert must be rolled back
+		require.NoError(t, db.Exec("DROP TABLE pull_request_reviewers").Error)
 
 		req := &pullrequestModel.CreatePullRequestRequest{
-			PullRequestID:   string(longID),
+			PullRequestID:   "pr-1",
 			PullRequestName: "Add feature",
 			AuthorID:        "u1",
 		}
 
-		// This should fail validation before transaction
 		resp, err := svc.CreatePullRequest(ctx, req)
 		assert.Nil(t, resp)
 		assert.Error(t, err)
+
+		var count int64
+		db.Table("pull_requests").Count(&count)
+		assert.Zero(t, count)
 	})
 
 	t.Run("error when assigning reviewer fails - max reviewers exceeded", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		repo := repository.New(db, zap.NewNop().Sugar())
 		svc := New(repo, db, zap.NewNop().Sugar())
 
-		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u1", "Alice", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u2", "Bob", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u3", "Charlie", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u4", "David", "backend", true)
+		testutil.NewTeam().WithMembers(4).Create(t, db)
 
 		// Create PR manually with 2 reviewers already assigned
-		db.Exec(
-			"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES (?, ?, ?, ?)",
-			"pr-1", "Add feature", "u1", pullrequestModel.StatusOPEN,
-		)
-		db.Exec(
-			"INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)",
-			"pr-1", "u2",
-		)
-		db.Exec(
-			"INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)",
-			"pr-1", "u3",
-		)
+		testutil.NewPR().WithID("pr-1").Named("Add feature").ByAuthor("u1").WithReviewers("u2", "u3").Create(t, db)
 
 		// Try to create PR with same ID - should fail with ErrPullRequestExists
 		// This tests that CreatePullRequest checks for existing PR in transaction
@@ -580,15 +743,11 @@ func TestService_CreatePullRequest_TransactionErrors(t *testing.T) {
 	})
 
 	t.Run("error when getting reviewers after assignment", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		repo := repository.New(db, zap.NewNop().Sugar())
 		svc := New(repo, db, zap.NewNop().Sugar())
 
-		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u1", "Alice", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u2", "Bob", "backend", true)
+		testutil.NewTeam().WithMembers(2).Create(t, db)
 
 		req := &pullrequestModel.CreatePullRequestRequest{
 			PullRequestID:   "pr-1",
@@ -611,21 +770,12 @@ func TestService_ReassignReviewer_TransactionErrors(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("reassign when all other candidates already assigned", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		repo := repository.New(db, zap.NewNop().Sugar())
 		svc := New(repo, db, zap.NewNop().Sugar())
 
-		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u1", "Alice", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u2", "Bob", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u3", "Charlie", "backend", true)
-		db.Exec(
-			"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES (?, ?, ?, ?)",
-			"pr-1", "Add feature", "u1", pullrequestModel.StatusOPEN,
-		)
+		testutil.NewTeam().WithMembers(3).Create(t, db)
+		testutil.NewPR().WithID("pr-1").Named("Add feature").ByAuthor("u1").Create(t, db)
 		// Assign both reviewers
 		db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)", "pr-1", "u2")
 		db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)", "pr-1", "u3")
@@ -642,23 +792,15 @@ func TestService_ReassignReviewer_TransactionErrors(t *testing.T) {
 	})
 
 	t.Run("reassign when no active users in team except author and old reviewer", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		repo := repository.New(db, zap.NewNop().Sugar())
 		svc := New(repo, db, zap.NewNop().Sugar())
 
-		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u1", "Alice", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u2", "Bob", "backend", true)
+		testutil.NewTeam().WithMembers(2).Create(t, db)
 		// u3 is inactive
 		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
 			"u3", "Charlie", "backend", false)
-		db.Exec(
-			"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES (?, ?, ?, ?)",
-			"pr-1", "Add feature", "u1", pullrequestModel.StatusOPEN,
-		)
-		db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)", "pr-1", "u2")
+		testutil.NewPR().WithID("pr-1").Named("Add feature").ByAuthor("u1").WithReviewers("u2").Create(t, db)
 
 		req := &pullrequestModel.ReassignReviewerRequest{
 			PullRequestID: "pr-1",
@@ -672,17 +814,12 @@ func TestService_ReassignReviewer_TransactionErrors(t *testing.T) {
 	})
 
 	t.Run("error when old reviewer not found (ErrAuthorNotFound)", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		repo := repository.New(db, zap.NewNop().Sugar())
 		svc := New(repo, db, zap.NewNop().Sugar())
 
-		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u1", "Alice", "backend", true)
-		db.Exec(
-			"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES (?, ?, ?, ?)",
-			"pr-1", "Add feature", "u1", pullrequestModel.StatusOPEN,
-		)
+		testutil.NewTeam().WithMembers(1).Create(t, db)
+		testutil.NewPR().WithID("pr-1").Named("Add feature").ByAuthor("u1").Create(t, db)
 
 		req := &pullrequestModel.ReassignReviewerRequest{
 			PullRequestID: "pr-1",
@@ -698,17 +835,11 @@ func TestService_ReassignReviewer_TransactionErrors(t *testing.T) {
 	})
 
 	t.Run("error when PR is merged", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		repo := repository.New(db, zap.NewNop().Sugar())
 		svc := New(repo, db, zap.NewNop().Sugar())
 
-		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u1", "Alice", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u2", "Bob", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u3", "Charlie", "backend", true)
+		testutil.NewTeam().WithMembers(3).Create(t, db)
 		mergedAt := time.Now()
 		db.Exec(
 			"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, merged_at) "+
@@ -729,21 +860,12 @@ func TestService_ReassignReviewer_TransactionErrors(t *testing.T) {
 	})
 
 	t.Run("error when old reviewer not assigned to PR", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		repo := repository.New(db, zap.NewNop().Sugar())
 		svc := New(repo, db, zap.NewNop().Sugar())
 
-		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u1", "Alice", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u2", "Bob", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u3", "Charlie", "backend", true)
-		db.Exec(
-			"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES (?, ?, ?, ?)",
-			"pr-1", "Add feature", "u1", pullrequestModel.StatusOPEN,
-		)
+		testutil.NewTeam().WithMembers(3).Create(t, db)
+		testutil.NewPR().WithID("pr-1").Named("Add feature").ByAuthor("u1").Create(t, db)
 		// u3 is assigned, not u2
 		db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)", "pr-1", "u3")
 
@@ -765,21 +887,12 @@ func TestService_ReassignReviewer_EdgeCases(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("reassign when team has 3 people (author + 2 reviewers) - all assigned", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		repo := repository.New(db, zap.NewNop().Sugar())
 		svc := New(repo, db, zap.NewNop().Sugar())
 
-		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u1", "Alice", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u2", "Bob", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u3", "Charlie", "backend", true)
-		db.Exec(
-			"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES (?, ?, ?, ?)",
-			"pr-1", "Add feature", "u1", pullrequestModel.StatusOPEN,
-		)
+		testutil.NewTeam().WithMembers(3).Create(t, db)
+		testutil.NewPR().WithID("pr-1").Named("Add feature").ByAuthor("u1").Create(t, db)
 		// Both reviewers assigned
 		db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)", "pr-1", "u2")
 		db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)", "pr-1", "u3")
@@ -796,20 +909,12 @@ func TestService_ReassignReviewer_EdgeCases(t *testing.T) {
 	})
 
 	t.Run("reassign when team has only author and 1 reviewer (no candidates)", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		repo := repository.New(db, zap.NewNop().Sugar())
 		svc := New(repo, db, zap.NewNop().Sugar())
 
-		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u1", "Alice", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u2", "Bob", "backend", true)
-		db.Exec(
-			"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES (?, ?, ?, ?)",
-			"pr-1", "Add feature", "u1", pullrequestModel.StatusOPEN,
-		)
-		db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)", "pr-1", "u2")
+		testutil.NewTeam().WithMembers(2).Create(t, db)
+		testutil.NewPR().WithID("pr-1").Named("Add feature").ByAuthor("u1").WithReviewers("u2").Create(t, db)
 
 		req := &pullrequestModel.ReassignReviewerRequest{
 			PullRequestID: "pr-1",
@@ -823,22 +928,12 @@ func TestService_ReassignReviewer_EdgeCases(t *testing.T) {
 	})
 
 	t.Run("reassign when PR was merged between check and operation", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		repo := repository.New(db, zap.NewNop().Sugar())
 		svc := New(repo, db, zap.NewNop().Sugar())
 
-		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u1", "Alice", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u2", "Bob", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u3", "Charlie", "backend", true)
-		db.Exec(
-			"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES (?, ?, ?, ?)",
-			"pr-1", "Add feature", "u1", pullrequestModel.StatusOPEN,
-		)
-		db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)", "pr-1", "u2")
+		testutil.NewTeam().WithMembers(3).Create(t, db)
+		testutil.NewPR().WithID("pr-1").Named("Add feature").ByAuthor("u1").WithReviewers("u2").Create(t, db)
 
 		// Merge PR before reassign
 		mergeReq := &pullrequestModel.MergePullRequestRequest{
@@ -865,17 +960,12 @@ func TestService_Idempotency(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("multiple MergePullRequest calls with same PR (idempotent)", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		repo := repository.New(db, zap.NewNop().Sugar())
 		svc := New(repo, db, zap.NewNop().Sugar())
 
-		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u1", "Alice", "backend", true)
-		db.Exec(
-			"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES (?, ?, ?, ?)",
-			"pr-1", "Add feature", "u1", pullrequestModel.StatusOPEN,
-		)
+		testutil.NewTeam().WithMembers(1).Create(t, db)
+		testutil.NewPR().WithID("pr-1").Named("Add feature").ByAuthor("u1").Create(t, db)
 
 		req := &pullrequestModel.MergePullRequestRequest{
 			PullRequestID: "pr-1",
@@ -901,15 +991,11 @@ func TestService_Idempotency(t *testing.T) {
 	})
 
 	t.Run("attempt to create PR twice (should return error, not idempotent)", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		repo := repository.New(db, zap.NewNop().Sugar())
 		svc := New(repo, db, zap.NewNop().Sugar())
 
-		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u1", "Alice", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u2", "Bob", "backend", true)
+		testutil.NewTeam().WithMembers(2).Create(t, db)
 
 		req := &pullrequestModel.CreatePullRequestRequest{
 			PullRequestID:   "pr-1",
@@ -929,22 +1015,12 @@ func TestService_Idempotency(t *testing.T) {
 	})
 
 	t.Run("reassign same reviewer twice (should return error)", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		repo := repository.New(db, zap.NewNop().Sugar())
 		svc := New(repo, db, zap.NewNop().Sugar())
 
-		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u1", "Alice", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u2", "Bob", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u3", "Charlie", "backend", true)
-		db.Exec(
-			"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES (?, ?, ?, ?)",
-			"pr-1", "Add feature", "u1", pullrequestModel.StatusOPEN,
-		)
-		db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)", "pr-1", "u2")
+		testutil.NewTeam().WithMembers(3).Create(t, db)
+		testutil.NewPR().WithID("pr-1").Named("Add feature").ByAuthor("u1").WithReviewers("u2").Create(t, db)
 
 		req := &pullrequestModel.ReassignReviewerRequest{
 			PullRequestID: "pr-1",
@@ -970,17 +1046,11 @@ func TestService_BusinessRules(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("create PR when all team members inactive", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		repo := repository.New(db, zap.NewNop().Sugar())
 		svc := New(repo, db, zap.NewNop().Sugar())
 
-		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u1", "Alice", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u2", "Bob", "backend", false)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u3", "Charlie", "backend", false)
+		testutil.NewTeam().WithMembers(3).Inactive(2).Create(t, db)
 
 		req := &pullrequestModel.CreatePullRequestRequest{
 			PullRequestID:   "pr-1",
@@ -995,13 +1065,11 @@ func TestService_BusinessRules(t *testing.T) {
 	})
 
 	t.Run("create PR when team has only author", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		repo := repository.New(db, zap.NewNop().Sugar())
 		svc := New(repo, db, zap.NewNop().Sugar())
 
-		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u1", "Alice", "backend", true)
+		testutil.NewTeam().WithMembers(1).Create(t, db)
 
 		req := &pullrequestModel.CreatePullRequestRequest{
 			PullRequestID:   "pr-1",
@@ -1016,15 +1084,11 @@ func TestService_BusinessRules(t *testing.T) {
 	})
 
 	t.Run("inactive users excluded from reviewer selection", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		repo := repository.New(db, zap.NewNop().Sugar())
 		svc := New(repo, db, zap.NewNop().Sugar())
 
-		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u1", "Alice", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u2", "Bob", "backend", true)
+		testutil.NewTeam().WithMembers(2).Create(t, db)
 		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
 			"u3", "Charlie", "backend", false) // inactive
 
@@ -1043,17 +1107,11 @@ func TestService_BusinessRules(t *testing.T) {
 	})
 
 	t.Run("author always excluded from reviewer list", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		repo := repository.New(db, zap.NewNop().Sugar())
 		svc := New(repo, db, zap.NewNop().Sugar())
 
-		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u1", "Alice", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u2", "Bob", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u3", "Charlie", "backend", true)
+		testutil.NewTeam().WithMembers(3).Create(t, db)
 
 		req := &pullrequestModel.CreatePullRequestRequest{
 			PullRequestID:   "pr-1",
@@ -1069,21 +1127,11 @@ func TestService_BusinessRules(t *testing.T) {
 	})
 
 	t.Run("maximum 2 reviewers assigned", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		repo := repository.New(db, zap.NewNop().Sugar())
 		svc := New(repo, db, zap.NewNop().Sugar())
 
-		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u1", "Alice", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u2", "Bob", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u3", "Charlie", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u4", "David", "backend", true)
-		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
-			"u5", "Eve", "backend", true)
+		testutil.NewTeam().WithMembers(5).Create(t, db)
 
 		req := &pullrequestModel.CreatePullRequestRequest{
 			PullRequestID:   "pr-1",
@@ -1120,7 +1168,7 @@ func TestService_MergePullRequest_Unit(t *testing.T) {
 	t.Run("pull request not found", func(t *testing.T) {
 		// MergePullRequest uses transactions which require real DB
 		// Use real DB instead of mock repository
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		repo := repository.New(db, zap.NewNop().Sugar())
 		svc := New(repo, db, zap.NewNop().Sugar())
 
@@ -1170,31 +1218,1707 @@ func TestService_ReassignReviewer_Unit(t *testing.T) {
 		assert.Contains(t, err.Error(), "old_user_id is required")
 		mockRepo.AssertExpectations(t)
 	})
+}
+
+func TestService_CreatePullRequest_SizeWeighted(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("prefers reviewers with lower review load", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		svc := New(repo, db, zap.NewNop().Sugar())
+
+		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
+		for _, id := range []string{"u1", "u2", "u3", "u4"} {
+			db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
+				id, id, "backend", true)
+		}
+
+		// u2 already reviews a huge open PR
+		_, err := svc.CreatePullRequest(ctx, &pullrequestModel.CreatePullRequestRequest{
+			PullRequestID:   "pr-0",
+			PullRequestName: "Huge refactoring",
+			AuthorID:        "u3",
+			LinesAdded:      2000,
+			LinesRemoved:    1000,
+		})
+		require.NoError(t, err)
+		db.Exec("DELETE FROM pull_request_reviewers WHERE pull_request_id = ?", "pr-0")
+		db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)", "pr-0", "u2")
+
+		resp, err := svc.CreatePullRequest(ctx, &pullrequestModel.CreatePullRequestRequest{
+			PullRequestID:   "pr-1",
+			PullRequestName: "Small fix",
+			AuthorID:        "u1",
+			LinesAdded:      10,
+			LinesRemoved:    2,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 10, resp.LinesAdded)
+		assert.Equal(t, 2, resp.LinesRemoved)
+		assert.ElementsMatch(t, []string{"u3", "u4"}, resp.AssignedReviewers)
+	})
 
-	t.Run("validation - old_user_id too long", func(t *testing.T) {
+	t.Run("review load error", func(t *testing.T) {
 		mockRepo := new(mockRepository)
 		svc := New(mockRepo, nil, zap.NewNop().Sugar())
 
-		longID := make([]byte, 256)
-		for i := range longID {
-			longID[i] = 'a'
+		loadErr := errors.New("database error")
+		mockRepo.On("GetUserTeam", mock.Anything, "u1").Return("backend", nil)
+		mockRepo.On("GetActiveTeamMembers", mock.Anything, "backend", "u1").
+			Return([]userModel.User{{UserID: "u2"}}, nil)
+		mockRepo.On("GetReviewLoad", mock.Anything, []string{"u2"}).Return(nil, loadErr)
+
+		resp, err := svc.CreatePullRequest(ctx, &pullrequestModel.CreatePullRequestRequest{
+			PullRequestID:   "pr-1",
+			PullRequestName: "Add feature",
+			AuthorID:        "u1",
+		})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, loadErr)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+// recordingNotifier collects notifications sent by the service.
+type recordingNotifier struct {
+	notifications []notification.Notification
+}
+
+func (n *recordingNotifier) Notify(_ context.Context, notif notification.Notification) error {
+	n.notifications = append(n.notifications, notif)
+	return nil
+}
+
+func TestService_WatchPullRequest(t *testing.T) {
+	ctx := context.Background()
+
+	seed := func(db *gorm.DB) {
+		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
+		for _, id := range []string{"u1", "u2", "u3"} {
+			db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
+				id, id, "backend", true)
 		}
+		testutil.NewPR().WithID("pr-1").Named("Add feature").ByAuthor("u1").WithReviewers("u2").Create(t, db)
+	}
 
-		req := &pullrequestModel.ReassignReviewerRequest{
+	t.Run("success is idempotent", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		svc := New(repo, db, zap.NewNop().Sugar())
+		seed(db)
+
+		req := &pullrequestModel.WatchPullRequestRequest{PullRequestID: "pr-1", UserID: "u3"}
+
+		resp, err := svc.WatchPullRequest(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u3"}, resp.Watchers)
+		assert.Equal(t, []string{"u2"}, resp.AssignedReviewers)
+
+		resp, err = svc.WatchPullRequest(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u3"}, resp.Watchers)
+	})
+
+	t.Run("pull request not found", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		svc := New(repo, db, zap.NewNop().Sugar())
+		seed(db)
+
+		resp, err := svc.WatchPullRequest(ctx, &pullrequestModel.WatchPullRequestRequest{
+			PullRequestID: "nonexistent",
+			UserID:        "u3",
+		})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, pullrequestModel.ErrPullRequestNotFound)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		svc := New(repo, db, zap.NewNop().Sugar())
+		seed(db)
+
+		resp, err := svc.WatchPullRequest(ctx, &pullrequestModel.WatchPullRequestRequest{
 			PullRequestID: "pr-1",
-			OldUserID:     string(longID),
+			UserID:        "ghost",
+		})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, pullrequestModel.ErrAuthorNotFound)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		mockRepo := new(mockRepository)
+		svc := New(mockRepo, nil, zap.NewNop().Sugar())
+
+		_, err := svc.WatchPullRequest(ctx, &pullrequestModel.WatchPullRequestRequest{UserID: "u3"})
+		assert.ErrorIs(t, err, pullrequestModel.ErrInvalidPullRequestID)
+
+		_, err = svc.WatchPullRequest(ctx, &pullrequestModel.WatchPullRequestRequest{PullRequestID: "pr-1"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "user_id")
+	})
+
+	t.Run("watchers are notified on merge", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		notifier := &recordingNotifier{}
+		svc := New(repo, db, zap.NewNop().Sugar(), WithNotifier(notifier))
+		seed(db)
+
+		_, err := svc.WatchPullRequest(ctx, &pullrequestModel.WatchPullRequestRequest{PullRequestID: "pr-1", UserID: "u3"})
+		require.NoError(t, err)
+
+		merged, err := svc.MergePullRequest(ctx, &pullrequestModel.MergePullRequestRequest{PullRequestID: "pr-1"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u3"}, merged.Watchers)
+
+		// Repeated merge is idempotent and sends nothing
+		_, err = svc.MergePullRequest(ctx, &pullrequestModel.MergePullRequestRequest{PullRequestID: "pr-1"})
+		require.NoError(t, err)
+
+		require.Len(t, notifier.notifications, 1)
+		assert.Equal(t, notification.EventPullRequestMerged, notifier.notifications[0].Event)
+		assert.Equal(t, []string{"u2", "u3"}, notifier.notifications[0].Recipients)
+	})
+
+	t.Run("watchers and replaced reviewer are notified on reassign", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		notifier := &recordingNotifier{}
+		svc := New(repo, db, zap.NewNop().Sugar(), WithNotifier(notifier))
+		seed(db)
+		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
+			"u4", "u4", "backend", true)
+
+		_, err := svc.WatchPullRequest(ctx, &pullrequestModel.WatchPullRequestRequest{PullRequestID: "pr-1", UserID: "u4"})
+		require.NoError(t, err)
+
+		resp, err := svc.ReassignReviewer(ctx, &pullrequestModel.ReassignReviewerRequest{
+			PullRequestID: "pr-1",
+			OldUserID:     "u2",
+		})
+		require.NoError(t, err)
+
+		require.Len(t, notifier.notifications, 1)
+		n := notifier.notifications[0]
+		assert.Equal(t, notification.EventReviewerReassigned, n.Event)
+		assert.Contains(t, n.Recipients, "u2")
+		assert.Contains(t, n.Recipients, "u4")
+		assert.Contains(t, n.Recipients, resp.ReplacedBy)
+		assert.Equal(t, resp.ReplacedBy, n.Details["new_user_id"])
+	})
+}
+
+func TestService_GetActivity(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("lifecycle events are ordered", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		svc := New(repo, db, zap.NewNop().Sugar())
+
+		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
+		for _, id := range []string{"u1", "u2", "u3"} {
+			db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
+				id, id, "backend", true)
 		}
 
-		resp, err := svc.ReassignReviewer(ctx, req)
+		created, err := svc.CreatePullRequest(ctx, &pullrequestModel.CreatePullRequestRequest{
+			PullRequestID:   "pr-1",
+			PullRequestName: "Add feature",
+			AuthorID:        "u1",
+		})
+		require.NoError(t, err)
+		require.Len(t, created.AssignedReviewers, 2)
+
+		// Add a free team member to replace one of the reviewers
+		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
+			"u4", "u4", "backend", true)
+		oldReviewer := created.AssignedReviewers[0]
+		reassigned, err := svc.ReassignReviewer(ctx, &pullrequestModel.ReassignReviewerRequest{
+			PullRequestID: "pr-1",
+			OldUserID:     oldReviewer,
+		})
+		require.NoError(t, err)
+
+		_, err = svc.MergePullRequest(ctx, &pullrequestModel.MergePullRequestRequest{PullRequestID: "pr-1"})
+		require.NoError(t, err)
+
+		resp, err := svc.GetActivity(ctx, "pr-1", sortparam.Sort{})
+
+		require.NoError(t, err)
+		assert.Equal(t, "pr-1", resp.PullRequestID)
+		require.Len(t, resp.Events, 5)
+		assert.Equal(t, pullrequestModel.EventCreated, resp.Events[0].Type)
+		assert.Equal(t, "u1", resp.Events[0].UserID)
+		assert.Equal(t, pullrequestModel.EventReviewerAssigned, resp.Events[1].Type)
+		assert.Equal(t, pullrequestModel.EventReviewerAssigned, resp.Events[2].Type)
+		assert.ElementsMatch(t, created.AssignedReviewers, []string{resp.Events[1].UserID, resp.Events[2].UserID})
+		assert.Equal(t, pullrequestModel.SourceAuto, resp.Events[1].Source)
+		assert.Equal(t, pullrequestModel.EventReviewerReplaced, resp.Events[3].Type)
+		assert.Equal(t, reassigned.ReplacedBy, resp.Events[3].UserID)
+		assert.Equal(t, oldReviewer, resp.Events[3].PreviousUserID)
+		assert.Equal(t, pullrequestModel.SourceReassign, resp.Events[3].Source)
+		assert.Equal(t, pullrequestModel.EventMerged, resp.Events[4].Type)
+		assert.Empty(t, resp.Events[4].UserID)
+		assert.Empty(t, resp.Events[4].Source, "only reviewer events have a source")
+	})
+
+	t.Run("pull request without events", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		svc := New(repo, db, zap.NewNop().Sugar())
+
+		testutil.NewPR().WithID("pr-1").Named("Add feature").ByAuthor("u1").Create(t, db)
+
+		resp, err := svc.GetActivity(ctx, "pr-1", sortparam.Sort{})
+
+		require.NoError(t, err)
+		assert.NotNil(t, resp.Events)
+		assert.Empty(t, resp.Events)
+	})
+
+	t.Run("pull request not found", func(t *testing.T) {
+		mockRepo := new(mockRepository)
+		svc := New(mockRepo, nil, zap.NewNop().Sugar())
+
+		mockRepo.On("GetByID", ctx, "nonexistent").Return(nil, pullrequestModel.ErrPullRequestNotFound)
+
+		resp, err := svc.GetActivity(ctx, "nonexistent", sortparam.Sort{})
 
 		assert.Nil(t, resp)
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "old_user_id must be between 1 and 255 characters")
-		mockRepo.AssertExpectations(t)
+		assert.ErrorIs(t, err, pullrequestModel.ErrPullRequestNotFound)
+		mockRepo.AssertNotCalled(t, "GetEvents", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("empty pull request id", func(t *testing.T) {
+		mockRepo := new(mockRepository)
+		svc := New(mockRepo, nil, zap.NewNop().Sugar())
+
+		resp, err := svc.GetActivity(ctx, "", sortparam.Sort{})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, pullrequestModel.ErrInvalidPullRequestID)
 	})
 }
 
-// Unit tests for helper functions
+func TestService_ListPullRequests(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.NewDB(t)
+	repo := repository.New(db, zap.NewNop().Sugar())
+	svc := New(repo, db, zap.NewNop().Sugar(), WithPageLimits(pagination.Limits{Default: 2, Max: 3}))
+	testutil.NewTeam().WithMembers(3).Create(t, db)
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	testutil.NewPR().WithID("pr-1").WithReviewers("u2", "u3").CreatedAt(base).Create(t, db)
+	testutil.NewPR().WithID("pr-2").ByAuthor("u2").Merged().CreatedAt(base.Add(time.Hour)).Create(t, db)
+	testutil.NewPR().WithID("pr-3").WithReviewers("u3").CreatedAt(base.Add(2*time.Hour)).Create(t, db)
+
+	t.Run("pages with reviewers", func(t *testing.T) {
+		first, err := svc.ListPullRequests(ctx, pullrequestModel.ListQuery{})
+		require.NoError(t, err)
+		require.Len(t, first.PullRequests, 2)
+		assert.Equal(t, "pr-3", first.PullRequests[0].PullRequestID)
+		assert.Equal(t, []string{"u3"}, first.PullRequests[0].AssignedReviewers)
+		assert.Equal(t, []string{}, first.PullRequests[1].AssignedReviewers)
+		require.NotNil(t, first.Next)
+		assert.Equal(t, "pr-2", first.Next.PullRequestID)
+
+		second, err := svc.ListPullRequests(ctx, pullrequestModel.ListQuery{After: first.Next})
+		require.NoError(t, err)
+		require.Len(t, second.PullRequests, 1)
+		assert.Equal(t, "pr-1", second.PullRequests[0].PullRequestID)
+		assert.Equal(t, []string{"u2", "u3"}, second.PullRequests[0].AssignedReviewers)
+		assert.Nil(t, second.Next)
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		resp, err := svc.ListPullRequests(ctx, pullrequestModel.ListQuery{
+			Status:     pullrequestModel.StatusOPEN,
+			ReviewerID: "u3",
+			Limit:      3,
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.PullRequests, 2)
+		assert.Equal(t, "pr-3", resp.PullRequests[0].PullRequestID)
+		assert.Equal(t, "pr-1", resp.PullRequests[1].PullRequestID)
+
+		resp, err = svc.ListPullRequests(ctx, pullrequestModel.ListQuery{TeamName: "other"})
+		require.NoError(t, err)
+		assert.Empty(t, resp.PullRequests)
+	})
+
+	t.Run("invalid filters", func(t *testing.T) {
+		_, err := svc.ListPullRequests(ctx, pullrequestModel.ListQuery{Status: "open"})
+		assert.ErrorIs(t, err, pullrequestModel.ErrInvalidStatus)
+
+		_, err = svc.ListPullRequests(ctx, pullrequestModel.ListQuery{Limit: 4})
+		assert.ErrorIs(t, err, pagination.ErrInvalidLimit)
+	})
+}
+
+func TestService_SetConflicts(t *testing.T) {
+	ctx := context.Background()
+	hasConflicts := true
+
+	seed := func(db *gorm.DB, status string) {
+		testutil.NewTeam().WithMembers(1).Create(t, db)
+		db.Exec(
+			"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES (?, ?, ?, ?)",
+			"pr-1", "Add feature", "u1", status,
+		)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		svc := New(repo, db, zap.NewNop().Sugar())
+		seed(db, pullrequestModel.StatusOPEN)
+		db.Exec("INSERT INTO pull_request_watchers (pull_request_id, user_id) VALUES (?, ?)", "pr-1", "u1")
+
+		resp, err := svc.SetConflicts(ctx, &pullrequestModel.SetConflictsRequest{
+			PullRequestID: "pr-1",
+			HasConflicts:  &hasConflicts,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, resp.HasConflicts)
+		assert.Equal(t, pullrequestModel.StatusOPEN, resp.Status)
+		assert.Equal(t, []string{"u1"}, resp.Watchers)
+	})
+
+	t.Run("merged pull request", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		svc := New(repo, db, zap.NewNop().Sugar())
+		seed(db, pullrequestModel.StatusMERGED)
+
+		resp, err := svc.SetConflicts(ctx, &pullrequestModel.SetConflictsRequest{
+			PullRequestID: "pr-1",
+			HasConflicts:  &hasConflicts,
+		})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, pullrequestModel.ErrPullRequestMerged)
+	})
+
+	t.Run("pull request not found", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		svc := New(repo, db, zap.NewNop().Sugar())
+
+		resp, err := svc.SetConflicts(ctx, &pullrequestModel.SetConflictsRequest{
+			PullRequestID: "nonexistent",
+			HasConflicts:  &hasConflicts,
+		})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, pullrequestModel.ErrPullRequestNotFound)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		mockRepo := new(mockRepository)
+		svc := New(mockRepo, nil, zap.NewNop().Sugar())
+
+		_, err := svc.SetConflicts(ctx, &pullrequestModel.SetConflictsRequest{HasConflicts: &hasConflicts})
+		assert.ErrorIs(t, err, pullrequestModel.ErrInvalidPullRequestID)
+
+		_, err = svc.SetConflicts(ctx, &pullrequestModel.SetConflictsRequest{PullRequestID: "pr-1"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "has_conflicts")
+	})
+}
+
+func TestService_ClosePullRequest(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("closes open pull request", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		notifier := &recordingNotifier{}
+		svc := New(repo, db, zap.NewNop().Sugar(), WithNotifier(notifier))
+		testutil.NewTeam().WithMembers(3).Create(t, db)
+		testutil.NewPR().WithID("pr-1").WithReviewers("u2", "u3").Create(t, db)
+
+		resp, err := svc.ClosePullRequest(ctx, &pullrequestModel.ClosePullRequestRequest{
+			PullRequestID: "pr-1",
+			ClosedBy:      "u1",
+			CloseReason:   pullrequestModel.CloseReasonSuperseded,
+			Note:          " replaced by pr-2 ",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, pullrequestModel.StatusCLOSED, resp.Status)
+		assert.Empty(t, resp.MergedAt)
+		assert.Equal(t, []string{"u2", "u3"}, resp.AssignedReviewers)
+		assert.Equal(t, pullrequestModel.CloseReasonSuperseded, resp.CloseReason)
+		assert.Equal(t, "replaced by pr-2", resp.CloseNote)
+
+		events, err := repo.GetEvents(ctx, "pr-1", sortparam.Sort{})
+		require.NoError(t, err)
+		last := events[len(events)-1]
+		assert.Equal(t, pullrequestModel.EventClosed, last.EventType)
+		require.NotNil(t, last.ActorID)
+		assert.Equal(t, "u1", *last.ActorID)
+		require.NotNil(t, last.Reason)
+		assert.Equal(t, "SUPERSEDED: replaced by pr-2", *last.Reason)
+
+		// Repeated close is idempotent, keeps the first reason and sends nothing
+		again, err := svc.ClosePullRequest(ctx, &pullrequestModel.ClosePullRequestRequest{
+			PullRequestID: "pr-1",
+			CloseReason:   pullrequestModel.CloseReasonAbandoned,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, pullrequestModel.StatusCLOSED, again.Status)
+		assert.Equal(t, pullrequestModel.CloseReasonSuperseded, again.CloseReason)
+		require.Len(t, notifier.notifications, 1)
+		assert.Equal(t, notification.EventPullRequestClosed, notifier.notifications[0].Event)
+		assert.Equal(t, []string{"u2", "u3"}, notifier.notifications[0].Recipients)
+	})
+
+	t.Run("defaults to OTHER and accepts legacy reason as note", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		svc := New(repo, db, zap.NewNop().Sugar())
+		testutil.NewTeam().WithMembers(2).Create(t, db)
+		testutil.NewPR().WithID("pr-1").Create(t, db)
+
+		resp, err := svc.ClosePullRequest(ctx, &pullrequestModel.ClosePullRequestRequest{
+			PullRequestID: "pr-1",
+			Reason:        " not needed ",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, pullrequestModel.CloseReasonOther, resp.CloseReason)
+		assert.Equal(t, "not needed", resp.CloseNote)
+
+		pr, err := repo.GetByID(ctx, "pr-1")
+		require.NoError(t, err)
+		require.NotNil(t, pr.CloseReason)
+		assert.Equal(t, pullrequestModel.CloseReasonOther, *pr.CloseReason)
+		require.NotNil(t, pr.CloseNote)
+		assert.Equal(t, "not needed", *pr.CloseNote)
+	})
+
+	t.Run("closed pull request cannot be changed", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		svc := New(repo, db, zap.NewNop().Sugar())
+		testutil.NewTeam().WithMembers(4).Create(t, db)
+		testutil.NewPR().WithID("pr-1").WithReviewers("u2").Closed().Create(t, db)
+
+		_, err := svc.MergePullRequest(ctx, &pullrequestModel.MergePullRequestRequest{PullRequestID: "pr-1"})
+		require.ErrorIs(t, err, pullrequestModel.ErrPullRequestClosed)
+		_, err = svc.ReassignReviewer(ctx, &pullrequestModel.ReassignReviewerRequest{PullRequestID: "pr-1", OldUserID: "u2"})
+		assert.ErrorIs(t, err, pullrequestModel.ErrPullRequestClosed)
+		_, err = svc.GetCandidates(ctx, "pr-1")
+		assert.ErrorIs(t, err, pullrequestModel.ErrPullRequestClosed)
+	})
+
+	t.Run("merged pull request", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		svc := New(repo, db, zap.NewNop().Sugar())
+		testutil.NewTeam().WithMembers(2).Create(t, db)
+		testutil.NewPR().WithID("pr-1").Merged().Create(t, db)
+
+		resp, err := svc.ClosePullRequest(ctx, &pullrequestModel.ClosePullRequestRequest{PullRequestID: "pr-1"})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, pullrequestModel.ErrPullRequestMerged)
+	})
+
+	t.Run("pull request being assigned", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		svc := New(repo, db, zap.NewNop().Sugar())
+		testutil.NewTeam().WithMembers(2).Create(t, db)
+		testutil.NewPR().WithID("pr-1").Assigning().Create(t, db)
+
+		_, err := svc.ClosePullRequest(ctx, &pullrequestModel.ClosePullRequestRequest{PullRequestID: "pr-1"})
+		assert.ErrorIs(t, err, pullrequestModel.ErrAssignmentPending)
+	})
+
+	t.Run("pull request not found", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		svc := New(repository.New(db, zap.NewNop().Sugar()), db, zap.NewNop().Sugar())
+
+		_, err := svc.ClosePullRequest(ctx, &pullrequestModel.ClosePullRequestRequest{PullRequestID: "nonexistent"})
+		assert.ErrorIs(t, err, pullrequestModel.ErrPullRequestNotFound)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		svc := New(new(mockRepository), nil, zap.NewNop().Sugar())
+
+		_, err := svc.ClosePullRequest(ctx, &pullrequestModel.ClosePullRequestRequest{})
+		assert.ErrorIs(t, err, pullrequestModel.ErrInvalidPullRequestID)
+
+		_, err = svc.ClosePullRequest(ctx, &pullrequestModel.ClosePullRequestRequest{
+			PullRequestID: "pr-1",
+			CloseReason:   "BORED",
+		})
+		assert.ErrorIs(t, err, pullrequestModel.ErrInvalidCloseReason)
+	})
+}
+
+func TestService_ReopenPullRequest(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reopens closed pull request", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		notifier := &recordingNotifier{}
+		svc := New(repo, db, zap.NewNop().Sugar(), WithNotifier(notifier))
+		testutil.NewTeam().WithMembers(3).Create(t, db)
+		testutil.NewPR().WithID("pr-1").WithReviewers("u2", "u3").Closed().Create(t, db)
+
+		resp, err := svc.ReopenPullRequest(ctx, &pullrequestModel.ReopenPullRequestRequest{
+			PullRequestID: "pr-1",
+			ReopenedBy:    "u1",
+			Reason:        " still needed ",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, pullrequestModel.StatusOPEN, resp.Status)
+		assert.Equal(t, []string{"u2", "u3"}, resp.AssignedReviewers)
+
+		events, err := repo.GetEvents(ctx, "pr-1", sortparam.Sort{})
+		require.NoError(t, err)
+		last := events[len(events)-1]
+		assert.Equal(t, pullrequestModel.EventReopened, last.EventType)
+		require.NotNil(t, last.ActorID)
+		assert.Equal(t, "u1", *last.ActorID)
+		require.NotNil(t, last.Reason)
+		assert.Equal(t, "still needed", *last.Reason)
+		assert.Empty(t, resp.CloseReason)
+		pr, err := repo.GetByID(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.Nil(t, pr.CloseReason)
+		assert.Nil(t, pr.CloseNote)
+
+		// Reopening an open PR is idempotent and sends nothing
+		again, err := svc.ReopenPullRequest(ctx, &pullrequestModel.ReopenPullRequestRequest{PullRequestID: "pr-1"})
+		require.NoError(t, err)
+		assert.Equal(t, pullrequestModel.StatusOPEN, again.Status)
+		require.Len(t, notifier.notifications, 1)
+		assert.Equal(t, notification.EventPullRequestReopened, notifier.notifications[0].Event)
+		assert.Equal(t, []string{"u2", "u3"}, notifier.notifications[0].Recipients)
+	})
+
+	t.Run("replaces reviewers deactivated while closed", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		notifier := &recordingNotifier{}
+		svc := New(repo, db, zap.NewNop().Sugar(), WithNotifier(notifier))
+		testutil.NewTeam().WithMembers(5).Inactive(2).Create(t, db)
+		testutil.NewPR().WithID("pr-1").WithReviewers("u2", "u4").Closed().Create(t, db)
+
+		resp, err := svc.ReopenPullRequest(ctx, &pullrequestModel.ReopenPullRequestRequest{PullRequestID: "pr-1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u2", "u3"}, resp.AssignedReviewers)
+		reviewers, err := repo.GetReviewers(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"u2", "u3"}, reviewers)
+
+		events, err := repo.GetEvents(ctx, "pr-1", sortparam.Sort{})
+		require.NoError(t, err)
+		last := events[len(events)-1]
+		assert.Equal(t, pullrequestModel.EventReviewerReplaced, last.EventType)
+		assert.Equal(t, "u3", *last.UserID)
+		assert.Equal(t, "u4", *last.PreviousUserID)
+		assert.Equal(t, pullrequestModel.SourceDeactivation, *last.Source)
+
+		require.Len(t, notifier.notifications, 2)
+		assert.Equal(t, notification.EventPullRequestReopened, notifier.notifications[0].Event)
+		assert.Equal(t, notification.EventReviewerReassigned, notifier.notifications[1].Event)
+		assert.Equal(t, map[string]string{"old_user_id": "u4", "new_user_id": "u3"}, notifier.notifications[1].Details)
+	})
+
+	t.Run("merged pull request stays merged", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		svc := New(repo, db, zap.NewNop().Sugar())
+		testutil.NewTeam().WithMembers(2).Create(t, db)
+		testutil.NewPR().WithID("pr-1").WithReviewers("u2").Merged().Create(t, db)
+
+		resp, err := svc.ReopenPullRequest(ctx, &pullrequestModel.ReopenPullRequestRequest{PullRequestID: "pr-1"})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, pullrequestModel.ErrPullRequestMerged)
+		pr, err := repo.GetByID(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.Equal(t, pullrequestModel.StatusMERGED, pr.Status)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		svc := New(new(mockRepository), nil, zap.NewNop().Sugar())
+
+		_, err := svc.ReopenPullRequest(ctx, &pullrequestModel.ReopenPullRequestRequest{})
+		assert.ErrorIs(t, err, pullrequestModel.ErrInvalidPullRequestID)
+	})
+}
+
+func TestService_MergePullRequest_Conflicts(t *testing.T) {
+	ctx := context.Background()
+
+	seed := func(db *gorm.DB) {
+		testutil.NewTeam().WithMembers(2).WithLead("u2").Create(t, db)
+		db.Exec(
+			"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, has_conflicts) "+
+				"VALUES (?, ?, ?, ?, ?)",
+			"pr-1", "Add feature", "u1", pullrequestModel.StatusOPEN, true,
+		)
+	}
+
+	t.Run("blocked when policy is enabled", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		policy := Policy{BlockMergeOnConflicts: true}
+		svc := New(repo, db, zap.NewNop().Sugar(), WithPolicy(policy))
+		seed(db)
+
+		resp, err := svc.MergePullRequest(ctx, &pullrequestModel.MergePullRequestRequest{PullRequestID: "pr-1"})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, pullrequestModel.ErrPullRequestHasConflicts)
+
+		pr, getErr := repo.GetByID(ctx, "pr-1")
+		require.NoError(t, getErr)
+		assert.Equal(t, pullrequestModel.StatusOPEN, pr.Status)
+	})
+
+	t.Run("allowed by default", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		svc := New(repo, db, zap.NewNop().Sugar())
+		seed(db)
+
+		resp, err := svc.MergePullRequest(ctx, &pullrequestModel.MergePullRequestRequest{PullRequestID: "pr-1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, pullrequestModel.StatusMERGED, resp.Status)
+		assert.True(t, resp.HasConflicts)
+		assert.Nil(t, resp.MergeOverride)
+	})
+
+	t.Run("overridden by team lead", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		policy := Policy{BlockMergeOnConflicts: true}
+		svc := New(repo, db, zap.NewNop().Sugar(), WithPolicy(policy))
+		seed(db)
+
+		resp, err := svc.MergePullRequest(ctx, &pullrequestModel.MergePullRequestRequest{
+			PullRequestID: "pr-1",
+			Override:      true,
+			MergedBy:      "u2",
+			Reason:        " hotfix, conflicts resolved upstream ",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, pullrequestModel.StatusMERGED, resp.Status)
+		assert.Equal(t, &pullrequestModel.MergeOverrideResponse{
+			MergedBy: "u2",
+			Reason:   "hotfix, conflicts resolved upstream",
+		}, resp.MergeOverride)
+
+		activity, err := svc.GetActivity(ctx, "pr-1", sortparam.Sort{})
+		require.NoError(t, err)
+		require.NotEmpty(t, activity.Events)
+		last := activity.Events[len(activity.Events)-1]
+		assert.Equal(t, pullrequestModel.EventMerged, last.Type)
+		assert.Equal(t, "u2", last.ActorID)
+		assert.Equal(t, "hotfix, conflicts resolved upstream", last.Reason)
+	})
+
+	t.Run("override rejected", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		policy := Policy{BlockMergeOnConflicts: true}
+		svc := New(repo, db, zap.NewNop().Sugar(), WithPolicy(policy))
+		seed(db)
+
+		_, err := svc.MergePullRequest(ctx, &pullrequestModel.MergePullRequestRequest{
+			PullRequestID: "pr-1", Override: true, MergedBy: "u1", Reason: "urgent",
+		})
+		assert.ErrorIs(t, err, pullrequestModel.ErrNotTeamLead)
+
+		_, err = svc.MergePullRequest(ctx, &pullrequestModel.MergePullRequestRequest{
+			PullRequestID: "pr-1", Override: true, MergedBy: "u2", Reason: "  ",
+		})
+		assert.ErrorIs(t, err, pullrequestModel.ErrOverrideReasonRequired)
+
+		pr, getErr := repo.GetByID(ctx, "pr-1")
+		require.NoError(t, getErr)
+		assert.Equal(t, pullrequestModel.StatusOPEN, pr.Status)
+	})
+
+	t.Run("override of a merge no rule blocks", func(t *testing.T) {
+		for name, policy := range map[string]Policy{
+			"policy disabled": {},
+			"no conflicts":    {BlockMergeOnConflicts: true},
+		} {
+			t.Run(name, func(t *testing.T) {
+				db := testutil.NewDB(t)
+				repo := repository.New(db, zap.NewNop().Sugar())
+				svc := New(repo, db, zap.NewNop().Sugar(), WithPolicy(policy))
+				seed(db)
+				if policy.BlockMergeOnConflicts {
+					require.NoError(t, repo.SetHasConflicts(ctx, "pr-1", false))
+				}
+
+				_, err := svc.MergePullRequest(ctx, &pullrequestModel.MergePullRequestRequest{
+					PullRequestID: "pr-1", Override: true, MergedBy: "u2", Reason: "urgent",
+				})
+				assert.ErrorIs(t, err, pullrequestModel.ErrOverrideNotNeeded)
+
+				pr, getErr := repo.GetByID(ctx, "pr-1")
+				require.NoError(t, getErr)
+				assert.Equal(t, pullrequestModel.StatusOPEN, pr.Status)
+				activity, getErr := svc.GetActivity(ctx, "pr-1", sortparam.Sort{})
+				require.NoError(t, getErr)
+				for _, event := range activity.Events {
+					assert.NotEqual(t, pullrequestModel.EventMerged, event.Type)
+				}
+			})
+		}
+	})
+}
+
+func TestService_CreatePullRequest_Duplicate(t *testing.T) {
+	ctx := context.Background()
+
+	seed := func(db *gorm.DB) {
+		testutil.NewTeam().WithMembers(1).Create(t, db)
+		testutil.NewPR().WithID("pr-1").Named("Add feature").ByAuthor("u1").Create(t, db)
+	}
+	req := &pullrequestModel.CreatePullRequestRequest{
+		PullRequestID:   "pr-2",
+		PullRequestName: "  add   FEATURE ",
+		AuthorID:        "u1",
+	}
+
+	t.Run("warning by default", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		svc := New(repo, db, zap.NewNop().Sugar())
+		seed(db)
+
+		resp, err := svc.CreatePullRequest(ctx, req)
+
+		require.NoError(t, err)
+		assert.Equal(t, "pr-2", resp.PullRequestID)
+		assert.Contains(t, resp.Warning, "pr-1")
+	})
+
+	t.Run("rejected in strict mode", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		svc := New(repo, db, zap.NewNop().Sugar(), WithPolicy(Policy{RejectDuplicates: true}))
+		seed(db)
+
+		resp, err := svc.CreatePullRequest(ctx, req)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, pullrequestModel.ErrDuplicatePullRequest)
+
+		_, getErr := repo.GetByID(ctx, "pr-2")
+		assert.ErrorIs(t, getErr, pullrequestModel.ErrPullRequestNotFound)
+	})
+
+	t.Run("merged pull request is not a duplicate", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		svc := New(repo, db, zap.NewNop().Sugar(), WithPolicy(Policy{RejectDuplicates: true}))
+		seed(db)
+		db.Exec("UPDATE pull_requests SET status = ? WHERE pull_request_id = ?", pullrequestModel.StatusMERGED, "pr-1")
+
+		resp, err := svc.CreatePullRequest(ctx, req)
+
+		require.NoError(t, err)
+		assert.Empty(t, resp.Warning)
+	})
+}
+
+// blockingNotifier holds the first notification until release is closed.
+type blockingNotifier struct {
+	calls   atomic.Int32
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (n *blockingNotifier) Notify(context.Context, notification.Notification) error {
+	if n.calls.Add(1) == 1 {
+		close(n.entered)
+		<-n.release
+	}
+	return nil
+}
+
+func TestService_CreatePullRequest_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.NewDB(t)
+	repo := repository.New(db, zap.NewNop().Sugar())
+	notifier := &blockingNotifier{entered: make(chan struct{}), release: make(chan struct{})}
+	svc := New(repo, db, zap.NewNop().Sugar(), WithNotifier(notifier))
+	testutil.NewTeam().WithMembers(3).Create(t, db)
+	req := &pullrequestModel.CreatePullRequestRequest{
+		PullRequestID:   "pr-1",
+		PullRequestName: "Add feature",
+		AuthorID:        "u1",
+	}
+
+	const identical = 4
+	var wg sync.WaitGroup
+	results := make([]*pullrequestModel.PullRequestResponse, identical)
+	errs := make([]error, identical)
+	create := func(i int) {
+		defer wg.Done()
+		// Each request has its own copy of the body, as handlers decode it
+		reqCopy := *req
+		results[i], errs[i] = svc.CreatePullRequest(ctx, &reqCopy)
+	}
+
+	// The first request is held in flight until the others arrive
+	wg.Add(1)
+	go create(0)
+	<-notifier.entered
+	for i := 1; i < identical; i++ {
+		wg.Add(1)
+		go create(i)
+	}
+	// A request with a different body for the same ID waits for the creation and then conflicts
+	var conflictErr error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		conflicting := *req
+		conflicting.PullRequestName = "Other feature"
+		_, conflictErr = svc.CreatePullRequest(ctx, &conflicting)
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	close(notifier.release)
+	wg.Wait()
+
+	assert.ErrorIs(t, conflictErr, pullrequestModel.ErrPullRequestExists, "different body gets no result")
+	for i := range identical {
+		require.NoError(t, errs[i])
+		assert.Equal(t, results[0], results[i])
+	}
+	results[1].AssignedReviewers[0] = "changed"
+	assert.NotEqual(t, "changed", results[0].AssignedReviewers[0], "each caller gets its own copy")
+	assert.Equal(t, int32(1), notifier.calls.Load(), "one creation for all identical requests")
+
+	// Once completed, a repeated request is a conflict again
+	_, err := svc.CreatePullRequest(ctx, req)
+	assert.ErrorIs(t, err, pullrequestModel.ErrPullRequestExists)
+}
+
+func TestService_CreatePullRequest_CanceledLeader(t *testing.T) {
+	db := testutil.NewDB(t)
+	repo := repository.New(db, zap.NewNop().Sugar())
+	notifier := &blockingNotifier{entered: make(chan struct{}), release: make(chan struct{})}
+	svc := New(repo, db, zap.NewNop().Sugar(), WithNotifier(notifier))
+	testutil.NewTeam().WithMembers(3).Create(t, db)
+	req := &pullrequestModel.CreatePullRequestRequest{
+		PullRequestID:   "pr-1",
+		PullRequestName: "Add feature",
+		AuthorID:        "u1",
+	}
+
+	// The request that started the creation is canceled while another one waits for it
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	leaderDone := make(chan error, 1)
+	go func() {
+		reqCopy := *req
+		_, err := svc.CreatePullRequest(leaderCtx, &reqCopy)
+		leaderDone <- err
+	}()
+	<-notifier.entered
+
+	followerDone := make(chan error, 1)
+	go func() {
+		reqCopy := *req
+		_, err := svc.CreatePullRequest(context.Background(), &reqCopy)
+		followerDone <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	close(notifier.release)
+
+	require.NoError(t, <-leaderDone)
+	require.NoError(t, <-followerDone, "cancellation of the leader does not fail the waiting request")
+	_, err := repo.GetByID(context.Background(), "pr-1")
+	require.NoError(t, err)
+}
+
+// queueStub records enqueued pull requests; a non-positive capacity rejects all of them.
+type queueStub struct {
+	capacity int
+	ids      []string
+}
+
+func (q *queueStub) Enqueue(prID string) bool {
+	if len(q.ids) >= q.capacity {
+		return false
+	}
+	q.ids = append(q.ids, prID)
+	return true
+}
+
+func TestService_CreatePullRequest_Async(t *testing.T) {
+	ctx := context.Background()
+
+	seed := func(db *gorm.DB) {
+		testutil.NewTeam().WithMembers(3).Create(t, db)
+	}
+	req := &pullrequestModel.CreatePullRequestRequest{
+		PullRequestID:   "pr-1",
+		PullRequestName: "Add feature",
+		AuthorID:        "u1",
+	}
+
+	t.Run("creates PR in ASSIGNING status and enqueues assignment", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		seed(db)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		queue := &queueStub{capacity: 10}
+		svc := New(repo, db, zap.NewNop().Sugar(), WithAssignmentQueue(queue))
+
+		resp, err := svc.CreatePullRequest(ctx, req)
+
+		require.NoError(t, err)
+		assert.Equal(t, pullrequestModel.StatusASSIGNING, resp.Status)
+		assert.Empty(t, resp.AssignedReviewers)
+		assert.Equal(t, []string{"pr-1"}, queue.ids)
+
+		status, err := svc.GetAssignmentStatus(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.True(t, status.Pending)
+
+		require.NoError(t, svc.AssignReviewers(ctx, "pr-1"))
+
+		status, err = svc.GetAssignmentStatus(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.False(t, status.Pending)
+		assert.Equal(t, pullrequestModel.StatusOPEN, status.Status)
+		assert.ElementsMatch(t, []string{"u2", "u3"}, status.AssignedReviewers)
+
+		// Repeated assignment is a no-op
+		require.NoError(t, svc.AssignReviewers(ctx, "pr-1"))
+		reviewers, err := repo.GetReviewers(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.Len(t, reviewers, 2)
+	})
+
+	t.Run("assigns inline when queue is full", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		seed(db)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		svc := New(repo, db, zap.NewNop().Sugar(), WithAssignmentQueue(&queueStub{}))
+
+		resp, err := svc.CreatePullRequest(ctx, req)
+
+		require.NoError(t, err)
+		assert.Equal(t, pullrequestModel.StatusOPEN, resp.Status)
+		assert.ElementsMatch(t, []string{"u2", "u3"}, resp.AssignedReviewers)
+	})
+
+	t.Run("resumes pending assignments", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		seed(db)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		queue := &queueStub{capacity: 10}
+		svc := New(repo, db, zap.NewNop().Sugar(), WithAssignmentQueue(queue))
+		testutil.NewPR().WithID("pr-pending").Assigning().Create(t, db)
+
+		resumed, err := svc.ResumeAssignments(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, resumed)
+		assert.Equal(t, []string{"pr-pending"}, queue.ids)
+	})
+
+	t.Run("PR in ASSIGNING status cannot be merged", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		seed(db)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		svc := New(repo, db, zap.NewNop().Sugar(), WithAssignmentQueue(&queueStub{capacity: 10}))
+
+		_, err := svc.CreatePullRequest(ctx, req)
+		require.NoError(t, err)
+
+		_, err = svc.MergePullRequest(ctx, &pullrequestModel.MergePullRequestRequest{PullRequestID: "pr-1"})
+
+		assert.ErrorIs(t, err, pullrequestModel.ErrAssignmentPending)
+		pr, err := repo.GetByID(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.Equal(t, pullrequestModel.StatusASSIGNING, pr.Status)
+	})
+
+	t.Run("merged PR is not assigned", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		seed(db)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		svc := New(repo, db, zap.NewNop().Sugar(), WithAssignmentQueue(&queueStub{capacity: 10}))
+
+		_, err := svc.CreatePullRequest(ctx, req)
+		require.NoError(t, err)
+		// Merged by other means, e.g. an import, while the assignment was queued
+		now := time.Now()
+		require.NoError(t, repo.UpdateStatus(ctx, "pr-1", pullrequestModel.StatusMERGED, &now))
+
+		require.NoError(t, svc.AssignReviewers(ctx, "pr-1"))
+
+		reviewers, err := repo.GetReviewers(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.Empty(t, reviewers)
+	})
+}
+
+// Unit tests for helper functions
+
+func TestSelectLeastLoadedReviewers(t *testing.T) {
+	candidates := []userModel.User{
+		{UserID: "u1"},
+		{UserID: "u2"},
+		{UserID: "u3"},
+	}
+
+	t.Run("orders by load", func(t *testing.T) {
+		loads := map[string]int{"u1": 5, "u2": 0, "u3": 2}
+
+		selected := selectLeastLoadedReviewers(candidates, loads, 2)
+
+		require.Len(t, selected, 2)
+		assert.Equal(t, "u2", selected[0].UserID)
+		assert.Equal(t, "u3", selected[1].UserID)
+	})
+
+	t.Run("missing load counts as zero", func(t *testing.T) {
+		loads := map[string]int{"u1": 3, "u3": 1}
+
+		selected := selectLeastLoadedReviewers(candidates, loads, 1)
+
+		require.Len(t, selected, 1)
+		assert.Equal(t, "u2", selected[0].UserID)
+	})
+
+	t.Run("fewer candidates than maxCount", func(t *testing.T) {
+		selected := selectLeastLoadedReviewers(candidates[:1], map[string]int{}, 2)
+
+		assert.Len(t, selected, 1)
+	})
+}
+
+func TestService_DeterministicAssignment(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.NewDB(t)
+	repo := repository.New(db, zap.NewNop().Sugar())
+	policy := Policy{DeterministicAssignment: true}
+	svc := New(repo, db, zap.NewNop().Sugar(), WithPolicy(policy))
+
+	testutil.NewTeam().WithMemberIDs("u5", "u1", "u4", "u3", "u2").Create(t, db)
+
+	resp, err := svc.CreatePullRequest(ctx, &pullrequestModel.CreatePullRequestRequest{
+		PullRequestID:   "pr-1",
+		PullRequestName: "Add feature",
+		AuthorID:        "u1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2", "u3"}, resp.AssignedReviewers)
+
+	reassign, err := svc.ReassignReviewer(ctx, &pullrequestModel.ReassignReviewerRequest{
+		PullRequestID: "pr-1",
+		OldUserID:     "u2",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "u4", reassign.ReplacedBy)
+}
+
+func TestService_PreviewAssignment(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.NewDB(t)
+	repo := repository.New(db, zap.NewNop().Sugar())
+	policy := Policy{DeterministicAssignment: true}
+	svc := New(repo, db, zap.NewNop().Sugar(), WithPolicy(policy))
+
+	testutil.NewTeam().WithMembers(4).Create(t, db)
+	testutil.NewPR().WithID("pr-0").ByAuthor("u3").WithReviewers("u2").Create(t, db)
+
+	t.Run("selects least loaded candidates without writing", func(t *testing.T) {
+		resp, err := svc.PreviewAssignment(ctx, &pullrequestModel.PreviewAssignmentRequest{AuthorID: "u1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "u1", resp.AuthorID)
+		assert.Equal(t, "backend", resp.TeamName)
+		assert.Equal(t, []string{"u3", "u4"}, resp.AssignedReviewers)
+		require.Len(t, resp.Candidates, 3)
+		assert.Equal(t, []string{"u3", "u4", "u2"}, []string{
+			resp.Candidates[0].UserID, resp.Candidates[1].UserID, resp.Candidates[2].UserID,
+		})
+		assert.Zero(t, resp.Candidates[0].ReviewLoad)
+		assert.Positive(t, resp.Candidates[2].ReviewLoad)
+
+		var prs, reviewers int64
+		db.Table("pull_requests").Count(&prs)
+		db.Table("pull_request_reviewers").Count(&reviewers)
+		assert.Equal(t, int64(1), prs)
+		assert.Equal(t, int64(1), reviewers)
+	})
+
+	t.Run("author not found", func(t *testing.T) {
+		resp, err := svc.PreviewAssignment(ctx, &pullrequestModel.PreviewAssignmentRequest{AuthorID: "ghost"})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, pullrequestModel.ErrAuthorNotFound)
+	})
+
+	t.Run("empty author", func(t *testing.T) {
+		_, err := svc.PreviewAssignment(ctx, &pullrequestModel.PreviewAssignmentRequest{})
+
+		assert.ErrorIs(t, err, pullrequestModel.ErrInvalidAuthorID)
+	})
+}
+
+func TestService_GetCandidates(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.NewDB(t)
+	svc := New(repository.New(db, zap.NewNop().Sugar()), db, zap.NewNop().Sugar())
+
+	testutil.NewTeam().WithMembers(5).Inactive(1).Create(t, db)
+	testutil.NewPR().WithID("pr-1").ByAuthor("u1").WithReviewers("u2").Create(t, db)
+	testutil.NewPR().WithID("pr-2").ByAuthor("u2").WithReviewers("u3").Create(t, db)
+	testutil.NewPR().WithID("pr-3").ByAuthor("u1").WithReviewers("u2").Merged().Create(t, db)
+
+	t.Run("excludes author, assigned reviewers and inactive members", func(t *testing.T) {
+		resp, err := svc.GetCandidates(ctx, "pr-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "pr-1", resp.PullRequestID)
+		assert.Equal(t, []pullrequestModel.AssignmentCandidate{
+			{UserID: "u4", ReviewLoad: 0},
+			{UserID: "u3", ReviewLoad: 1},
+		}, resp.Candidates)
+	})
+
+	t.Run("merged pull request", func(t *testing.T) {
+		_, err := svc.GetCandidates(ctx, "pr-3")
+		assert.ErrorIs(t, err, pullrequestModel.ErrPullRequestMerged)
+	})
+
+	t.Run("pull request not found", func(t *testing.T) {
+		_, err := svc.GetCandidates(ctx, "missing")
+		assert.ErrorIs(t, err, pullrequestModel.ErrPullRequestNotFound)
+	})
+}
+
+func TestService_SuggestReviewers(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.NewDB(t)
+	svc := New(repository.New(db, zap.NewNop().Sugar()), db, zap.NewNop().Sugar())
+
+	testutil.NewTeam().WithMembers(6).Inactive(1).Create(t, db)
+	// u2 reviewed the author before, u3 knows the branch, u4 is busy, u5 has no history
+	testutil.NewPR().ByAuthor("u1").IntoBranch("release").WithReviewers("u2").Merged().Create(t, db)
+	testutil.NewPR().ByAuthor("u1").WithReviewers("u2").Merged().Create(t, db)
+	testutil.NewPR().ByAuthor("u5").IntoBranch("release").WithReviewers("u3").Merged().Create(t, db)
+	testutil.NewPR().ByAuthor("u5").WithReviewers("u4").Create(t, db)
+	testutil.NewPR().WithID("pr-new").ByAuthor("u1").IntoBranch("release").Create(t, db)
+
+	t.Run("ranks by history and load", func(t *testing.T) {
+		resp, err := svc.SuggestReviewers(ctx, "pr-new")
+
+		require.NoError(t, err)
+		assert.Equal(t, "pr-new", resp.PullRequestID)
+		assert.Equal(t, []pullrequestModel.ReviewerSuggestion{
+			{UserID: "u2", Score: 5, AuthorReviews: 2, BranchReviews: 1},
+			{UserID: "u3", Score: 1, BranchReviews: 1},
+			{UserID: "u5", Score: 0},
+			{UserID: "u4", Score: -2, ReviewLoad: 1},
+		}, resp.Suggestions)
+	})
+
+	t.Run("assigned reviewers are not suggested", func(t *testing.T) {
+		testutil.NewPR().WithID("pr-assigned").ByAuthor("u1").WithReviewers("u2").Create(t, db)
+
+		resp, err := svc.SuggestReviewers(ctx, "pr-assigned")
+
+		require.NoError(t, err)
+		for _, suggestion := range resp.Suggestions {
+			assert.NotEqual(t, "u2", suggestion.UserID)
+		}
+	})
+
+	t.Run("merged pull request", func(t *testing.T) {
+		testutil.NewPR().WithID("pr-merged").Merged().Create(t, db)
+
+		_, err := svc.SuggestReviewers(ctx, "pr-merged")
+		assert.ErrorIs(t, err, pullrequestModel.ErrPullRequestMerged)
+	})
+
+	t.Run("pull request not found", func(t *testing.T) {
+		_, err := svc.SuggestReviewers(ctx, "missing")
+		assert.ErrorIs(t, err, pullrequestModel.ErrPullRequestNotFound)
+	})
+}
+
+func TestService_ReassignAll(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.NewDB(t)
+	repo := repository.New(db, zap.NewNop().Sugar())
+	svc := New(repo, db, zap.NewNop().Sugar(), WithPolicy(Policy{DeterministicAssignment: true}))
+
+	testutil.NewTeam().WithMembers(3).Create(t, db)
+	testutil.NewPR().WithID("pr-1").ByAuthor("u1").WithReviewers("u2").Create(t, db)
+	testutil.NewPR().WithID("pr-2").ByAuthor("u1").WithReviewers("u2", "u3").Create(t, db)
+	testutil.NewPR().WithID("pr-3").ByAuthor("u1").WithReviewers("u2").Merged().Create(t, db)
+
+	t.Run("replaces reviewer in every open pull request", func(t *testing.T) {
+		resp, err := svc.ReassignAll(ctx, &pullrequestModel.ReassignAllRequest{OldUserID: "u2"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "u2", resp.OldUserID)
+		require.Len(t, resp.Results, 2)
+		assert.Equal(t, "pr-1", resp.Results[0].PullRequestID)
+		assert.Equal(t, "u3", resp.Results[0].ReplacedBy)
+		assert.Equal(t, pullrequestModel.ReassignAllResult{
+			PullRequestID: "pr-2",
+			Error:         pullrequestModel.ReassignResultNoCandidate,
+		}, resp.Results[1])
+
+		reviewers, err := repo.GetReviewers(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.NotContains(t, reviewers, "u2")
+		reviewers, err = repo.GetReviewers(ctx, "pr-2")
+		require.NoError(t, err)
+		assert.Contains(t, reviewers, "u2", "reviewer without a candidate is kept")
+		reviewers, err = repo.GetReviewers(ctx, "pr-3")
+		require.NoError(t, err)
+		assert.Contains(t, reviewers, "u2", "merged pull requests are not changed")
+	})
+
+	t.Run("user without reviews", func(t *testing.T) {
+		resp, err := svc.ReassignAll(ctx, &pullrequestModel.ReassignAllRequest{OldUserID: "u1"})
+
+		require.NoError(t, err)
+		assert.Empty(t, resp.Results)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		_, err := svc.ReassignAll(ctx, &pullrequestModel.ReassignAllRequest{OldUserID: "ghost"})
+		assert.ErrorIs(t, err, pullrequestModel.ErrAuthorNotFound)
+	})
+}
+
+func TestService_ReplaceDeactivatedReviewers(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.NewDB(t)
+	repo := repository.New(db, zap.NewNop().Sugar())
+	notifier := &recordingNotifier{}
+	svc := New(repo, db, zap.NewNop().Sugar(),
+		WithNotifier(notifier), WithPolicy(Policy{DeterministicAssignment: true}))
+
+	testutil.NewTeam().WithMembers(5).Create(t, db)
+	testutil.NewPR().WithID("pr-1").ByAuthor("u1").WithReviewers("u2").Create(t, db)
+	testutil.NewPR().WithID("pr-2").ByAuthor("u1").WithReviewers("u2", "u3").Create(t, db)
+	testutil.NewPR().WithID("pr-3").ByAuthor("u1").WithReviewers("u5").Create(t, db)
+	testutil.NewPR().WithID("pr-4").ByAuthor("u1").WithReviewers("u2").Merged().Create(t, db)
+
+	deactivate := func(userIDs ...string) func(tx *gorm.DB) ([]string, error) {
+		return func(tx *gorm.DB) ([]string, error) {
+			err := tx.Exec("UPDATE users SET is_active = ? WHERE user_id IN ?", false, userIDs).Error
+			return userIDs, err
+		}
+	}
+
+	t.Run("failed deactivation changes nothing", func(t *testing.T) {
+		failure := errors.New("deactivation failed")
+
+		_, err := svc.ReplaceDeactivatedReviewers(ctx, func(tx *gorm.DB) ([]string, error) {
+			if _, deactivateErr := deactivate("u2")(tx); deactivateErr != nil {
+				return nil, deactivateErr
+			}
+			return nil, failure
+		})
+
+		assert.ErrorIs(t, err, failure)
+		reviewers, err := repo.GetReviewers(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u2"}, reviewers)
+		assert.Empty(t, notifier.notifications)
+	})
+
+	t.Run("replaces by the least loaded member or removes", func(t *testing.T) {
+		// u4 is the only active candidate: u5 already reviews pr-3 and u1 is the author
+		prIDs, err := svc.ReplaceDeactivatedReviewers(ctx, deactivate("u2", "u3", "u5"))
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr-1", "pr-2", "pr-3"}, prIDs)
+		for prID, want := range map[string][]string{"pr-1": {"u4"}, "pr-2": {"u4"}, "pr-3": {"u4"}, "pr-4": {"u2"}} {
+			reviewers, getErr := repo.GetReviewers(ctx, prID)
+			require.NoError(t, getErr)
+			assert.Equal(t, want, reviewers, prID)
+		}
+
+		activity, err := svc.GetActivity(ctx, "pr-2", sortparam.Sort{})
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, len(activity.Events), 2)
+		last := activity.Events[len(activity.Events)-2:]
+		assert.Equal(t, pullrequestModel.EventReviewerReplaced, last[0].Type)
+		assert.Equal(t, pullrequestModel.EventReviewerRemoved, last[1].Type)
+		assert.Equal(t, pullrequestModel.SourceDeactivation, last[1].Source)
+
+		events := make([]string, 0, len(notifier.notifications))
+		for _, n := range notifier.notifications {
+			events = append(events, n.PullRequestID+" "+string(n.Event))
+		}
+		assert.Equal(t, []string{
+			"pr-1 " + string(notification.EventReviewerReassigned),
+			"pr-2 " + string(notification.EventReviewerReassigned),
+			"pr-2 " + string(notification.EventReviewerUnassigned),
+			"pr-3 " + string(notification.EventReviewerReassigned),
+		}, events)
+		assert.Equal(t, "u4", notifier.notifications[0].Details["new_user_id"])
+		assert.Contains(t, notifier.notifications[2].Recipients, "u3")
+	})
+
+	t.Run("nothing deactivated", func(t *testing.T) {
+		prIDs, err := svc.ReplaceDeactivatedReviewers(ctx, deactivate())
+
+		require.NoError(t, err)
+		assert.Empty(t, prIDs)
+	})
+}
+
+func TestService_AssignReviewer(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.NewDB(t)
+	repo := repository.New(db, zap.NewNop().Sugar())
+	svc := New(repo, db, zap.NewNop().Sugar())
+
+	testutil.NewTeam().WithMembers(5).Inactive(1).WithLead("u4").Create(t, db)
+	testutil.NewTeam().Named("frontend").WithMemberPrefix("f").WithMembers(1).Create(t, db)
+	testutil.NewPR().WithID("pr-1").ByAuthor("u1").WithReviewers("u2").Create(t, db)
+	testutil.NewPR().WithID("pr-2").ByAuthor("u1").WithReviewers("u2", "u3").Create(t, db)
+	testutil.NewPR().WithID("pr-3").ByAuthor("u1").WithReviewers("u2").Merged().Create(t, db)
+
+	assign := func(prID, userID, assignedBy string) error {
+		_, err := svc.AssignReviewer(ctx, &pullrequestModel.AssignReviewerRequest{
+			PullRequestID: prID,
+			UserID:        userID,
+			AssignedBy:    assignedBy,
+		})
+		return err
+	}
+
+	errorCases := []struct {
+		name       string
+		prID       string
+		userID     string
+		assignedBy string
+		wantErr    error
+	}{
+		{"caller is not the team lead", "pr-1", "u3", "u2", pullrequestModel.ErrNotTeamLead},
+		{"reviewer from another team", "pr-1", "f1", "u4", pullrequestModel.ErrReviewerNotInTeam},
+		{"inactive reviewer", "pr-1", "u5", "u4", pullrequestModel.ErrReviewerInactive},
+		{"author as reviewer", "pr-1", "u1", "u4", pullrequestModel.ErrAuthorCannotBeReviewer},
+		{"unknown reviewer", "pr-1", "ghost", "u4", pullrequestModel.ErrAuthorNotFound},
+		{"already assigned", "pr-1", "u2", "u4", pullrequestModel.ErrReviewerAlreadyAssigned},
+		{"maximum reviewers", "pr-2", "u4", "u4", pullrequestModel.ErrMaxReviewersExceeded},
+		{"merged pull request", "pr-3", "u3", "u4", pullrequestModel.ErrPullRequestMerged},
+		{"pull request not found", "missing", "u3", "u4", pullrequestModel.ErrPullRequestNotFound},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.ErrorIs(t, assign(tc.prID, tc.userID, tc.assignedBy), tc.wantErr)
+		})
+	}
+
+	t.Run("reviewer at load limit", func(t *testing.T) {
+		limited := New(repo, db, zap.NewNop().Sugar(), WithPolicy(Policy{ReviewerMaxLoad: 1}))
+
+		_, err := limited.AssignReviewer(ctx, &pullrequestModel.AssignReviewerRequest{
+			PullRequestID: "pr-1",
+			UserID:        "u3",
+			AssignedBy:    "u4",
+		})
+
+		assert.ErrorIs(t, err, pullrequestModel.ErrReviewerOverloaded)
+	})
+
+	t.Run("assigns reviewer and records the lead", func(t *testing.T) {
+		resp, err := svc.AssignReviewer(ctx, &pullrequestModel.AssignReviewerRequest{
+			PullRequestID: "pr-1",
+			UserID:        "u3",
+			AssignedBy:    "u4",
+		})
+
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"u2", "u3"}, resp.AssignedReviewers)
+
+		activity, err := svc.GetActivity(ctx, "pr-1", sortparam.Sort{})
+		require.NoError(t, err)
+		require.NotEmpty(t, activity.Events)
+		last := activity.Events[len(activity.Events)-1]
+		assert.Equal(t, pullrequestModel.EventReviewerAssignedManually, last.Type)
+		assert.Equal(t, "u3", last.UserID)
+		assert.Equal(t, "u4", last.ActorID)
+		assert.Equal(t, pullrequestModel.SourceManual, last.Source)
+	})
+}
+
+func TestService_UnassignReviewer(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.NewDB(t)
+	repo := repository.New(db, zap.NewNop().Sugar())
+	svc := New(repo, db, zap.NewNop().Sugar())
+
+	testutil.NewTeam().WithMembers(3).Create(t, db)
+	testutil.NewPR().WithID("pr-1").ByAuthor("u1").WithReviewers("u2", "u3").Create(t, db)
+	testutil.NewPR().WithID("pr-2").ByAuthor("u1").WithReviewers("u2").Merged().Create(t, db)
+	testutil.NewPR().WithID("pr-3").ByAuthor("u1").WithReviewers("u2").Create(t, db)
+
+	unassign := func(s Service, prID, userID string) (*pullrequestModel.PullRequestResponse, error) {
+		return s.UnassignReviewer(ctx, &pullrequestModel.UnassignReviewerRequest{
+			PullRequestID: prID,
+			UserID:        userID,
+		})
+	}
+
+	t.Run("minimum reviewers policy", func(t *testing.T) {
+		strict := New(repo, db, zap.NewNop().Sugar(), WithPolicy(Policy{MinReviewers: 2}))
+
+		_, err := unassign(strict, "pr-1", "u2")
+
+		assert.ErrorIs(t, err, pullrequestModel.ErrTooFewReviewers)
+	})
+
+	t.Run("removes reviewer without replacement", func(t *testing.T) {
+		resp, err := unassign(svc, "pr-1", "u2")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u3"}, resp.AssignedReviewers)
+
+		activity, err := svc.GetActivity(ctx, "pr-1", sortparam.Sort{})
+		require.NoError(t, err)
+		require.NotEmpty(t, activity.Events)
+		last := activity.Events[len(activity.Events)-1]
+		assert.Equal(t, pullrequestModel.EventReviewerRemoved, last.Type)
+		assert.Equal(t, "u2", last.UserID)
+		assert.Equal(t, pullrequestModel.SourceManual, last.Source)
+	})
+
+	t.Run("last reviewer without minimum", func(t *testing.T) {
+		resp, err := unassign(svc, "pr-3", "u2")
+
+		require.NoError(t, err)
+		assert.Empty(t, resp.AssignedReviewers)
+	})
+
+	t.Run("reviewer not assigned", func(t *testing.T) {
+		_, err := unassign(svc, "pr-1", "u2")
+		assert.ErrorIs(t, err, pullrequestModel.ErrReviewerNotAssigned)
+	})
+
+	t.Run("merged pull request", func(t *testing.T) {
+		_, err := unassign(svc, "pr-2", "u2")
+		assert.ErrorIs(t, err, pullrequestModel.ErrPullRequestMerged)
+	})
+
+	t.Run("pull request not found", func(t *testing.T) {
+		_, err := unassign(svc, "missing", "u2")
+		assert.ErrorIs(t, err, pullrequestModel.ErrPullRequestNotFound)
+	})
+}
+
+func TestService_ChangeAuthor(t *testing.T) {
+	ctx := context.Background()
+	changeAuthor := func(svc Service, prID, authorID string) (*pullrequestModel.PullRequestResponse, error) {
+		return svc.ChangeAuthor(ctx, &pullrequestModel.ChangeAuthorRequest{PullRequestID: prID, AuthorID: authorID})
+	}
+
+	t.Run("transfers authorship", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		svc := New(repository.New(db, zap.NewNop().Sugar()), db, zap.NewNop().Sugar())
+		testutil.NewTeam().WithMembers(4).Create(t, db)
+		testutil.NewPR().WithID("pr-1").ByAuthor("u1").WithReviewers("u2").Create(t, db)
+
+		resp, err := changeAuthor(svc, "pr-1", "u3")
+
+		require.NoError(t, err)
+		assert.Equal(t, "u3", resp.AuthorID)
+		assert.Equal(t, []string{"u2"}, resp.AssignedReviewers)
+
+		activity, err := svc.GetActivity(ctx, "pr-1", sortparam.Sort{})
+		require.NoError(t, err)
+		require.NotEmpty(t, activity.Events)
+		last := activity.Events[len(activity.Events)-1]
+		assert.Equal(t, pullrequestModel.EventAuthorChanged, last.Type)
+		assert.Equal(t, "u3", last.UserID)
+		assert.Equal(t, "u1", last.PreviousUserID)
+	})
+
+	t.Run("replaces new author among reviewers", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		policy := Policy{DeterministicAssignment: true}
+		svc := New(repository.New(db, zap.NewNop().Sugar()), db, zap.NewNop().Sugar(), WithPolicy(policy))
+		testutil.NewTeam().WithMembers(4).Create(t, db)
+		testutil.NewPR().WithID("pr-1").ByAuthor("u1").WithReviewers("u2", "u3").Create(t, db)
+
+		resp, err := changeAuthor(svc, "pr-1", "u2")
+
+		require.NoError(t, err)
+		assert.Equal(t, "u2", resp.AuthorID)
+		assert.ElementsMatch(t, []string{"u3", "u4"}, resp.AssignedReviewers)
+
+		activity, err := svc.GetActivity(ctx, "pr-1", sortparam.Sort{})
+		require.NoError(t, err)
+		last := activity.Events[len(activity.Events)-1]
+		assert.Equal(t, pullrequestModel.EventReviewerReplaced, last.Type)
+		assert.Equal(t, "u4", last.UserID)
+		assert.Equal(t, "u2", last.PreviousUserID)
+		assert.Equal(t, pullrequestModel.SourceAuthorChange, last.Source)
+	})
+
+	t.Run("removes new author among reviewers without candidates", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		svc := New(repository.New(db, zap.NewNop().Sugar()), db, zap.NewNop().Sugar())
+		testutil.NewTeam().WithMembers(3).Inactive(1).Create(t, db)
+		testutil.NewPR().WithID("pr-1").ByAuthor("u1").WithReviewers("u2").Create(t, db)
+
+		resp, err := changeAuthor(svc, "pr-1", "u2")
+
+		require.NoError(t, err)
+		assert.Empty(t, resp.AssignedReviewers)
+	})
+
+	t.Run("same author is a no-op", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		svc := New(repository.New(db, zap.NewNop().Sugar()), db, zap.NewNop().Sugar())
+		testutil.NewTeam().WithMembers(2).Create(t, db)
+		testutil.NewPR().WithID("pr-1").ByAuthor("u1").WithReviewers("u2").Create(t, db)
+
+		resp, err := changeAuthor(svc, "pr-1", "u1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "u1", resp.AuthorID)
+		var events int64
+		db.Table("pull_request_events").Where("event_type = ?", pullrequestModel.EventAuthorChanged).Count(&events)
+		assert.Zero(t, events)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		svc := New(repository.New(db, zap.NewNop().Sugar()), db, zap.NewNop().Sugar())
+		testutil.NewTeam().WithMembers(2).Create(t, db)
+		testutil.NewPR().WithID("pr-1").ByAuthor("u1").Create(t, db)
+		testutil.NewPR().WithID("pr-2").ByAuthor("u1").Merged().Create(t, db)
+
+		_, err := changeAuthor(svc, "pr-1", "ghost")
+		assert.ErrorIs(t, err, pullrequestModel.ErrAuthorNotFound)
+
+		_, err = changeAuthor(svc, "pr-2", "u2")
+		assert.ErrorIs(t, err, pullrequestModel.ErrPullRequestMerged)
+
+		_, err = changeAuthor(svc, "missing", "u2")
+		assert.ErrorIs(t, err, pullrequestModel.ErrPullRequestNotFound)
+
+		_, err = changeAuthor(svc, "pr-1", "")
+		assert.ErrorIs(t, err, pullrequestModel.ErrInvalidAuthorID)
+	})
+}
+
+func TestService_TransferTeam(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.NewDB(t)
+	repo := repository.New(db, zap.NewNop().Sugar())
+	svc := New(repo, db, zap.NewNop().Sugar(), WithPolicy(Policy{DeterministicAssignment: true}))
+
+	testutil.NewTeam().WithMembers(3).Create(t, db)
+	testutil.NewTeam().Named("frontend").WithMemberPrefix("f").WithMembers(4).Inactive(1).WithLead("f1").Create(t, db)
+	testutil.NewTeam().Named("empty").Create(t, db)
+	testutil.NewPR().WithID("pr-1").ByAuthor("u1").WithReviewers("u2", "u3").Create(t, db)
+	testutil.NewPR().WithID("pr-2").ByAuthor("u1").WithReviewers("u2").Create(t, db)
+	testutil.NewPR().WithID("pr-3").ByAuthor("u1").Merged().Create(t, db)
+
+	transfer := func(prID, teamName string) (*pullrequestModel.PullRequestResponse, error) {
+		return svc.TransferTeam(ctx, &pullrequestModel.TransferTeamRequest{PullRequestID: prID, TeamName: teamName})
+	}
+
+	t.Run("replaces reviewers with destination team members", func(t *testing.T) {
+		resp, err := transfer("pr-1", "frontend")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"f1", "f2"}, resp.AssignedReviewers)
+
+		activity, err := svc.GetActivity(ctx, "pr-1", sortparam.Sort{})
+		require.NoError(t, err)
+		types := make([]string, 0, len(activity.Events))
+		for _, e := range activity.Events {
+			types = append(types, e.Type)
+			assert.Equal(t, pullrequestModel.SourceTeamTransfer, e.Source)
+		}
+		assert.Equal(t, []string{
+			pullrequestModel.EventReviewerRemoved, pullrequestModel.EventReviewerRemoved,
+			pullrequestModel.EventReviewerAssigned, pullrequestModel.EventReviewerAssigned,
+		}, types)
+	})
+
+	t.Run("destination team owns the pull request afterwards", func(t *testing.T) {
+		candidates, err := svc.GetCandidates(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.Equal(t, []pullrequestModel.AssignmentCandidate{{UserID: "f3"}}, candidates.Candidates)
+
+		_, err = svc.UnassignReviewer(ctx, &pullrequestModel.UnassignReviewerRequest{
+			PullRequestID: "pr-1", UserID: "f2",
+		})
+		require.NoError(t, err)
+		resp, err := svc.AssignReviewer(ctx, &pullrequestModel.AssignReviewerRequest{
+			PullRequestID: "pr-1", UserID: "f3", AssignedBy: "f1",
+		})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"f1", "f3"}, resp.AssignedReviewers)
+
+		_, err = svc.AssignReviewer(ctx, &pullrequestModel.AssignReviewerRequest{
+			PullRequestID: "pr-1", UserID: "u2", AssignedBy: "f1",
+		})
+		assert.ErrorIs(t, err, pullrequestModel.ErrReviewerNotInTeam)
+	})
+
+	t.Run("destination team without active members", func(t *testing.T) {
+		resp, err := transfer("pr-2", "empty")
+
+		require.NoError(t, err)
+		assert.Empty(t, resp.AssignedReviewers)
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		_, err := transfer("pr-1", "missing")
+		assert.ErrorIs(t, err, teamModel.ErrTeamNotFound)
+	})
+
+	t.Run("merged pull request", func(t *testing.T) {
+		_, err := transfer("pr-3", "frontend")
+		assert.ErrorIs(t, err, pullrequestModel.ErrPullRequestMerged)
+	})
+}
+
+func TestSortByUserID(t *testing.T) {
+	candidates := []userModel.User{{UserID: "u3"}, {UserID: "u1"}, {UserID: "u2"}}
+
+	sorted := sortByUserID(candidates)
+
+	assert.Equal(t, []string{"u1", "u2", "u3"}, userIDs(sorted))
+	assert.Equal(t, "u3", candidates[0].UserID, "input must not be modified")
+}
 
 func TestSelectRandomReviewers(t *testing.T) {
 	t.Run("selects up to maxCount reviewers", func(t *testing.T) {
@@ -0,0 +1,143 @@
+// Package sla provides the background job that detects violations of team review SLAs.
+package sla
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/festy23/avito_internship/internal/notification"
+	pullrequestModel "github.com/festy23/avito_internship/internal/pullrequest/model"
+	"github.com/festy23/avito_internship/internal/pullrequest/repository"
+	"github.com/festy23/avito_internship/pkg/clock"
+)
+
+// Job checks open pull requests against the review SLA of their author's team, records new
+// violations and alerts team leads. The service does not track reviews themselves, so a pull
+// request waits for its first review while no reviewer is assigned to it.
+type Job struct {
+	repo     repository.Repository
+	notifier notification.Notifier
+	clock    clock.Clock
+	logger   *zap.SugaredLogger
+}
+
+// New creates a new SLA job instance.
+func New(repo repository.Repository, notifier notification.Notifier, logger *zap.SugaredLogger) *Job {
+	return NewWithClock(repo, notifier, clock.New(), logger)
+}
+
+// NewWithClock creates a new SLA job instance that takes the current time from clk.
+func NewWithClock(
+	repo repository.Repository,
+	notifier notification.Notifier,
+	clk clock.Clock,
+	logger *zap.SugaredLogger,
+) *Job {
+	return &Job{
+		repo:     repo,
+		notifier: notifier,
+		clock:    clk,
+		logger:   logger,
+	}
+}
+
+// Run executes a single SLA check; it is registered in the background job scheduler.
+func (j *Job) Run(ctx context.Context) error {
+	_, err := j.RunOnce(ctx)
+	return err
+}
+
+// RunOnce records violations detected since the previous check and returns them.
+// Every violation is recorded and reported once, even when several replicas check concurrently.
+func (j *Job) RunOnce(ctx context.Context) ([]pullrequestModel.SLAViolation, error) {
+	candidates, err := j.repo.GetSLACandidates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := j.clock.Now()
+	violations := make([]pullrequestModel.SLAViolation, 0)
+	for _, candidate := range candidates {
+		for _, v := range Check(candidate, now) {
+			recorded, recordErr := j.repo.RecordSLAViolation(ctx, &v)
+			if recordErr != nil {
+				return nil, recordErr
+			}
+			if !recorded {
+				continue
+			}
+			j.alert(ctx, v, candidate.LeadUserID)
+			violations = append(violations, v)
+		}
+	}
+
+	if len(violations) > 0 {
+		j.logger.Infow("SLA violations detected", "count", len(violations))
+	}
+	return violations, nil
+}
+
+// Check returns the SLAs of its team that a pull request has exceeded at now.
+func Check(candidate pullrequestModel.SLACandidate, now time.Time) []pullrequestModel.SLAViolation {
+	age := now.Sub(candidate.CreatedAt)
+	exceeded := func(hours *int) bool {
+		return hours != nil && age > time.Duration(*hours)*time.Hour
+	}
+
+	violations := make([]pullrequestModel.SLAViolation, 0)
+	if !candidate.HasReviewers && exceeded(candidate.FirstReviewHours) {
+		violations = append(violations,
+			violation(candidate, pullrequestModel.SLAFirstReview, *candidate.FirstReviewHours, now))
+	}
+	if exceeded(candidate.MergeHours) {
+		violations = append(violations, violation(candidate, pullrequestModel.SLAMerge, *candidate.MergeHours, now))
+	}
+	return violations
+}
+
+// violation builds a violation of an SLA by a candidate.
+func violation(
+	candidate pullrequestModel.SLACandidate,
+	kind string,
+	hours int,
+	now time.Time,
+) pullrequestModel.SLAViolation {
+	return pullrequestModel.SLAViolation{
+		PullRequestID:  candidate.PullRequestID,
+		TeamName:       candidate.TeamName,
+		Kind:           kind,
+		ThresholdHours: hours,
+		DetectedAt:     now,
+	}
+}
+
+// alert notifies the team lead of a violation; teams without a lead only get it logged.
+func (j *Job) alert(ctx context.Context, v pullrequestModel.SLAViolation, leadUserID *string) {
+	j.logger.Warnw("SLA violated",
+		"pull_request_id", v.PullRequestID,
+		"team_name", v.TeamName,
+		"kind", v.Kind,
+		"threshold_hours", v.ThresholdHours,
+	)
+
+	recipients := []string{}
+	if leadUserID != nil {
+		recipients = append(recipients, *leadUserID)
+	}
+	n := notification.Notification{
+		Event:         notification.EventSLAViolated,
+		PullRequestID: v.PullRequestID,
+		Recipients:    recipients,
+		Details: map[string]string{
+			"team_name":       v.TeamName,
+			"kind":            v.Kind,
+			"threshold_hours": strconv.Itoa(v.ThresholdHours),
+		},
+	}
+	if err := j.notifier.Notify(ctx, n); err != nil {
+		j.logger.Warnw("failed to send notification", "pull_request_id", v.PullRequestID, "error", err)
+	}
+}
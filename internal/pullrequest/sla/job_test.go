@@ -0,0 +1,149 @@
+package sla
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/festy23/avito_internship/internal/notification"
+	pullrequestModel "github.com/festy23/avito_internship/internal/pullrequest/model"
+	"github.com/festy23/avito_internship/internal/pullrequest/repository"
+	"github.com/festy23/avito_internship/internal/testutil"
+	"github.com/festy23/avito_internship/pkg/clock"
+)
+
+// recordingNotifier collects sent notifications.
+type recordingNotifier struct {
+	mu   sync.Mutex
+	sent []notification.Notification
+	err  error
+}
+
+func (n *recordingNotifier) Notify(_ context.Context, notification notification.Notification) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.sent = append(n.sent, notification)
+	return n.err
+}
+
+var base = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func newJob(db *gorm.DB, notifier notification.Notifier, now time.Time) *Job {
+	logger := zap.NewNop().Sugar()
+	return NewWithClock(repository.New(db, logger), notifier, clock.NewFake(now), logger)
+}
+
+func TestCheck(t *testing.T) {
+	hours := func(h int) *int { return &h }
+	candidate := pullrequestModel.SLACandidate{
+		PullRequestID:    "pr-1",
+		CreatedAt:        base,
+		TeamName:         "backend",
+		FirstReviewHours: hours(4),
+		MergeHours:       hours(48),
+	}
+
+	tests := []struct {
+		name         string
+		age          time.Duration
+		hasReviewers bool
+		want         []string
+	}{
+		{name: "within SLA", age: 4 * time.Hour, want: []string{}},
+		{name: "waiting for a reviewer", age: 5 * time.Hour, want: []string{pullrequestModel.SLAFirstReview}},
+		{name: "reviewer assigned", age: 5 * time.Hour, hasReviewers: true, want: []string{}},
+		{
+			name: "not merged",
+			age:  49 * time.Hour,
+			want: []string{pullrequestModel.SLAFirstReview, pullrequestModel.SLAMerge},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := candidate
+			c.HasReviewers = tt.hasReviewers
+
+			kinds := []string{}
+			for _, v := range Check(c, base.Add(tt.age)) {
+				kinds = append(kinds, v.Kind)
+				assert.Equal(t, "backend", v.TeamName)
+			}
+			assert.Equal(t, tt.want, kinds)
+		})
+	}
+
+	t.Run("disabled checks", func(t *testing.T) {
+		c := candidate
+		c.FirstReviewHours, c.MergeHours = nil, nil
+		assert.Empty(t, Check(c, base.Add(1000*time.Hour)))
+	})
+}
+
+func TestJob_RunOnce(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("records and reports each violation once", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		testutil.NewTeam().WithMembers(3).WithLead("u3").WithSLA(4, 48).Create(t, db)
+		testutil.NewTeam().Named("frontend").WithMemberPrefix("f").WithMembers(2).Create(t, db)
+		testutil.NewPR().WithID("waiting").ByAuthor("u1").CreatedAt(base).Create(t, db)
+		testutil.NewPR().WithID("reviewed").ByAuthor("u1").WithReviewers("u2").CreatedAt(base).Create(t, db)
+		testutil.NewPR().WithID("fresh").ByAuthor("u1").CreatedAt(base.Add(3*time.Hour)).Create(t, db)
+		testutil.NewPR().WithID("merged").ByAuthor("u1").Merged().CreatedAt(base).Create(t, db)
+		testutil.NewPR().WithID("no-sla").ByAuthor("f1").CreatedAt(base).Create(t, db)
+
+		notifier := &recordingNotifier{}
+		violations, err := newJob(db, notifier, base.Add(5*time.Hour)).RunOnce(ctx)
+
+		require.NoError(t, err)
+		require.Len(t, violations, 1)
+		assert.Equal(t, "waiting", violations[0].PullRequestID)
+		assert.Equal(t, pullrequestModel.SLAFirstReview, violations[0].Kind)
+		assert.Equal(t, 4, violations[0].ThresholdHours)
+
+		require.Len(t, notifier.sent, 1)
+		assert.Equal(t, notification.EventSLAViolated, notifier.sent[0].Event)
+		assert.Equal(t, []string{"u3"}, notifier.sent[0].Recipients)
+		assert.Equal(t, map[string]string{
+			"team_name":       "backend",
+			"kind":            pullrequestModel.SLAFirstReview,
+			"threshold_hours": "4",
+		}, notifier.sent[0].Details)
+
+		// The next check reports only violations detected since
+		violations, err = newJob(db, notifier, base.Add(6*time.Hour)).RunOnce(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, violations)
+
+		violations, err = newJob(db, notifier, base.Add(49*time.Hour)).RunOnce(ctx)
+		require.NoError(t, err)
+		assert.Len(t, violations, 3, "merge SLA of waiting and reviewed, first review of fresh")
+		assert.Len(t, notifier.sent, 4)
+
+		var stored int64
+		require.NoError(t, db.Model(&pullrequestModel.SLAViolation{}).Count(&stored).Error)
+		assert.Equal(t, int64(4), stored)
+	})
+
+	t.Run("team without lead", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		testutil.NewTeam().WithMembers(2).WithSLA(0, 1).Create(t, db)
+		testutil.NewPR().WithID("pr-1").ByAuthor("u1").WithReviewers("u2").CreatedAt(base).Create(t, db)
+
+		notifier := &recordingNotifier{err: errors.New("unavailable")}
+		violations, err := newJob(db, notifier, base.Add(2*time.Hour)).RunOnce(ctx)
+
+		require.NoError(t, err, "notification failures do not fail the job")
+		require.Len(t, violations, 1)
+		assert.Equal(t, pullrequestModel.SLAMerge, violations[0].Kind)
+		require.Len(t, notifier.sent, 1)
+		assert.Empty(t, notifier.sent[0].Recipients)
+	})
+}
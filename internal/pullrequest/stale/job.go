@@ -0,0 +1,140 @@
+// Package stale provides the background job that closes inactive pull requests.
+package stale
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/festy23/avito_internship/internal/config"
+	"github.com/festy23/avito_internship/internal/notification"
+	pullrequestModel "github.com/festy23/avito_internship/internal/pullrequest/model"
+	"github.com/festy23/avito_internship/internal/pullrequest/repository"
+	"github.com/festy23/avito_internship/internal/tenant"
+	"github.com/festy23/avito_internship/pkg/clock"
+)
+
+// Closer closes pull requests. The pull request service implements it, so a stale pull request
+// is closed, logged and reported to its reviewers exactly like a manually closed one.
+type Closer interface {
+	ClosePullRequest(
+		ctx context.Context,
+		req *pullrequestModel.ClosePullRequestRequest,
+	) (*pullrequestModel.PullRequestResponse, error)
+}
+
+// Job closes open pull requests without activity for the configured number of days, unless their
+// team has opted out, and notifies their authors and team leads.
+type Job struct {
+	repo      repository.Repository
+	closer    Closer
+	notifier  notification.Notifier
+	afterDays int
+	clock     clock.Clock
+	logger    *zap.SugaredLogger
+}
+
+// New creates a new stale pull request job instance.
+func New(
+	repo repository.Repository,
+	closer Closer,
+	notifier notification.Notifier,
+	cfg config.StaleConfig,
+	logger *zap.SugaredLogger,
+) *Job {
+	return NewWithClock(repo, closer, notifier, cfg, clock.New(), logger)
+}
+
+// NewWithClock creates a new stale pull request job instance that takes the current time from clk.
+func NewWithClock(
+	repo repository.Repository,
+	closer Closer,
+	notifier notification.Notifier,
+	cfg config.StaleConfig,
+	clk clock.Clock,
+	logger *zap.SugaredLogger,
+) *Job {
+	return &Job{
+		repo:      repo,
+		closer:    closer,
+		notifier:  notifier,
+		afterDays: cfg.CloseAfterDays,
+		clock:     clk,
+		logger:    logger,
+	}
+}
+
+// Run executes a single pass; it is registered in the background job scheduler.
+func (j *Job) Run(ctx context.Context) error {
+	_, err := j.RunOnce(ctx)
+	return err
+}
+
+// RunOnce closes the pull requests inactive since the cutoff and returns their IDs.
+// A pull request that is merged, closed or taken into assignment meanwhile is skipped.
+func (j *Job) RunOnce(ctx context.Context) ([]string, error) {
+	cutoff := j.clock.Now().Add(-time.Duration(j.afterDays) * 24 * time.Hour)
+
+	candidates, err := j.repo.GetStaleCandidates(ctx, cutoff)
+	if err != nil {
+		return nil, err
+	}
+
+	closed := make([]string, 0)
+	for _, candidate := range candidates {
+		// Candidates of all tenants are found at once; each is closed within its own tenant
+		prCtx := tenant.WithID(ctx, candidate.TenantID)
+		_, closeErr := j.closer.ClosePullRequest(prCtx, &pullrequestModel.ClosePullRequestRequest{
+			PullRequestID: candidate.PullRequestID,
+			CloseReason:   pullrequestModel.CloseReasonStale,
+			Note:          "no activity for " + strconv.Itoa(j.afterDays) + " days",
+		})
+		if skipped(closeErr) {
+			j.logger.Debugw("stale pull request skipped", "pull_request_id", candidate.PullRequestID, "error", closeErr)
+			continue
+		}
+		if closeErr != nil {
+			return closed, closeErr
+		}
+
+		j.alert(prCtx, candidate)
+		closed = append(closed, candidate.PullRequestID)
+	}
+
+	if len(closed) > 0 {
+		j.logger.Infow("closed stale pull requests", "count", len(closed), "cutoff", cutoff)
+	}
+	return closed, nil
+}
+
+// skipped reports whether closing failed because the pull request changed after it was found.
+func skipped(err error) bool {
+	return errors.Is(err, pullrequestModel.ErrPullRequestMerged) ||
+		errors.Is(err, pullrequestModel.ErrAssignmentPending) ||
+		errors.Is(err, pullrequestModel.ErrPullRequestNotFound)
+}
+
+// alert notifies the author and the team lead that a pull request was closed for inactivity.
+func (j *Job) alert(ctx context.Context, candidate pullrequestModel.StaleCandidate) {
+	recipients := []string{candidate.AuthorID}
+	if candidate.LeadUserID != nil {
+		recipients = append(recipients, *candidate.LeadUserID)
+	}
+	details := map[string]string{"inactive_days": strconv.Itoa(j.afterDays)}
+	if candidate.TeamName != nil {
+		details["team_name"] = *candidate.TeamName
+	}
+
+	n := notification.Notification{
+		Event:         notification.EventPullRequestAutoClosed,
+		PullRequestID: candidate.PullRequestID,
+		Recipients:    notification.Recipients(recipients),
+		Details:       details,
+	}
+	if err := j.notifier.Notify(ctx, n); err != nil {
+		j.logger.Warnw("failed to send notification", "pull_request_id", candidate.PullRequestID, "error", err)
+	}
+}
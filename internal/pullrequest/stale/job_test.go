@@ -0,0 +1,179 @@
+package stale
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/festy23/avito_internship/internal/config"
+	"github.com/festy23/avito_internship/internal/notification"
+	pullrequestModel "github.com/festy23/avito_internship/internal/pullrequest/model"
+	"github.com/festy23/avito_internship/internal/pullrequest/repository"
+	"github.com/festy23/avito_internship/internal/pullrequest/service"
+	"github.com/festy23/avito_internship/internal/tenant"
+	"github.com/festy23/avito_internship/internal/testutil"
+	"github.com/festy23/avito_internship/pkg/clock"
+	"github.com/festy23/avito_internship/pkg/sortparam"
+)
+
+// recordingNotifier collects sent notifications.
+type recordingNotifier struct {
+	mu   sync.Mutex
+	sent []notification.Notification
+}
+
+func (n *recordingNotifier) Notify(_ context.Context, notification notification.Notification) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.sent = append(n.sent, notification)
+	return nil
+}
+
+// closerFunc adapts a function to the Closer interface.
+type closerFunc func(ctx context.Context, req *pullrequestModel.ClosePullRequestRequest) error
+
+func (f closerFunc) ClosePullRequest(
+	ctx context.Context,
+	req *pullrequestModel.ClosePullRequestRequest,
+) (*pullrequestModel.PullRequestResponse, error) {
+	if err := f(ctx, req); err != nil {
+		return nil, err
+	}
+	return &pullrequestModel.PullRequestResponse{PullRequestID: req.PullRequestID}, nil
+}
+
+var (
+	base = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	cfg  = config.StaleConfig{CloseAfterDays: 30}
+	day  = 24 * time.Hour
+)
+
+func newJob(db *gorm.DB, closer Closer, notifier notification.Notifier, now time.Time) *Job {
+	logger := zap.NewNop().Sugar()
+	return NewWithClock(repository.New(db, logger), closer, notifier, cfg, clock.NewFake(now), logger)
+}
+
+func TestJob_RunOnce(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("closes inactive pull requests", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		testutil.NewTeam().WithMembers(3).WithLead("u3").Create(t, db)
+		testutil.NewTeam().Named("frontend").WithMemberPrefix("f").WithMembers(2).AutoCloseDisabled().Create(t, db)
+		testutil.NewPR().WithID("stale").ByAuthor("u1").WithReviewers("u2").CreatedAt(base).Create(t, db)
+		testutil.NewPR().WithID("fresh").ByAuthor("u1").CreatedAt(base.Add(2*day)).Create(t, db)
+		testutil.NewPR().WithID("logged").ByAuthor("u1").CreatedAt(base).Create(t, db)
+		testutil.NewPR().WithID("commented").ByAuthor("u1").CreatedAt(base).Create(t, db)
+		testutil.NewPR().WithID("merged").ByAuthor("u1").Merged().CreatedAt(base).Create(t, db)
+		testutil.NewPR().WithID("opted-out").ByAuthor("f1").CreatedAt(base).Create(t, db)
+
+		// Activity after the cutoff keeps a pull request open
+		event := pullrequestModel.NewPullRequestEvent("logged", pullrequestModel.EventReviewerAssigned, "u2", "")
+		event.CreatedAt = base.Add(5 * day)
+		require.NoError(t, db.Create(event).Error)
+		require.NoError(t, db.Exec(
+			"INSERT INTO pull_request_comments (pull_request_id, user_id, body, created_at) VALUES (?, ?, ?, ?)",
+			"commented", "u2", "LGTM?", base.Add(10*day),
+		).Error)
+
+		logger := zap.NewNop().Sugar()
+		repo := repository.New(db, logger)
+		notifier := &recordingNotifier{}
+		svc := service.New(repo, db, logger, service.WithNotifier(notifier))
+
+		closed, err := newJob(db, svc, notifier, base.Add(31*day)).RunOnce(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"stale"}, closed)
+
+		pr, err := repo.GetByID(ctx, "stale")
+		require.NoError(t, err)
+		assert.Equal(t, pullrequestModel.StatusCLOSED, pr.Status)
+		require.NotNil(t, pr.CloseReason)
+		assert.Equal(t, pullrequestModel.CloseReasonStale, *pr.CloseReason)
+
+		events, err := repo.GetEvents(ctx, "stale", sortparam.Sort{})
+		require.NoError(t, err)
+		last := events[len(events)-1]
+		assert.Equal(t, pullrequestModel.EventClosed, last.EventType)
+		require.NotNil(t, last.Reason)
+		assert.Equal(t, "STALE: no activity for 30 days", *last.Reason)
+
+		// Reviewers are notified by the close path, the author and the team lead by the job
+		require.Len(t, notifier.sent, 2)
+		assert.Equal(t, notification.EventPullRequestClosed, notifier.sent[0].Event)
+		assert.Equal(t, []string{"u2"}, notifier.sent[0].Recipients)
+		assert.Equal(t, notification.EventPullRequestAutoClosed, notifier.sent[1].Event)
+		assert.Equal(t, []string{"u1", "u3"}, notifier.sent[1].Recipients)
+		assert.Equal(t, map[string]string{"team_name": "backend", "inactive_days": "30"}, notifier.sent[1].Details)
+
+		// Closed pull requests are not candidates any more
+		closed, err = newJob(db, svc, notifier, base.Add(32*day)).RunOnce(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, closed)
+		assert.Len(t, notifier.sent, 2)
+	})
+
+	t.Run("closes within the tenant of the pull request", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		testutil.NewTeam().WithMembers(2).Create(t, db)
+		testutil.NewPR().WithID("pr-1").ByAuthor("u1").CreatedAt(base).Create(t, db)
+		require.NoError(t, db.Exec("UPDATE pull_requests SET tenant_id = ?", "acme").Error)
+
+		var tenantID string
+		closer := closerFunc(func(ctx context.Context, req *pullrequestModel.ClosePullRequestRequest) error {
+			tenantID = tenant.ID(ctx)
+			assert.Equal(t, pullrequestModel.CloseReasonStale, req.CloseReason)
+			return nil
+		})
+
+		closed, err := newJob(db, closer, notification.NewNop(), base.Add(31*day)).RunOnce(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr-1"}, closed)
+		assert.Equal(t, "acme", tenantID)
+	})
+
+	t.Run("skips pull requests changed meanwhile", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		testutil.NewTeam().WithMembers(2).Create(t, db)
+		testutil.NewPR().WithID("pr-1").ByAuthor("u1").CreatedAt(base).Create(t, db)
+		testutil.NewPR().WithID("pr-2").ByAuthor("u1").CreatedAt(base.Add(time.Hour)).Create(t, db)
+
+		closer := closerFunc(func(_ context.Context, req *pullrequestModel.ClosePullRequestRequest) error {
+			if req.PullRequestID == "pr-1" {
+				return pullrequestModel.ErrPullRequestMerged
+			}
+			return nil
+		})
+		notifier := &recordingNotifier{}
+
+		closed, err := newJob(db, closer, notifier, base.Add(31*day)).RunOnce(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr-2"}, closed)
+		require.Len(t, notifier.sent, 1)
+		assert.Equal(t, "pr-2", notifier.sent[0].PullRequestID)
+	})
+
+	t.Run("close failure fails the run", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		testutil.NewTeam().WithMembers(2).Create(t, db)
+		testutil.NewPR().WithID("pr-1").ByAuthor("u1").CreatedAt(base).Create(t, db)
+
+		closeErr := errors.New("connection refused")
+		closer := closerFunc(func(context.Context, *pullrequestModel.ClosePullRequestRequest) error {
+			return closeErr
+		})
+
+		err := newJob(db, closer, notification.NewNop(), base.Add(31*day)).Run(ctx)
+		assert.ErrorIs(t, err, closeErr)
+	})
+}
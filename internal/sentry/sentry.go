@@ -0,0 +1,169 @@
+// Package sentry reports recovered panics to Sentry through its HTTP store API.
+package sentry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/festy23/avito_internship/pkg/httpclient"
+)
+
+const clientName = "avito-internship/1.0"
+
+// DSN is a parsed Sentry DSN.
+type DSN struct {
+	// Endpoint is the URL of the store API of the project.
+	Endpoint string
+	// PublicKey authenticates events.
+	PublicKey string
+	// Host is the Sentry host; unlike the DSN it is safe to log.
+	Host string
+}
+
+// ParseDSN parses a DSN of the form "https://<public key>@<host>[/<path>]/<project id>".
+func ParseDSN(dsn string) (DSN, error) {
+	u, err := url.Parse(dsn)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return DSN{}, fmt.Errorf("DSN must be an absolute http(s) URL")
+	}
+	if u.User == nil || u.User.Username() == "" {
+		return DSN{}, fmt.Errorf("DSN must contain a public key")
+	}
+	path := strings.TrimSuffix(u.Path, "/")
+	slash := strings.LastIndex(path, "/")
+	projectID := path[slash+1:]
+	if projectID == "" {
+		return DSN{}, fmt.Errorf("DSN must contain a project ID")
+	}
+
+	endpoint := url.URL{Scheme: u.Scheme, Host: u.Host, Path: path[:slash] + "/api/" + projectID + "/store/"}
+	return DSN{Endpoint: endpoint.String(), PublicKey: u.User.Username(), Host: u.Host}, nil
+}
+
+// Client sends panic reports to Sentry. Reports are sent in the background so a panic response
+// is not delayed by the error tracker.
+type Client struct {
+	dsn         DSN
+	environment string
+	client      *httpclient.Client
+	logger      *zap.SugaredLogger
+	wg          sync.WaitGroup
+}
+
+// New creates a Sentry client from a DSN. Environment tags events, e.g. "production"; it may be empty.
+func New(dsn, environment string, timeout time.Duration, logger *zap.SugaredLogger) (*Client, error) {
+	parsed, err := ParseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		dsn:         parsed,
+		environment: environment,
+		client:      httpclient.New(httpclient.Options{Name: "sentry", Timeout: timeout, Retry: httpclient.DefaultRetry()}),
+		logger:      logger,
+	}, nil
+}
+
+// Host returns the Sentry host events are sent to.
+func (c *Client) Host() string {
+	return c.dsn.Host
+}
+
+// event is a Sentry event in the store API format.
+type event struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   string            `json:"timestamp"`
+	Level       string            `json:"level"`
+	Platform    string            `json:"platform"`
+	Logger      string            `json:"logger"`
+	Environment string            `json:"environment,omitempty"`
+	Message     string            `json:"message"`
+	Exception   exceptions        `json:"exception"`
+	Request     request           `json:"request"`
+	Tags        map[string]string `json:"tags"`
+	Extra       map[string]string `json:"extra"`
+}
+
+type exceptions struct {
+	Values []exception `json:"values"`
+}
+
+type exception struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+}
+
+// ReportPanic sends a recovered panic. The error ID is used as the Sentry event ID, so an ID from
+// a bug report can be looked up directly. Only the method and path of the request are sent.
+func (c *Client) ReportPanic(errorID string, recovered any, stack []byte, r *http.Request) {
+	value := fmt.Sprint(recovered)
+	body, err := json.Marshal(event{
+		EventID:     errorID,
+		Timestamp:   time.Now().UTC().Format(time.RFC3339),
+		Level:       "error",
+		Platform:    "go",
+		Logger:      "middleware.Recovery",
+		Environment: c.environment,
+		Message:     "panic: " + value,
+		Exception:   exceptions{Values: []exception{{Type: "panic", Value: value}}},
+		Request:     request{Method: r.Method, URL: r.URL.Path},
+		Tags:        map[string]string{"error_id": errorID},
+		Extra:       map[string]string{"stack": string(stack)},
+	})
+	if err != nil {
+		c.logger.Errorw("failed to encode sentry event", "error_id", errorID, "error", err)
+		return
+	}
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		if err := c.send(context.Background(), body); err != nil {
+			c.logger.Warnw("failed to report panic to sentry", "error_id", errorID, "host", c.dsn.Host, "error", err)
+		}
+	}()
+}
+
+// Wait blocks until all reports have been sent.
+func (c *Client) Wait() {
+	c.wg.Wait()
+}
+
+func (c *Client) send(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.dsn.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sentry-Auth", fmt.Sprintf("Sentry sentry_version=7, sentry_client=%s, sentry_key=%s",
+		clientName, c.dsn.PublicKey))
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+	return nil
+}
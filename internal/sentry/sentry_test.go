@@ -0,0 +1,90 @@
+package sentry
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestParseDSN(t *testing.T) {
+	t.Run("valid DSN", func(t *testing.T) {
+		dsn, err := ParseDSN("https://abc123@o1.ingest.sentry.io/42")
+		require.NoError(t, err)
+		assert.Equal(t, DSN{
+			Endpoint:  "https://o1.ingest.sentry.io/api/42/store/",
+			PublicKey: "abc123",
+			Host:      "o1.ingest.sentry.io",
+		}, dsn)
+	})
+
+	t.Run("DSN with path prefix", func(t *testing.T) {
+		dsn, err := ParseDSN("http://key@sentry.internal:9000/sentry/7")
+		require.NoError(t, err)
+		assert.Equal(t, "http://sentry.internal:9000/sentry/api/7/store/", dsn.Endpoint)
+	})
+
+	invalid := map[string]string{
+		"not a URL":          "sentry",
+		"unsupported scheme": "ftp://key@sentry.io/1",
+		"missing key":        "https://sentry.io/1",
+		"missing project":    "https://key@sentry.io/",
+	}
+	for name, dsn := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseDSN(dsn)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestClient_ReportPanic(t *testing.T) {
+	var (
+		auth string
+		body map[string]any
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/42/store/", r.URL.Path)
+		auth = r.Header.Get("X-Sentry-Auth")
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	dsn := strings.Replace(server.URL, "http://", "http://public@", 1) + "/42"
+	client, err := New(dsn, "staging", time.Second, zap.NewNop().Sugar())
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/create?token=secret", nil)
+	client.ReportPanic("0123456789abcdef0123456789abcdef", "boom", []byte("goroutine 1"), req)
+	client.Wait()
+
+	assert.Contains(t, auth, "sentry_key=public")
+	assert.Equal(t, "0123456789abcdef0123456789abcdef", body["event_id"])
+	assert.Equal(t, "staging", body["environment"])
+	assert.Equal(t, "panic: boom", body["message"])
+	assert.Equal(t, map[string]any{"method": "POST", "url": "/pullRequest/create"}, body["request"])
+	assert.Equal(t, map[string]any{"stack": "goroutine 1"}, body["extra"])
+}
+
+func TestClient_ReportPanic_ServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	dsn := strings.Replace(server.URL, "http://", "http://public@", 1) + "/42"
+	client, err := New(dsn, "", time.Second, zap.NewNop().Sugar())
+	require.NoError(t, err)
+
+	err = client.send(t.Context(), []byte(`{}`))
+	assert.ErrorContains(t, err, "429")
+}
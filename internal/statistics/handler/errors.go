@@ -0,0 +1,10 @@
+package handler
+
+import (
+	"github.com/festy23/avito_internship/internal/statistics/model"
+	"github.com/festy23/avito_internship/pkg/apierror"
+)
+
+// errorRegistry maps errors of the statistics endpoints.
+var errorRegistry = apierror.Registry{}.
+	Register(model.ErrInvalidFairnessWindow, apierror.InvalidField("days", "range", ""))
@@ -3,22 +3,22 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
-	"go.uber.org/zap"
 
 	"github.com/festy23/avito_internship/internal/statistics/service"
+	"github.com/festy23/avito_internship/pkg/apierror"
 )
 
 // Handler handles HTTP requests for statistics endpoints.
 type Handler struct {
 	service service.Service
-	logger  *zap.SugaredLogger
 }
 
 // New creates a new statistics handler instance.
-func New(svc service.Service, logger *zap.SugaredLogger) *Handler {
-	return &Handler{service: svc, logger: logger}
+func New(svc service.Service) *Handler {
+	return &Handler{service: svc}
 }
 
 // GetReviewersStatistics handles GET /statistics/reviewers request.
@@ -31,8 +31,7 @@ func New(svc service.Service, logger *zap.SugaredLogger) *Handler {
 func (h *Handler) GetReviewersStatistics(c *gin.Context) {
 	resp, err := h.service.GetReviewersStatistics(c.Request.Context())
 	if err != nil {
-		h.logger.Errorw("error getting reviewers statistics", "error", err)
-		errorResponse(c, "INTERNAL_ERROR", "internal server error", http.StatusInternalServerError)
+		apierror.Fail(c, err)
 		return
 	}
 
@@ -49,8 +48,36 @@ func (h *Handler) GetReviewersStatistics(c *gin.Context) {
 func (h *Handler) GetPullRequestStatistics(c *gin.Context) {
 	resp, err := h.service.GetPullRequestStatistics(c.Request.Context())
 	if err != nil {
-		h.logger.Errorw("error getting pull request statistics", "error", err)
-		errorResponse(c, "INTERNAL_ERROR", "internal server error", http.StatusInternalServerError)
+		apierror.Fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetFairness handles GET /statistics/fairness request.
+// Reports for each team how evenly reviewer assignments of the last days were distributed among its members.
+// @Summary Get fairness of reviewer distribution per team
+// @Tags Statistics
+// @Produce json
+// @Param days query int false "Window in days (1-365), 30 by default"
+// @Success 200 {object} model.FairnessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /statistics/fairness [get] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) GetFairness(c *gin.Context) {
+	days := 0
+	if raw := c.Query("days"); raw != "" {
+		var err error
+		if days, err = strconv.Atoi(raw); err != nil {
+			apierror.Fail(c, apierror.InvalidField("days", "type", "days must be an integer"))
+			return
+		}
+	}
+
+	resp, err := h.service.GetFairness(c.Request.Context(), days)
+	if err != nil {
+		errorRegistry.Fail(c, err)
 		return
 	}
 
@@ -12,7 +12,6 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
-	"go.uber.org/zap"
 
 	"github.com/festy23/avito_internship/internal/statistics/model"
 	"github.com/festy23/avito_internship/internal/statistics/service"
@@ -39,6 +38,14 @@ func (m *mockService) GetPullRequestStatistics(ctx context.Context) (*model.Pull
 	return args.Get(0).(*model.PullRequestStatisticsResponse), args.Error(1)
 }
 
+func (m *mockService) GetFairness(ctx context.Context, days int) (*model.FairnessResponse, error) {
+	args := m.Called(ctx, days)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.FairnessResponse), args.Error(1)
+}
+
 var _ service.Service = (*mockService)(nil)
 
 func setupRouter() *gin.Engine {
@@ -49,7 +56,7 @@ func setupRouter() *gin.Engine {
 func TestHandler_GetReviewersStatistics(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.GET("/statistics/reviewers", handler.GetReviewersStatistics)
 
@@ -93,7 +100,7 @@ func TestHandler_GetReviewersStatistics(t *testing.T) {
 
 	t.Run("success empty list", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.GET("/statistics/reviewers", handler.GetReviewersStatistics)
 
@@ -120,7 +127,7 @@ func TestHandler_GetReviewersStatistics(t *testing.T) {
 
 	t.Run("service error", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.GET("/statistics/reviewers", handler.GetReviewersStatistics)
 
@@ -145,7 +152,7 @@ func TestHandler_GetReviewersStatistics(t *testing.T) {
 func TestHandler_GetPullRequestStatistics(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.GET("/statistics/pullrequests", handler.GetPullRequestStatistics)
 
@@ -181,7 +188,7 @@ func TestHandler_GetPullRequestStatistics(t *testing.T) {
 
 	t.Run("success with zero statistics", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.GET("/statistics/pullrequests", handler.GetPullRequestStatistics)
 
@@ -215,7 +222,7 @@ func TestHandler_GetPullRequestStatistics(t *testing.T) {
 
 	t.Run("service error", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.GET("/statistics/pullrequests", handler.GetPullRequestStatistics)
 
@@ -236,3 +243,69 @@ func TestHandler_GetPullRequestStatistics(t *testing.T) {
 		mockSvc.AssertExpectations(t)
 	})
 }
+
+func TestHandler_GetFairness(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockSvc := new(mockService)
+		router := setupRouter()
+		router.GET("/statistics/fairness", New(mockSvc).GetFairness)
+
+		mockSvc.On("GetFairness", mock.Anything, 7).Return(&model.FairnessResponse{
+			WindowDays: 7,
+			Teams: []model.TeamFairness{
+				{TeamName: "backend", Members: 2, Assignments: 4, MinAssignments: 1, MaxAssignments: 3, Gini: 0.25},
+			},
+		}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/statistics/fairness?days=7", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp model.FairnessResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, 7, resp.WindowDays)
+		require.Len(t, resp.Teams, 1)
+		assert.InDelta(t, 0.25, resp.Teams[0].Gini, 1e-9)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("default window", func(t *testing.T) {
+		mockSvc := new(mockService)
+		router := setupRouter()
+		router.GET("/statistics/fairness", New(mockSvc).GetFairness)
+		mockSvc.On("GetFairness", mock.Anything, 0).Return(&model.FairnessResponse{WindowDays: 30}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/statistics/fairness", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("invalid days", func(t *testing.T) {
+		mockSvc := new(mockService)
+		router := setupRouter()
+		router.GET("/statistics/fairness", New(mockSvc).GetFairness)
+		mockSvc.On("GetFairness", mock.Anything, 400).Return(nil, model.ErrInvalidFairnessWindow)
+
+		for _, days := range []string{"abc", "400"} {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/statistics/fairness?days="+days, nil))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), `"field":"days"`)
+		}
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		mockSvc := new(mockService)
+		router := setupRouter()
+		router.GET("/statistics/fairness", New(mockSvc).GetFairness)
+		mockSvc.On("GetFairness", mock.Anything, 0).Return(nil, errors.New("database error"))
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/statistics/fairness", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
@@ -1,12 +1,15 @@
 // Package model provides data transfer objects for statistics module.
 package model
 
+import "time"
+
 // ReviewerStatistics represents statistics for a reviewer.
 type ReviewerStatistics struct {
 	UserID          string `json:"user_id"`
 	Username        string `json:"username"`
 	TeamName        string `json:"team_name"`
 	AssignmentCount int    `json:"assignment_count"`
+	ReviewWeight    int    `json:"review_weight"`
 	IsActive        bool   `json:"is_active"`
 }
 
@@ -21,6 +24,7 @@ type PullRequestStatistics struct {
 	TotalPRs              int     `json:"total_prs"`
 	OpenPRs               int     `json:"open_prs"`
 	MergedPRs             int     `json:"merged_prs"`
+	ClosedPRs             int     `json:"closed_prs"`
 	AverageReviewersPerPR float64 `json:"average_reviewers_per_pr"`
 	PRsWith0Reviewers     int     `json:"prs_with_0_reviewers"`
 	PRsWith1Reviewer      int     `json:"prs_with_1_reviewer"`
@@ -31,3 +35,35 @@ type PullRequestStatistics struct {
 type PullRequestStatisticsResponse struct {
 	Statistics PullRequestStatistics `json:"statistics"`
 }
+
+// DefaultFairnessWindowDays is the window of fairness statistics when none is requested.
+const DefaultFairnessWindowDays = 30
+
+// MaxFairnessWindowDays is the longest window of fairness statistics.
+const MaxFairnessWindowDays = 365
+
+// MemberAssignments represents the number of reviewer assignments of a team member over a window.
+type MemberAssignments struct {
+	UserID      string
+	TeamName    string
+	Assignments int
+}
+
+// TeamFairness represents how evenly reviews are distributed among the members of a team.
+type TeamFairness struct {
+	TeamName       string `json:"team_name"`
+	Members        int    `json:"members"`
+	Assignments    int    `json:"assignments"`
+	MinAssignments int    `json:"min_assignments"`
+	MaxAssignments int    `json:"max_assignments"`
+	// Gini is the Gini coefficient of assignments per member: 0 when all members got the same number
+	// of reviews, approaching 1 when a single member got all of them.
+	Gini float64 `json:"gini"`
+}
+
+// FairnessResponse represents response for reviewer distribution fairness.
+type FairnessResponse struct {
+	WindowDays int            `json:"window_days"`
+	Since      time.Time      `json:"since"`
+	Teams      []TeamFairness `json:"teams"`
+}
@@ -0,0 +1,6 @@
+package model
+
+import "errors"
+
+// ErrInvalidFairnessWindow indicates that the fairness window is out of range.
+var ErrInvalidFairnessWindow = errors.New("days must be between 1 and 365")
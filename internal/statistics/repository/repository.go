@@ -3,11 +3,16 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 
+	"github.com/festy23/avito_internship/internal/database/dberror"
+	"github.com/festy23/avito_internship/internal/database/dialect"
+	pullrequestModel "github.com/festy23/avito_internship/internal/pullrequest/model"
 	"github.com/festy23/avito_internship/internal/statistics/model"
+	"github.com/festy23/avito_internship/internal/tenant"
 )
 
 // Repository defines the interface for statistics data access operations.
@@ -17,6 +22,17 @@ type Repository interface {
 
 	// GetPullRequestStatistics returns statistics for pull requests.
 	GetPullRequestStatistics(ctx context.Context) (*model.PullRequestStatistics, error)
+
+	// GetMemberAssignments returns the number of reviewer assignments since the given time for active
+	// users and users assigned in that period, ordered by team and user.
+	GetMemberAssignments(ctx context.Context, since time.Time) ([]model.MemberAssignments, error)
+}
+
+// assignmentEvents are the activity events that make a user a reviewer of a pull request.
+var assignmentEvents = []string{
+	pullrequestModel.EventReviewerAssigned,
+	pullrequestModel.EventReviewerAssignedManually,
+	pullrequestModel.EventReviewerReplaced,
 }
 
 type repository struct {
@@ -38,6 +54,9 @@ func (r *repository) GetReviewersStatistics(ctx context.Context) ([]model.Review
 
 	var stats []model.ReviewerStatistics
 
+	lines := "(" + dialect.CastBigInt(r.db, "pull_requests.lines_added") + " + pull_requests.lines_removed)"
+	weight := dialect.CastBigInt(r.db, "COALESCE(SUM(1 + "+dialect.IntDiv(r.db, lines, "?")+"), 0)")
+
 	err := r.db.WithContext(ctx).
 		Table("users").
 		Select(`
@@ -45,16 +64,19 @@ func (r *repository) GetReviewersStatistics(ctx context.Context) ([]model.Review
 			users.username,
 			users.team_name,
 			users.is_active,
-			COALESCE(COUNT(pull_request_reviewers.user_id), 0) as assignment_count
-		`).
+			COALESCE(COUNT(pull_request_reviewers.user_id), 0) as assignment_count,
+			`+weight+` as review_weight
+		`, pullrequestModel.ReviewWeightLinesPerUnit).
 		Joins("LEFT JOIN pull_request_reviewers ON users.user_id = pull_request_reviewers.user_id").
+		Joins("LEFT JOIN pull_requests ON pull_request_reviewers.pull_request_id = pull_requests.pull_request_id").
+		Scopes(tenant.Scope(ctx, "users")).
 		Group("users.user_id, users.username, users.team_name, users.is_active").
 		Order("assignment_count DESC, users.user_id ASC").
 		Scan(&stats).Error
 
 	if err != nil {
 		r.logger.Errorw("GetReviewersStatistics database error", "error", err)
-		return nil, err
+		return nil, dberror.Wrap(err, "get reviewers statistics")
 	}
 
 	if stats == nil {
@@ -73,6 +95,7 @@ func (r *repository) GetPullRequestStatistics(ctx context.Context) (*model.PullR
 		TotalPRs              int64   `gorm:"column:total_prs"`
 		OpenPRs               int64   `gorm:"column:open_prs"`
 		MergedPRs             int64   `gorm:"column:merged_prs"`
+		ClosedPRs             int64   `gorm:"column:closed_prs"`
 		AverageReviewersPerPR float64 `gorm:"column:avg_reviewers"`
 		PRsWith0Reviewers     int64   `gorm:"column:prs_0_reviewers"`
 		PRsWith1Reviewer      int64   `gorm:"column:prs_1_reviewer"`
@@ -85,6 +108,7 @@ func (r *repository) GetPullRequestStatistics(ctx context.Context) (*model.PullR
 			COUNT(*) as total_prs,
 			SUM(CASE WHEN status = 'OPEN' THEN 1 ELSE 0 END) as open_prs,
 			SUM(CASE WHEN status = 'MERGED' THEN 1 ELSE 0 END) as merged_prs,
+			SUM(CASE WHEN status = 'CLOSED' THEN 1 ELSE 0 END) as closed_prs,
 			COALESCE(AVG(reviewer_counts.reviewer_count), 0) as avg_reviewers,
 			SUM(CASE WHEN COALESCE(reviewer_counts.reviewer_count, 0) = 0 THEN 1 ELSE 0 END) as prs_0_reviewers,
 			SUM(CASE WHEN COALESCE(reviewer_counts.reviewer_count, 0) = 1 THEN 1 ELSE 0 END) as prs_1_reviewer,
@@ -97,17 +121,19 @@ func (r *repository) GetPullRequestStatistics(ctx context.Context) (*model.PullR
 				GROUP BY pull_request_id
 			) reviewer_counts ON pull_requests.pull_request_id = reviewer_counts.pull_request_id
 		`).
+		Scopes(tenant.Scope(ctx, "pull_requests")).
 		Scan(&result).Error
 
 	if err != nil {
 		r.logger.Errorw("GetPullRequestStatistics database error", "error", err)
-		return nil, err
+		return nil, dberror.Wrap(err, "get pull request statistics")
 	}
 
 	stats := &model.PullRequestStatistics{
 		TotalPRs:              int(result.TotalPRs),
 		OpenPRs:               int(result.OpenPRs),
 		MergedPRs:             int(result.MergedPRs),
+		ClosedPRs:             int(result.ClosedPRs),
 		AverageReviewersPerPR: result.AverageReviewersPerPR,
 		PRsWith0Reviewers:     int(result.PRsWith0Reviewers),
 		PRsWith1Reviewer:      int(result.PRsWith1Reviewer),
@@ -117,3 +143,33 @@ func (r *repository) GetPullRequestStatistics(ctx context.Context) (*model.PullR
 	r.logger.Debugw("GetPullRequestStatistics completed", "total_prs", stats.TotalPRs)
 	return stats, nil
 }
+
+// GetMemberAssignments returns the number of reviewer assignments since the given time per user.
+// Assignments are counted from the activity log, so reviewers replaced later are still counted.
+func (r *repository) GetMemberAssignments(ctx context.Context, since time.Time) ([]model.MemberAssignments, error) {
+	r.logger.Debugw("GetMemberAssignments called", "since", since)
+
+	var members []model.MemberAssignments
+
+	err := r.db.WithContext(ctx).
+		Table("users").
+		Select("users.user_id, users.team_name, COUNT(pull_request_events.id) AS assignments").
+		Joins(
+			"LEFT JOIN pull_request_events ON pull_request_events.user_id = users.user_id "+
+				"AND pull_request_events.event_type IN ? AND pull_request_events.created_at >= ?",
+			assignmentEvents, since,
+		).
+		Scopes(tenant.Scope(ctx, "users")).
+		Group("users.user_id, users.team_name, users.is_active").
+		Having("users.is_active OR COUNT(pull_request_events.id) > 0").
+		Order("users.team_name ASC, users.user_id ASC").
+		Scan(&members).Error
+
+	if err != nil {
+		r.logger.Errorw("GetMemberAssignments database error", "error", err)
+		return nil, dberror.Wrap(err, "get member assignments")
+	}
+
+	r.logger.Debugw("GetMemberAssignments completed", "count", len(members))
+	return members, nil
+}
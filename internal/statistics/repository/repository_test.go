@@ -5,6 +5,7 @@ package repository
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -44,7 +45,9 @@ func setupTestDB(t *testing.T) *gorm.DB {
 			pull_request_id VARCHAR(255) PRIMARY KEY,
 			pull_request_name VARCHAR(255) NOT NULL,
 			author_id VARCHAR(255) NOT NULL,
-			status VARCHAR(50) NOT NULL DEFAULT 'OPEN'
+			status VARCHAR(50) NOT NULL DEFAULT 'OPEN',
+			lines_added INTEGER NOT NULL DEFAULT 0,
+			lines_removed INTEGER NOT NULL DEFAULT 0
 		)
 	`).Error
 	require.NoError(t, err)
@@ -58,6 +61,17 @@ func setupTestDB(t *testing.T) *gorm.DB {
 	`).Error
 	require.NoError(t, err)
 
+	err = db.Exec(`
+		CREATE TABLE pull_request_events (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			pull_request_id VARCHAR(255) NOT NULL,
+			event_type VARCHAR(32) NOT NULL,
+			user_id VARCHAR(255),
+			created_at DATETIME NOT NULL
+		)
+	`).Error
+	require.NoError(t, err)
+
 	return db
 }
 
@@ -109,6 +123,60 @@ func TestGetReviewersStatistics(t *testing.T) {
 		}
 		require.NotNil(t, u2Stat)
 		assert.Equal(t, 1, u2Stat.AssignmentCount)
+		assert.Equal(t, 1, u2Stat.ReviewWeight)
+	})
+
+	t.Run("review weight accounts for PR size", func(t *testing.T) {
+		err := db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
+			"u3", "Charlie", "backend", true).Error
+		require.NoError(t, err)
+
+		err = db.Exec(
+			"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, lines_added, lines_removed) VALUES (?, ?, ?, ?, ?, ?)",
+			"pr-big", "Big PR", "u1", "MERGED", 450, 100).Error
+		require.NoError(t, err)
+
+		err = db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)",
+			"pr-big", "u3").Error
+		require.NoError(t, err)
+
+		stats, err := repo.GetReviewersStatistics(ctx)
+		require.NoError(t, err)
+
+		for _, stat := range stats {
+			if stat.UserID == "u3" {
+				assert.Equal(t, 1, stat.AssignmentCount)
+				assert.Equal(t, 6, stat.ReviewWeight)
+				return
+			}
+		}
+		t.Fatal("u3 not found in statistics")
+	})
+
+	t.Run("review weight of PRs with line counts near the column limit", func(t *testing.T) {
+		err := db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
+			"u4", "Dave", "backend", true).Error
+		require.NoError(t, err)
+
+		err = db.Exec(
+			"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, lines_added, lines_removed) VALUES (?, ?, ?, ?, ?, ?)",
+			"pr-huge", "Huge PR", "u1", "OPEN", 2147483647, 2147483647).Error
+		require.NoError(t, err)
+
+		err = db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)",
+			"pr-huge", "u4").Error
+		require.NoError(t, err)
+
+		stats, err := repo.GetReviewersStatistics(ctx)
+		require.NoError(t, err)
+
+		for _, stat := range stats {
+			if stat.UserID == "u4" {
+				assert.Equal(t, 42949673, stat.ReviewWeight)
+				return
+			}
+		}
+		t.Fatal("u4 not found in statistics")
 	})
 }
 
@@ -137,6 +205,10 @@ func TestGetPullRequestStatistics(t *testing.T) {
 			"pr2", "PR2", "u1", "MERGED").Error
 		require.NoError(t, err)
 
+		err = db.Exec("INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES (?, ?, ?, ?)",
+			"pr3", "PR3", "u1", "CLOSED").Error
+		require.NoError(t, err)
+
 		err = db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)",
 			"pr1", "u2").Error
 		require.NoError(t, err)
@@ -148,10 +220,60 @@ func TestGetPullRequestStatistics(t *testing.T) {
 		stats, err := repo.GetPullRequestStatistics(ctx)
 		require.NoError(t, err)
 		assert.NotNil(t, stats)
-		assert.Equal(t, 2, stats.TotalPRs)
+		assert.Equal(t, 3, stats.TotalPRs)
 		assert.Equal(t, 1, stats.OpenPRs)
 		assert.Equal(t, 1, stats.MergedPRs)
+		assert.Equal(t, 1, stats.ClosedPRs)
 		assert.Equal(t, 1, stats.PRsWith2Reviewers)
-		assert.Equal(t, 1, stats.PRsWith0Reviewers)
+		assert.Equal(t, 2, stats.PRsWith0Reviewers)
 	})
 }
+
+func TestGetMemberAssignments(t *testing.T) {
+	db := setupTestDB(t)
+	repo := New(db, zap.NewNop().Sugar())
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	users := []struct {
+		id, team string
+		active   bool
+	}{
+		{"u1", "backend", true},
+		{"u2", "backend", true},
+		{"u3", "backend", false},
+		{"u4", "backend", false},
+		{"u5", "frontend", true},
+	}
+	for _, u := range users {
+		require.NoError(t, db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
+			u.id, u.id, u.team, u.active).Error)
+	}
+
+	events := []struct {
+		eventType, userID string
+		at                time.Time
+	}{
+		{"REVIEWER_ASSIGNED", "u1", now.Add(-time.Hour)},
+		{"REVIEWER_ASSIGNED_MANUALLY", "u1", now.Add(-2 * time.Hour)},
+		{"REVIEWER_REPLACED", "u3", now.Add(-3 * time.Hour)},
+		{"REVIEWER_ASSIGNED", "u2", now.Add(-48 * time.Hour)},
+		{"CREATED", "u2", now.Add(-time.Hour)},
+	}
+	for _, e := range events {
+		require.NoError(t, db.Exec(
+			"INSERT INTO pull_request_events (pull_request_id, event_type, user_id, created_at) VALUES (?, ?, ?, ?)",
+			"pr1", e.eventType, e.userID, e.at).Error)
+	}
+
+	members, err := repo.GetMemberAssignments(ctx, now.Add(-24*time.Hour))
+	require.NoError(t, err)
+
+	// u2 was assigned before the window, u4 is inactive and was not assigned
+	assert.Equal(t, []model.MemberAssignments{
+		{UserID: "u1", TeamName: "backend", Assignments: 2},
+		{UserID: "u2", TeamName: "backend", Assignments: 0},
+		{UserID: "u3", TeamName: "backend", Assignments: 1},
+		{UserID: "u5", TeamName: "frontend", Assignments: 0},
+	}, members)
+}
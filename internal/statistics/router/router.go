@@ -12,11 +12,12 @@ import (
 )
 
 // RegisterRoutes registers statistics module routes.
-func RegisterRoutes(r *gin.Engine, db *gorm.DB, logger *zap.SugaredLogger) {
+func RegisterRoutes(r gin.IRouter, db *gorm.DB, logger *zap.SugaredLogger) {
 	repo := repository.New(db, logger)
 	svc := service.New(repo, logger)
-	h := handler.New(svc, logger)
+	h := handler.New(svc)
 
 	r.GET("/statistics/reviewers", h.GetReviewersStatistics)
 	r.GET("/statistics/pullrequests", h.GetPullRequestStatistics)
+	r.GET("/statistics/fairness", h.GetFairness)
 }
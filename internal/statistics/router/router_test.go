@@ -35,7 +35,9 @@ func setupTestDB(t *testing.T) *gorm.DB {
 			pull_request_id VARCHAR(255) PRIMARY KEY,
 			pull_request_name VARCHAR(255) NOT NULL,
 			author_id VARCHAR(255) NOT NULL,
-			status VARCHAR(50) NOT NULL DEFAULT 'OPEN'
+			status VARCHAR(50) NOT NULL DEFAULT 'OPEN',
+			lines_added INTEGER NOT NULL DEFAULT 0,
+			lines_removed INTEGER NOT NULL DEFAULT 0
 		)
 	`).Error
 	require.NoError(t, err)
@@ -49,6 +51,17 @@ func setupTestDB(t *testing.T) *gorm.DB {
 	`).Error
 	require.NoError(t, err)
 
+	err = db.Exec(`
+		CREATE TABLE pull_request_events (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			pull_request_id VARCHAR(255) NOT NULL,
+			event_type VARCHAR(32) NOT NULL,
+			user_id VARCHAR(255),
+			created_at DATETIME NOT NULL
+		)
+	`).Error
+	require.NoError(t, err)
+
 	return db
 }
 
@@ -87,6 +100,22 @@ func TestRegisterRoutes(t *testing.T) {
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
 
+	t.Run("registers fairness statistics route", func(t *testing.T) {
+		db := setupTestDB(t)
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		logger := zap.NewNop().Sugar()
+
+		RegisterRoutes(router, db, logger)
+
+		req := httptest.NewRequest(http.MethodGet, "/statistics/fairness", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
 	t.Run("routes are accessible after registration", func(t *testing.T) {
 		db := setupTestDB(t)
 		gin.SetMode(gin.TestMode)
@@ -3,11 +3,15 @@ package service
 
 import (
 	"context"
+	"math"
+	"slices"
+	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/festy23/avito_internship/internal/statistics/model"
 	"github.com/festy23/avito_internship/internal/statistics/repository"
+	"github.com/festy23/avito_internship/pkg/clock"
 )
 
 // Service defines the interface for statistics business logic operations.
@@ -17,17 +21,28 @@ type Service interface {
 
 	// GetPullRequestStatistics returns statistics for pull requests.
 	GetPullRequestStatistics(ctx context.Context) (*model.PullRequestStatisticsResponse, error)
+
+	// GetFairness returns how evenly reviews were distributed within each team over the last days;
+	// 0 days means model.DefaultFairnessWindowDays.
+	GetFairness(ctx context.Context, days int) (*model.FairnessResponse, error)
 }
 
 type service struct {
 	repo   repository.Repository
+	clock  clock.Clock
 	logger *zap.SugaredLogger
 }
 
 // New creates a new statistics service instance.
 func New(repo repository.Repository, logger *zap.SugaredLogger) Service {
+	return NewWithClock(repo, clock.New(), logger)
+}
+
+// NewWithClock creates a new statistics service instance that takes the current time from clk.
+func NewWithClock(repo repository.Repository, clk clock.Clock, logger *zap.SugaredLogger) Service {
 	return &service{
 		repo:   repo,
+		clock:  clk,
 		logger: logger,
 	}
 }
@@ -73,3 +88,72 @@ func (s *service) GetPullRequestStatistics(ctx context.Context) (*model.PullRequ
 		Statistics: *stats,
 	}, nil
 }
+
+// GetFairness returns the distribution of reviewer assignments within each team over the last days.
+// A team includes its active members and members assigned in the window, so inactive users do not
+// count as members that got no reviews.
+func (s *service) GetFairness(ctx context.Context, days int) (*model.FairnessResponse, error) {
+	s.logger.Debugw("GetFairness called", "days", days)
+
+	if days == 0 {
+		days = model.DefaultFairnessWindowDays
+	}
+	if days < 1 || days > model.MaxFairnessWindowDays {
+		return nil, model.ErrInvalidFairnessWindow
+	}
+
+	since := s.clock.Now().UTC().Add(-time.Duration(days) * 24 * time.Hour)
+	members, err := s.repo.GetMemberAssignments(ctx, since)
+	if err != nil {
+		s.logger.Errorw("GetFairness failed", "error", err)
+		return nil, err
+	}
+
+	// Members are ordered by team, so each team is a contiguous run
+	teams := []model.TeamFairness{}
+	for start := 0; start < len(members); {
+		end := start
+		counts := []int{}
+		for end < len(members) && members[end].TeamName == members[start].TeamName {
+			counts = append(counts, members[end].Assignments)
+			end++
+		}
+		teams = append(teams, teamFairness(members[start].TeamName, counts))
+		start = end
+	}
+
+	s.logger.Infow("GetFairness completed", "days", days, "teams", len(teams))
+	return &model.FairnessResponse{WindowDays: days, Since: since, Teams: teams}, nil
+}
+
+// teamFairness summarizes the assignment counts of the members of a team.
+func teamFairness(teamName string, counts []int) model.TeamFairness {
+	sorted := slices.Sorted(slices.Values(counts))
+	total := 0
+	for _, count := range sorted {
+		total += count
+	}
+	return model.TeamFairness{
+		TeamName:       teamName,
+		Members:        len(sorted),
+		Assignments:    total,
+		MinAssignments: sorted[0],
+		MaxAssignments: sorted[len(sorted)-1],
+		Gini:           gini(sorted, total),
+	}
+}
+
+// gini returns the Gini coefficient of counts sorted in ascending order, rounded to 4 decimal places.
+// A team with fewer than 2 members or without assignments is perfectly fair.
+func gini(sorted []int, total int) float64 {
+	n := len(sorted)
+	if n < 2 || total == 0 {
+		return 0
+	}
+	weighted := 0
+	for i, count := range sorted {
+		weighted += (i + 1) * count
+	}
+	g := 2*float64(weighted)/(float64(n)*float64(total)) - float64(n+1)/float64(n)
+	return math.Round(g*10000) / 10000
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -11,6 +12,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/festy23/avito_internship/internal/statistics/model"
+	"github.com/festy23/avito_internship/pkg/clock"
 )
 
 // mockRepository is a mock implementation of repository.Repository for unit tests.
@@ -34,6 +36,14 @@ func (m *mockRepository) GetPullRequestStatistics(ctx context.Context) (*model.P
 	return args.Get(0).(*model.PullRequestStatistics), args.Error(1)
 }
 
+func (m *mockRepository) GetMemberAssignments(ctx context.Context, since time.Time) ([]model.MemberAssignments, error) {
+	args := m.Called(ctx, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.MemberAssignments), args.Error(1)
+}
+
 func TestService_GetReviewersStatistics(t *testing.T) {
 	ctx := context.Background()
 
@@ -191,3 +201,94 @@ func TestService_GetPullRequestStatistics(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+func TestService_GetFairness(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("groups members by team", func(t *testing.T) {
+		mockRepo := new(mockRepository)
+		svc := NewWithClock(mockRepo, clock.NewFake(now), zap.NewNop().Sugar())
+
+		since := now.Add(-30 * 24 * time.Hour)
+		mockRepo.On("GetMemberAssignments", ctx, since).Return([]model.MemberAssignments{
+			{UserID: "u1", TeamName: "backend", Assignments: 3},
+			{UserID: "u2", TeamName: "backend", Assignments: 3},
+			{UserID: "u3", TeamName: "backend", Assignments: 3},
+			{UserID: "u4", TeamName: "frontend", Assignments: 0},
+			{UserID: "u5", TeamName: "frontend", Assignments: 0},
+			{UserID: "u6", TeamName: "frontend", Assignments: 4},
+		}, nil)
+
+		resp, err := svc.GetFairness(ctx, 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, model.DefaultFairnessWindowDays, resp.WindowDays)
+		assert.Equal(t, since, resp.Since)
+		assert.Equal(t, []model.TeamFairness{
+			{TeamName: "backend", Members: 3, Assignments: 9, MinAssignments: 3, MaxAssignments: 3, Gini: 0},
+			{TeamName: "frontend", Members: 3, Assignments: 4, MinAssignments: 0, MaxAssignments: 4, Gini: 0.6667},
+		}, resp.Teams)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("no members", func(t *testing.T) {
+		mockRepo := new(mockRepository)
+		svc := NewWithClock(mockRepo, clock.NewFake(now), zap.NewNop().Sugar())
+		mockRepo.On("GetMemberAssignments", ctx, now.Add(-7*24*time.Hour)).Return([]model.MemberAssignments{}, nil)
+
+		resp, err := svc.GetFairness(ctx, 7)
+
+		require.NoError(t, err)
+		assert.Equal(t, 7, resp.WindowDays)
+		assert.Empty(t, resp.Teams)
+		assert.NotNil(t, resp.Teams)
+	})
+
+	t.Run("invalid window", func(t *testing.T) {
+		mockRepo := new(mockRepository)
+		svc := New(mockRepo, zap.NewNop().Sugar())
+
+		for _, days := range []int{-1, model.MaxFairnessWindowDays + 1} {
+			resp, err := svc.GetFairness(ctx, days)
+			assert.Nil(t, resp)
+			assert.ErrorIs(t, err, model.ErrInvalidFairnessWindow)
+		}
+		mockRepo.AssertNotCalled(t, "GetMemberAssignments", mock.Anything, mock.Anything)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockRepo := new(mockRepository)
+		svc := NewWithClock(mockRepo, clock.NewFake(now), zap.NewNop().Sugar())
+		repoErr := errors.New("database error")
+		mockRepo.On("GetMemberAssignments", ctx, mock.Anything).Return(nil, repoErr)
+
+		resp, err := svc.GetFairness(ctx, 1)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, repoErr)
+	})
+}
+
+func TestGini(t *testing.T) {
+	tests := []struct {
+		name   string
+		counts []int
+		want   float64
+	}{
+		{name: "single member", counts: []int{5}, want: 0},
+		{name: "no assignments", counts: []int{0, 0, 0}, want: 0},
+		{name: "equal", counts: []int{2, 2, 2, 2}, want: 0},
+		{name: "one member takes all", counts: []int{0, 0, 0, 8}, want: 0.75},
+		{name: "uneven", counts: []int{1, 2, 3, 4}, want: 0.25},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			total := 0
+			for _, count := range tt.counts {
+				total += count
+			}
+			assert.Equal(t, tt.want, gini(tt.counts, total))
+		})
+	}
+}
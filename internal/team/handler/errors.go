@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/festy23/avito_internship/internal/database/dberror"
+	teamModel "github.com/festy23/avito_internship/internal/team/model"
+	"github.com/festy23/avito_internship/internal/tenant"
+	"github.com/festy23/avito_internship/pkg/apierror"
+	"github.com/festy23/avito_internship/pkg/sortparam"
+)
+
+// errorRegistry maps errors shared by all team endpoints.
+var errorRegistry = apierror.Registry{}.
+	Register(teamModel.ErrTeamNotFound, apierror.NotFound("team not found")).
+	Register(teamModel.ErrInvalidTeamName, apierror.InvalidField("team_name", "required", "team_name is required")).
+	Register(teamModel.ErrLeadNotMember, apierror.InvalidRequest("")).
+	Register(teamModel.ErrInvalidSLA, apierror.InvalidRequest("")).
+	Register(sortparam.ErrInvalidField, apierror.InvalidField("sort", "enum", "")).
+	Register(sortparam.ErrInvalidOrder, apierror.InvalidField("order", "enum", "")).
+	RegisterFunc(dberror.IsTransient, apierror.ConcurrentUpdate())
+
+var addTeamErrors = errorRegistry.
+	// TEAM_EXISTS is documented as 400 in the OpenAPI spec.
+	Register(teamModel.ErrTeamExists,
+		apierror.New(apierror.CodeTeamExists, http.StatusBadRequest, "team_name already exists")).
+	Register(teamModel.ErrEmptyMembers, apierror.InvalidField("members", "min", "members list cannot be empty")).
+	Register(tenant.ErrIDUnavailable, apierror.Conflict(apierror.CodeIDUnavailable, ""))
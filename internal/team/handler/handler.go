@@ -2,25 +2,24 @@
 package handler
 
 import (
-	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"go.uber.org/zap"
 
 	teamModel "github.com/festy23/avito_internship/internal/team/model"
 	"github.com/festy23/avito_internship/internal/team/service"
+	"github.com/festy23/avito_internship/pkg/apierror"
+	"github.com/festy23/avito_internship/pkg/bind"
 )
 
 // Handler handles HTTP requests for team endpoints.
 type Handler struct {
 	service service.Service
-	logger  *zap.SugaredLogger
 }
 
 // New creates a new team handler instance.
-func New(svc service.Service, logger *zap.SugaredLogger) *Handler {
-	return &Handler{service: svc, logger: logger}
+func New(svc service.Service) *Handler {
+	return &Handler{service: svc}
 }
 
 // AddTeam handles POST /team/add request.
@@ -35,27 +34,13 @@ func New(svc service.Service, logger *zap.SugaredLogger) *Handler {
 // @Router /team/add [post] //nolint:godot // Swagger annotation should not end with period
 func (h *Handler) AddTeam(c *gin.Context) {
 	var req teamModel.AddTeamRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errorResponse(c, "INVALID_REQUEST", "invalid request body", http.StatusBadRequest)
+	if !bind.JSON(c, &req) {
 		return
 	}
 
 	resp, err := h.service.AddTeam(c.Request.Context(), &req)
 	if err != nil {
-		if errors.Is(err, teamModel.ErrTeamExists) {
-			errorResponse(c, "TEAM_EXISTS", "team_name already exists", http.StatusBadRequest)
-			return
-		}
-		if errors.Is(err, teamModel.ErrInvalidTeamName) {
-			errorResponse(c, "INVALID_REQUEST", "team_name is required", http.StatusBadRequest)
-			return
-		}
-		if errors.Is(err, teamModel.ErrEmptyMembers) {
-			errorResponse(c, "INVALID_REQUEST", "members list cannot be empty", http.StatusBadRequest)
-			return
-		}
-		h.logger.Errorw("error adding team", "error", err)
-		errorResponse(c, "INTERNAL_ERROR", "internal server error", http.StatusInternalServerError)
+		addTeamErrors.Fail(c, err)
 		return
 	}
 
@@ -69,6 +54,8 @@ func (h *Handler) AddTeam(c *gin.Context) {
 // @Tags Teams
 // @Produce json
 // @Param team_name query string true "Team Name"
+// @Param sort query string false "Member sort field, user_id by default" Enums(user_id, username)
+// @Param order query string false "Sort order, asc by default" Enums(asc, desc)
 // @Success 200 {object} teamModel.TeamResponse "Team response"
 // @Failure 400 {object} ErrorResponse "Bad request (missing team_name parameter)"
 // @Failure 404 {object} ErrorResponse "Team not found"
@@ -77,20 +64,107 @@ func (h *Handler) AddTeam(c *gin.Context) {
 func (h *Handler) GetTeam(c *gin.Context) {
 	teamName := c.Query("team_name")
 	if teamName == "" {
-		errorResponse(c, "INVALID_REQUEST", "team_name parameter is required", http.StatusBadRequest)
+		apierror.Fail(c, apierror.InvalidField("team_name", "required", "team_name parameter is required"))
 		return
 	}
 
-	resp, err := h.service.GetTeam(c.Request.Context(), teamName)
+	srt, err := teamModel.MemberSort.Parse(c.Query("sort"), c.Query("order"))
 	if err != nil {
-		if errors.Is(err, teamModel.ErrTeamNotFound) {
-			notFoundResponse(c, "team not found")
-			return
-		}
-		h.logger.Errorw("error getting team", "team_name", teamName, "error", err)
-		errorResponse(c, "INTERNAL_ERROR", "internal server error", http.StatusInternalServerError)
+		errorRegistry.Fail(c, err)
+		return
+	}
+
+	resp, err := h.service.GetTeam(c.Request.Context(), teamName, srt)
+	if err != nil {
+		errorRegistry.Fail(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, resp)
 }
+
+// SetLead handles POST /team/setLead request.
+// @Summary Designate a team member as the team lead
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Param request body teamModel.SetLeadRequest true "Request"
+// @Success 200 {object} map[string]teamModel.TeamResponse "Response wrapped in team object"
+// @Failure 400 {object} ErrorResponse "Bad request (INVALID_REQUEST)"
+// @Failure 404 {object} ErrorResponse "Team not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /team/setLead [post] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) SetLead(c *gin.Context) {
+	var req teamModel.SetLeadRequest
+	if !bind.JSON(c, &req) {
+		return
+	}
+
+	resp, err := h.service.SetLead(c.Request.Context(), &req)
+	if err != nil {
+		errorRegistry.Fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"team": resp,
+	})
+}
+
+// SetSLA handles POST /team/setSLA request.
+// @Summary Set the review SLA of a team
+// @Description Hours an open pull request may wait for a reviewer and stay unmerged; 0 disables the check.
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Param request body teamModel.SetSLARequest true "Request"
+// @Success 200 {object} map[string]teamModel.TeamResponse "Response wrapped in team object"
+// @Failure 400 {object} ErrorResponse "Bad request (INVALID_REQUEST)"
+// @Failure 404 {object} ErrorResponse "Team not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /team/setSLA [post] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) SetSLA(c *gin.Context) {
+	var req teamModel.SetSLARequest
+	if !bind.JSON(c, &req) {
+		return
+	}
+
+	resp, err := h.service.SetSLA(c.Request.Context(), &req)
+	if err != nil {
+		errorRegistry.Fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"team": resp,
+	})
+}
+
+// SetAutoClose handles POST /team/setAutoClose request.
+// @Summary Opt a team in or out of closing inactive pull requests
+// @Description With enabled=false the stale job does not close open pull requests of the team.
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Param request body teamModel.SetAutoCloseRequest true "Request"
+// @Success 200 {object} map[string]teamModel.TeamResponse "Response wrapped in team object"
+// @Failure 400 {object} ErrorResponse "Bad request (INVALID_REQUEST)"
+// @Failure 404 {object} ErrorResponse "Team not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /team/setAutoClose [post] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) SetAutoClose(c *gin.Context) {
+	var req teamModel.SetAutoCloseRequest
+	if !bind.JSON(c, &req) {
+		return
+	}
+
+	resp, err := h.service.SetAutoClose(c.Request.Context(), &req)
+	if err != nil {
+		errorRegistry.Fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"team": resp,
+	})
+}
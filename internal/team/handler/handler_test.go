@@ -5,18 +5,20 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
-	"go.uber.org/zap"
 
 	teamModel "github.com/festy23/avito_internship/internal/team/model"
 	"github.com/festy23/avito_internship/internal/team/service"
+	"github.com/festy23/avito_internship/pkg/sortparam"
 )
 
 type mockService struct {
@@ -31,8 +33,39 @@ func (m *mockService) AddTeam(ctx context.Context, req *teamModel.AddTeamRequest
 	return args.Get(0).(*teamModel.TeamResponse), args.Error(1)
 }
 
-func (m *mockService) GetTeam(ctx context.Context, teamName string) (*teamModel.TeamResponse, error) {
-	args := m.Called(ctx, teamName)
+func (m *mockService) GetTeam(
+	ctx context.Context,
+	teamName string,
+	srt sortparam.Sort,
+) (*teamModel.TeamResponse, error) {
+	args := m.Called(ctx, teamName, srt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*teamModel.TeamResponse), args.Error(1)
+}
+
+func (m *mockService) SetLead(ctx context.Context, req *teamModel.SetLeadRequest) (*teamModel.TeamResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*teamModel.TeamResponse), args.Error(1)
+}
+
+func (m *mockService) SetSLA(ctx context.Context, req *teamModel.SetSLARequest) (*teamModel.TeamResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*teamModel.TeamResponse), args.Error(1)
+}
+
+func (m *mockService) SetAutoClose(
+	ctx context.Context,
+	req *teamModel.SetAutoCloseRequest,
+) (*teamModel.TeamResponse, error) {
+	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -49,7 +82,7 @@ func setupRouter() *gin.Engine {
 func TestHandler_AddTeam(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/team/add", handler.AddTeam)
 
@@ -87,7 +120,7 @@ func TestHandler_AddTeam(t *testing.T) {
 
 	t.Run("duplicate team", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/team/add", handler.AddTeam)
 
@@ -117,7 +150,7 @@ func TestHandler_AddTeam(t *testing.T) {
 
 	t.Run("invalid request body", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/team/add", handler.AddTeam)
 
@@ -135,7 +168,7 @@ func TestHandler_AddTeam(t *testing.T) {
 
 	t.Run("empty team name", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/team/add", handler.AddTeam)
 
@@ -163,7 +196,7 @@ func TestHandler_AddTeam(t *testing.T) {
 
 	t.Run("empty members", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/team/add", handler.AddTeam)
 
@@ -190,7 +223,7 @@ func TestHandler_AddTeam(t *testing.T) {
 
 	t.Run("internal error", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/team/add", handler.AddTeam)
 
@@ -219,9 +252,43 @@ func TestHandler_AddTeam(t *testing.T) {
 }
 
 func TestHandler_GetTeam(t *testing.T) {
+	t.Run("sort options", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.GET("/team/get", handler.GetTeam)
+
+		resp := &teamModel.TeamResponse{TeamName: "backend", Members: []teamModel.TeamMember{}}
+		srt := sortparam.Sort{Field: "username", Order: sortparam.Desc}
+		mockSvc.On("GetTeam", mock.Anything, "backend", srt).Return(resp, nil)
+
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("GET", "/team/get?team_name=backend&sort=username&order=desc", nil)
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("unsupported sort options", func(t *testing.T) {
+		for _, query := range []string{"sort=is_active", "sort=username&order=up"} {
+			mockSvc := new(mockService)
+			handler := New(mockSvc)
+			router := setupRouter()
+			router.GET("/team/get", handler.GetTeam)
+
+			w := httptest.NewRecorder()
+			httpReq, _ := http.NewRequest("GET", "/team/get?team_name=backend&"+query, nil)
+			router.ServeHTTP(w, httpReq)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+			mockSvc.AssertNotCalled(t, "GetTeam")
+		}
+	})
+
 	t.Run("success", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.GET("/team/get", handler.GetTeam)
 
@@ -233,7 +300,7 @@ func TestHandler_GetTeam(t *testing.T) {
 			},
 		}
 
-		mockSvc.On("GetTeam", mock.Anything, "backend").Return(resp, nil)
+		mockSvc.On("GetTeam", mock.Anything, "backend", sortparam.Sort{}).Return(resp, nil)
 
 		w := httptest.NewRecorder()
 		httpReq, _ := http.NewRequest("GET", "/team/get?team_name=backend", nil)
@@ -250,11 +317,11 @@ func TestHandler_GetTeam(t *testing.T) {
 
 	t.Run("team not found", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.GET("/team/get", handler.GetTeam)
 
-		mockSvc.On("GetTeam", mock.Anything, "nonexistent").Return(nil, teamModel.ErrTeamNotFound)
+		mockSvc.On("GetTeam", mock.Anything, "nonexistent", sortparam.Sort{}).Return(nil, teamModel.ErrTeamNotFound)
 
 		w := httptest.NewRecorder()
 		httpReq, _ := http.NewRequest("GET", "/team/get?team_name=nonexistent", nil)
@@ -271,7 +338,7 @@ func TestHandler_GetTeam(t *testing.T) {
 
 	t.Run("missing team_name parameter", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.GET("/team/get", handler.GetTeam)
 
@@ -288,11 +355,11 @@ func TestHandler_GetTeam(t *testing.T) {
 
 	t.Run("internal error", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.GET("/team/get", handler.GetTeam)
 
-		mockSvc.On("GetTeam", mock.Anything, "backend").Return(nil, errors.New("database error"))
+		mockSvc.On("GetTeam", mock.Anything, "backend", sortparam.Sort{}).Return(nil, errors.New("database error"))
 
 		w := httptest.NewRecorder()
 		httpReq, _ := http.NewRequest("GET", "/team/get?team_name=backend", nil)
@@ -306,9 +373,33 @@ func TestHandler_GetTeam(t *testing.T) {
 		mockSvc.AssertExpectations(t)
 	})
 
+	t.Run("transient database error is retryable", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.GET("/team/get", handler.GetTeam)
+
+		deadlock := &pgconn.PgError{Code: "40P01", Message: "deadlock detected"}
+		mockSvc.On("GetTeam", mock.Anything, "backend", sortparam.Sort{}).
+			Return(nil, fmt.Errorf("get team: %w", deadlock))
+
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("GET", "/team/get?team_name=backend", nil)
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, "1", w.Header().Get("Retry-After"))
+		var response ErrorResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, "CONCURRENT_UPDATE", response.Error.Code)
+		assert.True(t, response.Error.Retryable)
+		mockSvc.AssertExpectations(t)
+	})
+
 	t.Run("empty team (no members)", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.GET("/team/get", handler.GetTeam)
 
@@ -317,7 +408,7 @@ func TestHandler_GetTeam(t *testing.T) {
 			Members:  []teamModel.TeamMember{},
 		}
 
-		mockSvc.On("GetTeam", mock.Anything, "backend").Return(resp, nil)
+		mockSvc.On("GetTeam", mock.Anything, "backend", sortparam.Sort{}).Return(resp, nil)
 
 		w := httptest.NewRecorder()
 		httpReq, _ := http.NewRequest("GET", "/team/get?team_name=backend", nil)
@@ -332,3 +423,205 @@ func TestHandler_GetTeam(t *testing.T) {
 		mockSvc.AssertExpectations(t)
 	})
 }
+
+func TestHandler_SetLead(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.POST("/team/setLead", handler.SetLead)
+
+		req := &teamModel.SetLeadRequest{TeamName: "backend", UserID: "u1"}
+		resp := &teamModel.TeamResponse{
+			TeamName:   "backend",
+			LeadUserID: "u1",
+			Members: []teamModel.TeamMember{
+				{UserID: "u1", Username: "Alice", IsActive: true},
+			},
+		}
+
+		mockSvc.On("SetLead", mock.Anything, req).Return(resp, nil)
+
+		body, _ := json.Marshal(req)
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/team/setLead", bytes.NewBuffer(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response map[string]teamModel.TeamResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, "u1", response["team"].LeadUserID)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("missing user_id", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.POST("/team/setLead", handler.SetLead)
+
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/team/setLead", bytes.NewBufferString(`{"team_name":"backend"}`))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockSvc.AssertNotCalled(t, "SetLead")
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.POST("/team/setLead", handler.SetLead)
+
+		req := &teamModel.SetLeadRequest{TeamName: "nonexistent", UserID: "u1"}
+		mockSvc.On("SetLead", mock.Anything, req).Return(nil, teamModel.ErrTeamNotFound)
+
+		body, _ := json.Marshal(req)
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/team/setLead", bytes.NewBuffer(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("user is not a team member", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.POST("/team/setLead", handler.SetLead)
+
+		req := &teamModel.SetLeadRequest{TeamName: "backend", UserID: "u9"}
+		mockSvc.On("SetLead", mock.Anything, req).Return(nil, teamModel.ErrLeadNotMember)
+
+		body, _ := json.Marshal(req)
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/team/setLead", bytes.NewBuffer(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var response ErrorResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, "INVALID_REQUEST", response.Error.Code)
+		mockSvc.AssertExpectations(t)
+	})
+}
+
+func TestHandler_SetSLA(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockSvc := new(mockService)
+		router := setupRouter()
+		router.POST("/team/setSLA", New(mockSvc).SetSLA)
+
+		req := &teamModel.SetSLARequest{
+			TeamName: "backend",
+			TeamSLA:  teamModel.TeamSLA{FirstReviewHours: 4, MergeHours: 48},
+		}
+		mockSvc.On("SetSLA", mock.Anything, req).Return(&teamModel.TeamResponse{
+			TeamName: "backend",
+			SLA:      &req.TeamSLA,
+			Members:  []teamModel.TeamMember{},
+		}, nil)
+
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/team/setSLA",
+			bytes.NewBufferString(`{"team_name":"backend","first_review_hours":4,"merge_hours":48}`))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"sla":{"first_review_hours":4,"merge_hours":48}`)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("negative hours", func(t *testing.T) {
+		mockSvc := new(mockService)
+		router := setupRouter()
+		router.POST("/team/setSLA", New(mockSvc).SetSLA)
+
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/team/setSLA",
+			bytes.NewBufferString(`{"team_name":"backend","first_review_hours":-1}`))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockSvc.AssertNotCalled(t, "SetSLA")
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		mockSvc := new(mockService)
+		router := setupRouter()
+		router.POST("/team/setSLA", New(mockSvc).SetSLA)
+		mockSvc.On("SetSLA", mock.Anything, mock.Anything).Return(nil, teamModel.ErrTeamNotFound)
+
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/team/setSLA", bytes.NewBufferString(`{"team_name":"nonexistent"}`))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestHandler_SetAutoClose(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockSvc := new(mockService)
+		router := setupRouter()
+		router.POST("/team/setAutoClose", New(mockSvc).SetAutoClose)
+
+		enabled := false
+		req := &teamModel.SetAutoCloseRequest{TeamName: "backend", Enabled: &enabled}
+		mockSvc.On("SetAutoClose", mock.Anything, req).Return(&teamModel.TeamResponse{
+			TeamName:          "backend",
+			AutoCloseDisabled: true,
+			Members:           []teamModel.TeamMember{},
+		}, nil)
+
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/team/setAutoClose",
+			bytes.NewBufferString(`{"team_name":"backend","enabled":false}`))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"auto_close_disabled":true`)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("missing enabled", func(t *testing.T) {
+		mockSvc := new(mockService)
+		router := setupRouter()
+		router.POST("/team/setAutoClose", New(mockSvc).SetAutoClose)
+
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/team/setAutoClose", bytes.NewBufferString(`{"team_name":"backend"}`))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockSvc.AssertNotCalled(t, "SetAutoClose")
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		mockSvc := new(mockService)
+		router := setupRouter()
+		router.POST("/team/setAutoClose", New(mockSvc).SetAutoClose)
+		mockSvc.On("SetAutoClose", mock.Anything, mock.Anything).Return(nil, teamModel.ErrTeamNotFound)
+
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/team/setAutoClose",
+			bytes.NewBufferString(`{"team_name":"nonexistent","enabled":true}`))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
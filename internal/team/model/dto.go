@@ -1,22 +1,67 @@
 // Package model provides domain models and DTOs for team module.
 package model
 
+import "github.com/festy23/avito_internship/pkg/sortparam"
+
+// MemberSort declares the sort fields of team members: by user_id by default.
+var MemberSort = sortparam.Spec{
+	Columns:      map[string][]string{"user_id": {"user_id"}, "username": {"username"}},
+	DefaultField: "user_id",
+	DefaultOrder: sortparam.Asc,
+	TieBreaker:   "user_id",
+}
+
 // TeamMember represents a team member in API responses.
 // Used in team creation and retrieval.
 type TeamMember struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
+	UserID   string `json:"user_id"   binding:"max=255"`
+	Username string `json:"username"  binding:"max=255"`
 	IsActive bool   `json:"is_active"`
 }
 
 // AddTeamRequest represents the request to create a team with members.
+// LeadUserID is optional and must reference one of the members.
 type AddTeamRequest struct {
-	TeamName string       `json:"team_name" binding:"required"`
-	Members  []TeamMember `json:"members"   binding:"required,dive"`
+	TeamName   string       `json:"team_name"              binding:"required,max=255"`
+	Members    []TeamMember `json:"members"                binding:"required,dive"`
+	LeadUserID string       `json:"lead_user_id,omitempty" binding:"max=255"`
 }
 
 // TeamResponse represents the response after creating or getting a team.
 type TeamResponse struct {
-	TeamName string       `json:"team_name"`
-	Members  []TeamMember `json:"members"`
+	TeamName   string   `json:"team_name"`
+	LeadUserID string   `json:"lead_user_id,omitempty"`
+	SLA        *TeamSLA `json:"sla,omitempty"`
+	// AutoCloseDisabled is set when the team has opted out of closing inactive pull requests.
+	AutoCloseDisabled bool         `json:"auto_close_disabled,omitempty"`
+	Members           []TeamMember `json:"members"`
+}
+
+// MaxSLAHours is the longest SLA that can be set for a team, one year.
+const MaxSLAHours = 8760
+
+// TeamSLA represents the review SLA of a team in hours; 0 means the check is disabled.
+// A pull request waits for its first review while no reviewer is assigned to it.
+type TeamSLA struct {
+	FirstReviewHours int `json:"first_review_hours" binding:"min=0,max=8760"`
+	MergeHours       int `json:"merge_hours"        binding:"min=0,max=8760"`
+}
+
+// SetSLARequest represents the request to set the review SLA of a team.
+type SetSLARequest struct {
+	TeamName string `json:"team_name" binding:"required,max=255"`
+	TeamSLA
+}
+
+// SetAutoCloseRequest represents the request to opt a team in or out of closing its inactive
+// pull requests by the stale job.
+type SetAutoCloseRequest struct {
+	TeamName string `json:"team_name" binding:"required,max=255"`
+	Enabled  *bool  `json:"enabled"   binding:"required"`
+}
+
+// SetLeadRequest represents the request to designate a team lead.
+type SetLeadRequest struct {
+	TeamName string `json:"team_name" binding:"required,max=255"`
+	UserID   string `json:"user_id"   binding:"required,max=255"`
 }
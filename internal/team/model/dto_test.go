@@ -9,6 +9,9 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/festy23/avito_internship/pkg/apierror"
+	"github.com/festy23/avito_internship/pkg/bind"
 )
 
 func TestTeamMember_JSONSerialization(t *testing.T) {
@@ -151,6 +154,43 @@ func TestAddTeamRequest_Validation(t *testing.T) {
 	})
 }
 
+func TestAddTeamRequest_BindingTags(t *testing.T) {
+	t.Run("valid request", func(t *testing.T) {
+		req := AddTeamRequest{
+			TeamName: "backend",
+			Members:  []TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}},
+		}
+
+		assert.NoError(t, bind.Struct(&req))
+	})
+
+	t.Run("member fields too long", func(t *testing.T) {
+		req := AddTeamRequest{
+			TeamName: "backend",
+			Members: []TeamMember{
+				{UserID: "u1", Username: "Alice"},
+				{UserID: strings.Repeat("u", 256), Username: strings.Repeat("b", 256)},
+			},
+		}
+
+		var apiErr *apierror.Error
+		require.ErrorAs(t, bind.Struct(&req), &apiErr)
+		require.Len(t, apiErr.Details, 2)
+		assert.Equal(t, "members[1].user_id", apiErr.Details[0].Field)
+		assert.Equal(t, "members[1].username", apiErr.Details[1].Field)
+	})
+
+	t.Run("team name too long", func(t *testing.T) {
+		req := AddTeamRequest{TeamName: strings.Repeat("t", 256), Members: []TeamMember{}}
+
+		var apiErr *apierror.Error
+		require.ErrorAs(t, bind.Struct(&req), &apiErr)
+		require.Len(t, apiErr.Details, 1)
+		assert.Equal(t, "team_name", apiErr.Details[0].Field)
+		assert.Equal(t, "max", apiErr.Details[0].Rule)
+	})
+}
+
 func TestTeamResponse_JSONSerialization(t *testing.T) {
 	t.Run("marshal to JSON", func(t *testing.T) {
 		resp := TeamResponse{
@@ -11,4 +11,8 @@ var (
 	ErrInvalidTeamName = errors.New("invalid team name")
 	// ErrEmptyMembers indicates that the members list is empty.
 	ErrEmptyMembers = errors.New("members list cannot be empty")
+	// ErrLeadNotMember indicates that the designated team lead is not a member of the team.
+	ErrLeadNotMember = errors.New("team lead must be a member of the team")
+	// ErrInvalidSLA indicates that SLA hours are out of range.
+	ErrInvalidSLA = errors.New("SLA hours must be between 0 and 8760")
 )
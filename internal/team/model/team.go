@@ -7,11 +7,18 @@ import (
 )
 
 // Team represents a team entity in the system.
-// Matches the teams table schema.
+// Matches the teams table schema. SLAFirstReviewHours and SLAMergeHours are the review SLA
+// of the team; nil disables the corresponding check. AutoCloseDisabled opts the team out of
+// closing its inactive pull requests by the stale job.
 type Team struct {
-	TeamName  string    `gorm:"primaryKey;column:team_name;type:varchar(255)"             json:"team_name"`
-	CreatedAt time.Time `gorm:"column:created_at;type:timestamptz;not null;default:now()" json:"-"`
-	UpdatedAt time.Time `gorm:"column:updated_at;type:timestamptz;not null;default:now()" json:"-"`
+	TeamName            string    `gorm:"primaryKey;column:team_name;type:varchar(255)"                        json:"team_name"`
+	LeadUserID          *string   `gorm:"column:lead_user_id;type:varchar(255);index:idx_teams_lead_user_id" json:"lead_user_id,omitempty"`
+	SLAFirstReviewHours *int      `gorm:"column:sla_first_review_hours;type:integer"                         json:"-"`
+	SLAMergeHours       *int      `gorm:"column:sla_merge_hours;type:integer"                                json:"-"`
+	AutoCloseDisabled   bool      `gorm:"column:auto_close_disabled;not null;default:false"                    json:"-"`
+	CreatedAt           time.Time `gorm:"column:created_at;type:timestamptz;not null;default:now()"            json:"-"`
+	UpdatedAt           time.Time `gorm:"column:updated_at;type:timestamptz;not null;default:now()"            json:"-"`
+	TenantID            string    `gorm:"column:tenant_id;type:varchar(255);not null;default:'default'"     json:"-"`
 }
 
 // TableName specifies the table name for GORM.
@@ -197,8 +197,13 @@ func setupTestDB(t *testing.T) *gorm.DB {
 	err = db.Exec(`
 		CREATE TABLE teams (
 			team_name VARCHAR(255) PRIMARY KEY,
+			lead_user_id VARCHAR(255),
+			sla_first_review_hours INTEGER,
+			sla_merge_hours INTEGER,
+			auto_close_disabled BOOLEAN NOT NULL DEFAULT FALSE,
 			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
-			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			tenant_id VARCHAR(255) NOT NULL DEFAULT 'default'
 		)
 	`).Error
 	require.NoError(t, err)
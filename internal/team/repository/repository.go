@@ -4,13 +4,17 @@ package repository
 import (
 	"context"
 	"errors"
-	"time"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 
+	"github.com/festy23/avito_internship/internal/database/dberror"
+	"github.com/festy23/avito_internship/internal/database/dialect"
 	teamModel "github.com/festy23/avito_internship/internal/team/model"
+	"github.com/festy23/avito_internship/internal/tenant"
 	userModel "github.com/festy23/avito_internship/internal/user/model"
+	"github.com/festy23/avito_internship/pkg/clock"
+	"github.com/festy23/avito_internship/pkg/sortparam"
 )
 
 // Repository defines the interface for team data access operations.
@@ -28,71 +32,81 @@ type Repository interface {
 		isActive bool,
 	) (*userModel.User, error)
 
-	// GetTeamMembers returns all members of a team.
-	GetTeamMembers(ctx context.Context, teamName string) ([]teamModel.TeamMember, error)
+	// GetTeamMembers returns all members of a team ordered by srt (see teamModel.MemberSort).
+	GetTeamMembers(ctx context.Context, teamName string, srt sortparam.Sort) ([]teamModel.TeamMember, error)
+
+	// SetLead sets (or clears, when leadUserID is nil) the team lead.
+	SetLead(ctx context.Context, teamName string, leadUserID *string) error
+
+	// SetSLA sets the review SLA of a team; nil hours disable the corresponding check.
+	SetSLA(ctx context.Context, teamName string, firstReviewHours, mergeHours *int) error
+
+	// SetAutoCloseDisabled opts a team out of (or back into) closing its inactive pull requests.
+	SetAutoCloseDisabled(ctx context.Context, teamName string, disabled bool) error
+
+	// ReplaceLead makes newUserID the lead of the teams led by oldUserID.
+	ReplaceLead(ctx context.Context, oldUserID, newUserID string) error
 }
 
 type repository struct {
 	db     *gorm.DB
+	clock  clock.Clock
 	logger *zap.SugaredLogger
 }
 
 // New creates a new team repository instance.
 func New(db *gorm.DB, logger *zap.SugaredLogger) Repository {
-	return &repository{db: db, logger: logger}
+	return NewWithClock(db, clock.New(), logger)
+}
+
+// NewWithClock creates a new team repository instance that takes timestamps from clk.
+func NewWithClock(db *gorm.DB, clk clock.Clock, logger *zap.SugaredLogger) Repository {
+	return &repository{db: db, clock: clk, logger: logger}
 }
 
 // Create creates a new team.
 func (r *repository) Create(ctx context.Context, teamName string) (*teamModel.Team, error) {
 	r.logger.Infow("Creating team", "team_name", teamName)
 
-	now := time.Now()
+	now := r.clock.Now()
 	team := &teamModel.Team{
 		TeamName:  teamName,
 		CreatedAt: now,
 		UpdatedAt: now,
+		TenantID:  tenant.ID(ctx),
 	}
 
-	err := r.db.WithContext(ctx).Create(team).Error
+	// A conflicting insert is skipped rather than failed, so the transaction can still tell whose team it is
+	created, err := dialect.CreateOrSkip(r.db.WithContext(ctx), team, "team_name")
 	if err != nil {
-		// Check for unique constraint violation
-		if errors.Is(err, gorm.ErrDuplicatedKey) || isDuplicateError(err) {
-			r.logger.Debugw("Create team duplicate key", "team_name", teamName)
-			return nil, teamModel.ErrTeamExists
-		}
 		r.logger.Errorw("Failed to create team", "team_name", teamName, "error", err)
-		return nil, err
+		return nil, dberror.Wrap(err, "create team", teamName)
+	}
+	if !created {
+		r.logger.Debugw("Create team duplicate key", "team_name", teamName)
+		return nil, r.existingTeamError(ctx, teamName)
 	}
 
 	r.logger.Infow("Team created", "team_name", teamName)
 	return team, nil
 }
 
-// isDuplicateError checks if error is a duplicate key error.
-func isDuplicateError(err error) bool {
-	if err == nil {
-		return false
-	}
-	// PostgreSQL duplicate key error code
-	return errors.Is(err, gorm.ErrDuplicatedKey) ||
-		(err.Error() != "" && (
-		// Check for common duplicate key error messages
-		contains(err.Error(), "duplicate key") ||
-			contains(err.Error(), "UNIQUE constraint")))
-}
-
-func contains(s, substr string) bool {
-	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
-		(len(s) > 0 && len(substr) > 0 && findSubstring(s, substr)))
-}
-
-func findSubstring(s, substr string) bool {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
-		}
+// existingTeamError returns ErrTeamExists if the team exists in the tenant of ctx and
+// tenant.ErrIDUnavailable if the name is taken by another tenant.
+func (r *repository) existingTeamError(ctx context.Context, teamName string) error {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&teamModel.Team{}).
+		Scopes(tenant.Scope(ctx, "teams")).
+		Where("team_name = ?", teamName).
+		Count(&count).Error
+	if err != nil {
+		return dberror.Wrap(err, "check existing team", teamName)
 	}
-	return false
+	if count == 0 {
+		return tenant.ErrIDUnavailable
+	}
+	return teamModel.ErrTeamExists
 }
 
 // GetByName finds team by team_name.
@@ -101,6 +115,7 @@ func (r *repository) GetByName(ctx context.Context, teamName string) (*teamModel
 
 	var team teamModel.Team
 	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "teams")).
 		Where("team_name = ?", teamName).
 		First(&team).Error
 
@@ -110,7 +125,7 @@ func (r *repository) GetByName(ctx context.Context, teamName string) (*teamModel
 			return nil, teamModel.ErrTeamNotFound
 		}
 		r.logger.Errorw("GetByName database error", "team_name", teamName, "error", err)
-		return nil, err
+		return nil, dberror.Wrap(err, "get team", teamName)
 	}
 
 	return &team, nil
@@ -125,7 +140,7 @@ func (r *repository) CreateOrUpdateUser(
 ) (*userModel.User, error) {
 	r.logger.Infow("CreateOrUpdateUser called", "team_name", teamName, "user_id", userID, "is_active", isActive)
 
-	now := time.Now()
+	now := r.clock.Now()
 	user := &userModel.User{
 		UserID:    userID,
 		Username:  username,
@@ -142,33 +157,80 @@ func (r *repository) CreateOrUpdateUser(
 	// 3. Raw SQL allows explicit value that bypasses DEFAULT constraint
 	// 4. This is a known limitation when using GORM with SQLite and DEFAULT values
 	// Note: GORM handles boolean-to-INTEGER conversion automatically for SQLite
-	err := r.db.WithContext(ctx).
-		Exec("INSERT INTO users (user_id, username, team_name, is_active, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?) ON CONFLICT(user_id) DO UPDATE SET username = ?, team_name = ?, is_active = ?, updated_at = ?",
-			userID, username, teamName, isActive, now, now,
-			username, teamName, isActive, now).
-		Error
+	// User IDs are unique across tenants: a user of another tenant is not updated.
+	tenantID := tenant.ID(ctx)
+	owned, err := r.upsertUser(ctx, user, tenantID)
+	if err != nil {
+		r.logger.Errorw("CreateOrUpdateUser database error",
+			"team_name", teamName, "user_id", userID, "error", err)
+		return nil, dberror.Wrap(err, "upsert team member", teamName, userID)
+	}
+	if !owned {
+		r.logger.Debugw("CreateOrUpdateUser user belongs to another tenant", "user_id", userID)
+		return nil, tenant.ErrIDUnavailable
+	}
 
+	// A user moved to another team can no longer lead their previous team
+	err = r.db.WithContext(ctx).
+		Model(&teamModel.Team{}).
+		Scopes(tenant.Scope(ctx, "teams")).
+		Where("lead_user_id = ? AND team_name != ?", userID, teamName).
+		Update("lead_user_id", nil).
+		Error
 	if err != nil {
-		r.logger.Errorw("CreateOrUpdateUser database error", "team_name", teamName, "user_id", userID, "error", err)
-		return nil, err
+		r.logger.Errorw("CreateOrUpdateUser failed to clear stale team lead", "user_id", userID, "error", err)
+		return nil, dberror.Wrap(err, "clear stale team lead", userID)
 	}
 
 	// Fetch the user to return complete data (including created_at if it was a new record)
 	// Use the same db connection (which may be a transaction) to ensure consistency
-	err = r.db.WithContext(ctx).Where("user_id = ?", userID).First(user).Error
+	err = r.db.WithContext(ctx).Scopes(tenant.Scope(ctx, "users")).Where("user_id = ?", userID).First(user).Error
 	if err != nil {
 		r.logger.Errorw("CreateOrUpdateUser failed to fetch user", "user_id", userID, "error", err)
-		return nil, err
+		return nil, dberror.Wrap(err, "get team member", userID)
 	}
 
 	r.logger.Infow("CreateOrUpdateUser completed", "team_name", teamName, "user_id", userID)
 	return user, nil
 }
 
-// GetTeamMembers returns all members of a team.
+// upsertUser inserts user or updates it if it belongs to tenantID; returns false if the user belongs
+// to another tenant and was left unchanged.
+func (r *repository) upsertUser(ctx context.Context, user *userModel.User, tenantID string) (bool, error) {
+	db := r.db.WithContext(ctx)
+	if !dialect.IsMySQL(db) {
+		result := db.Exec("INSERT INTO users (user_id, username, team_name, is_active, created_at, updated_at, tenant_id) "+
+			"VALUES (?, ?, ?, ?, ?, ?, ?) ON CONFLICT(user_id) DO UPDATE "+
+			"SET username = ?, team_name = ?, is_active = ?, updated_at = ? WHERE users.tenant_id = ?",
+			user.UserID, user.Username, user.TeamName, user.IsActive, user.CreatedAt, user.UpdatedAt, tenantID,
+			user.Username, user.TeamName, user.IsActive, user.UpdatedAt, tenantID)
+		return result.RowsAffected > 0, result.Error
+	}
+
+	// MySQL has no conditional upsert and counts a row left unchanged as affected, so the owner is read back
+	err := db.Exec("INSERT INTO users (user_id, username, team_name, is_active, created_at, updated_at, tenant_id) "+
+		"VALUES (?, ?, ?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE "+
+		"username = IF(tenant_id = ?, VALUES(username), username), "+
+		"team_name = IF(tenant_id = ?, VALUES(team_name), team_name), "+
+		"is_active = IF(tenant_id = ?, VALUES(is_active), is_active), "+
+		"updated_at = IF(tenant_id = ?, VALUES(updated_at), updated_at)",
+		user.UserID, user.Username, user.TeamName, user.IsActive, user.CreatedAt, user.UpdatedAt, tenantID,
+		tenantID, tenantID, tenantID, tenantID).Error
+	if err != nil {
+		return false, err
+	}
+	var owner string
+	if err := db.Table("users").Select("tenant_id").Where("user_id = ?", user.UserID).Row().Scan(&owner); err != nil {
+		return false, err
+	}
+	return owner == tenantID, nil
+}
+
+// GetTeamMembers returns all members of a team ordered by srt (see teamModel.MemberSort).
 func (r *repository) GetTeamMembers(
 	ctx context.Context,
 	teamName string,
+	srt sortparam.Sort,
 ) ([]teamModel.TeamMember, error) {
 	r.logger.Debugw("GetTeamMembers called", "team_name", teamName)
 
@@ -176,14 +238,15 @@ func (r *repository) GetTeamMembers(
 
 	err := r.db.WithContext(ctx).
 		Table("users").
+		Scopes(tenant.Scope(ctx, "users")).
 		Select("user_id, username, is_active").
 		Where("team_name = ?", teamName).
-		Order("user_id ASC").
+		Order(teamModel.MemberSort.OrderBy(srt)).
 		Scan(&members).Error
 
 	if err != nil {
 		r.logger.Errorw("GetTeamMembers database error", "team_name", teamName, "error", err)
-		return nil, err
+		return nil, dberror.Wrap(err, "get team members", teamName)
 	}
 
 	if members == nil {
@@ -193,3 +256,95 @@ func (r *repository) GetTeamMembers(
 	r.logger.Debugw("GetTeamMembers completed", "team_name", teamName, "member_count", len(members))
 	return members, nil
 }
+
+// SetLead sets (or clears, when leadUserID is nil) the team lead.
+func (r *repository) SetLead(ctx context.Context, teamName string, leadUserID *string) error {
+	r.logger.Infow("SetLead called", "team_name", teamName, "lead_user_id", leadUserID)
+
+	result := r.db.WithContext(ctx).
+		Model(&teamModel.Team{}).
+		Scopes(tenant.Scope(ctx, "teams")).
+		Where("team_name = ?", teamName).
+		Update("lead_user_id", leadUserID)
+
+	if result.Error != nil {
+		r.logger.Errorw("SetLead database error", "team_name", teamName, "error", result.Error)
+		return dberror.Wrap(result.Error, "set team lead", teamName)
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.Debugw("SetLead team not found", "team_name", teamName)
+		return teamModel.ErrTeamNotFound
+	}
+
+	r.logger.Infow("SetLead completed", "team_name", teamName)
+	return nil
+}
+
+// SetSLA sets the review SLA of a team; nil hours disable the corresponding check.
+func (r *repository) SetSLA(ctx context.Context, teamName string, firstReviewHours, mergeHours *int) error {
+	r.logger.Infow("SetSLA called", "team_name", teamName,
+		"first_review_hours", firstReviewHours, "merge_hours", mergeHours)
+
+	result := r.db.WithContext(ctx).
+		Model(&teamModel.Team{}).
+		Scopes(tenant.Scope(ctx, "teams")).
+		Where("team_name = ?", teamName).
+		Updates(map[string]interface{}{
+			"sla_first_review_hours": firstReviewHours,
+			"sla_merge_hours":        mergeHours,
+		})
+
+	if result.Error != nil {
+		r.logger.Errorw("SetSLA database error", "team_name", teamName, "error", result.Error)
+		return dberror.Wrap(result.Error, "set team SLA", teamName)
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.Debugw("SetSLA team not found", "team_name", teamName)
+		return teamModel.ErrTeamNotFound
+	}
+
+	r.logger.Infow("SetSLA completed", "team_name", teamName)
+	return nil
+}
+
+// SetAutoCloseDisabled opts a team out of (or back into) closing its inactive pull requests.
+func (r *repository) SetAutoCloseDisabled(ctx context.Context, teamName string, disabled bool) error {
+	r.logger.Infow("SetAutoCloseDisabled called", "team_name", teamName, "disabled", disabled)
+
+	result := r.db.WithContext(ctx).
+		Model(&teamModel.Team{}).
+		Scopes(tenant.Scope(ctx, "teams")).
+		Where("team_name = ?", teamName).
+		Update("auto_close_disabled", disabled)
+
+	if result.Error != nil {
+		r.logger.Errorw("SetAutoCloseDisabled database error", "team_name", teamName, "error", result.Error)
+		return dberror.Wrap(result.Error, "set team auto-close", teamName)
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.Debugw("SetAutoCloseDisabled team not found", "team_name", teamName)
+		return teamModel.ErrTeamNotFound
+	}
+
+	r.logger.Infow("SetAutoCloseDisabled completed", "team_name", teamName)
+	return nil
+}
+
+// ReplaceLead makes newUserID the lead of the teams led by oldUserID.
+func (r *repository) ReplaceLead(ctx context.Context, oldUserID, newUserID string) error {
+	r.logger.Infow("ReplaceLead called", "new_user_id", newUserID)
+
+	err := r.db.WithContext(ctx).
+		Model(&teamModel.Team{}).
+		Scopes(tenant.Scope(ctx, "teams")).
+		Where("lead_user_id = ?", oldUserID).
+		Update("lead_user_id", newUserID).Error
+	if err != nil {
+		r.logger.Errorw("ReplaceLead database error", "new_user_id", newUserID, "error", err)
+		return dberror.Wrap(err, "replace team lead", newUserID)
+	}
+	return nil
+}
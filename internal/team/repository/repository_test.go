@@ -12,13 +12,21 @@ import (
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 
+	"github.com/festy23/avito_internship/internal/database/dberror"
 	teamModel "github.com/festy23/avito_internship/internal/team/model"
+	"github.com/festy23/avito_internship/internal/tenant"
+	"github.com/festy23/avito_internship/pkg/sortparam"
 )
 
 type testTeam struct {
-	TeamName  string    `gorm:"primaryKey;column:team_name"`
-	CreatedAt time.Time `gorm:"column:created_at"`
-	UpdatedAt time.Time `gorm:"column:updated_at"`
+	TeamName            string    `gorm:"primaryKey;column:team_name"`
+	LeadUserID          *string   `gorm:"column:lead_user_id"`
+	SLAFirstReviewHours *int      `gorm:"column:sla_first_review_hours"`
+	SLAMergeHours       *int      `gorm:"column:sla_merge_hours"`
+	AutoCloseDisabled   bool      `gorm:"column:auto_close_disabled;not null;default:false"`
+	CreatedAt           time.Time `gorm:"column:created_at"`
+	UpdatedAt           time.Time `gorm:"column:updated_at"`
+	TenantID            string    `gorm:"column:tenant_id;not null;default:'default'"`
 }
 
 func (testTeam) TableName() string {
@@ -32,6 +40,7 @@ type testUser struct {
 	IsActive  bool      `gorm:"column:is_active;not null;default:true"`
 	CreatedAt time.Time `gorm:"column:created_at"`
 	UpdatedAt time.Time `gorm:"column:updated_at"`
+	TenantID  string    `gorm:"column:tenant_id;not null;default:'default'"`
 }
 
 func (testUser) TableName() string {
@@ -177,6 +186,85 @@ func TestRepository_CreateOrUpdateUser(t *testing.T) {
 	})
 }
 
+func TestRepository_TenantIsolation(t *testing.T) {
+	acme := tenant.WithID(context.Background(), "acme")
+	globex := tenant.WithID(context.Background(), "globex")
+
+	t.Run("team of another tenant is not found", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := New(db, zap.NewNop().Sugar())
+
+		_, err := repo.Create(acme, "backend")
+		require.NoError(t, err)
+
+		team, err := repo.GetByName(globex, "backend")
+		assert.Nil(t, team)
+		assert.ErrorIs(t, err, teamModel.ErrTeamNotFound)
+
+		team, err = repo.GetByName(acme, "backend")
+		require.NoError(t, err)
+		assert.Equal(t, "acme", team.TenantID)
+	})
+
+	t.Run("team name of another tenant", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := New(db, zap.NewNop().Sugar())
+
+		_, err := repo.Create(acme, "backend")
+		require.NoError(t, err)
+
+		_, err = repo.Create(globex, "backend")
+		assert.ErrorIs(t, err, tenant.ErrIDUnavailable)
+		_, err = repo.Create(acme, "backend")
+		assert.ErrorIs(t, err, teamModel.ErrTeamExists)
+	})
+
+	t.Run("context without tenant is not restricted", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := New(db, zap.NewNop().Sugar())
+
+		_, err := repo.Create(acme, "backend")
+		require.NoError(t, err)
+
+		team, err := repo.GetByName(context.Background(), "backend")
+		require.NoError(t, err)
+		assert.Equal(t, "backend", team.TeamName)
+	})
+
+	t.Run("members are stamped with tenant", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := New(db, zap.NewNop().Sugar())
+
+		_, err := repo.CreateOrUpdateUser(acme, "backend", "u1", "Alice", true)
+		require.NoError(t, err)
+
+		var dbUser testUser
+		require.NoError(t, db.Where("user_id = ?", "u1").First(&dbUser).Error)
+		assert.Equal(t, "acme", dbUser.TenantID)
+
+		members, err := repo.GetTeamMembers(globex, "backend", sortparam.Sort{})
+		require.NoError(t, err)
+		assert.Empty(t, members)
+	})
+
+	t.Run("user of another tenant is not updated", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := New(db, zap.NewNop().Sugar())
+
+		_, err := repo.CreateOrUpdateUser(acme, "backend", "u1", "Alice", true)
+		require.NoError(t, err)
+
+		user, err := repo.CreateOrUpdateUser(globex, "payments", "u1", "Mallory", true)
+		assert.Nil(t, user)
+		assert.ErrorIs(t, err, tenant.ErrIDUnavailable)
+
+		var dbUser testUser
+		require.NoError(t, db.Where("user_id = ?", "u1").First(&dbUser).Error)
+		assert.Equal(t, "Alice", dbUser.Username)
+		assert.Equal(t, "backend", dbUser.TeamName)
+	})
+}
+
 func TestRepository_GetTeamMembers(t *testing.T) {
 	ctx := context.Background()
 
@@ -185,7 +273,7 @@ func TestRepository_GetTeamMembers(t *testing.T) {
 		repo := New(db, zap.NewNop().Sugar())
 		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
 
-		members, err := repo.GetTeamMembers(ctx, "backend")
+		members, err := repo.GetTeamMembers(ctx, "backend", sortparam.Sort{})
 
 		require.NoError(t, err)
 		assert.Empty(t, members)
@@ -202,7 +290,7 @@ func TestRepository_GetTeamMembers(t *testing.T) {
 		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
 			"u2", "Bob", "backend", true)
 
-		members, err := repo.GetTeamMembers(ctx, "backend")
+		members, err := repo.GetTeamMembers(ctx, "backend", sortparam.Sort{})
 
 		require.NoError(t, err)
 		require.Len(t, members, 3)
@@ -215,13 +303,20 @@ func TestRepository_GetTeamMembers(t *testing.T) {
 		assert.Equal(t, "u3", members[2].UserID)
 		assert.Equal(t, "Charlie", members[2].Username)
 		assert.False(t, members[2].IsActive)
+
+		members, err = repo.GetTeamMembers(ctx, "backend", sortparam.Sort{Field: "username", Order: sortparam.Desc})
+
+		require.NoError(t, err)
+		require.Len(t, members, 3)
+		assert.Equal(t, "Charlie", members[0].Username)
+		assert.Equal(t, "Alice", members[2].Username)
 	})
 
 	t.Run("non-existent team returns empty list", func(t *testing.T) {
 		db := setupTestDB(t)
 		repo := New(db, zap.NewNop().Sugar())
 
-		members, err := repo.GetTeamMembers(ctx, "nonexistent")
+		members, err := repo.GetTeamMembers(ctx, "nonexistent", sortparam.Sort{})
 
 		require.NoError(t, err)
 		assert.Empty(t, members)
@@ -237,7 +332,7 @@ func TestRepository_GetTeamMembers(t *testing.T) {
 		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
 			"u2", "Bob", "frontend", true)
 
-		members, err := repo.GetTeamMembers(ctx, "backend")
+		members, err := repo.GetTeamMembers(ctx, "backend", sortparam.Sort{})
 
 		require.NoError(t, err)
 		require.Len(t, members, 1)
@@ -356,7 +451,7 @@ func TestRepository_GetTeamMembers_Extended(t *testing.T) {
 				"u"+strconv.Itoa(i), "User"+strconv.Itoa(i), "backend", true)
 		}
 
-		members, err := repo.GetTeamMembers(ctx, "backend")
+		members, err := repo.GetTeamMembers(ctx, "backend", sortparam.Sort{})
 		require.NoError(t, err)
 		assert.Len(t, members, 10)
 	})
@@ -367,7 +462,7 @@ func TestRepository_GetTeamMembers_Extended(t *testing.T) {
 		sqlDB, _ := db.DB()
 		sqlDB.Close()
 
-		members, err := repo.GetTeamMembers(ctx, "backend")
+		members, err := repo.GetTeamMembers(ctx, "backend", sortparam.Sort{})
 		assert.Nil(t, members)
 		assert.Error(t, err)
 	})
@@ -424,3 +519,139 @@ func TestRepository_isDuplicateError_Extended(t *testing.T) {
 		assert.ErrorIs(t, err3, teamModel.ErrTeamExists)
 	})
 }
+
+func TestRepository_SetLead(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("set and clear lead", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := New(db, zap.NewNop().Sugar())
+		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
+
+		lead := "u1"
+		require.NoError(t, repo.SetLead(ctx, "backend", &lead))
+
+		team, err := repo.GetByName(ctx, "backend")
+		require.NoError(t, err)
+		require.NotNil(t, team.LeadUserID)
+		assert.Equal(t, "u1", *team.LeadUserID)
+
+		require.NoError(t, repo.SetLead(ctx, "backend", nil))
+
+		team, err = repo.GetByName(ctx, "backend")
+		require.NoError(t, err)
+		assert.Nil(t, team.LeadUserID)
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := New(db, zap.NewNop().Sugar())
+
+		lead := "u1"
+		err := repo.SetLead(ctx, "nonexistent", &lead)
+
+		assert.ErrorIs(t, err, teamModel.ErrTeamNotFound)
+	})
+
+	t.Run("moving lead to another team clears previous lead", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := New(db, zap.NewNop().Sugar())
+		db.Exec("INSERT INTO teams (team_name, lead_user_id) VALUES (?, ?)", "backend", "u1")
+		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "frontend")
+		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
+			"u1", "Alice", "backend", true)
+
+		_, err := repo.CreateOrUpdateUser(ctx, "frontend", "u1", "Alice", true)
+		require.NoError(t, err)
+
+		team, err := repo.GetByName(ctx, "backend")
+		require.NoError(t, err)
+		assert.Nil(t, team.LeadUserID)
+	})
+}
+
+func TestRepository_SetSLA(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("set and clear SLA", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := New(db, zap.NewNop().Sugar())
+		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
+
+		firstReview, merge := 4, 48
+		require.NoError(t, repo.SetSLA(ctx, "backend", &firstReview, &merge))
+
+		team, err := repo.GetByName(ctx, "backend")
+		require.NoError(t, err)
+		require.NotNil(t, team.SLAFirstReviewHours)
+		require.NotNil(t, team.SLAMergeHours)
+		assert.Equal(t, 4, *team.SLAFirstReviewHours)
+		assert.Equal(t, 48, *team.SLAMergeHours)
+
+		require.NoError(t, repo.SetSLA(ctx, "backend", nil, &merge))
+
+		team, err = repo.GetByName(ctx, "backend")
+		require.NoError(t, err)
+		assert.Nil(t, team.SLAFirstReviewHours)
+		assert.NotNil(t, team.SLAMergeHours)
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := New(db, zap.NewNop().Sugar())
+
+		err := repo.SetSLA(ctx, "nonexistent", nil, nil)
+
+		assert.ErrorIs(t, err, teamModel.ErrTeamNotFound)
+	})
+}
+
+func TestRepository_SetAutoCloseDisabled(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("opt out and back in", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := New(db, zap.NewNop().Sugar())
+		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
+
+		team, err := repo.GetByName(ctx, "backend")
+		require.NoError(t, err)
+		assert.False(t, team.AutoCloseDisabled)
+
+		require.NoError(t, repo.SetAutoCloseDisabled(ctx, "backend", true))
+		team, err = repo.GetByName(ctx, "backend")
+		require.NoError(t, err)
+		assert.True(t, team.AutoCloseDisabled)
+
+		require.NoError(t, repo.SetAutoCloseDisabled(ctx, "backend", false))
+		team, err = repo.GetByName(ctx, "backend")
+		require.NoError(t, err)
+		assert.False(t, team.AutoCloseDisabled)
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := New(db, zap.NewNop().Sugar())
+
+		err := repo.SetAutoCloseDisabled(ctx, "nonexistent", true)
+
+		assert.ErrorIs(t, err, teamModel.ErrTeamNotFound)
+	})
+}
+
+func TestRepository_DatabaseErrorContext(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repo := New(db, zap.NewNop().Sugar())
+	require.NoError(t, db.Migrator().DropTable(&testUser{}))
+
+	_, err := repo.GetTeamMembers(ctx, "backend", sortparam.Sort{})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "get team members backend: ")
+
+	var dbErr *dberror.Error
+	require.ErrorAs(t, err, &dbErr)
+	assert.Equal(t, "get team members", dbErr.Op)
+	assert.Equal(t, "backend", dbErr.Key)
+}
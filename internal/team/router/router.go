@@ -6,17 +6,21 @@ import (
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 
+	"github.com/festy23/avito_internship/internal/middleware"
 	"github.com/festy23/avito_internship/internal/team/handler"
 	"github.com/festy23/avito_internship/internal/team/repository"
 	"github.com/festy23/avito_internship/internal/team/service"
 )
 
 // RegisterRoutes registers team module routes.
-func RegisterRoutes(r *gin.Engine, db *gorm.DB, logger *zap.SugaredLogger) {
+func RegisterRoutes(r gin.IRouter, db *gorm.DB, logger *zap.SugaredLogger) {
 	repo := repository.New(db, logger)
 	svc := service.New(repo, db, logger)
-	h := handler.New(svc, logger)
+	h := handler.New(svc)
 
 	r.POST("/team/add", h.AddTeam)
-	r.GET("/team/get", h.GetTeam)
+	r.GET("/team/get", middleware.ConditionalGet(), h.GetTeam)
+	r.POST("/team/setLead", h.SetLead)
+	r.POST("/team/setSLA", h.SetSLA)
+	r.POST("/team/setAutoClose", h.SetAutoClose)
 }
@@ -21,9 +21,14 @@ import (
 )
 
 type testTeam struct {
-	TeamName  string    `gorm:"primaryKey;column:team_name"`
-	CreatedAt time.Time `gorm:"column:created_at"`
-	UpdatedAt time.Time `gorm:"column:updated_at"`
+	TeamName            string    `gorm:"primaryKey;column:team_name"`
+	LeadUserID          *string   `gorm:"column:lead_user_id"`
+	SLAFirstReviewHours *int      `gorm:"column:sla_first_review_hours"`
+	SLAMergeHours       *int      `gorm:"column:sla_merge_hours"`
+	AutoCloseDisabled   bool      `gorm:"column:auto_close_disabled;not null;default:false"`
+	CreatedAt           time.Time `gorm:"column:created_at"`
+	UpdatedAt           time.Time `gorm:"column:updated_at"`
+	TenantID            string    `gorm:"column:tenant_id;not null;default:'default'"`
 }
 
 func (testTeam) TableName() string {
@@ -37,6 +42,7 @@ type testUser struct {
 	IsActive  bool      `gorm:"column:is_active;not null"`
 	CreatedAt time.Time `gorm:"column:created_at"`
 	UpdatedAt time.Time `gorm:"column:updated_at"`
+	TenantID  string    `gorm:"column:tenant_id;not null;default:'default'"`
 }
 
 func (testUser) TableName() string {
@@ -276,10 +282,13 @@ func TestIntegration_GetTeam(t *testing.T) {
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 
-		var response map[string]map[string]string
+		var response map[string]map[string]any
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		require.NoError(t, err)
 		assert.Equal(t, "INVALID_REQUEST", response["error"]["code"])
+		assert.Equal(t, []any{map[string]any{
+			"field": "team_name", "rule": "required", "message": "team_name parameter is required",
+		}}, response["error"]["details"])
 	})
 }
 
@@ -346,3 +355,63 @@ func TestIntegration_FullFlow(t *testing.T) {
 		// User updates would come through a different endpoint (users/setIsActive)
 	})
 }
+
+func TestIntegration_SetLead(t *testing.T) {
+	t.Run("designate lead then get team", func(t *testing.T) {
+		db := setupIntegrationDB(t)
+		router := setupRouter(db)
+
+		createReq := &teamModel.AddTeamRequest{
+			TeamName: "payments",
+			Members: []teamModel.TeamMember{
+				{UserID: "p1", Username: "Peter", IsActive: true},
+				{UserID: "p2", Username: "Paul", IsActive: true},
+			},
+		}
+
+		body, _ := json.Marshal(createReq)
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/team/add", bytes.NewBuffer(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		body, _ = json.Marshal(&teamModel.SetLeadRequest{TeamName: "payments", UserID: "p2"})
+		w = httptest.NewRecorder()
+		httpReq, _ = http.NewRequest("POST", "/team/setLead", bytes.NewBuffer(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = httptest.NewRecorder()
+		httpReq, _ = http.NewRequest("GET", "/team/get?team_name=payments", nil)
+		router.ServeHTTP(w, httpReq)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response teamModel.TeamResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, "p2", response.LeadUserID)
+	})
+
+	t.Run("lead must be a team member", func(t *testing.T) {
+		db := setupIntegrationDB(t)
+		router := setupRouter(db)
+
+		createReq := &teamModel.AddTeamRequest{
+			TeamName:   "payments",
+			LeadUserID: "x1",
+			Members: []teamModel.TeamMember{
+				{UserID: "p1", Username: "Peter", IsActive: true},
+			},
+		}
+
+		body, _ := json.Marshal(createReq)
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/team/add", bytes.NewBuffer(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
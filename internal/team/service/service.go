@@ -9,6 +9,8 @@ import (
 
 	teamModel "github.com/festy23/avito_internship/internal/team/model"
 	"github.com/festy23/avito_internship/internal/team/repository"
+	"github.com/festy23/avito_internship/pkg/clock"
+	"github.com/festy23/avito_internship/pkg/sortparam"
 )
 
 // Service defines the interface for team business logic operations.
@@ -16,21 +18,37 @@ type Service interface {
 	// AddTeam creates a new team with members.
 	AddTeam(ctx context.Context, req *teamModel.AddTeamRequest) (*teamModel.TeamResponse, error)
 
-	// GetTeam returns a team with its members.
-	GetTeam(ctx context.Context, teamName string) (*teamModel.TeamResponse, error)
+	// GetTeam returns a team with its members ordered by srt.
+	GetTeam(ctx context.Context, teamName string, srt sortparam.Sort) (*teamModel.TeamResponse, error)
+
+	// SetLead designates a team member as the team lead.
+	SetLead(ctx context.Context, req *teamModel.SetLeadRequest) (*teamModel.TeamResponse, error)
+
+	// SetSLA sets the review SLA of a team.
+	SetSLA(ctx context.Context, req *teamModel.SetSLARequest) (*teamModel.TeamResponse, error)
+
+	// SetAutoClose opts a team in or out of closing its inactive pull requests.
+	SetAutoClose(ctx context.Context, req *teamModel.SetAutoCloseRequest) (*teamModel.TeamResponse, error)
 }
 
 type service struct {
 	repo   repository.Repository
 	db     *gorm.DB
+	clock  clock.Clock
 	logger *zap.SugaredLogger
 }
 
 // New creates a new team service instance.
 func New(repo repository.Repository, db *gorm.DB, logger *zap.SugaredLogger) Service {
+	return NewWithClock(repo, db, clock.New(), logger)
+}
+
+// NewWithClock creates a new team service instance that takes timestamps from clk.
+func NewWithClock(repo repository.Repository, db *gorm.DB, clk clock.Clock, logger *zap.SugaredLogger) Service {
 	return &service{
 		repo:   repo,
 		db:     db,
+		clock:  clk,
 		logger: logger,
 	}
 }
@@ -46,11 +64,15 @@ func (s *service) AddTeam(ctx context.Context, req *teamModel.AddTeamRequest) (*
 		return nil, teamModel.ErrEmptyMembers
 	}
 
+	if req.LeadUserID != "" && !hasMember(req.Members, req.LeadUserID) {
+		return nil, teamModel.ErrLeadNotMember
+	}
+
 	// Use transaction to ensure atomicity
 	var result *teamModel.TeamResponse
 	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// Create repository with transaction
-		txRepo := repository.New(tx, s.logger)
+		txRepo := repository.NewWithClock(tx, s.clock, s.logger)
 
 		// Create team
 		_, err := txRepo.Create(ctx, req.TeamName)
@@ -69,15 +91,23 @@ func (s *service) AddTeam(ctx context.Context, req *teamModel.AddTeamRequest) (*
 			}
 		}
 
+		if req.LeadUserID != "" {
+			leadUserID := req.LeadUserID
+			if err = txRepo.SetLead(ctx, req.TeamName, &leadUserID); err != nil {
+				return err
+			}
+		}
+
 		// Fetch team members
-		members, err := txRepo.GetTeamMembers(ctx, req.TeamName)
+		members, err := txRepo.GetTeamMembers(ctx, req.TeamName, sortparam.Sort{})
 		if err != nil {
 			return err
 		}
 
 		result = &teamModel.TeamResponse{
-			TeamName: req.TeamName,
-			Members:  members,
+			TeamName:   req.TeamName,
+			LeadUserID: req.LeadUserID,
+			Members:    members,
 		}
 
 		return nil
@@ -90,26 +120,226 @@ func (s *service) AddTeam(ctx context.Context, req *teamModel.AddTeamRequest) (*
 	return result, nil
 }
 
-// GetTeam returns a team with its members.
-func (s *service) GetTeam(ctx context.Context, teamName string) (*teamModel.TeamResponse, error) {
+// GetTeam returns a team with its members ordered by srt.
+func (s *service) GetTeam(
+	ctx context.Context,
+	teamName string,
+	srt sortparam.Sort,
+) (*teamModel.TeamResponse, error) {
 	if teamName == "" {
 		return nil, teamModel.ErrInvalidTeamName
 	}
 
 	// Check if team exists
-	_, err := s.repo.GetByName(ctx, teamName)
+	team, err := s.repo.GetByName(ctx, teamName)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get team members
-	members, err := s.repo.GetTeamMembers(ctx, teamName)
+	members, err := s.repo.GetTeamMembers(ctx, teamName, srt)
 	if err != nil {
 		return nil, err
 	}
 
 	return &teamModel.TeamResponse{
-		TeamName: teamName,
-		Members:  members,
+		TeamName:   teamName,
+		LeadUserID: leadOf(team),
+		SLA:        slaOf(team),
+		Members:    members,
+
+		AutoCloseDisabled: team.AutoCloseDisabled,
 	}, nil
 }
+
+// SetLead designates a team member as the team lead.
+func (s *service) SetLead(ctx context.Context, req *teamModel.SetLeadRequest) (*teamModel.TeamResponse, error) {
+	if req.TeamName == "" {
+		return nil, teamModel.ErrInvalidTeamName
+	}
+	if req.UserID == "" {
+		return nil, teamModel.ErrLeadNotMember
+	}
+
+	var result *teamModel.TeamResponse
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txRepo := repository.NewWithClock(tx, s.clock, s.logger)
+
+		team, err := txRepo.GetByName(ctx, req.TeamName)
+		if err != nil {
+			return err
+		}
+
+		members, err := txRepo.GetTeamMembers(ctx, req.TeamName, sortparam.Sort{})
+		if err != nil {
+			return err
+		}
+
+		if !hasMember(members, req.UserID) {
+			return teamModel.ErrLeadNotMember
+		}
+
+		leadUserID := req.UserID
+		if err = txRepo.SetLead(ctx, req.TeamName, &leadUserID); err != nil {
+			return err
+		}
+
+		result = &teamModel.TeamResponse{
+			TeamName:   req.TeamName,
+			LeadUserID: req.UserID,
+			SLA:        slaOf(team),
+			Members:    members,
+
+			AutoCloseDisabled: team.AutoCloseDisabled,
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Infow("SetLead completed", "team_name", req.TeamName, "lead_user_id", req.UserID)
+	return result, nil
+}
+
+// SetSLA sets the review SLA of a team; zero hours disable the corresponding check.
+func (s *service) SetSLA(ctx context.Context, req *teamModel.SetSLARequest) (*teamModel.TeamResponse, error) {
+	if req.TeamName == "" {
+		return nil, teamModel.ErrInvalidTeamName
+	}
+	if !validSLAHours(req.FirstReviewHours) || !validSLAHours(req.MergeHours) {
+		return nil, teamModel.ErrInvalidSLA
+	}
+
+	var result *teamModel.TeamResponse
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txRepo := repository.NewWithClock(tx, s.clock, s.logger)
+
+		err := txRepo.SetSLA(ctx, req.TeamName, slaHours(req.FirstReviewHours), slaHours(req.MergeHours))
+		if err != nil {
+			return err
+		}
+
+		team, err := txRepo.GetByName(ctx, req.TeamName)
+		if err != nil {
+			return err
+		}
+
+		members, err := txRepo.GetTeamMembers(ctx, req.TeamName, sortparam.Sort{})
+		if err != nil {
+			return err
+		}
+
+		result = &teamModel.TeamResponse{
+			TeamName:   req.TeamName,
+			LeadUserID: leadOf(team),
+			SLA:        slaOf(team),
+			Members:    members,
+
+			AutoCloseDisabled: team.AutoCloseDisabled,
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Infow("SetSLA completed", "team_name", req.TeamName,
+		"first_review_hours", req.FirstReviewHours, "merge_hours", req.MergeHours)
+	return result, nil
+}
+
+// SetAutoClose opts a team in or out of closing its inactive pull requests by the stale job.
+func (s *service) SetAutoClose(
+	ctx context.Context,
+	req *teamModel.SetAutoCloseRequest,
+) (*teamModel.TeamResponse, error) {
+	if req.TeamName == "" {
+		return nil, teamModel.ErrInvalidTeamName
+	}
+	enabled := req.Enabled == nil || *req.Enabled
+
+	var result *teamModel.TeamResponse
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txRepo := repository.NewWithClock(tx, s.clock, s.logger)
+
+		if err := txRepo.SetAutoCloseDisabled(ctx, req.TeamName, !enabled); err != nil {
+			return err
+		}
+
+		team, err := txRepo.GetByName(ctx, req.TeamName)
+		if err != nil {
+			return err
+		}
+
+		members, err := txRepo.GetTeamMembers(ctx, req.TeamName, sortparam.Sort{})
+		if err != nil {
+			return err
+		}
+
+		result = &teamModel.TeamResponse{
+			TeamName:   req.TeamName,
+			LeadUserID: leadOf(team),
+			SLA:        slaOf(team),
+			Members:    members,
+
+			AutoCloseDisabled: team.AutoCloseDisabled,
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Infow("SetAutoClose completed", "team_name", req.TeamName, "enabled", enabled)
+	return result, nil
+}
+
+// hasMember checks if userID is present in the members list.
+func hasMember(members []teamModel.TeamMember, userID string) bool {
+	for _, member := range members {
+		if member.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// validSLAHours checks that hours is 0 (disabled) or a valid SLA.
+func validSLAHours(hours int) bool {
+	return hours >= 0 && hours <= teamModel.MaxSLAHours
+}
+
+// slaHours converts SLA hours of a request to a column value, NULL when the check is disabled.
+func slaHours(hours int) *int {
+	if hours == 0 {
+		return nil
+	}
+	return &hours
+}
+
+// slaOf returns the review SLA of the team or nil if none is set.
+func slaOf(team *teamModel.Team) *teamModel.TeamSLA {
+	if team == nil || (team.SLAFirstReviewHours == nil && team.SLAMergeHours == nil) {
+		return nil
+	}
+	sla := &teamModel.TeamSLA{}
+	if team.SLAFirstReviewHours != nil {
+		sla.FirstReviewHours = *team.SLAFirstReviewHours
+	}
+	if team.SLAMergeHours != nil {
+		sla.MergeHours = *team.SLAMergeHours
+	}
+	return sla
+}
+
+// leadOf returns the team lead user ID or an empty string if none is set.
+func leadOf(team *teamModel.Team) string {
+	if team == nil || team.LeadUserID == nil {
+		return ""
+	}
+	return *team.LeadUserID
+}
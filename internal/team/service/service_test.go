@@ -10,12 +10,13 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
-	"gorm.io/driver/sqlite"
-	"gorm.io/gorm"
 
 	teamModel "github.com/festy23/avito_internship/internal/team/model"
 	"github.com/festy23/avito_internship/internal/team/repository"
+	"github.com/festy23/avito_internship/internal/testutil"
 	userModel "github.com/festy23/avito_internship/internal/user/model"
+	"github.com/festy23/avito_internship/pkg/clock"
+	"github.com/festy23/avito_internship/pkg/sortparam"
 )
 
 type mockRepository struct {
@@ -50,45 +51,43 @@ func (m *mockRepository) CreateOrUpdateUser(
 	return args.Get(0).(*userModel.User), args.Error(1)
 }
 
-func (m *mockRepository) GetTeamMembers(ctx context.Context, teamName string) ([]teamModel.TeamMember, error) {
-	args := m.Called(ctx, teamName)
+func (m *mockRepository) GetTeamMembers(
+	ctx context.Context,
+	teamName string,
+	srt sortparam.Sort,
+) ([]teamModel.TeamMember, error) {
+	args := m.Called(ctx, teamName, srt)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]teamModel.TeamMember), args.Error(1)
 }
 
-func setupTestDB(t *testing.T) *gorm.DB {
-	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
-	require.NoError(t, err)
+func (m *mockRepository) SetLead(ctx context.Context, teamName string, leadUserID *string) error {
+	args := m.Called(ctx, teamName, leadUserID)
+	return args.Error(0)
+}
 
-	// Define test models
-	type Team struct {
-		TeamName  string    `gorm:"primaryKey;column:team_name"`
-		CreatedAt time.Time `gorm:"column:created_at"`
-		UpdatedAt time.Time `gorm:"column:updated_at"`
-	}
-	type User struct {
-		UserID    string    `gorm:"primaryKey;column:user_id"`
-		Username  string    `gorm:"column:username"`
-		TeamName  string    `gorm:"column:team_name"`
-		IsActive  bool      `gorm:"column:is_active;not null"`
-		CreatedAt time.Time `gorm:"column:created_at"`
-		UpdatedAt time.Time `gorm:"column:updated_at"`
-	}
+func (m *mockRepository) SetSLA(ctx context.Context, teamName string, firstReviewHours, mergeHours *int) error {
+	args := m.Called(ctx, teamName, firstReviewHours, mergeHours)
+	return args.Error(0)
+}
 
-	// Migrate all tables
-	err = db.AutoMigrate(&Team{}, &User{})
-	require.NoError(t, err)
+func (m *mockRepository) SetAutoCloseDisabled(ctx context.Context, teamName string, disabled bool) error {
+	args := m.Called(ctx, teamName, disabled)
+	return args.Error(0)
+}
 
-	return db
+func (m *mockRepository) ReplaceLead(ctx context.Context, oldUserID, newUserID string) error {
+	args := m.Called(ctx, oldUserID, newUserID)
+	return args.Error(0)
 }
 
 func TestService_AddTeam(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("empty team name", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		mockRepo := new(mockRepository)
 		svc := New(mockRepo, db, zap.NewNop().Sugar())
 
@@ -106,7 +105,7 @@ func TestService_AddTeam(t *testing.T) {
 	})
 
 	t.Run("empty members list", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		mockRepo := new(mockRepository)
 		svc := New(mockRepo, db, zap.NewNop().Sugar())
 
@@ -126,7 +125,7 @@ func TestService_AddTeam_Integration(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("success with multiple members", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		repo := repository.New(db, zap.NewNop().Sugar())
 		svc := New(repo, db, zap.NewNop().Sugar())
 
@@ -152,7 +151,7 @@ func TestService_AddTeam_Integration(t *testing.T) {
 	})
 
 	t.Run("duplicate team returns error", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		repo := repository.New(db, zap.NewNop().Sugar())
 		svc := New(repo, db, zap.NewNop().Sugar())
 
@@ -173,7 +172,7 @@ func TestService_AddTeam_Integration(t *testing.T) {
 	})
 
 	t.Run("skip members with empty user_id", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		repo := repository.New(db, zap.NewNop().Sugar())
 		svc := New(repo, db, zap.NewNop().Sugar())
 
@@ -204,11 +203,33 @@ func TestService_AddTeam_Integration(t *testing.T) {
 	})
 }
 
+func TestService_AddTeam_Clock(t *testing.T) {
+	db := testutil.NewDB(t)
+	now := time.Date(2025, 11, 3, 9, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(now)
+	repo := repository.NewWithClock(db, clk, zap.NewNop().Sugar())
+	svc := NewWithClock(repo, db, clk, zap.NewNop().Sugar())
+
+	_, err := svc.AddTeam(context.Background(), &teamModel.AddTeamRequest{
+		TeamName: "backend",
+		Members:  []teamModel.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}},
+	})
+	require.NoError(t, err)
+
+	var team teamModel.Team
+	require.NoError(t, db.First(&team, "team_name = ?", "backend").Error)
+	assert.True(t, now.Equal(team.CreatedAt))
+
+	var user userModel.User
+	require.NoError(t, db.First(&user, "user_id = ?", "u1").Error)
+	assert.True(t, now.Equal(user.CreatedAt))
+}
+
 func TestService_GetTeam(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("success", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		mockRepo := new(mockRepository)
 		svc := New(mockRepo, db, zap.NewNop().Sugar())
 
@@ -219,9 +240,9 @@ func TestService_GetTeam(t *testing.T) {
 		}
 
 		mockRepo.On("GetByName", ctx, "backend").Return(team, nil)
-		mockRepo.On("GetTeamMembers", ctx, "backend").Return(members, nil)
+		mockRepo.On("GetTeamMembers", ctx, "backend", sortparam.Sort{}).Return(members, nil)
 
-		resp, err := svc.GetTeam(ctx, "backend")
+		resp, err := svc.GetTeam(ctx, "backend", sortparam.Sort{})
 
 		require.NoError(t, err)
 		assert.Equal(t, "backend", resp.TeamName)
@@ -232,24 +253,24 @@ func TestService_GetTeam(t *testing.T) {
 	})
 
 	t.Run("empty team name", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		mockRepo := new(mockRepository)
 		svc := New(mockRepo, db, zap.NewNop().Sugar())
 
-		resp, err := svc.GetTeam(ctx, "")
+		resp, err := svc.GetTeam(ctx, "", sortparam.Sort{})
 
 		assert.Nil(t, resp)
 		assert.ErrorIs(t, err, teamModel.ErrInvalidTeamName)
 	})
 
 	t.Run("team not found", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		mockRepo := new(mockRepository)
 		svc := New(mockRepo, db, zap.NewNop().Sugar())
 
 		mockRepo.On("GetByName", ctx, "nonexistent").Return(nil, teamModel.ErrTeamNotFound)
 
-		resp, err := svc.GetTeam(ctx, "nonexistent")
+		resp, err := svc.GetTeam(ctx, "nonexistent", sortparam.Sort{})
 
 		assert.Nil(t, resp)
 		assert.ErrorIs(t, err, teamModel.ErrTeamNotFound)
@@ -257,7 +278,7 @@ func TestService_GetTeam(t *testing.T) {
 	})
 
 	t.Run("team with no members", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		mockRepo := new(mockRepository)
 		svc := New(mockRepo, db, zap.NewNop().Sugar())
 
@@ -265,9 +286,9 @@ func TestService_GetTeam(t *testing.T) {
 		members := []teamModel.TeamMember{}
 
 		mockRepo.On("GetByName", ctx, "backend").Return(team, nil)
-		mockRepo.On("GetTeamMembers", ctx, "backend").Return(members, nil)
+		mockRepo.On("GetTeamMembers", ctx, "backend", sortparam.Sort{}).Return(members, nil)
 
-		resp, err := svc.GetTeam(ctx, "backend")
+		resp, err := svc.GetTeam(ctx, "backend", sortparam.Sort{})
 
 		require.NoError(t, err)
 		assert.Equal(t, "backend", resp.TeamName)
@@ -276,7 +297,7 @@ func TestService_GetTeam(t *testing.T) {
 	})
 
 	t.Run("repository error on GetTeamMembers", func(t *testing.T) {
-		db := setupTestDB(t)
+		db := testutil.NewDB(t)
 		mockRepo := new(mockRepository)
 		svc := New(mockRepo, db, zap.NewNop().Sugar())
 
@@ -284,12 +305,219 @@ func TestService_GetTeam(t *testing.T) {
 		dbError := errors.New("database error")
 
 		mockRepo.On("GetByName", ctx, "backend").Return(team, nil)
-		mockRepo.On("GetTeamMembers", ctx, "backend").Return(nil, dbError)
+		mockRepo.On("GetTeamMembers", ctx, "backend", sortparam.Sort{}).Return(nil, dbError)
 
-		resp, err := svc.GetTeam(ctx, "backend")
+		resp, err := svc.GetTeam(ctx, "backend", sortparam.Sort{})
 
 		assert.Nil(t, resp)
 		assert.ErrorIs(t, err, dbError)
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+func TestService_TeamLead(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("add team with lead", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		svc := New(repo, db, zap.NewNop().Sugar())
+
+		resp, err := svc.AddTeam(ctx, &teamModel.AddTeamRequest{
+			TeamName:   "backend",
+			LeadUserID: "u2",
+			Members: []teamModel.TeamMember{
+				{UserID: "u1", Username: "Alice", IsActive: true},
+				{UserID: "u2", Username: "Bob", IsActive: true},
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "u2", resp.LeadUserID)
+
+		team, err := svc.GetTeam(ctx, "backend", sortparam.Sort{})
+		require.NoError(t, err)
+		assert.Equal(t, "u2", team.LeadUserID)
+	})
+
+	t.Run("add team with lead outside members", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		mockRepo := new(mockRepository)
+		svc := New(mockRepo, db, zap.NewNop().Sugar())
+
+		resp, err := svc.AddTeam(ctx, &teamModel.AddTeamRequest{
+			TeamName:   "backend",
+			LeadUserID: "u9",
+			Members: []teamModel.TeamMember{
+				{UserID: "u1", Username: "Alice", IsActive: true},
+			},
+		})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, teamModel.ErrLeadNotMember)
+		mockRepo.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("set lead replaces previous lead", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		svc := New(repo, db, zap.NewNop().Sugar())
+
+		_, err := svc.AddTeam(ctx, &teamModel.AddTeamRequest{
+			TeamName:   "backend",
+			LeadUserID: "u1",
+			Members: []teamModel.TeamMember{
+				{UserID: "u1", Username: "Alice", IsActive: true},
+				{UserID: "u2", Username: "Bob", IsActive: true},
+			},
+		})
+		require.NoError(t, err)
+
+		resp, err := svc.SetLead(ctx, &teamModel.SetLeadRequest{TeamName: "backend", UserID: "u2"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "u2", resp.LeadUserID)
+		assert.Len(t, resp.Members, 2)
+	})
+
+	t.Run("set lead for non-member", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		svc := New(repo, db, zap.NewNop().Sugar())
+
+		_, err := svc.AddTeam(ctx, &teamModel.AddTeamRequest{
+			TeamName: "backend",
+			Members: []teamModel.TeamMember{
+				{UserID: "u1", Username: "Alice", IsActive: true},
+			},
+		})
+		require.NoError(t, err)
+
+		resp, err := svc.SetLead(ctx, &teamModel.SetLeadRequest{TeamName: "backend", UserID: "u9"})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, teamModel.ErrLeadNotMember)
+	})
+
+	t.Run("set lead for missing team", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		svc := New(repo, db, zap.NewNop().Sugar())
+
+		resp, err := svc.SetLead(ctx, &teamModel.SetLeadRequest{TeamName: "nonexistent", UserID: "u1"})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, teamModel.ErrTeamNotFound)
+	})
+}
+
+func TestService_SetSLA(t *testing.T) {
+	ctx := context.Background()
+
+	addTeam := func(t *testing.T, svc Service) {
+		t.Helper()
+		_, err := svc.AddTeam(ctx, &teamModel.AddTeamRequest{
+			TeamName:   "backend",
+			LeadUserID: "u1",
+			Members:    []teamModel.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}},
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("set and disable", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		svc := New(repo, db, zap.NewNop().Sugar())
+		addTeam(t, svc)
+
+		resp, err := svc.SetSLA(ctx, &teamModel.SetSLARequest{
+			TeamName: "backend",
+			TeamSLA:  teamModel.TeamSLA{FirstReviewHours: 4, MergeHours: 48},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &teamModel.TeamSLA{FirstReviewHours: 4, MergeHours: 48}, resp.SLA)
+		assert.Equal(t, "u1", resp.LeadUserID)
+		assert.Len(t, resp.Members, 1)
+
+		team, err := svc.GetTeam(ctx, "backend", sortparam.Sort{})
+		require.NoError(t, err)
+		assert.Equal(t, &teamModel.TeamSLA{FirstReviewHours: 4, MergeHours: 48}, team.SLA)
+
+		// Zero hours disable a check; with both disabled the team has no SLA
+		resp, err = svc.SetSLA(ctx, &teamModel.SetSLARequest{
+			TeamName: "backend",
+			TeamSLA:  teamModel.TeamSLA{MergeHours: 24},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &teamModel.TeamSLA{MergeHours: 24}, resp.SLA)
+
+		resp, err = svc.SetSLA(ctx, &teamModel.SetSLARequest{TeamName: "backend"})
+		require.NoError(t, err)
+		assert.Nil(t, resp.SLA)
+	})
+
+	t.Run("invalid hours", func(t *testing.T) {
+		mockRepo := new(mockRepository)
+		svc := New(mockRepo, testutil.NewDB(t), zap.NewNop().Sugar())
+
+		for _, sla := range []teamModel.TeamSLA{{FirstReviewHours: -1}, {MergeHours: teamModel.MaxSLAHours + 1}} {
+			resp, err := svc.SetSLA(ctx, &teamModel.SetSLARequest{TeamName: "backend", TeamSLA: sla})
+			assert.Nil(t, resp)
+			assert.ErrorIs(t, err, teamModel.ErrInvalidSLA)
+		}
+		mockRepo.AssertNotCalled(t, "SetSLA")
+	})
+
+	t.Run("missing team", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		svc := New(repository.New(db, zap.NewNop().Sugar()), db, zap.NewNop().Sugar())
+
+		resp, err := svc.SetSLA(ctx, &teamModel.SetSLARequest{
+			TeamName: "nonexistent",
+			TeamSLA:  teamModel.TeamSLA{MergeHours: 24},
+		})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, teamModel.ErrTeamNotFound)
+	})
+}
+
+func TestService_SetAutoClose(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("opt out and back in", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		repo := repository.New(db, zap.NewNop().Sugar())
+		svc := New(repo, db, zap.NewNop().Sugar())
+		_, err := svc.AddTeam(ctx, &teamModel.AddTeamRequest{
+			TeamName: "backend",
+			Members:  []teamModel.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}},
+		})
+		require.NoError(t, err)
+
+		disabled, enabled := false, true
+		resp, err := svc.SetAutoClose(ctx, &teamModel.SetAutoCloseRequest{TeamName: "backend", Enabled: &disabled})
+		require.NoError(t, err)
+		assert.True(t, resp.AutoCloseDisabled)
+		assert.Len(t, resp.Members, 1)
+
+		team, err := svc.GetTeam(ctx, "backend", sortparam.Sort{})
+		require.NoError(t, err)
+		assert.True(t, team.AutoCloseDisabled)
+
+		resp, err = svc.SetAutoClose(ctx, &teamModel.SetAutoCloseRequest{TeamName: "backend", Enabled: &enabled})
+		require.NoError(t, err)
+		assert.False(t, resp.AutoCloseDisabled)
+	})
+
+	t.Run("missing team", func(t *testing.T) {
+		db := testutil.NewDB(t)
+		svc := New(repository.New(db, zap.NewNop().Sugar()), db, zap.NewNop().Sugar())
+
+		enabled := false
+		resp, err := svc.SetAutoClose(ctx, &teamModel.SetAutoCloseRequest{TeamName: "nonexistent", Enabled: &enabled})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, teamModel.ErrTeamNotFound)
+	})
+}
@@ -0,0 +1,81 @@
+package tenant
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/festy23/avito_internship/pkg/apierror"
+)
+
+// Header is the request header naming the tenant in header mode.
+const Header = "X-Tenant-ID"
+
+// APIKeyHeader is the request header carrying the API key in API key mode.
+const APIKeyHeader = "X-API-Key"
+
+// Modes of tenant resolution.
+const (
+	// ModeHeader takes the tenant from the X-Tenant-ID header. It trusts the client, so it is meant
+	// for deployments behind a gateway that authenticates requests and sets the header.
+	ModeHeader = "header"
+	// ModeAPIKey maps the X-API-Key header to a tenant.
+	ModeAPIKey = "api_key"
+)
+
+// HeaderMiddleware returns a middleware taking the tenant of requests from the X-Tenant-ID header.
+// Requests without a valid header are rejected with 400.
+func HeaderMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(Header)
+		if err := ValidateID(id); err != nil {
+			apierror.Abort(c, apierror.InvalidField(Header, "tenant", fmt.Sprintf("%s header: %v", Header, err)))
+			return
+		}
+		c.Request = c.Request.WithContext(WithID(c.Request.Context(), id))
+		c.Next()
+	}
+}
+
+// APIKeyMiddleware returns a middleware resolving the tenant of requests from the X-API-Key header
+// using keys (API key to tenant ID). Requests without a known key are rejected with 401.
+func APIKeyMiddleware(keys map[string]string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := keys[c.GetHeader(APIKeyHeader)]
+		if !ok {
+			apierror.Abort(c, apierror.Unauthorized("missing or unknown API key"))
+			return
+		}
+		c.Request = c.Request.WithContext(WithID(c.Request.Context(), id))
+		c.Next()
+	}
+}
+
+// ParseAPIKeys parses a comma-separated list of "<api key>=<tenant ID>" pairs.
+// Several keys may map to the same tenant, e.g. during key rotation.
+func ParseAPIKeys(s string) (map[string]string, error) {
+	keys := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, id, ok := strings.Cut(pair, "=")
+		key, id = strings.TrimSpace(key), strings.TrimSpace(id)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("entry must be <api key>=<tenant ID>")
+		}
+		if err := ValidateID(id); err != nil {
+			return nil, err
+		}
+		if _, dup := keys[key]; dup {
+			return nil, fmt.Errorf("duplicate API key")
+		}
+		keys[key] = id
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no API keys")
+	}
+	return keys, nil
+}
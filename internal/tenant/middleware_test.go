@@ -0,0 +1,97 @@
+package tenant
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRouter(middleware gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware)
+	r.GET("/tenant", func(c *gin.Context) {
+		c.String(http.StatusOK, ID(c.Request.Context()))
+	})
+	return r
+}
+
+func serve(r *gin.Engine, header, value string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/tenant", nil)
+	if value != "" {
+		req.Header.Set(header, value)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestHeaderMiddleware(t *testing.T) {
+	r := setupRouter(HeaderMiddleware())
+
+	t.Run("valid header", func(t *testing.T) {
+		w := serve(r, Header, "acme")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "acme", w.Body.String())
+	})
+
+	t.Run("missing header", func(t *testing.T) {
+		w := serve(r, Header, "")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `"field":"X-Tenant-ID"`)
+	})
+
+	t.Run("invalid header", func(t *testing.T) {
+		w := serve(r, Header, "Acme Corp")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestAPIKeyMiddleware(t *testing.T) {
+	r := setupRouter(APIKeyMiddleware(map[string]string{"key-a": "acme"}))
+
+	t.Run("known key", func(t *testing.T) {
+		w := serve(r, APIKeyHeader, "key-a")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "acme", w.Body.String())
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		w := serve(r, APIKeyHeader, "key-b")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), `"code":"UNAUTHORIZED"`)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		w := serve(r, APIKeyHeader, "")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestParseAPIKeys(t *testing.T) {
+	keys, err := ParseAPIKeys(" key-a=acme, key-b = acme ,key-c=globex,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"key-a": "acme", "key-b": "acme", "key-c": "globex"}, keys)
+
+	tests := []struct {
+		name string
+		keys string
+		err  string
+	}{
+		{"empty", " , ", "no API keys"},
+		{"missing tenant", "key-a", "must be <api key>=<tenant ID>"},
+		{"missing key", "=acme", "must be <api key>=<tenant ID>"},
+		{"invalid tenant", "key-a=Acme", "lowercase"},
+		{"duplicate key", "key-a=acme,key-a=globex", "duplicate API key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseAPIKeys(tt.keys)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Package tenant isolates teams, users and pull requests of organizations sharing one deployment.
+//
+// The tenant of a request is resolved by a middleware (see HeaderMiddleware and APIKeyMiddleware) and carried in the
+// request context. Repositories restrict queries on tenant-owned tables with Scope and stamp new rows
+// with ID. A context without a tenant is not restricted: this is the single-tenant mode and the mode
+// of background jobs, which process all tenants.
+package tenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"gorm.io/gorm"
+)
+
+// Default is the tenant of rows created without a tenant, including all rows created before
+// multi-tenancy was enabled.
+const Default = "default"
+
+// ErrIDUnavailable indicates that a team_name, user_id or pull_request_id is taken by another tenant.
+// These IDs are unique across the deployment; the error does not tell what the ID is used for.
+var ErrIDUnavailable = errors.New("id is not available")
+
+// MaxIDLength is the maximum length of a tenant ID.
+const MaxIDLength = 63
+
+var idPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+type contextKey struct{}
+
+// WithID returns a context carrying the tenant ID.
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the tenant ID of the context, if any.
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(contextKey{}).(string)
+	return id, ok
+}
+
+// ID returns the tenant ID of the context, or Default if the context has none.
+func ID(ctx context.Context) string {
+	if id, ok := FromContext(ctx); ok {
+		return id
+	}
+	return Default
+}
+
+// ValidateID checks that id is a valid tenant ID: lowercase letters, digits, "-" and "_",
+// starting with a letter or digit.
+func ValidateID(id string) error {
+	if id == "" {
+		return fmt.Errorf("tenant ID must not be empty")
+	}
+	if len(id) > MaxIDLength {
+		return fmt.Errorf("tenant ID must be at most %d characters", MaxIDLength)
+	}
+	if !idPattern.MatchString(id) {
+		return fmt.Errorf("tenant ID must contain only lowercase letters, digits, '-' and '_'")
+	}
+	return nil
+}
+
+// Scope returns a GORM scope restricting rows of table (teams, users or pull_requests) to the tenant
+// of ctx. Without a tenant in ctx the query is not restricted.
+func Scope(ctx context.Context, table string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		id, ok := FromContext(ctx)
+		if !ok {
+			return db
+		}
+		return db.Where(table+".tenant_id = ?", id)
+	}
+}
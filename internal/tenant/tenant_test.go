@@ -0,0 +1,81 @@
+package tenant
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestID(t *testing.T) {
+	t.Run("context without tenant", func(t *testing.T) {
+		_, ok := FromContext(context.Background())
+		assert.False(t, ok)
+		assert.Equal(t, Default, ID(context.Background()))
+	})
+
+	t.Run("context with tenant", func(t *testing.T) {
+		ctx := WithID(context.Background(), "acme")
+		id, ok := FromContext(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, "acme", id)
+		assert.Equal(t, "acme", ID(ctx))
+	})
+}
+
+func TestValidateID(t *testing.T) {
+	for _, id := range []string{"acme", "default", "team-42", "a_b", "0"} {
+		assert.NoError(t, ValidateID(id), id)
+	}
+
+	tests := []struct {
+		name string
+		id   string
+		err  string
+	}{
+		{"empty", "", "must not be empty"},
+		{"too long", strings.Repeat("a", MaxIDLength+1), "at most"},
+		{"uppercase", "Acme", "lowercase"},
+		{"leading dash", "-acme", "lowercase"},
+		{"space", "ac me", "lowercase"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorContains(t, ValidateID(tt.id), tt.err)
+		})
+	}
+}
+
+type testTeam struct {
+	TeamName string `gorm:"primaryKey;column:team_name"`
+	TenantID string `gorm:"column:tenant_id"`
+}
+
+func (testTeam) TableName() string {
+	return "teams"
+}
+
+func TestScope(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&testTeam{}))
+	require.NoError(t, db.Create([]testTeam{
+		{TeamName: "backend", TenantID: "acme"},
+		{TeamName: "payments", TenantID: "globex"},
+	}).Error)
+
+	names := func(ctx context.Context) []string {
+		var names []string
+		require.NoError(t, db.Model(&testTeam{}).Scopes(Scope(ctx, "teams")).
+			Order("team_name").Pluck("team_name", &names).Error)
+		return names
+	}
+
+	assert.Equal(t, []string{"backend"}, names(WithID(context.Background(), "acme")))
+	assert.Empty(t, names(WithID(context.Background(), "initech")))
+	assert.Equal(t, []string{"backend", "payments"}, names(context.Background()))
+}
@@ -0,0 +1,254 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	pullrequestModel "github.com/festy23/avito_internship/internal/pullrequest/model"
+	pullrequestRepository "github.com/festy23/avito_internship/internal/pullrequest/repository"
+	teamRepository "github.com/festy23/avito_internship/internal/team/repository"
+)
+
+var prSequence atomic.Int64
+
+// Team is a team created by TeamBuilder.
+type Team struct {
+	Name string
+	// MemberIDs lists all members in creation order; active members come first.
+	MemberIDs   []string
+	ActiveIDs   []string
+	InactiveIDs []string
+}
+
+// TeamBuilder builds a team with members.
+type TeamBuilder struct {
+	name      string
+	prefix    string
+	memberIDs []string
+	inactive  int
+	lead      string
+	sla       [2]*int
+	noClose   bool
+}
+
+// NewTeam starts building team "backend" without members.
+func NewTeam() *TeamBuilder {
+	return &TeamBuilder{name: "backend", prefix: "u"}
+}
+
+// Named sets the team name.
+func (b *TeamBuilder) Named(name string) *TeamBuilder {
+	b.name = name
+	return b
+}
+
+// WithMembers adds n members with IDs <prefix>1..<prefix>n (u1..un by default).
+func (b *TeamBuilder) WithMembers(n int) *TeamBuilder {
+	for i := 1; i <= n; i++ {
+		b.memberIDs = append(b.memberIDs, fmt.Sprintf("%s%d", b.prefix, i))
+	}
+	return b
+}
+
+// WithMemberPrefix sets the prefix of generated member IDs; call it before WithMembers.
+func (b *TeamBuilder) WithMemberPrefix(prefix string) *TeamBuilder {
+	b.prefix = prefix
+	return b
+}
+
+// WithMemberIDs adds members with the given IDs.
+func (b *TeamBuilder) WithMemberIDs(ids ...string) *TeamBuilder {
+	b.memberIDs = append(b.memberIDs, ids...)
+	return b
+}
+
+// Inactive marks the last n members as inactive.
+func (b *TeamBuilder) Inactive(n int) *TeamBuilder {
+	b.inactive = n
+	return b
+}
+
+// WithLead designates a member as the team lead.
+func (b *TeamBuilder) WithLead(userID string) *TeamBuilder {
+	b.lead = userID
+	return b
+}
+
+// WithSLA sets the review SLA of the team in hours; 0 leaves the corresponding check disabled.
+func (b *TeamBuilder) WithSLA(firstReviewHours, mergeHours int) *TeamBuilder {
+	for i, hours := range []int{firstReviewHours, mergeHours} {
+		if hours > 0 {
+			b.sla[i] = &hours
+		}
+	}
+	return b
+}
+
+// AutoCloseDisabled opts the team out of closing its inactive pull requests.
+func (b *TeamBuilder) AutoCloseDisabled() *TeamBuilder {
+	b.noClose = true
+	return b
+}
+
+// Create inserts the team and its members through the team repository.
+func (b *TeamBuilder) Create(t testing.TB, db *gorm.DB) *Team {
+	t.Helper()
+	require.LessOrEqual(t, b.inactive, len(b.memberIDs), "more inactive members than members")
+
+	ctx := context.Background()
+	repo := teamRepository.New(db, zap.NewNop().Sugar())
+
+	_, err := repo.Create(ctx, b.name)
+	require.NoError(t, err)
+
+	result := &Team{Name: b.name, MemberIDs: b.memberIDs}
+	activeCount := len(b.memberIDs) - b.inactive
+	for i, id := range b.memberIDs {
+		active := i < activeCount
+		_, err = repo.CreateOrUpdateUser(ctx, b.name, id, id, active)
+		require.NoError(t, err)
+		if active {
+			result.ActiveIDs = append(result.ActiveIDs, id)
+		} else {
+			result.InactiveIDs = append(result.InactiveIDs, id)
+		}
+	}
+
+	if b.lead != "" {
+		require.NoError(t, repo.SetLead(ctx, b.name, &b.lead))
+	}
+	if b.sla[0] != nil || b.sla[1] != nil {
+		require.NoError(t, repo.SetSLA(ctx, b.name, b.sla[0], b.sla[1]))
+	}
+	if b.noClose {
+		require.NoError(t, repo.SetAutoCloseDisabled(ctx, b.name, true))
+	}
+
+	return result
+}
+
+// PRBuilder builds a pull request with reviewers.
+type PRBuilder struct {
+	pr        pullrequestModel.PullRequest
+	reviewers []string
+	mergedAt  *time.Time
+	closed    bool
+	createdAt *time.Time
+}
+
+// NewPR starts building an open pull request with a generated ID (pr-1, pr-2, ...) authored by u1.
+func NewPR() *PRBuilder {
+	id := fmt.Sprintf("pr-%d", prSequence.Add(1))
+	return &PRBuilder{pr: pullrequestModel.PullRequest{
+		PullRequestID:   id,
+		PullRequestName: "Change " + id,
+		AuthorID:        "u1",
+	}}
+}
+
+// WithID sets the pull request ID.
+func (b *PRBuilder) WithID(id string) *PRBuilder {
+	b.pr.PullRequestID = id
+	return b
+}
+
+// Named sets the pull request name.
+func (b *PRBuilder) Named(name string) *PRBuilder {
+	b.pr.PullRequestName = name
+	return b
+}
+
+// ByAuthor sets the author.
+func (b *PRBuilder) ByAuthor(userID string) *PRBuilder {
+	b.pr.AuthorID = userID
+	return b
+}
+
+// WithReviewers assigns reviewers in the given order.
+func (b *PRBuilder) WithReviewers(userIDs ...string) *PRBuilder {
+	b.reviewers = append(b.reviewers, userIDs...)
+	return b
+}
+
+// WithLines sets the change size.
+func (b *PRBuilder) WithLines(added, removed int) *PRBuilder {
+	b.pr.LinesAdded = added
+	b.pr.LinesRemoved = removed
+	return b
+}
+
+// IntoBranch sets the target branch.
+func (b *PRBuilder) IntoBranch(branch string) *PRBuilder {
+	b.pr.TargetBranch = &branch
+	return b
+}
+
+// Assigning creates the pull request in ASSIGNING status.
+func (b *PRBuilder) Assigning() *PRBuilder {
+	b.pr.Status = pullrequestModel.StatusASSIGNING
+	return b
+}
+
+// Merged marks the pull request as merged now.
+func (b *PRBuilder) Merged() *PRBuilder {
+	return b.MergedAt(time.Now())
+}
+
+// MergedAt marks the pull request as merged at the given time.
+func (b *PRBuilder) MergedAt(at time.Time) *PRBuilder {
+	b.mergedAt = &at
+	return b
+}
+
+// Closed marks the pull request as closed without merging, with close reason OTHER.
+func (b *PRBuilder) Closed() *PRBuilder {
+	b.closed = true
+	return b
+}
+
+// CreatedAt overrides the creation time set by the repository.
+func (b *PRBuilder) CreatedAt(at time.Time) *PRBuilder {
+	b.createdAt = &at
+	return b
+}
+
+// Create inserts the pull request and its reviewers through the pull request repository.
+func (b *PRBuilder) Create(t testing.TB, db *gorm.DB) *pullrequestModel.PullRequest {
+	t.Helper()
+
+	ctx := context.Background()
+	repo := pullrequestRepository.New(db, zap.NewNop().Sugar())
+
+	pr := b.pr
+	_, err := repo.Create(ctx, &pr)
+	require.NoError(t, err)
+
+	for _, reviewerID := range b.reviewers {
+		require.NoError(t, repo.AssignReviewer(ctx, pr.PullRequestID, reviewerID))
+	}
+	if b.mergedAt != nil {
+		require.NoError(t, repo.UpdateStatus(ctx, pr.PullRequestID, pullrequestModel.StatusMERGED, b.mergedAt))
+	}
+	if b.closed {
+		require.NoError(t, repo.UpdateStatus(ctx, pr.PullRequestID, pullrequestModel.StatusCLOSED, nil))
+		reason := pullrequestModel.CloseReasonOther
+		require.NoError(t, repo.SetCloseReason(ctx, pr.PullRequestID, &reason, nil))
+	}
+	if b.createdAt != nil {
+		err = db.Model(&pullrequestModel.PullRequest{}).
+			Where("pull_request_id = ?", pr.PullRequestID).
+			Update("created_at", *b.createdAt).Error
+		require.NoError(t, err)
+	}
+
+	created, err := repo.GetByID(ctx, pr.PullRequestID)
+	require.NoError(t, err)
+	return created
+}
@@ -0,0 +1,81 @@
+package testutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	pullrequestModel "github.com/festy23/avito_internship/internal/pullrequest/model"
+	pullrequestRepository "github.com/festy23/avito_internship/internal/pullrequest/repository"
+	teamRepository "github.com/festy23/avito_internship/internal/team/repository"
+	"github.com/festy23/avito_internship/pkg/sortparam"
+)
+
+func TestTeamBuilder(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("members with inactive tail and lead", func(t *testing.T) {
+		db := NewDB(t)
+
+		team := NewTeam().WithMembers(5).Inactive(2).WithLead("u1").Create(t, db)
+
+		assert.Equal(t, "backend", team.Name)
+		assert.Equal(t, []string{"u1", "u2", "u3"}, team.ActiveIDs)
+		assert.Equal(t, []string{"u4", "u5"}, team.InactiveIDs)
+
+		members, err := teamRepository.New(db, zap.NewNop().Sugar()).GetTeamMembers(ctx, "backend", sortparam.Sort{})
+		require.NoError(t, err)
+		require.Len(t, members, 5)
+
+		var lead *string
+		require.NoError(t, db.Table("teams").Select("lead_user_id").Where("team_name = ?", "backend").Scan(&lead).Error)
+		require.NotNil(t, lead)
+		assert.Equal(t, "u1", *lead)
+	})
+
+	t.Run("several teams with distinct member IDs", func(t *testing.T) {
+		db := NewDB(t)
+
+		backend := NewTeam().WithMembers(2).Create(t, db)
+		frontend := NewTeam().Named("frontend").WithMemberPrefix("f").WithMembers(2).Create(t, db)
+
+		assert.Equal(t, []string{"u1", "u2"}, backend.MemberIDs)
+		assert.Equal(t, []string{"f1", "f2"}, frontend.MemberIDs)
+	})
+}
+
+func TestPRBuilder(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("open PR with reviewers", func(t *testing.T) {
+		db := NewDB(t)
+		NewTeam().WithMembers(3).Create(t, db)
+
+		pr := NewPR().WithID("pr-open").ByAuthor("u1").WithReviewers("u2", "u3").WithLines(10, 5).Create(t, db)
+
+		assert.Equal(t, pullrequestModel.StatusOPEN, pr.Status)
+		assert.Equal(t, 10, pr.LinesAdded)
+		reviewers, err := pullrequestRepository.New(db, zap.NewNop().Sugar()).GetReviewers(ctx, "pr-open")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"u2", "u3"}, reviewers)
+	})
+
+	t.Run("merged PR with custom creation time", func(t *testing.T) {
+		db := NewDB(t)
+		created := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+		pr := NewPR().CreatedAt(created).MergedAt(created.Add(time.Hour)).Create(t, db)
+
+		assert.Equal(t, pullrequestModel.StatusMERGED, pr.Status)
+		require.NotNil(t, pr.MergedAt)
+		assert.True(t, pr.CreatedAt.Equal(created))
+	})
+
+	t.Run("generated IDs are unique", func(t *testing.T) {
+		assert.NotEqual(t, NewPR().pr.PullRequestID, NewPR().pr.PullRequestID)
+	})
+}
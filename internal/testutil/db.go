@@ -0,0 +1,143 @@
+// Package testutil provides a shared in-memory database and fluent fixture builders for tests.
+package testutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// SQLite-compatible mirrors of the production tables. The production models use
+// PostgreSQL-specific types and defaults, so they cannot be migrated into SQLite directly.
+type (
+	team struct {
+		TeamName            string    `gorm:"primaryKey;column:team_name"`
+		LeadUserID          *string   `gorm:"column:lead_user_id"`
+		SLAFirstReviewHours *int      `gorm:"column:sla_first_review_hours"`
+		SLAMergeHours       *int      `gorm:"column:sla_merge_hours"`
+		AutoCloseDisabled   bool      `gorm:"column:auto_close_disabled;not null;default:false"`
+		CreatedAt           time.Time `gorm:"column:created_at"`
+		UpdatedAt           time.Time `gorm:"column:updated_at"`
+		TenantID            string    `gorm:"column:tenant_id;not null;default:'default'"`
+	}
+	user struct {
+		UserID    string    `gorm:"primaryKey;column:user_id"`
+		Username  string    `gorm:"column:username"`
+		TeamName  string    `gorm:"column:team_name"`
+		IsActive  bool      `gorm:"column:is_active;not null"`
+		CreatedAt time.Time `gorm:"column:created_at"`
+		UpdatedAt time.Time `gorm:"column:updated_at"`
+		TenantID  string    `gorm:"column:tenant_id;not null;default:'default'"`
+	}
+	pullRequest struct {
+		PullRequestID   string     `gorm:"primaryKey;column:pull_request_id"`
+		PullRequestName string     `gorm:"column:pull_request_name;not null"`
+		AuthorID        string     `gorm:"column:author_id;not null"`
+		Status          string     `gorm:"column:status;not null"`
+		CreatedAt       time.Time  `gorm:"column:created_at"`
+		MergedAt        *time.Time `gorm:"column:merged_at"`
+		ArchivedAt      *time.Time `gorm:"column:archived_at"`
+		SourceBranch    *string    `gorm:"column:source_branch"`
+		TargetBranch    *string    `gorm:"column:target_branch"`
+		PullRequestURL  *string    `gorm:"column:pull_request_url"`
+		LinesAdded      int        `gorm:"column:lines_added;not null;default:0"`
+		LinesRemoved    int        `gorm:"column:lines_removed;not null;default:0"`
+		HasConflicts    bool       `gorm:"column:has_conflicts;not null;default:false"`
+		TeamName        *string    `gorm:"column:team_name"`
+		CloseReason     *string    `gorm:"column:close_reason"`
+		CloseNote       *string    `gorm:"column:close_note"`
+		TenantID        string     `gorm:"column:tenant_id;not null;default:'default'"`
+	}
+	pullRequestReviewer struct {
+		ID            int64     `gorm:"primaryKey;column:id"`
+		PullRequestID string    `gorm:"column:pull_request_id;not null;uniqueIndex:uq_reviewers_pr_user"`
+		UserID        string    `gorm:"column:user_id;not null;uniqueIndex:uq_reviewers_pr_user"`
+		AssignedAt    time.Time `gorm:"column:assigned_at"`
+	}
+	pullRequestWatcher struct {
+		ID            int64     `gorm:"primaryKey;column:id"`
+		PullRequestID string    `gorm:"column:pull_request_id;not null;uniqueIndex:uq_watchers_pr_user"`
+		UserID        string    `gorm:"column:user_id;not null;uniqueIndex:uq_watchers_pr_user"`
+		CreatedAt     time.Time `gorm:"column:created_at"`
+	}
+	pullRequestEvent struct {
+		ID             int64     `gorm:"primaryKey;column:id"`
+		PullRequestID  string    `gorm:"column:pull_request_id;not null"`
+		EventType      string    `gorm:"column:event_type;not null"`
+		UserID         *string   `gorm:"column:user_id"`
+		PreviousUserID *string   `gorm:"column:previous_user_id"`
+		ActorID        *string   `gorm:"column:actor_id"`
+		Reason         *string   `gorm:"column:reason"`
+		Source         *string   `gorm:"column:source"`
+		CreatedAt      time.Time `gorm:"column:created_at"`
+	}
+	pullRequestComment struct {
+		ID            int64     `gorm:"primaryKey;column:id"`
+		PullRequestID string    `gorm:"column:pull_request_id;not null"`
+		UserID        string    `gorm:"column:user_id;not null"`
+		Body          string    `gorm:"column:body;not null"`
+		CreatedAt     time.Time `gorm:"column:created_at"`
+	}
+	slaViolation struct {
+		ID             int64     `gorm:"primaryKey;column:id"`
+		PullRequestID  string    `gorm:"column:pull_request_id;not null;uniqueIndex:uq_sla_violations_pr_kind"`
+		TeamName       string    `gorm:"column:team_name;not null"`
+		Kind           string    `gorm:"column:kind;not null;uniqueIndex:uq_sla_violations_pr_kind"`
+		ThresholdHours int       `gorm:"column:threshold_hours;not null"`
+		DetectedAt     time.Time `gorm:"column:detected_at"`
+	}
+	webhookDeadLetter struct {
+		ID            int64     `gorm:"primaryKey;column:id"`
+		Event         string    `gorm:"column:event;not null"`
+		PullRequestID string    `gorm:"column:pull_request_id;not null"`
+		Payload       string    `gorm:"column:payload;not null"`
+		Attempts      int       `gorm:"column:attempts;not null"`
+		LastError     string    `gorm:"column:last_error;not null"`
+		CreatedAt     time.Time `gorm:"column:created_at"`
+	}
+	activationChange struct {
+		ID          int64     `gorm:"primaryKey;column:id"`
+		UserID      string    `gorm:"column:user_id;not null"`
+		OldIsActive bool      `gorm:"column:old_is_active;not null"`
+		NewIsActive bool      `gorm:"column:new_is_active;not null"`
+		Source      string    `gorm:"column:source;not null"`
+		ActorID     *string   `gorm:"column:actor_id"`
+		Reason      *string   `gorm:"column:reason"`
+		TenantID    string    `gorm:"column:tenant_id;not null;default:'default'"`
+		CreatedAt   time.Time `gorm:"column:created_at"`
+	}
+)
+
+func (team) TableName() string                { return "teams" }
+func (user) TableName() string                { return "users" }
+func (pullRequest) TableName() string         { return "pull_requests" }
+func (pullRequestReviewer) TableName() string { return "pull_request_reviewers" }
+func (pullRequestWatcher) TableName() string  { return "pull_request_watchers" }
+func (pullRequestEvent) TableName() string    { return "pull_request_events" }
+func (pullRequestComment) TableName() string  { return "pull_request_comments" }
+func (slaViolation) TableName() string        { return "sla_violations" }
+func (webhookDeadLetter) TableName() string   { return "webhook_dead_letters" }
+func (activationChange) TableName() string    { return "user_activation_history" }
+
+// NewDB opens an in-memory SQLite database with the teams, users, user activation history, pull request,
+// comment, SLA violation and webhook dead letter tables.
+func NewDB(t testing.TB) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	err = db.AutoMigrate(
+		&team{}, &user{}, &pullRequest{}, &pullRequestReviewer{}, &pullRequestWatcher{}, &pullRequestEvent{},
+		&pullRequestComment{}, &slaViolation{}, &webhookDeadLetter{}, &activationChange{},
+	)
+	require.NoError(t, err)
+
+	return db
+}
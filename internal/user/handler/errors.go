@@ -0,0 +1,22 @@
+package handler
+
+import (
+	"github.com/festy23/avito_internship/internal/database/dberror"
+	teamModel "github.com/festy23/avito_internship/internal/team/model"
+	"github.com/festy23/avito_internship/internal/user/model"
+	"github.com/festy23/avito_internship/pkg/apierror"
+	"github.com/festy23/avito_internship/pkg/cursor"
+	"github.com/festy23/avito_internship/pkg/pagination"
+	"github.com/festy23/avito_internship/pkg/sortparam"
+)
+
+// errorRegistry maps errors of the user endpoints.
+var errorRegistry = apierror.Registry{}.
+	Register(model.ErrUserNotFound, apierror.NotFound("user not found")).
+	Register(teamModel.ErrTeamNotFound, apierror.NotFound("team not found")).
+	Register(model.ErrInvalidSearchQuery, apierror.InvalidField("q", "length", model.ErrInvalidSearchQuery.Error())).
+	Register(pagination.ErrInvalidLimit, apierror.InvalidField("limit", "range", "")).
+	Register(sortparam.ErrInvalidField, apierror.InvalidField("sort", "enum", "")).
+	Register(sortparam.ErrInvalidOrder, apierror.InvalidField("order", "enum", "")).
+	Register(cursor.ErrInvalid, apierror.InvalidField("cursor", "format", "invalid cursor or changed filters")).
+	RegisterFunc(dberror.IsTransient, apierror.ConcurrentUpdate())
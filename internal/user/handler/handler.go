@@ -4,29 +4,38 @@ package handler
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
-	"go.uber.org/zap"
 
-	teamModel "github.com/festy23/avito_internship/internal/team/model"
 	"github.com/festy23/avito_internship/internal/user/model"
 	"github.com/festy23/avito_internship/internal/user/service"
+	"github.com/festy23/avito_internship/pkg/apierror"
+	"github.com/festy23/avito_internship/pkg/bind"
+	"github.com/festy23/avito_internship/pkg/cursor"
 )
 
 // Handler handles HTTP requests for user endpoints.
 type Handler struct {
 	service service.Service
-	logger  *zap.SugaredLogger
+	cursors *cursor.Codec
 }
 
-// New creates a new user handler instance.
-func New(svc service.Service, logger *zap.SugaredLogger) *Handler {
-	return &Handler{service: svc, logger: logger}
+// New creates a new user handler instance. Its pagination cursors are valid only within this process.
+func New(svc service.Service) *Handler {
+	return NewWithCursors(svc, cursor.New(""))
+}
+
+// NewWithCursors creates a new user handler instance signing pagination cursors with cursors.
+func NewWithCursors(svc service.Service, cursors *cursor.Codec) *Handler {
+	return &Handler{service: svc, cursors: cursors}
 }
 
 // SetIsActive handles POST /users/setIsActive request.
+// With reassign_open_reviews, a deactivated user is replaced in their open reviews atomically.
 // @Summary Set user activity status
 // @Tags Users
 // @Accept json
@@ -39,36 +48,36 @@ func (h *Handler) SetIsActive(c *gin.Context) {
 	// Read raw body to validate required field presence
 	body, err := io.ReadAll(c.Request.Body)
 	if err != nil {
-		errorResponse(c, "INVALID_REQUEST", "failed to read request body", http.StatusBadRequest)
+		apierror.Fail(c, apierror.InvalidRequest("failed to read request body"))
 		return
 	}
 
 	// Validate that is_active field is present in JSON (required by OpenAPI spec)
 	var rawData map[string]interface{}
 	if err = json.Unmarshal(body, &rawData); err != nil {
-		errorResponse(c, "INVALID_REQUEST", "invalid JSON format", http.StatusBadRequest)
+		apierror.Fail(c, apierror.InvalidRequest("invalid JSON format"))
 		return
 	}
 
 	if _, exists := rawData["is_active"]; !exists {
-		errorResponse(c, "INVALID_REQUEST", "is_active field is required", http.StatusBadRequest)
+		apierror.Fail(c, apierror.InvalidField("is_active", "required", "is_active field is required"))
 		return
 	}
 
 	// Parse into struct
 	var req model.SetIsActiveRequest
 	if err = json.Unmarshal(body, &req); err != nil {
-		errorResponse(c, "INVALID_REQUEST", "invalid request body", http.StatusBadRequest)
+		apierror.Fail(c, apierror.Binding(err))
+		return
+	}
+	if err = bind.Struct(&req); err != nil {
+		apierror.Fail(c, err)
 		return
 	}
 
 	resp, err := h.service.SetIsActive(c.Request.Context(), &req)
 	if err != nil {
-		if errors.Is(err, model.ErrUserNotFound) {
-			notFoundResponse(c, "user not found")
-			return
-		}
-		errorResponse(c, "INTERNAL_ERROR", "internal server error", http.StatusInternalServerError)
+		errorRegistry.Fail(c, err)
 		return
 	}
 
@@ -77,21 +86,33 @@ func (h *Handler) SetIsActive(c *gin.Context) {
 
 // GetReview handles GET /users/getReview request.
 // Returns 200 with empty list for nonexistent users rather than 404.
+// With limit, PRs are returned in pages: next_cursor of a page is passed as cursor to get the next one.
 // @Summary Get PRs assigned to user
 // @Tags Users
 // @Produce json
 // @Param user_id query string true "User ID"
+// @Param archived query bool false "Return archived PRs instead of active ones"
+// @Param sort query string false "Sort field" Enums(created_at)
+// @Param order query string false "Sort order, desc by default" Enums(asc, desc)
+// @Param limit query int false "Page size (up to PAGINATION_MAX_LIMIT), all PRs when omitted"
+// @Param cursor query string false "next_cursor of the previous page, with the same filters"
 // @Success 200 {object} model.GetReviewResponse
 // @Failure 400 {object} ErrorResponse
 // @Router /users/getReview [get] //nolint:godot // Swagger annotation should not end with period
 func (h *Handler) GetReview(c *gin.Context) {
 	userID := c.Query("user_id")
 	if userID == "" {
-		errorResponse(c, "INVALID_REQUEST", "user_id parameter is required", http.StatusBadRequest)
+		apierror.Fail(c, apierror.InvalidField("user_id", "required", "user_id parameter is required"))
 		return
 	}
 
-	resp, err := h.service.GetReview(c.Request.Context(), userID)
+	query, err := h.parseReviewQuery(c, userID)
+	if err != nil {
+		errorRegistry.Fail(c, err)
+		return
+	}
+
+	resp, err := h.service.GetReview(c.Request.Context(), userID, query)
 	if err != nil {
 		if errors.Is(err, model.ErrUserNotFound) {
 			c.JSON(http.StatusOK, &model.GetReviewResponse{
@@ -100,14 +121,89 @@ func (h *Handler) GetReview(c *gin.Context) {
 			})
 			return
 		}
-		h.logger.Errorw("error getting review for user", "user_id", userID, "error", err)
-		errorResponse(c, "INTERNAL_ERROR", "internal server error", http.StatusInternalServerError)
+		errorRegistry.Fail(c, err)
 		return
 	}
 
+	if resp.Next != nil {
+		resp.NextCursor, err = h.cursors.Encode(reviewCursorScope(userID, query), resp.Next)
+		if err != nil {
+			errorRegistry.Fail(c, err)
+			return
+		}
+	}
+
 	c.JSON(http.StatusOK, resp)
 }
 
+// WaitReview handles GET /users/getReview/wait request.
+// Long polling for clients that cannot use server-sent events: the request is held until the active PRs
+// assigned to the user differ from the set identified by version, or until USER_REVIEW_WAIT_TIMEOUT
+// elapses. Without version the current set is returned immediately; the version of a response is passed
+// to the next wait. Like GetReview, nonexistent users get an empty list.
+// @Summary Wait for changes of PRs assigned to user
+// @Tags Users
+// @Produce json
+// @Param user_id query string true "User ID"
+// @Param version query string false "version of the previous response"
+// @Success 200 {object} model.WaitReviewResponse "changed is false when the wait timed out"
+// @Failure 400 {object} ErrorResponse
+// @Router /users/getReview/wait [get] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) WaitReview(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		apierror.Fail(c, apierror.InvalidField("user_id", "required", "user_id parameter is required"))
+		return
+	}
+
+	resp, err := h.service.WaitReview(c.Request.Context(), userID, c.Query("version"))
+	if err != nil {
+		errorRegistry.Fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// parseReviewQuery parses the query parameters of GetReview.
+func (h *Handler) parseReviewQuery(c *gin.Context, userID string) (model.ReviewQuery, error) {
+	var query model.ReviewQuery
+	if raw := c.Query("archived"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return query, apierror.InvalidField("archived", "type", "archived must be a boolean")
+		}
+		query.Archived = parsed
+	}
+
+	srt, err := model.ReviewSort.Parse(c.Query("sort"), c.Query("order"))
+	if err != nil {
+		return query, err
+	}
+	query.Sort = srt
+
+	if raw := c.Query("limit"); raw != "" {
+		if query.Limit, err = strconv.Atoi(raw); err != nil {
+			return query, apierror.InvalidField("limit", "type", "limit must be an integer")
+		}
+	}
+
+	if token := c.Query("cursor"); token != "" {
+		var after model.ReviewPosition
+		if err = h.cursors.Decode(reviewCursorScope(userID, query), token, &after); err != nil {
+			return query, err
+		}
+		query.After = &after
+	}
+	return query, nil
+}
+
+// reviewCursorScope binds GetReview cursors to the user and the filters of the listing.
+// The page size is not part of the scope, so clients may change it between pages.
+func reviewCursorScope(userID string, query model.ReviewQuery) string {
+	return fmt.Sprintf("getReview %q %t %q", userID, query.Archived, model.ReviewSort.OrderBy(query.Sort))
+}
+
 // BulkDeactivateTeamMembers handles POST /users/bulkDeactivate request.
 // @Summary Bulk deactivate team members and safely reassign open PRs
 // @Tags Users
@@ -121,23 +217,113 @@ func (h *Handler) GetReview(c *gin.Context) {
 // @Router /users/bulkDeactivate [post] //nolint:godot // Swagger annotation should not end with period
 func (h *Handler) BulkDeactivateTeamMembers(c *gin.Context) {
 	var req model.BulkDeactivateTeamRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errorResponse(c, "INVALID_REQUEST", "invalid request body", http.StatusBadRequest)
+	if !bind.JSON(c, &req) {
 		return
 	}
 
 	resp, err := h.service.BulkDeactivateTeamMembers(c.Request.Context(), &req)
 	if err != nil {
-		if errors.Is(err, teamModel.ErrTeamNotFound) {
-			notFoundResponse(c, "team not found")
+		errorRegistry.Fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// AnonymizeUser handles POST /admin/anonymizeUser request.
+// @Summary Anonymize a user, keeping their history and statistics
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body model.AnonymizeUserRequest true "Request"
+// @Success 200 {object} model.AnonymizeUserResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/anonymizeUser [post] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) AnonymizeUser(c *gin.Context) {
+	var req model.AnonymizeUserRequest
+	if !bind.JSON(c, &req) {
+		return
+	}
+
+	resp, err := h.service.AnonymizeUser(c.Request.Context(), &req)
+	if err != nil {
+		errorRegistry.Fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// SearchUsers handles GET /users/search request.
+// @Summary Search users by user_id or username fragment
+// @Tags Users
+// @Produce json
+// @Param q query string true "Search query"
+// @Param limit query int false "Maximum number of users (default PAGINATION_DEFAULT_LIMIT, max PAGINATION_MAX_LIMIT)"
+// @Success 200 {object} model.SearchUsersResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /users/search [get] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) SearchUsers(c *gin.Context) {
+	query := c.Query("q")
+	if query == "" {
+		apierror.Fail(c, apierror.InvalidField("q", "required", "q parameter is required"))
+		return
+	}
+
+	limit := 0
+	if rawLimit := c.Query("limit"); rawLimit != "" {
+		parsed, err := strconv.Atoi(rawLimit)
+		if err != nil {
+			apierror.Fail(c, apierror.InvalidField("limit", "type", "limit must be an integer"))
 			return
 		}
-		if errors.Is(err, model.ErrUserNotFound) {
-			notFoundResponse(c, "user not found")
+		limit = parsed
+	}
+
+	resp, err := h.service.SearchUsers(c.Request.Context(), query, limit)
+	if err != nil {
+		errorRegistry.Fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetActivationHistory handles GET /users/activationHistory request.
+// @Summary Get is_active changes of a user, newest first
+// @Tags Users
+// @Produce json
+// @Param user_id query string true "User ID"
+// @Param limit query int false "Maximum number of changes (default PAGINATION_DEFAULT_LIMIT, max PAGINATION_MAX_LIMIT)"
+// @Success 200 {object} model.ActivationHistoryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /users/activationHistory [get] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) GetActivationHistory(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		apierror.Fail(c, apierror.InvalidField("user_id", "required", "user_id parameter is required"))
+		return
+	}
+
+	limit := 0
+	if rawLimit := c.Query("limit"); rawLimit != "" {
+		parsed, err := strconv.Atoi(rawLimit)
+		if err != nil {
+			apierror.Fail(c, apierror.InvalidField("limit", "type", "limit must be an integer"))
 			return
 		}
-		h.logger.Errorw("error bulk deactivating team members", "team_name", req.TeamName, "error", err)
-		errorResponse(c, "INTERNAL_ERROR", "internal server error", http.StatusInternalServerError)
+		limit = parsed
+	}
+
+	resp, err := h.service.GetActivationHistory(c.Request.Context(), userID, limit)
+	if err != nil {
+		errorRegistry.Fail(c, err)
 		return
 	}
 
@@ -9,15 +9,17 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
-	"go.uber.org/zap"
 
 	"github.com/festy23/avito_internship/internal/user/model"
 	"github.com/festy23/avito_internship/internal/user/service"
+	"github.com/festy23/avito_internship/pkg/pagination"
+	"github.com/festy23/avito_internship/pkg/sortparam"
 )
 
 type mockService struct {
@@ -35,14 +37,26 @@ func (m *mockService) SetIsActive(
 	return args.Get(0).(*model.SetIsActiveResponse), args.Error(1)
 }
 
-func (m *mockService) GetReview(ctx context.Context, userID string) (*model.GetReviewResponse, error) {
-	args := m.Called(ctx, userID)
+func (m *mockService) GetReview(
+	ctx context.Context,
+	userID string,
+	query model.ReviewQuery,
+) (*model.GetReviewResponse, error) {
+	args := m.Called(ctx, userID, query)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.GetReviewResponse), args.Error(1)
 }
 
+func (m *mockService) WaitReview(ctx context.Context, userID, version string) (*model.WaitReviewResponse, error) {
+	args := m.Called(ctx, userID, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.WaitReviewResponse), args.Error(1)
+}
+
 func (m *mockService) BulkDeactivateTeamMembers(
 	ctx context.Context,
 	req *model.BulkDeactivateTeamRequest,
@@ -54,6 +68,37 @@ func (m *mockService) BulkDeactivateTeamMembers(
 	return args.Get(0).(*model.BulkDeactivateTeamResponse), args.Error(1)
 }
 
+func (m *mockService) SearchUsers(ctx context.Context, query string, limit int) (*model.SearchUsersResponse, error) {
+	args := m.Called(ctx, query, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SearchUsersResponse), args.Error(1)
+}
+
+func (m *mockService) AnonymizeUser(
+	ctx context.Context,
+	req *model.AnonymizeUserRequest,
+) (*model.AnonymizeUserResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.AnonymizeUserResponse), args.Error(1)
+}
+
+func (m *mockService) GetActivationHistory(
+	ctx context.Context,
+	userID string,
+	limit int,
+) (*model.ActivationHistoryResponse, error) {
+	args := m.Called(ctx, userID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ActivationHistoryResponse), args.Error(1)
+}
+
 var _ service.Service = (*mockService)(nil)
 
 func setupRouter() *gin.Engine {
@@ -64,7 +109,7 @@ func setupRouter() *gin.Engine {
 func TestHandler_SetIsActive(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/users/setIsActive", handler.SetIsActive)
 
@@ -100,9 +145,36 @@ func TestHandler_SetIsActive(t *testing.T) {
 		mockSvc.AssertExpectations(t)
 	})
 
+	t.Run("success with reassignment", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.POST("/users/setIsActive", handler.SetIsActive)
+
+		reqBody := model.SetIsActiveRequest{UserID: "u1", IsActive: false, ReassignOpenReviews: true}
+		expectedResp := &model.SetIsActiveResponse{
+			User:          model.User{UserID: "u1", Username: "Alice", TeamName: "team1"},
+			ReassignedPRs: []string{"pr-1", "pr-2"},
+		}
+		mockSvc.On("SetIsActive", mock.Anything, &reqBody).Return(expectedResp, nil)
+
+		body := `{"user_id":"u1","is_active":false,"reassign_open_reviews":true}`
+		req := httptest.NewRequest(http.MethodPost, "/users/setIsActive", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp model.SetIsActiveResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, []string{"pr-1", "pr-2"}, resp.ReassignedPRs)
+		mockSvc.AssertExpectations(t)
+	})
+
 	t.Run("invalid request body", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/users/setIsActive", handler.SetIsActive)
 
@@ -122,7 +194,7 @@ func TestHandler_SetIsActive(t *testing.T) {
 
 	t.Run("user not found", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/users/setIsActive", handler.SetIsActive)
 
@@ -150,7 +222,7 @@ func TestHandler_SetIsActive(t *testing.T) {
 
 	t.Run("missing is_active field", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/users/setIsActive", handler.SetIsActive)
 
@@ -176,7 +248,7 @@ func TestHandler_SetIsActive(t *testing.T) {
 func TestHandler_GetReview(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.GET("/users/getReview", handler.GetReview)
 
@@ -192,7 +264,7 @@ func TestHandler_GetReview(t *testing.T) {
 			},
 		}
 
-		mockSvc.On("GetReview", mock.Anything, "u1").Return(expectedResp, nil)
+		mockSvc.On("GetReview", mock.Anything, "u1", model.ReviewQuery{}).Return(expectedResp, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/users/getReview?user_id=u1", nil)
 		w := httptest.NewRecorder()
@@ -211,7 +283,7 @@ func TestHandler_GetReview(t *testing.T) {
 
 	t.Run("missing user_id parameter", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.GET("/users/getReview", handler.GetReview)
 
@@ -228,9 +300,80 @@ func TestHandler_GetReview(t *testing.T) {
 		mockSvc.AssertNotCalled(t, "GetReview")
 	})
 
+	t.Run("archived flag", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.GET("/users/getReview", handler.GetReview)
+
+		expectedResp := &model.GetReviewResponse{UserID: "u1", PullRequests: []model.PullRequestShort{}}
+		mockSvc.On("GetReview", mock.Anything, "u1", model.ReviewQuery{Archived: true}).Return(expectedResp, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/getReview?user_id=u1&archived=true", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("sort options", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.GET("/users/getReview", handler.GetReview)
+
+		expectedResp := &model.GetReviewResponse{UserID: "u1", PullRequests: []model.PullRequestShort{}}
+		query := model.ReviewQuery{Sort: sortparam.Sort{Field: "created_at", Order: sortparam.Asc}}
+		mockSvc.On("GetReview", mock.Anything, "u1", query).Return(expectedResp, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/getReview?user_id=u1&sort=created_at&order=asc", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("unsupported sort field", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.GET("/users/getReview", handler.GetReview)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/getReview?user_id=u1&sort=due_at", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var resp ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "INVALID_REQUEST", resp.Error.Code)
+		assert.Contains(t, resp.Error.Message, "supported: created_at")
+		mockSvc.AssertNotCalled(t, "GetReview")
+	})
+
+	t.Run("invalid archived flag", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.GET("/users/getReview", handler.GetReview)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/getReview?user_id=u1&archived=maybe", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockSvc.AssertNotCalled(t, "GetReview")
+	})
+
 	t.Run("user not found returns empty list", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.GET("/users/getReview", handler.GetReview)
 
@@ -239,7 +382,7 @@ func TestHandler_GetReview(t *testing.T) {
 			PullRequests: []model.PullRequestShort{},
 		}
 
-		mockSvc.On("GetReview", mock.Anything, "nonexistent").Return(expectedResp, nil)
+		mockSvc.On("GetReview", mock.Anything, "nonexistent", model.ReviewQuery{}).Return(expectedResp, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/users/getReview?user_id=nonexistent", nil)
 		w := httptest.NewRecorder()
@@ -257,7 +400,7 @@ func TestHandler_GetReview(t *testing.T) {
 
 	t.Run("empty list", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.GET("/users/getReview", handler.GetReview)
 
@@ -266,7 +409,7 @@ func TestHandler_GetReview(t *testing.T) {
 			PullRequests: []model.PullRequestShort{},
 		}
 
-		mockSvc.On("GetReview", mock.Anything, "u1").Return(expectedResp, nil)
+		mockSvc.On("GetReview", mock.Anything, "u1", model.ReviewQuery{}).Return(expectedResp, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/users/getReview?user_id=u1", nil)
 		w := httptest.NewRecorder()
@@ -281,12 +424,92 @@ func TestHandler_GetReview(t *testing.T) {
 		assert.Empty(t, resp.PullRequests)
 		mockSvc.AssertExpectations(t)
 	})
+
+	t.Run("next_cursor resumes the listing", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.GET("/users/getReview", handler.GetReview)
+
+		next := &model.ReviewPosition{CreatedAt: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC), PullRequestID: "pr-2"}
+		first := &model.GetReviewResponse{UserID: "u1", PullRequests: []model.PullRequestShort{}, Next: next}
+		mockSvc.On("GetReview", mock.Anything, "u1", model.ReviewQuery{Limit: 2}).Return(first, nil)
+		last := &model.GetReviewResponse{UserID: "u1", PullRequests: []model.PullRequestShort{}}
+		mockSvc.On("GetReview", mock.Anything, "u1", model.ReviewQuery{Limit: 5, After: next}).Return(last, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/getReview?user_id=u1&limit=2", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp model.GetReviewResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.NotEmpty(t, resp.NextCursor)
+
+		// The page size may change between pages
+		w = httptest.NewRecorder()
+		target := "/users/getReview?user_id=u1&limit=5&cursor=" + url.QueryEscape(resp.NextCursor)
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "next_cursor")
+
+		// The cursor is bound to the filters it was issued for
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target+"&archived=true", nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("invalid query parameters", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			query string
+			field string
+		}{
+			{name: "non-integer limit", query: "limit=ten", field: "limit"},
+			{
+				name:  "tampered cursor",
+				query: "cursor=eyJ0IjoiMjAyNS0wNi0wMVQxMjowMDowMFoiLCJpZCI6InByLTIifQ.AAAA",
+				field: "cursor",
+			},
+			{name: "malformed cursor", query: "cursor=not-a-cursor", field: "cursor"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				mockSvc := new(mockService)
+				handler := New(mockSvc)
+				router := setupRouter()
+				router.GET("/users/getReview", handler.GetReview)
+
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/getReview?user_id=u1&"+tt.query, nil))
+
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+				assert.Contains(t, w.Body.String(), `"field":"`+tt.field+`"`)
+				mockSvc.AssertNotCalled(t, "GetReview")
+			})
+		}
+	})
+
+	t.Run("limit out of range", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.GET("/users/getReview", handler.GetReview)
+
+		mockSvc.On("GetReview", mock.Anything, "u1", model.ReviewQuery{Limit: 500}).
+			Return(nil, pagination.ErrInvalidLimit)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/getReview?user_id=u1&limit=500", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `"field":"limit"`)
+	})
 }
 
 func TestHandler_EdgeCases(t *testing.T) {
 	t.Run("user_id with special characters", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.GET("/users/getReview", handler.GetReview)
 
@@ -297,7 +520,7 @@ func TestHandler_EdgeCases(t *testing.T) {
 			PullRequests: []model.PullRequestShort{},
 		}
 
-		mockSvc.On("GetReview", mock.Anything, specialUserID).Return(expectedResp, nil)
+		mockSvc.On("GetReview", mock.Anything, specialUserID, model.ReviewQuery{}).Return(expectedResp, nil)
 
 		reqURL := "/users/getReview?user_id=" + url.QueryEscape(specialUserID)
 		req := httptest.NewRequest(http.MethodGet, reqURL, nil)
@@ -314,7 +537,7 @@ func TestHandler_EdgeCases(t *testing.T) {
 
 	t.Run("internal server error in SetIsActive", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/users/setIsActive", handler.SetIsActive)
 
@@ -343,7 +566,7 @@ func TestHandler_EdgeCases(t *testing.T) {
 
 	t.Run("setting user to active", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/users/setIsActive", handler.SetIsActive)
 
@@ -380,7 +603,7 @@ func TestHandler_EdgeCases(t *testing.T) {
 	})
 
 	t.Run("malformed JSON in SetIsActive", func(t *testing.T) {
-		handler := New(new(mockService), zap.NewNop().Sugar())
+		handler := New(new(mockService))
 		router := setupRouter()
 		router.POST("/users/setIsActive", handler.SetIsActive)
 
@@ -396,7 +619,7 @@ func TestHandler_EdgeCases(t *testing.T) {
 	})
 
 	t.Run("empty request body in SetIsActive", func(t *testing.T) {
-		handler := New(new(mockService), zap.NewNop().Sugar())
+		handler := New(new(mockService))
 		router := setupRouter()
 		router.POST("/users/setIsActive", handler.SetIsActive)
 
@@ -411,11 +634,11 @@ func TestHandler_EdgeCases(t *testing.T) {
 
 	t.Run("user not found - returns empty list", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.GET("/users/getReview", handler.GetReview)
 
-		mockSvc.On("GetReview", mock.Anything, "nonexistent-user").
+		mockSvc.On("GetReview", mock.Anything, "nonexistent-user", model.ReviewQuery{}).
 			Return(nil, model.ErrUserNotFound)
 
 		req := httptest.NewRequest(http.MethodGet, "/users/getReview?user_id=nonexistent-user", nil)
@@ -435,11 +658,11 @@ func TestHandler_EdgeCases(t *testing.T) {
 
 	t.Run("internal server error in GetReview", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.GET("/users/getReview", handler.GetReview)
 
-		mockSvc.On("GetReview", mock.Anything, "u1").
+		mockSvc.On("GetReview", mock.Anything, "u1", model.ReviewQuery{}).
 			Return(nil, errors.New("database query timeout"))
 
 		req := httptest.NewRequest(http.MethodGet, "/users/getReview?user_id=u1", nil)
@@ -457,7 +680,7 @@ func TestHandler_EdgeCases(t *testing.T) {
 
 	t.Run("user with many assigned PRs", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.GET("/users/getReview", handler.GetReview)
 
@@ -476,7 +699,7 @@ func TestHandler_EdgeCases(t *testing.T) {
 			PullRequests: prs,
 		}
 
-		mockSvc.On("GetReview", mock.Anything, "u1").Return(expectedResp, nil)
+		mockSvc.On("GetReview", mock.Anything, "u1", model.ReviewQuery{}).Return(expectedResp, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/users/getReview?user_id=u1", nil)
 		w := httptest.NewRecorder()
@@ -494,7 +717,7 @@ func TestHandler_EdgeCases(t *testing.T) {
 
 	t.Run("special characters in user_id", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.GET("/users/getReview", handler.GetReview)
 
@@ -504,7 +727,7 @@ func TestHandler_EdgeCases(t *testing.T) {
 			PullRequests: []model.PullRequestShort{},
 		}
 
-		mockSvc.On("GetReview", mock.Anything, userID).Return(expectedResp, nil)
+		mockSvc.On("GetReview", mock.Anything, userID, model.ReviewQuery{}).Return(expectedResp, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/users/getReview?user_id="+userID, nil)
 		w := httptest.NewRecorder()
@@ -517,7 +740,7 @@ func TestHandler_EdgeCases(t *testing.T) {
 
 	t.Run("URL encoded user_id", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.GET("/users/getReview", handler.GetReview)
 
@@ -527,7 +750,7 @@ func TestHandler_EdgeCases(t *testing.T) {
 			PullRequests: []model.PullRequestShort{},
 		}
 
-		mockSvc.On("GetReview", mock.Anything, userID).Return(expectedResp, nil)
+		mockSvc.On("GetReview", mock.Anything, userID, model.ReviewQuery{}).Return(expectedResp, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/users/getReview?user_id=user+id+with+spaces", nil)
 		w := httptest.NewRecorder()
@@ -539,7 +762,7 @@ func TestHandler_EdgeCases(t *testing.T) {
 	})
 
 	t.Run("error response structure", func(t *testing.T) {
-		handler := New(new(mockService), zap.NewNop().Sugar())
+		handler := New(new(mockService))
 		router := setupRouter()
 		router.POST("/users/setIsActive", handler.SetIsActive)
 
@@ -558,7 +781,7 @@ func TestHandler_EdgeCases(t *testing.T) {
 
 	t.Run("not found response structure", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/users/setIsActive", handler.SetIsActive)
 
@@ -587,7 +810,7 @@ func TestHandler_EdgeCases(t *testing.T) {
 
 	t.Run("concurrent GetReview requests", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.GET("/users/getReview", handler.GetReview)
 
@@ -596,7 +819,7 @@ func TestHandler_EdgeCases(t *testing.T) {
 			PullRequests: []model.PullRequestShort{},
 		}
 
-		mockSvc.On("GetReview", mock.Anything, "u1").Return(expectedResp, nil).Times(5)
+		mockSvc.On("GetReview", mock.Anything, "u1", model.ReviewQuery{}).Return(expectedResp, nil).Times(5)
 
 		done := make(chan bool)
 		for i := 0; i < 5; i++ {
@@ -617,7 +840,7 @@ func TestHandler_EdgeCases(t *testing.T) {
 
 	t.Run("concurrent SetIsActive requests", func(t *testing.T) {
 		mockSvc := new(mockService)
-		handler := New(mockSvc, zap.NewNop().Sugar())
+		handler := New(mockSvc)
 		router := setupRouter()
 		router.POST("/users/setIsActive", handler.SetIsActive)
 
@@ -656,3 +879,276 @@ func TestHandler_EdgeCases(t *testing.T) {
 		mockSvc.AssertExpectations(t)
 	})
 }
+
+func TestHandler_SearchUsers(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.GET("/users/search", handler.SearchUsers)
+
+		expectedResp := &model.SearchUsersResponse{
+			Query: "ali",
+			Users: []model.User{{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}},
+		}
+		mockSvc.On("SearchUsers", mock.Anything, "ali", 5).Return(expectedResp, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/users/search?q=ali&limit=5", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp model.SearchUsersResponse
+		err := json.Unmarshal(w.Body.Bytes(), &resp)
+		require.NoError(t, err)
+		require.Len(t, resp.Users, 1)
+		assert.Equal(t, "u1", resp.Users[0].UserID)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("missing q parameter", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.GET("/users/search", handler.SearchUsers)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/users/search", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockSvc.AssertNotCalled(t, "SearchUsers")
+	})
+
+	t.Run("non-numeric limit", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.GET("/users/search", handler.SearchUsers)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/users/search?q=ali&limit=abc", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockSvc.AssertNotCalled(t, "SearchUsers")
+	})
+
+	t.Run("limit out of range", func(t *testing.T) {
+		mockSvc := new(mockService)
+		handler := New(mockSvc)
+		router := setupRouter()
+		router.GET("/users/search", handler.SearchUsers)
+
+		mockSvc.On("SearchUsers", mock.Anything, "ali", 500).Return(nil, pagination.ErrInvalidLimit)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/users/search?q=ali&limit=500", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var resp ErrorResponse
+		err := json.Unmarshal(w.Body.Bytes(), &resp)
+		require.NoError(t, err)
+		assert.Equal(t, "INVALID_REQUEST", resp.Error.Code)
+	})
+}
+
+func TestHandler_AnonymizeUser(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		setupMock  func(m *mockService)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "success",
+			body: `{"user_id":"u1"}`,
+			setupMock: func(m *mockService) {
+				m.On("AnonymizeUser", mock.Anything, &model.AnonymizeUserRequest{UserID: "u1"}).
+					Return(&model.AnonymizeUserResponse{AnonymousID: "anon-0123456789abcdef", TeamName: "backend"}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"anonymous_id":"anon-0123456789abcdef","team_name":"backend"}`,
+		},
+		{
+			name:       "missing user_id",
+			body:       `{}`,
+			setupMock:  func(*mockService) {},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `"field":"user_id"`,
+		},
+		{
+			name: "user not found",
+			body: `{"user_id":"u9"}`,
+			setupMock: func(m *mockService) {
+				m.On("AnonymizeUser", mock.Anything, mock.Anything).Return(nil, model.ErrUserNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   `"code":"NOT_FOUND"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mockService)
+			tt.setupMock(mockSvc)
+			router := setupRouter()
+			router.POST("/admin/anonymizeUser", New(mockSvc).AnonymizeUser)
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/anonymizeUser", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+			assert.Contains(t, w.Body.String(), tt.wantBody)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_GetActivationHistory(t *testing.T) {
+	actor := "lead"
+	tests := []struct {
+		name       string
+		query      string
+		setupMock  func(m *mockService)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:  "success",
+			query: "?user_id=u1&limit=10",
+			setupMock: func(m *mockService) {
+				m.On("GetActivationHistory", mock.Anything, "u1", 10).Return(&model.ActivationHistoryResponse{
+					UserID: "u1",
+					Changes: []model.ActivationChange{{
+						ID: 1, UserID: "u1", OldIsActive: true, Source: model.ActivationSourceSetIsActive,
+						ActorID: &actor, CreatedAt: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+					}},
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `{"user_id":"u1","changes":[{"id":1,"user_id":"u1","old_is_active":true,` +
+				`"new_is_active":false,"source":"SET_IS_ACTIVE","changed_by":"lead",` +
+				`"created_at":"2025-06-01T12:00:00Z"}]}`,
+		},
+		{
+			name:       "missing user_id",
+			query:      "",
+			setupMock:  func(*mockService) {},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `"field":"user_id"`,
+		},
+		{
+			name:       "non-numeric limit",
+			query:      "?user_id=u1&limit=abc",
+			setupMock:  func(*mockService) {},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `"field":"limit"`,
+		},
+		{
+			name:  "limit out of range",
+			query: "?user_id=u1&limit=500",
+			setupMock: func(m *mockService) {
+				m.On("GetActivationHistory", mock.Anything, "u1", 500).
+					Return(nil, pagination.ErrInvalidLimit)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `"field":"limit"`,
+		},
+		{
+			name:  "user not found",
+			query: "?user_id=u9",
+			setupMock: func(m *mockService) {
+				m.On("GetActivationHistory", mock.Anything, "u9", 0).Return(nil, model.ErrUserNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   `"code":"NOT_FOUND"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mockService)
+			tt.setupMock(mockSvc)
+			router := setupRouter()
+			router.GET("/users/activationHistory", New(mockSvc).GetActivationHistory)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/activationHistory"+tt.query, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+			assert.Contains(t, w.Body.String(), tt.wantBody)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_WaitReview(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		setupMock  func(m *mockService)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:  "changed",
+			query: "?user_id=u1&version=abc",
+			setupMock: func(m *mockService) {
+				m.On("WaitReview", mock.Anything, "u1", "abc").Return(&model.WaitReviewResponse{
+					UserID:       "u1",
+					PullRequests: []model.PullRequestShort{{PullRequestID: "pr-1", AuthorID: "u2", Status: "OPEN"}},
+					Version:      "def",
+					Changed:      true,
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `{"user_id":"u1","pull_requests":[{"pull_request_id":"pr-1","pull_request_name":"",` +
+				`"author_id":"u2","status":"OPEN","has_conflicts":false}],"version":"def","changed":true}`,
+		},
+		{
+			name:  "timed out",
+			query: "?user_id=u1&version=abc",
+			setupMock: func(m *mockService) {
+				m.On("WaitReview", mock.Anything, "u1", "abc").Return(&model.WaitReviewResponse{
+					UserID: "u1", PullRequests: []model.PullRequestShort{}, Version: "abc",
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `"version":"abc","changed":false`,
+		},
+		{
+			name:       "missing user_id",
+			query:      "?version=abc",
+			setupMock:  func(*mockService) {},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `"field":"user_id"`,
+		},
+		{
+			name:  "database error",
+			query: "?user_id=u1",
+			setupMock: func(m *mockService) {
+				m.On("WaitReview", mock.Anything, "u1", "").Return(nil, errors.New("connection refused"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   `"code":"INTERNAL_ERROR"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mockService)
+			tt.setupMock(mockSvc)
+			router := setupRouter()
+			router.GET("/users/getReview/wait", New(mockSvc).WaitReview)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/getReview/wait"+tt.query, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+			assert.Contains(t, w.Body.String(), tt.wantBody)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
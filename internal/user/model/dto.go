@@ -1,38 +1,92 @@
 // Package model provides domain models and DTOs for user module.
 package model
 
+import (
+	"time"
+
+	"github.com/festy23/avito_internship/pkg/sortparam"
+)
+
 // SetIsActiveRequest represents the request to update user activity status.
 // Note: IsActive doesn't use binding:"required" because Gin treats false as zero value
 // and fails validation. The field is required by OpenAPI spec and is validated in handler
 // to ensure it's present in the JSON request body.
+// ReassignOpenReviews replaces a deactivated user in their open reviews within the same transaction.
+// ChangedBy and Reason are recorded in the activation history of the user.
 type SetIsActiveRequest struct {
-	UserID   string `json:"user_id"   binding:"required"`
-	IsActive bool   `json:"is_active"`
+	UserID              string `json:"user_id"               binding:"required,max=255"`
+	IsActive            bool   `json:"is_active"`
+	ReassignOpenReviews bool   `json:"reassign_open_reviews"`
+	ChangedBy           string `json:"changed_by"            binding:"max=255"`
+	Reason              string `json:"reason"                binding:"max=1000"`
 }
 
 // SetIsActiveResponse represents the response after updating user activity.
+// ReassignedPRs lists the open PRs the user was removed from when reassign_open_reviews is set.
 type SetIsActiveResponse struct {
-	User User `json:"user"`
+	User          User     `json:"user"`
+	ReassignedPRs []string `json:"reassigned_prs,omitempty"`
 }
 
 // PullRequestShort represents a shortened pull request information.
 // Used in GetReviewResponse.
 type PullRequestShort struct {
-	PullRequestID   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
-	AuthorID        string `json:"author_id"`
-	Status          string `json:"status"` // OPEN or MERGED
+	PullRequestID   string    `json:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name"`
+	AuthorID        string    `json:"author_id"`
+	Status          string    `json:"status"` // OPEN, MERGED, ASSIGNING or CLOSED
+	HasConflicts    bool      `json:"has_conflicts"`
+	CreatedAt       time.Time `json:"-"` // Keyset of the next page cursor
+}
+
+// ReviewSort declares the sort fields of GetReview: newest PRs first by default.
+var ReviewSort = sortparam.Spec{
+	Columns:      map[string][]string{"created_at": {"pull_requests.created_at"}},
+	DefaultField: "created_at",
+	DefaultOrder: sortparam.Desc,
+	TieBreaker:   "pull_requests.pull_request_id",
+}
+
+// ReviewQuery holds the options of GetReview. The zero Sort means the defaults of ReviewSort.
+// A positive Limit splits the result into pages; After is the position of the previous page.
+type ReviewQuery struct {
+	Archived bool
+	Sort     sortparam.Sort
+	Limit    int
+	After    *ReviewPosition
+}
+
+// ReviewPosition is the keyset of the last PR of a GetReview page, encoded in its cursor.
+type ReviewPosition struct {
+	CreatedAt     time.Time `json:"t"`
+	PullRequestID string    `json:"id"`
 }
 
 // GetReviewResponse represents the response for getting user's assigned PRs.
+// NextCursor is set when more PRs follow the page; Next is its position before encoding.
 type GetReviewResponse struct {
 	UserID       string             `json:"user_id"`
 	PullRequests []PullRequestShort `json:"pull_requests"`
+	NextCursor   string             `json:"next_cursor,omitempty"`
+	Next         *ReviewPosition    `json:"-"`
+}
+
+// WaitReviewResponse represents the response of waiting for changes of the user's assigned PRs.
+// Version identifies the set of PRs for the next wait; Changed is false when the wait timed out
+// with the set the client already had.
+type WaitReviewResponse struct {
+	UserID       string             `json:"user_id"`
+	PullRequests []PullRequestShort `json:"pull_requests"`
+	Version      string             `json:"version"`
+	Changed      bool               `json:"changed"`
 }
 
 // BulkDeactivateTeamRequest represents the request to bulk deactivate team members.
+// ChangedBy and Reason are recorded in the activation history of every deactivated user.
 type BulkDeactivateTeamRequest struct {
-	TeamName string `json:"team_name" binding:"required"`
+	TeamName  string `json:"team_name"  binding:"required,max=255"`
+	ChangedBy string `json:"changed_by" binding:"max=255"`
+	Reason    string `json:"reason"     binding:"max=1000"`
 }
 
 // BulkDeactivateTeamResponse represents the response after bulk deactivation.
@@ -43,3 +97,27 @@ type BulkDeactivateTeamResponse struct {
 	DeactivatedCount  int      `json:"deactivated_count"`
 	ReassignedPRCount int      `json:"reassigned_pr_count"`
 }
+
+// AnonymizeUserRequest represents the request to anonymize a user.
+type AnonymizeUserRequest struct {
+	UserID string `json:"user_id" binding:"required,max=255"`
+}
+
+// AnonymizeUserResponse represents the response after anonymization.
+type AnonymizeUserResponse struct {
+	// AnonymousID replaces the user ID and username of the user everywhere.
+	AnonymousID string `json:"anonymous_id"`
+	TeamName    string `json:"team_name"`
+}
+
+// ActivationHistoryResponse represents the activation changes of a user, newest first.
+type ActivationHistoryResponse struct {
+	UserID  string             `json:"user_id"`
+	Changes []ActivationChange `json:"changes"`
+}
+
+// SearchUsersResponse represents the response for user search.
+type SearchUsersResponse struct {
+	Query string `json:"query"`
+	Users []User `json:"users"`
+}
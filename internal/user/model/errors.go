@@ -9,4 +9,6 @@ var (
 	ErrInvalidUserID = errors.New("invalid user ID")
 	// ErrInvalidIsActive indicates that is_active field is missing or invalid.
 	ErrInvalidIsActive = errors.New("is_active field is required")
+	// ErrInvalidSearchQuery indicates that the search query is empty or too long.
+	ErrInvalidSearchQuery = errors.New("q must be between 1 and 255 characters")
 )
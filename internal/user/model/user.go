@@ -1,6 +1,9 @@
 package model
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
 	"time"
 
 	"gorm.io/gorm"
@@ -15,6 +18,7 @@ type User struct {
 	IsActive  bool      `gorm:"column:is_active;type:boolean;not null;default:true;index:idx_users_team_active,composite:team_name" json:"is_active"`
 	CreatedAt time.Time `gorm:"column:created_at;type:timestamptz;not null;default:now()"                                           json:"-"`
 	UpdatedAt time.Time `gorm:"column:updated_at;type:timestamptz;not null;default:now()"                                           json:"-"`
+	TenantID  string    `gorm:"column:tenant_id;type:varchar(255);not null;default:'default'"                                     json:"-"`
 }
 
 // TableName specifies the table name for GORM.
@@ -27,3 +31,89 @@ func (u *User) BeforeUpdate(tx *gorm.DB) error {
 	u.UpdatedAt = time.Now()
 	return nil
 }
+
+// AnonymousIDPrefix starts the user_id and username of anonymized users.
+const AnonymousIDPrefix = "anon-"
+
+// AnonymousID returns the ID replacing userID on anonymization: a hash of userID and salt.
+// With a random salt the ID cannot be traced back to userID by hashing known user IDs.
+func AnonymousID(userID string, salt []byte) string {
+	h := sha256.New()
+	h.Write(salt)
+	h.Write([]byte(userID))
+	return AnonymousIDPrefix + hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// Defaults of waiting for changes of the assigned PRs (GET /users/getReview/wait).
+const (
+	// DefaultReviewWaitTimeout is how long a wait holds the connection without changes. It must stay
+	// below the server write timeout.
+	DefaultReviewWaitTimeout = 8 * time.Second
+	// DefaultReviewWaitInterval is how often a wait checks the assigned PRs.
+	DefaultReviewWaitInterval = time.Second
+)
+
+// ReviewVersion identifies the set of assigned PRs with their statuses, regardless of their order:
+// it changes when a PR is assigned, unassigned or changes status.
+func ReviewVersion(prs []PullRequestShort) string {
+	keys := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		keys = append(keys, pr.PullRequestID+"\x00"+pr.Status)
+	}
+	slices.Sort(keys)
+
+	h := sha256.New()
+	for _, key := range keys {
+		h.Write([]byte(key))
+		h.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// Sources of activation changes.
+const (
+	// ActivationSourceSetIsActive marks changes made by /users/setIsActive.
+	ActivationSourceSetIsActive = "SET_IS_ACTIVE"
+	// ActivationSourceBulkDeactivate marks changes made by /users/bulkDeactivate.
+	ActivationSourceBulkDeactivate = "BULK_DEACTIVATE"
+)
+
+// ActivationChange represents a change of the is_active flag of a user.
+// Matches the user_activation_history table schema.
+type ActivationChange struct {
+	ID          int64     `gorm:"primaryKey;column:id;type:bigserial"                           json:"id"`
+	UserID      string    `gorm:"column:user_id;type:varchar(255);not null"                     json:"user_id"`
+	OldIsActive bool      `gorm:"column:old_is_active;type:boolean;not null"                    json:"old_is_active"`
+	NewIsActive bool      `gorm:"column:new_is_active;type:boolean;not null"                    json:"new_is_active"`
+	Source      string    `gorm:"column:source;type:varchar(32);not null"                       json:"source"`
+	ActorID     *string   `gorm:"column:actor_id;type:varchar(255)"                             json:"changed_by,omitempty"`
+	Reason      *string   `gorm:"column:reason;type:text"                                       json:"reason,omitempty"`
+	TenantID    string    `gorm:"column:tenant_id;type:varchar(255);not null;default:'default'" json:"-"`
+	CreatedAt   time.Time `gorm:"column:created_at;type:timestamptz;not null;default:now()"     json:"created_at"`
+}
+
+// TableName specifies the table name for GORM.
+func (ActivationChange) TableName() string {
+	return "user_activation_history"
+}
+
+// NewActivationChange builds an activation change of userID; empty actorID and reason are stored as NULL.
+func NewActivationChange(
+	userID string,
+	oldIsActive, newIsActive bool,
+	source, actorID, reason string,
+) ActivationChange {
+	change := ActivationChange{
+		UserID:      userID,
+		OldIsActive: oldIsActive,
+		NewIsActive: newIsActive,
+		Source:      source,
+	}
+	if actorID != "" {
+		change.ActorID = &actorID
+	}
+	if reason != "" {
+		change.Reason = &reason
+	}
+	return change
+}
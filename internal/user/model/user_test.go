@@ -310,7 +310,8 @@ func setupTestDB(t *testing.T) *gorm.DB {
 			team_name VARCHAR(255) NOT NULL,
 			is_active INTEGER NOT NULL,
 			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
-			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			tenant_id VARCHAR(255) NOT NULL DEFAULT 'default'
 		)
 	`).Error
 	require.NoError(t, err)
@@ -348,3 +349,24 @@ func BenchmarkUser_BeforeUpdate(b *testing.B) {
 		_ = user.BeforeUpdate(nil)
 	}
 }
+
+func TestAnonymousID(t *testing.T) {
+	id := AnonymousID("u1", []byte("salt"))
+	assert.Regexp(t, `^anon-[0-9a-f]{16}$`, id)
+	assert.Equal(t, id, AnonymousID("u1", []byte("salt")))
+	assert.NotEqual(t, id, AnonymousID("u1", []byte("pepper")))
+	assert.NotEqual(t, id, AnonymousID("u2", []byte("salt")))
+}
+
+func TestReviewVersion(t *testing.T) {
+	prs := []PullRequestShort{
+		{PullRequestID: "pr-1", Status: "OPEN"},
+		{PullRequestID: "pr-2", Status: "OPEN"},
+	}
+	version := ReviewVersion(prs)
+	assert.Regexp(t, `^[0-9a-f]{16}$`, version)
+	assert.Equal(t, version, ReviewVersion([]PullRequestShort{prs[1], prs[0]}), "order does not matter")
+	assert.NotEqual(t, version, ReviewVersion(prs[:1]))
+	assert.NotEqual(t, version, ReviewVersion([]PullRequestShort{prs[0], {PullRequestID: "pr-2", Status: "MERGED"}}))
+	assert.NotEqual(t, version, ReviewVersion(nil))
+}
@@ -4,11 +4,17 @@ package repository
 import (
 	"context"
 	"errors"
+	"strings"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	"github.com/festy23/avito_internship/internal/database/dberror"
+	"github.com/festy23/avito_internship/internal/database/dialect"
+	"github.com/festy23/avito_internship/internal/tenant"
 	"github.com/festy23/avito_internship/internal/user/model"
+	"github.com/festy23/avito_internship/pkg/clock"
 )
 
 // Repository defines the interface for user data access operations.
@@ -16,27 +22,59 @@ type Repository interface {
 	// GetByID finds user by user_id.
 	GetByID(ctx context.Context, userID string) (*model.User, error)
 
+	// GetByIDForUpdate finds user by user_id and locks the row until the end of the transaction.
+	GetByIDForUpdate(ctx context.Context, userID string) (*model.User, error)
+
 	// UpdateIsActive updates user's is_active flag.
 	UpdateIsActive(ctx context.Context, userID string, isActive bool) (*model.User, error)
 
-	// GetAssignedPullRequests returns PRs where user is reviewer.
-	GetAssignedPullRequests(ctx context.Context, userID string) ([]model.PullRequestShort, error)
+	// GetAssignedPullRequests returns PRs where user is reviewer, ordered as requested by query.
+	// When query.Archived is true only archived PRs are returned, otherwise only non-archived ones.
+	GetAssignedPullRequests(
+		ctx context.Context,
+		userID string,
+		query model.ReviewQuery,
+	) ([]model.PullRequestShort, error)
 
 	// BulkDeactivateTeamMembers deactivates all active members of a team.
 	BulkDeactivateTeamMembers(ctx context.Context, teamName string) ([]string, error)
 
 	// GetTeamMemberIDs returns all user IDs for a team.
 	GetTeamMemberIDs(ctx context.Context, teamName string) ([]string, error)
+
+	// Search finds users whose user_id or username contains the query (case-insensitive).
+	Search(ctx context.Context, query string, limit int) ([]model.User, error)
+
+	// CreateAnonymized creates an inactive copy of user with user_id and username set to anonymousID.
+	CreateAnonymized(ctx context.Context, user *model.User, anonymousID string) (*model.User, error)
+
+	// Delete deletes a user that is no longer referenced.
+	Delete(ctx context.Context, userID string) error
+
+	// RecordActivationChanges adds entries to the activation history.
+	RecordActivationChanges(ctx context.Context, changes []model.ActivationChange) error
+
+	// GetActivationHistory returns up to limit activation changes of a user, newest first.
+	GetActivationHistory(ctx context.Context, userID string, limit int) ([]model.ActivationChange, error)
+
+	// ReplaceUser replaces oldUserID by newUserID in the activation history.
+	ReplaceUser(ctx context.Context, oldUserID, newUserID string) error
 }
 
 type repository struct {
 	db     *gorm.DB
+	clock  clock.Clock
 	logger *zap.SugaredLogger
 }
 
 // New creates a new user repository instance.
 func New(db *gorm.DB, logger *zap.SugaredLogger) Repository {
-	return &repository{db: db, logger: logger}
+	return NewWithClock(db, clock.New(), logger)
+}
+
+// NewWithClock creates a new user repository instance that takes timestamps from clk.
+func NewWithClock(db *gorm.DB, clk clock.Clock, logger *zap.SugaredLogger) Repository {
+	return &repository{db: db, clock: clk, logger: logger}
 }
 
 // GetByID finds user by user_id.
@@ -45,6 +83,7 @@ func (r *repository) GetByID(ctx context.Context, userID string) (*model.User, e
 
 	var user model.User
 	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "users")).
 		Where("user_id = ?", userID).
 		First(&user).Error
 
@@ -54,7 +93,30 @@ func (r *repository) GetByID(ctx context.Context, userID string) (*model.User, e
 			return nil, model.ErrUserNotFound
 		}
 		r.logger.Errorw("GetByID database error", "user_id", userID, "error", err)
-		return nil, err
+		return nil, dberror.Wrap(err, "get user", userID)
+	}
+
+	return &user, nil
+}
+
+// GetByIDForUpdate finds user by user_id with SELECT ... FOR UPDATE, so concurrent changes of the
+// user wait for the surrounding transaction. Outside a transaction it behaves as GetByID.
+func (r *repository) GetByIDForUpdate(ctx context.Context, userID string) (*model.User, error) {
+	r.logger.Debugw("GetByIDForUpdate called", "user_id", userID)
+
+	var user model.User
+	err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Scopes(tenant.Scope(ctx, "users")).
+		Where("user_id = ?", userID).
+		First(&user).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrUserNotFound
+		}
+		r.logger.Errorw("GetByIDForUpdate database error", "user_id", userID, "error", err)
+		return nil, dberror.Wrap(err, "lock user", userID)
 	}
 
 	return &user, nil
@@ -67,12 +129,13 @@ func (r *repository) UpdateIsActive(ctx context.Context, userID string, isActive
 	var user model.User
 	result := r.db.WithContext(ctx).
 		Model(&model.User{}).
+		Scopes(tenant.Scope(ctx, "users")).
 		Where("user_id = ?", userID).
 		Update("is_active", isActive)
 
 	if result.Error != nil {
 		r.logger.Errorw("UpdateIsActive database error", "user_id", userID, "error", result.Error)
-		return nil, result.Error
+		return nil, dberror.Wrap(result.Error, "update user activity", userID)
 	}
 
 	if result.RowsAffected == 0 {
@@ -82,35 +145,71 @@ func (r *repository) UpdateIsActive(ctx context.Context, userID string, isActive
 
 	// Fetch updated user
 	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "users")).
 		Where("user_id = ?", userID).
 		First(&user).Error
 
 	if err != nil {
 		r.logger.Errorw("UpdateIsActive failed to fetch updated user", "user_id", userID, "error", err)
-		return nil, err
+		return nil, dberror.Wrap(err, "get updated user", userID)
 	}
 
 	r.logger.Infow("UpdateIsActive completed", "user_id", userID, "new_state", isActive)
 	return &user, nil
 }
 
-// GetAssignedPullRequests returns PRs where user is reviewer.
-func (r *repository) GetAssignedPullRequests(ctx context.Context, userID string) ([]model.PullRequestShort, error) {
-	r.logger.Debugw("GetAssignedPullRequests called", "user_id", userID)
+// GetAssignedPullRequests returns PRs where user is reviewer, ordered as requested by query.
+// When query.Archived is true only archived PRs are returned, otherwise only non-archived ones.
+// A positive query.Limit caps the number of PRs, and query.After skips PRs up to that position.
+func (r *repository) GetAssignedPullRequests(
+	ctx context.Context,
+	userID string,
+	query model.ReviewQuery,
+) ([]model.PullRequestShort, error) {
+	r.logger.Debugw("GetAssignedPullRequests called",
+		"user_id", userID, "archived", query.Archived, "sort", query.Sort.Field, "order", query.Sort.Order)
 
 	var prs []model.PullRequestShort
 
-	err := r.db.WithContext(ctx).
+	archivedFilter := "pull_requests.archived_at IS NULL"
+	if query.Archived {
+		archivedFilter = "pull_requests.archived_at IS NOT NULL"
+	}
+
+	db := r.db.WithContext(ctx).
 		Table("pull_request_reviewers").
-		Select("pull_requests.pull_request_id, pull_requests.pull_request_name, pull_requests.author_id, pull_requests.status").
+		Select(
+			"pull_requests.pull_request_id, pull_requests.pull_request_name, "+
+				"pull_requests.author_id, pull_requests.status, pull_requests.has_conflicts, "+
+				"pull_requests.created_at",
+		).
 		Joins("JOIN pull_requests ON pull_request_reviewers.pull_request_id = pull_requests.pull_request_id").
+		Scopes(tenant.Scope(ctx, "pull_requests")).
 		Where("pull_request_reviewers.user_id = ?", userID).
-		Order("pull_requests.created_at DESC").
-		Scan(&prs).Error
+		Where(archivedFilter)
+
+	// Keyset of ReviewSort: created_at in the requested order, then pull_request_id ascending
+	if query.After != nil {
+		op := ">"
+		if model.ReviewSort.Descending(query.Sort) {
+			op = "<"
+		}
+		after := query.After.CreatedAt
+		db = db.Where(
+			"pull_requests.created_at "+op+" ? OR "+
+				"(pull_requests.created_at = ? AND pull_requests.pull_request_id > ?)",
+			after, after, query.After.PullRequestID,
+		)
+	}
+	if query.Limit > 0 {
+		db = db.Limit(query.Limit)
+	}
+
+	err := db.Order(model.ReviewSort.OrderBy(query.Sort)).Scan(&prs).Error
 
 	if err != nil {
 		r.logger.Errorw("GetAssignedPullRequests database error", "user_id", userID, "error", err)
-		return nil, err
+		return nil, dberror.Wrap(err, "get assigned pull requests", userID)
 	}
 
 	if prs == nil {
@@ -123,7 +222,8 @@ func (r *repository) GetAssignedPullRequests(ctx context.Context, userID string)
 
 // BulkDeactivateTeamMembers deactivates all active members of a team atomically.
 // Uses PostgreSQL RETURNING clause to get updated user IDs in a single operation,
-// avoiding TOCTOU (Time-of-check to time-of-use) race conditions.
+// avoiding TOCTOU (Time-of-check to time-of-use) race conditions. MySQL has no RETURNING,
+// so there the members are locked and read before the update in one transaction.
 func (r *repository) BulkDeactivateTeamMembers(ctx context.Context, teamName string) ([]string, error) {
 	r.logger.Infow("BulkDeactivateTeamMembers called", "team_name", teamName)
 
@@ -131,17 +231,32 @@ func (r *repository) BulkDeactivateTeamMembers(ctx context.Context, teamName str
 
 	// Use GORM Raw to execute UPDATE ... RETURNING within the transaction scope
 	// This ensures the operation runs inside any surrounding GORM transaction
+	// Like tenant.Scope, the update is restricted to the tenant of ctx only if it has one
+	where, args := "team_name = ? AND is_active = true", []any{teamName}
+	if tenantID, ok := tenant.FromContext(ctx); ok {
+		where, args = where+" AND tenant_id = ?", append(args, tenantID)
+	}
 	query := `
 		UPDATE users 
 		SET is_active = false 
-		WHERE team_name = ? AND is_active = true 
+		WHERE ` + where + `
 		RETURNING user_id
 	`
+	if dialect.IsMySQL(r.db) {
+		deactivatedUserIDs, err := r.bulkDeactivateMySQL(ctx, where, args)
+		if err != nil {
+			r.logger.Errorw("BulkDeactivateTeamMembers database error", "team_name", teamName, "error", err)
+			return nil, dberror.Wrap(err, "deactivate team members", teamName)
+		}
+		r.logger.Infow("BulkDeactivateTeamMembers completed",
+			"team_name", teamName, "deactivated_count", len(deactivatedUserIDs))
+		return deactivatedUserIDs, nil
+	}
 
-	rows, err := r.db.WithContext(ctx).Raw(query, teamName).Rows()
+	rows, err := r.db.WithContext(ctx).Raw(query, args...).Rows()
 	if err != nil {
 		r.logger.Errorw("BulkDeactivateTeamMembers database error", "team_name", teamName, "error", err)
-		return nil, err
+		return nil, dberror.Wrap(err, "deactivate team members", teamName)
 	}
 	defer func() {
 		if closeErr := rows.Close(); closeErr != nil {
@@ -153,14 +268,14 @@ func (r *repository) BulkDeactivateTeamMembers(ctx context.Context, teamName str
 		var userID string
 		if err := rows.Scan(&userID); err != nil {
 			r.logger.Errorw("BulkDeactivateTeamMembers failed to scan user_id", "team_name", teamName, "error", err)
-			return nil, err
+			return nil, dberror.Wrap(err, "scan deactivated user", teamName)
 		}
 		deactivatedUserIDs = append(deactivatedUserIDs, userID)
 	}
 
 	if err := rows.Err(); err != nil {
 		r.logger.Errorw("BulkDeactivateTeamMembers row iteration error", "team_name", teamName, "error", err)
-		return nil, err
+		return nil, dberror.Wrap(err, "deactivate team members", teamName)
 	}
 
 	r.logger.Infow(
@@ -173,6 +288,23 @@ func (r *repository) BulkDeactivateTeamMembers(ctx context.Context, teamName str
 	return deactivatedUserIDs, nil
 }
 
+// bulkDeactivateMySQL deactivates the users matching where and returns their IDs.
+func (r *repository) bulkDeactivateMySQL(ctx context.Context, where string, args []any) ([]string, error) {
+	var userIDs []string
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.User{}).
+			Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+			Where(where, args...).
+			Order("user_id").
+			Pluck("user_id", &userIDs).Error
+		if err != nil || len(userIDs) == 0 {
+			return err
+		}
+		return tx.Model(&model.User{}).Where("user_id IN ?", userIDs).UpdateColumn("is_active", false).Error
+	})
+	return userIDs, err
+}
+
 // GetTeamMemberIDs returns all user IDs for a team.
 func (r *repository) GetTeamMemberIDs(ctx context.Context, teamName string) ([]string, error) {
 	r.logger.Debugw("GetTeamMemberIDs called", "team_name", teamName)
@@ -180,12 +312,13 @@ func (r *repository) GetTeamMemberIDs(ctx context.Context, teamName string) ([]s
 	var userIDs []string
 	err := r.db.WithContext(ctx).
 		Model(&model.User{}).
+		Scopes(tenant.Scope(ctx, "users")).
 		Where("team_name = ?", teamName).
 		Pluck("user_id", &userIDs).Error
 
 	if err != nil {
 		r.logger.Errorw("GetTeamMemberIDs database error", "team_name", teamName, "error", err)
-		return nil, err
+		return nil, dberror.Wrap(err, "get team member IDs", teamName)
 	}
 
 	if userIDs == nil {
@@ -195,3 +328,164 @@ func (r *repository) GetTeamMemberIDs(ctx context.Context, teamName string) ([]s
 	r.logger.Debugw("GetTeamMemberIDs completed", "team_name", teamName, "count", len(userIDs))
 	return userIDs, nil
 }
+
+// Search finds users whose user_id or username contains the query (case-insensitive).
+// Exact matches are ranked first, then prefix matches, then substring matches.
+// On PostgreSQL the LOWER(...) LIKE predicates are served by pg_trgm GIN indexes; MySQL scans the
+// users of the tenant.
+func (r *repository) Search(ctx context.Context, query string, limit int) ([]model.User, error) {
+	r.logger.Debugw("Search called", "query", query, "limit", limit)
+
+	lowered := strings.ToLower(query)
+	escaped := escapeLike(lowered)
+	contains := "%" + escaped + "%"
+	prefix := escaped + "%"
+
+	like := "LIKE ?" + dialect.LikeEscape(r.db)
+	var users []model.User
+	err := r.db.WithContext(ctx).
+		Model(&model.User{}).
+		Scopes(tenant.Scope(ctx, "users")).
+		Where("LOWER(user_id) "+like+" OR LOWER(username) "+like, contains, contains).
+		Clauses(clause.OrderBy{Expression: clause.Expr{
+			SQL: `CASE
+				WHEN LOWER(user_id) = ? OR LOWER(username) = ? THEN 0
+				WHEN LOWER(user_id) ` + like + ` OR LOWER(username) ` + like + ` THEN 1
+				ELSE 2
+			END, user_id ASC`,
+			Vars: []any{lowered, lowered, prefix, prefix},
+		}}).
+		Limit(limit).
+		Find(&users).Error
+
+	if err != nil {
+		r.logger.Errorw("Search database error", "query", query, "error", err)
+		return nil, dberror.Wrap(err, "search users", query)
+	}
+
+	if users == nil {
+		users = []model.User{}
+	}
+
+	r.logger.Debugw("Search completed", "query", query, "count", len(users))
+	return users, nil
+}
+
+// escapeLike escapes LIKE wildcards so user input is matched literally.
+func escapeLike(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(s)
+}
+
+// CreateAnonymized creates an inactive copy of user with user_id and username set to anonymousID.
+// The copy stays in the team and tenant of user and keeps its creation time.
+func (r *repository) CreateAnonymized(
+	ctx context.Context,
+	user *model.User,
+	anonymousID string,
+) (*model.User, error) {
+	r.logger.Infow("CreateAnonymized called", "anonymous_id", anonymousID)
+
+	anonymized := &model.User{
+		UserID:    anonymousID,
+		Username:  anonymousID,
+		TeamName:  user.TeamName,
+		IsActive:  false,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: r.clock.Now(),
+		TenantID:  user.TenantID,
+	}
+	// Created from a map: GORM would replace is_active = false of a struct by the column default
+	err := r.db.WithContext(ctx).Model(&model.User{}).Create(map[string]interface{}{
+		"user_id":    anonymized.UserID,
+		"username":   anonymized.Username,
+		"team_name":  anonymized.TeamName,
+		"is_active":  anonymized.IsActive,
+		"created_at": anonymized.CreatedAt,
+		"updated_at": anonymized.UpdatedAt,
+		"tenant_id":  anonymized.TenantID,
+	}).Error
+	if err != nil {
+		r.logger.Errorw("CreateAnonymized database error", "anonymous_id", anonymousID, "error", err)
+		return nil, dberror.Wrap(err, "create anonymized user", anonymousID)
+	}
+	return anonymized, nil
+}
+
+// Delete deletes a user that is no longer referenced.
+func (r *repository) Delete(ctx context.Context, userID string) error {
+	r.logger.Infow("Delete called")
+
+	result := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "users")).
+		Where("user_id = ?", userID).
+		Delete(&model.User{})
+	if result.Error != nil {
+		r.logger.Errorw("Delete database error", "error", result.Error)
+		return dberror.Wrap(result.Error, "delete user")
+	}
+	if result.RowsAffected == 0 {
+		return model.ErrUserNotFound
+	}
+	return nil
+}
+
+// RecordActivationChanges adds entries to the activation history in the tenant of ctx.
+func (r *repository) RecordActivationChanges(ctx context.Context, changes []model.ActivationChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	r.logger.Debugw("RecordActivationChanges called", "count", len(changes))
+
+	tenantID, now := tenant.ID(ctx), r.clock.Now()
+	for i := range changes {
+		changes[i].TenantID = tenantID
+		changes[i].CreatedAt = now
+	}
+	if err := r.db.WithContext(ctx).Create(&changes).Error; err != nil {
+		r.logger.Errorw("RecordActivationChanges database error", "error", err)
+		return dberror.Wrap(err, "record activation changes")
+	}
+	return nil
+}
+
+// GetActivationHistory returns up to limit activation changes of a user, newest first.
+func (r *repository) GetActivationHistory(
+	ctx context.Context,
+	userID string,
+	limit int,
+) ([]model.ActivationChange, error) {
+	r.logger.Debugw("GetActivationHistory called", "user_id", userID, "limit", limit)
+
+	var changes []model.ActivationChange
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(ctx, "user_activation_history")).
+		Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&changes).Error
+	if err != nil {
+		r.logger.Errorw("GetActivationHistory database error", "user_id", userID, "error", err)
+		return nil, dberror.Wrap(err, "get activation history", userID)
+	}
+
+	if changes == nil {
+		changes = []model.ActivationChange{}
+	}
+	return changes, nil
+}
+
+// ReplaceUser replaces oldUserID by newUserID as the subject and the actor of activation changes.
+func (r *repository) ReplaceUser(ctx context.Context, oldUserID, newUserID string) error {
+	for _, column := range []string{"user_id", "actor_id"} {
+		err := r.db.WithContext(ctx).
+			Table("user_activation_history").
+			Where(column+" = ?", oldUserID).
+			Update(column, newUserID).Error
+		if err != nil {
+			r.logger.Errorw("ReplaceUser database error", "column", column, "error", err)
+			return dberror.Wrap(err, "replace user in activation history")
+		}
+	}
+	return nil
+}
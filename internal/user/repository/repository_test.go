@@ -11,7 +11,10 @@ import (
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 
+	"github.com/festy23/avito_internship/internal/tenant"
+	"github.com/festy23/avito_internship/internal/testutil"
 	"github.com/festy23/avito_internship/internal/user/model"
+	"github.com/festy23/avito_internship/pkg/sortparam"
 )
 
 type testUser struct {
@@ -21,6 +24,7 @@ type testUser struct {
 	IsActive  bool      `gorm:"column:is_active;not null;default:true"`
 	CreatedAt time.Time `gorm:"column:created_at"`
 	UpdatedAt time.Time `gorm:"column:updated_at"`
+	TenantID  string    `gorm:"column:tenant_id;not null;default:'default'"`
 }
 
 func (testUser) TableName() string {
@@ -44,6 +48,16 @@ func setupTestDB(t *testing.T) *gorm.DB {
 		Status          string     `gorm:"column:status;not null"`
 		CreatedAt       time.Time  `gorm:"column:created_at"`
 		MergedAt        *time.Time `gorm:"column:merged_at"`
+		ArchivedAt      *time.Time `gorm:"column:archived_at"`
+		SourceBranch    *string    `gorm:"column:source_branch"`
+		TargetBranch    *string    `gorm:"column:target_branch"`
+		PullRequestURL  *string    `gorm:"column:pull_request_url"`
+		LinesAdded      int        `gorm:"column:lines_added;not null;default:0"`
+		LinesRemoved    int        `gorm:"column:lines_removed;not null;default:0"`
+		HasConflicts    bool       `gorm:"column:has_conflicts;not null;default:false"`
+		CloseReason     *string    `gorm:"column:close_reason"`
+		CloseNote       *string    `gorm:"column:close_note"`
+		TeamName        *string    `gorm:"column:team_name"`
 	}
 
 	type PullRequestReviewer struct {
@@ -129,7 +143,7 @@ func TestRepository_GetAssignedPullRequests(t *testing.T) {
 		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
 			"u1", "Alice", "team1", true)
 
-		prs, err := repo.GetAssignedPullRequests(ctx, "u1")
+		prs, err := repo.GetAssignedPullRequests(ctx, "u1", model.ReviewQuery{})
 
 		require.NoError(t, err)
 		assert.Empty(t, prs)
@@ -161,7 +175,7 @@ func TestRepository_GetAssignedPullRequests(t *testing.T) {
 		db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)", "pr-1", "u1")
 		db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)", "pr-2", "u1")
 
-		prs, err := repo.GetAssignedPullRequests(ctx, "u1")
+		prs, err := repo.GetAssignedPullRequests(ctx, "u1", model.ReviewQuery{})
 
 		require.NoError(t, err)
 		require.Len(t, prs, 2)
@@ -323,11 +337,36 @@ func TestRepository_GetAssignedPullRequests_Extended(t *testing.T) {
 		db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)", "pr-2", "u1")
 		db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)", "pr-3", "u1")
 
-		prs, err := repo.GetAssignedPullRequests(ctx, "u1")
+		prs, err := repo.GetAssignedPullRequests(ctx, "u1", model.ReviewQuery{})
 		require.NoError(t, err)
 		assert.Len(t, prs, 3)
 	})
 
+	t.Run("archived PRs are returned only on request", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := New(db, zap.NewNop().Sugar())
+		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "team1")
+		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
+			"u1", "Alice", "team1", true)
+		db.Exec("INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES (?, ?, ?, ?)",
+			"pr-1", "PR 1", "u2", "OPEN")
+		db.Exec(
+			"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, archived_at) VALUES (?, ?, ?, ?, ?)",
+			"pr-2", "PR 2", "u2", "MERGED", time.Now())
+		db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)", "pr-1", "u1")
+		db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)", "pr-2", "u1")
+
+		active, err := repo.GetAssignedPullRequests(ctx, "u1", model.ReviewQuery{})
+		require.NoError(t, err)
+		require.Len(t, active, 1)
+		assert.Equal(t, "pr-1", active[0].PullRequestID)
+
+		archived, err := repo.GetAssignedPullRequests(ctx, "u1", model.ReviewQuery{Archived: true})
+		require.NoError(t, err)
+		require.Len(t, archived, 1)
+		assert.Equal(t, "pr-2", archived[0].PullRequestID)
+	})
+
 	t.Run("user with no assigned PRs", func(t *testing.T) {
 		db := setupTestDB(t)
 		repo := New(db, zap.NewNop().Sugar())
@@ -335,7 +374,7 @@ func TestRepository_GetAssignedPullRequests_Extended(t *testing.T) {
 		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
 			"u1", "Alice", "team1", true)
 
-		prs, err := repo.GetAssignedPullRequests(ctx, "u1")
+		prs, err := repo.GetAssignedPullRequests(ctx, "u1", model.ReviewQuery{})
 		require.NoError(t, err)
 		assert.Empty(t, prs)
 		assert.NotNil(t, prs)
@@ -347,7 +386,7 @@ func TestRepository_GetAssignedPullRequests_Extended(t *testing.T) {
 		sqlDB, _ := db.DB()
 		sqlDB.Close()
 
-		prs, err := repo.GetAssignedPullRequests(ctx, "u1")
+		prs, err := repo.GetAssignedPullRequests(ctx, "u1", model.ReviewQuery{})
 		assert.Nil(t, prs)
 		assert.Error(t, err)
 	})
@@ -374,13 +413,63 @@ func TestRepository_GetAssignedPullRequests_Extended(t *testing.T) {
 		db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)", "pr-2", "u1")
 		db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)", "pr-3", "u1")
 
-		prs, err := repo.GetAssignedPullRequests(ctx, "u1")
+		prs, err := repo.GetAssignedPullRequests(ctx, "u1", model.ReviewQuery{})
 		require.NoError(t, err)
 		assert.Len(t, prs, 3)
 		// Should be ordered DESC by created_at
 		assert.Equal(t, "pr-3", prs[0].PullRequestID)
 		assert.Equal(t, "pr-2", prs[1].PullRequestID)
 		assert.Equal(t, "pr-1", prs[2].PullRequestID)
+
+		prs, err = repo.GetAssignedPullRequests(ctx, "u1", model.ReviewQuery{
+			Sort: sortparam.Sort{Order: sortparam.Asc},
+		})
+		require.NoError(t, err)
+		require.Len(t, prs, 3)
+		assert.Equal(t, "pr-1", prs[0].PullRequestID)
+		assert.Equal(t, "pr-3", prs[2].PullRequestID)
+	})
+
+	t.Run("keyset pages are stable under inserts", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := New(db, zap.NewNop().Sugar())
+		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "team1")
+		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
+			"u1", "Alice", "team1", true)
+		base := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+		addPR := func(id string, createdAt time.Time) {
+			db.Exec("INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at) "+
+				"VALUES (?, ?, ?, ?, ?)", id, id, "u2", "OPEN", createdAt)
+			db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)", id, "u1")
+		}
+		// pr-2 and pr-3 share created_at and are ordered by pull_request_id
+		addPR("pr-1", base)
+		addPR("pr-2", base.Add(time.Hour))
+		addPR("pr-3", base.Add(time.Hour))
+		addPR("pr-4", base.Add(2*time.Hour))
+
+		page, err := repo.GetAssignedPullRequests(ctx, "u1", model.ReviewQuery{Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, page, 2)
+		assert.Equal(t, "pr-4", page[0].PullRequestID)
+		assert.Equal(t, "pr-2", page[1].PullRequestID)
+
+		// A PR created between pages does not shift the next page
+		addPR("pr-0", base.Add(3*time.Hour))
+		after := &model.ReviewPosition{CreatedAt: page[1].CreatedAt, PullRequestID: page[1].PullRequestID}
+		page, err = repo.GetAssignedPullRequests(ctx, "u1", model.ReviewQuery{Limit: 2, After: after})
+		require.NoError(t, err)
+		require.Len(t, page, 2)
+		assert.Equal(t, "pr-3", page[0].PullRequestID)
+		assert.Equal(t, "pr-1", page[1].PullRequestID)
+
+		asc := sortparam.Sort{Order: sortparam.Asc}
+		after = &model.ReviewPosition{CreatedAt: base.Add(time.Hour), PullRequestID: "pr-2"}
+		page, err = repo.GetAssignedPullRequests(ctx, "u1", model.ReviewQuery{Sort: asc, After: after})
+		require.NoError(t, err)
+		require.Len(t, page, 3)
+		assert.Equal(t, "pr-3", page[0].PullRequestID)
+		assert.Equal(t, "pr-0", page[2].PullRequestID)
 	})
 }
 
@@ -477,3 +566,153 @@ func TestRepository_BulkDeactivateTeamMembers(t *testing.T) {
 		assert.Empty(t, deactivatedIDs)
 	})
 }
+
+func TestRepository_Search(t *testing.T) {
+	ctx := context.Background()
+
+	seed := func(db *gorm.DB) {
+		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "team1")
+		for _, u := range [][2]string{
+			{"u1", "Alice"},
+			{"u2", "Malik"},
+			{"ali", "Bob"},
+			{"u_4", "Charlie"},
+		} {
+			db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
+				u[0], u[1], "team1", true)
+		}
+	}
+
+	t.Run("matches user_id and username case-insensitively", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := New(db, zap.NewNop().Sugar())
+		seed(db)
+
+		users, err := repo.Search(ctx, "ALI", 10)
+
+		require.NoError(t, err)
+		require.Len(t, users, 3)
+		// Exact match first, then prefix, then substring
+		assert.Equal(t, "ali", users[0].UserID)
+		assert.Equal(t, "u1", users[1].UserID)
+		assert.Equal(t, "u2", users[2].UserID)
+	})
+
+	t.Run("respects limit", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := New(db, zap.NewNop().Sugar())
+		seed(db)
+
+		users, err := repo.Search(ctx, "ali", 1)
+
+		require.NoError(t, err)
+		assert.Len(t, users, 1)
+	})
+
+	t.Run("wildcards are matched literally", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := New(db, zap.NewNop().Sugar())
+		seed(db)
+
+		users, err := repo.Search(ctx, "_", 10)
+
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		assert.Equal(t, "u_4", users[0].UserID)
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := New(db, zap.NewNop().Sugar())
+		seed(db)
+
+		users, err := repo.Search(ctx, "zzz", 10)
+
+		require.NoError(t, err)
+		assert.NotNil(t, users)
+		assert.Empty(t, users)
+	})
+}
+
+func TestRepository_GetAssignedPullRequests_HasConflicts(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	repo := New(db, zap.NewNop().Sugar())
+	db.Exec("INSERT INTO teams (team_name) VALUES (?)", "team1")
+	db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
+		"u1", "Alice", "team1", true)
+	db.Exec(
+		"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, has_conflicts) VALUES (?, ?, ?, ?, ?)",
+		"pr-1",
+		"PR 1",
+		"u1",
+		"OPEN",
+		true,
+	)
+	db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)", "pr-1", "u1")
+
+	prs, err := repo.GetAssignedPullRequests(ctx, "u1", model.ReviewQuery{})
+
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	assert.True(t, prs[0].HasConflicts)
+}
+
+func TestRepository_ActivationHistory(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.NewDB(t)
+	repo := New(db, zap.NewNop().Sugar())
+
+	require.NoError(t, repo.RecordActivationChanges(ctx, []model.ActivationChange{
+		model.NewActivationChange("u1", true, false, model.ActivationSourceBulkDeactivate, "", ""),
+		model.NewActivationChange("u2", true, false, model.ActivationSourceBulkDeactivate, "", ""),
+	}))
+	require.NoError(t, repo.RecordActivationChanges(ctx, []model.ActivationChange{
+		model.NewActivationChange("u1", false, true, model.ActivationSourceSetIsActive, "u2", "back"),
+	}))
+	acme := tenant.WithID(ctx, "acme")
+	require.NoError(t, repo.RecordActivationChanges(acme, []model.ActivationChange{
+		model.NewActivationChange("u1", true, false, model.ActivationSourceSetIsActive, "", ""),
+	}))
+	require.NoError(t, repo.RecordActivationChanges(ctx, nil))
+
+	t.Run("newest first", func(t *testing.T) {
+		changes, err := repo.GetActivationHistory(ctx, "u1", 10)
+		require.NoError(t, err)
+		require.Len(t, changes, 3, "a context without tenant sees all tenants")
+		assert.Equal(t, "acme", changes[0].TenantID)
+		assert.Equal(t, model.ActivationSourceSetIsActive, changes[1].Source)
+		require.NotNil(t, changes[1].ActorID)
+		assert.Equal(t, "u2", *changes[1].ActorID)
+		assert.Equal(t, model.ActivationSourceBulkDeactivate, changes[2].Source)
+		assert.Nil(t, changes[2].ActorID)
+		assert.Nil(t, changes[2].Reason)
+	})
+
+	t.Run("limit and tenant", func(t *testing.T) {
+		changes, err := repo.GetActivationHistory(acme, "u1", 10)
+		require.NoError(t, err)
+		assert.Len(t, changes, 1)
+
+		changes, err = repo.GetActivationHistory(ctx, "u1", 1)
+		require.NoError(t, err)
+		assert.Len(t, changes, 1)
+
+		changes, err = repo.GetActivationHistory(ctx, "u9", 10)
+		require.NoError(t, err)
+		assert.NotNil(t, changes)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("replace user", func(t *testing.T) {
+		require.NoError(t, repo.ReplaceUser(ctx, "u2", "anon-1"))
+
+		changes, err := repo.GetActivationHistory(ctx, "anon-1", 10)
+		require.NoError(t, err)
+		assert.Len(t, changes, 1)
+		changes, err = repo.GetActivationHistory(ctx, "u1", 10)
+		require.NoError(t, err)
+		require.NotNil(t, changes[1].ActorID)
+		assert.Equal(t, "anon-1", *changes[1].ActorID)
+	})
+}
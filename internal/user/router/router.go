@@ -6,22 +6,47 @@ import (
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 
-	pullrequestRepo "github.com/festy23/avito_internship/internal/pullrequest/repository"
+	"github.com/festy23/avito_internship/internal/config"
+	"github.com/festy23/avito_internship/internal/middleware"
 	teamRepo "github.com/festy23/avito_internship/internal/team/repository"
 	"github.com/festy23/avito_internship/internal/user/handler"
 	"github.com/festy23/avito_internship/internal/user/repository"
 	"github.com/festy23/avito_internship/internal/user/service"
+	"github.com/festy23/avito_internship/pkg/cursor"
+	"github.com/festy23/avito_internship/pkg/pagination"
 )
 
 // RegisterRoutes registers user module routes.
-func RegisterRoutes(r *gin.Engine, db *gorm.DB, logger *zap.SugaredLogger) {
+// cfg sets how long review waits are held; reviewers replaces deactivated users in their open reviews; cursors signs pagination cursors and
+// limits bound the page size of list endpoints.
+func RegisterRoutes(
+	r gin.IRouter,
+	db *gorm.DB,
+	cfg config.UserConfig,
+	reviewers service.ReviewerReplacer,
+	cursors *cursor.Codec,
+	limits pagination.Limits,
+	logger *zap.SugaredLogger,
+) {
 	repo := repository.New(db, logger)
 	teamRepository := teamRepo.New(db, logger)
-	pullrequestRepository := pullrequestRepo.New(db, logger)
-	svc := service.NewWithDependencies(repo, teamRepository, pullrequestRepository, db, logger)
-	h := handler.New(svc, logger)
+	svc := service.New(repo, logger,
+		service.WithTransactions(db, teamRepository), service.WithReviewerReplacer(reviewers),
+		service.WithPageLimits(limits), service.WithReviewWait(cfg.ReviewWaitTimeout, cfg.ReviewWaitInterval))
+	h := handler.NewWithCursors(svc, cursors)
 
 	r.POST("/users/setIsActive", h.SetIsActive)
-	r.GET("/users/getReview", h.GetReview)
+	r.GET("/users/getReview", middleware.ConditionalGet(), h.GetReview)
+	r.GET("/users/getReview/wait", h.WaitReview)
 	r.POST("/users/bulkDeactivate", h.BulkDeactivateTeamMembers)
+	r.GET("/users/search", h.SearchUsers)
+	r.GET("/users/activationHistory", h.GetActivationHistory)
+}
+
+// RegisterAdminRoutes registers administrative user routes; r must restrict access to administrators.
+func RegisterAdminRoutes(r gin.IRouter, db *gorm.DB, logger *zap.SugaredLogger) {
+	svc := service.New(repository.New(db, logger), logger, service.WithTransactions(db, teamRepo.New(db, logger)))
+	h := handler.New(svc)
+
+	r.POST("/admin/anonymizeUser", h.AnonymizeUser)
 }
@@ -15,7 +15,12 @@ import (
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 
+	"github.com/festy23/avito_internship/internal/config"
+	pullrequestRepo "github.com/festy23/avito_internship/internal/pullrequest/repository"
+	pullrequestService "github.com/festy23/avito_internship/internal/pullrequest/service"
 	"github.com/festy23/avito_internship/internal/user/model"
+	"github.com/festy23/avito_internship/pkg/cursor"
+	"github.com/festy23/avito_internship/pkg/pagination"
 )
 
 type testUser struct {
@@ -25,6 +30,7 @@ type testUser struct {
 	IsActive  bool      `gorm:"column:is_active;not null;default:true"`
 	CreatedAt time.Time `gorm:"column:created_at"`
 	UpdatedAt time.Time `gorm:"column:updated_at"`
+	TenantID  string    `gorm:"column:tenant_id;not null;default:'default'"`
 }
 
 func (testUser) TableName() string {
@@ -48,6 +54,16 @@ func setupIntegrationDB(t *testing.T) *gorm.DB {
 		Status          string     `gorm:"column:status;not null"`
 		CreatedAt       time.Time  `gorm:"column:created_at"`
 		MergedAt        *time.Time `gorm:"column:merged_at"`
+		ArchivedAt      *time.Time `gorm:"column:archived_at"`
+		SourceBranch    *string    `gorm:"column:source_branch"`
+		TargetBranch    *string    `gorm:"column:target_branch"`
+		PullRequestURL  *string    `gorm:"column:pull_request_url"`
+		LinesAdded      int        `gorm:"column:lines_added;not null;default:0"`
+		LinesRemoved    int        `gorm:"column:lines_removed;not null;default:0"`
+		HasConflicts    bool       `gorm:"column:has_conflicts;not null;default:false"`
+		CloseReason     *string    `gorm:"column:close_reason"`
+		CloseNote       *string    `gorm:"column:close_note"`
+		TeamName        *string    `gorm:"column:team_name"`
 	}
 
 	type PullRequestReviewer struct {
@@ -57,7 +73,33 @@ func setupIntegrationDB(t *testing.T) *gorm.DB {
 		AssignedAt    time.Time `gorm:"column:assigned_at"`
 	}
 
-	err = db.AutoMigrate(&Team{}, &testUser{}, &PullRequest{}, &PullRequestReviewer{})
+	type PullRequestEvent struct {
+		ID             int       `gorm:"primaryKey;autoIncrement"`
+		PullRequestID  string    `gorm:"column:pull_request_id;not null"`
+		EventType      string    `gorm:"column:event_type;not null"`
+		UserID         *string   `gorm:"column:user_id"`
+		PreviousUserID *string   `gorm:"column:previous_user_id"`
+		ActorID        *string   `gorm:"column:actor_id"`
+		Reason         *string   `gorm:"column:reason"`
+		Source         *string   `gorm:"column:source"`
+		CreatedAt      time.Time `gorm:"column:created_at"`
+	}
+
+	type ActivationChange struct {
+		ID          int       `gorm:"primaryKey;autoIncrement"`
+		UserID      string    `gorm:"column:user_id;not null"`
+		OldIsActive bool      `gorm:"column:old_is_active;not null"`
+		NewIsActive bool      `gorm:"column:new_is_active;not null"`
+		Source      string    `gorm:"column:source;not null"`
+		ActorID     *string   `gorm:"column:actor_id"`
+		Reason      *string   `gorm:"column:reason"`
+		TenantID    string    `gorm:"column:tenant_id;not null;default:'default'"`
+		CreatedAt   time.Time `gorm:"column:created_at"`
+	}
+
+	err = db.AutoMigrate(&Team{}, &testUser{}, &PullRequest{}, &PullRequestReviewer{}, &PullRequestEvent{})
+	require.NoError(t, err)
+	err = db.Table("user_activation_history").AutoMigrate(&ActivationChange{})
 	require.NoError(t, err)
 
 	db.Exec("ALTER TABLE test_users RENAME TO users")
@@ -69,15 +111,17 @@ func TestIntegration_SetIsActive(t *testing.T) {
 	db := setupIntegrationDB(t)
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
-	RegisterRoutes(router, db, zap.NewNop().Sugar())
+	RegisterRoutes(router, db, config.UserConfig{}, newReviewerReplacer(db), cursor.New(""), pagination.DefaultLimits(), zap.NewNop().Sugar())
 
 	db.Exec("INSERT INTO teams (team_name) VALUES (?)", "team1")
 	db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
 		"u1", "Alice", "team1", true)
 
 	reqBody := model.SetIsActiveRequest{
-		UserID:   "u1",
-		IsActive: false,
+		UserID:    "u1",
+		IsActive:  false,
+		ChangedBy: "lead",
+		Reason:    "vacation",
 	}
 	jsonBody, _ := json.Marshal(reqBody)
 
@@ -93,13 +137,33 @@ func TestIntegration_SetIsActive(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "u1", resp.User.UserID)
 	assert.False(t, resp.User.IsActive)
+
+	// Repeating the request does not change the flag, so it is not recorded again
+	req = httptest.NewRequest(http.MethodPost, "/users/setIsActive", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/activationHistory?user_id=u1", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var history model.ActivationHistoryResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &history))
+	require.Len(t, history.Changes, 1)
+	change := history.Changes[0]
+	assert.True(t, change.OldIsActive)
+	assert.False(t, change.NewIsActive)
+	assert.Equal(t, model.ActivationSourceSetIsActive, change.Source)
+	require.NotNil(t, change.ActorID)
+	assert.Equal(t, "lead", *change.ActorID)
+	require.NotNil(t, change.Reason)
+	assert.Equal(t, "vacation", *change.Reason)
 }
 
 func TestIntegration_GetReview(t *testing.T) {
 	db := setupIntegrationDB(t)
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
-	RegisterRoutes(router, db, zap.NewNop().Sugar())
+	RegisterRoutes(router, db, config.UserConfig{}, newReviewerReplacer(db), cursor.New(""), pagination.DefaultLimits(), zap.NewNop().Sugar())
 
 	db.Exec("INSERT INTO teams (team_name) VALUES (?)", "team1")
 	db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
@@ -123,3 +187,34 @@ func TestIntegration_GetReview(t *testing.T) {
 	require.Len(t, resp.PullRequests, 1)
 	assert.Equal(t, "pr-1", resp.PullRequests[0].PullRequestID)
 }
+
+func TestIntegration_SearchUsers(t *testing.T) {
+	db := setupIntegrationDB(t)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	RegisterRoutes(router, db, config.UserConfig{}, newReviewerReplacer(db), cursor.New(""), pagination.DefaultLimits(), zap.NewNop().Sugar())
+
+	db.Exec("INSERT INTO teams (team_name) VALUES (?)", "team1")
+	db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
+		"u1", "Alice", "team1", true)
+	db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
+		"u2", "Bob", "team1", true)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/search?q=bo", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp model.SearchUsersResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	require.Len(t, resp.Users, 1)
+	assert.Equal(t, "u2", resp.Users[0].UserID)
+}
+
+// newReviewerReplacer returns the pull request service replacing deactivated reviewers in db.
+func newReviewerReplacer(db *gorm.DB) pullrequestService.Service {
+	logger := zap.NewNop().Sugar()
+	return pullrequestService.New(pullrequestRepo.New(db, logger), db, logger)
+}
@@ -3,8 +3,10 @@ package service
 
 import (
 	"context"
+	cryptorand "crypto/rand"
 	"errors"
-	"math/rand"
+	"fmt"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
@@ -15,8 +17,14 @@ import (
 	teamRepo "github.com/festy23/avito_internship/internal/team/repository"
 	userModel "github.com/festy23/avito_internship/internal/user/model"
 	"github.com/festy23/avito_internship/internal/user/repository"
+	"github.com/festy23/avito_internship/internal/webhook"
+	"github.com/festy23/avito_internship/pkg/clock"
+	"github.com/festy23/avito_internship/pkg/pagination"
 )
 
+// anonymizationSaltSize is the size of the random salt hashed into anonymous user IDs.
+const anonymizationSaltSize = 16
+
 // Service defines the interface for user business logic operations.
 type Service interface {
 	// SetIsActive updates user activity status.
@@ -25,44 +33,113 @@ type Service interface {
 		req *userModel.SetIsActiveRequest,
 	) (*userModel.SetIsActiveResponse, error)
 
-	// GetReview returns PRs assigned to user.
-	GetReview(ctx context.Context, userID string) (*userModel.GetReviewResponse, error)
+	// GetReview returns PRs assigned to user (archived PRs only when query.Archived is true)
+	// in the order requested by query.
+	GetReview(ctx context.Context, userID string, query userModel.ReviewQuery) (*userModel.GetReviewResponse, error)
+
+	// WaitReview returns the active PRs assigned to user once they differ from the set identified by
+	// version, or the unchanged set when the wait times out. An empty version returns immediately.
+	WaitReview(ctx context.Context, userID, version string) (*userModel.WaitReviewResponse, error)
 
-	// BulkDeactivateTeamMembers deactivates all team members and safely reassigns open PRs.
+	// BulkDeactivateTeamMembers deactivates all team members and replaces them in their open reviews.
 	BulkDeactivateTeamMembers(
 		ctx context.Context,
 		req *userModel.BulkDeactivateTeamRequest,
 	) (*userModel.BulkDeactivateTeamResponse, error)
+
+	// SearchUsers finds users by a fragment of user_id or username.
+	SearchUsers(ctx context.Context, query string, limit int) (*userModel.SearchUsersResponse, error)
+
+	// AnonymizeUser replaces the user by an anonymous one in all data, keeping their history.
+	AnonymizeUser(ctx context.Context, req *userModel.AnonymizeUserRequest) (*userModel.AnonymizeUserResponse, error)
+
+	// GetActivationHistory returns the latest is_active changes of a user, newest first.
+	GetActivationHistory(ctx context.Context, userID string, limit int) (*userModel.ActivationHistoryResponse, error)
+}
+
+// ReviewerReplacer replaces deactivated users in the open pull requests they review.
+// The pull request service implements it.
+type ReviewerReplacer interface {
+	ReplaceDeactivatedReviewers(
+		ctx context.Context,
+		deactivate func(tx *gorm.DB) ([]string, error),
+	) ([]string, error)
 }
 
 type service struct {
-	repo            repository.Repository
-	teamRepo        teamRepo.Repository
-	pullrequestRepo pullrequestRepo.Repository
-	db              *gorm.DB
-	logger          *zap.SugaredLogger
+	repo      repository.Repository
+	teamRepo  teamRepo.Repository
+	db        *gorm.DB
+	reviewers ReviewerReplacer
+	clock     clock.Clock
+	limits    pagination.Limits
+	// waitTimeout and waitInterval bound WaitReview and set how often it checks the assigned PRs.
+	waitTimeout  time.Duration
+	waitInterval time.Duration
+	logger       *zap.SugaredLogger
 }
 
-// New creates a new user service instance.
-func New(repo repository.Repository, logger *zap.SugaredLogger) Service {
-	return &service{repo: repo, logger: logger}
+// Option configures an optional dependency of the service.
+type Option func(*service)
+
+// WithTransactions runs multi-step operations in transactions of db and looks teams up in teamRepo.
+// Bulk deactivation and anonymization require it.
+func WithTransactions(db *gorm.DB, teamRepo teamRepo.Repository) Option {
+	return func(s *service) {
+		s.db = db
+		s.teamRepo = teamRepo
+	}
 }
 
-// NewWithDependencies creates a new user service instance with additional dependencies.
-func NewWithDependencies(
-	repo repository.Repository,
-	teamRepo teamRepo.Repository,
-	pullrequestRepo pullrequestRepo.Repository,
-	db *gorm.DB,
-	logger *zap.SugaredLogger,
-) Service {
-	return &service{
-		repo:            repo,
-		teamRepo:        teamRepo,
-		pullrequestRepo: pullrequestRepo,
-		db:              db,
-		logger:          logger,
+// WithReviewerReplacer replaces deactivated users in their open reviews with reviewers. Reassigning open
+// reviews and bulk deactivation require it.
+func WithReviewerReplacer(reviewers ReviewerReplacer) Option {
+	return func(s *service) {
+		s.reviewers = reviewers
+	}
+}
+
+// WithClock takes timestamps of rows written in transactions from clk instead of the system clock.
+func WithClock(clk clock.Clock) Option {
+	return func(s *service) {
+		s.clock = clk
+	}
+}
+
+// WithPageLimits bounds the page size of list operations by limits instead of pagination.DefaultLimits.
+func WithPageLimits(limits pagination.Limits) Option {
+	return func(s *service) {
+		s.limits = limits
+	}
+}
+
+// WithReviewWait makes WaitReview hold for up to timeout, checking the assigned PRs every interval.
+// Zero values keep userModel.DefaultReviewWaitTimeout and userModel.DefaultReviewWaitInterval.
+func WithReviewWait(timeout, interval time.Duration) Option {
+	return func(s *service) {
+		if timeout > 0 {
+			s.waitTimeout = timeout
+		}
+		if interval > 0 {
+			s.waitInterval = interval
+		}
+	}
+}
+
+// New creates a new user service instance.
+func New(repo repository.Repository, logger *zap.SugaredLogger, opts ...Option) Service {
+	s := &service{
+		repo:         repo,
+		clock:        clock.New(),
+		limits:       pagination.DefaultLimits(),
+		waitTimeout:  userModel.DefaultReviewWaitTimeout,
+		waitInterval: userModel.DefaultReviewWaitInterval,
+		logger:       logger,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // SetIsActive updates user activity status.
@@ -77,7 +154,16 @@ func (s *service) SetIsActive(
 		return nil, userModel.ErrUserNotFound
 	}
 
-	user, err := s.repo.UpdateIsActive(ctx, req.UserID, req.IsActive)
+	if req.ReassignOpenReviews && !req.IsActive {
+		return s.deactivateAndReassign(ctx, req)
+	}
+
+	var user *userModel.User
+	err := s.inTransaction(ctx, func(repo repository.Repository) error {
+		var updateErr error
+		user, updateErr = updateIsActive(ctx, repo, req)
+		return updateErr
+	})
 	if err != nil {
 		s.logger.Errorw(
 			"SetIsActive failed",
@@ -95,279 +181,381 @@ func (s *service) SetIsActive(
 	return &userModel.SetIsActiveResponse{User: *user}, nil
 }
 
-// GetReview returns PRs assigned to user.
+// inTransaction runs fn with a repository bound to a new transaction, or with the service repository
+// when the service has no database.
+func (s *service) inTransaction(ctx context.Context, fn func(repo repository.Repository) error) error {
+	if s.db == nil {
+		return fn(s.repo)
+	}
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(repository.NewWithClock(tx, s.clock, s.logger))
+	})
+}
+
+// updateIsActive sets the is_active flag of the user of req and records the change in the activation
+// history. The user row is locked first, so concurrent requests record the actual previous value.
+func updateIsActive(
+	ctx context.Context,
+	repo repository.Repository,
+	req *userModel.SetIsActiveRequest,
+) (*userModel.User, error) {
+	current, err := repo.GetByIDForUpdate(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	user, err := repo.UpdateIsActive(ctx, req.UserID, req.IsActive)
+	if err != nil {
+		return nil, err
+	}
+	if current.IsActive != req.IsActive {
+		change := userModel.NewActivationChange(req.UserID, current.IsActive, req.IsActive,
+			userModel.ActivationSourceSetIsActive, req.ChangedBy, req.Reason)
+		if err = repo.RecordActivationChanges(ctx, []userModel.ActivationChange{change}); err != nil {
+			return nil, err
+		}
+	}
+	return user, nil
+}
+
+// deactivateAndReassign deactivates a user and replaces them in their open reviews in one transaction.
+func (s *service) deactivateAndReassign(
+	ctx context.Context,
+	req *userModel.SetIsActiveRequest,
+) (*userModel.SetIsActiveResponse, error) {
+	if s.reviewers == nil {
+		return nil, errors.New("reassign_open_reviews is not supported by this service")
+	}
+
+	userID := req.UserID
+	var user *userModel.User
+	reassignedPRs, err := s.reviewers.ReplaceDeactivatedReviewers(ctx, func(tx *gorm.DB) ([]string, error) {
+		var updateErr error
+		user, updateErr = updateIsActive(ctx, repository.NewWithClock(tx, s.clock, s.logger), req)
+		if updateErr != nil {
+			return nil, updateErr
+		}
+		return []string{userID}, nil
+	})
+	if err != nil {
+		s.logger.Errorw("SetIsActive with reassignment failed", "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	s.logger.Infow("SetIsActive completed with reassignment",
+		"user_id", userID,
+		"reassigned_pr_count", len(reassignedPRs))
+	return &userModel.SetIsActiveResponse{User: *user, ReassignedPRs: reassignedPRs}, nil
+}
+
+// GetReview returns PRs assigned to user (archived PRs only when query.Archived is true)
+// in the order requested by query.
 func (s *service) GetReview(
 	ctx context.Context,
 	userID string,
+	query userModel.ReviewQuery,
 ) (*userModel.GetReviewResponse, error) {
-	s.logger.Debugw("GetReview called", "user_id", userID)
+	s.logger.Debugw("GetReview called", "user_id", userID, "archived", query.Archived)
 
 	if userID == "" {
 		s.logger.Debugw("GetReview validation failed", "error", "empty user_id")
 		return nil, userModel.ErrUserNotFound
 	}
 
-	prs, err := s.repo.GetAssignedPullRequests(ctx, userID)
+	// Without a limit all PRs are returned, as the original API did
+	if query.Limit != 0 {
+		if err := s.limits.Check(query.Limit); err != nil {
+			return nil, err
+		}
+	}
+
+	// One extra PR tells whether another page follows
+	repoQuery := query
+	if query.Limit > 0 {
+		repoQuery.Limit = query.Limit + 1
+	}
+
+	prs, err := s.repo.GetAssignedPullRequests(ctx, userID, repoQuery)
 	if err != nil {
 		s.logger.Errorw("GetReview failed", "user_id", userID, "error", err)
 		return nil, err
 	}
 
-	s.logger.Infow("GetReview completed", "user_id", userID, "pr_count", len(prs))
-	return &userModel.GetReviewResponse{
+	resp := &userModel.GetReviewResponse{
 		UserID:       userID,
 		PullRequests: prs,
-	}, nil
+	}
+	if query.Limit > 0 && len(prs) > query.Limit {
+		resp.PullRequests = prs[:query.Limit]
+		last := resp.PullRequests[query.Limit-1]
+		resp.Next = &userModel.ReviewPosition{CreatedAt: last.CreatedAt, PullRequestID: last.PullRequestID}
+	}
+
+	s.logger.Infow("GetReview completed", "user_id", userID, "pr_count", len(resp.PullRequests))
+	return resp, nil
 }
 
-// BulkDeactivateTeamMembers deactivates all team members and safely reassigns open PRs.
-//
-//nolint:gocognit,funlen // Complex business logic with multiple steps
-func (s *service) BulkDeactivateTeamMembers(
+// WaitReview returns the active PRs assigned to user once they differ from the set identified by
+// version, or the unchanged set when the wait times out. The PRs are read from the database every
+// waitInterval, so changes made by any replica are noticed. A wait cut short by ctx (e.g. a handler
+// timeout) ends like a timed out one.
+func (s *service) WaitReview(
 	ctx context.Context,
-	req *userModel.BulkDeactivateTeamRequest,
-) (*userModel.BulkDeactivateTeamResponse, error) {
-	s.logger.Infow("BulkDeactivateTeamMembers called", "team_name", req.TeamName)
+	userID string,
+	version string,
+) (*userModel.WaitReviewResponse, error) {
+	s.logger.Debugw("WaitReview called", "user_id", userID, "version", version)
 
-	if req.TeamName == "" {
-		return nil, errors.New("team_name is required")
+	if userID == "" {
+		s.logger.Debugw("WaitReview validation failed", "error", "empty user_id")
+		return nil, userModel.ErrUserNotFound
 	}
 
-	// Check if team exists
-	_, err := s.teamRepo.GetByName(ctx, req.TeamName)
-	if err != nil {
-		if errors.Is(err, teamModel.ErrTeamNotFound) {
-			return nil, err
-		}
-		s.logger.Errorw(
-			"BulkDeactivateTeamMembers failed to get team",
-			"team_name",
-			req.TeamName,
-			"error",
-			err,
-		)
-		return nil, err
+	resp, err := s.reviewQueue(ctx, userID, version)
+	if err != nil || resp.Changed {
+		return resp, err
 	}
 
-	var result *userModel.BulkDeactivateTeamResponse
-
-	// Use transaction to ensure atomicity
-	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		txUserRepo := repository.New(tx, s.logger)
-		txPRRepo := pullrequestRepo.New(tx, s.logger)
-
-		// Get active team members BEFORE deactivating (to get candidates before they're deactivated)
-		activeCandidates, candidatesErr := txPRRepo.GetActiveTeamMembers(ctx, req.TeamName, "")
-		if candidatesErr != nil {
-			return candidatesErr
-		}
-
-		// Bulk deactivate (this also returns deactivated user IDs)
-		deactivatedUserIDs, deactivateErr := txUserRepo.BulkDeactivateTeamMembers(ctx, req.TeamName)
-		if deactivateErr != nil {
-			return deactivateErr
-		}
-
-		if len(deactivatedUserIDs) == 0 {
-			result = &userModel.BulkDeactivateTeamResponse{
-				TeamName:          req.TeamName,
-				DeactivatedUsers:  []string{},
-				ReassignedPRs:     []string{},
-				DeactivatedCount:  0,
-				ReassignedPRCount: 0,
+	timeout := time.NewTimer(s.waitTimeout)
+	defer timeout.Stop()
+	ticker := time.NewTicker(s.waitInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return resp, nil
+		case <-timeout.C:
+			return resp, nil
+		case <-ticker.C:
+			next, err := s.reviewQueue(ctx, userID, version)
+			if err != nil && ctx.Err() != nil {
+				// ctx ended while the PRs were read
+				return resp, nil
 			}
-			return nil
-		}
-
-		// Get open PRs with authors (optimized: single query)
-		prAuthors, prsErr := txPRRepo.GetOpenPRsWithAuthors(ctx, deactivatedUserIDs)
-		if prsErr != nil {
-			return prsErr
-		}
-
-		if len(prAuthors) == 0 {
-			result = &userModel.BulkDeactivateTeamResponse{
-				TeamName:          req.TeamName,
-				DeactivatedUsers:  deactivatedUserIDs,
-				ReassignedPRs:     []string{},
-				DeactivatedCount:  len(deactivatedUserIDs),
-				ReassignedPRCount: 0,
+			if err != nil || next.Changed {
+				return next, err
 			}
-			return nil
+			resp = next
 		}
+	}
+}
 
-		// Reassign reviewers for each PR
-		reassignedPRs := make([]string, 0)
-		for prID, authorID := range prAuthors {
-			reassignErr := s.reassignDeactivatedReviewersOptimized(
-				ctx, txPRRepo, prID, authorID, deactivatedUserIDs, activeCandidates)
-			if reassignErr != nil {
-				// Log error but continue with other PRs
-				s.logger.Warnw(
-					"BulkDeactivateTeamMembers failed to reassign PR",
-					"pr_id",
-					prID,
-					"error",
-					reassignErr,
-				)
-				continue
-			}
-			reassignedPRs = append(reassignedPRs, prID)
+// reviewQueue returns the active PRs assigned to user, compared with the set identified by version.
+func (s *service) reviewQueue(
+	ctx context.Context,
+	userID string,
+	version string,
+) (*userModel.WaitReviewResponse, error) {
+	prs, err := s.repo.GetAssignedPullRequests(ctx, userID, userModel.ReviewQuery{})
+	if err != nil {
+		if ctx.Err() == nil {
+			s.logger.Errorw("WaitReview failed", "user_id", userID, "error", err)
 		}
+		return nil, err
+	}
 
-		result = &userModel.BulkDeactivateTeamResponse{
-			TeamName:          req.TeamName,
-			DeactivatedUsers:  deactivatedUserIDs,
-			ReassignedPRs:     reassignedPRs,
-			DeactivatedCount:  len(deactivatedUserIDs),
-			ReassignedPRCount: len(reassignedPRs),
-		}
+	current := userModel.ReviewVersion(prs)
+	return &userModel.WaitReviewResponse{
+		UserID:       userID,
+		PullRequests: prs,
+		Version:      current,
+		Changed:      current != version,
+	}, nil
+}
 
-		return nil
-	})
+// SearchUsers finds users by a fragment of user_id or username.
+// A zero limit falls back to the default page size.
+func (s *service) SearchUsers(
+	ctx context.Context,
+	query string,
+	limit int,
+) (*userModel.SearchUsersResponse, error) {
+	s.logger.Debugw("SearchUsers called", "query", query, "limit", limit)
+
+	query = strings.TrimSpace(query)
+	if len(query) == 0 || len(query) > 255 {
+		return nil, userModel.ErrInvalidSearchQuery
+	}
 
+	limit, err := s.limits.Resolve(limit)
 	if err != nil {
-		s.logger.Errorw("BulkDeactivateTeamMembers failed", "team_name", req.TeamName, "error", err)
 		return nil, err
 	}
 
-	s.logger.Infow("BulkDeactivateTeamMembers completed",
-		"team_name", req.TeamName,
-		"deactivated_count", result.DeactivatedCount,
-		"reassigned_pr_count", result.ReassignedPRCount)
+	users, err := s.repo.Search(ctx, query, limit)
+	if err != nil {
+		s.logger.Errorw("SearchUsers failed", "query", query, "error", err)
+		return nil, err
+	}
 
-	return result, nil
+	s.logger.Infow("SearchUsers completed", "query", query, "count", len(users))
+	return &userModel.SearchUsersResponse{
+		Query: query,
+		Users: users,
+	}, nil
 }
 
-// reassignDeactivatedReviewersOptimized reassigns deactivated reviewers in a PR (optimized version).
-//
-//nolint:gocognit,gocyclo // Complex business logic with multiple steps and filtering
-func (s *service) reassignDeactivatedReviewersOptimized(
+// GetActivationHistory returns the latest is_active changes of a user, newest first.
+// A zero limit falls back to the default page size.
+func (s *service) GetActivationHistory(
 	ctx context.Context,
-	prRepo pullrequestRepo.Repository,
-	prID string,
-	authorID string,
-	deactivatedUserIDs []string,
-	activeCandidates []userModel.User,
-) error {
-	// Get current reviewers
-	reviewers, err := prRepo.GetReviewers(ctx, prID)
+	userID string,
+	limit int,
+) (*userModel.ActivationHistoryResponse, error) {
+	s.logger.Debugw("GetActivationHistory called", "user_id", userID, "limit", limit)
+
+	limit, err := s.limits.Resolve(limit)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Find deactivated reviewers in this PR
-	deactivatedInPR := make([]string, 0)
-	deactivatedSet := make(map[string]bool, len(deactivatedUserIDs))
-	for _, id := range deactivatedUserIDs {
-		deactivatedSet[id] = true
+	// The history of a missing user is empty, but the client most likely mistyped the ID
+	if _, err := s.repo.GetByID(ctx, userID); err != nil {
+		return nil, err
 	}
 
-	for _, reviewerID := range reviewers {
-		if deactivatedSet[reviewerID] {
-			deactivatedInPR = append(deactivatedInPR, reviewerID)
-		}
+	changes, err := s.repo.GetActivationHistory(ctx, userID, limit)
+	if err != nil {
+		s.logger.Errorw("GetActivationHistory failed", "user_id", userID, "error", err)
+		return nil, err
 	}
 
-	if len(deactivatedInPR) == 0 {
-		return nil // No deactivated reviewers in this PR
+	return &userModel.ActivationHistoryResponse{UserID: userID, Changes: changes}, nil
+}
+
+// AnonymizeUser replaces the user by an anonymous one in all data in one transaction. The user's
+// pull requests, reviews, activity log entries, activation history and team leadership are moved to an
+// inactive user named by a salted hash of the user ID, so statistics are kept while the user can no longer
+// be identified.
+func (s *service) AnonymizeUser(
+	ctx context.Context,
+	req *userModel.AnonymizeUserRequest,
+) (*userModel.AnonymizeUserResponse, error) {
+	if s.db == nil {
+		return nil, errors.New("anonymization is not supported by this service")
 	}
 
-	// Filter candidates: exclude author, already assigned reviewers, and deactivated users
-	reviewerSet := make(map[string]bool, len(reviewers))
-	for _, reviewerID := range reviewers {
-		reviewerSet[reviewerID] = true
+	salt := make([]byte, anonymizationSaltSize)
+	if _, err := cryptorand.Read(salt); err != nil {
+		return nil, fmt.Errorf("generate anonymization salt: %w", err)
 	}
+	anonymousID := userModel.AnonymousID(req.UserID, salt)
+
+	var result *userModel.AnonymizeUserResponse
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txUserRepo := repository.NewWithClock(tx, s.clock, s.logger)
+		txTeamRepo := teamRepo.NewWithClock(tx, s.clock, s.logger)
+		txPRRepo := pullrequestRepo.NewWithClock(tx, s.clock, s.logger)
+		txWebhookRepo := webhook.NewRepository(tx, s.logger)
+
+		user, getErr := txUserRepo.GetByID(ctx, req.UserID)
+		if getErr != nil {
+			return getErr
+		}
+		anonymized, createErr := txUserRepo.CreateAnonymized(ctx, user, anonymousID)
+		if createErr != nil {
+			return createErr
+		}
 
-	filteredCandidates := make([]userModel.User, 0, len(activeCandidates))
-	for _, candidate := range activeCandidates {
-		// Skip author
-		if candidate.UserID == authorID {
-			continue
+		// References are moved before the user is deleted: they are restricted by foreign keys
+		if replaceErr := txTeamRepo.ReplaceLead(ctx, user.UserID, anonymousID); replaceErr != nil {
+			return replaceErr
+		}
+		if replaceErr := txPRRepo.ReplaceUser(ctx, user.UserID, anonymousID); replaceErr != nil {
+			return replaceErr
+		}
+		if _, replaceErr := txWebhookRepo.ReplaceUser(ctx, user.UserID, anonymousID); replaceErr != nil {
+			return replaceErr
 		}
-		// Skip already assigned reviewers
-		if reviewerSet[candidate.UserID] {
-			continue
+		// The activation history would be deleted together with the user
+		if replaceErr := txUserRepo.ReplaceUser(ctx, user.UserID, anonymousID); replaceErr != nil {
+			return replaceErr
 		}
-		// Skip deactivated users (they were in activeCandidates before deactivation)
-		if deactivatedSet[candidate.UserID] {
-			continue
+		if deleteErr := txUserRepo.Delete(ctx, user.UserID); deleteErr != nil {
+			return deleteErr
 		}
-		filteredCandidates = append(filteredCandidates, candidate)
+
+		result = &userModel.AnonymizeUserResponse{AnonymousID: anonymousID, TeamName: anonymized.TeamName}
+		return nil
+	})
+	if err != nil {
+		s.logger.Errorw("AnonymizeUser failed", "error", err)
+		return nil, err
 	}
 
-	// Reassign each deactivated reviewer
-	for _, deactivatedID := range deactivatedInPR {
-		if len(filteredCandidates) == 0 {
-			// No candidates available, just remove the reviewer
-			if removeErr := prRepo.RemoveReviewer(ctx, prID, deactivatedID); removeErr != nil {
-				return removeErr
-			}
-			continue
-		}
+	// The original user ID is not logged
+	s.logger.Infow("AnonymizeUser completed", "anonymous_id", anonymousID)
+	return result, nil
+}
 
-		// Select random replacement
-		selected := selectRandomReviewers(filteredCandidates, 1)
-		if len(selected) == 0 {
-			// No candidates, just remove
-			if removeErr := prRepo.RemoveReviewer(ctx, prID, deactivatedID); removeErr != nil {
-				return removeErr
-			}
-			continue
-		}
+// BulkDeactivateTeamMembers deactivates all team members and replaces them in their open reviews
+// in one transaction.
+func (s *service) BulkDeactivateTeamMembers(
+	ctx context.Context,
+	req *userModel.BulkDeactivateTeamRequest,
+) (*userModel.BulkDeactivateTeamResponse, error) {
+	s.logger.Infow("BulkDeactivateTeamMembers called", "team_name", req.TeamName)
 
-		newReviewerID := selected[0].UserID
+	if req.TeamName == "" {
+		return nil, errors.New("team_name is required")
+	}
+	if s.teamRepo == nil || s.reviewers == nil {
+		return nil, errors.New("bulk deactivation is not supported by this service")
+	}
 
-		// Remove old and assign new
-		if removeErr := prRepo.RemoveReviewer(ctx, prID, deactivatedID); removeErr != nil {
-			return removeErr
+	// Check if team exists
+	_, err := s.teamRepo.GetByName(ctx, req.TeamName)
+	if err != nil {
+		if errors.Is(err, teamModel.ErrTeamNotFound) {
+			return nil, err
 		}
+		s.logger.Errorw(
+			"BulkDeactivateTeamMembers failed to get team",
+			"team_name",
+			req.TeamName,
+			"error",
+			err,
+		)
+		return nil, err
+	}
 
-		if assignErr := prRepo.AssignReviewer(ctx, prID, newReviewerID); assignErr != nil {
-			// If assignment fails (e.g., max reviewers), just continue
-			s.logger.Debugw(
-				"BulkDeactivateTeamMembers failed to assign reviewer",
-				"pr_id",
-				prID,
-				"user_id",
-				newReviewerID,
-				"error",
-				assignErr,
-			)
-		}
+	deactivatedUserIDs := []string{}
+	reassignedPRs, err := s.reviewers.ReplaceDeactivatedReviewers(ctx, func(tx *gorm.DB) ([]string, error) {
+		txUserRepo := repository.NewWithClock(tx, s.clock, s.logger)
 
-		// Remove assigned reviewer from candidates for next iteration
-		for i, candidate := range filteredCandidates {
-			if candidate.UserID == newReviewerID {
-				filteredCandidates = append(filteredCandidates[:i], filteredCandidates[i+1:]...)
-				break
-			}
+		userIDs, deactivateErr := txUserRepo.BulkDeactivateTeamMembers(ctx, req.TeamName)
+		if deactivateErr != nil {
+			return nil, deactivateErr
 		}
-	}
 
-	return nil
-}
+		changes := make([]userModel.ActivationChange, 0, len(userIDs))
+		for _, userID := range userIDs {
+			changes = append(changes, userModel.NewActivationChange(userID, true, false,
+				userModel.ActivationSourceBulkDeactivate, req.ChangedBy, req.Reason))
+		}
+		if recordErr := txUserRepo.RecordActivationChanges(ctx, changes); recordErr != nil {
+			return nil, recordErr
+		}
 
-// selectRandomReviewers selects up to maxCount random reviewers from candidates.
-func selectRandomReviewers(candidates []userModel.User, maxCount int) []userModel.User {
-	if len(candidates) == 0 {
-		return []userModel.User{}
+		deactivatedUserIDs = append(deactivatedUserIDs, userIDs...)
+		return userIDs, nil
+	})
+	if err != nil {
+		s.logger.Errorw("BulkDeactivateTeamMembers failed", "team_name", req.TeamName, "error", err)
+		return nil, err
 	}
 
-	count := maxCount
-	if len(candidates) < maxCount {
-		count = len(candidates)
+	result := &userModel.BulkDeactivateTeamResponse{
+		TeamName:          req.TeamName,
+		DeactivatedUsers:  deactivatedUserIDs,
+		ReassignedPRs:     reassignedPRs,
+		DeactivatedCount:  len(deactivatedUserIDs),
+		ReassignedPRCount: len(reassignedPRs),
 	}
 
-	candidatesCopy := make([]userModel.User, len(candidates))
-	copy(candidatesCopy, candidates)
-
-	//nolint:gosec // G404: math/rand is sufficient for reviewer selection
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-	for i := len(candidatesCopy) - 1; i > 0; i-- {
-		j := r.Intn(i + 1)
-		candidatesCopy[i], candidatesCopy[j] = candidatesCopy[j], candidatesCopy[i]
-	}
+	s.logger.Infow("BulkDeactivateTeamMembers completed",
+		"team_name", req.TeamName,
+		"deactivated_count", result.DeactivatedCount,
+		"reassigned_pr_count", result.ReassignedPRCount)
 
-	return candidatesCopy[:count]
+	return result, nil
 }
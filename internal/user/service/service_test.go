@@ -4,19 +4,25 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
-	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 
+	pullrequestModel "github.com/festy23/avito_internship/internal/pullrequest/model"
 	pullrequestRepo "github.com/festy23/avito_internship/internal/pullrequest/repository"
+	pullrequestService "github.com/festy23/avito_internship/internal/pullrequest/service"
 	teamModel "github.com/festy23/avito_internship/internal/team/model"
 	teamRepo "github.com/festy23/avito_internship/internal/team/repository"
+	"github.com/festy23/avito_internship/internal/testutil"
 	userModel "github.com/festy23/avito_internship/internal/user/model"
 	"github.com/festy23/avito_internship/internal/user/repository"
+	"github.com/festy23/avito_internship/pkg/clock"
+	"github.com/festy23/avito_internship/pkg/pagination"
+	"github.com/festy23/avito_internship/pkg/sortparam"
 )
 
 type mockRepository struct {
@@ -31,6 +37,14 @@ func (m *mockRepository) GetByID(ctx context.Context, userID string) (*userModel
 	return args.Get(0).(*userModel.User), args.Error(1)
 }
 
+func (m *mockRepository) GetByIDForUpdate(ctx context.Context, userID string) (*userModel.User, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userModel.User), args.Error(1)
+}
+
 func (m *mockRepository) UpdateIsActive(
 	ctx context.Context,
 	userID string,
@@ -46,8 +60,9 @@ func (m *mockRepository) UpdateIsActive(
 func (m *mockRepository) GetAssignedPullRequests(
 	ctx context.Context,
 	userID string,
+	query userModel.ReviewQuery,
 ) ([]userModel.PullRequestShort, error) {
-	args := m.Called(ctx, userID)
+	args := m.Called(ctx, userID, query)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -73,6 +88,53 @@ func (m *mockRepository) GetTeamMemberIDs(ctx context.Context, teamName string)
 	return args.Get(0).([]string), args.Error(1)
 }
 
+func (m *mockRepository) Search(ctx context.Context, query string, limit int) ([]userModel.User, error) {
+	args := m.Called(ctx, query, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]userModel.User), args.Error(1)
+}
+
+func (m *mockRepository) CreateAnonymized(
+	ctx context.Context,
+	user *userModel.User,
+	anonymousID string,
+) (*userModel.User, error) {
+	args := m.Called(ctx, user, anonymousID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userModel.User), args.Error(1)
+}
+
+func (m *mockRepository) Delete(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *mockRepository) RecordActivationChanges(ctx context.Context, changes []userModel.ActivationChange) error {
+	args := m.Called(ctx, changes)
+	return args.Error(0)
+}
+
+func (m *mockRepository) GetActivationHistory(
+	ctx context.Context,
+	userID string,
+	limit int,
+) ([]userModel.ActivationChange, error) {
+	args := m.Called(ctx, userID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]userModel.ActivationChange), args.Error(1)
+}
+
+func (m *mockRepository) ReplaceUser(ctx context.Context, oldUserID, newUserID string) error {
+	args := m.Called(ctx, oldUserID, newUserID)
+	return args.Error(0)
+}
+
 func TestService_SetIsActive(t *testing.T) {
 	ctx := context.Background()
 
@@ -81,8 +143,9 @@ func TestService_SetIsActive(t *testing.T) {
 		svc := New(mockRepo, zap.NewNop().Sugar())
 
 		req := &userModel.SetIsActiveRequest{
-			UserID:   "u1",
-			IsActive: false,
+			UserID:    "u1",
+			IsActive:  false,
+			ChangedBy: "lead",
 		}
 
 		expectedUser := &userModel.User{
@@ -92,7 +155,11 @@ func TestService_SetIsActive(t *testing.T) {
 			IsActive: false,
 		}
 
+		mockRepo.On("GetByIDForUpdate", ctx, "u1").Return(&userModel.User{UserID: "u1", IsActive: true}, nil)
 		mockRepo.On("UpdateIsActive", ctx, "u1", false).Return(expectedUser, nil)
+		mockRepo.On("RecordActivationChanges", ctx, []userModel.ActivationChange{
+			userModel.NewActivationChange("u1", true, false, userModel.ActivationSourceSetIsActive, "lead", ""),
+		}).Return(nil)
 
 		resp, err := svc.SetIsActive(ctx, req)
 
@@ -112,8 +179,7 @@ func TestService_SetIsActive(t *testing.T) {
 			IsActive: false,
 		}
 
-		mockRepo.On("UpdateIsActive", ctx, "nonexistent", false).
-			Return(nil, userModel.ErrUserNotFound)
+		mockRepo.On("GetByIDForUpdate", ctx, "nonexistent").Return(nil, userModel.ErrUserNotFound)
 
 		resp, err := svc.SetIsActive(ctx, req)
 
@@ -148,6 +214,7 @@ func TestService_SetIsActive(t *testing.T) {
 		}
 
 		repoErr := errors.New("database error")
+		mockRepo.On("GetByIDForUpdate", ctx, "u1").Return(&userModel.User{UserID: "u1", IsActive: true}, nil)
 		mockRepo.On("UpdateIsActive", ctx, "u1", false).Return(nil, repoErr)
 
 		resp, err := svc.SetIsActive(ctx, req)
@@ -156,6 +223,21 @@ func TestService_SetIsActive(t *testing.T) {
 		assert.ErrorIs(t, err, repoErr)
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("unchanged flag is not recorded", func(t *testing.T) {
+		mockRepo := new(mockRepository)
+		svc := New(mockRepo, zap.NewNop().Sugar())
+
+		user := &userModel.User{UserID: "u1", IsActive: true}
+		mockRepo.On("GetByIDForUpdate", ctx, "u1").Return(user, nil)
+		mockRepo.On("UpdateIsActive", ctx, "u1", true).Return(user, nil)
+
+		_, err := svc.SetIsActive(ctx, &userModel.SetIsActiveRequest{UserID: "u1", IsActive: true})
+
+		require.NoError(t, err)
+		mockRepo.AssertNotCalled(t, "RecordActivationChanges", mock.Anything, mock.Anything)
+		mockRepo.AssertExpectations(t)
+	})
 }
 
 func TestService_GetReview(t *testing.T) {
@@ -180,9 +262,9 @@ func TestService_GetReview(t *testing.T) {
 			},
 		}
 
-		mockRepo.On("GetAssignedPullRequests", ctx, "u1").Return(expectedPRs, nil)
+		mockRepo.On("GetAssignedPullRequests", ctx, "u1", userModel.ReviewQuery{}).Return(expectedPRs, nil)
 
-		resp, err := svc.GetReview(ctx, "u1")
+		resp, err := svc.GetReview(ctx, "u1", userModel.ReviewQuery{})
 
 		require.NoError(t, err)
 		assert.Equal(t, "u1", resp.UserID)
@@ -196,10 +278,10 @@ func TestService_GetReview(t *testing.T) {
 		mockRepo := new(mockRepository)
 		svc := New(mockRepo, zap.NewNop().Sugar())
 
-		mockRepo.On("GetAssignedPullRequests", ctx, "u1").
+		mockRepo.On("GetAssignedPullRequests", ctx, "u1", userModel.ReviewQuery{}).
 			Return([]userModel.PullRequestShort{}, nil)
 
-		resp, err := svc.GetReview(ctx, "u1")
+		resp, err := svc.GetReview(ctx, "u1", userModel.ReviewQuery{})
 
 		require.NoError(t, err)
 		assert.Equal(t, "u1", resp.UserID)
@@ -211,7 +293,7 @@ func TestService_GetReview(t *testing.T) {
 		mockRepo := new(mockRepository)
 		svc := New(mockRepo, zap.NewNop().Sugar())
 
-		resp, err := svc.GetReview(ctx, "")
+		resp, err := svc.GetReview(ctx, "", userModel.ReviewQuery{})
 
 		assert.Nil(t, resp)
 		assert.ErrorIs(t, err, userModel.ErrUserNotFound)
@@ -223,51 +305,65 @@ func TestService_GetReview(t *testing.T) {
 		svc := New(mockRepo, zap.NewNop().Sugar())
 
 		repoErr := errors.New("database error")
-		mockRepo.On("GetAssignedPullRequests", ctx, "u1").Return(nil, repoErr)
+		mockRepo.On("GetAssignedPullRequests", ctx, "u1", userModel.ReviewQuery{}).Return(nil, repoErr)
 
-		resp, err := svc.GetReview(ctx, "u1")
+		resp, err := svc.GetReview(ctx, "u1", userModel.ReviewQuery{})
 
 		assert.Nil(t, resp)
 		assert.ErrorIs(t, err, repoErr)
 		mockRepo.AssertExpectations(t)
 	})
-}
 
-func setupTestDBForBulkDeactivate(t *testing.T) *gorm.DB {
-	t.Helper()
+	t.Run("limit trims the page and sets the next position", func(t *testing.T) {
+		mockRepo := new(mockRepository)
+		svc := New(mockRepo, zap.NewNop().Sugar())
 
-	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
-	require.NoError(t, err)
+		created := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+		prs := []userModel.PullRequestShort{
+			{PullRequestID: "pr-3", CreatedAt: created.Add(2 * time.Hour)},
+			{PullRequestID: "pr-2", CreatedAt: created.Add(time.Hour)},
+			{PullRequestID: "pr-1", CreatedAt: created},
+		}
+		mockRepo.On("GetAssignedPullRequests", ctx, "u1", userModel.ReviewQuery{Limit: 3}).Return(prs, nil)
 
-	// Create tables
-	type Team struct {
-		TeamName string `gorm:"primaryKey;column:team_name"`
-	}
+		resp, err := svc.GetReview(ctx, "u1", userModel.ReviewQuery{Limit: 2})
 
-	type User struct {
-		UserID   string `gorm:"primaryKey;column:user_id"`
-		Username string `gorm:"column:username;not null"`
-		TeamName string `gorm:"column:team_name;not null"`
-		IsActive bool   `gorm:"column:is_active;not null;default:true"`
-	}
+		require.NoError(t, err)
+		require.Len(t, resp.PullRequests, 2)
+		assert.Equal(t, &userModel.ReviewPosition{CreatedAt: created.Add(time.Hour), PullRequestID: "pr-2"}, resp.Next)
+		mockRepo.AssertExpectations(t)
+	})
 
-	type PullRequest struct {
-		PullRequestID   string `gorm:"primaryKey;column:pull_request_id"`
-		PullRequestName string `gorm:"column:pull_request_name;not null"`
-		AuthorID        string `gorm:"column:author_id;not null"`
-		Status          string `gorm:"column:status;not null"`
-	}
+	t.Run("last page has no next position", func(t *testing.T) {
+		mockRepo := new(mockRepository)
+		svc := New(mockRepo, zap.NewNop().Sugar())
 
-	type PullRequestReviewer struct {
-		ID            int    `gorm:"primaryKey;autoIncrement"`
-		PullRequestID string `gorm:"column:pull_request_id;not null"`
-		UserID        string `gorm:"column:user_id;not null"`
-	}
+		prs := []userModel.PullRequestShort{{PullRequestID: "pr-1"}}
+		mockRepo.On("GetAssignedPullRequests", ctx, "u1", userModel.ReviewQuery{Limit: 3}).Return(prs, nil)
 
-	err = db.AutoMigrate(&Team{}, &User{}, &PullRequest{}, &PullRequestReviewer{})
-	require.NoError(t, err)
+		resp, err := svc.GetReview(ctx, "u1", userModel.ReviewQuery{Limit: 2})
+
+		require.NoError(t, err)
+		assert.Len(t, resp.PullRequests, 1)
+		assert.Nil(t, resp.Next)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		mockRepo := new(mockRepository)
+		svc := New(mockRepo, zap.NewNop().Sugar())
+
+		_, err := svc.GetReview(ctx, "u1", userModel.ReviewQuery{Limit: pagination.DefaultMaxLimit + 1})
+
+		assert.ErrorIs(t, err, pagination.ErrInvalidLimit)
+		mockRepo.AssertNotCalled(t, "GetAssignedPullRequests")
+	})
+}
+
+func setupTestDBForBulkDeactivate(t *testing.T) *gorm.DB {
+	t.Helper()
 
-	return db
+	// Reviewers are replaced by the pull request service, which needs the full pull request schema
+	return testutil.NewDB(t)
 }
 
 func TestService_BulkDeactivateTeamMembers(t *testing.T) {
@@ -277,8 +373,8 @@ func TestService_BulkDeactivateTeamMembers(t *testing.T) {
 		db := setupTestDBForBulkDeactivate(t)
 		userRepo := repository.New(db, zap.NewNop().Sugar())
 		teamRepoInstance := teamRepo.New(db, zap.NewNop().Sugar())
-		prRepo := pullrequestRepo.New(db, zap.NewNop().Sugar())
-		svc := NewWithDependencies(userRepo, teamRepoInstance, prRepo, db, zap.NewNop().Sugar())
+		svc := New(userRepo, zap.NewNop().Sugar(), WithTransactions(db, teamRepoInstance),
+			WithReviewerReplacer(newReviewerReplacer(db, false)))
 
 		req := &userModel.BulkDeactivateTeamRequest{
 			TeamName: "",
@@ -295,8 +391,8 @@ func TestService_BulkDeactivateTeamMembers(t *testing.T) {
 		db := setupTestDBForBulkDeactivate(t)
 		userRepo := repository.New(db, zap.NewNop().Sugar())
 		teamRepoInstance := teamRepo.New(db, zap.NewNop().Sugar())
-		prRepo := pullrequestRepo.New(db, zap.NewNop().Sugar())
-		svc := NewWithDependencies(userRepo, teamRepoInstance, prRepo, db, zap.NewNop().Sugar())
+		svc := New(userRepo, zap.NewNop().Sugar(), WithTransactions(db, teamRepoInstance),
+			WithReviewerReplacer(newReviewerReplacer(db, false)))
 
 		req := &userModel.BulkDeactivateTeamRequest{
 			TeamName: "nonexistent",
@@ -313,8 +409,8 @@ func TestService_BulkDeactivateTeamMembers(t *testing.T) {
 		db := setupTestDBForBulkDeactivate(t)
 		userRepo := repository.New(db, zap.NewNop().Sugar())
 		teamRepoInstance := teamRepo.New(db, zap.NewNop().Sugar())
-		prRepo := pullrequestRepo.New(db, zap.NewNop().Sugar())
-		svc := NewWithDependencies(userRepo, teamRepoInstance, prRepo, db, zap.NewNop().Sugar())
+		svc := New(userRepo, zap.NewNop().Sugar(), WithTransactions(db, teamRepoInstance),
+			WithReviewerReplacer(newReviewerReplacer(db, false)))
 
 		// Setup: create team and inactive users
 		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
@@ -341,8 +437,8 @@ func TestService_BulkDeactivateTeamMembers(t *testing.T) {
 		db := setupTestDBForBulkDeactivate(t)
 		userRepo := repository.New(db, zap.NewNop().Sugar())
 		teamRepoInstance := teamRepo.New(db, zap.NewNop().Sugar())
-		prRepo := pullrequestRepo.New(db, zap.NewNop().Sugar())
-		svc := NewWithDependencies(userRepo, teamRepoInstance, prRepo, db, zap.NewNop().Sugar())
+		svc := New(userRepo, zap.NewNop().Sugar(), WithTransactions(db, teamRepoInstance),
+			WithReviewerReplacer(newReviewerReplacer(db, false)))
 
 		// Setup: create team and active users
 		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
@@ -379,8 +475,8 @@ func TestService_BulkDeactivateTeamMembers(t *testing.T) {
 		db := setupTestDBForBulkDeactivate(t)
 		userRepo := repository.New(db, zap.NewNop().Sugar())
 		teamRepoInstance := teamRepo.New(db, zap.NewNop().Sugar())
-		prRepo := pullrequestRepo.New(db, zap.NewNop().Sugar())
-		svc := NewWithDependencies(userRepo, teamRepoInstance, prRepo, db, zap.NewNop().Sugar())
+		svc := New(userRepo, zap.NewNop().Sugar(), WithTransactions(db, teamRepoInstance),
+			WithReviewerReplacer(newReviewerReplacer(db, false)))
 
 		db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
 
@@ -398,3 +494,429 @@ func TestService_BulkDeactivateTeamMembers(t *testing.T) {
 		assert.Equal(t, 0, resp.ReassignedPRCount)
 	})
 }
+
+func TestService_BulkDeactivateTeamMembers_RecordsActivity(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDBForBulkDeactivate(t)
+	userRepo := repository.New(db, zap.NewNop().Sugar())
+	teamRepoInstance := teamRepo.New(db, zap.NewNop().Sugar())
+	prRepo := pullrequestRepo.New(db, zap.NewNop().Sugar())
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	svc := New(userRepo, zap.NewNop().Sugar(), WithTransactions(db, teamRepoInstance),
+		WithReviewerReplacer(newReviewerReplacer(db, false)), WithClock(clock.NewFake(now)))
+
+	// Reviewer from backend on a frontend PR; no active backend members remain after deactivation
+	db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
+	db.Exec("INSERT INTO teams (team_name) VALUES (?)", "frontend")
+	db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
+		"u1", "Alice", "backend", true)
+	db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
+		"u2", "Bob", "frontend", true)
+	db.Exec("INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES (?, ?, ?, ?)",
+		"pr-1", "Add feature", "u2", "OPEN")
+	db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)", "pr-1", "u1")
+
+	_, err := svc.BulkDeactivateTeamMembers(ctx, &userModel.BulkDeactivateTeamRequest{
+		TeamName: "backend",
+		Reason:   "team disbanded",
+	})
+	require.NoError(t, err)
+
+	events, err := prRepo.GetEvents(ctx, "pr-1", sortparam.Sort{})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, pullrequestModel.EventReviewerRemoved, events[0].EventType)
+	require.NotNil(t, events[0].UserID)
+	assert.Equal(t, "u1", *events[0].UserID)
+	require.NotNil(t, events[0].Source)
+	assert.Equal(t, pullrequestModel.SourceDeactivation, *events[0].Source)
+
+	history, err := userRepo.GetActivationHistory(ctx, "u1", 10)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, userModel.ActivationSourceBulkDeactivate, history[0].Source)
+	assert.True(t, history[0].CreatedAt.Equal(now), "timestamps are taken from the service clock")
+	assert.True(t, history[0].OldIsActive)
+	assert.False(t, history[0].NewIsActive)
+	assert.Nil(t, history[0].ActorID)
+	require.NotNil(t, history[0].Reason)
+	assert.Equal(t, "team disbanded", *history[0].Reason)
+}
+
+func TestService_SetIsActive_ReassignOpenReviews(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDBForBulkDeactivate(t)
+	userRepo := repository.New(db, zap.NewNop().Sugar())
+	teamRepoInstance := teamRepo.New(db, zap.NewNop().Sugar())
+	prRepo := pullrequestRepo.New(db, zap.NewNop().Sugar())
+	svc := New(userRepo, zap.NewNop().Sugar(), WithTransactions(db, teamRepoInstance),
+		WithReviewerReplacer(newReviewerReplacer(db, true)))
+
+	db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
+	for _, id := range []string{"u1", "u2", "u3"} {
+		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
+			id, "User "+id, "backend", true)
+	}
+	for _, pr := range [][3]string{{"pr-1", "u1", "OPEN"}, {"pr-2", "u3", "OPEN"}, {"pr-3", "u1", "MERGED"}} {
+		db.Exec("INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES (?, ?, ?, ?)",
+			pr[0], "Feature", pr[1], pr[2])
+		db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)", pr[0], "u2")
+	}
+
+	resp, err := svc.SetIsActive(ctx, &userModel.SetIsActiveRequest{
+		UserID:              "u2",
+		IsActive:            false,
+		ReassignOpenReviews: true,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, resp.User.IsActive)
+	assert.Equal(t, []string{"pr-1", "pr-2"}, resp.ReassignedPRs)
+
+	history, err := userRepo.GetActivationHistory(ctx, "u2", 10)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, userModel.ActivationSourceSetIsActive, history[0].Source)
+
+	reviewers, err := prRepo.GetReviewers(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u3"}, reviewers)
+	reviewers, err = prRepo.GetReviewers(ctx, "pr-2")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u1"}, reviewers)
+	reviewers, err = prRepo.GetReviewers(ctx, "pr-3")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2"}, reviewers, "merged PRs are not changed")
+
+	t.Run("user not found rolls back", func(t *testing.T) {
+		_, err := svc.SetIsActive(ctx, &userModel.SetIsActiveRequest{UserID: "ghost", ReassignOpenReviews: true})
+		assert.ErrorIs(t, err, userModel.ErrUserNotFound)
+	})
+
+	t.Run("requires database", func(t *testing.T) {
+		_, err := New(new(mockRepository), zap.NewNop().Sugar()).SetIsActive(ctx, &userModel.SetIsActiveRequest{
+			UserID:              "u1",
+			ReassignOpenReviews: true,
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestService_WaitReview(t *testing.T) {
+	ctx := context.Background()
+	before := []userModel.PullRequestShort{{PullRequestID: "pr-1", Status: "OPEN"}}
+	after := []userModel.PullRequestShort{{PullRequestID: "pr-1", Status: "OPEN"}, {PullRequestID: "pr-2", Status: "OPEN"}}
+	version := userModel.ReviewVersion(before)
+	newService := func(repo *mockRepository) Service {
+		return New(repo, zap.NewNop().Sugar(), WithReviewWait(200*time.Millisecond, 5*time.Millisecond))
+	}
+
+	t.Run("without version returns immediately", func(t *testing.T) {
+		mockRepo := new(mockRepository)
+		mockRepo.On("GetAssignedPullRequests", ctx, "u1", userModel.ReviewQuery{}).Return(before, nil).Once()
+
+		resp, err := newService(mockRepo).WaitReview(ctx, "u1", "")
+
+		require.NoError(t, err)
+		assert.True(t, resp.Changed)
+		assert.Equal(t, version, resp.Version)
+		assert.Equal(t, before, resp.PullRequests)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("returns when the set changes", func(t *testing.T) {
+		mockRepo := new(mockRepository)
+		mockRepo.On("GetAssignedPullRequests", ctx, "u1", userModel.ReviewQuery{}).Return(before, nil).Twice()
+		mockRepo.On("GetAssignedPullRequests", ctx, "u1", userModel.ReviewQuery{}).Return(after, nil).Once()
+
+		resp, err := newService(mockRepo).WaitReview(ctx, "u1", version)
+
+		require.NoError(t, err)
+		assert.True(t, resp.Changed)
+		assert.Equal(t, userModel.ReviewVersion(after), resp.Version)
+		assert.Len(t, resp.PullRequests, 2)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("times out without changes", func(t *testing.T) {
+		mockRepo := new(mockRepository)
+		mockRepo.On("GetAssignedPullRequests", ctx, "u1", userModel.ReviewQuery{}).Return(before, nil)
+
+		start := time.Now()
+		resp, err := newService(mockRepo).WaitReview(ctx, "u1", version)
+
+		require.NoError(t, err)
+		assert.False(t, resp.Changed)
+		assert.Equal(t, version, resp.Version)
+		assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+	})
+
+	t.Run("canceled context ends the wait", func(t *testing.T) {
+		mockRepo := new(mockRepository)
+		mockRepo.On("GetAssignedPullRequests", mock.Anything, "u1", userModel.ReviewQuery{}).Return(before, nil)
+		waitCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+
+		resp, err := New(mockRepo, zap.NewNop().Sugar(), WithReviewWait(time.Minute, time.Second)).
+			WaitReview(waitCtx, "u1", version)
+
+		require.NoError(t, err)
+		assert.False(t, resp.Changed)
+	})
+
+	t.Run("context ending during a check ends the wait", func(t *testing.T) {
+		mockRepo := new(mockRepository)
+		waitCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		mockRepo.On("GetAssignedPullRequests", waitCtx, "u1", userModel.ReviewQuery{}).Return(before, nil).Once()
+		mockRepo.On("GetAssignedPullRequests", waitCtx, "u1", userModel.ReviewQuery{}).
+			Run(func(mock.Arguments) { cancel() }).
+			Return(nil, context.Canceled).Once()
+
+		resp, err := newService(mockRepo).WaitReview(waitCtx, "u1", version)
+
+		require.NoError(t, err)
+		assert.False(t, resp.Changed)
+		assert.Equal(t, before, resp.PullRequests)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockRepo := new(mockRepository)
+		mockRepo.On("GetAssignedPullRequests", ctx, "u1", userModel.ReviewQuery{}).Return(before, nil).Once()
+		mockRepo.On("GetAssignedPullRequests", ctx, "u1", userModel.ReviewQuery{}).
+			Return(nil, errors.New("connection refused")).Once()
+
+		_, err := newService(mockRepo).WaitReview(ctx, "u1", version)
+
+		assert.EqualError(t, err, "connection refused")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("empty user_id", func(t *testing.T) {
+		_, err := newService(new(mockRepository)).WaitReview(ctx, "", "")
+		assert.ErrorIs(t, err, userModel.ErrUserNotFound)
+	})
+}
+
+func TestService_SearchUsers(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success with default limit", func(t *testing.T) {
+		mockRepo := new(mockRepository)
+		svc := New(mockRepo, zap.NewNop().Sugar())
+
+		users := []userModel.User{{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}}
+		mockRepo.On("Search", ctx, "ali", pagination.DefaultLimit).Return(users, nil)
+
+		resp, err := svc.SearchUsers(ctx, "  ali ", 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, "ali", resp.Query)
+		assert.Len(t, resp.Users, 1)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("empty query", func(t *testing.T) {
+		mockRepo := new(mockRepository)
+		svc := New(mockRepo, zap.NewNop().Sugar())
+
+		resp, err := svc.SearchUsers(ctx, "   ", 10)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, userModel.ErrInvalidSearchQuery)
+		mockRepo.AssertNotCalled(t, "Search")
+	})
+
+	t.Run("limit out of range", func(t *testing.T) {
+		mockRepo := new(mockRepository)
+		svc := New(mockRepo, zap.NewNop().Sugar())
+
+		for _, limit := range []int{-1, pagination.DefaultMaxLimit + 1} {
+			resp, err := svc.SearchUsers(ctx, "ali", limit)
+
+			assert.Nil(t, resp)
+			assert.ErrorIs(t, err, pagination.ErrInvalidLimit)
+		}
+		mockRepo.AssertNotCalled(t, "Search")
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockRepo := new(mockRepository)
+		svc := New(mockRepo, zap.NewNop().Sugar())
+
+		dbErr := errors.New("database error")
+		mockRepo.On("Search", ctx, "ali", 5).Return(nil, dbErr)
+
+		resp, err := svc.SearchUsers(ctx, "ali", 5)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, dbErr)
+	})
+}
+
+func TestService_GetActivationHistory(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success with default limit", func(t *testing.T) {
+		mockRepo := new(mockRepository)
+		svc := New(mockRepo, zap.NewNop().Sugar())
+		changes := []userModel.ActivationChange{
+			userModel.NewActivationChange("u1", true, false, userModel.ActivationSourceSetIsActive, "", ""),
+		}
+		mockRepo.On("GetByID", ctx, "u1").Return(&userModel.User{UserID: "u1"}, nil)
+		mockRepo.On("GetActivationHistory", ctx, "u1", pagination.DefaultLimit).Return(changes, nil)
+
+		resp, err := svc.GetActivationHistory(ctx, "u1", 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, "u1", resp.UserID)
+		assert.Equal(t, changes, resp.Changes)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mockRepo := new(mockRepository)
+		svc := New(mockRepo, zap.NewNop().Sugar())
+		mockRepo.On("GetByID", ctx, "u9").Return(nil, userModel.ErrUserNotFound)
+
+		_, err := svc.GetActivationHistory(ctx, "u9", 10)
+
+		assert.ErrorIs(t, err, userModel.ErrUserNotFound)
+		mockRepo.AssertNotCalled(t, "GetActivationHistory", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	for _, limit := range []int{-1, pagination.DefaultMaxLimit + 1} {
+		t.Run("invalid limit", func(t *testing.T) {
+			mockRepo := new(mockRepository)
+
+			_, err := New(mockRepo, zap.NewNop().Sugar()).GetActivationHistory(ctx, "u1", limit)
+
+			assert.ErrorIs(t, err, pagination.ErrInvalidLimit)
+			mockRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestService_SetIsActive_ReassignOpenReviewsLeastLoaded(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDBForBulkDeactivate(t)
+	userRepo := repository.New(db, zap.NewNop().Sugar())
+	prRepo := pullrequestRepo.New(db, zap.NewNop().Sugar())
+	svc := New(userRepo, zap.NewNop().Sugar(), WithTransactions(db, teamRepo.New(db, zap.NewNop().Sugar())),
+		WithReviewerReplacer(newReviewerReplacer(db, true)))
+
+	db.Exec("INSERT INTO teams (team_name) VALUES (?)", "backend")
+	for _, id := range []string{"u1", "u2", "u3", "u4"} {
+		db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
+			id, "User "+id, "backend", true)
+	}
+	// u3 precedes u4 in user_id order but already reviews another open PR
+	for _, pr := range [][2]string{{"pr-1", "u2"}, {"pr-2", "u3"}} {
+		db.Exec("INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES (?, ?, ?, ?)",
+			pr[0], "Feature", "u1", "OPEN")
+		db.Exec("INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES (?, ?)", pr[0], pr[1])
+	}
+
+	resp, err := svc.SetIsActive(ctx, &userModel.SetIsActiveRequest{
+		UserID:              "u2",
+		IsActive:            false,
+		ReassignOpenReviews: true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pr-1"}, resp.ReassignedPRs)
+	reviewers, err := prRepo.GetReviewers(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u4"}, reviewers)
+
+	events, err := prRepo.GetEvents(ctx, "pr-1", sortparam.Sort{})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, pullrequestModel.EventReviewerReplaced, events[0].EventType)
+	require.NotNil(t, events[0].Source)
+	assert.Equal(t, pullrequestModel.SourceDeactivation, *events[0].Source)
+}
+
+// newReviewerReplacer returns the pull request service replacing deactivated reviewers in db.
+func newReviewerReplacer(db *gorm.DB, deterministic bool) ReviewerReplacer {
+	logger := zap.NewNop().Sugar()
+	return pullrequestService.New(pullrequestRepo.New(db, logger), db, logger,
+		pullrequestService.WithPolicy(pullrequestService.Policy{DeterministicAssignment: deterministic}))
+}
+
+func TestService_AnonymizeUser(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.NewDB(t)
+	logger := zap.NewNop().Sugar()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	svc := New(repository.New(db, logger), logger, WithTransactions(db, teamRepo.New(db, logger)),
+		WithClock(clock.NewFake(now)))
+
+	testutil.NewTeam().WithMembers(3).WithMemberIDs("u10").WithLead("u1").Create(t, db)
+	testutil.NewPR().WithID("pr-1").ByAuthor("u1").WithReviewers("u2").Create(t, db)
+	testutil.NewPR().WithID("pr-2").ByAuthor("u2").WithReviewers("u1", "u3").Merged().Create(t, db)
+	require.NoError(t, db.Exec("INSERT INTO pull_request_watchers (pull_request_id, user_id) VALUES (?, ?)",
+		"pr-2", "u1").Error)
+	require.NoError(t, db.Exec("INSERT INTO pull_request_comments (pull_request_id, user_id, body) VALUES (?, ?, ?)",
+		"pr-1", "u1", "LGTM").Error)
+	require.NoError(t, repository.New(db, logger).RecordActivationChanges(ctx, []userModel.ActivationChange{
+		userModel.NewActivationChange("u2", true, false, userModel.ActivationSourceSetIsActive, "u1", ""),
+		userModel.NewActivationChange("u1", false, true, userModel.ActivationSourceSetIsActive, "u2", ""),
+	}))
+	require.NoError(t, db.Exec(`INSERT INTO pull_request_events (pull_request_id, event_type, user_id, actor_id)
+		VALUES (?, ?, ?, ?)`, "pr-2", pullrequestModel.EventReviewerAssignedManually, "u1", "u1").Error)
+	for _, payload := range []string{`{"recipients":["u1","u2"]}`, `{"recipients":["u10"]}`} {
+		require.NoError(t, db.Exec(`INSERT INTO webhook_dead_letters
+			(event, pull_request_id, payload, attempts, last_error) VALUES (?, ?, ?, ?, ?)`,
+			"pull_request.created", "pr-1", payload, 3, "timeout").Error)
+	}
+
+	resp, err := svc.AnonymizeUser(ctx, &userModel.AnonymizeUserRequest{UserID: "u1"})
+	require.NoError(t, err)
+	anon := resp.AnonymousID
+	assert.Regexp(t, `^anon-[0-9a-f]{16}$`, anon)
+	assert.Equal(t, "backend", resp.TeamName)
+
+	count := func(query string, args ...any) int64 {
+		var n int64
+		require.NoError(t, db.Raw("SELECT COUNT(*) FROM "+query, args...).Scan(&n).Error)
+		return n
+	}
+	assert.Zero(t, count("users WHERE user_id = ? OR username = ?", "u1", "u1"))
+	assert.Equal(t, int64(1), count("users WHERE user_id = ? AND username = ? AND team_name = ? AND NOT is_active",
+		anon, anon, "backend"))
+	var anonymized userModel.User
+	require.NoError(t, db.Where("user_id = ?", anon).First(&anonymized).Error)
+	assert.True(t, anonymized.UpdatedAt.Equal(now), "timestamps are taken from the service clock")
+	assert.Equal(t, int64(1), count("teams WHERE lead_user_id = ?", anon))
+	assert.Equal(t, int64(1), count("pull_requests WHERE author_id = ?", anon))
+	assert.Equal(t, int64(1), count("pull_request_reviewers WHERE user_id = ?", anon))
+	assert.Equal(t, int64(3), count("pull_request_reviewers"), "review history is kept")
+	assert.Equal(t, int64(1), count("pull_request_watchers WHERE user_id = ?", anon))
+	assert.Equal(t, int64(1), count("pull_request_comments WHERE user_id = ?", anon))
+	assert.Equal(t, int64(1), count("pull_request_events WHERE user_id = ? AND actor_id = ?", anon, anon))
+	assert.Equal(t, int64(1), count("user_activation_history WHERE user_id = ?", anon))
+	assert.Equal(t, int64(1), count("user_activation_history WHERE actor_id = ?", anon))
+	assert.Equal(t, int64(1), count("webhook_dead_letters WHERE payload = ?", `{"recipients":["`+anon+`","u2"]}`))
+	assert.Equal(t, int64(1), count("webhook_dead_letters WHERE payload = ?", `{"recipients":["u10"]}`),
+		"other users are not changed")
+
+	t.Run("user not found", func(t *testing.T) {
+		_, err := svc.AnonymizeUser(ctx, &userModel.AnonymizeUserRequest{UserID: "u1"})
+		assert.ErrorIs(t, err, userModel.ErrUserNotFound)
+	})
+
+	t.Run("failure rolls back", func(t *testing.T) {
+		require.NoError(t, db.Migrator().DropTable("webhook_dead_letters"))
+		_, err := svc.AnonymizeUser(ctx, &userModel.AnonymizeUserRequest{UserID: "u2"})
+		require.Error(t, err)
+		assert.Equal(t, int64(1), count("users WHERE user_id = ?", "u2"))
+		assert.Equal(t, int64(1), count("pull_requests WHERE author_id = ?", "u2"))
+	})
+
+	t.Run("requires database", func(t *testing.T) {
+		_, err := New(new(mockRepository), logger).AnonymizeUser(ctx, &userModel.AnonymizeUserRequest{UserID: "u1"})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,140 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/festy23/avito_internship/internal/database/dberror"
+	"github.com/festy23/avito_internship/internal/database/dialect"
+)
+
+// ErrDeadLetterNotFound indicates that a dead-lettered delivery does not exist.
+var ErrDeadLetterNotFound = errors.New("dead letter not found")
+
+// DeadLetter is a webhook delivery that failed after all attempts.
+// Matches the webhook_dead_letters table schema.
+type DeadLetter struct {
+	ID            int64     `gorm:"primaryKey;column:id;type:bigserial"                      json:"id"`
+	Event         string    `gorm:"column:event;type:varchar(64);not null"                   json:"event"`
+	PullRequestID string    `gorm:"column:pull_request_id;type:varchar(255);not null"        json:"pull_request_id"`
+	Payload       string    `gorm:"column:payload;type:jsonb;not null"                       json:"-"`
+	Attempts      int       `gorm:"column:attempts;not null"                                 json:"attempts"`
+	LastError     string    `gorm:"column:last_error;type:text;not null"                     json:"last_error"`
+	CreatedAt     time.Time `gorm:"column:created_at;type:timestamptz;not null;default:now()" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM.
+func (DeadLetter) TableName() string {
+	return "webhook_dead_letters"
+}
+
+// Repository defines data access for dead-lettered webhook deliveries.
+type Repository interface {
+	Add(ctx context.Context, dl *DeadLetter) error
+	List(ctx context.Context, limit int) ([]DeadLetter, error)
+	GetByID(ctx context.Context, id int64) (*DeadLetter, error)
+	Delete(ctx context.Context, id int64) error
+	// ReplaceUser replaces oldUserID with newUserID in payloads and returns the number of changed dead letters.
+	ReplaceUser(ctx context.Context, oldUserID, newUserID string) (int, error)
+}
+
+type repository struct {
+	db     *gorm.DB
+	logger *zap.SugaredLogger
+}
+
+// NewRepository creates a new dead letter repository instance.
+func NewRepository(db *gorm.DB, logger *zap.SugaredLogger) Repository {
+	return &repository{db: db, logger: logger}
+}
+
+// Add stores a failed delivery.
+func (r *repository) Add(ctx context.Context, dl *DeadLetter) error {
+	if err := r.db.WithContext(ctx).Create(dl).Error; err != nil {
+		r.logger.Errorw("failed to store webhook dead letter", "pull_request_id", dl.PullRequestID, "error", err)
+		return dberror.Wrap(err, "add webhook dead letter", dl.PullRequestID)
+	}
+	return nil
+}
+
+// List returns the oldest failed deliveries first.
+func (r *repository) List(ctx context.Context, limit int) ([]DeadLetter, error) {
+	var deadLetters []DeadLetter
+	err := r.db.WithContext(ctx).
+		Order("created_at, id").
+		Limit(limit).
+		Find(&deadLetters).Error
+	if err != nil {
+		r.logger.Errorw("failed to list webhook dead letters", "error", err)
+		return nil, dberror.Wrap(err, "list webhook dead letters")
+	}
+	return deadLetters, nil
+}
+
+// GetByID retrieves a failed delivery by ID.
+func (r *repository) GetByID(ctx context.Context, id int64) (*DeadLetter, error) {
+	var dl DeadLetter
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&dl).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDeadLetterNotFound
+		}
+		r.logger.Errorw("failed to get webhook dead letter", "id", id, "error", err)
+		return nil, dberror.Wrap(err, "get webhook dead letter", strconv.FormatInt(id, 10))
+	}
+	return &dl, nil
+}
+
+// Delete removes a failed delivery.
+func (r *repository) Delete(ctx context.Context, id int64) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&DeadLetter{})
+	if result.Error != nil {
+		r.logger.Errorw("failed to delete webhook dead letter", "id", id, "error", result.Error)
+		return dberror.Wrap(result.Error, "delete webhook dead letter", strconv.FormatInt(id, 10))
+	}
+	if result.RowsAffected == 0 {
+		return ErrDeadLetterNotFound
+	}
+	return nil
+}
+
+// ReplaceUser replaces oldUserID with newUserID in payloads and returns the number of changed dead letters.
+// Payloads are rewritten as text, so every JSON string equal to oldUserID is replaced.
+func (r *repository) ReplaceUser(ctx context.Context, oldUserID, newUserID string) (int, error) {
+	oldValue, _ := json.Marshal(oldUserID)
+	newValue, _ := json.Marshal(newUserID)
+
+	var deadLetters []DeadLetter
+	err := r.db.WithContext(ctx).
+		Where(dialect.CastText(r.db, "payload")+" LIKE ?", "%"+string(oldValue)+"%").
+		Find(&deadLetters).Error
+	if err != nil {
+		r.logger.Errorw("failed to find webhook dead letters of user", "error", err)
+		return 0, dberror.Wrap(err, "find webhook dead letters of user", newUserID)
+	}
+
+	changed := 0
+	for i := range deadLetters {
+		payload := strings.ReplaceAll(deadLetters[i].Payload, string(oldValue), string(newValue))
+		if payload == deadLetters[i].Payload {
+			continue
+		}
+		err = r.db.WithContext(ctx).
+			Model(&DeadLetter{}).
+			Where("id = ?", deadLetters[i].ID).
+			Update("payload", payload).Error
+		if err != nil {
+			r.logger.Errorw("failed to update webhook dead letter", "id", deadLetters[i].ID, "error", err)
+			return changed, dberror.Wrap(err, "update webhook dead letter", strconv.FormatInt(deadLetters[i].ID, 10))
+		}
+		changed++
+	}
+	return changed, nil
+}
@@ -0,0 +1,235 @@
+// Package webhook delivers pull request lifecycle events to an external HTTP endpoint.
+// Deliveries are signed with HMAC-SHA256 when secrets are configured.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/festy23/avito_internship/internal/config"
+	"github.com/festy23/avito_internship/internal/notification"
+	"github.com/festy23/avito_internship/pkg/httpclient"
+	"github.com/festy23/avito_internship/pkg/retry"
+)
+
+// ErrQueueFull indicates that the delivery queue has no free capacity.
+var ErrQueueFull = errors.New("webhook queue is full")
+
+// Payload is the JSON body of a webhook request.
+type Payload struct {
+	Event         notification.Event `json:"event"`
+	PullRequestID string             `json:"pull_request_id"`
+	Recipients    []string           `json:"recipients"`
+	Details       map[string]string  `json:"details,omitempty"`
+	OccurredAt    time.Time          `json:"occurred_at"`
+}
+
+type delivery struct {
+	event         string
+	pullRequestID string
+	body          []byte
+}
+
+// Dispatcher is a notifier that posts events to the webhook URL from a bounded worker pool.
+// Failed deliveries are retried with exponential backoff and full jitter; after the last attempt
+// they are stored in the dead-letter table and can be replayed.
+type Dispatcher struct {
+	url     string
+	secrets []string
+	client  *httpclient.Client
+	queue   chan delivery
+	workers int
+	retry   retry.Config
+	repo    Repository
+	logger  *zap.SugaredLogger
+	wg      sync.WaitGroup
+}
+
+// New creates a new webhook dispatcher instance.
+func New(cfg config.WebhookConfig, repo Repository, logger *zap.SugaredLogger) *Dispatcher {
+	return &Dispatcher{
+		url:     cfg.URL,
+		secrets: cfg.Secrets,
+		// Deliveries are re-signed per attempt, so the dispatcher retries them itself
+		client:  httpclient.New(httpclient.Options{Name: "webhook", Timeout: cfg.Timeout}),
+		queue:   make(chan delivery, cfg.QueueSize),
+		workers: cfg.Workers,
+		retry: retry.Config{
+			MaxAttempts:  cfg.MaxAttempts,
+			InitialDelay: cfg.InitialBackoff,
+			MaxDelay:     cfg.MaxBackoff,
+			Multiplier:   2,
+			Jitter:       retry.JitterFull,
+		},
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// Notify queues the notification for delivery without blocking.
+// When the queue is full the delivery goes straight to the dead-letter table.
+func (d *Dispatcher) Notify(ctx context.Context, n notification.Notification) error {
+	body, err := json.Marshal(Payload{
+		Event:         n.Event,
+		PullRequestID: n.PullRequestID,
+		Recipients:    n.Recipients,
+		Details:       n.Details,
+		OccurredAt:    time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	del := delivery{event: string(n.Event), pullRequestID: n.PullRequestID, body: body}
+	if d.enqueue(del) {
+		return nil
+	}
+	d.deadLetter(ctx, del, 0, ErrQueueFull)
+	return ErrQueueFull
+}
+
+// Replay queues a dead-lettered delivery again and removes it from the dead-letter table.
+func (d *Dispatcher) Replay(ctx context.Context, id int64) error {
+	dl, err := d.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !d.enqueue(delivery{event: dl.Event, pullRequestID: dl.PullRequestID, body: []byte(dl.Payload)}) {
+		return ErrQueueFull
+	}
+	if err := d.repo.Delete(ctx, id); err != nil && !errors.Is(err, ErrDeadLetterNotFound) {
+		return err
+	}
+	d.logger.Infow("webhook dead letter replayed", "id", id, "pull_request_id", dl.PullRequestID)
+	return nil
+}
+
+// DeadLetters returns failed deliveries, oldest first.
+func (d *Dispatcher) DeadLetters(ctx context.Context, limit int) ([]DeadLetter, error) {
+	return d.repo.List(ctx, limit)
+}
+
+// Start launches the delivery workers. They stop when ctx is canceled.
+func (d *Dispatcher) Start(ctx context.Context) {
+	d.logger.Infow("webhook workers started", "workers", d.workers, "queue_size", cap(d.queue))
+
+	for i := 0; i < d.workers; i++ {
+		d.wg.Add(1)
+		go func() {
+			defer d.wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case del := <-d.queue:
+					d.process(ctx, del)
+				}
+			}
+		}()
+	}
+}
+
+// Wait blocks until all workers have stopped and moves undelivered webhooks to the dead-letter table.
+func (d *Dispatcher) Wait() {
+	d.wg.Wait()
+	for {
+		select {
+		case del := <-d.queue:
+			d.deadLetter(context.Background(), del, 0, errors.New("not delivered before shutdown"))
+		default:
+			return
+		}
+	}
+}
+
+func (d *Dispatcher) enqueue(del delivery) bool {
+	select {
+	case d.queue <- del:
+		return true
+	default:
+		d.logger.Warnw("webhook queue is full", "event", del.event, "pull_request_id", del.pullRequestID)
+		return false
+	}
+}
+
+// process delivers a webhook, retrying failed attempts with exponential backoff. Deliveries that
+// failed every attempt or were interrupted by shutdown go to the dead-letter table.
+func (d *Dispatcher) process(ctx context.Context, del delivery) {
+	attempts := 0
+	err := retry.Do(ctx, d.retry, func() error {
+		attempts++
+		retryable, err := d.send(ctx, del)
+		if err == nil {
+			return nil
+		}
+		d.logger.Warnw("webhook delivery attempt failed",
+			"event", del.event, "pull_request_id", del.pullRequestID, "attempt", attempts, "error", err)
+		if !retryable {
+			return retry.Permanent(err)
+		}
+		return err
+	})
+	if err != nil {
+		d.deadLetter(context.WithoutCancel(ctx), del, attempts, err)
+		return
+	}
+	d.logger.Debugw("webhook delivered", "event", del.event, "pull_request_id", del.pullRequestID,
+		"attempts", attempts)
+}
+
+// send performs a single delivery attempt. Client errors other than 408 and 429 are not retried.
+func (d *Dispatcher) send(ctx context.Context, del delivery) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.url, bytes.NewReader(del.body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Event", del.event)
+	if len(d.secrets) > 0 {
+		// Signed per attempt, so retries and replays carry a fresh timestamp
+		req.Header.Set(SignatureHeader, Sign(d.secrets, time.Now(), del.body))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	err = fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+		return false, err
+	}
+	return true, err
+}
+
+func (d *Dispatcher) deadLetter(ctx context.Context, del delivery, attempts int, cause error) {
+	dl := &DeadLetter{
+		Event:         del.event,
+		PullRequestID: del.pullRequestID,
+		Payload:       string(del.body),
+		Attempts:      attempts,
+		LastError:     cause.Error(),
+	}
+	if err := d.repo.Add(ctx, dl); err != nil {
+		return
+	}
+	d.logger.Errorw("webhook moved to dead letters",
+		"id", dl.ID, "event", del.event, "pull_request_id", del.pullRequestID, "attempts", attempts, "error", cause)
+}
@@ -0,0 +1,272 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/festy23/avito_internship/internal/config"
+	"github.com/festy23/avito_internship/internal/notification"
+	"github.com/festy23/avito_internship/pkg/retry"
+)
+
+func setupTestRepo(t *testing.T) Repository {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	err = db.Exec(`
+		CREATE TABLE webhook_dead_letters (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			event TEXT NOT NULL,
+			pull_request_id TEXT NOT NULL,
+			payload TEXT NOT NULL,
+			attempts INTEGER NOT NULL,
+			last_error TEXT NOT NULL,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`).Error
+	require.NoError(t, err)
+	return NewRepository(db, zap.NewNop().Sugar())
+}
+
+func testConfig(url string) config.WebhookConfig {
+	return config.WebhookConfig{
+		URL:            url,
+		Workers:        1,
+		QueueSize:      10,
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+		Timeout:        time.Second,
+	}
+}
+
+var testNotification = notification.Notification{
+	Event:         notification.EventPullRequestMerged,
+	PullRequestID: "pr-1",
+	Recipients:    []string{"u2"},
+}
+
+// runUntilDone starts the dispatcher and stops it once wait returns.
+func runUntilDone(t *testing.T, d *Dispatcher, wait func() bool) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	d.Start(ctx)
+	assert.Eventually(t, wait, 2*time.Second, 5*time.Millisecond)
+	cancel()
+	d.Wait()
+}
+
+func TestDispatcher_Deliver(t *testing.T) {
+	t.Run("delivers payload", func(t *testing.T) {
+		received := make(chan Payload, 1)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.Equal(t, string(notification.EventPullRequestMerged), r.Header.Get("X-Webhook-Event"))
+			var p Payload
+			body, _ := io.ReadAll(r.Body)
+			assert.NoError(t, json.Unmarshal(body, &p))
+			received <- p
+		}))
+		defer srv.Close()
+
+		repo := setupTestRepo(t)
+		d := New(testConfig(srv.URL), repo, zap.NewNop().Sugar())
+		require.NoError(t, d.Notify(context.Background(), testNotification))
+
+		var p Payload
+		runUntilDone(t, d, func() bool {
+			select {
+			case p = <-received:
+				return true
+			default:
+				return false
+			}
+		})
+
+		assert.Equal(t, "pr-1", p.PullRequestID)
+		assert.Equal(t, []string{"u2"}, p.Recipients)
+		deadLetters, err := repo.List(context.Background(), 10)
+		require.NoError(t, err)
+		assert.Empty(t, deadLetters)
+	})
+
+	t.Run("signs deliveries", func(t *testing.T) {
+		secrets := []string{"new-secret-0123456789", "old-secret-0123456789"}
+		received := make(chan error, 1)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			// A receiver still on the old secret accepts the delivery
+			received <- Verify(secrets[1:], r.Header.Get(SignatureHeader), body, time.Now(), time.Minute)
+		}))
+		defer srv.Close()
+
+		cfg := testConfig(srv.URL)
+		cfg.Secrets = secrets
+		d := New(cfg, setupTestRepo(t), zap.NewNop().Sugar())
+		require.NoError(t, d.Notify(context.Background(), testNotification))
+
+		var err error
+		runUntilDone(t, d, func() bool {
+			select {
+			case err = <-received:
+				return true
+			default:
+				return false
+			}
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("retries server errors", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			if calls.Add(1) < 3 {
+				w.WriteHeader(http.StatusBadGateway)
+			}
+		}))
+		defer srv.Close()
+
+		repo := setupTestRepo(t)
+		d := New(testConfig(srv.URL), repo, zap.NewNop().Sugar())
+		require.NoError(t, d.Notify(context.Background(), testNotification))
+
+		runUntilDone(t, d, func() bool { return calls.Load() == 3 })
+
+		deadLetters, err := repo.List(context.Background(), 10)
+		require.NoError(t, err)
+		assert.Empty(t, deadLetters)
+	})
+
+	t.Run("dead-letters after max attempts", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer srv.Close()
+
+		repo := setupTestRepo(t)
+		d := New(testConfig(srv.URL), repo, zap.NewNop().Sugar())
+		require.NoError(t, d.Notify(context.Background(), testNotification))
+
+		runUntilDone(t, d, func() bool {
+			deadLetters, _ := repo.List(context.Background(), 10)
+			return len(deadLetters) == 1
+		})
+
+		assert.Equal(t, int32(3), calls.Load())
+		deadLetters, err := repo.List(context.Background(), 10)
+		require.NoError(t, err)
+		require.Len(t, deadLetters, 1)
+		assert.Equal(t, "pr-1", deadLetters[0].PullRequestID)
+		assert.Equal(t, 3, deadLetters[0].Attempts)
+		assert.Contains(t, deadLetters[0].LastError, "503")
+	})
+
+	t.Run("does not retry client errors", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer srv.Close()
+
+		repo := setupTestRepo(t)
+		d := New(testConfig(srv.URL), repo, zap.NewNop().Sugar())
+		require.NoError(t, d.Notify(context.Background(), testNotification))
+
+		runUntilDone(t, d, func() bool {
+			deadLetters, _ := repo.List(context.Background(), 10)
+			return len(deadLetters) == 1
+		})
+
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
+
+func TestDispatcher_QueueFull(t *testing.T) {
+	repo := setupTestRepo(t)
+	cfg := testConfig("http://127.0.0.1:1")
+	cfg.QueueSize = 1
+	d := New(cfg, repo, zap.NewNop().Sugar())
+
+	require.NoError(t, d.Notify(context.Background(), testNotification))
+	err := d.Notify(context.Background(), testNotification)
+
+	assert.ErrorIs(t, err, ErrQueueFull)
+	deadLetters, listErr := repo.List(context.Background(), 10)
+	require.NoError(t, listErr)
+	require.Len(t, deadLetters, 1)
+	assert.Zero(t, deadLetters[0].Attempts)
+}
+
+func TestDispatcher_WaitDeadLettersQueued(t *testing.T) {
+	repo := setupTestRepo(t)
+	d := New(testConfig("http://127.0.0.1:1"), repo, zap.NewNop().Sugar())
+	require.NoError(t, d.Notify(context.Background(), testNotification))
+
+	d.Wait()
+
+	deadLetters, err := repo.List(context.Background(), 10)
+	require.NoError(t, err)
+	require.Len(t, deadLetters, 1)
+	assert.Contains(t, deadLetters[0].LastError, "shutdown")
+}
+
+func TestDispatcher_Replay(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		calls.Add(1)
+	}))
+	defer srv.Close()
+
+	repo := setupTestRepo(t)
+	dl := &DeadLetter{
+		Event:         string(notification.EventPullRequestCreated),
+		PullRequestID: "pr-1",
+		Payload:       `{"event":"pull_request.created","pull_request_id":"pr-1"}`,
+		Attempts:      5,
+		LastError:     "unexpected response status 500",
+	}
+	require.NoError(t, repo.Add(context.Background(), dl))
+	d := New(testConfig(srv.URL), repo, zap.NewNop().Sugar())
+
+	t.Run("not found", func(t *testing.T) {
+		assert.ErrorIs(t, d.Replay(context.Background(), dl.ID+1), ErrDeadLetterNotFound)
+	})
+
+	t.Run("redelivers and removes dead letter", func(t *testing.T) {
+		require.NoError(t, d.Replay(context.Background(), dl.ID))
+
+		runUntilDone(t, d, func() bool { return calls.Load() == 1 })
+
+		_, err := repo.GetByID(context.Background(), dl.ID)
+		assert.ErrorIs(t, err, ErrDeadLetterNotFound)
+	})
+}
+
+func TestDispatcher_RetryConfig(t *testing.T) {
+	d := New(config.WebhookConfig{MaxAttempts: 4, InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}, nil,
+		zap.NewNop().Sugar())
+
+	assert.Equal(t, 4, d.retry.MaxAttempts)
+	assert.Equal(t, time.Second, d.retry.InitialDelay)
+	assert.Equal(t, 5*time.Second, d.retry.MaxDelay)
+	assert.Equal(t, 2.0, d.retry.Multiplier)
+	assert.Equal(t, retry.JitterFull, d.retry.Jitter)
+}
@@ -0,0 +1,121 @@
+package webhook
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/festy23/avito_internship/pkg/apierror"
+	"github.com/festy23/avito_internship/pkg/bind"
+	"github.com/festy23/avito_internship/pkg/pagination"
+)
+
+var replayErrors = apierror.Registry{}.
+	Register(ErrDeadLetterNotFound, apierror.NotFound("dead letter not found")).
+	Register(ErrQueueFull,
+		apierror.New(apierror.CodeQueueFull, http.StatusServiceUnavailable, "").WithRetryAfter(time.Second))
+
+// Handler exposes administration of failed webhook deliveries over HTTP.
+type Handler struct {
+	dispatcher *Dispatcher
+	limits     pagination.Limits
+}
+
+// NewHandler creates a new webhook handler instance; limits bound the page size of dead letter lists.
+func NewHandler(dispatcher *Dispatcher, limits pagination.Limits) *Handler {
+	return &Handler{dispatcher: dispatcher, limits: limits}
+}
+
+// DeadLetterResponse represents a failed delivery in API responses.
+type DeadLetterResponse struct {
+	DeadLetter
+	Payload jsonPayload `json:"payload"`
+}
+
+// DeadLettersResponse represents the response with failed deliveries.
+type DeadLettersResponse struct {
+	DeadLetters []DeadLetterResponse `json:"dead_letters"`
+}
+
+// ReplayRequest represents the request to replay a failed delivery.
+type ReplayRequest struct {
+	ID int64 `json:"id" binding:"required"`
+}
+
+// ErrorResponse represents error response structure.
+type ErrorResponse = apierror.Response
+
+// jsonPayload renders a stored JSON document without re-encoding it as a string.
+type jsonPayload string
+
+// MarshalJSON returns the payload as raw JSON.
+func (p jsonPayload) MarshalJSON() ([]byte, error) {
+	return []byte(p), nil
+}
+
+// ListDeadLetters handles GET /webhooks/deadLetters request.
+// @Summary List webhook deliveries that failed after all attempts
+// @Tags Webhooks
+// @Produce json
+// @Param limit query int false "Maximum number of entries (default PAGINATION_DEFAULT_LIMIT, max PAGINATION_MAX_LIMIT)"
+// @Success 200 {object} DeadLettersResponse
+// @Failure 400 {object} ErrorResponse "Invalid limit (INVALID_REQUEST)"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /webhooks/deadLetters [get] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) ListDeadLetters(c *gin.Context) {
+	limit := h.limits.Default
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err == nil {
+			err = h.limits.Check(parsed)
+		} else {
+			err = fmt.Errorf("%w: must be an integer", pagination.ErrInvalidLimit)
+		}
+		if err != nil {
+			apierror.Fail(c, apierror.InvalidField("limit", "range", err.Error()))
+			return
+		}
+		limit = parsed
+	}
+
+	deadLetters, err := h.dispatcher.DeadLetters(c.Request.Context(), limit)
+	if err != nil {
+		apierror.Fail(c, err)
+		return
+	}
+
+	resp := DeadLettersResponse{DeadLetters: make([]DeadLetterResponse, 0, len(deadLetters))}
+	for _, dl := range deadLetters {
+		resp.DeadLetters = append(resp.DeadLetters, DeadLetterResponse{DeadLetter: dl, Payload: jsonPayload(dl.Payload)})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// ReplayDeadLetter handles POST /webhooks/deadLetters/replay request.
+// @Summary Queue a failed webhook delivery again
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param request body ReplayRequest true "Request"
+// @Success 200 {object} map[string]int64 "Replayed dead letter ID"
+// @Failure 400 {object} ErrorResponse "Bad request (INVALID_REQUEST)"
+// @Failure 404 {object} ErrorResponse "Dead letter not found"
+// @Failure 503 {object} ErrorResponse "Delivery queue is full (QUEUE_FULL)"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /webhooks/deadLetters/replay [post] //nolint:godot // Swagger annotation should not end with period
+func (h *Handler) ReplayDeadLetter(c *gin.Context) {
+	var req ReplayRequest
+	if !bind.JSON(c, &req) {
+		return
+	}
+
+	if err := h.dispatcher.Replay(c.Request.Context(), req.ID); err != nil {
+		replayErrors.Fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"replayed": req.ID})
+}
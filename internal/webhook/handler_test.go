@@ -0,0 +1,135 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/festy23/avito_internship/pkg/pagination"
+)
+
+func setupHandlerRouter(t *testing.T, queueSize int) (*gin.Engine, Repository) {
+	gin.SetMode(gin.TestMode)
+
+	repo := setupTestRepo(t)
+	cfg := testConfig("http://127.0.0.1:1")
+	cfg.QueueSize = queueSize
+	h := NewHandler(New(cfg, repo, zap.NewNop().Sugar()), pagination.DefaultLimits())
+
+	router := gin.New()
+	router.GET("/webhooks/deadLetters", h.ListDeadLetters)
+	router.POST("/webhooks/deadLetters/replay", h.ReplayDeadLetter)
+	return router, repo
+}
+
+func addDeadLetter(t *testing.T, repo Repository) *DeadLetter {
+	dl := &DeadLetter{
+		Event:         "pull_request.merged",
+		PullRequestID: "pr-1",
+		Payload:       `{"event":"pull_request.merged","pull_request_id":"pr-1"}`,
+		Attempts:      5,
+		LastError:     "unexpected response status 500",
+	}
+	require.NoError(t, repo.Add(context.Background(), dl))
+	return dl
+}
+
+func TestHandler_ListDeadLetters(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		router, repo := setupHandlerRouter(t, 10)
+		addDeadLetter(t, repo)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/webhooks/deadLetters", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			DeadLetters []struct {
+				ID      int64           `json:"id"`
+				Event   string          `json:"event"`
+				Payload json.RawMessage `json:"payload"`
+			} `json:"dead_letters"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.DeadLetters, 1)
+		assert.Equal(t, "pull_request.merged", response.DeadLetters[0].Event)
+		assert.JSONEq(t, `{"event":"pull_request.merged","pull_request_id":"pr-1"}`,
+			string(response.DeadLetters[0].Payload))
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		router, _ := setupHandlerRouter(t, 10)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/webhooks/deadLetters?limit=0", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("limit above maximum", func(t *testing.T) {
+		router, _ := setupHandlerRouter(t, 10)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/webhooks/deadLetters?limit=101", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "must be between 1 and 100, got 101")
+	})
+}
+
+func TestHandler_ReplayDeadLetter(t *testing.T) {
+	replay := func(router *gin.Engine, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/webhooks/deadLetters/replay", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("success", func(t *testing.T) {
+		router, repo := setupHandlerRouter(t, 10)
+		dl := addDeadLetter(t, repo)
+
+		w := replay(router, `{"id":1}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		_, err := repo.GetByID(context.Background(), dl.ID)
+		assert.ErrorIs(t, err, ErrDeadLetterNotFound)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		router, _ := setupHandlerRouter(t, 10)
+
+		assert.Equal(t, http.StatusBadRequest, replay(router, `{}`).Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		router, _ := setupHandlerRouter(t, 10)
+
+		assert.Equal(t, http.StatusNotFound, replay(router, `{"id":42}`).Code)
+	})
+
+	t.Run("queue full", func(t *testing.T) {
+		router, repo := setupHandlerRouter(t, 1)
+		addDeadLetter(t, repo)
+		addDeadLetter(t, repo)
+		require.Equal(t, http.StatusOK, replay(router, `{"id":1}`).Code)
+
+		w := replay(router, `{"id":2}`)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		_, err := repo.GetByID(context.Background(), 2)
+		assert.NoError(t, err)
+	})
+}
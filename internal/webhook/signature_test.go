@@ -0,0 +1,49 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	header := Sign([]string{"secret-a", "secret-b"}, time.Unix(1700000000, 0), []byte(`{"event":"x"}`))
+
+	assert.Regexp(t, `^t=1700000000,v1=[0-9a-f]{64},v1=[0-9a-f]{64}$`, header)
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"event":"pull_request.merged"}`)
+	now := time.Unix(1700000000, 0)
+	header := Sign([]string{"current-secret", "previous-secret"}, now, body)
+
+	tests := []struct {
+		name    string
+		secrets []string
+		header  string
+		body    []byte
+		now     time.Time
+		wantErr error
+	}{
+		{"current secret", []string{"current-secret"}, header, body, now, nil},
+		{"previous secret", []string{"previous-secret"}, header, body, now, nil},
+		{"one of receiver secrets", []string{"other-secret", "current-secret"}, header, body, now, nil},
+		{"within tolerance", []string{"current-secret"}, header, body, now.Add(4 * time.Minute), nil},
+		{"unknown secret", []string{"other-secret"}, header, body, now, ErrInvalidSignature},
+		{"altered body", []string{"current-secret"}, header, []byte(`{}`), now, ErrInvalidSignature},
+		{"altered timestamp", []string{"current-secret"}, "t=1700000001" + header[len("t=1700000000"):], body, now,
+			ErrInvalidSignature},
+		{"expired", []string{"current-secret"}, header, body, now.Add(6 * time.Minute), ErrSignatureExpired},
+		{"from the future", []string{"current-secret"}, header, body, now.Add(-6 * time.Minute), ErrSignatureExpired},
+		{"missing", []string{"current-secret"}, "", body, now, ErrInvalidSignature},
+		{"no signatures", []string{"current-secret"}, "t=1700000000", body, now, ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.secrets, tt.header, tt.body, tt.now, 5*time.Minute)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_teams_lead_user_id;
+ALTER TABLE teams DROP CONSTRAINT IF EXISTS fk_teams_lead_user_id;
+ALTER TABLE teams DROP COLUMN IF EXISTS lead_user_id;
@@ -0,0 +1,6 @@
+ALTER TABLE teams ADD COLUMN lead_user_id VARCHAR(255);
+
+ALTER TABLE teams ADD CONSTRAINT fk_teams_lead_user_id FOREIGN KEY (lead_user_id)
+    REFERENCES users(user_id) ON DELETE SET NULL;
+
+CREATE INDEX idx_teams_lead_user_id ON teams(lead_user_id);
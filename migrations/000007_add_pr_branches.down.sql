@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_pull_requests_target_branch;
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS chk_target_branch_length;
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS chk_source_branch_length;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS target_branch;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS source_branch;
@@ -0,0 +1,9 @@
+ALTER TABLE pull_requests ADD COLUMN source_branch VARCHAR(255);
+ALTER TABLE pull_requests ADD COLUMN target_branch VARCHAR(255);
+
+ALTER TABLE pull_requests ADD CONSTRAINT chk_source_branch_length
+    CHECK (source_branch IS NULL OR LENGTH(source_branch) BETWEEN 1 AND 255);
+ALTER TABLE pull_requests ADD CONSTRAINT chk_target_branch_length
+    CHECK (target_branch IS NULL OR LENGTH(target_branch) BETWEEN 1 AND 255);
+
+CREATE INDEX idx_pull_requests_target_branch ON pull_requests(target_branch);
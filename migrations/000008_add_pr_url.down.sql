@@ -0,0 +1,2 @@
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS chk_pull_request_url_length;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS pull_request_url;
@@ -0,0 +1,4 @@
+ALTER TABLE pull_requests ADD COLUMN pull_request_url VARCHAR(2048);
+
+ALTER TABLE pull_requests ADD CONSTRAINT chk_pull_request_url_length
+    CHECK (pull_request_url IS NULL OR LENGTH(pull_request_url) BETWEEN 1 AND 2048);
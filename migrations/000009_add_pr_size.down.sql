@@ -0,0 +1,4 @@
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS chk_lines_removed_non_negative;
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS chk_lines_added_non_negative;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS lines_removed;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS lines_added;
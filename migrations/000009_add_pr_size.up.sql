@@ -0,0 +1,5 @@
+ALTER TABLE pull_requests ADD COLUMN lines_added INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE pull_requests ADD COLUMN lines_removed INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE pull_requests ADD CONSTRAINT chk_lines_added_non_negative CHECK (lines_added >= 0);
+ALTER TABLE pull_requests ADD CONSTRAINT chk_lines_removed_non_negative CHECK (lines_removed >= 0);
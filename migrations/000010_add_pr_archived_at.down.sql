@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_pull_requests_archive_candidates;
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS chk_archived_only_merged;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS archived_at;
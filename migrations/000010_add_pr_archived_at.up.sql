@@ -0,0 +1,7 @@
+ALTER TABLE pull_requests ADD COLUMN archived_at TIMESTAMPTZ;
+
+ALTER TABLE pull_requests ADD CONSTRAINT chk_archived_only_merged
+    CHECK (archived_at IS NULL OR status = 'MERGED');
+
+CREATE INDEX idx_pull_requests_archive_candidates ON pull_requests(merged_at)
+    WHERE archived_at IS NULL AND status = 'MERGED';
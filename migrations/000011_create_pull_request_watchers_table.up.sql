@@ -0,0 +1,13 @@
+CREATE TABLE pull_request_watchers (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_watchers_pull_request_id FOREIGN KEY (pull_request_id)
+        REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    CONSTRAINT fk_watchers_user_id FOREIGN KEY (user_id)
+        REFERENCES users(user_id) ON DELETE CASCADE,
+    CONSTRAINT uq_watchers_pr_user UNIQUE (pull_request_id, user_id)
+);
+
+CREATE INDEX idx_watchers_user_id ON pull_request_watchers(user_id);
@@ -0,0 +1,15 @@
+CREATE TABLE pull_request_events (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL,
+    event_type VARCHAR(32) NOT NULL,
+    user_id VARCHAR(255),
+    previous_user_id VARCHAR(255),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_events_pull_request_id FOREIGN KEY (pull_request_id)
+        REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    CONSTRAINT chk_events_type CHECK (
+        event_type IN ('CREATED', 'REVIEWER_ASSIGNED', 'REVIEWER_REPLACED', 'REVIEWER_REMOVED', 'MERGED')
+    )
+);
+
+CREATE INDEX idx_events_pull_request_id ON pull_request_events(pull_request_id, created_at, id);
@@ -0,0 +1 @@
+ALTER TABLE pull_requests ADD COLUMN has_conflicts BOOLEAN NOT NULL DEFAULT FALSE;
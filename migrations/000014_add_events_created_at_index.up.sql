@@ -0,0 +1 @@
+CREATE INDEX idx_events_created_at ON pull_request_events(created_at, id);
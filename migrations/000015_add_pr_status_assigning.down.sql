@@ -0,0 +1,8 @@
+-- PostgreSQL cannot drop an enum value, so the type is recreated without it
+UPDATE pull_requests SET status = 'OPEN' WHERE status = 'ASSIGNING';
+
+ALTER TYPE pr_status_enum RENAME TO pr_status_enum_old;
+CREATE TYPE pr_status_enum AS ENUM ('OPEN', 'MERGED');
+ALTER TABLE pull_requests
+    ALTER COLUMN status TYPE pr_status_enum USING status::text::pr_status_enum;
+DROP TYPE pr_status_enum_old;
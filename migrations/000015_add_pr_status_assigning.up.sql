@@ -0,0 +1 @@
+ALTER TYPE pr_status_enum ADD VALUE IF NOT EXISTS 'ASSIGNING';
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_pull_requests_tenant_status;
+DROP INDEX IF EXISTS idx_users_tenant_id;
+DROP INDEX IF EXISTS idx_teams_tenant_id;
+
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE users DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE teams DROP COLUMN IF EXISTS tenant_id;
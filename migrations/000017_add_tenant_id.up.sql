@@ -0,0 +1,8 @@
+-- Existing rows belong to the default tenant; identifiers stay globally unique across tenants
+ALTER TABLE teams ADD COLUMN tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE users ADD COLUMN tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE pull_requests ADD COLUMN tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+
+CREATE INDEX idx_teams_tenant_id ON teams(tenant_id);
+CREATE INDEX idx_users_tenant_id ON users(tenant_id);
+CREATE INDEX idx_pull_requests_tenant_status ON pull_requests(tenant_id, status);
@@ -0,0 +1,9 @@
+-- Manual assignments are kept in the log as regular ones
+UPDATE pull_request_events SET event_type = 'REVIEWER_ASSIGNED' WHERE event_type = 'REVIEWER_ASSIGNED_MANUALLY';
+
+ALTER TABLE pull_request_events DROP CONSTRAINT chk_events_type;
+ALTER TABLE pull_request_events ADD CONSTRAINT chk_events_type CHECK (
+    event_type IN ('CREATED', 'REVIEWER_ASSIGNED', 'REVIEWER_REPLACED', 'REVIEWER_REMOVED', 'MERGED')
+);
+
+ALTER TABLE pull_request_events DROP COLUMN actor_id;
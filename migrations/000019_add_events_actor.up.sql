@@ -0,0 +1,9 @@
+-- Manual actions record the user who performed them
+ALTER TABLE pull_request_events ADD COLUMN actor_id VARCHAR(255);
+
+ALTER TABLE pull_request_events DROP CONSTRAINT chk_events_type;
+ALTER TABLE pull_request_events ADD CONSTRAINT chk_events_type CHECK (
+    event_type IN (
+        'CREATED', 'REVIEWER_ASSIGNED', 'REVIEWER_ASSIGNED_MANUALLY', 'REVIEWER_REPLACED', 'REVIEWER_REMOVED', 'MERGED'
+    )
+);
@@ -0,0 +1,9 @@
+-- Authorship transfers have no equivalent in the previous event types
+DELETE FROM pull_request_events WHERE event_type = 'AUTHOR_CHANGED';
+
+ALTER TABLE pull_request_events DROP CONSTRAINT chk_events_type;
+ALTER TABLE pull_request_events ADD CONSTRAINT chk_events_type CHECK (
+    event_type IN (
+        'CREATED', 'REVIEWER_ASSIGNED', 'REVIEWER_ASSIGNED_MANUALLY', 'REVIEWER_REPLACED', 'REVIEWER_REMOVED', 'MERGED'
+    )
+);
@@ -0,0 +1 @@
+ALTER TABLE pull_request_events DROP COLUMN reason;
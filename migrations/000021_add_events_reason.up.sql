@@ -0,0 +1,2 @@
+-- Manual actions that bypass business rules record the justification given by the user
+ALTER TABLE pull_request_events ADD COLUMN reason TEXT;
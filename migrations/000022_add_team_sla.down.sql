@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS sla_violations;
+ALTER TABLE teams DROP COLUMN IF EXISTS sla_merge_hours;
+ALTER TABLE teams DROP COLUMN IF EXISTS sla_first_review_hours;
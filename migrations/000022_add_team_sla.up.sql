@@ -0,0 +1,19 @@
+-- Review SLA of a team in hours; NULL disables the corresponding check
+ALTER TABLE teams ADD COLUMN sla_first_review_hours INTEGER CHECK (sla_first_review_hours > 0);
+ALTER TABLE teams ADD COLUMN sla_merge_hours INTEGER CHECK (sla_merge_hours > 0);
+
+-- Each pull request violates each SLA at most once, so the team lead is alerted once
+CREATE TABLE sla_violations (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL,
+    team_name VARCHAR(255) NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    threshold_hours INTEGER NOT NULL,
+    detected_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_sla_violations_pull_request_id FOREIGN KEY (pull_request_id)
+        REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    CONSTRAINT uq_sla_violations_pr_kind UNIQUE (pull_request_id, kind),
+    CONSTRAINT chk_sla_violations_kind CHECK (kind IN ('FIRST_REVIEW', 'MERGE'))
+);
+
+CREATE INDEX idx_sla_violations_team_detected ON sla_violations(team_name, detected_at);
@@ -0,0 +1,17 @@
+-- Every change of users.is_active made through the API, newest entries are read first
+CREATE TABLE user_activation_history (
+    id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    old_is_active BOOLEAN NOT NULL,
+    new_is_active BOOLEAN NOT NULL,
+    source VARCHAR(32) NOT NULL,
+    actor_id VARCHAR(255),
+    reason TEXT,
+    tenant_id VARCHAR(255) NOT NULL DEFAULT 'default',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_user_activation_history_user_id FOREIGN KEY (user_id)
+        REFERENCES users(user_id) ON DELETE CASCADE,
+    CONSTRAINT chk_user_activation_history_source CHECK (source IN ('SET_IS_ACTIVE', 'BULK_DEACTIVATE'))
+);
+
+CREATE INDEX idx_user_activation_history_user_created ON user_activation_history(user_id, created_at, id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_events_source_created_at;
+ALTER TABLE pull_request_events DROP COLUMN IF EXISTS source;
@@ -0,0 +1,11 @@
+-- Path through which a reviewer was assigned, replaced or removed; NULL for other events and for
+-- reviewer events recorded before the column existed
+ALTER TABLE pull_request_events ADD COLUMN source VARCHAR(32);
+ALTER TABLE pull_request_events ADD CONSTRAINT chk_pull_request_events_source CHECK (source IN (
+    'AUTO', 'MANUAL', 'REASSIGN', 'AUTHOR_CHANGE', 'TEAM_TRANSFER', 'DEACTIVATION', 'REBALANCE', 'RECONCILE'
+));
+
+-- Manual assignments are the only reviewer events whose path is known from the event type
+UPDATE pull_request_events SET source = 'MANUAL' WHERE event_type = 'REVIEWER_ASSIGNED_MANUALLY';
+
+CREATE INDEX idx_events_source_created_at ON pull_request_events(source, created_at) WHERE source IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_pull_requests_team_name;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS team_name;
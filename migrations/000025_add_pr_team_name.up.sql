@@ -0,0 +1,9 @@
+-- Team owning a pull request: the author's team at creation, changed by /pullRequest/transferTeam.
+-- Reviewer candidates and manual assignments are restricted to this team.
+ALTER TABLE pull_requests ADD COLUMN team_name VARCHAR(255);
+
+UPDATE pull_requests SET team_name = users.team_name
+FROM users
+WHERE users.user_id = pull_requests.author_id;
+
+CREATE INDEX idx_pull_requests_team_name ON pull_requests(team_name);
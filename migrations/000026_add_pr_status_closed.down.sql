@@ -0,0 +1,19 @@
+-- Closed pull requests have no equivalent in the previous statuses and are reopened
+DELETE FROM pull_request_events WHERE event_type = 'CLOSED';
+
+ALTER TABLE pull_request_events DROP CONSTRAINT chk_events_type;
+ALTER TABLE pull_request_events ADD CONSTRAINT chk_events_type CHECK (
+    event_type IN (
+        'CREATED', 'REVIEWER_ASSIGNED', 'REVIEWER_ASSIGNED_MANUALLY', 'REVIEWER_REPLACED', 'REVIEWER_REMOVED',
+        'AUTHOR_CHANGED', 'MERGED'
+    )
+);
+
+-- PostgreSQL cannot drop an enum value, so the type is recreated without it
+UPDATE pull_requests SET status = 'OPEN' WHERE status = 'CLOSED';
+
+ALTER TYPE pr_status_enum RENAME TO pr_status_enum_old;
+CREATE TYPE pr_status_enum AS ENUM ('OPEN', 'MERGED', 'ASSIGNING');
+ALTER TABLE pull_requests
+    ALTER COLUMN status TYPE pr_status_enum USING status::text::pr_status_enum;
+DROP TYPE pr_status_enum_old;
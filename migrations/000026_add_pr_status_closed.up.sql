@@ -0,0 +1,10 @@
+-- Pull requests can be closed without merging; closing is recorded in the activity log
+ALTER TYPE pr_status_enum ADD VALUE IF NOT EXISTS 'CLOSED';
+
+ALTER TABLE pull_request_events DROP CONSTRAINT chk_events_type;
+ALTER TABLE pull_request_events ADD CONSTRAINT chk_events_type CHECK (
+    event_type IN (
+        'CREATED', 'REVIEWER_ASSIGNED', 'REVIEWER_ASSIGNED_MANUALLY', 'REVIEWER_REPLACED', 'REVIEWER_REMOVED',
+        'AUTHOR_CHANGED', 'MERGED', 'CLOSED'
+    )
+);
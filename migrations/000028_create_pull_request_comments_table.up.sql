@@ -0,0 +1,14 @@
+CREATE TABLE pull_request_comments (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_comments_pull_request_id FOREIGN KEY (pull_request_id)
+        REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    CONSTRAINT fk_comments_user_id FOREIGN KEY (user_id)
+        REFERENCES users(user_id) ON DELETE CASCADE,
+    CONSTRAINT chk_comments_body_length CHECK (LENGTH(body) BETWEEN 1 AND 10000)
+);
+
+CREATE INDEX idx_comments_pull_request_id ON pull_request_comments(pull_request_id, id);
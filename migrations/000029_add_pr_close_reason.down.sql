@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_pull_requests_close_reason;
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS chk_close_note_length;
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS chk_close_reason;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS close_note;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS close_reason;
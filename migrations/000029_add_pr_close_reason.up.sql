@@ -0,0 +1,13 @@
+-- Closed pull requests keep why they were closed, for reporting on abandoned work
+ALTER TABLE pull_requests ADD COLUMN close_reason VARCHAR(32);
+ALTER TABLE pull_requests ADD COLUMN close_note TEXT;
+
+-- Pull requests closed before reasons were recorded
+UPDATE pull_requests SET close_reason = 'OTHER' WHERE status = 'CLOSED';
+
+ALTER TABLE pull_requests ADD CONSTRAINT chk_close_reason CHECK (
+    close_reason IS NULL OR close_reason IN ('ABANDONED', 'SUPERSEDED', 'DUPLICATE', 'REJECTED', 'STALE', 'OTHER')
+);
+ALTER TABLE pull_requests ADD CONSTRAINT chk_close_note_length CHECK (close_note IS NULL OR LENGTH(close_note) <= 1000);
+
+CREATE INDEX idx_pull_requests_close_reason ON pull_requests(close_reason) WHERE close_reason IS NOT NULL;
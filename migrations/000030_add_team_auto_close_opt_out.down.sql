@@ -0,0 +1 @@
+ALTER TABLE teams DROP COLUMN IF EXISTS auto_close_disabled;
@@ -0,0 +1,2 @@
+-- Teams can opt out of closing their inactive pull requests by the stale job
+ALTER TABLE teams ADD COLUMN auto_close_disabled BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS xact_locks;
+DROP TABLE IF EXISTS pull_request_comments;
+DROP TABLE IF EXISTS user_activation_history;
+DROP TABLE IF EXISTS sla_violations;
+DROP TABLE IF EXISTS leader_leases;
+DROP TABLE IF EXISTS webhook_dead_letters;
+DROP TABLE IF EXISTS pull_request_events;
+DROP TABLE IF EXISTS pull_request_watchers;
+DROP TABLE IF EXISTS pull_request_reviewers;
+DROP TABLE IF EXISTS pull_requests;
+ALTER TABLE teams DROP FOREIGN KEY fk_teams_lead_user_id;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS teams;
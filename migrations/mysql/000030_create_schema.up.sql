@@ -0,0 +1,212 @@
+-- MySQL schema matching the PostgreSQL migrations up to 000030. Later migrations are added to both
+-- directories under the same version, so the schema version means the same on both databases.
+-- Identifiers are compared case-sensitively (utf8mb4_bin) and timestamps are stored without a time
+-- zone in DB_TIMEZONE, which the driver converts to and from.
+-- Differences: user search has no trigram indexes, partial indexes are plain indexes, and the
+-- updated_at trigger of teams is left out because the service sets updated_at itself.
+
+CREATE TABLE teams (
+    team_name VARCHAR(255) PRIMARY KEY,
+    lead_user_id VARCHAR(255),
+    sla_first_review_hours INTEGER,
+    sla_merge_hours INTEGER,
+    auto_close_disabled BOOLEAN NOT NULL DEFAULT FALSE,
+    tenant_id VARCHAR(255) NOT NULL DEFAULT 'default',
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    CONSTRAINT chk_teams_team_name_length CHECK (CHAR_LENGTH(team_name) BETWEEN 1 AND 255),
+    CONSTRAINT chk_teams_sla_first_review_hours CHECK (sla_first_review_hours > 0),
+    CONSTRAINT chk_teams_sla_merge_hours CHECK (sla_merge_hours > 0),
+    INDEX idx_teams_lead_user_id (lead_user_id),
+    INDEX idx_teams_tenant_id (tenant_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE users (
+    user_id VARCHAR(255) PRIMARY KEY,
+    username VARCHAR(255) NOT NULL,
+    team_name VARCHAR(255) NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    tenant_id VARCHAR(255) NOT NULL DEFAULT 'default',
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    CONSTRAINT fk_users_team_name FOREIGN KEY (team_name)
+        REFERENCES teams(team_name) ON DELETE RESTRICT,
+    CONSTRAINT chk_users_user_id_length CHECK (CHAR_LENGTH(user_id) BETWEEN 1 AND 255),
+    CONSTRAINT chk_users_username_length CHECK (CHAR_LENGTH(username) BETWEEN 1 AND 255),
+    CONSTRAINT chk_users_team_name_length CHECK (CHAR_LENGTH(team_name) BETWEEN 1 AND 255),
+    INDEX idx_users_team_active (team_name, is_active),
+    INDEX idx_users_tenant_id (tenant_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+ALTER TABLE teams ADD CONSTRAINT fk_teams_lead_user_id FOREIGN KEY (lead_user_id)
+    REFERENCES users(user_id) ON DELETE SET NULL;
+
+CREATE TABLE pull_requests (
+    pull_request_id VARCHAR(255) PRIMARY KEY,
+    pull_request_name VARCHAR(255) NOT NULL,
+    author_id VARCHAR(255) NOT NULL,
+    team_name VARCHAR(255),
+    status ENUM('OPEN', 'MERGED', 'ASSIGNING', 'CLOSED') NOT NULL,
+    source_branch VARCHAR(255),
+    target_branch VARCHAR(255),
+    pull_request_url VARCHAR(2048),
+    lines_added INTEGER NOT NULL DEFAULT 0,
+    lines_removed INTEGER NOT NULL DEFAULT 0,
+    has_conflicts BOOLEAN NOT NULL DEFAULT FALSE,
+    close_reason VARCHAR(32),
+    close_note TEXT,
+    tenant_id VARCHAR(255) NOT NULL DEFAULT 'default',
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    merged_at DATETIME(6),
+    archived_at DATETIME(6),
+    CONSTRAINT fk_pull_requests_author_id FOREIGN KEY (author_id)
+        REFERENCES users(user_id) ON DELETE RESTRICT,
+    CONSTRAINT chk_pull_requests_pull_request_id_length CHECK (CHAR_LENGTH(pull_request_id) BETWEEN 1 AND 255),
+    CONSTRAINT chk_pull_requests_pull_request_name_length CHECK (CHAR_LENGTH(pull_request_name) BETWEEN 1 AND 255),
+    CONSTRAINT chk_pull_requests_author_id_length CHECK (CHAR_LENGTH(author_id) BETWEEN 1 AND 255),
+    CONSTRAINT chk_merged_at_after_created CHECK (merged_at IS NULL OR merged_at >= created_at),
+    CONSTRAINT chk_merged_status_consistency CHECK (
+        (status = 'MERGED' AND merged_at IS NOT NULL) OR
+        (status != 'MERGED' AND merged_at IS NULL)
+    ),
+    CONSTRAINT chk_source_branch_length
+        CHECK (source_branch IS NULL OR CHAR_LENGTH(source_branch) BETWEEN 1 AND 255),
+    CONSTRAINT chk_target_branch_length
+        CHECK (target_branch IS NULL OR CHAR_LENGTH(target_branch) BETWEEN 1 AND 255),
+    CONSTRAINT chk_pull_request_url_length
+        CHECK (pull_request_url IS NULL OR CHAR_LENGTH(pull_request_url) BETWEEN 1 AND 2048),
+    CONSTRAINT chk_lines_added_non_negative CHECK (lines_added >= 0),
+    CONSTRAINT chk_lines_removed_non_negative CHECK (lines_removed >= 0),
+    CONSTRAINT chk_archived_only_merged CHECK (archived_at IS NULL OR status = 'MERGED'),
+    CONSTRAINT chk_close_reason CHECK (
+        close_reason IS NULL OR close_reason IN ('ABANDONED', 'SUPERSEDED', 'DUPLICATE', 'REJECTED', 'STALE', 'OTHER')
+    ),
+    CONSTRAINT chk_close_note_length CHECK (close_note IS NULL OR CHAR_LENGTH(close_note) <= 1000),
+    INDEX idx_pull_requests_status (status),
+    INDEX idx_pull_requests_target_branch (target_branch),
+    INDEX idx_pull_requests_archive_candidates (status, archived_at, merged_at),
+    INDEX idx_pull_requests_tenant_status (tenant_id, status),
+    INDEX idx_pull_requests_team_name (team_name),
+    INDEX idx_pull_requests_close_reason (close_reason)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE pull_request_reviewers (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    assigned_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    CONSTRAINT fk_reviewers_pull_request_id FOREIGN KEY (pull_request_id)
+        REFERENCES pull_requests(pull_request_id) ON DELETE RESTRICT,
+    CONSTRAINT fk_reviewers_user_id FOREIGN KEY (user_id)
+        REFERENCES users(user_id) ON DELETE RESTRICT,
+    CONSTRAINT uq_reviewers_pr_user UNIQUE (pull_request_id, user_id),
+    CONSTRAINT chk_reviewers_pull_request_id_length CHECK (CHAR_LENGTH(pull_request_id) BETWEEN 1 AND 255),
+    CONSTRAINT chk_reviewers_user_id_length CHECK (CHAR_LENGTH(user_id) BETWEEN 1 AND 255),
+    INDEX idx_reviewers_user_id (user_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE pull_request_watchers (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    CONSTRAINT fk_watchers_pull_request_id FOREIGN KEY (pull_request_id)
+        REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    CONSTRAINT fk_watchers_user_id FOREIGN KEY (user_id)
+        REFERENCES users(user_id) ON DELETE CASCADE,
+    CONSTRAINT uq_watchers_pr_user UNIQUE (pull_request_id, user_id),
+    INDEX idx_watchers_user_id (user_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE pull_request_events (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL,
+    event_type VARCHAR(32) NOT NULL,
+    user_id VARCHAR(255),
+    previous_user_id VARCHAR(255),
+    actor_id VARCHAR(255),
+    reason TEXT,
+    source VARCHAR(32),
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    CONSTRAINT fk_events_pull_request_id FOREIGN KEY (pull_request_id)
+        REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    CONSTRAINT chk_events_type CHECK (
+        event_type IN (
+            'CREATED', 'REVIEWER_ASSIGNED', 'REVIEWER_ASSIGNED_MANUALLY', 'REVIEWER_REPLACED', 'REVIEWER_REMOVED',
+            'AUTHOR_CHANGED', 'MERGED', 'CLOSED', 'REOPENED'
+        )
+    ),
+    CONSTRAINT chk_pull_request_events_source CHECK (source IN (
+        'AUTO', 'MANUAL', 'REASSIGN', 'AUTHOR_CHANGE', 'TEAM_TRANSFER', 'DEACTIVATION', 'REBALANCE', 'RECONCILE'
+    )),
+    INDEX idx_events_pull_request_id (pull_request_id, created_at, id),
+    INDEX idx_events_created_at (created_at, id),
+    INDEX idx_events_source_created_at (source, created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE webhook_dead_letters (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    event VARCHAR(64) NOT NULL,
+    pull_request_id VARCHAR(255) NOT NULL,
+    payload JSON NOT NULL,
+    attempts INTEGER NOT NULL,
+    last_error TEXT NOT NULL,
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    INDEX idx_webhook_dead_letters_created_at (created_at, id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE leader_leases (
+    name VARCHAR(64) PRIMARY KEY,
+    holder VARCHAR(255) NOT NULL,
+    expires_at DATETIME(6) NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE sla_violations (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL,
+    team_name VARCHAR(255) NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    threshold_hours INTEGER NOT NULL,
+    detected_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    CONSTRAINT fk_sla_violations_pull_request_id FOREIGN KEY (pull_request_id)
+        REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    CONSTRAINT uq_sla_violations_pr_kind UNIQUE (pull_request_id, kind),
+    CONSTRAINT chk_sla_violations_kind CHECK (kind IN ('FIRST_REVIEW', 'MERGE')),
+    INDEX idx_sla_violations_team_detected (team_name, detected_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE user_activation_history (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    old_is_active BOOLEAN NOT NULL,
+    new_is_active BOOLEAN NOT NULL,
+    source VARCHAR(32) NOT NULL,
+    actor_id VARCHAR(255),
+    reason TEXT,
+    tenant_id VARCHAR(255) NOT NULL DEFAULT 'default',
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    CONSTRAINT fk_user_activation_history_user_id FOREIGN KEY (user_id)
+        REFERENCES users(user_id) ON DELETE CASCADE,
+    CONSTRAINT chk_user_activation_history_source CHECK (source IN ('SET_IS_ACTIVE', 'BULK_DEACTIVATE')),
+    INDEX idx_user_activation_history_user_created (user_id, created_at, id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE pull_request_comments (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    CONSTRAINT fk_comments_pull_request_id FOREIGN KEY (pull_request_id)
+        REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    CONSTRAINT fk_comments_user_id FOREIGN KEY (user_id)
+        REFERENCES users(user_id) ON DELETE CASCADE,
+    CONSTRAINT chk_comments_body_length CHECK (CHAR_LENGTH(body) BETWEEN 1 AND 10000),
+    INDEX idx_comments_pull_request_id (pull_request_id, id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+-- Transaction locks of pkg/lock: MySQL has no transaction-scoped advisory locks, so a transaction
+-- locks the row of the lock key until it ends
+CREATE TABLE xact_locks (
+    lock_key BIGINT PRIMARY KEY
+) ENGINE=InnoDB;
@@ -0,0 +1,225 @@
+// Package apierror defines API error codes and writes error responses in the format of the OpenAPI spec.
+package apierror
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Error codes returned in the "code" field of error responses.
+const (
+	CodeInvalidRequest   = "INVALID_REQUEST"
+	CodeNotFound         = "NOT_FOUND"
+	CodeTeamExists       = "TEAM_EXISTS"
+	CodePRExists         = "PR_EXISTS"
+	CodePRDuplicate      = "PR_DUPLICATE"
+	CodePRMerged         = "PR_MERGED"
+	CodePRHasConflicts   = "PR_HAS_CONFLICTS"
+	CodeNotAssigned      = "NOT_ASSIGNED"
+	CodeNoCandidate      = "NO_CANDIDATE"
+	CodeQueueFull        = "QUEUE_FULL"
+	CodeUnauthorized     = "UNAUTHORIZED"
+	CodeForbidden        = "FORBIDDEN"
+	CodeMethodNotAllowed = "METHOD_NOT_ALLOWED"
+	CodePayloadTooLarge  = "PAYLOAD_TOO_LARGE"
+	CodeInternal         = "INTERNAL_ERROR"
+
+	// Conflicts of manual reviewer changes: the reviewer is already assigned, the PR has the maximum or
+	// minimum number of reviewers, the reviewer has reached the load limit, or automatic assignment is
+	// in progress.
+	CodeAlreadyAssigned    = "ALREADY_ASSIGNED"
+	CodeTooManyReviewers   = "TOO_MANY_REVIEWERS"
+	CodeTooFewReviewers    = "TOO_FEW_REVIEWERS"
+	CodeReviewerOverloaded = "REVIEWER_OVERLOADED"
+	CodeAssignmentPending  = "ASSIGNMENT_PENDING"
+
+	// CodePRClosed reports a change of a pull request closed without merging.
+	CodePRClosed = "PR_CLOSED"
+
+	// CodeIDUnavailable reports an ID taken by another tenant; the ID of other tenants' data is not
+	// reported as existing.
+	CodeIDUnavailable = "ID_UNAVAILABLE"
+
+	// CodeConcurrentUpdate reports a transient conflict with a concurrent request; the request can be retried.
+	CodeConcurrentUpdate = "CONCURRENT_UPDATE"
+
+	// CodeTimeout reports a request that exceeded its time budget; the request can be retried.
+	CodeTimeout = "TIMEOUT"
+
+	// CodeUnavailable reports a temporarily unavailable dependency, e.g. the database behind an open
+	// circuit breaker; the request can be retried.
+	CodeUnavailable = "SERVICE_UNAVAILABLE"
+)
+
+// Error is an error returned to API clients.
+type Error struct {
+	// Code is the machine-readable error code.
+	Code string
+	// Status is the HTTP status code of the response.
+	Status int
+	// Message is the client-facing message; empty means the message of Err.
+	Message string
+	// Err is the underlying error. It is never exposed for internal errors.
+	Err error
+	// Details lists invalid request fields.
+	Details []FieldError
+	// ErrorID identifies the failure in logs and error reports, so users can reference it.
+	ErrorID string
+	// RetryAfter is the delay after which the request may be repeated; 0 means it is not retryable.
+	RetryAfter time.Duration
+}
+
+// New creates an API error.
+func New(code string, status int, message string) *Error {
+	return &Error{Code: code, Status: status, Message: message}
+}
+
+// InvalidRequest creates a 400 INVALID_REQUEST error.
+func InvalidRequest(message string) *Error {
+	return New(CodeInvalidRequest, http.StatusBadRequest, message)
+}
+
+// NotFound creates a 404 NOT_FOUND error.
+func NotFound(message string) *Error {
+	return New(CodeNotFound, http.StatusNotFound, message)
+}
+
+// Unauthorized creates a 401 UNAUTHORIZED error.
+func Unauthorized(message string) *Error {
+	return New(CodeUnauthorized, http.StatusUnauthorized, message)
+}
+
+// Forbidden creates a 403 FORBIDDEN error.
+func Forbidden(message string) *Error {
+	return New(CodeForbidden, http.StatusForbidden, message)
+}
+
+// MethodNotAllowed creates a 405 METHOD_NOT_ALLOWED error.
+func MethodNotAllowed(message string) *Error {
+	return New(CodeMethodNotAllowed, http.StatusMethodNotAllowed, message)
+}
+
+// Conflict creates a 409 error with the given code.
+func Conflict(code, message string) *Error {
+	return New(code, http.StatusConflict, message)
+}
+
+// ConcurrentUpdate creates a retryable 409 CONCURRENT_UPDATE error for requests that lost a race
+// with a concurrent transaction (deadlock, serialization failure, lock timeout).
+func ConcurrentUpdate() *Error {
+	return Conflict(CodeConcurrentUpdate, "request conflicts with a concurrent update, retry later").
+		WithRetryAfter(time.Second)
+}
+
+// Timeout creates a retryable 503 TIMEOUT error for requests that ran out of their time budget.
+func Timeout() *Error {
+	return New(CodeTimeout, http.StatusServiceUnavailable, "request exceeded its time budget, retry later").
+		WithRetryAfter(time.Second)
+}
+
+// Unavailable creates a 503 SERVICE_UNAVAILABLE error retryable after retryAfter.
+func Unavailable(retryAfter time.Duration) *Error {
+	return New(CodeUnavailable, http.StatusServiceUnavailable, "service is temporarily unavailable, retry later").
+		WithRetryAfter(retryAfter)
+}
+
+// Internal creates a 500 INTERNAL_ERROR error wrapping err.
+func Internal(err error) *Error {
+	return &Error{
+		Code:    CodeInternal,
+		Status:  http.StatusInternalServerError,
+		Message: "internal server error",
+		Err:     err,
+	}
+}
+
+// WithRetryAfter returns a copy of e marked as retryable after d. The response then carries
+// a Retry-After header and "retryable": true.
+func (e *Error) WithRetryAfter(d time.Duration) *Error {
+	retryable := *e
+	retryable.RetryAfter = d
+	return &retryable
+}
+
+// Retryable reports whether the request may be repeated.
+func (e *Error) Retryable() bool {
+	return e.RetryAfter > 0
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return e.Code + ": " + e.message()
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// wrap returns a copy of e caused by err.
+func (e *Error) wrap(err error) *Error {
+	wrapped := *e
+	wrapped.Err = err
+	return &wrapped
+}
+
+func (e *Error) message() string {
+	if e.Message == "" && e.Err != nil {
+		return e.Err.Error()
+	}
+	return e.Message
+}
+
+// Response is the error response body.
+type Response struct {
+	Error Body `json:"error"`
+}
+
+// Body holds the error code, message and, for invalid requests, field details.
+type Body struct {
+	Code      string       `json:"code"`
+	Message   string       `json:"message"`
+	ErrorID   string       `json:"error_id,omitempty"`
+	Retryable bool         `json:"retryable,omitempty"`
+	Details   []FieldError `json:"details,omitempty"`
+}
+
+// Write writes err as an error response in the format of the request (see UseFormat) and counts it
+// in ErrorsTotal. Errors that are not *Error are written as INTERNAL_ERROR.
+func Write(c *gin.Context, err error) {
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		apiErr = Internal(err)
+	}
+	observe(c, apiErr)
+	if apiErr.Retryable() {
+		c.Header("Retry-After", retryAfterSeconds(apiErr.RetryAfter))
+	}
+	if formatOf(c) == FormatProblem {
+		writeProblem(c, apiErr)
+		return
+	}
+	c.JSON(apiErr.Status, Response{Error: Body{
+		Code:      apiErr.Code,
+		Message:   apiErr.message(),
+		ErrorID:   apiErr.ErrorID,
+		Retryable: apiErr.Retryable(),
+		Details:   apiErr.Details,
+	}})
+}
+
+// retryAfterSeconds formats d as Retry-After delay-seconds, rounded up to at least one second.
+func retryAfterSeconds(d time.Duration) string {
+	seconds := int((d + time.Second - 1) / time.Second)
+	return strconv.Itoa(max(seconds, 1))
+}
+
+// Abort writes err as an error response and stops the handler chain.
+func Abort(c *gin.Context, err error) {
+	Write(c, err)
+	c.Abort()
+}
@@ -0,0 +1,135 @@
+package apierror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func write(t *testing.T, err error) (*httptest.ResponseRecorder, Response) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	Write(c, err)
+
+	var resp Response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return w, resp
+}
+
+func TestWrite(t *testing.T) {
+	t.Run("api error", func(t *testing.T) {
+		w, resp := write(t, Conflict(CodeNoCandidate, "no active replacement candidate in team"))
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, CodeNoCandidate, resp.Error.Code)
+		assert.Equal(t, "no active replacement candidate in team", resp.Error.Message)
+	})
+
+	t.Run("wrapped api error", func(t *testing.T) {
+		w, resp := write(t, fmt.Errorf("handler: %w", NotFound("team not found")))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, CodeNotFound, resp.Error.Code)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		w, resp := write(t, Forbidden("only the team lead can assign reviewers"))
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Equal(t, CodeForbidden, resp.Error.Code)
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		w, resp := write(t, MethodNotAllowed("method POST is not allowed"))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+		assert.Equal(t, CodeMethodNotAllowed, resp.Error.Code)
+	})
+
+	t.Run("plain error is internal", func(t *testing.T) {
+		w, resp := write(t, errors.New("connection refused"))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, CodeInternal, resp.Error.Code)
+		assert.Equal(t, "internal server error", resp.Error.Message)
+	})
+
+	t.Run("empty message uses underlying error", func(t *testing.T) {
+		cause := errors.New("limit must be between 1 and 100")
+		_, resp := write(t, &Error{Code: CodeInvalidRequest, Status: http.StatusBadRequest, Err: cause})
+
+		assert.Equal(t, cause.Error(), resp.Error.Message)
+	})
+}
+
+func TestAbort(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	Abort(c, InvalidRequest("invalid request body"))
+
+	assert.True(t, c.IsAborted())
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestError_Unwrap(t *testing.T) {
+	cause := errors.New("boom")
+	err := Internal(cause)
+
+	assert.ErrorIs(t, err, cause)
+	assert.Equal(t, "INTERNAL_ERROR: internal server error", err.Error())
+}
+
+func TestWrite_RetryAfter(t *testing.T) {
+	t.Run("retryable error", func(t *testing.T) {
+		w, resp := write(t, ConcurrentUpdate())
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, "1", w.Header().Get("Retry-After"))
+		assert.Equal(t, CodeConcurrentUpdate, resp.Error.Code)
+		assert.True(t, resp.Error.Retryable)
+	})
+
+	t.Run("not retryable error", func(t *testing.T) {
+		w, resp := write(t, NotFound("team not found"))
+
+		assert.Empty(t, w.Header().Get("Retry-After"))
+		assert.False(t, resp.Error.Retryable)
+		assert.NotContains(t, w.Body.String(), "retryable")
+	})
+}
+
+func TestError_WithRetryAfter(t *testing.T) {
+	base := New(CodeQueueFull, http.StatusServiceUnavailable, "queue is full")
+	retryable := base.WithRetryAfter(2 * time.Second)
+
+	assert.True(t, retryable.Retryable())
+	assert.False(t, base.Retryable(), "original error must not be modified")
+}
+
+func TestRetryAfterSeconds(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{time.Second, "1"},
+		{1500 * time.Millisecond, "2"},
+		{100 * time.Millisecond, "1"},
+		{time.Minute, "60"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, retryAfterSeconds(tt.d), tt.d.String())
+	}
+}
@@ -0,0 +1,136 @@
+package apierror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError describes a request field that failed validation.
+type FieldError struct {
+	// Field is the JSON path of the field, e.g. "members[0].user_id".
+	Field string `json:"field"`
+	// Rule is the failed rule, e.g. "required" or "type".
+	Rule string `json:"rule"`
+	// Message is a human-readable description of the failure.
+	Message string `json:"message"`
+}
+
+// init makes validation errors report JSON field names; it must run before the first request is bound.
+func init() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(jsonFieldName)
+	}
+}
+
+// jsonFieldName reports struct fields by their JSON names in validation errors.
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// InvalidField creates a 400 INVALID_REQUEST error with details of a single field.
+func InvalidField(field, rule, message string) *Error {
+	apiErr := InvalidRequest(message)
+	apiErr.Details = []FieldError{{Field: field, Rule: rule, Message: message}}
+	return apiErr
+}
+
+// Binding creates a 400 INVALID_REQUEST error for a failed request body binding, with details
+// of every invalid field.
+func Binding(err error) *Error {
+	apiErr := InvalidRequest("invalid request body")
+	apiErr.Err = err
+	apiErr.Details = bindingDetails(err)
+	return apiErr
+}
+
+func bindingDetails(err error) []FieldError {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		details := make([]FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			field := fieldPath(fe.Namespace())
+			details = append(details, FieldError{
+				Field:   field,
+				Rule:    fe.Tag(),
+				Message: ruleMessage(field, fe.Tag(), fe.Param(), fe.Kind()),
+			})
+		}
+		return details
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return []FieldError{{
+			Field:   typeErr.Field,
+			Rule:    "type",
+			Message: fmt.Sprintf("%s must be %s", typeErr.Field, jsonType(typeErr.Type)),
+		}}
+	}
+
+	return nil
+}
+
+// fieldPath strips the request type name from a validator namespace.
+func fieldPath(namespace string) string {
+	if _, path, ok := strings.Cut(namespace, "."); ok {
+		return path
+	}
+	return namespace
+}
+
+func ruleMessage(field, rule, param string, kind reflect.Kind) string {
+	unit := ""
+	switch kind {
+	case reflect.String:
+		unit = " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		unit = " items"
+	}
+
+	switch rule {
+	case "required":
+		return field + " is required"
+	case "min":
+		return fmt.Sprintf("%s must be at least %s%s", field, param, unit)
+	case "max":
+		return fmt.Sprintf("%s must be at most %s%s", field, param, unit)
+	case "oneof":
+		return fmt.Sprintf("%s must be one of: %s", field, param)
+	default:
+		return fmt.Sprintf("%s failed %s validation", field, rule)
+	}
+}
+
+// jsonType names a Go type the way it appears in JSON.
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.String:
+		return "a string"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Ptr:
+		return jsonType(t.Elem())
+	default:
+		return "an object"
+	}
+}
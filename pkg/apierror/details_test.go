@@ -0,0 +1,72 @@
+package apierror
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type member struct {
+	UserID string `json:"user_id" binding:"required"`
+}
+
+type addTeamRequest struct {
+	TeamName string   `json:"team_name" binding:"required"`
+	Members  []member `json:"members"   binding:"required,dive"`
+	IsActive bool     `json:"is_active"`
+}
+
+func bind(t *testing.T, body string) error {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	var req addTeamRequest
+	err := c.ShouldBindJSON(&req)
+	require.Error(t, err)
+	return err
+}
+
+func TestBinding(t *testing.T) {
+	t.Run("validation errors", func(t *testing.T) {
+		apiErr := Binding(bind(t, `{"members":[{"user_id":"u1"},{}]}`))
+
+		assert.Equal(t, CodeInvalidRequest, apiErr.Code)
+		assert.Equal(t, "invalid request body", apiErr.Message)
+		assert.Equal(t, []FieldError{
+			{Field: "team_name", Rule: "required", Message: "team_name is required"},
+			{Field: "members[1].user_id", Rule: "required", Message: "members[1].user_id is required"},
+		}, apiErr.Details)
+	})
+
+	t.Run("type error", func(t *testing.T) {
+		apiErr := Binding(bind(t, `{"team_name":"backend","members":[],"is_active":"yes"}`))
+
+		assert.Equal(t, []FieldError{
+			{Field: "is_active", Rule: "type", Message: "is_active must be a boolean"},
+		}, apiErr.Details)
+	})
+
+	t.Run("malformed JSON has no details", func(t *testing.T) {
+		apiErr := Binding(bind(t, `{"team_name":`))
+
+		assert.Equal(t, "invalid request body", apiErr.Message)
+		assert.Empty(t, apiErr.Details)
+	})
+}
+
+func TestInvalidField(t *testing.T) {
+	w, resp := write(t, InvalidField("user_id", "required", "user_id parameter is required"))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, []FieldError{
+		{Field: "user_id", Rule: "required", Message: "user_id parameter is required"},
+	}, resp.Error.Details)
+}
@@ -0,0 +1,22 @@
+package apierror
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// Fail writes the error response for err and stops the handler chain. Domain errors are mapped
+// with the zero registry, so only *Error values keep their code; use Registry.Fail for domain errors.
+func Fail(c *gin.Context, err error) {
+	Registry{}.Fail(c, err)
+}
+
+// Fail maps err with r, writes the error response and stops the handler chain.
+//
+// Handlers hand every error to Fail instead of logging and mapping it themselves: err is recorded in
+// c.Errors, so the request logger reports it together with the request, and unregistered errors are
+// written as INTERNAL_ERROR.
+func (r Registry) Fail(c *gin.Context, err error) {
+	_ = c.Error(err)
+	apiErr, _ := r.Lookup(err)
+	Abort(c, apiErr)
+}
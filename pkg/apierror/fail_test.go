@@ -0,0 +1,61 @@
+package apierror
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_Fail(t *testing.T) {
+	errMissing := errors.New("team not found")
+	registry := Registry{}.Register(errMissing, NotFound("resource not found"))
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{"registered error", errMissing, http.StatusNotFound, CodeNotFound},
+		{"API error", InvalidField("limit", "type", "limit must be an integer"),
+			http.StatusBadRequest, CodeInvalidRequest},
+		{"unregistered error", errors.New("connection refused"), http.StatusInternalServerError, CodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			registry.Fail(c, tt.err)
+
+			assert.True(t, c.IsAborted())
+			assert.Equal(t, tt.status, w.Code)
+			var resp Response
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tt.code, resp.Error.Code)
+
+			// The original error is kept for the request logger.
+			require.Len(t, c.Errors, 1)
+			assert.ErrorIs(t, c.Errors.Last(), tt.err)
+		})
+	}
+}
+
+func TestFail(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	Fail(c, errors.New("boom"))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.NotContains(t, w.Body.String(), "boom")
+}
@@ -0,0 +1,27 @@
+package apierror
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// unmatchedRoute labels errors of requests that matched no route.
+const unmatchedRoute = "unmatched"
+
+// ErrorsTotal counts error responses by error code and route template, so business conflicts
+// (PR_EXISTS, NO_CANDIDATE, ...) can be told apart from failures (INTERNAL_ERROR) in alerts.
+// It is not registered by the package; the application registers it with its metrics registry.
+var ErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "api_errors_total",
+	Help: "Number of error responses by error code and route.",
+}, []string{"code", "route"})
+
+// observe counts an error response. Routes are labeled by their template rather than the request
+// path to keep the label cardinality bounded.
+func observe(c *gin.Context, apiErr *Error) {
+	route := c.FullPath()
+	if route == "" {
+		route = unmatchedRoute
+	}
+	ErrorsTotal.WithLabelValues(apiErr.Code, route).Inc()
+}
@@ -0,0 +1,46 @@
+package apierror
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrite_Metrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/pullRequest/create", func(c *gin.Context) {
+		Fail(c, Conflict(CodePRExists, "PR id already exists"))
+	})
+	r.GET("/team/get", func(c *gin.Context) {
+		Fail(c, Internal(assert.AnError))
+	})
+
+	conflicts := testutil.ToFloat64(ErrorsTotal.WithLabelValues(CodePRExists, "/pullRequest/create"))
+	internals := testutil.ToFloat64(ErrorsTotal.WithLabelValues(CodeInternal, "/team/get"))
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodPost, "/pullRequest/create", nil),
+		httptest.NewRequest(http.MethodPost, "/pullRequest/create", nil),
+		httptest.NewRequest(http.MethodGet, "/team/get?team_name=backend", nil),
+	} {
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	assert.Equal(t, conflicts+2, testutil.ToFloat64(ErrorsTotal.WithLabelValues(CodePRExists, "/pullRequest/create")))
+	assert.Equal(t, internals+1, testutil.ToFloat64(ErrorsTotal.WithLabelValues(CodeInternal, "/team/get")))
+}
+
+func TestWrite_MetricsUnmatchedRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	before := testutil.ToFloat64(ErrorsTotal.WithLabelValues(CodeNotFound, unmatchedRoute))
+
+	Write(c, NotFound("not found"))
+
+	assert.Equal(t, before+1, testutil.ToFloat64(ErrorsTotal.WithLabelValues(CodeNotFound, unmatchedRoute)))
+}
@@ -0,0 +1,93 @@
+package apierror
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Format is the format of error response bodies.
+type Format string
+
+const (
+	// FormatDefault is the {"error": {"code", "message"}} format of the OpenAPI spec.
+	FormatDefault Format = "default"
+	// FormatProblem is the RFC 7807 application/problem+json format.
+	FormatProblem Format = "problem"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem details.
+const ProblemContentType = "application/problem+json"
+
+// problemTypePrefix prefixes error codes to build problem type URIs.
+const problemTypePrefix = "urn:avito-internship:error:"
+
+const formatKey = "apierror.format"
+
+// ParseFormat parses an error format name; empty means FormatDefault.
+func ParseFormat(s string) (Format, error) {
+	switch Format(s) {
+	case "", FormatDefault:
+		return FormatDefault, nil
+	case FormatProblem:
+		return FormatProblem, nil
+	}
+	return "", fmt.Errorf("unknown error format %q (must be: default, problem)", s)
+}
+
+// UseFormat returns a middleware setting the error format of requests. Clients can still request
+// problem details with "Accept: application/problem+json" when the default format is used.
+func UseFormat(format Format) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(formatKey, format)
+		c.Next()
+	}
+}
+
+// formatOf returns the error format of the request.
+func formatOf(c *gin.Context) Format {
+	if c.Request != nil && strings.Contains(c.GetHeader("Accept"), ProblemContentType) {
+		return FormatProblem
+	}
+	if format, ok := c.Get(formatKey); ok {
+		if f, ok := format.(Format); ok {
+			return f
+		}
+	}
+	return FormatDefault
+}
+
+// Problem is an RFC 7807 problem details body. Code and Details are extension members
+// carrying the same data as the default format.
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      string       `json:"code"`
+	ErrorID   string       `json:"error_id,omitempty"`
+	Retryable bool         `json:"retryable,omitempty"`
+	Details   []FieldError `json:"details,omitempty"`
+}
+
+func writeProblem(c *gin.Context, apiErr *Error) {
+	problem := Problem{
+		Type:      problemTypePrefix + strings.ToLower(apiErr.Code),
+		Title:     http.StatusText(apiErr.Status),
+		Status:    apiErr.Status,
+		Detail:    apiErr.message(),
+		Code:      apiErr.Code,
+		ErrorID:   apiErr.ErrorID,
+		Retryable: apiErr.Retryable(),
+		Details:   apiErr.Details,
+	}
+	if c.Request != nil {
+		problem.Instance = c.Request.URL.Path
+	}
+	// c.JSON keeps an explicitly set Content-Type.
+	c.Header("Content-Type", ProblemContentType)
+	c.JSON(apiErr.Status, problem)
+}
@@ -0,0 +1,85 @@
+package apierror
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupProblemRouter(format Format) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(UseFormat(format))
+	r.GET("/team/get", func(c *gin.Context) {
+		Write(c, InvalidField("team_name", "required", "team_name parameter is required"))
+	})
+	return r
+}
+
+func TestWrite_ProblemFormat(t *testing.T) {
+	r := setupProblemRouter(FormatProblem)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/team/get", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+
+	var problem Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, Problem{
+		Type:     "urn:avito-internship:error:invalid_request",
+		Title:    "Bad Request",
+		Status:   http.StatusBadRequest,
+		Detail:   "team_name parameter is required",
+		Instance: "/team/get",
+		Code:     CodeInvalidRequest,
+		Details:  []FieldError{{Field: "team_name", Rule: "required", Message: "team_name parameter is required"}},
+	}, problem)
+}
+
+func TestWrite_FormatNegotiation(t *testing.T) {
+	r := setupProblemRouter(FormatDefault)
+
+	t.Run("default format", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/team/get", nil))
+
+		assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+		var resp Response
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, CodeInvalidRequest, resp.Error.Code)
+	})
+
+	t.Run("problem requested by Accept header", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/team/get", nil)
+		req.Header.Set("Accept", "application/problem+json, application/json;q=0.9")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+		var problem Problem
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, http.StatusBadRequest, problem.Status)
+	})
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, s := range []string{"", "default"} {
+		format, err := ParseFormat(s)
+		require.NoError(t, err)
+		assert.Equal(t, FormatDefault, format)
+	}
+
+	format, err := ParseFormat("problem")
+	require.NoError(t, err)
+	assert.Equal(t, FormatProblem, format)
+
+	_, err = ParseFormat("xml")
+	assert.Error(t, err)
+}
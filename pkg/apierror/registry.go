@@ -0,0 +1,64 @@
+package apierror
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// unavailableError is implemented by errors of calls to a dependency that is known to be down and
+// should not be retried before the returned delay, e.g. breaker.OpenError.
+type unavailableError interface {
+	error
+	Unavailable() time.Duration
+}
+
+// Registry maps domain errors to API errors.
+//
+// Registries are immutable: Register returns a new registry, so an endpoint can extend a shared
+// registry with its own messages without affecting other endpoints.
+type Registry struct {
+	entries []entry
+}
+
+type entry struct {
+	match  func(error) bool
+	apiErr *Error
+}
+
+// Register returns a registry mapping errors matching target (errors.Is) to apiErr.
+// Later registrations take precedence. An empty apiErr message uses the message of the domain error.
+func (r Registry) Register(target error, apiErr *Error) Registry {
+	return r.RegisterFunc(func(err error) bool { return errors.Is(err, target) }, apiErr)
+}
+
+// RegisterFunc returns a registry mapping errors accepted by match to apiErr.
+func (r Registry) RegisterFunc(match func(error) bool, apiErr *Error) Registry {
+	entries := make([]entry, 0, len(r.entries)+1)
+	entries = append(entries, entry{match: match, apiErr: apiErr})
+	entries = append(entries, r.entries...)
+	return Registry{entries: entries}
+}
+
+// Lookup returns the API error registered for err. Errors caused by an expired request deadline
+// resolve to TIMEOUT and errors of unavailable dependencies to SERVICE_UNAVAILABLE; other
+// unregistered errors resolve to INTERNAL_ERROR and false, so the caller can log them.
+func (r Registry) Lookup(err error) (*Error, bool) {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	for _, e := range r.entries {
+		if e.match(err) {
+			return e.apiErr.wrap(err), true
+		}
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return Timeout().wrap(err), true
+	}
+	var unavailable unavailableError
+	if errors.As(err, &unavailable) {
+		return Unavailable(unavailable.Unavailable()).wrap(err), true
+	}
+	return Internal(err), false
+}
@@ -0,0 +1,103 @@
+package apierror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	errNotFound = errors.New("pull request not found")
+	errMerged   = errors.New("pull request is merged")
+	errInvalid  = errors.New("pull_request_id must be between 1 and 255 characters")
+)
+
+// unavailable is an error of a dependency that is down for the given delay.
+type unavailable time.Duration
+
+func (u unavailable) Error() string              { return "database is down" }
+func (u unavailable) Unavailable() time.Duration { return time.Duration(u) }
+
+func TestRegistry_Lookup(t *testing.T) {
+	registry := Registry{}.
+		Register(errNotFound, NotFound("pull request not found")).
+		Register(errMerged, Conflict(CodePRMerged, "cannot modify merged PR")).
+		Register(errInvalid, InvalidRequest(""))
+
+	t.Run("registered error", func(t *testing.T) {
+		apiErr, ok := registry.Lookup(fmt.Errorf("merge: %w", errMerged))
+
+		assert.True(t, ok)
+		assert.Equal(t, CodePRMerged, apiErr.Code)
+		assert.Equal(t, http.StatusConflict, apiErr.Status)
+		assert.ErrorIs(t, apiErr, errMerged)
+	})
+
+	t.Run("message of domain error", func(t *testing.T) {
+		apiErr, ok := registry.Lookup(errInvalid)
+
+		assert.True(t, ok)
+		assert.Equal(t, "INVALID_REQUEST: "+errInvalid.Error(), apiErr.Error())
+	})
+
+	t.Run("unregistered error", func(t *testing.T) {
+		apiErr, ok := registry.Lookup(errors.New("connection refused"))
+
+		assert.False(t, ok)
+		assert.Equal(t, CodeInternal, apiErr.Code)
+		assert.Equal(t, http.StatusInternalServerError, apiErr.Status)
+	})
+
+	t.Run("expired deadline", func(t *testing.T) {
+		apiErr, ok := registry.Lookup(fmt.Errorf("get pull request: %w", context.DeadlineExceeded))
+
+		assert.True(t, ok)
+		assert.Equal(t, CodeTimeout, apiErr.Code)
+		assert.Equal(t, http.StatusServiceUnavailable, apiErr.Status)
+		assert.True(t, apiErr.Retryable())
+	})
+
+	t.Run("unavailable dependency", func(t *testing.T) {
+		apiErr, ok := registry.Lookup(fmt.Errorf("get pull request: %w", unavailable(3*time.Second)))
+
+		assert.True(t, ok)
+		assert.Equal(t, CodeUnavailable, apiErr.Code)
+		assert.Equal(t, http.StatusServiceUnavailable, apiErr.Status)
+		assert.Equal(t, 3*time.Second, apiErr.RetryAfter)
+	})
+
+	t.Run("api error passes through", func(t *testing.T) {
+		apiErr, ok := registry.Lookup(Conflict(CodeNoCandidate, "no candidate"))
+
+		assert.True(t, ok)
+		assert.Equal(t, CodeNoCandidate, apiErr.Code)
+	})
+}
+
+func TestRegistry_Override(t *testing.T) {
+	base := Registry{}.Register(errMerged, Conflict(CodePRMerged, "cannot modify merged PR"))
+	reassign := base.Register(errMerged, Conflict(CodePRMerged, "cannot reassign on merged PR"))
+
+	apiErr, _ := reassign.Lookup(errMerged)
+	assert.Equal(t, "cannot reassign on merged PR", apiErr.Message)
+
+	// The base registry is not affected by the override.
+	apiErr, _ = base.Lookup(errMerged)
+	assert.Equal(t, "cannot modify merged PR", apiErr.Message)
+}
+
+func TestRegistry_RegisterFunc(t *testing.T) {
+	registry := Registry{}.RegisterFunc(func(err error) bool {
+		return strings.Contains(err.Error(), "required")
+	}, InvalidRequest(""))
+
+	apiErr, ok := registry.Lookup(errors.New("old_user_id is required"))
+	assert.True(t, ok)
+	assert.Equal(t, CodeInvalidRequest, apiErr.Code)
+}
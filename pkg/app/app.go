@@ -0,0 +1,523 @@
+// Package app assembles the service: HTTP routes with middleware and the background workers.
+//
+// The server binary (cmd/server) is a thin wrapper around App, and other Go programs can embed the
+// service the same way. The caller owns the database connection: App neither migrates nor closes it.
+// Handler can be served without Start, e.g. with httptest; background jobs then do not run.
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	commentRouter "github.com/festy23/avito_internship/internal/comment/router"
+	"github.com/festy23/avito_internship/internal/config"
+	"github.com/festy23/avito_internship/internal/database/circuit"
+	"github.com/festy23/avito_internship/internal/database/maintenance"
+	"github.com/festy23/avito_internship/internal/database/migrate"
+	"github.com/festy23/avito_internship/internal/export"
+	"github.com/festy23/avito_internship/internal/health"
+	"github.com/festy23/avito_internship/internal/jobs"
+	"github.com/festy23/avito_internship/internal/leader"
+	"github.com/festy23/avito_internship/internal/middleware"
+	"github.com/festy23/avito_internship/internal/notification"
+	"github.com/festy23/avito_internship/internal/otlp"
+	"github.com/festy23/avito_internship/internal/pullrequest/archive"
+	"github.com/festy23/avito_internship/internal/pullrequest/assignment"
+	"github.com/festy23/avito_internship/internal/pullrequest/cleanup"
+	"github.com/festy23/avito_internship/internal/pullrequest/rebalance"
+	"github.com/festy23/avito_internship/internal/pullrequest/reconcile"
+	pullrequestRepository "github.com/festy23/avito_internship/internal/pullrequest/repository"
+	pullrequestRouter "github.com/festy23/avito_internship/internal/pullrequest/router"
+	pullrequestService "github.com/festy23/avito_internship/internal/pullrequest/service"
+	"github.com/festy23/avito_internship/internal/pullrequest/sla"
+	"github.com/festy23/avito_internship/internal/pullrequest/stale"
+	"github.com/festy23/avito_internship/internal/sentry"
+	statisticsRouter "github.com/festy23/avito_internship/internal/statistics/router"
+	teamRouter "github.com/festy23/avito_internship/internal/team/router"
+	"github.com/festy23/avito_internship/internal/tenant"
+	userRouter "github.com/festy23/avito_internship/internal/user/router"
+	"github.com/festy23/avito_internship/internal/webhook"
+	"github.com/festy23/avito_internship/pkg/apierror"
+	"github.com/festy23/avito_internship/pkg/cursor"
+	"github.com/festy23/avito_internship/pkg/httpclient"
+)
+
+// deprecatedRoutes lists the deprecated routes, keyed by middleware.RouteKey with the registered path.
+// Their responses announce the deprecation and the sunset date, so clients have time to migrate before
+// a route changes or is removed. A route is listed here for at least one release before it is removed.
+var deprecatedRoutes = map[string]middleware.Deprecation{}
+
+// App is an assembled instance of the service.
+type App struct {
+	cfg    config.Config
+	db     *gorm.DB
+	logger *zap.SugaredLogger
+
+	router *gin.Engine
+	health *health.Handler
+	server *http.Server
+	addr   net.Addr
+
+	notifier          notification.Notifier
+	maintainer        *maintenance.Maintainer
+	scheduler         *jobs.Scheduler
+	elector           *leader.Elector
+	webhookDispatcher *webhook.Dispatcher
+	assignmentWorker  *assignment.Worker
+	pullrequestSvc    pullrequestService.Service
+	sentryClient      *sentry.Client
+	metricsExporter   *otlp.Exporter
+
+	stopJobs     context.CancelFunc
+	stopElection context.CancelFunc
+}
+
+// New assembles the service on an open database connection with an up-to-date schema.
+// The configuration is validated, so invalid settings are reported here rather than on Start.
+func New(cfg config.Config, db *gorm.DB, logger *zap.SugaredLogger) (*App, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	a := &App{cfg: cfg, db: db, logger: logger}
+	if err := a.setupRouter(); err != nil {
+		return nil, err
+	}
+	if err := a.registerServiceRoutes(); err != nil {
+		return nil, err
+	}
+	a.registerAPIRoutes()
+	if err := a.registerJobs(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Handler returns the HTTP handler serving all endpoints of the service.
+func (a *App) Handler() http.Handler {
+	return a.router
+}
+
+// setupRouter creates the router with the middleware shared by all endpoints.
+func (a *App) setupRouter() error {
+	cfg, log := a.cfg, a.logger
+	a.router = gin.New()
+	// Unknown routes and methods get error bodies like any other error; OPTIONS is answered for every route
+	a.router.HandleMethodNotAllowed = true
+	a.router.NoRoute(middleware.NoRoute)
+	a.router.NoMethod(middleware.NoMethod)
+
+	// Request metrics wrap everything to count the responses to panics. The collector is shared by all
+	// instances of the process, so the buckets of the first instance apply.
+	httpMetrics, err := registerCollector(middleware.NewHTTPMetrics(cfg.Metrics.Buckets()))
+	if err != nil {
+		return err
+	}
+	a.router.Use(middleware.Metrics(httpMetrics))
+
+	// Order matters: recovery first, then logger
+	if cfg.Sentry.Enabled() {
+		// The DSN is validated together with the rest of the configuration
+		a.sentryClient, _ = sentry.New(cfg.Sentry.DSN, cfg.Sentry.Environment, cfg.Sentry.Timeout, log)
+		a.router.Use(middleware.RecoveryWithReporter(log, a.sentryClient))
+		log.Infow("panic reporting to sentry enabled", "host", a.sentryClient.Host())
+	} else {
+		a.router.Use(middleware.Recovery(log))
+	}
+	a.router.Use(middleware.Logger(log))
+	// Validated together with the rest of the configuration
+	errorFormat, _ := apierror.ParseFormat(cfg.Server.ErrorFormat)
+	a.router.Use(apierror.UseFormat(errorFormat))
+	if cfg.Compression.Enabled {
+		a.router.Use(middleware.Compression(cfg.Compression.Options()))
+	}
+	// Validated together with the rest of the configuration; inside compression, which sees renamed bodies
+	naming, _ := middleware.ParseNaming(cfg.Server.JSONNaming)
+	a.router.Use(middleware.FieldNaming(naming))
+	if len(deprecatedRoutes) > 0 {
+		a.router.Use(middleware.Deprecations(deprecatedRoutes))
+	}
+	if cfg.FaultInjection.Enabled() {
+		// Rules are validated together with the rest of the configuration
+		rules, _ := middleware.ParseFaultRules(cfg.FaultInjection.Rules)
+		a.router.Use(middleware.FaultInjection(rules, log))
+		log.Warnw("fault injection enabled", "rules", cfg.FaultInjection.Rules)
+	}
+	if cfg.Server.HandlerTimeouts != "" {
+		// Rules are validated together with the rest of the configuration
+		rules, _ := middleware.ParseTimeoutRules(cfg.Server.HandlerTimeouts)
+		a.router.Use(middleware.Timeouts(rules))
+	}
+	return nil
+}
+
+// registerCollector registers a Prometheus collector with the default registry. If another instance
+// of the application in the process has registered an equal collector, that collector is returned.
+func registerCollector[T prometheus.Collector](collector T) (T, error) {
+	if err := prometheus.Register(collector); err != nil {
+		var registered prometheus.AlreadyRegisteredError
+		if !errors.As(err, &registered) {
+			return collector, fmt.Errorf("register metrics: %w", err)
+		}
+		if existing, ok := registered.ExistingCollector.(T); ok {
+			return existing, nil
+		}
+		return collector, fmt.Errorf("register metrics: %w", err)
+	}
+	return collector, nil
+}
+
+// registerServiceRoutes registers the health, metrics and operations endpoints.
+func (a *App) registerServiceRoutes() error {
+	cfg, db, log, r := a.cfg, a.db, a.logger, a.router
+
+	a.health = health.New(db, log)
+	if latestVersion, err := migrate.LatestVersion(); err != nil {
+		log.Warnw("schema version is not reported by health check", "error", err)
+	} else {
+		a.health = health.NewWithSchema(db, latestVersion, log)
+	}
+	r.GET("/ping", a.health.Ping)
+	r.GET("/health", a.health.Check)
+	r.GET("/health/ready", a.health.Check)
+
+	// Prometheus metrics: requests and error responses by route, database circuit breakers, outbound
+	// requests of integrations, plus Go runtime and process metrics.
+	// The collector is shared by all instances of the process.
+	for _, collector := range []prometheus.Collector{
+		apierror.ErrorsTotal, circuit.State, circuit.TransitionsTotal,
+		httpclient.RequestsTotal, httpclient.RequestDuration,
+	} {
+		if _, err := registerCollector(collector); err != nil {
+			return err
+		}
+	}
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	// Background job scheduler; jobs are registered before start
+	a.scheduler = jobs.New(log)
+
+	// Database maintenance: table statistics and optional ANALYZE runs
+	a.maintainer = maintenance.New(db, log)
+
+	// Notifications are written to the log and, if configured, delivered as webhooks
+	a.notifier = notification.NewLogNotifier(log)
+	if cfg.Webhook.Enabled() {
+		a.webhookDispatcher = webhook.New(cfg.Webhook, webhook.NewRepository(db, log), log)
+		a.notifier = notification.NewMulti(a.notifier, a.webhookDispatcher)
+	}
+
+	// Administrative endpoints exist only if the admin token is configured
+	if cfg.Admin.Enabled() {
+		admin := r.Group("", middleware.AdminToken(cfg.Admin.Token))
+		admin.GET("/jobs", jobs.NewHandler(a.scheduler).GetStats)
+		admin.GET("/maintenance/tables", maintenance.NewHandler(a.maintainer).GetTables)
+		if a.webhookDispatcher != nil {
+			// Dead letters contain payloads with user IDs and can be resent
+			webhookHandler := webhook.NewHandler(a.webhookDispatcher, cfg.Pagination.Limits())
+			admin.GET("/webhooks/deadLetters", webhookHandler.ListDeadLetters)
+			admin.POST("/webhooks/deadLetters/replay", webhookHandler.ReplayDeadLetter)
+		}
+		exportHandler := export.NewHandler(export.New(db, log), export.NewImporter(db, log), cfg.Admin.ImportMaxSize())
+		admin.GET("/admin/export", exportHandler.Export)
+		admin.POST("/admin/import", exportHandler.Import)
+		reconciler := reconcile.New(pullrequestRepository.New(db, log), db, cfg.Reconcile, log)
+		admin.POST("/admin/consistencyCheck", reconcile.NewHandler(reconciler).Check)
+		rebalancer := rebalance.New(pullrequestRepository.New(db, log), db, cfg.Rebalance, a.notifier, log)
+		admin.POST("/admin/rebalance", rebalance.NewHandler(rebalancer).Rebalance)
+		userRouter.RegisterAdminRoutes(admin, db, log)
+	}
+	return nil
+}
+
+// registerAPIRoutes registers the endpoints of the public API.
+func (a *App) registerAPIRoutes() {
+	cfg, db, log := a.cfg, a.db, a.logger
+
+	// API routes are isolated by tenant if multi-tenancy is enabled; service endpoints are not
+	api := a.router.Group("")
+	if cfg.Tenancy.Enabled() {
+		api.Use(tenantMiddleware(cfg.Tenancy))
+		log.Infow("multi-tenancy enabled", "mode", cfg.Tenancy.Mode)
+	}
+
+	if cfg.Pagination.CursorSecret == "" {
+		log.Warnw("PAGINATION_CURSOR_SECRET is not set, pagination cursors are valid only on this replica",
+			"gin_mode", cfg.GinMode)
+	}
+	cursors := cursor.New(cfg.Pagination.CursorSecret)
+
+	teamRouter.RegisterRoutes(api, db, log)
+
+	// Asynchronous reviewer assignment workers are started together with background jobs
+	var assignmentQueue pullrequestService.AssignmentQueue
+	if cfg.PullRequest.AsyncAssignment {
+		a.assignmentWorker = assignment.New(
+			cfg.PullRequest.AssignmentWorkers, cfg.PullRequest.AssignmentQueueSize, log,
+		)
+		assignmentQueue = a.assignmentWorker
+	}
+	a.pullrequestSvc = pullrequestRouter.RegisterRoutes(
+		api, db, cfg.PullRequest, assignmentQueue, a.notifier, cursors, cfg.Pagination.Limits(), log,
+	)
+	// Deactivated users are replaced in their reviews by the pull request service
+	userRouter.RegisterRoutes(api, db, cfg.User, a.pullrequestSvc, cursors, cfg.Pagination.Limits(), log)
+	commentRouter.RegisterRoutes(api, db, cursors, cfg.Pagination.Limits(), log)
+	statisticsRouter.RegisterRoutes(api, db, log)
+}
+
+// tenantMiddleware returns the middleware resolving the tenant of requests in the configured mode.
+func tenantMiddleware(cfg config.TenancyConfig) gin.HandlerFunc {
+	if cfg.Mode == tenant.ModeAPIKey {
+		// API keys are validated together with the rest of the configuration
+		keys, _ := tenant.ParseAPIKeys(cfg.APIKeys)
+		return tenant.APIKeyMiddleware(keys)
+	}
+	return tenant.HeaderMiddleware()
+}
+
+// registerJobs registers the configured background jobs in the scheduler.
+func (a *App) registerJobs() error {
+	cfg, db, log := a.cfg, a.db, a.logger
+
+	if cfg.Archive.Enabled() {
+		var afterArchive func(ctx context.Context) error
+		if cfg.Maintenance.AnalyzeAfterArchive {
+			afterArchive = a.maintainer.Analyze
+		}
+		archiveJob := archive.NewWithAfterArchive(pullrequestRepository.New(db, log), cfg.Archive, afterArchive, log)
+		if err := a.registerJob("archive", cfg.Archive.Job, archiveJob.Run); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Cleanup.Enabled() {
+		cleanupJob := cleanup.New(pullrequestRepository.New(db, log), cfg.Cleanup, log)
+		if err := a.registerJob("cleanup", cfg.Cleanup.Job, cleanupJob.Run); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Rebalance.Enabled() {
+		rebalanceJob := rebalance.New(pullrequestRepository.New(db, log), db, cfg.Rebalance, a.notifier, log)
+		if err := a.registerJob("rebalance", cfg.Rebalance.Job, rebalanceJob.Run); err != nil {
+			return err
+		}
+	}
+
+	if cfg.SLA.Enabled() {
+		slaJob := sla.New(pullrequestRepository.New(db, log), a.notifier, log)
+		if err := a.registerJob("sla", cfg.SLA.Job, slaJob.Run); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Stale.Enabled() {
+		// Stale pull requests are closed through the pull request service like manually closed ones
+		staleJob := stale.New(pullrequestRepository.New(db, log), a.pullrequestSvc, a.notifier, cfg.Stale, log)
+		if err := a.registerJob("stale", cfg.Stale.Job, staleJob.Run); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Maintenance.Enabled() {
+		return a.registerJob("maintenance", cfg.Maintenance.Job, a.maintainer.Run)
+	}
+	return nil
+}
+
+// registerJob registers a configured background job in the scheduler.
+func (a *App) registerJob(name string, cfg config.JobConfig, run func(ctx context.Context) error) error {
+	schedule, err := jobs.ParseSchedule(cfg.Schedule)
+	if err != nil {
+		return fmt.Errorf("invalid schedule of job %s: %w", name, err)
+	}
+
+	job := jobs.Job{Name: name, Schedule: schedule, Jitter: cfg.Jitter, Run: run}
+	if err := a.scheduler.Register(job); err != nil {
+		return fmt.Errorf("register job %s: %w", name, err)
+	}
+	return nil
+}
+
+// Start reconciles reviewer assignments, starts listening on the configured TCP address and Unix socket
+// and launches the background workers. Background work runs until Shutdown; ctx bounds the startup only.
+func (a *App) Start(ctx context.Context) error {
+	cfg, log := a.cfg, a.logger
+
+	// Check reviewer assignments for inconsistencies before serving requests
+	if cfg.Reconcile.Enabled() {
+		reconciler := reconcile.New(pullrequestRepository.New(a.db, log), a.db, cfg.Reconcile, log)
+		if _, err := reconciler.Run(ctx); err != nil {
+			log.Errorw("data reconciliation failed", "error", err)
+		}
+	}
+
+	listener, err := net.Listen("tcp", cfg.Server.GetAddress())
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", cfg.Server.GetAddress(), err)
+	}
+	var socket net.Listener
+	if path := cfg.Server.SocketPath(); path != "" {
+		if socket, err = listenUnix(path); err != nil {
+			_ = listener.Close()
+			return err
+		}
+	}
+
+	// One server serves all listeners, so Shutdown closes them together
+	a.addr = listener.Addr()
+	a.server = &http.Server{
+		Handler:      a.router,
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+		IdleTimeout:  cfg.Server.IdleTimeout,
+	}
+	go a.serve(listener)
+	if socket != nil {
+		go a.serve(socket)
+	}
+
+	a.startBackground(ctx)
+	return nil
+}
+
+// listenUnix listens on a Unix domain socket. A socket file left by a previous run that did not
+// shut down cleanly is removed; the listener removes its file itself when closed.
+func listenUnix(path string) (net.Listener, error) {
+	if info, err := os.Lstat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("remove stale socket %s: %w", path, err)
+		}
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("listen on %s: %w", path, err)
+	}
+	return listener, nil
+}
+
+// serve serves HTTP requests on the listener until the server is shut down.
+func (a *App) serve(listener net.Listener) {
+	a.logger.Infow("starting server", "network", listener.Addr().Network(), "address", listener.Addr().String())
+	if err := a.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		a.logger.Errorw("server stopped", "address", listener.Addr().String(), "error", err)
+	}
+}
+
+// startBackground launches scheduled jobs, webhook delivery and asynchronous assignment.
+func (a *App) startBackground(ctx context.Context) {
+	var jobsCtx, electionCtx context.Context
+	jobsCtx, a.stopJobs = context.WithCancel(context.Background())
+	electionCtx, a.stopElection = context.WithCancel(context.Background())
+
+	// With several replicas scheduled jobs run only on the one holding the leader lease. The lease
+	// outlives jobsCtx, so it is released only after in-flight runs have finished.
+	if a.cfg.LeaderElection.Enabled {
+		a.elector = leader.New(a.db, leader.JobsLease, a.cfg.LeaderElection, a.logger)
+		a.elector.Start(electionCtx)
+		a.scheduler.RunOnlyWhen(a.elector.IsLeader)
+		a.logger.Infow("leader election enabled", "id", a.elector.ID(), "leader", a.elector.IsLeader())
+	}
+
+	a.scheduler.Start(jobsCtx)
+
+	// Metrics are pushed by every replica, not only by the leader
+	if a.cfg.OTLP.Enabled() {
+		// Headers are validated together with the rest of the configuration
+		headers, _ := otlp.ParseHeaders(a.cfg.OTLP.Headers)
+		a.metricsExporter = otlp.New(a.cfg.OTLP.MetricsEndpoint, headers, a.cfg.OTLP.Interval,
+			a.cfg.OTLP.Timeout, a.cfg.OTLP.ServiceName, prometheus.DefaultGatherer, a.logger)
+		a.metricsExporter.Start(jobsCtx)
+	}
+
+	if a.webhookDispatcher != nil {
+		a.webhookDispatcher.Start(jobsCtx)
+	}
+
+	if a.assignmentWorker != nil {
+		a.assignmentWorker.Start(jobsCtx, a.pullrequestSvc.AssignReviewers)
+		resumed, err := a.pullrequestSvc.ResumeAssignments(ctx)
+		if err != nil {
+			a.logger.Errorw("failed to resume pending reviewer assignments", "error", err)
+		} else if resumed > 0 {
+			a.logger.Infow("resumed pending reviewer assignments", "count", resumed)
+		}
+	}
+}
+
+// Addr returns the TCP address the server listens on, or nil before Start.
+func (a *App) Addr() net.Addr {
+	return a.addr
+}
+
+// Shutdown gracefully stops the service: it fails the health check, waits the configured shutdown
+// delay, stops background work and then the HTTP server. Closing the server is bounded by the
+// configured shutdown timeout and by ctx. The database connection is left open.
+func (a *App) Shutdown(ctx context.Context) error {
+	log := a.logger
+
+	// Keep serving while load balancers notice the failing health check and stop routing requests
+	a.health.Drain()
+	if delay := a.cfg.Server.ShutdownDelay; delay > 0 && a.server != nil {
+		log.Infow("waiting before closing listener", "delay", delay)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+		}
+	}
+
+	a.stopBackground()
+
+	var err error
+	if a.server != nil {
+		shutdownCtx, cancel := context.WithTimeout(ctx, a.cfg.Server.ShutdownTimeout)
+		defer cancel()
+		if err = a.server.Shutdown(shutdownCtx); err != nil {
+			log.Errorw("server forced to shutdown", "error", err)
+		} else {
+			log.Infow("HTTP server stopped")
+		}
+	}
+
+	// Undelivered webhooks are moved to dead letters once no more requests are served
+	if a.webhookDispatcher != nil && a.stopJobs != nil {
+		a.webhookDispatcher.Wait()
+	}
+	if a.sentryClient != nil {
+		a.sentryClient.Wait()
+	}
+	return err
+}
+
+// stopBackground stops background jobs and waits for in-flight runs. The leader lease is released
+// last, so no other replica starts a job while a run is still in progress here.
+func (a *App) stopBackground() {
+	if a.stopJobs == nil {
+		return
+	}
+	a.stopJobs()
+	a.scheduler.Wait()
+	if a.assignmentWorker != nil {
+		a.assignmentWorker.Wait()
+	}
+	if a.metricsExporter != nil {
+		a.metricsExporter.Wait()
+	}
+	if a.elector != nil {
+		a.stopElection()
+		a.elector.Wait()
+	}
+	a.logger.Infow("background jobs stopped")
+}
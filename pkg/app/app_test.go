@@ -0,0 +1,293 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/festy23/avito_internship/internal/config"
+)
+
+type testTeam struct {
+	TeamName            string    `gorm:"primaryKey;column:team_name"`
+	LeadUserID          *string   `gorm:"column:lead_user_id"`
+	SLAFirstReviewHours *int      `gorm:"column:sla_first_review_hours"`
+	SLAMergeHours       *int      `gorm:"column:sla_merge_hours"`
+	AutoCloseDisabled   bool      `gorm:"column:auto_close_disabled;not null;default:false"`
+	CreatedAt           time.Time `gorm:"column:created_at"`
+	UpdatedAt           time.Time `gorm:"column:updated_at"`
+	TenantID            string    `gorm:"column:tenant_id;not null;default:'default'"`
+}
+
+func (testTeam) TableName() string {
+	return "teams"
+}
+
+type testUser struct {
+	UserID    string    `gorm:"primaryKey;column:user_id"`
+	Username  string    `gorm:"column:username;not null"`
+	TeamName  string    `gorm:"column:team_name;not null"`
+	IsActive  bool      `gorm:"column:is_active;not null"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+	TenantID  string    `gorm:"column:tenant_id;not null;default:'default'"`
+}
+
+func (testUser) TableName() string {
+	return "users"
+}
+
+func setupDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	var sqlDB *sql.DB
+	sqlDB, err = db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+
+	require.NoError(t, db.AutoMigrate(&testTeam{}, &testUser{}))
+	return db
+}
+
+func testConfig() config.Config {
+	gin.SetMode(gin.TestMode)
+	cfg := config.LoadFromEnv()
+	cfg.GinMode = gin.TestMode
+	cfg.Server.Host = "127.0.0.1"
+	cfg.Server.Port = "0"
+	cfg.Server.ShutdownDelay = 0
+	return cfg
+}
+
+func TestApp_Handler(t *testing.T) {
+	a, err := New(testConfig(), setupDB(t), zap.NewNop().Sugar())
+	require.NoError(t, err)
+
+	body := `{"team_name":"backend","members":[{"user_id":"u1","username":"Alice","is_active":true}]}`
+	req := httptest.NewRequest(http.MethodPost, "/team/add", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	a.Handler().ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	w = httptest.NewRecorder()
+	a.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/team/get?team_name=backend", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"user_id":"u1"`)
+
+	require.NoError(t, a.Shutdown(context.Background()))
+}
+
+func TestApp_UnroutedRequests(t *testing.T) {
+	a, err := New(testConfig(), setupDB(t), zap.NewNop().Sugar())
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	a.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/team/get", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Equal(t, "GET, OPTIONS", w.Header().Get("Allow"))
+	assert.Contains(t, w.Body.String(), `"code":"METHOD_NOT_ALLOWED"`)
+
+	w = httptest.NewRecorder()
+	a.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodOptions, "/pullRequest/create", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "POST, OPTIONS", w.Header().Get("Allow"))
+
+	w = httptest.NewRecorder()
+	a.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/unknown", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"NOT_FOUND"`)
+
+	require.NoError(t, a.Shutdown(context.Background()))
+}
+
+func TestApp_Metrics(t *testing.T) {
+	// Applications in one process share the collectors
+	for range 2 {
+		a, err := New(testConfig(), setupDB(t), zap.NewNop().Sugar())
+		require.NoError(t, err)
+		a.Handler().ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/team/get?team_name=x", nil))
+
+		w := httptest.NewRecorder()
+		a.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `http_requests_total{method="GET",route="/team/get",status="404"}`)
+		assert.Contains(t, w.Body.String(), `http_request_duration_seconds_bucket{method="GET",route="/team/get"`)
+		require.NoError(t, a.Shutdown(context.Background()))
+	}
+}
+
+func TestApp_StartShutdown(t *testing.T) {
+	a, err := New(testConfig(), setupDB(t), zap.NewNop().Sugar())
+	require.NoError(t, err)
+	assert.Nil(t, a.Addr(), "no address before Start")
+
+	require.NoError(t, a.Start(context.Background()))
+	require.NotNil(t, a.Addr())
+
+	resp, err := http.Get("http://" + a.Addr().String() + "/ping")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	require.NoError(t, a.Shutdown(context.Background()))
+	resp, err = http.Get("http://" + a.Addr().String() + "/health")
+	if err == nil {
+		_ = resp.Body.Close()
+	}
+	assert.Error(t, err, "listener is closed after Shutdown")
+}
+
+func TestApp_UnixSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "app.sock")
+	cfg := testConfig()
+	cfg.Server.Listen = "unix://" + socket
+
+	a, err := New(cfg, setupDB(t), zap.NewNop().Sugar())
+	require.NoError(t, err)
+	require.NoError(t, a.Start(context.Background()))
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+		},
+	}}
+	resp, err := client.Get("http://app/ping")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// The TCP listener is served alongside the socket
+	resp, err = http.Get("http://" + a.Addr().String() + "/ping")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	require.NoError(t, a.Shutdown(context.Background()))
+	assert.NoFileExists(t, socket, "socket file is removed on shutdown")
+}
+
+func TestApp_UnixSocketStale(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "app.sock")
+	stale, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	// Keep the file, as a crashed process would
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+	require.FileExists(t, socket)
+
+	cfg := testConfig()
+	cfg.Server.Listen = "unix://" + socket
+	a, err := New(cfg, setupDB(t), zap.NewNop().Sugar())
+	require.NoError(t, err)
+	require.NoError(t, a.Start(context.Background()))
+	require.NoError(t, a.Shutdown(context.Background()))
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	cfg := testConfig()
+	cfg.Server.ReadTimeout = 0
+
+	_, err := New(cfg, setupDB(t), zap.NewNop().Sugar())
+	assert.ErrorContains(t, err, "invalid configuration")
+}
+
+func TestNew_StaleJob(t *testing.T) {
+	cfg := testConfig()
+	cfg.Stale = config.StaleConfig{CloseAfterDays: 30, Job: config.JobConfig{Enabled: true, Schedule: "@every 1h"}}
+
+	a, err := New(cfg, setupDB(t), zap.NewNop().Sugar())
+	require.NoError(t, err)
+
+	names := []string{}
+	for _, stats := range a.scheduler.Stats() {
+		names = append(names, stats.Name)
+	}
+	assert.Contains(t, names, "stale")
+	require.NoError(t, a.Shutdown(context.Background()))
+}
+
+func TestApp_AdminExport(t *testing.T) {
+	const token = "0123456789abcdef0123456789abcdef"
+
+	a, err := New(testConfig(), setupDB(t), zap.NewNop().Sugar())
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	a.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/export", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code, "admin endpoints are not registered without a token")
+	w = httptest.NewRecorder()
+	a.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/jobs", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code, "operations endpoints are not registered without a token")
+	require.NoError(t, a.Shutdown(context.Background()))
+
+	cfg := testConfig()
+	cfg.Admin.Token = token
+	a, err = New(cfg, setupDB(t), zap.NewNop().Sugar())
+	require.NoError(t, err)
+
+	w = httptest.NewRecorder()
+	a.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/export", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, `Bearer realm="admin"`, w.Header().Get("WWW-Authenticate"))
+	w = httptest.NewRecorder()
+	a.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/import", bytes.NewBufferString("{}")))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	w = httptest.NewRecorder()
+	a.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/anonymizeUser", bytes.NewBufferString("{}")))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	w = httptest.NewRecorder()
+	a.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/consistencyCheck", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	for _, path := range []string{"/jobs", "/maintenance/tables"} {
+		w = httptest.NewRecorder()
+		a.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusUnauthorized, w.Code, path)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/export?format=ndjson", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w = httptest.NewRecorder()
+	a.Handler().ServeHTTP(w, req)
+	// The test database has no pull request tables, so the export fails before anything is sent
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	require.NoError(t, a.Shutdown(context.Background()))
+}
+
+func TestApp_WebhookDeadLettersRequireAdmin(t *testing.T) {
+	const token = "0123456789abcdef0123456789abcdef"
+
+	cfg := testConfig()
+	cfg.Webhook.URL = "http://127.0.0.1:1/hook"
+	a, err := New(cfg, setupDB(t), zap.NewNop().Sugar())
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	a.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/webhooks/deadLetters", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code, "dead letters are not exposed without an admin token")
+	require.NoError(t, a.Shutdown(context.Background()))
+
+	cfg.Admin.Token = token
+	a, err = New(cfg, setupDB(t), zap.NewNop().Sugar())
+	require.NoError(t, err)
+	w = httptest.NewRecorder()
+	a.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/webhooks/deadLetters", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	w = httptest.NewRecorder()
+	a.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/webhooks/deadLetters/replay",
+		bytes.NewBufferString(`{"id":1}`)))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	require.NoError(t, a.Shutdown(context.Background()))
+}
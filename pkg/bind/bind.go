@@ -0,0 +1,31 @@
+// Package bind binds and validates request DTOs.
+//
+// Binding tags of the DTOs (required, max=255, min=0, ...) are the single source of truth for input
+// validation: handlers bind requests with JSON, code building requests by hand validates them with
+// Struct. Both report failures as apierror.Binding errors with field details.
+package bind
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/festy23/avito_internship/pkg/apierror"
+)
+
+// JSON binds the JSON request body into obj and validates its binding tags. On failure it writes
+// an INVALID_REQUEST response with field details and returns false.
+func JSON(c *gin.Context, obj any) bool {
+	if err := c.ShouldBindJSON(obj); err != nil {
+		apierror.Fail(c, apierror.Binding(err))
+		return false
+	}
+	return true
+}
+
+// Struct validates binding tags of obj.
+func Struct(obj any) error {
+	if err := binding.Validator.ValidateStruct(obj); err != nil {
+		return apierror.Binding(err)
+	}
+	return nil
+}
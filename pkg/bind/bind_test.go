@@ -0,0 +1,82 @@
+package bind
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/festy23/avito_internship/pkg/apierror"
+)
+
+type request struct {
+	ID    string `json:"id"    binding:"required,max=3"`
+	Count int    `json:"count" binding:"min=0"`
+}
+
+func bindJSON(t *testing.T, body string) (*httptest.ResponseRecorder, request, bool) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	var req request
+	ok := JSON(c, &req)
+	return w, req, ok
+}
+
+func TestJSON(t *testing.T) {
+	t.Run("valid body", func(t *testing.T) {
+		w, req, ok := bindJSON(t, `{"id":"a1","count":2}`)
+
+		require.True(t, ok)
+		assert.Equal(t, request{ID: "a1", Count: 2}, req)
+		assert.Zero(t, w.Body.Len())
+	})
+
+	t.Run("tag violations", func(t *testing.T) {
+		w, _, ok := bindJSON(t, `{"id":"abcd","count":-1}`)
+
+		require.False(t, ok)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		var resp apierror.Response
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, apierror.CodeInvalidRequest, resp.Error.Code)
+		assert.Equal(t, []apierror.FieldError{
+			{Field: "id", Rule: "max", Message: "id must be at most 3 characters"},
+			{Field: "count", Rule: "min", Message: "count must be at least 0"},
+		}, resp.Error.Details)
+	})
+
+	t.Run("malformed JSON", func(t *testing.T) {
+		w, _, ok := bindJSON(t, `{"id":`)
+
+		require.False(t, ok)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestStruct(t *testing.T) {
+	t.Run("valid struct", func(t *testing.T) {
+		assert.NoError(t, Struct(&request{ID: "a1"}))
+	})
+
+	t.Run("missing required field", func(t *testing.T) {
+		err := Struct(&request{})
+
+		var apiErr *apierror.Error
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusBadRequest, apiErr.Status)
+		assert.Equal(t, []apierror.FieldError{
+			{Field: "id", Rule: "required", Message: "id is required"},
+		}, apiErr.Details)
+	})
+}
@@ -0,0 +1,163 @@
+// Package breaker provides a circuit breaker that fails calls fast while a dependency is down.
+package breaker
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/festy23/avito_internship/pkg/clock"
+)
+
+// ErrOpen is matched (errors.Is) by the errors of calls rejected by an open breaker.
+var ErrOpen = errors.New("circuit breaker is open")
+
+// State is the state of a breaker.
+type State int
+
+const (
+	// Closed lets all calls through and counts consecutive failures.
+	Closed State = iota
+	// HalfOpen lets a single probe call through after the open timeout.
+	HalfOpen
+	// Open rejects all calls until the open timeout expires.
+	Open
+)
+
+// String returns the lowercase name of the state, used in logs and metric labels.
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case HalfOpen:
+		return "half_open"
+	case Open:
+		return "open"
+	default:
+		return fmt.Sprintf("state(%d)", int(s))
+	}
+}
+
+// Config controls when a breaker trips and recovers.
+type Config struct {
+	// FailureThreshold is the number of consecutive failures that opens the breaker.
+	FailureThreshold int
+	// OpenTimeout is how long the breaker stays open before a probe call is let through.
+	OpenTimeout time.Duration
+}
+
+// OpenError is returned for calls rejected by an open breaker.
+type OpenError struct {
+	// Name is the name of the breaker.
+	Name string
+	// RetryAfter is the time left until the breaker lets a probe call through.
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface.
+func (e *OpenError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Name, ErrOpen)
+}
+
+// Is reports whether target is ErrOpen.
+func (e *OpenError) Is(target error) bool {
+	return target == ErrOpen
+}
+
+// Unavailable returns the delay after which the call may be repeated.
+func (e *OpenError) Unavailable() time.Duration {
+	return e.RetryAfter
+}
+
+// Breaker is a circuit breaker. It opens after FailureThreshold consecutive failures, rejects calls
+// for OpenTimeout and then lets a single probe call through: a successful probe closes it, a failed
+// one opens it again. It is safe for concurrent use.
+type Breaker struct {
+	name         string
+	cfg          Config
+	clock        clock.Clock
+	onTransition func(name string, from, to State)
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	probing  bool
+	probedAt time.Time
+}
+
+// New creates a closed breaker. onTransition, if not nil, is called on every state change with the
+// breaker locked, so it must not call the breaker.
+func New(name string, cfg Config, clk clock.Clock, onTransition func(name string, from, to State)) *Breaker {
+	return &Breaker{name: name, cfg: cfg, clock: clk, onTransition: onTransition}
+}
+
+// Name returns the name of the breaker.
+func (b *Breaker) Name() string {
+	return b.name
+}
+
+// State returns the current state of the breaker.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// Allow reports whether a call may proceed. It returns an *OpenError if the breaker is open or a
+// probe call is already in flight. Every allowed call must be followed by Record.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.clock.Now()
+	switch b.state {
+	case Open:
+		if wait := b.openedAt.Add(b.cfg.OpenTimeout).Sub(now); wait > 0 {
+			return &OpenError{Name: b.name, RetryAfter: wait}
+		}
+		b.transition(HalfOpen)
+		fallthrough
+	case HalfOpen:
+		// A probe that never reported back (e.g. a panicking caller) does not block the breaker forever
+		if b.probing && now.Sub(b.probedAt) < b.cfg.OpenTimeout {
+			return &OpenError{Name: b.name, RetryAfter: b.probedAt.Add(b.cfg.OpenTimeout).Sub(now)}
+		}
+		b.probing, b.probedAt = true, now
+	}
+	return nil
+}
+
+// Record reports the outcome of an allowed call.
+func (b *Breaker) Record(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !failed {
+		b.failures = 0
+		if b.state == HalfOpen {
+			b.probing = false
+			b.transition(Closed)
+		}
+		return
+	}
+
+	b.failures++
+	if b.state == HalfOpen || (b.state == Closed && b.failures >= b.cfg.FailureThreshold) {
+		b.probing = false
+		b.openedAt = b.clock.Now()
+		b.transition(Open)
+	}
+}
+
+func (b *Breaker) transition(to State) {
+	from := b.state
+	b.state = to
+	if to == Closed {
+		b.failures = 0
+	}
+	if b.onTransition != nil {
+		b.onTransition(b.name, from, to)
+	}
+}
@@ -0,0 +1,98 @@
+package breaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/festy23/avito_internship/pkg/clock"
+)
+
+type transition struct {
+	from, to State
+}
+
+func newTestBreaker() (*Breaker, *clock.Fake, *[]transition) {
+	clk := clock.NewFake(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	var transitions []transition
+	b := New("db", Config{FailureThreshold: 3, OpenTimeout: 10 * time.Second}, clk, func(name string, from, to State) {
+		transitions = append(transitions, transition{from, to})
+	})
+	return b, clk, &transitions
+}
+
+func fail(t *testing.T, b *Breaker, n int) {
+	t.Helper()
+	for range n {
+		require.NoError(t, b.Allow())
+		b.Record(true)
+	}
+}
+
+func TestBreaker_Opens(t *testing.T) {
+	b, clk, transitions := newTestBreaker()
+
+	fail(t, b, 2)
+	require.NoError(t, b.Allow())
+	b.Record(false)
+	fail(t, b, 2)
+	assert.Equal(t, Closed, b.State(), "a success resets the failure count")
+
+	fail(t, b, 1)
+	assert.Equal(t, Open, b.State())
+	assert.Equal(t, []transition{{Closed, Open}}, *transitions)
+
+	clk.Advance(4 * time.Second)
+	err := b.Allow()
+	require.ErrorIs(t, err, ErrOpen)
+	var openErr *OpenError
+	require.True(t, errors.As(err, &openErr))
+	assert.Equal(t, 6*time.Second, openErr.Unavailable())
+	assert.Equal(t, "db: circuit breaker is open", err.Error())
+}
+
+func TestBreaker_HalfOpen(t *testing.T) {
+	t.Run("successful probe closes", func(t *testing.T) {
+		b, clk, transitions := newTestBreaker()
+		fail(t, b, 3)
+		clk.Advance(10 * time.Second)
+
+		require.NoError(t, b.Allow())
+		assert.Equal(t, HalfOpen, b.State())
+		assert.ErrorIs(t, b.Allow(), ErrOpen, "only one probe at a time")
+
+		b.Record(false)
+		assert.Equal(t, Closed, b.State())
+		assert.NoError(t, b.Allow())
+		assert.Equal(t, []transition{{Closed, Open}, {Open, HalfOpen}, {HalfOpen, Closed}}, *transitions)
+	})
+
+	t.Run("failed probe opens again", func(t *testing.T) {
+		b, clk, _ := newTestBreaker()
+		fail(t, b, 3)
+		clk.Advance(10 * time.Second)
+
+		fail(t, b, 1)
+		assert.Equal(t, Open, b.State())
+		assert.ErrorIs(t, b.Allow(), ErrOpen)
+	})
+
+	t.Run("lost probe is replaced", func(t *testing.T) {
+		b, clk, _ := newTestBreaker()
+		fail(t, b, 3)
+		clk.Advance(10 * time.Second)
+		require.NoError(t, b.Allow())
+
+		clk.Advance(10 * time.Second)
+		assert.NoError(t, b.Allow())
+	})
+}
+
+func TestState_String(t *testing.T) {
+	assert.Equal(t, "closed", Closed.String())
+	assert.Equal(t, "half_open", HalfOpen.String())
+	assert.Equal(t, "open", Open.String())
+}
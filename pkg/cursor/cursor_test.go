@@ -0,0 +1,69 @@
+package cursor
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type position struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"id"`
+}
+
+func TestCodec_RoundTrip(t *testing.T) {
+	codec := New("secret")
+	want := position{CreatedAt: time.Date(2025, 6, 1, 12, 0, 0, 123456000, time.UTC), ID: "pr-1"}
+
+	token, err := codec.Encode("getReview|u1", want)
+	require.NoError(t, err)
+	assert.NotContains(t, token, "pr-1", "token is opaque")
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "pr-1", "position is encrypted, not just encoded")
+
+	var got position
+	require.NoError(t, codec.Decode("getReview|u1", token, &got))
+	assert.True(t, want.CreatedAt.Equal(got.CreatedAt))
+	assert.Equal(t, want.ID, got.ID)
+}
+
+func TestCodec_Rejects(t *testing.T) {
+	codec := New("secret")
+	token, err := codec.Encode("getReview|u1", position{ID: "pr-1"})
+	require.NoError(t, err)
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	require.NoError(t, err)
+	raw[len(raw)-1] ^= 1
+
+	tests := []struct {
+		name  string
+		scope string
+		token string
+	}{
+		{"other scope", "getReview|u2", token},
+		{"altered token", "getReview|u1", base64.RawURLEncoding.EncodeToString(raw)},
+		{"truncated", "getReview|u1", token[:10]},
+		{"malformed", "getReview|u1", "not-a-cursor"},
+		{"empty", "getReview|u1", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got position
+			assert.ErrorIs(t, codec.Decode(tt.scope, tt.token, &got), ErrInvalid)
+		})
+	}
+
+	t.Run("other secret", func(t *testing.T) {
+		var got position
+		assert.ErrorIs(t, New("other").Decode("getReview|u1", token, &got), ErrInvalid)
+	})
+
+	t.Run("random secret", func(t *testing.T) {
+		var got position
+		assert.ErrorIs(t, New("").Decode("getReview|u1", token, &got), ErrInvalid)
+	})
+}
@@ -0,0 +1,192 @@
+// Package httpclient provides the HTTP client of outbound integrations: pooled connections, bounded
+// timeouts, optional retries with backoff and request metrics labeled by integration.
+package httpclient
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/festy23/avito_internship/pkg/retry"
+)
+
+var (
+	// RequestsTotal counts outbound requests by client and status code ("error" for transport errors).
+	// It is not registered by the package; the application registers it with its metrics registry.
+	RequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_client_requests_total",
+		Help: "Number of outbound HTTP requests by client and status code.",
+	}, []string{"client", "status"})
+	// RequestDuration observes the duration of outbound requests in seconds by client.
+	// It is not registered by the package; the application registers it with its metrics registry.
+	RequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_client_request_duration_seconds",
+		Help:    "Duration of outbound HTTP requests in seconds by client.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"client"})
+)
+
+// Options configures a client.
+type Options struct {
+	// Name identifies the integration in metrics, e.g. "webhook". It must be a constant.
+	Name string
+	// Timeout bounds a single attempt including reading the response body.
+	Timeout time.Duration
+	// Retry retries transport errors and 408, 429 and 5xx responses. A zero MaxAttempts makes a
+	// single attempt; callers that need a fresh request per attempt (e.g. signed requests) retry
+	// themselves. Requests with a body are retried only if the body can be rewound (GetBody, set by
+	// http.NewRequest for in-memory bodies).
+	Retry retry.Config
+}
+
+// StatusError is returned instead of the response when every attempt got a retryable status.
+type StatusError struct {
+	// StatusCode is the status of the last response.
+	StatusCode int
+}
+
+// Error implements the error interface.
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected response status %d", e.StatusCode)
+}
+
+// DefaultRetry returns the retry configuration of best-effort integrations: a few quick attempts
+// with full jitter, so a briefly unavailable endpoint does not lose the request.
+func DefaultRetry() retry.Config {
+	return retry.Config{
+		MaxAttempts:  3,
+		InitialDelay: 200 * time.Millisecond,
+		MaxDelay:     2 * time.Second,
+		Multiplier:   2,
+		Jitter:       retry.JitterFull,
+	}
+}
+
+// Client sends outbound HTTP requests.
+type Client struct {
+	http  *http.Client
+	retry retry.Config
+}
+
+// New creates a client with its own connection pool.
+func New(opts Options) *Client {
+	return &Client{
+		http: &http.Client{
+			Timeout:   opts.Timeout,
+			Transport: &instrumented{name: opts.Name, next: newTransport()},
+		},
+		retry: opts.Retry,
+	}
+}
+
+// newTransport returns a transport with bounded dial and handshake times and a small pool of idle
+// connections per host, which is enough for the few endpoints each integration talks to.
+func newTransport() *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   5 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          20,
+		MaxIdleConnsPerHost:   10,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+}
+
+// Do sends the request, retrying it as configured. The caller closes the body of the response.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	if c.retry.MaxAttempts <= 1 || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		return c.http.Do(req)
+	}
+
+	cfg := c.retry
+	cfg.Retryable = isRetryable
+	var (
+		resp     *http.Response
+		attempts int
+	)
+	err := retry.Do(req.Context(), cfg, func() error {
+		attempts++
+		attempt, err := rewind(req, attempts)
+		if err != nil {
+			return retry.Permanent(err)
+		}
+		if resp, err = c.http.Do(attempt); err != nil {
+			return err
+		}
+		if !retryableStatus(resp.StatusCode) {
+			return nil
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		return &StatusError{StatusCode: resp.StatusCode}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// rewind returns the request for the given attempt with a fresh copy of the body.
+func rewind(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 1 || req.GetBody == nil {
+		return req, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	clone := req.Clone(req.Context())
+	clone.Body = body
+	return clone, nil
+}
+
+// isRetryable reports whether a failed attempt may succeed when repeated.
+func isRetryable(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return true
+	}
+	// url.Error wraps every error of http.Client and implements net.Error itself
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// retryableStatus reports whether a response status is worth retrying.
+func retryableStatus(status int) bool {
+	return status == http.StatusRequestTimeout || status == http.StatusTooManyRequests || status >= 500
+}
+
+// instrumented records metrics of every request sent through the transport.
+type instrumented struct {
+	name string
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *instrumented) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	RequestDuration.WithLabelValues(t.name).Observe(time.Since(start).Seconds())
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+	RequestsTotal.WithLabelValues(t.name, status).Inc()
+	return resp, err
+}
@@ -0,0 +1,118 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/festy23/avito_internship/pkg/retry"
+)
+
+var testRetry = retry.Config{MaxAttempts: 3, InitialDelay: time.Millisecond, MaxDelay: time.Millisecond, Multiplier: 2}
+
+// newServer answers with the given statuses in turn and records the request bodies.
+func newServer(t *testing.T, statuses ...int) (*httptest.Server, *[]string) {
+	t.Helper()
+	var (
+		calls  atomic.Int32
+		bodies []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.WriteHeader(statuses[min(int(calls.Add(1))-1, len(statuses)-1)])
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &bodies
+}
+
+func post(t *testing.T, c *Client, url string) (*http.Response, error) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, bytes.NewReader([]byte("payload")))
+	require.NoError(t, err)
+	resp, err := c.Do(req)
+	if err == nil {
+		t.Cleanup(func() { _ = resp.Body.Close() })
+	}
+	return resp, err
+}
+
+func TestClient_Do(t *testing.T) {
+	t.Run("retries with the body", func(t *testing.T) {
+		srv, bodies := newServer(t, http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK)
+		c := New(Options{Name: "test_retry", Timeout: time.Second, Retry: testRetry})
+
+		resp, err := post(t, c, srv.URL)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []string{"payload", "payload", "payload"}, *bodies)
+	})
+
+	t.Run("retries run out", func(t *testing.T) {
+		srv, bodies := newServer(t, http.StatusBadGateway)
+		c := New(Options{Name: "test_exhausted", Timeout: time.Second, Retry: testRetry})
+
+		_, err := post(t, c, srv.URL)
+		var statusErr *StatusError
+		require.ErrorAs(t, err, &statusErr)
+		assert.Equal(t, http.StatusBadGateway, statusErr.StatusCode)
+		assert.EqualError(t, err, "unexpected response status 502")
+		assert.Len(t, *bodies, 3)
+	})
+
+	t.Run("client errors are returned", func(t *testing.T) {
+		srv, bodies := newServer(t, http.StatusBadRequest)
+		c := New(Options{Name: "test_client_error", Timeout: time.Second, Retry: testRetry})
+
+		resp, err := post(t, c, srv.URL)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Len(t, *bodies, 1)
+	})
+
+	t.Run("single attempt without retries", func(t *testing.T) {
+		srv, bodies := newServer(t, http.StatusServiceUnavailable)
+		c := New(Options{Name: "test_single", Timeout: time.Second})
+
+		resp, err := post(t, c, srv.URL)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Len(t, *bodies, 1)
+	})
+
+	t.Run("transport errors are retried", func(t *testing.T) {
+		srv, _ := newServer(t, http.StatusOK)
+		url := srv.URL
+		srv.Close()
+		c := New(Options{Name: "test_transport", Timeout: time.Second, Retry: testRetry})
+
+		_, err := post(t, c, url)
+		assert.Error(t, err)
+		assert.Equal(t, 3.0, testutil.ToFloat64(RequestsTotal.WithLabelValues("test_transport", "error")))
+	})
+}
+
+func TestClient_Metrics(t *testing.T) {
+	srv, _ := newServer(t, http.StatusInternalServerError, http.StatusNoContent)
+	c := New(Options{Name: "test_metrics", Timeout: time.Second, Retry: testRetry})
+
+	_, err := post(t, c, srv.URL)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(RequestsTotal.WithLabelValues("test_metrics", "500")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(RequestsTotal.WithLabelValues("test_metrics", "204")))
+	var metric dto.Metric
+	require.NoError(t, RequestDuration.WithLabelValues("test_metrics").(prometheus.Histogram).Write(&metric))
+	assert.Equal(t, uint64(2), metric.GetHistogram().GetSampleCount())
+}
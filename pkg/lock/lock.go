@@ -0,0 +1,217 @@
+// Package lock provides named locks shared by all replicas of the service, built on PostgreSQL
+// advisory locks or MySQL user-level locks.
+//
+// A lock name is hashed to the 64-bit key of an advisory lock, so any string can be used as a name.
+// Session locks (Locker) are held on a dedicated connection until released; transaction locks
+// (XactLock) are released when the transaction ends. MySQL has no transaction-scoped user-level
+// locks, so there a transaction locks the row of the key in the xact_locks table created by the
+// migrations. On other databases (SQLite in tests) session locks are held within the process and
+// transaction locks are no-ops, since SQLite serializes write transactions anyway.
+package lock
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// unlockTimeout bounds releasing a session lock, which must not depend on the caller's context.
+const unlockTimeout = 5 * time.Second
+
+// Key returns the advisory lock key of a lock name.
+func Key(name string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+	//nolint:gosec // G115: the key is an opaque hash, overflow into negative keys is intended
+	return int64(h.Sum64())
+}
+
+// queries holds the locking statements of a database. Each statement takes the key of the lock.
+type queries struct {
+	// tryLock returns true if the lock was acquired without waiting.
+	tryLock string
+	// lock waits for the lock and returns true.
+	lock string
+	// unlock releases the lock.
+	unlock string
+}
+
+var (
+	postgresQueries = queries{
+		tryLock: "SELECT pg_try_advisory_lock($1)",
+		lock:    "SELECT true FROM pg_advisory_lock($1)",
+		unlock:  "SELECT pg_advisory_unlock($1)",
+	}
+	// mysqlQueries use the decimal key as the lock name; a negative timeout waits indefinitely.
+	mysqlQueries = queries{
+		tryLock: "SELECT GET_LOCK(CAST(? AS CHAR), 0) = 1",
+		lock:    "SELECT GET_LOCK(CAST(? AS CHAR), -1) = 1",
+		unlock:  "SELECT RELEASE_LOCK(CAST(? AS CHAR))",
+	}
+)
+
+// Unlock releases a held lock. Calls after the first one do nothing.
+type Unlock func() error
+
+// Locker acquires named session locks.
+type Locker struct {
+	db *gorm.DB
+}
+
+// New creates a locker on the database connection pool.
+func New(db *gorm.DB) *Locker {
+	return &Locker{db: db}
+}
+
+// TryLock acquires the named lock without waiting. It returns false if the lock is held elsewhere.
+func (l *Locker) TryLock(ctx context.Context, name string) (Unlock, bool, error) {
+	q, ok := lockQueries(l.db)
+	if !ok {
+		return localTryLock(name)
+	}
+	return l.acquire(ctx, q.tryLock, q.unlock, name)
+}
+
+// Lock acquires the named lock, waiting until it is released elsewhere or ctx is done.
+func (l *Locker) Lock(ctx context.Context, name string) (Unlock, error) {
+	q, ok := lockQueries(l.db)
+	if !ok {
+		return localLock(ctx, name)
+	}
+	unlock, _, err := l.acquire(ctx, q.lock, q.unlock, name)
+	return unlock, err
+}
+
+// acquire runs a locking query on a dedicated connection, which is kept until the lock is released
+// by unlockQuery. The query must return true if the lock was acquired.
+func (l *Locker) acquire(ctx context.Context, query, unlockQuery, name string) (Unlock, bool, error) {
+	sqlDB, err := l.db.DB()
+	if err != nil {
+		return nil, false, fmt.Errorf("lock %q: %w", name, err)
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("lock %q: %w", name, err)
+	}
+
+	key := Key(name)
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, query, key).Scan(&acquired); err != nil {
+		discard(conn)
+		return nil, false, fmt.Errorf("lock %q: %w", name, err)
+	}
+	if !acquired {
+		_ = conn.Close()
+		return nil, false, nil
+	}
+
+	return once(func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), unlockTimeout)
+		defer cancel()
+		if _, err := conn.ExecContext(ctx, unlockQuery, key); err != nil {
+			// The lock lives as long as the session: drop the connection instead of returning it to the pool
+			discard(conn)
+			return fmt.Errorf("unlock %q: %w", name, err)
+		}
+		return conn.Close()
+	}), true, nil
+}
+
+// once returns an Unlock running release on the first call only.
+func once(release func() error) Unlock {
+	var (
+		o   sync.Once
+		err error
+	)
+	return func() error {
+		o.Do(func() { err = release() })
+		return err
+	}
+}
+
+// discard closes the connection of conn instead of returning it to the pool.
+func discard(conn *sql.Conn) {
+	_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+	_ = conn.Close()
+}
+
+// XactLock acquires the named lock for the rest of transaction tx, waiting until it is released
+// elsewhere. The lock is released on commit or rollback.
+func XactLock(tx *gorm.DB, name string) error {
+	var query string
+	switch tx.Dialector.Name() {
+	case "postgres":
+		query = "SELECT pg_advisory_xact_lock(?)"
+	case "mysql":
+		query = "INSERT INTO xact_locks (lock_key) VALUES (?) ON DUPLICATE KEY UPDATE lock_key = lock_key"
+	default:
+		return nil
+	}
+	if err := tx.Exec(query, Key(name)).Error; err != nil {
+		return fmt.Errorf("lock %q: %w", name, err)
+	}
+	return nil
+}
+
+// lockQueries returns the session locking statements of db; false if it has no shared locks.
+func lockQueries(db *gorm.DB) (queries, bool) {
+	switch db.Dialector.Name() {
+	case "postgres":
+		return postgresQueries, true
+	case "mysql":
+		return mysqlQueries, true
+	default:
+		return queries{}, false
+	}
+}
+
+// local holds the in-process locks used on databases without advisory locks.
+var local = struct {
+	sync.Mutex
+	held map[string]chan struct{}
+}{held: make(map[string]chan struct{})}
+
+// localChannel returns the channel of the named lock; the lock is held while the channel holds a value.
+func localChannel(name string) chan struct{} {
+	local.Lock()
+	defer local.Unlock()
+	ch, ok := local.held[name]
+	if !ok {
+		ch = make(chan struct{}, 1)
+		local.held[name] = ch
+	}
+	return ch
+}
+
+func localTryLock(name string) (Unlock, bool, error) {
+	ch := localChannel(name)
+	select {
+	case ch <- struct{}{}:
+		return localUnlock(ch), true, nil
+	default:
+		return nil, false, nil
+	}
+}
+
+func localLock(ctx context.Context, name string) (Unlock, error) {
+	ch := localChannel(name)
+	select {
+	case ch <- struct{}{}:
+		return localUnlock(ch), nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("lock %q: %w", name, ctx.Err())
+	}
+}
+
+func localUnlock(ch chan struct{}) Unlock {
+	return once(func() error {
+		<-ch
+		return nil
+	})
+}
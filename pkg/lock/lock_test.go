@@ -0,0 +1,119 @@
+package lock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newLocker(t *testing.T) *Locker {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	return New(db)
+}
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, Key("rebalance"), Key("rebalance"))
+	assert.NotEqual(t, Key("rebalance"), Key("archive"))
+}
+
+func TestLocker_TryLock(t *testing.T) {
+	ctx := context.Background()
+	locker := newLocker(t)
+
+	unlock, acquired, err := locker.TryLock(ctx, "test:try")
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	// Locks are shared by all lockers of the process
+	_, acquired, err = newLocker(t).TryLock(ctx, "test:try")
+	require.NoError(t, err)
+	assert.False(t, acquired)
+
+	_, acquired, err = locker.TryLock(ctx, "test:other")
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	require.NoError(t, unlock())
+	require.NoError(t, unlock(), "unlock is idempotent")
+
+	unlock, acquired, err = locker.TryLock(ctx, "test:try")
+	require.NoError(t, err)
+	assert.True(t, acquired)
+	require.NoError(t, unlock())
+}
+
+func TestLocker_Lock(t *testing.T) {
+	locker := newLocker(t)
+
+	t.Run("waits for release", func(t *testing.T) {
+		unlock, err := locker.Lock(context.Background(), "test:wait")
+		require.NoError(t, err)
+
+		acquired := make(chan struct{})
+		go func() {
+			second, lockErr := locker.Lock(context.Background(), "test:wait")
+			if lockErr == nil {
+				close(acquired)
+				_ = second()
+			}
+		}()
+
+		select {
+		case <-acquired:
+			t.Fatal("lock acquired while held")
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		require.NoError(t, unlock())
+		select {
+		case <-acquired:
+		case <-time.After(time.Second):
+			t.Fatal("lock not acquired after release")
+		}
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		unlock, err := locker.Lock(context.Background(), "test:cancel")
+		require.NoError(t, err)
+		defer func() { _ = unlock() }()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err = locker.Lock(ctx, "test:cancel")
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestXactLock(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		return XactLock(tx, "test:xact")
+	})
+	assert.NoError(t, err, "transaction locks are no-ops on SQLite")
+}
+
+func TestLockQueries(t *testing.T) {
+	sqliteDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	q, ok := lockQueries(&gorm.DB{Config: &gorm.Config{Dialector: postgres.Dialector{}}})
+	assert.True(t, ok)
+	assert.Equal(t, postgresQueries, q)
+
+	q, ok = lockQueries(&gorm.DB{Config: &gorm.Config{Dialector: mysql.Dialector{}}})
+	assert.True(t, ok)
+	assert.Equal(t, mysqlQueries, q)
+
+	_, ok = lockQueries(sqliteDB)
+	assert.False(t, ok, "SQLite falls back to in-process locks")
+}
@@ -2,8 +2,11 @@
 package logger
 
 import (
+	"time"
+
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 
 	appConfig "github.com/festy23/avito_internship/internal/config"
 )
@@ -39,16 +42,24 @@ func NewWithConfig(cfg appConfig.LoggerConfig) (*zap.SugaredLogger, error) {
 		zapConfig.Encoding = "json"
 	}
 
+	// Repeated entries beyond the initial rate are sampled, so a burst of identical errors cannot
+	// flood the output
+	zapConfig.Sampling = nil
+	if cfg.SamplingInitial > 0 {
+		zapConfig.Sampling = &zap.SamplingConfig{Initial: cfg.SamplingInitial, Thereafter: cfg.SamplingThereafter}
+	}
+	zapConfig.ErrorOutputPaths = []string{"stderr"}
+
+	if cfg.IsFile() {
+		return newFileLogger(zapConfig, cfg)
+	}
+
 	// Set output
-	if cfg.Output != "stdout" && cfg.Output != "stderr" {
-		// File output would require additional setup
-		// For now, default to stdout
-		zapConfig.OutputPaths = []string{"stdout"}
-		zapConfig.ErrorOutputPaths = []string{"stderr"}
-	} else {
-		zapConfig.OutputPaths = []string{cfg.Output}
-		zapConfig.ErrorOutputPaths = []string{"stderr"}
+	output := cfg.Output
+	if output == "" {
+		output = "stdout"
 	}
+	zapConfig.OutputPaths = []string{output}
 
 	logger, err := zapConfig.Build()
 	if err != nil {
@@ -57,3 +68,38 @@ func NewWithConfig(cfg appConfig.LoggerConfig) (*zap.SugaredLogger, error) {
 
 	return logger.Sugar(), nil
 }
+
+// newFileLogger creates a logger writing to the file cfg.Output, rotated by size, for deployments
+// without a log collector. Rotated files are named after their rotation time and pruned by
+// age and count.
+func newFileLogger(zapConfig zap.Config, cfg appConfig.LoggerConfig) (*zap.SugaredLogger, error) {
+	writer := &lumberjack.Logger{
+		Filename:   cfg.Output,
+		MaxSize:    cfg.MaxSizeMB,
+		MaxAge:     cfg.MaxAgeDays,
+		MaxBackups: cfg.MaxBackups,
+		Compress:   cfg.Compress,
+	}
+	encoder := zapcore.NewJSONEncoder(zapConfig.EncoderConfig)
+	if zapConfig.Encoding == "console" {
+		encoder = zapcore.NewConsoleEncoder(zapConfig.EncoderConfig)
+	}
+
+	// zap opens output paths itself, so the rotating writer replaces the core it builds; sampling
+	// is applied here because the replaced core would otherwise drop it
+	sampling := zapConfig.Sampling
+	zapConfig.Sampling = nil
+	zapConfig.OutputPaths = nil
+	logger, err := zapConfig.Build(zap.WrapCore(func(zapcore.Core) zapcore.Core {
+		core := zapcore.NewCore(encoder, zapcore.AddSync(writer), zapConfig.Level)
+		if sampling != nil {
+			core = zapcore.NewSamplerWithOptions(core, time.Second, sampling.Initial, sampling.Thereafter)
+		}
+		return core
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return logger.Sugar(), nil
+}
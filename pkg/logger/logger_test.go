@@ -1,6 +1,9 @@
 package logger
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -280,6 +283,33 @@ func TestLoggerOutputs(t *testing.T) {
 	}
 }
 
+func TestLoggerFileOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	cfg := appConfig.LoggerConfig{
+		Level:              "info",
+		Format:             "json",
+		Output:             path,
+		SamplingInitial:    2,
+		SamplingThereafter: 100,
+		MaxSizeMB:          1,
+	}
+
+	logger, err := NewWithConfig(cfg)
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		logger.Infow("repeated message", "i", i)
+	}
+	logger.Infow("other message")
+	require.NoError(t, logger.Sync())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 3, "repeated entries beyond the initial rate are sampled")
+	assert.Contains(t, lines[0], `"msg":"repeated message"`)
+	assert.Contains(t, lines[2], `"msg":"other message"`)
+}
+
 func TestLoggerEdgeCases(t *testing.T) {
 	t.Run("empty config uses defaults", func(t *testing.T) {
 		cfg := appConfig.LoggerConfig{}
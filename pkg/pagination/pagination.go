@@ -0,0 +1,53 @@
+// Package pagination holds the page size limits shared by all list endpoints.
+//
+// The limits are configured once and passed to the modules, so every list endpoint falls back to the
+// same page size when the limit parameter is omitted and rejects pages larger than the same maximum.
+package pagination
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidLimit indicates that the requested page size is out of the allowed range.
+var ErrInvalidLimit = errors.New("limit out of range")
+
+const (
+	// DefaultLimit is the page size of requests without a limit unless configured otherwise.
+	DefaultLimit = 50
+	// DefaultMaxLimit is the largest accepted page size unless configured otherwise.
+	DefaultMaxLimit = 100
+)
+
+// Limits bound the page size of list endpoints.
+type Limits struct {
+	// Default is the page size of requests without a limit.
+	Default int
+	// Max is the largest accepted page size.
+	Max int
+}
+
+// DefaultLimits returns the limits used when none are configured.
+func DefaultLimits() Limits {
+	return Limits{Default: DefaultLimit, Max: DefaultMaxLimit}
+}
+
+// Resolve returns the page size of a request: limit, or Default for a zero limit.
+// Other limits outside 1..Max fail with ErrInvalidLimit.
+func (l Limits) Resolve(limit int) (int, error) {
+	if limit == 0 {
+		return l.Default, nil
+	}
+	if err := l.Check(limit); err != nil {
+		return 0, err
+	}
+	return limit, nil
+}
+
+// Check validates an explicit page size; limits outside 1..Max fail with ErrInvalidLimit naming the range.
+func (l Limits) Check(limit int) error {
+	if limit < 1 || limit > l.Max {
+		return fmt.Errorf("%w: must be between 1 and %d, got %d", ErrInvalidLimit, l.Max, limit)
+	}
+	return nil
+}
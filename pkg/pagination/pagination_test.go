@@ -0,0 +1,43 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimits_Resolve(t *testing.T) {
+	limits := Limits{Default: 20, Max: 50}
+
+	t.Run("default", func(t *testing.T) {
+		limit, err := limits.Resolve(0)
+		require.NoError(t, err)
+		assert.Equal(t, 20, limit)
+	})
+
+	t.Run("explicit", func(t *testing.T) {
+		for _, want := range []int{1, 35, 50} {
+			limit, err := limits.Resolve(want)
+			require.NoError(t, err)
+			assert.Equal(t, want, limit)
+		}
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		for _, limit := range []int{-1, 51} {
+			_, err := limits.Resolve(limit)
+			assert.ErrorIs(t, err, ErrInvalidLimit)
+		}
+		_, err := limits.Resolve(500)
+		assert.EqualError(t, err, "limit out of range: must be between 1 and 50, got 500")
+	})
+}
+
+func TestLimits_Check(t *testing.T) {
+	limits := DefaultLimits()
+
+	assert.NoError(t, limits.Check(DefaultMaxLimit))
+	assert.ErrorIs(t, limits.Check(0), ErrInvalidLimit)
+	assert.ErrorIs(t, limits.Check(DefaultMaxLimit+1), ErrInvalidLimit)
+}
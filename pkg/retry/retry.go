@@ -3,6 +3,7 @@ package retry
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"math/rand"
@@ -10,6 +11,22 @@ import (
 	"time"
 )
 
+// ErrBudgetExceeded is matched (errors.Is) by the error of a call that ran out of its retry budget.
+var ErrBudgetExceeded = errors.New("retry budget exceeded")
+
+// Jitter is the randomization of the delays between attempts.
+type Jitter int
+
+const (
+	// JitterProportional varies each delay by up to ±10%. It is the default.
+	JitterProportional Jitter = iota
+	// JitterFull picks each delay uniformly between 0 and the backoff delay ("full jitter"), which
+	// spreads the retries of many clients that failed at once the most.
+	JitterFull
+	// JitterNone uses the exact backoff delays.
+	JitterNone
+)
+
 // Config holds retry strategy configuration.
 type Config struct {
 	// MaxAttempts is the maximum number of retry attempts (including initial attempt).
@@ -23,6 +40,34 @@ type Config struct {
 	// RetryableErrors is a list of error patterns to retry on.
 	// If empty, all errors are considered retryable.
 	RetryableErrors []string
+	// Retryable classifies errors as retryable. If both Retryable and RetryableErrors are set, errors
+	// accepted by either are retried; if neither is set, all errors are retried. Errors wrapped with
+	// Permanent are never retried.
+	Retryable func(error) bool
+	// Jitter is the randomization of the delays.
+	Jitter Jitter
+	// Budget limits the total time of a call including all attempts and delays; 0 means no limit.
+	// A retry whose delay would end past the budget is not made.
+	Budget time.Duration
+	// OnRetry, if not nil, is called before waiting for the next attempt, e.g. to log the failure.
+	OnRetry func(attempt int, err error, delay time.Duration)
+}
+
+// permanentError marks an error that must not be retried.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so that it is returned at once without retrying, e.g. for a 4xx response.
+// Do returns the wrapped error itself. It returns nil for a nil err.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
 }
 
 // DefaultConfig returns default retry configuration.
@@ -53,11 +98,12 @@ func DoWithResult[T any](ctx context.Context, cfg Config, fn func() (T, error))
 		return zero, fmt.Errorf("MaxAttempts must be greater than 0")
 	}
 
+	start := time.Now()
 	var lastErr error
 	for attempt := 0; attempt < cfg.MaxAttempts; attempt++ {
 		// Check context before attempt
 		if ctx.Err() != nil {
-			return zero, ctx.Err()
+			return zero, abandoned(ctx.Err(), lastErr)
 		}
 
 		// Execute function
@@ -66,6 +112,10 @@ func DoWithResult[T any](ctx context.Context, cfg Config, fn func() (T, error))
 			return result, nil
 		}
 
+		var permanent *permanentError
+		if errors.As(err, &permanent) {
+			return zero, permanent.err
+		}
 		lastErr = err
 
 		// Check if error is retryable
@@ -79,14 +129,26 @@ func DoWithResult[T any](ctx context.Context, cfg Config, fn func() (T, error))
 		}
 
 		// Calculate delay
-		delay := calculateDelay(attempt, cfg)
-		delay = addJitter(delay)
+		delay := jitter(calculateDelay(attempt, cfg), cfg.Jitter)
+
+		// Give up early rather than wait for an attempt that cannot be made in time
+		if cfg.Budget > 0 && time.Since(start)+delay > cfg.Budget {
+			return zero, fmt.Errorf("%w: %w", ErrBudgetExceeded, lastErr)
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return zero, abandoned(context.DeadlineExceeded, lastErr)
+		}
+		if cfg.OnRetry != nil {
+			cfg.OnRetry(attempt+1, err, delay)
+		}
 
 		// Wait with context cancellation support
+		timer := time.NewTimer(delay)
 		select {
 		case <-ctx.Done():
-			return zero, ctx.Err()
-		case <-time.After(delay):
+			timer.Stop()
+			return zero, abandoned(ctx.Err(), lastErr)
+		case <-timer.C:
 			// Continue to next attempt
 		}
 	}
@@ -94,6 +156,14 @@ func DoWithResult[T any](ctx context.Context, cfg Config, fn func() (T, error))
 	return zero, lastErr
 }
 
+// abandoned returns the context error of a call abandoned after lastErr, matching both errors.
+func abandoned(ctxErr, lastErr error) error {
+	if lastErr == nil {
+		return ctxErr
+	}
+	return fmt.Errorf("%w: %w", ctxErr, lastErr)
+}
+
 // calculateDelay calculates exponential backoff delay.
 func calculateDelay(attempt int, cfg Config) time.Duration {
 	if attempt < 0 {
@@ -111,6 +181,22 @@ func calculateDelay(attempt int, cfg Config) time.Duration {
 	return time.Duration(delay)
 }
 
+// jitter randomizes delay according to the strategy.
+func jitter(delay time.Duration, strategy Jitter) time.Duration {
+	switch strategy {
+	case JitterFull:
+		if delay <= 0 {
+			return 0
+		}
+		//nolint:gosec // math/rand is sufficient for jitter calculation, no security requirement
+		return time.Duration(rand.Int63n(int64(delay) + 1))
+	case JitterNone:
+		return delay
+	default:
+		return addJitter(delay)
+	}
+}
+
 // addJitter adds random jitter to delay to avoid thundering herd.
 func addJitter(delay time.Duration) time.Duration {
 	// Add ±10% jitter
@@ -126,11 +212,19 @@ func IsRetryableError(err error, cfg Config) bool {
 		return false
 	}
 
-	// If no retryable errors specified, all errors are retryable
-	if len(cfg.RetryableErrors) == 0 {
+	var permanent *permanentError
+	if errors.As(err, &permanent) {
+		return false
+	}
+	if cfg.Retryable != nil && cfg.Retryable(err) {
 		return true
 	}
 
+	// If no retryable errors specified, all errors are retryable unless classified by Retryable
+	if len(cfg.RetryableErrors) == 0 {
+		return cfg.Retryable == nil
+	}
+
 	errMsg := strings.ToLower(err.Error())
 
 	// Check if error message matches any retryable pattern
@@ -162,9 +256,11 @@ func DefaultPostgresRetryableErrors() []string {
 	}
 }
 
-// PostgresConfig returns retry configuration optimized for PostgreSQL connections.
+// PostgresConfig returns retry configuration optimized for PostgreSQL connections. Replicas started
+// together retry with full jitter, so they do not reconnect to a recovering database at once.
 func PostgresConfig() Config {
 	cfg := DefaultConfig()
 	cfg.RetryableErrors = DefaultPostgresRetryableErrors()
+	cfg.Jitter = JitterFull
 	return cfg
 }
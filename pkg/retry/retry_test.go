@@ -3,6 +3,7 @@ package retry
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -325,6 +326,7 @@ func TestPostgresConfig(t *testing.T) {
 	assert.Equal(t, 5, cfg.MaxAttempts)
 	assert.NotEmpty(t, cfg.RetryableErrors)
 	assert.Contains(t, cfg.RetryableErrors, "connection refused")
+	assert.Equal(t, JitterFull, cfg.Jitter)
 }
 
 func TestDo_ContextTimeout(t *testing.T) {
@@ -418,3 +420,110 @@ func TestAddJitter_ZeroDelay(t *testing.T) {
 	jittered := addJitter(delay)
 	assert.Equal(t, time.Duration(0), jittered)
 }
+
+func TestJitter(t *testing.T) {
+	delay := time.Second
+
+	for range 100 {
+		jittered := jitter(delay, JitterFull)
+		assert.GreaterOrEqual(t, jittered, time.Duration(0))
+		assert.LessOrEqual(t, jittered, delay)
+	}
+	assert.Equal(t, delay, jitter(delay, JitterNone))
+	assert.Equal(t, time.Duration(0), jitter(0, JitterFull))
+}
+
+func TestDo_Permanent(t *testing.T) {
+	errRejected := errors.New("rejected")
+	attempts := 0
+	err := Do(context.Background(), DefaultConfig(), func() error {
+		attempts++
+		return Permanent(errRejected)
+	})
+
+	assert.Equal(t, errRejected, err)
+	assert.Equal(t, 1, attempts)
+	assert.NoError(t, Permanent(nil))
+}
+
+func TestDo_RetryableFunc(t *testing.T) {
+	errTransient := errors.New("transient")
+	cfg := Config{
+		MaxAttempts:     5,
+		InitialDelay:    time.Millisecond,
+		MaxDelay:        time.Millisecond,
+		Multiplier:      2,
+		RetryableErrors: []string{"connection refused"},
+		Retryable:       func(err error) bool { return errors.Is(err, errTransient) },
+	}
+
+	assert.True(t, IsRetryableError(fmt.Errorf("query: %w", errTransient), cfg))
+	assert.True(t, IsRetryableError(errors.New("dial: connection refused"), cfg))
+	assert.False(t, IsRetryableError(errors.New("invalid credentials"), cfg))
+
+	cfg.RetryableErrors = nil
+	assert.False(t, IsRetryableError(errors.New("invalid credentials"), cfg), "Retryable alone classifies errors")
+
+	attempts := 0
+	err := Do(context.Background(), cfg, func() error {
+		attempts++
+		return errTransient
+	})
+	assert.ErrorIs(t, err, errTransient)
+	assert.Equal(t, 5, attempts)
+}
+
+func TestDo_Budget(t *testing.T) {
+	errTemporary := errors.New("temporary error")
+	cfg := Config{
+		MaxAttempts:  10,
+		InitialDelay: 20 * time.Millisecond,
+		MaxDelay:     20 * time.Millisecond,
+		Multiplier:   1,
+		Jitter:       JitterNone,
+		Budget:       50 * time.Millisecond,
+	}
+
+	attempts := 0
+	err := Do(context.Background(), cfg, func() error {
+		attempts++
+		return errTemporary
+	})
+
+	assert.ErrorIs(t, err, ErrBudgetExceeded)
+	assert.ErrorIs(t, err, errTemporary)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestDo_ContextDeadlineBeforeDelay(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	errTemporary := errors.New("temporary error")
+	cfg := DefaultConfig()
+	cfg.InitialDelay = time.Minute
+	cfg.MaxDelay = time.Minute
+
+	start := time.Now()
+	err := Do(ctx, cfg, func() error { return errTemporary })
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorIs(t, err, errTemporary)
+	assert.Less(t, time.Since(start), 500*time.Millisecond, "does not wait for the deadline")
+}
+
+func TestDo_OnRetry(t *testing.T) {
+	cfg := Config{MaxAttempts: 3, InitialDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond, Multiplier: 2,
+		Jitter: JitterNone}
+	var retries []int
+	var delays []time.Duration
+	cfg.OnRetry = func(attempt int, err error, delay time.Duration) {
+		retries = append(retries, attempt)
+		delays = append(delays, delay)
+	}
+
+	_ = Do(context.Background(), cfg, func() error { return errors.New("temporary error") })
+
+	assert.Equal(t, []int{1, 2}, retries)
+	assert.Equal(t, []time.Duration{time.Millisecond, 2 * time.Millisecond}, delays)
+}
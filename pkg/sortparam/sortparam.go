@@ -0,0 +1,107 @@
+// Package sortparam parses the sort and order query parameters of list endpoints.
+//
+// Each endpoint declares a Spec with the fields clients may sort by and the columns each field
+// orders by. Only columns declared in a spec reach ORDER BY, so the parameters cannot inject SQL.
+package sortparam
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Sort orders.
+const (
+	// Asc orders from the smallest (oldest) value.
+	Asc = "asc"
+	// Desc orders from the largest (newest) value.
+	Desc = "desc"
+)
+
+var (
+	// ErrInvalidField indicates that the sort field is not declared by the endpoint.
+	ErrInvalidField = errors.New("unsupported sort field")
+	// ErrInvalidOrder indicates that the order is neither asc nor desc.
+	ErrInvalidOrder = errors.New("order must be asc or desc")
+)
+
+// Sort is a validated sort field and order. The zero value means the defaults of the spec.
+type Sort struct {
+	Field string
+	Order string
+}
+
+// Spec declares the sortable fields of a list endpoint.
+type Spec struct {
+	// Columns maps sort fields to the columns they order by, all in the requested order.
+	Columns map[string][]string
+	// DefaultField and DefaultOrder apply when the parameters are omitted.
+	DefaultField string
+	DefaultOrder string
+	// TieBreaker is a unique column appended to ORDER BY, in ascending order, so that rows with
+	// equal sort values keep a stable order between requests.
+	TieBreaker string
+}
+
+// Parse validates the sort and order query parameters. Empty parameters are left to the defaults.
+func (s Spec) Parse(field, order string) (Sort, error) {
+	if field != "" {
+		if _, ok := s.Columns[field]; !ok {
+			return Sort{}, fmt.Errorf("%w %q, supported: %s", ErrInvalidField, field, strings.Join(s.fields(), ", "))
+		}
+	}
+	if order != "" && order != Asc && order != Desc {
+		return Sort{}, ErrInvalidOrder
+	}
+	return Sort{Field: field, Order: order}, nil
+}
+
+// OrderBy returns the ORDER BY clause of srt. Undeclared fields and orders fall back to the defaults.
+func (s Spec) OrderBy(srt Sort) string {
+	columns, ok := s.Columns[srt.Field]
+	if !ok {
+		columns = s.Columns[s.DefaultField]
+	}
+
+	direction := " ASC"
+	if s.Descending(srt) {
+		direction = " DESC"
+	}
+
+	terms := make([]string, 0, len(columns)+1)
+	for _, column := range columns {
+		terms = append(terms, column+direction)
+	}
+	if s.TieBreaker != "" && !contains(columns, s.TieBreaker) {
+		terms = append(terms, s.TieBreaker+" ASC")
+	}
+	return strings.Join(terms, ", ")
+}
+
+// Descending reports whether srt orders from the largest value, taking the default order into account.
+func (s Spec) Descending(srt Sort) bool {
+	if srt.Order == Asc || srt.Order == Desc {
+		return srt.Order == Desc
+	}
+	return s.DefaultOrder == Desc
+}
+
+func contains(columns []string, column string) bool {
+	for _, c := range columns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
+
+// fields returns the declared sort fields in alphabetical order.
+func (s Spec) fields() []string {
+	fields := make([]string, 0, len(s.Columns))
+	for field := range s.Columns {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
@@ -0,0 +1,78 @@
+package sortparam
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSpec = Spec{
+	Columns: map[string][]string{
+		"created_at": {"pull_requests.created_at"},
+		"name":       {"pull_requests.pull_request_name"},
+	},
+	DefaultField: "created_at",
+	DefaultOrder: Desc,
+	TieBreaker:   "pull_requests.pull_request_id",
+}
+
+func TestSpec_Parse(t *testing.T) {
+	t.Run("declared field and order", func(t *testing.T) {
+		srt, err := testSpec.Parse("name", "asc")
+		require.NoError(t, err)
+		assert.Equal(t, Sort{Field: "name", Order: Asc}, srt)
+	})
+
+	t.Run("empty parameters", func(t *testing.T) {
+		srt, err := testSpec.Parse("", "")
+		require.NoError(t, err)
+		assert.Equal(t, Sort{}, srt)
+	})
+
+	t.Run("undeclared field", func(t *testing.T) {
+		_, err := testSpec.Parse("author_id; DROP TABLE users", "")
+		assert.ErrorIs(t, err, ErrInvalidField)
+		assert.ErrorContains(t, err, "supported: created_at, name")
+	})
+
+	t.Run("invalid order", func(t *testing.T) {
+		_, err := testSpec.Parse("name", "DESC")
+		assert.ErrorIs(t, err, ErrInvalidOrder)
+	})
+}
+
+func TestSpec_Descending(t *testing.T) {
+	assert.True(t, testSpec.Descending(Sort{}))
+	assert.False(t, testSpec.Descending(Sort{Order: Asc}))
+	assert.True(t, testSpec.Descending(Sort{Field: "name", Order: Desc}))
+}
+
+func TestSpec_OrderBy(t *testing.T) {
+	tests := []struct {
+		name string
+		srt  Sort
+		want string
+	}{
+		{"defaults", Sort{}, "pull_requests.created_at DESC, pull_requests.pull_request_id ASC"},
+		{"field and order", Sort{Field: "name", Order: Asc},
+			"pull_requests.pull_request_name ASC, pull_requests.pull_request_id ASC"},
+		{"undeclared field falls back", Sort{Field: "1; --", Order: "x"},
+			"pull_requests.created_at DESC, pull_requests.pull_request_id ASC"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, testSpec.OrderBy(tt.srt))
+		})
+	}
+
+	t.Run("several columns follow the order", func(t *testing.T) {
+		spec := Spec{
+			Columns:      map[string][]string{"created_at": {"created_at", "id"}},
+			DefaultField: "created_at",
+			DefaultOrder: Asc,
+			TieBreaker:   "id",
+		}
+		assert.Equal(t, "created_at DESC, id DESC", spec.OrderBy(Sort{Order: Desc}))
+	})
+}
@@ -0,0 +1,188 @@
+//go:build bench
+// +build bench
+
+// Package bench benchmarks reviewer assignment against PostgreSQL.
+//
+// Connection settings are read from DB_* environment variables. Benchmarks run in a temporary
+// schema that is dropped afterwards, so they can be pointed at a development database.
+package bench
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	dbConfig "github.com/festy23/avito_internship/internal/database/config"
+	"github.com/festy23/avito_internship/internal/database/database"
+	"github.com/festy23/avito_internship/internal/database/migrate"
+	"github.com/festy23/avito_internship/internal/gendata"
+	pullrequestModel "github.com/festy23/avito_internship/internal/pullrequest/model"
+	"github.com/festy23/avito_internship/internal/pullrequest/repository"
+	"github.com/festy23/avito_internship/internal/pullrequest/service"
+)
+
+var (
+	db *gorm.DB
+	// seeded maps a seed prefix to true once its data exists; a benchmark function runs several times.
+	seeded = make(map[string]bool)
+	// seq makes pull request IDs unique across benchmark runs.
+	seq int
+)
+
+// scenarios are the team sizes and numbers of open PRs in the author's team.
+var scenarios = []struct {
+	teamSize int
+	openPRs  int
+}{
+	{teamSize: 5, openPRs: 0},
+	{teamSize: 5, openPRs: 1000},
+	{teamSize: 50, openPRs: 1000},
+	{teamSize: 50, openPRs: 10000},
+	{teamSize: 500, openPRs: 10000},
+}
+
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+func run(m *testing.M) int {
+	cfg := dbConfig.LoadConfigFromEnv()
+	schema := fmt.Sprintf("bench_%d", os.Getpid())
+
+	admin, err := database.NewWithConfig(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to connect to database: %v\n", err)
+		return 1
+	}
+	if err := admin.Exec(fmt.Sprintf("CREATE SCHEMA %q", schema)).Error; err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create schema: %v\n", err)
+		return 1
+	}
+	defer admin.Exec(fmt.Sprintf("DROP SCHEMA IF EXISTS %q CASCADE", schema))
+
+	cfg.Schema = schema
+	db, err = database.NewWithConfig(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to connect to database: %v\n", err)
+		return 1
+	}
+	if os.Getenv("MIGRATIONS_PATH") == "" {
+		_ = os.Setenv("MIGRATIONS_PATH", "../../migrations")
+	}
+	if err := migrate.Migrate(db); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to run migrations: %v\n", err)
+		return 1
+	}
+
+	return m.Run()
+}
+
+// seed creates (once per name and scenario) a team of teamSize active users with openPRs open
+// pull requests and returns the ID prefix of the generated rows.
+func seed(b *testing.B, name string, teamSize, openPRs int) string {
+	b.Helper()
+
+	prefix := fmt.Sprintf("%s-t%d-o%d", name, teamSize, openPRs)
+	if seeded[prefix] {
+		return prefix
+	}
+	cfg := gendata.DefaultConfig()
+	cfg.Prefix = prefix
+	cfg.Teams = 1
+	cfg.MinTeamSize = teamSize
+	cfg.MaxTeamSize = teamSize
+	cfg.ActiveRatio = 1
+	cfg.PullRequests = openPRs
+	cfg.MergedRatio = 0
+	cfg.BatchSize = 1000
+	cfg.Seed = 1
+
+	if _, err := gendata.New(db, cfg, zap.NewNop().Sugar()).Run(context.Background()); err != nil {
+		b.Fatalf("failed to seed data: %v", err)
+	}
+	seeded[prefix] = true
+	return prefix
+}
+
+// cleanupCreated removes pull requests created by the benchmark run so every run starts with the
+// scenario's number of open PRs.
+func cleanupCreated(b *testing.B, prefix string) {
+	b.Cleanup(func() {
+		pattern := prefix + "-bench-%"
+		db.Exec("DELETE FROM pull_request_reviewers WHERE pull_request_id LIKE ?", pattern)
+		db.Exec("DELETE FROM pull_requests WHERE pull_request_id LIKE ?", pattern)
+	})
+}
+
+func newService() service.Service {
+	logger := zap.NewNop().Sugar()
+	return service.New(repository.New(db, logger), db, logger)
+}
+
+func BenchmarkCreatePullRequest(b *testing.B) {
+	for _, sc := range scenarios {
+		b.Run(fmt.Sprintf("team=%d/open=%d", sc.teamSize, sc.openPRs), func(b *testing.B) {
+			prefix := seed(b, "create", sc.teamSize, sc.openPRs)
+			cleanupCreated(b, prefix)
+			svc := newService()
+			ctx := context.Background()
+			author := prefix + "-u-000001"
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				seq++
+				_, err := svc.CreatePullRequest(ctx, &pullrequestModel.CreatePullRequestRequest{
+					PullRequestID:   fmt.Sprintf("%s-bench-%d", prefix, seq),
+					PullRequestName: fmt.Sprintf("Bench %d", seq),
+					AuthorID:        author,
+				})
+				if err != nil {
+					b.Fatalf("create pull request: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkReassignReviewer(b *testing.B) {
+	for _, sc := range scenarios {
+		b.Run(fmt.Sprintf("team=%d/open=%d", sc.teamSize, sc.openPRs), func(b *testing.B) {
+			prefix := seed(b, "reassign", sc.teamSize, sc.openPRs)
+			cleanupCreated(b, prefix)
+			svc := newService()
+			ctx := context.Background()
+
+			seq++
+			prID := fmt.Sprintf("%s-bench-%d", prefix, seq)
+			resp, err := svc.CreatePullRequest(ctx, &pullrequestModel.CreatePullRequestRequest{
+				PullRequestID:   prID,
+				PullRequestName: "Reassign bench",
+				AuthorID:        prefix + "-u-000001",
+			})
+			if err != nil {
+				b.Fatalf("create pull request: %v", err)
+			}
+			if len(resp.AssignedReviewers) == 0 {
+				b.Fatal("no reviewers assigned")
+			}
+			reviewer := resp.AssignedReviewers[0]
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				// Each iteration replaces the reviewer assigned by the previous one.
+				reassigned, err := svc.ReassignReviewer(ctx, &pullrequestModel.ReassignReviewerRequest{
+					PullRequestID: prID,
+					OldUserID:     reviewer,
+				})
+				if err != nil {
+					b.Fatalf("reassign reviewer: %v", err)
+				}
+				reviewer = reassigned.ReplacedBy
+			}
+		})
+	}
+}
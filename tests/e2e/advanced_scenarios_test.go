@@ -24,6 +24,7 @@ type AdvancedScenariosTestSuite struct {
 }
 
 func TestAdvancedScenarios(t *testing.T) {
+	t.Parallel()
 	suite.Run(t, new(AdvancedScenariosTestSuite))
 }
 
@@ -21,6 +21,7 @@ type BusinessScenariosTestSuite struct {
 }
 
 func TestBusinessScenarios(t *testing.T) {
+	t.Parallel()
 	suite.Run(t, new(BusinessScenariosTestSuite))
 }
 
@@ -11,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/suite"
 
+	commentModel "github.com/festy23/avito_internship/internal/comment/model"
 	pullrequestModel "github.com/festy23/avito_internship/internal/pullrequest/model"
 	teamModel "github.com/festy23/avito_internship/internal/team/model"
 )
@@ -20,6 +21,7 @@ type EdgeCasesTestSuite struct {
 }
 
 func TestEdgeCases(t *testing.T) {
+	t.Parallel()
 	suite.Run(t, new(EdgeCasesTestSuite))
 }
 
@@ -343,3 +345,45 @@ func (s *EdgeCasesTestSuite) TestEdgeCase_EmptyReviewerList() {
 		s.Require().Empty(pr.AssignedReviewers, "should have no reviewers when all others are inactive")
 	})
 }
+
+// TestEdgeCase_CommentsOfDeletedPR tests that comments are deleted together with their PR
+func (s *EdgeCasesTestSuite) TestEdgeCase_CommentsOfDeletedPR() {
+	teamReq := &teamModel.AddTeamRequest{
+		TeamName: "comments-team",
+		Members: []teamModel.TeamMember{
+			{UserID: "commenter1", Username: "Alice", IsActive: true},
+			{UserID: "commenter2", Username: "Bob", IsActive: true},
+		},
+	}
+	resp, _ := s.createTeam(teamReq)
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+
+	resp, _ = s.createPR(&pullrequestModel.CreatePullRequestRequest{
+		PullRequestID:   "pr-commented",
+		PullRequestName: "PR with comments",
+		AuthorID:        "commenter1",
+	})
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+
+	for _, userID := range []string{"commenter2", "commenter1"} {
+		body, _ := json.Marshal(commentModel.CreateCommentRequest{
+			PullRequestID: "pr-commented",
+			UserID:        userID,
+			Body:          "Comment from " + userID,
+		})
+		resp, _ = s.doRequest("POST", "/pullRequest/comment", strings.NewReader(string(body)))
+		s.Require().Equal(http.StatusCreated, resp.StatusCode)
+	}
+
+	resp, respBody := s.doRequest("GET", "/pullRequest/comments?pull_request_id=pr-commented", nil)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	var list commentModel.ListCommentsResponse
+	s.Require().NoError(json.Unmarshal(respBody, &list))
+	s.Require().Len(list.Comments, 2)
+	s.Equal("commenter2", list.Comments[0].UserID)
+
+	s.Require().NoError(s.db.Exec("DELETE FROM pull_requests WHERE pull_request_id = ?", "pr-commented").Error)
+	var count int64
+	s.db.Table("pull_request_comments").Where("pull_request_id = ?", "pr-commented").Count(&count)
+	s.Zero(count, "comments should be deleted with the PR")
+}
@@ -18,6 +18,7 @@ type ErrorScenariosTestSuite struct {
 }
 
 func TestErrorScenarios(t *testing.T) {
+	t.Parallel()
 	suite.Run(t, new(ErrorScenariosTestSuite))
 }
 
@@ -0,0 +1,14 @@
+//go:build e2e
+// +build e2e
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/festy23/avito_internship/tests/harness"
+)
+
+func TestMain(m *testing.M) {
+	harness.Main(m)
+}
@@ -0,0 +1,89 @@
+//go:build e2e
+// +build e2e
+
+package e2e
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	teamModel "github.com/festy23/avito_internship/internal/team/model"
+)
+
+// ResilienceTestSuite runs the application with fault injection to exercise client retries and timeouts.
+type ResilienceTestSuite struct {
+	E2ETestSuite
+}
+
+func TestResilience(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(ResilienceTestSuite))
+}
+
+// SetupSuite enables fault injection; it is not allowed in release mode.
+func (s *ResilienceTestSuite) SetupSuite() {
+	s.appEnv = map[string]string{
+		"GIN_MODE": "test",
+		"FAULT_INJECTION": "POST /pullRequest/merge status=503;" +
+			" GET /team/get status=503 percent=50;" +
+			" GET /users/getReview latency=2s",
+	}
+	s.E2ETestSuite.SetupSuite()
+}
+
+func (s *ResilienceTestSuite) createBackendTeam() {
+	resp, _ := s.createTeam(&teamModel.AddTeamRequest{
+		TeamName: "backend",
+		Members: []teamModel.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+		},
+	})
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+}
+
+func (s *ResilienceTestSuite) TestInjectedError() {
+	resp, body := s.doRequest(http.MethodPost, "/pullRequest/merge", nil)
+	s.Require().Equal(http.StatusServiceUnavailable, resp.StatusCode)
+	s.Equal("true", resp.Header.Get("X-Fault-Injected"))
+
+	code, _ := s.parseErrorResponse(body)
+	s.Equal("FAULT_INJECTED", code)
+}
+
+func (s *ResilienceTestSuite) TestRetryOnPartialFailures() {
+	s.createBackendTeam()
+
+	var failures int
+	for attempt := 0; attempt < 20; attempt++ {
+		resp, _ := s.doRequest(http.MethodGet, "/team/get?team_name=backend", nil)
+		if resp.StatusCode == http.StatusOK {
+			s.T().Logf("succeeded after %d injected failures", failures)
+			return
+		}
+		s.Require().Equal(http.StatusServiceUnavailable, resp.StatusCode)
+		failures++
+	}
+	s.Fail("request did not succeed after 20 attempts")
+}
+
+func (s *ResilienceTestSuite) TestClientTimeout() {
+	s.createBackendTeam()
+	url := s.baseURL + "/users/getReview?user_id=u1"
+
+	client := &http.Client{Timeout: 500 * time.Millisecond}
+	_, err := client.Get(url)
+	s.Require().Error(err)
+	var netErr net.Error
+	s.Require().True(errors.As(err, &netErr) && netErr.Timeout(), "expected timeout, got %v", err)
+
+	start := time.Now()
+	resp, _ := s.doRequest(http.MethodGet, "/users/getReview?user_id=u1", nil)
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.GreaterOrEqual(time.Since(start), 2*time.Second)
+}
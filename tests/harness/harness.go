@@ -0,0 +1,326 @@
+//go:build e2e
+// +build e2e
+
+// Package harness starts the containers used by E2E tests.
+//
+// One PostgreSQL container is shared by all suites of a test binary. Every Env gets its own
+// schema in that database and its own application container bound to the schema (DB_SCHEMA),
+// so suites do not see each other's data and can run in parallel.
+package harness
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/client"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	postgresDriver "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	// AppImage is the application image built by `make test-e2e-build`.
+	AppImage = "avito-internship-e2e:test"
+
+	dbName     = "testdb"
+	dbUser     = "testuser"
+	dbPassword = "testpass"
+)
+
+var (
+	sharedOnce sync.Once
+	shared     *Harness
+	errShared  error
+
+	schemaSeq atomic.Int64
+)
+
+// Harness owns the shared PostgreSQL container.
+type Harness struct {
+	pg *postgres.PostgresContainer
+	// connStr is the connection string reachable from the test process.
+	connStr string
+	// dbHost is the PostgreSQL address reachable from other containers.
+	dbHost string
+}
+
+// Main runs the tests and terminates the shared containers afterwards. Call it from TestMain.
+func Main(m *testing.M) {
+	code := m.Run()
+	if shared != nil {
+		if err := shared.pg.Terminate(context.Background()); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to terminate PostgreSQL container: %v\n", err)
+		}
+	}
+	os.Exit(code)
+}
+
+// Shared returns the harness of the test binary, starting PostgreSQL on first use.
+func Shared(ctx context.Context) (*Harness, error) {
+	sharedOnce.Do(func() {
+		shared, errShared = start(ctx)
+	})
+	return shared, errShared
+}
+
+func start(ctx context.Context) (*Harness, error) {
+	pg, err := postgres.Run(ctx,
+		"postgres:12-alpine",
+		postgres.WithDatabase(dbName),
+		postgres.WithUsername(dbUser),
+		postgres.WithPassword(dbPassword),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start PostgreSQL container: %w", err)
+	}
+	h := &Harness{pg: pg}
+
+	h.connStr, err = pg.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		return h, fmt.Errorf("failed to get connection string: %w", err)
+	}
+
+	h.dbHost, err = containerIP(ctx, pg)
+	if err != nil {
+		return h, err
+	}
+
+	// Extensions are database-wide: create them once in public (kept in every search_path)
+	// instead of racing on CREATE EXTENSION from parallel migrations.
+	db, err := h.open("public")
+	if err != nil {
+		return h, err
+	}
+	defer closeDB(db)
+	if err := db.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error; err != nil {
+		return h, fmt.Errorf("failed to create pg_trgm extension: %w", err)
+	}
+
+	return h, nil
+}
+
+// containerIP returns the address of the container on its Docker network.
+func containerIP(ctx context.Context, c testcontainers.Container) (string, error) {
+	name, err := c.Name(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get container name: %w", err)
+	}
+	name = strings.TrimPrefix(name, "/")
+
+	dockerClient, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return "", fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	defer dockerClient.Close()
+
+	info, err := dockerClient.ContainerInspect(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect container: %w", err)
+	}
+	for _, network := range info.NetworkSettings.Networks {
+		if network.IPAddress != "" {
+			return network.IPAddress, nil
+		}
+	}
+
+	// Fall back to the container name if no IP is assigned.
+	return name, nil
+}
+
+// open connects to the shared database with schema first in search_path.
+func (h *Harness) open(schema string) (*gorm.DB, error) {
+	sep := "?"
+	if strings.Contains(h.connStr, "?") {
+		sep = "&"
+	}
+	dsn := h.connStr + sep + "search_path=" + schema + ",public"
+
+	db, err := gorm.Open(postgresDriver.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	return db, nil
+}
+
+func closeDB(db *gorm.DB) {
+	if sqlDB, err := db.DB(); err == nil {
+		_ = sqlDB.Close()
+	}
+}
+
+var nonIdent = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// schemaName builds a unique PostgreSQL identifier for the test.
+func schemaName(testName string) string {
+	name := nonIdent.ReplaceAllString(strings.ToLower(testName), "_")
+	suffix := fmt.Sprintf("_%d", schemaSeq.Add(1))
+	const maxLen = 63 - len("e2e_")
+	if len(name)+len(suffix) > maxLen {
+		name = name[:maxLen-len(suffix)]
+	}
+	return "e2e_" + name + suffix
+}
+
+// Env is an isolated environment: a schema in the shared database and an application bound to it.
+type Env struct {
+	// BaseURL is the address of the application.
+	BaseURL string
+	// DB is connected to the shared database with the environment schema first in search_path.
+	DB *gorm.DB
+	// Schema is the name of the environment schema.
+	Schema string
+
+	app testcontainers.Container
+}
+
+// NewEnv creates a schema, starts an application container that migrates it and waits until the
+// application is healthy. Extra environment variables override the defaults. The environment is
+// torn down when t finishes.
+func (h *Harness) NewEnv(t testing.TB, env map[string]string) *Env {
+	t.Helper()
+	ctx := context.Background()
+
+	schema := schemaName(t.Name())
+	db, err := h.open(schema)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if err := db.Exec(fmt.Sprintf("CREATE SCHEMA %q", schema)).Error; err != nil {
+		t.Fatalf("failed to create schema %s: %v", schema, err)
+	}
+	e := &Env{DB: db, Schema: schema}
+	t.Cleanup(func() { e.terminate(t) })
+
+	appEnv := map[string]string{
+		"DB_HOST":                  h.dbHost,
+		"DB_PORT":                  "5432",
+		"DB_USER":                  dbUser,
+		"DB_PASSWORD":              dbPassword,
+		"DB_NAME":                  dbName,
+		"DB_SCHEMA":                schema,
+		"DB_SSLMODE":               "disable",
+		"DB_TIMEZONE":              "UTC",
+		"DB_RETRY_MAX_ATTEMPTS":    "5",
+		"DB_RETRY_INITIAL_DELAY":   "1s",
+		"DB_RETRY_MAX_DELAY":       "30s",
+		"DB_RETRY_MULTIPLIER":      "2.0",
+		"SERVER_HOST":              "",
+		"SERVER_PORT":              ":8080",
+		"SERVER_READ_TIMEOUT":      "10s",
+		"SERVER_WRITE_TIMEOUT":     "10s",
+		"SERVER_IDLE_TIMEOUT":      "120s",
+		"GIN_MODE":                 "release",
+		"PAGINATION_CURSOR_SECRET": "e2e-pagination-cursor-secret-0123456789",
+		"LOG_LEVEL":                "info",
+		"LOG_FORMAT":               "json",
+		"LOG_OUTPUT":               "stdout",
+		"MIGRATIONS_PATH":          "migrations",
+		"ASSIGNMENT_DETERMINISTIC": "true",
+	}
+	for k, v := range env {
+		appEnv[k] = v
+	}
+
+	e.app, err = testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        AppImage,
+			ExposedPorts: []string{"8080/tcp"},
+			Env:          appEnv,
+			WaitingFor: wait.ForHTTP("/health").
+				WithPort("8080/tcp").
+				WithStartupTimeout(120 * time.Second).
+				WithPollInterval(time.Second),
+		},
+		Started: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to start application container: %v", err)
+	}
+
+	host, err := e.app.Host(ctx)
+	if err != nil {
+		t.Fatalf("failed to get container host: %v", err)
+	}
+	port, err := e.app.MappedPort(ctx, "8080")
+	if err != nil {
+		t.Fatalf("failed to get container port: %v", err)
+	}
+	e.BaseURL = fmt.Sprintf("http://%s:%s", host, port.Port())
+
+	return e
+}
+
+// Reset truncates all tables of the environment schema except the migrations table.
+func (e *Env) Reset(t testing.TB) {
+	t.Helper()
+
+	var tables []string
+	err := e.DB.Raw(`
+		SELECT table_name FROM information_schema.tables
+		WHERE table_schema = ? AND table_type = 'BASE TABLE' AND table_name <> 'schema_migrations'`,
+		e.Schema).Scan(&tables).Error
+	if err != nil {
+		t.Fatalf("failed to list tables: %v", err)
+	}
+	if len(tables) == 0 {
+		return
+	}
+
+	quoted := make([]string, len(tables))
+	for i, table := range tables {
+		quoted[i] = fmt.Sprintf("%q.%q", e.Schema, table)
+	}
+	if err := e.DB.Exec("TRUNCATE TABLE " + strings.Join(quoted, ", ") + " RESTART IDENTITY CASCADE").Error; err != nil {
+		t.Fatalf("failed to truncate tables: %v", err)
+	}
+}
+
+// Logs returns the application container logs.
+func (e *Env) Logs() string {
+	if e.app == nil {
+		return ""
+	}
+
+	logs, err := e.app.Logs(context.Background())
+	if err != nil {
+		return fmt.Sprintf("Failed to get logs: %v", err)
+	}
+	defer logs.Close()
+
+	logBytes, err := io.ReadAll(logs)
+	if err != nil {
+		return fmt.Sprintf("Failed to read logs: %v", err)
+	}
+	return string(logBytes)
+}
+
+func (e *Env) terminate(t testing.TB) {
+	ctx := context.Background()
+	if e.app != nil {
+		if err := e.app.Terminate(ctx); err != nil {
+			t.Logf("failed to terminate application container: %v", err)
+		}
+	}
+	if err := e.DB.Exec(fmt.Sprintf("DROP SCHEMA IF EXISTS %q CASCADE", e.Schema)).Error; err != nil {
+		t.Logf("failed to drop schema %s: %v", e.Schema, err)
+	}
+	closeDB(e.DB)
+}
@@ -0,0 +1,129 @@
+//go:build integration
+// +build integration
+
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/festy23/avito_internship/internal/config"
+	"github.com/festy23/avito_internship/internal/notification"
+	pullrequestRouter "github.com/festy23/avito_internship/internal/pullrequest/router"
+	teamRouter "github.com/festy23/avito_internship/internal/team/router"
+	"github.com/festy23/avito_internship/internal/testutil"
+	userRouter "github.com/festy23/avito_internship/internal/user/router"
+	"github.com/festy23/avito_internship/pkg/cursor"
+	"github.com/festy23/avito_internship/pkg/pagination"
+)
+
+// contractStep is a request replayed through the router; its response must match the OpenAPI spec.
+type contractStep struct {
+	name   string
+	method string
+	path   string
+	query  url.Values
+	body   any
+	status int
+}
+
+// TestContract replays requests covering every documented response and validates
+// status codes and bodies against api/openapi.yml.
+func TestContract(t *testing.T) {
+	spec := loadOpenAPISpec(t)
+
+	db := testutil.NewDB(t)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	logger := zap.NewNop().Sugar()
+	teamRouter.RegisterRoutes(r, db, logger)
+	pullrequestSvc := pullrequestRouter.RegisterRoutes(r, db, config.PullRequestConfig{DeterministicAssignment: true},
+		nil, notification.NewNop(), cursor.New(""), pagination.DefaultLimits(), logger)
+	userRouter.RegisterRoutes(r, db, config.UserConfig{}, pullrequestSvc, cursor.New(""), pagination.DefaultLimits(), logger)
+
+	members := []map[string]any{}
+	for i := 1; i <= 5; i++ {
+		id := "u" + strconv.Itoa(i)
+		members = append(members, map[string]any{"user_id": id, "username": "User " + id, "is_active": true})
+	}
+
+	steps := []contractStep{
+		{"add team", "POST", "/team/add", nil,
+			map[string]any{"team_name": "backend", "members": members}, http.StatusCreated},
+		{"add existing team", "POST", "/team/add", nil,
+			map[string]any{"team_name": "backend", "members": members}, http.StatusBadRequest},
+		{"get team", "GET", "/team/get", url.Values{"team_name": {"backend"}}, nil, http.StatusOK},
+		{"get missing team", "GET", "/team/get", url.Values{"team_name": {"missing"}}, nil, http.StatusNotFound},
+		{"deactivate user", "POST", "/users/setIsActive", nil,
+			map[string]any{"user_id": "u5", "is_active": false}, http.StatusOK},
+		{"deactivate missing user", "POST", "/users/setIsActive", nil,
+			map[string]any{"user_id": "missing", "is_active": false}, http.StatusNotFound},
+		{"create PR", "POST", "/pullRequest/create", nil,
+			map[string]any{"pull_request_id": "pr-1", "pull_request_name": "Add search", "author_id": "u1"},
+			http.StatusCreated},
+		{"create existing PR", "POST", "/pullRequest/create", nil,
+			map[string]any{"pull_request_id": "pr-1", "pull_request_name": "Add search", "author_id": "u1"},
+			http.StatusConflict},
+		{"create PR of missing author", "POST", "/pullRequest/create", nil,
+			map[string]any{"pull_request_id": "pr-2", "pull_request_name": "Fix bug", "author_id": "missing"},
+			http.StatusNotFound},
+		{"reassign reviewer", "POST", "/pullRequest/reassign", nil,
+			map[string]any{"pull_request_id": "pr-1", "old_user_id": "u2"}, http.StatusOK},
+		{"reassign not assigned reviewer", "POST", "/pullRequest/reassign", nil,
+			map[string]any{"pull_request_id": "pr-1", "old_user_id": "u1"}, http.StatusConflict},
+		{"reassign on missing PR", "POST", "/pullRequest/reassign", nil,
+			map[string]any{"pull_request_id": "missing", "old_user_id": "u2"}, http.StatusNotFound},
+		{"get review", "GET", "/users/getReview", url.Values{"user_id": {"u3"}}, nil, http.StatusOK},
+		{"merge PR", "POST", "/pullRequest/merge", nil,
+			map[string]any{"pull_request_id": "pr-1"}, http.StatusOK},
+		{"merge PR again", "POST", "/pullRequest/merge", nil,
+			map[string]any{"pull_request_id": "pr-1"}, http.StatusOK},
+		{"merge missing PR", "POST", "/pullRequest/merge", nil,
+			map[string]any{"pull_request_id": "missing"}, http.StatusNotFound},
+		{"reassign on merged PR", "POST", "/pullRequest/reassign", nil,
+			map[string]any{"pull_request_id": "pr-1", "old_user_id": "u3"}, http.StatusConflict},
+	}
+
+	covered := make(map[responseKey]bool)
+	for _, step := range steps {
+		// Steps depend on each other, so a failed step stops the scenario.
+		ok := t.Run(step.name, func(t *testing.T) {
+			var body bytes.Buffer
+			if step.body != nil {
+				require.NoError(t, json.NewEncoder(&body).Encode(step.body))
+			}
+			target := step.path
+			if step.query != nil {
+				target += "?" + step.query.Encode()
+			}
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(step.method, target, &body)
+			req.Header.Set("Content-Type", "application/json")
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, step.status, w.Code, w.Body.String())
+
+			key := responseKey{Method: step.method, Path: step.path, Status: strconv.Itoa(w.Code)}
+			covered[key] = true
+			assert.NoError(t, spec.validateResponse(key, w.Body.Bytes()), w.Body.String())
+		})
+		if !ok {
+			t.FailNow()
+		}
+	}
+
+	for _, key := range spec.documentedResponses() {
+		assert.True(t, covered[key], "documented response %s is not covered by the contract test", key)
+	}
+}
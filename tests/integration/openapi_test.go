@@ -0,0 +1,227 @@
+//go:build integration
+// +build integration
+
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const openAPIPath = "../../api/openapi.yml"
+
+// openAPISpec is the subset of an OpenAPI 3.0 document needed to validate responses.
+type openAPISpec struct {
+	Paths      map[string]map[string]openAPIOperation `yaml:"paths"`
+	Components struct {
+		Schemas map[string]*openAPISchema `yaml:"schemas"`
+	} `yaml:"components"`
+}
+
+type openAPIOperation struct {
+	Responses map[string]struct {
+		Content map[string]struct {
+			Schema *openAPISchema `yaml:"schema"`
+		} `yaml:"content"`
+	} `yaml:"responses"`
+}
+
+type openAPISchema struct {
+	Ref        string                    `yaml:"$ref"`
+	Type       string                    `yaml:"type"`
+	Format     string                    `yaml:"format"`
+	Nullable   bool                      `yaml:"nullable"`
+	Required   []string                  `yaml:"required"`
+	Properties map[string]*openAPISchema `yaml:"properties"`
+	Items      *openAPISchema            `yaml:"items"`
+	Enum       []string                  `yaml:"enum"`
+}
+
+func loadOpenAPISpec(t *testing.T) *openAPISpec {
+	t.Helper()
+
+	data, err := os.ReadFile(openAPIPath)
+	require.NoError(t, err)
+
+	var spec openAPISpec
+	require.NoError(t, yaml.Unmarshal(data, &spec))
+	return &spec
+}
+
+// responseKey identifies a documented response.
+type responseKey struct {
+	Method string
+	Path   string
+	Status string
+}
+
+func (k responseKey) String() string {
+	return fmt.Sprintf("%s %s -> %s", k.Method, k.Path, k.Status)
+}
+
+// documentedResponses lists all responses declared in the spec.
+func (s *openAPISpec) documentedResponses() []responseKey {
+	var keys []responseKey
+	for path, ops := range s.Paths {
+		for method, op := range ops {
+			for status := range op.Responses {
+				keys = append(keys, responseKey{Method: strings.ToUpper(method), Path: path, Status: status})
+			}
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	return keys
+}
+
+// validateResponse checks that the status is documented for the operation and the body matches its schema.
+func (s *openAPISpec) validateResponse(key responseKey, body []byte) error {
+	ops, ok := s.Paths[key.Path]
+	if !ok {
+		return fmt.Errorf("path %s is not documented", key.Path)
+	}
+	op, ok := ops[strings.ToLower(key.Method)]
+	if !ok {
+		return fmt.Errorf("%s %s is not documented", key.Method, key.Path)
+	}
+	resp, ok := op.Responses[key.Status]
+	if !ok {
+		return fmt.Errorf("%s is not documented", key)
+	}
+
+	media, ok := resp.Content["application/json"]
+	if !ok || media.Schema == nil {
+		return nil
+	}
+
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Errorf("%s: body is not JSON: %w", key, err)
+	}
+	return s.validate(media.Schema, value, "$")
+}
+
+// validate checks value against schema; path is used in error messages.
+//
+//nolint:gocognit,gocyclo // One branch per schema keyword
+func (s *openAPISpec) validate(schema *openAPISchema, value any, path string) error {
+	if schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		resolved, ok := s.Components.Schemas[name]
+		if !ok {
+			return fmt.Errorf("%s: unresolved $ref %s", path, schema.Ref)
+		}
+		return s.validate(resolved, value, path)
+	}
+
+	if value == nil {
+		if schema.Nullable {
+			return nil
+		}
+		return fmt.Errorf("%s: null is not allowed", path)
+	}
+
+	switch schema.Type {
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: expected object, got %T", path, value)
+		}
+		for _, name := range schema.Required {
+			if _, ok := obj[name]; !ok {
+				return fmt.Errorf("%s: missing required property %q", path, name)
+			}
+		}
+		for name, prop := range schema.Properties {
+			v, ok := obj[name]
+			if !ok {
+				continue
+			}
+			if err := s.validate(prop, v, path+"."+name); err != nil {
+				return err
+			}
+		}
+	case "array":
+		arr, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("%s: expected array, got %T", path, value)
+		}
+		if schema.Items != nil {
+			for i, item := range arr {
+				if err := s.validate(schema.Items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s: expected string, got %T", path, value)
+		}
+		if len(schema.Enum) > 0 && !containsString(schema.Enum, str) {
+			return fmt.Errorf("%s: %q is not one of %v", path, str, schema.Enum)
+		}
+		if schema.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339, str); err != nil {
+				return fmt.Errorf("%s: %q is not a date-time", path, str)
+			}
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: expected boolean, got %T", path, value)
+		}
+	case "integer", "number":
+		if _, ok := value.(float64); !ok {
+			return fmt.Errorf("%s: expected number, got %T", path, value)
+		}
+	}
+
+	return nil
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func TestOpenAPISpec_Validate(t *testing.T) {
+	spec := loadOpenAPISpec(t)
+	key := responseKey{Method: "GET", Path: "/team/get", Status: "200"}
+
+	t.Run("valid team", func(t *testing.T) {
+		body := `{"team_name":"backend","members":[{"user_id":"u1","username":"Alice","is_active":true}]}`
+		require.NoError(t, spec.validateResponse(key, []byte(body)))
+	})
+
+	t.Run("missing required property", func(t *testing.T) {
+		body := `{"team_name":"backend"}`
+		require.ErrorContains(t, spec.validateResponse(key, []byte(body)), `missing required property "members"`)
+	})
+
+	t.Run("wrong type in nested item", func(t *testing.T) {
+		body := `{"team_name":"backend","members":[{"user_id":"u1","username":"Alice","is_active":"yes"}]}`
+		require.ErrorContains(t, spec.validateResponse(key, []byte(body)), "$.members[0].is_active")
+	})
+
+	t.Run("undocumented status", func(t *testing.T) {
+		key := responseKey{Method: "GET", Path: "/team/get", Status: "500"}
+		require.ErrorContains(t, spec.validateResponse(key, []byte(`{}`)), "is not documented")
+	})
+
+	t.Run("enum violation", func(t *testing.T) {
+		key := responseKey{Method: "GET", Path: "/team/get", Status: "404"}
+		body := `{"error":{"code":"SOMETHING_ELSE","message":"x"}}`
+		require.ErrorContains(t, spec.validateResponse(key, []byte(body)), "is not one of")
+	})
+}
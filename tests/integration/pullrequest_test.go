@@ -20,16 +20,25 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 
+	"github.com/festy23/avito_internship/internal/config"
+	"github.com/festy23/avito_internship/internal/notification"
 	pullrequestModel "github.com/festy23/avito_internship/internal/pullrequest/model"
 	pullrequestRouter "github.com/festy23/avito_internship/internal/pullrequest/router"
 	teamModel "github.com/festy23/avito_internship/internal/team/model"
 	teamRouter "github.com/festy23/avito_internship/internal/team/router"
+	"github.com/festy23/avito_internship/pkg/cursor"
+	"github.com/festy23/avito_internship/pkg/pagination"
 )
 
 type prTestTeam struct {
-	TeamName  string    `gorm:"primaryKey;column:team_name"`
-	CreatedAt time.Time `gorm:"column:created_at"`
-	UpdatedAt time.Time `gorm:"column:updated_at"`
+	TeamName            string    `gorm:"primaryKey;column:team_name"`
+	CreatedAt           time.Time `gorm:"column:created_at"`
+	UpdatedAt           time.Time `gorm:"column:updated_at"`
+	LeadUserID          *string   `gorm:"column:lead_user_id"`
+	SLAFirstReviewHours *int      `gorm:"column:sla_first_review_hours"`
+	SLAMergeHours       *int      `gorm:"column:sla_merge_hours"`
+	AutoCloseDisabled   bool      `gorm:"column:auto_close_disabled;not null;default:false"`
+	TenantID            string    `gorm:"column:tenant_id;not null;default:'default'"`
 }
 
 func (prTestTeam) TableName() string {
@@ -43,6 +52,7 @@ type prTestUser struct {
 	IsActive  bool      `gorm:"column:is_active;not null"`
 	CreatedAt time.Time `gorm:"column:created_at"`
 	UpdatedAt time.Time `gorm:"column:updated_at"`
+	TenantID  string    `gorm:"column:tenant_id;not null;default:'default'"`
 }
 
 func (prTestUser) TableName() string {
@@ -56,6 +66,17 @@ type prTestPullRequest struct {
 	Status          string     `gorm:"column:status;not null"`
 	CreatedAt       time.Time  `gorm:"column:created_at"`
 	MergedAt        *time.Time `gorm:"column:merged_at"`
+	ArchivedAt      *time.Time `gorm:"column:archived_at"`
+	SourceBranch    *string    `gorm:"column:source_branch"`
+	TargetBranch    *string    `gorm:"column:target_branch"`
+	PullRequestURL  *string    `gorm:"column:pull_request_url"`
+	LinesAdded      int        `gorm:"column:lines_added;not null;default:0"`
+	LinesRemoved    int        `gorm:"column:lines_removed;not null;default:0"`
+	HasConflicts    bool       `gorm:"column:has_conflicts;not null;default:false"`
+	CloseReason     *string    `gorm:"column:close_reason"`
+	CloseNote       *string    `gorm:"column:close_note"`
+	TeamName        *string    `gorm:"column:team_name"`
+	TenantID        string     `gorm:"column:tenant_id;not null;default:'default'"`
 }
 
 func (prTestPullRequest) TableName() string {
@@ -73,6 +94,33 @@ func (prTestPullRequestReviewer) TableName() string {
 	return "pull_request_reviewers"
 }
 
+type prTestPullRequestWatcher struct {
+	ID            int64     `gorm:"primaryKey;column:id"`
+	PullRequestID string    `gorm:"column:pull_request_id;not null;uniqueIndex:uq_watchers_pr_user"`
+	UserID        string    `gorm:"column:user_id;not null;uniqueIndex:uq_watchers_pr_user"`
+	CreatedAt     time.Time `gorm:"column:created_at"`
+}
+
+func (prTestPullRequestWatcher) TableName() string {
+	return "pull_request_watchers"
+}
+
+type prTestPullRequestEvent struct {
+	ID             int64     `gorm:"primaryKey;column:id"`
+	PullRequestID  string    `gorm:"column:pull_request_id;not null"`
+	EventType      string    `gorm:"column:event_type;not null"`
+	UserID         *string   `gorm:"column:user_id"`
+	PreviousUserID *string   `gorm:"column:previous_user_id"`
+	ActorID        *string   `gorm:"column:actor_id"`
+	Reason         *string   `gorm:"column:reason"`
+	Source         *string   `gorm:"column:source"`
+	CreatedAt      time.Time `gorm:"column:created_at"`
+}
+
+func (prTestPullRequestEvent) TableName() string {
+	return "pull_request_events"
+}
+
 func setupDB(t *testing.T) *gorm.DB {
 	dbName := ":memory:"
 	db, err := gorm.Open(sqlite.Open(dbName), &gorm.Config{
@@ -85,7 +133,7 @@ func setupDB(t *testing.T) *gorm.DB {
 	require.NoError(t, err)
 	sqlDB.SetMaxOpenConns(1)
 
-	err = db.AutoMigrate(&prTestTeam{}, &prTestUser{}, &prTestPullRequest{}, &prTestPullRequestReviewer{})
+	err = db.AutoMigrate(&prTestTeam{}, &prTestUser{}, &prTestPullRequest{}, &prTestPullRequestReviewer{}, &prTestPullRequestWatcher{}, &prTestPullRequestEvent{})
 	require.NoError(t, err)
 
 	return db
@@ -95,7 +143,8 @@ func setupRouter(db *gorm.DB) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
 	teamRouter.RegisterRoutes(r, db, zap.NewNop().Sugar())
-	pullrequestRouter.RegisterRoutes(r, db, zap.NewNop().Sugar())
+	pullrequestRouter.RegisterRoutes(r, db, config.PullRequestConfig{}, nil, notification.NewNop(),
+		cursor.New(""), pagination.DefaultLimits(), zap.NewNop().Sugar())
 	return r
 }
 
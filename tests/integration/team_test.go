@@ -21,12 +21,18 @@ import (
 
 	teamModel "github.com/festy23/avito_internship/internal/team/model"
 	teamRouter "github.com/festy23/avito_internship/internal/team/router"
+	"github.com/festy23/avito_internship/internal/tenant"
 )
 
 type teamTestTeam struct {
-	TeamName  string    `gorm:"primaryKey;column:team_name"`
-	CreatedAt time.Time `gorm:"column:created_at"`
-	UpdatedAt time.Time `gorm:"column:updated_at"`
+	TeamName            string    `gorm:"primaryKey;column:team_name"`
+	CreatedAt           time.Time `gorm:"column:created_at"`
+	UpdatedAt           time.Time `gorm:"column:updated_at"`
+	LeadUserID          *string   `gorm:"column:lead_user_id"`
+	SLAFirstReviewHours *int      `gorm:"column:sla_first_review_hours"`
+	SLAMergeHours       *int      `gorm:"column:sla_merge_hours"`
+	AutoCloseDisabled   bool      `gorm:"column:auto_close_disabled;not null;default:false"`
+	TenantID            string    `gorm:"column:tenant_id;not null;default:'default'"`
 }
 
 func (teamTestTeam) TableName() string {
@@ -40,6 +46,7 @@ type teamTestUser struct {
 	IsActive  bool      `gorm:"column:is_active;not null"`
 	CreatedAt time.Time `gorm:"column:created_at"`
 	UpdatedAt time.Time `gorm:"column:updated_at"`
+	TenantID  string    `gorm:"column:tenant_id;not null;default:'default'"`
 }
 
 func (teamTestUser) TableName() string {
@@ -59,6 +66,16 @@ func setupTeamDB(t *testing.T) *gorm.DB {
 		Status          string     `gorm:"column:status;not null"`
 		CreatedAt       time.Time  `gorm:"column:created_at"`
 		MergedAt        *time.Time `gorm:"column:merged_at"`
+		ArchivedAt      *time.Time `gorm:"column:archived_at"`
+		SourceBranch    *string    `gorm:"column:source_branch"`
+		TargetBranch    *string    `gorm:"column:target_branch"`
+		PullRequestURL  *string    `gorm:"column:pull_request_url"`
+		LinesAdded      int        `gorm:"column:lines_added;not null;default:0"`
+		LinesRemoved    int        `gorm:"column:lines_removed;not null;default:0"`
+		HasConflicts    bool       `gorm:"column:has_conflicts;not null;default:false"`
+		CloseReason     *string    `gorm:"column:close_reason"`
+		CloseNote       *string    `gorm:"column:close_note"`
+		TeamName        *string    `gorm:"column:team_name"`
 	}
 
 	type PullRequestReviewer struct {
@@ -68,7 +85,21 @@ func setupTeamDB(t *testing.T) *gorm.DB {
 		AssignedAt    time.Time `gorm:"column:assigned_at"`
 	}
 
-	err = db.AutoMigrate(&teamTestTeam{}, &teamTestUser{}, &PullRequest{}, &PullRequestReviewer{})
+	type PullRequestEvent struct {
+		ID             int       `gorm:"primaryKey;autoIncrement"`
+		PullRequestID  string    `gorm:"column:pull_request_id;not null"`
+		EventType      string    `gorm:"column:event_type;not null"`
+		UserID         *string   `gorm:"column:user_id"`
+		PreviousUserID *string   `gorm:"column:previous_user_id"`
+		ActorID        *string   `gorm:"column:actor_id"`
+		Reason         *string   `gorm:"column:reason"`
+		Source         *string   `gorm:"column:source"`
+		CreatedAt      time.Time `gorm:"column:created_at"`
+	}
+
+	err = db.AutoMigrate(
+		&teamTestTeam{}, &teamTestUser{}, &PullRequest{}, &PullRequestReviewer{}, &PullRequestEvent{},
+	)
 	require.NoError(t, err)
 
 	return db
@@ -140,6 +171,53 @@ func TestTeamLifecycle(t *testing.T) {
 	})
 }
 
+func TestTeamTenantIsolation(t *testing.T) {
+	db := setupTeamDB(t)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	api := router.Group("")
+	api.Use(tenant.HeaderMiddleware())
+	teamRouter.RegisterRoutes(api, db, zap.NewNop().Sugar())
+
+	addTeam := func(tenantID, teamName, userID string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(&teamModel.AddTeamRequest{
+			TeamName: teamName,
+			Members:  []teamModel.TeamMember{{UserID: userID, Username: "Alice", IsActive: true}},
+		})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/team/add", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(tenant.Header, tenantID)
+		router.ServeHTTP(w, req)
+		return w
+	}
+	getTeam := func(tenantID, teamName string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/team/get?team_name="+teamName, nil)
+		req.Header.Set(tenant.Header, tenantID)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	require.Equal(t, http.StatusCreated, addTeam("acme", "backend", "u1").Code)
+
+	assert.Equal(t, http.StatusOK, getTeam("acme", "backend").Code)
+	assert.Equal(t, http.StatusNotFound, getTeam("globex", "backend").Code)
+	assert.Equal(t, http.StatusBadRequest, getTeam("", "backend").Code)
+
+	// A user ID of another tenant cannot be taken over; the team is rolled back
+	w := addTeam("globex", "payments", "u1")
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "ID_UNAVAILABLE")
+	assert.NotContains(t, w.Body.String(), "tenant")
+	assert.Equal(t, http.StatusNotFound, getTeam("globex", "payments").Code)
+
+	// A team name of another tenant is reported without telling that the team exists
+	w = addTeam("globex", "backend", "u2")
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "ID_UNAVAILABLE")
+}
+
 func TestMultipleTeams(t *testing.T) {
 	t.Run("create and retrieve multiple teams", func(t *testing.T) {
 		db := setupTeamDB(t)
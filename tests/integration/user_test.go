@@ -19,8 +19,13 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 
+	"github.com/festy23/avito_internship/internal/config"
+	pullrequestRepo "github.com/festy23/avito_internship/internal/pullrequest/repository"
+	pullrequestService "github.com/festy23/avito_internship/internal/pullrequest/service"
 	"github.com/festy23/avito_internship/internal/user/model"
 	userRouter "github.com/festy23/avito_internship/internal/user/router"
+	"github.com/festy23/avito_internship/pkg/cursor"
+	"github.com/festy23/avito_internship/pkg/pagination"
 )
 
 type testUser struct {
@@ -30,6 +35,7 @@ type testUser struct {
 	IsActive  bool      `gorm:"column:is_active;not null;default:true"`
 	CreatedAt time.Time `gorm:"column:created_at"`
 	UpdatedAt time.Time `gorm:"column:updated_at"`
+	TenantID  string    `gorm:"column:tenant_id;not null;default:'default'"`
 }
 
 func (testUser) TableName() string {
@@ -43,9 +49,10 @@ func setupUserDB(t *testing.T) *gorm.DB {
 	require.NoError(t, err)
 
 	type Team struct {
-		TeamName  string    `gorm:"primaryKey;column:team_name"`
-		CreatedAt time.Time `gorm:"column:created_at"`
-		UpdatedAt time.Time `gorm:"column:updated_at"`
+		TeamName   string    `gorm:"primaryKey;column:team_name"`
+		CreatedAt  time.Time `gorm:"column:created_at"`
+		UpdatedAt  time.Time `gorm:"column:updated_at"`
+		LeadUserID *string   `gorm:"column:lead_user_id"`
 	}
 
 	type PullRequest struct {
@@ -55,6 +62,16 @@ func setupUserDB(t *testing.T) *gorm.DB {
 		Status          string     `gorm:"column:status;not null"`
 		CreatedAt       time.Time  `gorm:"column:created_at"`
 		MergedAt        *time.Time `gorm:"column:merged_at"`
+		ArchivedAt      *time.Time `gorm:"column:archived_at"`
+		SourceBranch    *string    `gorm:"column:source_branch"`
+		TargetBranch    *string    `gorm:"column:target_branch"`
+		PullRequestURL  *string    `gorm:"column:pull_request_url"`
+		LinesAdded      int        `gorm:"column:lines_added;not null;default:0"`
+		LinesRemoved    int        `gorm:"column:lines_removed;not null;default:0"`
+		HasConflicts    bool       `gorm:"column:has_conflicts;not null;default:false"`
+		CloseReason     *string    `gorm:"column:close_reason"`
+		CloseNote       *string    `gorm:"column:close_note"`
+		TeamName        *string    `gorm:"column:team_name"`
 	}
 
 	type PullRequestReviewer struct {
@@ -64,7 +81,33 @@ func setupUserDB(t *testing.T) *gorm.DB {
 		AssignedAt    time.Time `gorm:"column:assigned_at"`
 	}
 
-	err = db.AutoMigrate(&Team{}, &testUser{}, &PullRequest{}, &PullRequestReviewer{})
+	type PullRequestEvent struct {
+		ID             int       `gorm:"primaryKey;autoIncrement"`
+		PullRequestID  string    `gorm:"column:pull_request_id;not null"`
+		EventType      string    `gorm:"column:event_type;not null"`
+		UserID         *string   `gorm:"column:user_id"`
+		PreviousUserID *string   `gorm:"column:previous_user_id"`
+		ActorID        *string   `gorm:"column:actor_id"`
+		Reason         *string   `gorm:"column:reason"`
+		Source         *string   `gorm:"column:source"`
+		CreatedAt      time.Time `gorm:"column:created_at"`
+	}
+
+	type ActivationChange struct {
+		ID          int       `gorm:"primaryKey;autoIncrement"`
+		UserID      string    `gorm:"column:user_id;not null"`
+		OldIsActive bool      `gorm:"column:old_is_active;not null"`
+		NewIsActive bool      `gorm:"column:new_is_active;not null"`
+		Source      string    `gorm:"column:source;not null"`
+		ActorID     *string   `gorm:"column:actor_id"`
+		Reason      *string   `gorm:"column:reason"`
+		TenantID    string    `gorm:"column:tenant_id;not null;default:'default'"`
+		CreatedAt   time.Time `gorm:"column:created_at"`
+	}
+
+	err = db.AutoMigrate(&Team{}, &testUser{}, &PullRequest{}, &PullRequestReviewer{}, &PullRequestEvent{})
+	require.NoError(t, err)
+	err = db.Table("user_activation_history").AutoMigrate(&ActivationChange{})
 	require.NoError(t, err)
 
 	return db
@@ -74,7 +117,7 @@ func TestSetUserActive(t *testing.T) {
 	db := setupUserDB(t)
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
-	userRouter.RegisterRoutes(router, db, zap.NewNop().Sugar())
+	userRouter.RegisterRoutes(router, db, config.UserConfig{}, newReviewerReplacer(db), cursor.New(""), pagination.DefaultLimits(), zap.NewNop().Sugar())
 
 	db.Exec("INSERT INTO teams (team_name) VALUES (?)", "team1")
 	db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
@@ -104,7 +147,7 @@ func TestSetUserInactive(t *testing.T) {
 	db := setupUserDB(t)
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
-	userRouter.RegisterRoutes(router, db, zap.NewNop().Sugar())
+	userRouter.RegisterRoutes(router, db, config.UserConfig{}, newReviewerReplacer(db), cursor.New(""), pagination.DefaultLimits(), zap.NewNop().Sugar())
 
 	db.Exec("INSERT INTO teams (team_name) VALUES (?)", "team1")
 	db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
@@ -134,7 +177,7 @@ func TestGetReviewWithPRs(t *testing.T) {
 	db := setupUserDB(t)
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
-	userRouter.RegisterRoutes(router, db, zap.NewNop().Sugar())
+	userRouter.RegisterRoutes(router, db, config.UserConfig{}, newReviewerReplacer(db), cursor.New(""), pagination.DefaultLimits(), zap.NewNop().Sugar())
 
 	db.Exec("INSERT INTO teams (team_name) VALUES (?)", "team1")
 	db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
@@ -169,7 +212,7 @@ func TestGetReviewWithoutPRs(t *testing.T) {
 	db := setupUserDB(t)
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
-	userRouter.RegisterRoutes(router, db, zap.NewNop().Sugar())
+	userRouter.RegisterRoutes(router, db, config.UserConfig{}, newReviewerReplacer(db), cursor.New(""), pagination.DefaultLimits(), zap.NewNop().Sugar())
 
 	db.Exec("INSERT INTO teams (team_name) VALUES (?)", "team1")
 	db.Exec("INSERT INTO users (user_id, username, team_name, is_active) VALUES (?, ?, ?, ?)",
@@ -192,7 +235,7 @@ func TestGetReviewUserNotFound(t *testing.T) {
 	db := setupUserDB(t)
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
-	userRouter.RegisterRoutes(router, db, zap.NewNop().Sugar())
+	userRouter.RegisterRoutes(router, db, config.UserConfig{}, newReviewerReplacer(db), cursor.New(""), pagination.DefaultLimits(), zap.NewNop().Sugar())
 
 	req := httptest.NewRequest(http.MethodGet, "/users/getReview?user_id=nonexistent", nil)
 	w := httptest.NewRecorder()
@@ -206,3 +249,9 @@ func TestGetReviewUserNotFound(t *testing.T) {
 	assert.Equal(t, "nonexistent", resp.UserID)
 	assert.Empty(t, resp.PullRequests)
 }
+
+// newReviewerReplacer returns the pull request service replacing deactivated reviewers in db.
+func newReviewerReplacer(db *gorm.DB) pullrequestService.Service {
+	logger := zap.NewNop().Sugar()
+	return pullrequestService.New(pullrequestRepo.New(db, logger), db, logger)
+}